@@ -2,10 +2,13 @@ package gapi
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"strings"
 
+	db "github.com/r-scheele/sqr/internal/db/sqlc"
 	"github.com/r-scheele/sqr/internal/token"
+	"github.com/r-scheele/sqr/internal/util"
 	"google.golang.org/grpc/metadata"
 )
 
@@ -14,7 +17,17 @@ const (
 	authorizationBearer = "bearer"
 )
 
-func (server *Server) authorizeUser(ctx context.Context, accessibleRoles []string) (*token.Payload, error) {
+// allRoles is accepted by RPCs that any signed-in user may call
+var allRoles = []string{util.TenantRole, util.LandlordRole, util.InspectionAgentRole, util.AdminRole}
+
+// Principal is the authenticated caller of an RPC: the verified token payload
+// together with the user record it resolves to.
+type Principal struct {
+	User    db.User
+	Payload *token.Payload
+}
+
+func (server *Server) authorizeUser(ctx context.Context, accessibleRoles []string) (*Principal, error) {
 	md, ok := metadata.FromIncomingContext(ctx)
 	if !ok {
 		return nil, fmt.Errorf("missing metadata")
@@ -42,11 +55,50 @@ func (server *Server) authorizeUser(ctx context.Context, accessibleRoles []strin
 		return nil, fmt.Errorf("invalid access token: %s", err)
 	}
 
+	revoked, err := server.denylist.IsRevoked(ctx, payload)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check token revocation: %s", err)
+	}
+	if revoked {
+		return nil, fmt.Errorf("access token has been revoked")
+	}
+
 	if !hasPermission(payload.Role, accessibleRoles) {
 		return nil, fmt.Errorf("permission denied")
 	}
 
-	return payload, nil
+	user, err := server.store.GetUserByID(ctx, payload.UserID)
+	if err != nil {
+		if errors.Is(err, db.ErrRecordNotFound) {
+			return nil, fmt.Errorf("user not found")
+		}
+		return nil, fmt.Errorf("failed to get user: %s", err)
+	}
+
+	if user.IsActive.Valid && !user.IsActive.Bool {
+		return nil, fmt.Errorf("user account is deactivated")
+	}
+
+	return &Principal{
+		User:    user,
+		Payload: payload,
+	}, nil
+}
+
+// revokeUserAccess signs a user out everywhere: every session is deactivated
+// and every access token issued so far is denied until it expires.
+func (server *Server) revokeUserAccess(ctx context.Context, userID int64) error {
+	err := server.store.DeactivateUserSessions(ctx, userID)
+	if err != nil {
+		return fmt.Errorf("failed to deactivate sessions: %w", err)
+	}
+
+	err = server.denylist.RevokeUser(ctx, userID, server.config.AccessTokenDuration)
+	if err != nil {
+		return fmt.Errorf("failed to revoke access tokens: %w", err)
+	}
+
+	return nil
 }
 
 func hasPermission(userRole string, accessibleRoles []string) bool {
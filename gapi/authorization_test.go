@@ -0,0 +1,169 @@
+package gapi
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+	mockdb "github.com/r-scheele/sqr/internal/db/mock"
+	db "github.com/r-scheele/sqr/internal/db/sqlc"
+	"github.com/r-scheele/sqr/internal/pb"
+	"github.com/r-scheele/sqr/internal/token"
+	"github.com/r-scheele/sqr/internal/util"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAuthorizeUser(t *testing.T) {
+	user, _ := randomUser(t, util.TenantRole)
+	user.ID = util.RandomInt(1, 1000)
+	sessionID := uuid.New()
+
+	testCases := []struct {
+		name        string
+		roles       []string
+		buildStubs  func(store *mockdb.MockStore)
+		revoke      func(t *testing.T, server *Server)
+		checkResult func(t *testing.T, principal *Principal, err error)
+	}{
+		{
+			name:  "OK",
+			roles: []string{util.TenantRole},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetUserByID(gomock.Any(), user.ID).
+					Times(1).
+					Return(user, nil)
+			},
+			revoke: func(t *testing.T, server *Server) {},
+			checkResult: func(t *testing.T, principal *Principal, err error) {
+				require.NoError(t, err)
+				require.Equal(t, user, principal.User)
+				require.Equal(t, sessionID, principal.Payload.SessionID)
+			},
+		},
+		{
+			name:  "PermissionDenied",
+			roles: []string{util.AdminRole},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetUserByID(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			revoke: func(t *testing.T, server *Server) {},
+			checkResult: func(t *testing.T, principal *Principal, err error) {
+				require.Error(t, err)
+				require.Nil(t, principal)
+			},
+		},
+		{
+			name:  "DeactivatedUser",
+			roles: []string{util.TenantRole},
+			buildStubs: func(store *mockdb.MockStore) {
+				deactivated := user
+				deactivated.IsActive = pgtype.Bool{Bool: false, Valid: true}
+
+				store.EXPECT().
+					GetUserByID(gomock.Any(), user.ID).
+					Times(1).
+					Return(deactivated, nil)
+			},
+			revoke: func(t *testing.T, server *Server) {},
+			checkResult: func(t *testing.T, principal *Principal, err error) {
+				require.Error(t, err)
+				require.Nil(t, principal)
+			},
+		},
+		{
+			name:  "RevokedSession",
+			roles: []string{util.TenantRole},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetUserByID(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			revoke: func(t *testing.T, server *Server) {
+				err := server.denylist.RevokeSession(context.Background(), sessionID, time.Minute)
+				require.NoError(t, err)
+			},
+			checkResult: func(t *testing.T, principal *Principal, err error) {
+				require.EqualError(t, err, "access token has been revoked")
+				require.Nil(t, principal)
+			},
+		},
+		{
+			name:  "RevokedUser",
+			roles: []string{util.TenantRole},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetUserByID(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			revoke: func(t *testing.T, server *Server) {
+				err := server.denylist.RevokeUser(context.Background(), user.ID, time.Minute)
+				require.NoError(t, err)
+			},
+			checkResult: func(t *testing.T, principal *Principal, err error) {
+				require.EqualError(t, err, "access token has been revoked")
+				require.Nil(t, principal)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			ctx := newContextWithBearerToken(t, server.tokenMaker, user, sessionID, time.Minute, token.TokenTypeAccessToken)
+			tc.revoke(t, server)
+
+			principal, err := server.authorizeUser(ctx, tc.roles)
+			tc.checkResult(t, principal, err)
+		})
+	}
+}
+
+func TestLogoutRevokesAccessTokens(t *testing.T) {
+	user, _ := randomUser(t, util.TenantRole)
+	user.ID = util.RandomInt(1, 1000)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	store := mockdb.NewMockStore(ctrl)
+	server := newTestServer(t, store)
+
+	sessionID := uuid.New()
+	refreshToken, _, err := server.tokenMaker.CreateToken(user.ID, user.Email, string(user.UserType), sessionID, time.Hour, token.TokenTypeRefreshToken)
+	require.NoError(t, err)
+	ctx := newContextWithBearerToken(t, server.tokenMaker, user, sessionID, time.Minute, token.TokenTypeAccessToken)
+
+	store.EXPECT().
+		GetUserSessionByToken(gomock.Any(), refreshToken).
+		Times(1).
+		Return(db.UserSession{UserID: user.ID, SessionID: sessionID, SessionToken: refreshToken, IsActive: pgtype.Bool{Bool: true, Valid: true}}, nil)
+
+	store.EXPECT().
+		DeactivateSession(gomock.Any(), refreshToken).
+		Times(1).
+		Return(nil)
+
+	store.EXPECT().
+		GetUserByID(gomock.Any(), gomock.Any()).
+		Times(0)
+
+	_, err = server.LogoutUser(ctx, &pb.LogoutUserRequest{RefreshToken: refreshToken})
+	require.NoError(t, err)
+
+	_, err = server.authorizeUser(ctx, allRoles)
+	require.EqualError(t, err, "access token has been revoked")
+}
@@ -1,8 +1,12 @@
 package gapi
 
 import (
+	"strings"
+
+	"github.com/google/uuid"
 	db "github.com/r-scheele/sqr/internal/db/sqlc"
 	"github.com/r-scheele/sqr/internal/pb"
+	"github.com/r-scheele/sqr/internal/util"
 	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
@@ -16,6 +20,55 @@ func convertUser(user db.User) *pb.User {
 	}
 }
 
+// convertAdminUser returns the account details admins see, which include status fields hidden from convertUser
+func convertAdminUser(user db.User) *pb.AdminUser {
+	pbUser := &pb.AdminUser{
+		Id:                 user.ID,
+		Email:              user.Email,
+		Phone:              user.Phone,
+		FullName:           user.FirstName + " " + user.LastName,
+		UserType:           string(user.UserType),
+		IsVerified:         user.IsVerified.Bool,
+		IsIdentityVerified: user.IsIdentityVerified,
+		IsActive:           !user.IsActive.Valid || user.IsActive.Bool,
+		CreatedAt:          timestamppb.New(user.CreatedAt.Time),
+	}
+
+	if user.LastLogin.Valid {
+		pbUser.LastLogin = timestamppb.New(user.LastLogin.Time)
+	}
+
+	return pbUser
+}
+
+// convertVerification leaves out verification_data, which holds codes and hashes
+func convertVerification(verification db.UserVerification) *pb.Verification {
+	pbVerification := &pb.Verification{
+		Id:                 verification.ID,
+		VerificationType:   string(verification.VerificationType),
+		VerificationStatus: string(verification.VerificationStatus.VerificationStatusEnum),
+		CreatedAt:          timestamppb.New(verification.CreatedAt.Time),
+	}
+
+	if verification.VerifiedAt.Valid {
+		pbVerification.VerifiedAt = timestamppb.New(verification.VerifiedAt.Time)
+	}
+
+	return pbVerification
+}
+
+func convertSession(session db.UserSession, currentSessionID uuid.UUID) *pb.Session {
+	return &pb.Session{
+		SessionId:    session.SessionID.String(),
+		DeviceInfo:   session.DeviceInfo.String,
+		IpAddress:    session.IpAddress.String,
+		LocationData: session.LocationData.String,
+		Current:      session.SessionID == currentSessionID,
+		CreatedAt:    timestamppb.New(session.CreatedAt.Time),
+		ExpiresAt:    timestamppb.New(session.ExpiresAt),
+	}
+}
+
 func convertTenantProfile(profile db.TenantProfile) *pb.TenantProfile {
 	pbProfile := &pb.TenantProfile{
 		UserId:                      profile.UserID,
@@ -114,3 +167,358 @@ func calculateTenantProfileCompletion(profile db.TenantProfile) int32 {
 
 	return int32((completedFields * 100) / totalFields)
 }
+
+// convertAccountExport leaves out the content, which is only returned by DownloadAccountExport
+func convertAccountExport(export db.AccountExport) *pb.AccountExport {
+	pbExport := &pb.AccountExport{
+		Id:        export.ID,
+		Status:    string(export.Status),
+		FileName:  export.FileName.String,
+		CreatedAt: timestamppb.New(export.CreatedAt),
+	}
+
+	if export.CompletedAt.Valid {
+		pbExport.CompletedAt = timestamppb.New(export.CompletedAt.Time)
+	}
+	if export.ExpiresAt.Valid {
+		pbExport.ExpiresAt = timestamppb.New(export.ExpiresAt.Time)
+	}
+
+	return pbExport
+}
+
+func convertAccountDeletion(deletion db.AccountDeletion) *pb.AccountDeletion {
+	pbDeletion := &pb.AccountDeletion{
+		Id:           deletion.ID,
+		Status:       string(deletion.Status),
+		Reason:       deletion.Reason.String,
+		ScheduledFor: timestamppb.New(deletion.ScheduledFor),
+		CreatedAt:    timestamppb.New(deletion.CreatedAt),
+	}
+
+	if deletion.CancelledAt.Valid {
+		pbDeletion.CancelledAt = timestamppb.New(deletion.CancelledAt.Time)
+	}
+
+	return pbDeletion
+}
+
+// landlordProfileAudience is who a landlord profile is converted for
+type landlordProfileAudience int
+
+const (
+	landlordProfileOwner landlordProfileAudience = iota
+	// landlordProfileAdmin sees everything except the full bank account number
+	landlordProfileAdmin
+	// landlordProfilePublic only sees what a tenant needs to judge a landlord
+	landlordProfilePublic
+)
+
+func convertLandlordProfile(profile db.LandlordProfile, audience landlordProfileAudience) *pb.LandlordProfile {
+	pbProfile := &pb.LandlordProfile{
+		UserId:          profile.UserID,
+		BusinessName:    profile.BusinessName.String,
+		TotalProperties: profile.TotalProperties.Int32,
+		CreatedAt:       timestamppb.New(profile.CreatedAt.Time),
+		UpdatedAt:       timestamppb.New(profile.UpdatedAt.Time),
+	}
+
+	if profile.AverageRating.Valid {
+		averageRating, _ := profile.AverageRating.Float64Value()
+		pbProfile.AverageRating = averageRating.Float64
+	}
+
+	if audience == landlordProfilePublic {
+		return pbProfile
+	}
+
+	pbProfile.BusinessRegistration = profile.BusinessRegistration.String
+	pbProfile.TaxId = profile.TaxID.String
+	pbProfile.BankName = profile.BankName.String
+	pbProfile.BankAccountName = profile.BankAccountName.String
+	pbProfile.GuarantorName = profile.GuarantorName.String
+	pbProfile.GuarantorPhone = profile.GuarantorPhone.String
+	pbProfile.GuarantorAddress = profile.GuarantorAddress.String
+
+	pbProfile.BankAccount = profile.BankAccount.String
+	if audience != landlordProfileOwner {
+		pbProfile.BankAccount = maskBankAccount(profile.BankAccount.String)
+	}
+
+	return pbProfile
+}
+
+// maskBankAccount keeps the last four digits, which is enough to recognise an account
+func maskBankAccount(account string) string {
+	if len(account) <= 4 {
+		return strings.Repeat("*", len(account))
+	}
+	return strings.Repeat("*", len(account)-4) + account[len(account)-4:]
+}
+
+const (
+	agentApplicationPending  = "pending"
+	agentApplicationApproved = "approved"
+	agentApplicationRejected = "rejected"
+	// agentApplicationNotSubmitted is an agent profile that never went through review
+	agentApplicationNotSubmitted = "not_submitted"
+)
+
+func agentApplicationStatus(profile db.InspectionAgentProfile) string {
+	switch {
+	case profile.IsApproved.Bool:
+		return agentApplicationApproved
+	case profile.RejectedAt.Valid:
+		return agentApplicationRejected
+	case profile.SubmittedAt.Valid:
+		return agentApplicationPending
+	}
+	return agentApplicationNotSubmitted
+}
+
+func convertAgentApplication(user db.User, profile db.InspectionAgentProfile, documents []string) *pb.AgentApplication {
+	application := &pb.AgentApplication{
+		UserId:           profile.UserID,
+		FirstName:        user.FirstName,
+		LastName:         user.LastName,
+		Email:            user.Email,
+		LicenseNumber:    profile.LicenseNumber.String,
+		Specializations:  splitList(profile.Specializations.String),
+		ServiceAreas:     splitList(profile.ServiceAreas.String),
+		LicenseDocuments: documents,
+		Status:           agentApplicationStatus(profile),
+		RejectionReason:  profile.RejectionReason.String,
+		ApprovedBy:       profile.ApprovedBy.Int64,
+	}
+
+	if profile.HourlyRate.Valid {
+		hourlyRate, _ := profile.HourlyRate.Float64Value()
+		application.HourlyRate = hourlyRate.Float64
+	}
+
+	if profile.SubmittedAt.Valid {
+		application.SubmittedAt = timestamppb.New(profile.SubmittedAt.Time)
+	}
+
+	if profile.ApprovedAt.Valid {
+		application.ApprovedAt = timestamppb.New(profile.ApprovedAt.Time)
+	}
+
+	if profile.RejectedAt.Valid {
+		application.RejectedAt = timestamppb.New(profile.RejectedAt.Time)
+	}
+
+	return application
+}
+
+func convertPendingAgentApplication(row db.ListPendingAgentApplicationsRow) *pb.AgentApplication {
+	application := &pb.AgentApplication{
+		UserId:          row.UserID,
+		FirstName:       row.FirstName,
+		LastName:        row.LastName,
+		Email:           row.Email,
+		LicenseNumber:   row.LicenseNumber.String,
+		Specializations: splitList(row.Specializations.String),
+		ServiceAreas:    splitList(row.ServiceAreas.String),
+		Status:          agentApplicationPending,
+		SubmittedAt:     timestamppb.New(row.SubmittedAt.Time),
+	}
+
+	if row.HourlyRate.Valid {
+		hourlyRate, _ := row.HourlyRate.Float64Value()
+		application.HourlyRate = hourlyRate.Float64
+	}
+
+	return application
+}
+
+func convertAgentAvailability(agentID int64, windows []db.AgentAvailabilityWindow, exceptions []db.AgentAvailabilityException) *pb.AgentAvailability {
+	availability := &pb.AgentAvailability{
+		AgentId:    agentID,
+		Timezone:   util.AvailabilityTimezone,
+		Windows:    make([]*pb.AvailabilityWindow, 0, len(windows)),
+		Exceptions: make([]*pb.AvailabilityException, 0, len(exceptions)),
+	}
+
+	for _, window := range windows {
+		availability.Windows = append(availability.Windows, &pb.AvailabilityWindow{
+			DayOfWeek: int32(window.DayOfWeek),
+			StartTime: util.FormatClockTime(pgClockTime(window.StartTime)),
+			EndTime:   util.FormatClockTime(pgClockTime(window.EndTime)),
+		})
+	}
+
+	for _, exception := range exceptions {
+		pbException := &pb.AvailabilityException{
+			StartDate: util.FormatDate(exception.StartDate.Time),
+			EndDate:   util.FormatDate(exception.EndDate.Time),
+			Available: exception.IsAvailable,
+			Reason:    exception.Reason.String,
+		}
+		if exception.StartTime.Valid && exception.EndTime.Valid {
+			pbException.StartTime = util.FormatClockTime(pgClockTime(exception.StartTime))
+			pbException.EndTime = util.FormatClockTime(pgClockTime(exception.EndTime))
+		}
+		availability.Exceptions = append(availability.Exceptions, pbException)
+	}
+
+	return availability
+}
+
+func convertInspection(inspection db.InspectionRequest) *pb.Inspection {
+	pbInspection := &pb.Inspection{
+		Id:                  inspection.ID,
+		PropertyId:          inspection.PropertyID,
+		TenantId:            inspection.TenantID,
+		LandlordId:          inspection.LandlordID,
+		InspectionType:      string(inspection.InspectionType),
+		Status:              string(inspection.Status.InspectionStatusEnum),
+		SpecialRequirements: inspection.SpecialRequirements.String,
+		CreatedAt:           timestamppb.New(inspection.CreatedAt.Time),
+	}
+	if inspection.InspectionAgentID.Valid {
+		pbInspection.InspectionAgentId = inspection.InspectionAgentID.Int64
+	}
+	if inspection.RequestedDate.Valid {
+		pbInspection.RequestedDate = util.FormatDate(inspection.RequestedDate.Time)
+	}
+	if inspection.RequestedTime.Valid {
+		pbInspection.RequestedTime = util.FormatClockTime(pgClockTime(inspection.RequestedTime))
+	}
+	if inspection.ConfirmedDate.Valid {
+		pbInspection.ConfirmedDate = util.FormatDate(inspection.ConfirmedDate.Time)
+	}
+	if inspection.ConfirmedTime.Valid {
+		pbInspection.ConfirmedTime = util.FormatClockTime(pgClockTime(inspection.ConfirmedTime))
+	}
+
+	return pbInspection
+}
+
+func convertTimeSlot(slot util.TimeSlot) *pb.TimeSlot {
+	return &pb.TimeSlot{
+		Date:      util.FormatDate(slot.Start),
+		StartTime: slot.Start.Format("15:04"),
+		EndTime:   slot.End.Format("15:04"),
+		StartsAt:  timestamppb.New(slot.Start),
+	}
+}
+
+func convertProfileChecklistItem(item db.ProfileItem) *pb.ProfileChecklistItem {
+	return &pb.ProfileChecklistItem{
+		Key:       item.Key,
+		Label:     item.Label,
+		Weight:    item.Weight,
+		Completed: item.Completed,
+	}
+}
+
+// convertTenantDocument leaves out storage_key, which only locates the content in the blob store
+func convertTenantDocument(document db.TenantDocument) *pb.TenantDocument {
+	return &pb.TenantDocument{
+		Id:           document.ID,
+		DocumentType: string(document.DocumentType),
+		FileName:     document.FileName,
+		ContentType:  document.ContentType,
+		SizeBytes:    document.SizeBytes,
+		Checksum:     document.Checksum,
+		CreatedAt:    timestamppb.New(document.CreatedAt),
+	}
+}
+
+func convertApplicationDocument(row db.ListRentalApplicationDocumentsRow) *pb.ApplicationDocument {
+	document := &pb.ApplicationDocument{
+		Document: convertTenantDocument(db.TenantDocument{
+			ID:           row.ID,
+			OwnerID:      row.OwnerID,
+			DocumentType: row.DocumentType,
+			FileName:     row.FileName,
+			ContentType:  row.ContentType,
+			SizeBytes:    row.SizeBytes,
+			Checksum:     row.Checksum,
+			CreatedAt:    row.CreatedAt,
+		}),
+		SharedAt: timestamppb.New(row.SharedAt),
+		Revoked:  row.RevokedAt.Valid,
+	}
+
+	if row.RevokedAt.Valid {
+		document.RevokedAt = timestamppb.New(row.RevokedAt.Time)
+	}
+
+	return document
+}
+
+func convertProperty(property db.Property) *pb.Property {
+	return &pb.Property{
+		Id:               property.ID,
+		LandlordId:       property.LandlordID,
+		Title:            property.Title,
+		Description:      property.Description.String,
+		PropertyType:     string(property.PropertyType),
+		Address:          property.Address,
+		City:             property.City,
+		State:            property.State,
+		Country:          property.Country.String,
+		Latitude:         numericToFloat(property.Latitude),
+		Longitude:        numericToFloat(property.Longitude),
+		Bedrooms:         property.Bedrooms,
+		Bathrooms:        property.Bathrooms,
+		RentAmount:       numericToFloat(property.RentAmount),
+		RentPeriod:       string(property.RentPeriod.RentPeriodEnum),
+		SecurityDeposit:  numericToFloat(property.SecurityDeposit),
+		AgencyFee:        numericToFloat(property.AgencyFee),
+		LegalFee:         numericToFloat(property.LegalFee),
+		Amenities:        splitList(property.Amenities.String),
+		FurnishingStatus: string(property.FurnishingStatus.FurnishingStatusEnum),
+		ParkingSpaces:    property.ParkingSpaces.Int32,
+		TotalArea:        numericToFloat(property.TotalArea),
+		PetFriendly:      property.PetFriendly,
+		IsVerified:       property.IsVerified.Bool,
+		IsAvailable:      property.IsAvailable.Bool,
+		ViewsCount:       property.ViewsCount.Int32,
+		Status:           string(db.PropertyStatus(property)),
+		CreatedAt:        timestamppb.New(property.CreatedAt.Time),
+		UpdatedAt:        timestamppb.New(property.UpdatedAt.Time),
+	}
+}
+
+func convertPropertyPin(pin db.ListPropertyPinsInBoundsRow) *pb.PropertyPin {
+	return &pb.PropertyPin{
+		PropertyId:   pin.ID,
+		Title:        pin.Title,
+		PropertyType: string(pin.PropertyType),
+		Bedrooms:     pin.Bedrooms,
+		RentAmount:   numericToFloat(pin.RentAmount),
+		Latitude:     pin.Latitude,
+		Longitude:    pin.Longitude,
+	}
+}
+
+// convertPropertyCluster places a cluster at the centre of its properties. A cluster of one property
+// links to it, so the client can show it as a pin.
+func convertPropertyCluster(cluster db.ClusterPropertiesInBoundsRow) *pb.PropertyCluster {
+	rsp := &pb.PropertyCluster{
+		PropertyCount: cluster.PropertyCount,
+		Latitude:      cluster.Latitude,
+		Longitude:     cluster.Longitude,
+		MinRent:       numericToFloat(cluster.MinRent),
+	}
+	if cluster.PropertyCount == 1 {
+		rsp.PropertyId = cluster.PropertyID
+	}
+	return rsp
+}
+
+// splitList reverses the ", " joined lists stored in text columns
+func splitList(value string) []string {
+	if value == "" {
+		return nil
+	}
+
+	items := strings.Split(value, ",")
+	for i := range items {
+		items[i] = strings.TrimSpace(items[i])
+	}
+	return items
+}
@@ -0,0 +1,92 @@
+package gapi
+
+import (
+	"errors"
+	"mime"
+	"net/http"
+	"strconv"
+
+	"github.com/r-scheele/sqr/internal/blob"
+	db "github.com/r-scheele/sqr/internal/db/sqlc"
+	"github.com/r-scheele/sqr/internal/token"
+	"github.com/rs/zerolog/log"
+)
+
+// ServeDocumentDownload serves the content behind a link from GetDocumentDownloadURL. The link is its own
+// credential, so access is checked again in case it was revoked since the link was handed out.
+func (server *Server) ServeDocumentDownload(res http.ResponseWriter, req *http.Request) {
+	if req.Method != http.MethodGet && req.Method != http.MethodHead {
+		res.Header().Set("Allow", "GET, HEAD")
+		http.Error(res, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	payload, err := server.downloadSigner.VerifyDownloadToken(req.URL.Query().Get("token"))
+	if err != nil {
+		if errors.Is(err, token.ErrExpiredToken) {
+			http.Error(res, "download link has expired", http.StatusGone)
+			return
+		}
+		http.Error(res, "invalid download link", http.StatusForbidden)
+		return
+	}
+
+	ctx := req.Context()
+	document, err := server.store.GetTenantDocument(ctx, payload.DocumentID)
+	if err != nil {
+		if errors.Is(err, db.ErrRecordNotFound) {
+			http.Error(res, "document not found", http.StatusNotFound)
+			return
+		}
+		log.Error().Err(err).Int64("document_id", payload.DocumentID).Msg("failed to get document")
+		http.Error(res, "failed to get document", http.StatusInternalServerError)
+		return
+	}
+
+	user, err := server.store.GetUserByID(ctx, payload.UserID)
+	if err != nil {
+		http.Error(res, "invalid download link", http.StatusForbidden)
+		return
+	}
+	if user.IsActive.Valid && !user.IsActive.Bool {
+		http.Error(res, "user account is deactivated", http.StatusForbidden)
+		return
+	}
+
+	allowed, err := server.canReadDocument(ctx, user, document)
+	if err != nil {
+		log.Error().Err(err).Int64("document_id", document.ID).Msg("failed to check document access")
+		http.Error(res, "failed to check document access", http.StatusInternalServerError)
+		return
+	}
+	if !allowed {
+		http.Error(res, "you no longer have access to this document", http.StatusForbidden)
+		return
+	}
+
+	content, err := server.blobStore.Get(ctx, document.StorageKey)
+	if err != nil {
+		if errors.Is(err, blob.ErrBlobNotFound) {
+			http.Error(res, "document not found", http.StatusNotFound)
+			return
+		}
+		log.Error().Err(err).Int64("document_id", document.ID).Msg("failed to read document content")
+		http.Error(res, "failed to read document", http.StatusInternalServerError)
+		return
+	}
+
+	header := res.Header()
+	header.Set("Content-Type", document.ContentType)
+	header.Set("Content-Length", strconv.Itoa(len(content)))
+	header.Set("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{"filename": document.FileName}))
+	header.Set("X-Content-Type-Options", "nosniff")
+	header.Set("Cache-Control", "private, no-store")
+	res.WriteHeader(http.StatusOK)
+
+	if req.Method == http.MethodGet {
+		_, err = res.Write(content)
+		if err != nil {
+			log.Error().Err(err).Int64("document_id", document.ID).Msg("failed to write document")
+		}
+	}
+}
@@ -3,6 +3,7 @@ package gapi
 import (
 	"context"
 	"net/http"
+	"net/url"
 	"time"
 
 	"github.com/rs/zerolog/log"
@@ -74,10 +75,26 @@ func HttpLogger(handler http.Handler) http.Handler {
 
 		logger.Str("protocol", "http").
 			Str("method", req.Method).
-			Str("path", req.RequestURI).
+			Str("path", redactedRequestURI(req.URL)).
 			Int("status_code", rec.StatusCode).
 			Str("status_text", http.StatusText(rec.StatusCode)).
 			Dur("duration", duration).
 			Msg("received a HTTP request")
 	})
 }
+
+// sensitiveQueryParams carry credentials, such as the token of a document download link, and are never logged
+var sensitiveQueryParams = []string{"token"}
+
+func redactedRequestURI(requestURL *url.URL) string {
+	query := requestURL.Query()
+	for _, param := range sensitiveQueryParams {
+		if query.Has(param) {
+			query.Set(param, "REDACTED")
+		}
+	}
+
+	redacted := *requestURL
+	redacted.RawQuery = query.Encode()
+	return redacted.RequestURI()
+}
@@ -0,0 +1,42 @@
+package gapi
+
+import (
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRedactedRequestURI(t *testing.T) {
+	testCases := []struct {
+		name string
+		uri  string
+		want string
+	}{
+		{
+			name: "NoQuery",
+			uri:  "/v1/properties",
+			want: "/v1/properties",
+		},
+		{
+			name: "DownloadToken",
+			uri:  DocumentDownloadPath + "?token=secret",
+			want: DocumentDownloadPath + "?token=REDACTED",
+		},
+		{
+			name: "OtherParamsKept",
+			uri:  "/v1/properties?city=lekki&token=secret",
+			want: "/v1/properties?city=lekki&token=REDACTED",
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			requestURL, err := url.ParseRequestURI(tc.uri)
+			require.NoError(t, err)
+			require.Equal(t, tc.want, redactedRequestURI(requestURL))
+		})
+	}
+}
@@ -0,0 +1,149 @@
+package gapi
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/hibiken/asynq"
+	"github.com/jackc/pgx/v5/pgtype"
+	db "github.com/r-scheele/sqr/internal/db/sqlc"
+	"github.com/r-scheele/sqr/internal/worker"
+	"github.com/rs/zerolog/log"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Defaults used when the lockout policy is not configured
+const (
+	defaultLoginMaxFailedAttempts      = 5
+	defaultLoginMaxFailedAttemptsPerIP = 20
+	defaultLoginFailureWindow          = 15 * time.Minute
+	defaultLoginLockoutDuration        = 5 * time.Minute
+	defaultLoginMaxLockoutDuration     = 24 * time.Hour
+)
+
+type loginLockoutPolicy struct {
+	maxFailedAttempts      int32
+	maxFailedAttemptsPerIP int32
+	failureWindow          time.Duration
+	lockoutDuration        time.Duration
+	maxLockoutDuration     time.Duration
+}
+
+func (server *Server) loginLockoutPolicy() loginLockoutPolicy {
+	policy := loginLockoutPolicy{
+		maxFailedAttempts:      int32(server.config.LoginMaxFailedAttempts),
+		maxFailedAttemptsPerIP: int32(server.config.LoginMaxFailedAttemptsPerIP),
+		failureWindow:          server.config.LoginFailureWindow,
+		lockoutDuration:        server.config.LoginLockoutDuration,
+		maxLockoutDuration:     server.config.LoginMaxLockoutDuration,
+	}
+
+	if policy.maxFailedAttempts <= 0 {
+		policy.maxFailedAttempts = defaultLoginMaxFailedAttempts
+	}
+	if policy.maxFailedAttemptsPerIP <= 0 {
+		policy.maxFailedAttemptsPerIP = defaultLoginMaxFailedAttemptsPerIP
+	}
+	if policy.failureWindow <= 0 {
+		policy.failureWindow = defaultLoginFailureWindow
+	}
+	if policy.lockoutDuration <= 0 {
+		policy.lockoutDuration = defaultLoginLockoutDuration
+	}
+	if policy.maxLockoutDuration <= 0 {
+		policy.maxLockoutDuration = defaultLoginMaxLockoutDuration
+	}
+
+	return policy
+}
+
+// checkLoginAllowedFromIP rejects logins from an address with too many recent failures
+func (server *Server) checkLoginAllowedFromIP(ctx context.Context, clientIP string) error {
+	if clientIP == "" {
+		return nil
+	}
+
+	policy := server.loginLockoutPolicy()
+	attempts, err := server.store.GetFailedLoginAttempts(ctx, db.GetFailedLoginAttemptsParams{
+		Column1:   clientIP,
+		CreatedAt: pgtype.Timestamptz{Time: time.Now().Add(-policy.failureWindow), Valid: true},
+		Limit:     policy.maxFailedAttemptsPerIP,
+		Offset:    0,
+	})
+	if err != nil {
+		return status.Errorf(codes.Internal, "failed to check failed login attempts")
+	}
+
+	if int32(len(attempts)) >= policy.maxFailedAttemptsPerIP {
+		return status.Errorf(codes.ResourceExhausted, "too many failed login attempts, try again later")
+	}
+
+	return nil
+}
+
+// checkAccountNotLocked rejects logins to an account that is currently locked
+func (server *Server) checkAccountNotLocked(ctx context.Context, userID int64) error {
+	lockout, err := server.store.GetAccountLockout(ctx, userID)
+	if err != nil {
+		if errors.Is(err, db.ErrRecordNotFound) {
+			return nil
+		}
+		return status.Errorf(codes.Internal, "failed to check account lockout")
+	}
+
+	if lockout.LockedUntil.Valid && time.Now().Before(lockout.LockedUntil.Time) {
+		return status.Errorf(codes.PermissionDenied, "account is temporarily locked until %s", lockout.LockedUntil.Time.UTC().Format(time.RFC3339))
+	}
+
+	return nil
+}
+
+// recordFailedLogin audits a failed login and notifies the user when it locks their account.
+// user is nil when the login used an unknown email.
+func (server *Server) recordFailedLogin(ctx context.Context, email string, user *db.User) {
+	policy := server.loginLockoutPolicy()
+	mtdt := server.extractMetadata(ctx)
+
+	arg := db.RecordFailedLoginTxParams{
+		Email:              email,
+		IpAddress:          pgtype.Text{String: mtdt.ClientIP, Valid: mtdt.ClientIP != ""},
+		UserAgent:          pgtype.Text{String: mtdt.UserAgent, Valid: mtdt.UserAgent != ""},
+		MaxAttempts:        policy.maxFailedAttempts,
+		LockoutDuration:    policy.lockoutDuration,
+		MaxLockoutDuration: policy.maxLockoutDuration,
+	}
+	if user != nil {
+		arg.UserID = pgtype.Int8{Int64: user.ID, Valid: true}
+	}
+
+	result, err := server.store.RecordFailedLoginTx(ctx, arg)
+	if err != nil {
+		log.Error().Err(err).Str("email", email).Msg("failed to record failed login")
+		return
+	}
+
+	if !result.Locked {
+		return
+	}
+
+	log.Warn().Int64("user_id", user.ID).Time("locked_until", result.Lockout.LockedUntil.Time).
+		Msg("account locked after too many failed logins")
+
+	taskPayload := &worker.PayloadSendAccountLockedEmail{
+		Username:    user.FirstName + " " + user.LastName,
+		Email:       user.Email,
+		IpAddress:   mtdt.ClientIP,
+		LockedUntil: result.Lockout.LockedUntil.Time,
+	}
+	opts := []asynq.Option{
+		asynq.MaxRetry(10),
+		asynq.Queue(worker.QueueCritical),
+	}
+
+	err = server.taskDistributor.DistributeTaskSendAccountLockedEmail(ctx, taskPayload, opts...)
+	if err != nil {
+		log.Error().Err(err).Int64("user_id", user.ID).Msg("failed to distribute send account locked email task")
+	}
+}
@@ -7,6 +7,8 @@ import (
 	"time"
 
 	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/r-scheele/sqr/internal/cache"
 	db "github.com/r-scheele/sqr/internal/db/sqlc"
 	"github.com/r-scheele/sqr/internal/token"
 	"github.com/r-scheele/sqr/internal/util"
@@ -15,11 +17,20 @@ import (
 	"google.golang.org/grpc/metadata"
 )
 
+// The keys are shared by the test servers so fixtures can be encrypted and hashed before the server exists
+var (
+	testIdentityEncryptionKey = util.RandomString(util.EncryptionKeySize)
+	testOTPHashKey            = util.RandomString(32)
+)
+
 func newTestServer(t *testing.T, store db.Store) *Server {
 	config := util.Config{
-		TokenSymmetricKey:    util.RandomString(32),
-		AccessTokenDuration:  time.Minute,
-		RefreshTokenDuration: time.Hour, // Add missing refresh token duration
+		TokenSymmetricKey:     util.RandomString(32),
+		AccessTokenDuration:   time.Minute,
+		RefreshTokenDuration:  time.Hour, // Add missing refresh token duration
+		IdentityEncryptionKey: testIdentityEncryptionKey,
+		OTPHashKey:            testOTPHashKey,
+		BlobStoreType:         "memory",
 	}
 
 	// Create mock task distributor for tests
@@ -27,7 +38,7 @@ func newTestServer(t *testing.T, store db.Store) *Server {
 	taskDistributor := mockwk.NewMockTaskDistributor(ctrl)
 
 	// Rate limiter is optional for tests (pass nil)
-	server, err := NewServer(config, store, taskDistributor, nil)
+	server, err := NewServer(config, store, newTestCacheManager(t), taskDistributor, nil)
 	require.NoError(t, err)
 
 	return server
@@ -36,20 +47,34 @@ func newTestServer(t *testing.T, store db.Store) *Server {
 // For tests that need access to the task distributor
 func newTestServerWithTaskDistributor(t *testing.T, store db.Store, taskDistributor *mockwk.MockTaskDistributor) *Server {
 	config := util.Config{
-		TokenSymmetricKey:    util.RandomString(32),
-		AccessTokenDuration:  time.Minute,
-		RefreshTokenDuration: time.Hour, // Add missing refresh token duration
+		TokenSymmetricKey:     util.RandomString(32),
+		AccessTokenDuration:   time.Minute,
+		RefreshTokenDuration:  time.Hour, // Add missing refresh token duration
+		IdentityEncryptionKey: testIdentityEncryptionKey,
+		OTPHashKey:            testOTPHashKey,
+		BlobStoreType:         "memory",
 	}
 
 	// Rate limiter is optional for tests (pass nil)
-	server, err := NewServer(config, store, taskDistributor, nil)
+	server, err := NewServer(config, store, newTestCacheManager(t), taskDistributor, nil)
 	require.NoError(t, err)
 
 	return server
 }
 
-func newContextWithBearerToken(t *testing.T, tokenMaker token.Maker, username string, role string, duration time.Duration, tokenType token.TokenType) context.Context {
-	accessToken, _, err := tokenMaker.CreateToken(username, role, duration, tokenType)
+func newTestCacheManager(t *testing.T) cache.CacheManager {
+	memoryCache := cache.NewMemoryCache(util.Config{
+		MemoryCacheSize:            100,
+		MemoryCacheTTL:             time.Minute,
+		MemoryCacheCleanUpInterval: time.Minute,
+	})
+	t.Cleanup(func() { memoryCache.Close() })
+
+	return cache.NewManager(memoryCache)
+}
+
+func newContextWithBearerToken(t *testing.T, tokenMaker token.Maker, user db.User, sessionID uuid.UUID, duration time.Duration, tokenType token.TokenType) context.Context {
+	accessToken, _, err := tokenMaker.CreateToken(user.ID, user.Email, string(user.UserType), sessionID, duration, tokenType)
 	require.NoError(t, err)
 
 	bearerToken := fmt.Sprintf("%s %s", authorizationBearer, accessToken)
@@ -0,0 +1,64 @@
+package gapi
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+)
+
+func TestExtractMetadata(t *testing.T) {
+	server := newTestServer(t, nil)
+
+	gatewayMD := metadata.Pairs(
+		userAgentHeader, "grpc-go/1.45.0",
+		grpcGatewayUserAgentHeader, "Mozilla/5.0",
+		xForwardedForHeader, "203.0.113.7",
+	)
+
+	testCases := []struct {
+		name      string
+		peerAddr  net.Addr
+		md        metadata.MD
+		userAgent string
+		clientIP  string
+	}{
+		{
+			name:      "Gateway",
+			peerAddr:  &net.TCPAddr{IP: net.ParseIP("127.0.0.1"), Port: 51000},
+			md:        gatewayMD,
+			userAgent: "Mozilla/5.0",
+			clientIP:  "203.0.113.7",
+		},
+		{
+			name:      "ForwardedByRemotePeer",
+			peerAddr:  &net.TCPAddr{IP: net.ParseIP("198.51.100.2"), Port: 51000},
+			md:        gatewayMD,
+			userAgent: "Mozilla/5.0",
+			clientIP:  "198.51.100.2:51000",
+		},
+		{
+			name:      "GRPCClient",
+			peerAddr:  &net.TCPAddr{IP: net.ParseIP("198.51.100.2"), Port: 51000},
+			md:        metadata.Pairs(userAgentHeader, "grpc-go/1.45.0"),
+			userAgent: "grpc-go/1.45.0",
+			clientIP:  "198.51.100.2:51000",
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := metadata.NewIncomingContext(context.Background(), tc.md)
+			ctx = peer.NewContext(ctx, &peer.Peer{Addr: tc.peerAddr})
+
+			mtdt := server.extractMetadata(ctx)
+			require.Equal(t, tc.userAgent, mtdt.UserAgent)
+			require.Equal(t, tc.clientIP, mtdt.ClientIP)
+		})
+	}
+}
@@ -0,0 +1,165 @@
+package gapi
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	db "github.com/r-scheele/sqr/internal/db/sqlc"
+	"github.com/r-scheele/sqr/internal/pb"
+	"github.com/r-scheele/sqr/internal/token"
+	"github.com/r-scheele/sqr/internal/util"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+const (
+	defaultMFATokenDuration = 5 * time.Minute
+	defaultMFAIssuer        = "Sqr"
+	recoveryCodeCount       = 10
+)
+
+// mfaRoles may enrol in two-factor authentication. They control payouts and verification.
+var mfaRoles = []string{util.LandlordRole, util.InspectionAgentRole, util.AdminRole}
+
+// totpData is stored as the verification_data of a totp user verification
+type totpData struct {
+	// Secret is encrypted with the identity encryption key, like NINs
+	Secret string `json:"secret"`
+	// RecoveryCodes holds the hashes of the recovery codes that are still unused
+	RecoveryCodes []string `json:"recovery_codes,omitempty"`
+	// LastUsedStep is the time step of the last accepted code, so a code can't be replayed
+	LastUsedStep int64 `json:"last_used_step,omitempty"`
+}
+
+type mfaEnrolment struct {
+	Verification db.UserVerification
+	Data         totpData
+	// Secret is the decrypted Data.Secret
+	Secret string
+}
+
+// getTOTPVerification returns the latest totp verification of the user, enabled or not
+func (server *Server) getTOTPVerification(ctx context.Context, userID int64) (*mfaEnrolment, error) {
+	verification, err := server.store.GetUserVerificationByType(ctx, db.GetUserVerificationByTypeParams{
+		UserID:           userID,
+		VerificationType: db.VerificationTypeEnumTotp,
+	})
+	if err != nil {
+		if errors.Is(err, db.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, status.Errorf(codes.Internal, "failed to get mfa enrolment: %s", err)
+	}
+
+	enrolment := &mfaEnrolment{Verification: verification}
+	err = json.Unmarshal(verification.VerificationData, &enrolment.Data)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to read mfa enrolment: %s", err)
+	}
+
+	enrolment.Secret, err = util.DecryptField(server.config.IdentityEncryptionKey, enrolment.Data.Secret)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to decrypt mfa secret")
+	}
+
+	return enrolment, nil
+}
+
+// getMFAEnrolment returns the confirmed totp enrolment of the user, or nil if MFA is not enabled
+func (server *Server) getMFAEnrolment(ctx context.Context, userID int64) (*mfaEnrolment, error) {
+	enrolment, err := server.getTOTPVerification(ctx, userID)
+	if err != nil || enrolment == nil {
+		return nil, err
+	}
+
+	if enrolment.Verification.VerificationStatus.VerificationStatusEnum != db.VerificationStatusEnumVerified {
+		return nil, nil
+	}
+
+	return enrolment, nil
+}
+
+// checkMFACode accepts either a current TOTP code or an unused recovery code.
+// On success the enrolment data is updated and must be persisted by the caller.
+func checkMFACode(secret string, data *totpData, code string) bool {
+	if step, ok := util.ValidateTOTPCode(secret, code, time.Now()); ok {
+		if step <= data.LastUsedStep {
+			return false
+		}
+		data.LastUsedStep = step
+		return true
+	}
+
+	hashed := util.HashRecoveryCode(code)
+	for i, recoveryCode := range data.RecoveryCodes {
+		if recoveryCode == hashed {
+			data.RecoveryCodes = append(data.RecoveryCodes[:i], data.RecoveryCodes[i+1:]...)
+			return true
+		}
+	}
+
+	return false
+}
+
+// useMFACode checks the code and persists that it was used. The update only applies if the enrolment is
+// unchanged since it was read, so two concurrent requests can't both use the same code.
+func (server *Server) useMFACode(ctx context.Context, enrolment *mfaEnrolment, code string) (bool, error) {
+	if !checkMFACode(enrolment.Secret, &enrolment.Data, code) {
+		return false, nil
+	}
+
+	dataJSON, err := json.Marshal(enrolment.Data)
+	if err != nil {
+		return false, status.Errorf(codes.Internal, "failed to marshal mfa enrolment")
+	}
+
+	updated, err := server.store.SwapVerificationData(ctx, db.SwapVerificationDataParams{
+		VerificationData: dataJSON,
+		ID:               enrolment.Verification.ID,
+		PreviousData:     string(enrolment.Verification.VerificationData),
+	})
+	if err != nil {
+		return false, status.Errorf(codes.Internal, "failed to update mfa enrolment: %s", err)
+	}
+
+	return updated == 1, nil
+}
+
+// newMFAPendingResponse answers a correct password on an MFA enabled account with a
+// short-lived token that VerifyMFA exchanges for a session once the second factor is given.
+func (server *Server) newMFAPendingResponse(user db.User) (*pb.LoginUserResponse, error) {
+	duration := server.config.MFATokenDuration
+	if duration <= 0 {
+		duration = defaultMFATokenDuration
+	}
+
+	mfaToken, mfaPayload, err := server.tokenMaker.CreateToken(
+		user.ID,
+		user.Email,
+		string(user.UserType),
+		uuid.New(),
+		duration,
+		token.TokenTypeMFAPending,
+	)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to create mfa token")
+	}
+
+	rsp := &pb.LoginUserResponse{
+		MfaRequired:       true,
+		MfaToken:          mfaToken,
+		MfaTokenExpiresAt: timestamppb.New(mfaPayload.ExpiredAt),
+	}
+	return rsp, nil
+}
+
+func (server *Server) mfaIssuer() string {
+	if server.config.MFAIssuer != "" {
+		return server.config.MFAIssuer
+	}
+	return defaultMFAIssuer
+}
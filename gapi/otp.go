@@ -0,0 +1,121 @@
+package gapi
+
+import (
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"time"
+
+	db "github.com/r-scheele/sqr/internal/db/sqlc"
+	"github.com/r-scheele/sqr/internal/util"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	otpLength                = 6
+	defaultOTPDuration       = 10 * time.Minute
+	defaultOTPMaxAttempts    = 5
+	defaultOTPResendInterval = time.Minute
+)
+
+// otpData is stored as the verification_data of a user verification that is
+// confirmed with a short numeric code sent to the user
+type otpData struct {
+	// Destination is the phone number or email address the code was sent to
+	Destination string    `json:"destination"`
+	CodeHash    string    `json:"code_hash"`
+	SentAt      time.Time `json:"sent_at"`
+	ExpiresAt   time.Time `json:"expires_at"`
+	Attempts    int32     `json:"attempts"`
+}
+
+func (server *Server) otpDuration() time.Duration {
+	if server.config.OTPDuration > 0 {
+		return server.config.OTPDuration
+	}
+	return defaultOTPDuration
+}
+
+func (server *Server) otpMaxAttempts() int32 {
+	if server.config.OTPMaxAttempts > 0 {
+		return int32(server.config.OTPMaxAttempts)
+	}
+	return defaultOTPMaxAttempts
+}
+
+func (server *Server) otpResendInterval() time.Duration {
+	if server.config.OTPResendInterval > 0 {
+		return server.config.OTPResendInterval
+	}
+	return defaultOTPResendInterval
+}
+
+func (server *Server) otpHashKey() string {
+	if server.config.OTPHashKey != "" {
+		return server.config.OTPHashKey
+	}
+	return server.config.TokenSymmetricKey
+}
+
+// newOTP generates a code of the user for destination, returning the code to deliver and the data to store
+func (server *Server) newOTP(userID int64, destination string) (string, otpData, error) {
+	code, err := util.GenerateNumericCode(otpLength)
+	if err != nil {
+		return "", otpData{}, status.Errorf(codes.Internal, "failed to generate code")
+	}
+
+	now := time.Now()
+	data := otpData{
+		Destination: destination,
+		CodeHash:    util.HashOTPCode(server.otpHashKey(), userID, code),
+		SentAt:      now,
+		ExpiresAt:   now.Add(server.otpDuration()),
+	}
+	return code, data, nil
+}
+
+// checkOTPResend rejects a new code while the previous one was sent too recently
+func (server *Server) checkOTPResend(data otpData) error {
+	if wait := time.Until(data.SentAt.Add(server.otpResendInterval())); wait > 0 {
+		return status.Errorf(codes.ResourceExhausted, "a code was sent recently, try again in %d seconds", int(wait.Seconds())+1)
+	}
+	return nil
+}
+
+// checkOTP verifies code against the data stored for the user. A wrong guess increments data.Attempts,
+// which the caller must persist, and codes are burned after too many of them.
+func (server *Server) checkOTP(userID int64, data *otpData, code string) error {
+	if time.Now().After(data.ExpiresAt) {
+		return status.Errorf(codes.FailedPrecondition, "code has expired, request a new one")
+	}
+
+	if data.Attempts >= server.otpMaxAttempts() {
+		return status.Errorf(codes.ResourceExhausted, "too many incorrect attempts, request a new code")
+	}
+
+	if subtle.ConstantTimeCompare([]byte(data.CodeHash), []byte(util.HashOTPCode(server.otpHashKey(), userID, code))) != 1 {
+		data.Attempts++
+		return status.Errorf(codes.InvalidArgument, "incorrect code")
+	}
+
+	return nil
+}
+
+// saveVerificationData replaces the verification_data of a user verification
+func (server *Server) saveVerificationData(ctx context.Context, verificationID int64, data interface{}) error {
+	dataJSON, err := json.Marshal(data)
+	if err != nil {
+		return status.Errorf(codes.Internal, "failed to marshal verification data")
+	}
+
+	_, err = server.store.UpdateVerificationData(ctx, db.UpdateVerificationDataParams{
+		ID:               verificationID,
+		VerificationData: dataJSON,
+	})
+	if err != nil {
+		return status.Errorf(codes.Internal, "failed to update verification: %s", err)
+	}
+
+	return nil
+}
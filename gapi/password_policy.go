@@ -0,0 +1,45 @@
+package gapi
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/r-scheele/sqr/internal/util"
+	"github.com/r-scheele/sqr/internal/val"
+	"github.com/rs/zerolog/log"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+)
+
+func newPasswordPolicy(config util.Config) (val.PasswordPolicy, error) {
+	policy := val.DefaultPasswordPolicy()
+	if config.PasswordMinLength > 0 {
+		policy.MinLength = config.PasswordMinLength
+	}
+	if config.PasswordMinCharacterClasses > 0 {
+		policy.MinCharacterClasses = config.PasswordMinCharacterClasses
+	}
+
+	if config.BreachedPasswordsFile != "" {
+		list, err := val.LoadBreachedPasswordList(config.BreachedPasswordsFile)
+		if err != nil {
+			return policy, fmt.Errorf("cannot load breached passwords: %w", err)
+		}
+		policy.Breached = list
+	}
+
+	return policy, nil
+}
+
+// newPasswordViolations checks a password that is about to be set against the password policy
+func (server *Server) newPasswordViolations(ctx context.Context, field string, password string, owner val.PasswordOwner) (violations []*errdetails.BadRequest_FieldViolation) {
+	errs, err := server.passwordPolicy.Check(ctx, password, owner)
+	if err != nil {
+		// An unavailable lookup shouldn't stop anyone from setting a password the other rules accept
+		log.Error().Err(err).Msg("failed to check password against breached passwords")
+	}
+
+	for _, err := range errs {
+		violations = append(violations, fieldViolation(field, err))
+	}
+	return violations
+}
@@ -0,0 +1,144 @@
+package gapi
+
+import (
+	"context"
+	"crypto/sha1"
+	"encoding/hex"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	mockdb "github.com/r-scheele/sqr/internal/db/mock"
+	"github.com/r-scheele/sqr/internal/pb"
+	"github.com/r-scheele/sqr/internal/util"
+	"github.com/r-scheele/sqr/internal/val"
+	mockwk "github.com/r-scheele/sqr/internal/worker/mock"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func requireFieldViolation(t *testing.T, err error, field string) {
+	st, ok := status.FromError(err)
+	require.True(t, ok)
+	require.Equal(t, codes.InvalidArgument, st.Code())
+
+	for _, detail := range st.Details() {
+		badRequest, ok := detail.(*errdetails.BadRequest)
+		if !ok {
+			continue
+		}
+		for _, violation := range badRequest.GetFieldViolations() {
+			if violation.GetField() == field {
+				return
+			}
+		}
+	}
+	t.Fatalf("no violation of field %s in %v", field, err)
+}
+
+func writeBreachedPasswordsFile(t *testing.T, passwords ...string) string {
+	path := filepath.Join(t.TempDir(), "breached.txt")
+	err := os.WriteFile(path, []byte("# common passwords\n"+strings.Join(passwords, "\n")+"\n"), 0o600)
+	require.NoError(t, err)
+	return path
+}
+
+func TestNewPasswordPolicy(t *testing.T) {
+	policy, err := newPasswordPolicy(util.Config{})
+	require.NoError(t, err)
+	require.Equal(t, val.DefaultPasswordPolicy(), policy)
+
+	hashed := sha1.Sum([]byte("Hashed#Password1"))
+	path := writeBreachedPasswordsFile(t, "Summer2024!", strings.ToUpper(hex.EncodeToString(hashed[:]))+":42")
+
+	policy, err = newPasswordPolicy(util.Config{
+		PasswordMinLength:           12,
+		PasswordMinCharacterClasses: 4,
+		BreachedPasswordsFile:       path,
+	})
+	require.NoError(t, err)
+	require.Equal(t, 12, policy.MinLength)
+	require.Equal(t, 4, policy.MinCharacterClasses)
+
+	for _, password := range []string{"Summer2024!", "Hashed#Password1"} {
+		breached, err := val.IsBreachedPassword(context.Background(), policy.Breached, password)
+		require.NoError(t, err)
+		require.True(t, breached, password)
+	}
+
+	breached, err := val.IsBreachedPassword(context.Background(), policy.Breached, util.RandomPassword())
+	require.NoError(t, err)
+	require.False(t, breached)
+
+	_, err = newPasswordPolicy(util.Config{BreachedPasswordsFile: filepath.Join(t.TempDir(), "missing.txt")})
+	require.Error(t, err)
+}
+
+func TestPasswordPolicyViolations(t *testing.T) {
+	user, _ := randomUser(t, util.TenantRole)
+	owner := val.PasswordOwner{Email: user.Email, FirstName: user.FirstName, LastName: user.LastName}
+
+	policy := val.DefaultPasswordPolicy()
+	list, err := val.ParseBreachedPasswordList(strings.NewReader("Summer2024!\n"))
+	require.NoError(t, err)
+	policy.Breached = list
+
+	testCases := []struct {
+		name       string
+		password   string
+		violations int
+	}{
+		{name: "OK", password: util.RandomPassword(), violations: 0},
+		{name: "TooShort", password: "Ab1!", violations: 1},
+		{name: "TooFewCharacterClasses", password: "alllowercase", violations: 1},
+		{name: "ContainsName", password: "Xx" + strings.ToUpper(user.FirstName) + "9!", violations: 1},
+		{name: "ContainsEmail", password: strings.Split(user.Email, "@")[0] + "A1!", violations: 1},
+		{name: "Breached", password: "Summer2024!", violations: 1},
+		{name: "Everything", password: "abc", violations: 2},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			violations, err := policy.Check(context.Background(), tc.password, owner)
+			require.NoError(t, err)
+			require.Len(t, violations, tc.violations)
+		})
+	}
+}
+
+func TestCreateUserWithBreachedPassword(t *testing.T) {
+	user, _ := randomUser(t, util.TenantRole)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	store := mockdb.NewMockStore(ctrl)
+	store.EXPECT().
+		CreateUserTx(gomock.Any(), gomock.Any()).
+		Times(0)
+
+	config := util.Config{
+		TokenSymmetricKey:     util.RandomString(32),
+		AccessTokenDuration:   time.Minute,
+		RefreshTokenDuration:  time.Hour,
+		BreachedPasswordsFile: writeBreachedPasswordsFile(t, "Summer2024!"),
+	}
+	server, err := NewServer(config, store, newTestCacheManager(t), mockwk.NewMockTaskDistributor(ctrl), nil)
+	require.NoError(t, err)
+
+	_, err = server.CreateUser(context.Background(), &pb.CreateUserRequest{
+		Password:    "Summer2024!",
+		FullName:    user.FirstName + " " + user.LastName,
+		Email:       user.Email,
+		PhoneNumber: user.Phone,
+		UserType:    string(user.UserType),
+	})
+	require.Error(t, err)
+	requireFieldViolation(t, err, "password")
+}
@@ -0,0 +1,324 @@
+package gapi
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	db "github.com/r-scheele/sqr/internal/db/sqlc"
+	"github.com/r-scheele/sqr/internal/ratelimit"
+	"github.com/r-scheele/sqr/internal/util"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+const rpcMethodPrefix = "/pb.Sqr/"
+
+// ownershipPredicate reports whether the caller owns the resource a request refers to
+type ownershipPredicate func(ctx context.Context, server *Server, principal *Principal, req interface{}) (bool, error)
+
+// accessPolicy describes who may call an RPC.
+// Public RPCs authenticate the caller themselves (credentials, refresh or mfa token in the body) or need no caller at all.
+// Otherwise the caller must hold one of roles and, unless they are an admin, pass owns when it is set.
+type accessPolicy struct {
+	public bool
+	roles  []string
+	owns   ownershipPredicate
+}
+
+// accessPolicies is the authorization table of the Sqr service, keyed by full RPC method name.
+// A method that is missing from the table is denied, so every new RPC must be added here.
+var accessPolicies = map[string]accessPolicy{
+	"/pb.Sqr/CreateUser":        {public: true},
+	"/pb.Sqr/LoginUser":         {public: true},
+	"/pb.Sqr/VerifyEmail":       {public: true},
+	"/pb.Sqr/ForgotPassword":    {public: true},
+	"/pb.Sqr/ResetPassword":     {public: true},
+	"/pb.Sqr/RefreshToken":      {public: true},
+	"/pb.Sqr/LogoutUser":        {public: true},
+	"/pb.Sqr/VerifyMFA":         {public: true},
+	"/pb.Sqr/RequestLoginCode":  {public: true},
+	"/pb.Sqr/ExchangeLoginCode": {public: true},
+	"/pb.Sqr/LoginWithOIDC":     {public: true},
+
+	"/pb.Sqr/UpdateUser": {roles: allRoles, owns: ownsUsername},
+
+	"/pb.Sqr/ListMySessions":      {roles: allRoles},
+	"/pb.Sqr/RevokeSession":       {roles: allRoles},
+	"/pb.Sqr/RevokeOtherSessions": {roles: allRoles},
+
+	"/pb.Sqr/EnrollMFA":  {roles: mfaRoles},
+	"/pb.Sqr/ConfirmMFA": {roles: mfaRoles},
+	"/pb.Sqr/DisableMFA": {roles: mfaRoles},
+
+	"/pb.Sqr/RequestPhoneVerification": {roles: allRoles},
+	"/pb.Sqr/ConfirmPhoneVerification": {roles: allRoles},
+	"/pb.Sqr/SubmitNINVerification":    {roles: allRoles},
+
+	"/pb.Sqr/GetProfileCompletion": {roles: allRoles},
+
+	"/pb.Sqr/RequestAccountExport":   {roles: allRoles},
+	"/pb.Sqr/DownloadAccountExport":  {roles: allRoles, owns: ownsAccountExport},
+	"/pb.Sqr/RequestAccountDeletion": {roles: allRoles},
+	"/pb.Sqr/CancelAccountDeletion":  {roles: allRoles},
+
+	"/pb.Sqr/GetTenantProfile":    {roles: []string{util.TenantRole, util.AdminRole}, owns: ownsUserID},
+	"/pb.Sqr/UpdateTenantProfile": {roles: []string{util.TenantRole}, owns: ownsUserID},
+
+	"/pb.Sqr/CreateLandlordProfile":          {roles: []string{util.LandlordRole}},
+	"/pb.Sqr/GetLandlordProfile":             {roles: allRoles},
+	"/pb.Sqr/UpdateLandlordProfile":          {roles: []string{util.LandlordRole}, owns: ownsUserID},
+	"/pb.Sqr/UpdateLandlordBankingDetails":   {roles: []string{util.LandlordRole}, owns: ownsUserID},
+	"/pb.Sqr/UpdateLandlordGuarantorDetails": {roles: []string{util.LandlordRole}, owns: ownsUserID},
+
+	"/pb.Sqr/SubmitAgentApplication":  {roles: []string{util.InspectionAgentRole}},
+	"/pb.Sqr/GetAgentApplication":     {roles: []string{util.InspectionAgentRole, util.AdminRole}, owns: ownsUserID},
+	"/pb.Sqr/ListAgentApplications":   {roles: []string{util.AdminRole}},
+	"/pb.Sqr/ApproveAgentApplication": {roles: []string{util.AdminRole}},
+	"/pb.Sqr/RejectAgentApplication":  {roles: []string{util.AdminRole}},
+
+	"/pb.Sqr/UploadTenantDocument":      {roles: []string{util.TenantRole}},
+	"/pb.Sqr/ListTenantDocuments":       {roles: []string{util.TenantRole}},
+	"/pb.Sqr/DeleteTenantDocument":      {roles: []string{util.TenantRole}, owns: ownsTenantDocument},
+	"/pb.Sqr/ShareApplicationDocuments": {roles: []string{util.TenantRole}, owns: ownsRentalApplication},
+	"/pb.Sqr/RevokeApplicationDocument": {roles: []string{util.TenantRole}, owns: ownsRentalApplication},
+	"/pb.Sqr/ListApplicationDocuments":  {roles: []string{util.TenantRole, util.LandlordRole, util.AdminRole}, owns: partyToRentalApplication},
+	"/pb.Sqr/GetDocumentDownloadURL":    {roles: []string{util.TenantRole, util.LandlordRole, util.AdminRole}, owns: canReadTenantDocument},
+
+	"/pb.Sqr/CreateProperty":         {roles: []string{util.LandlordRole}},
+	"/pb.Sqr/GetProperty":            {roles: allRoles},
+	"/pb.Sqr/ListMyProperties":       {roles: []string{util.LandlordRole}},
+	"/pb.Sqr/UpdateProperty":         {roles: []string{util.LandlordRole}, owns: ownsProperty},
+	"/pb.Sqr/PublishProperty":        {roles: []string{util.LandlordRole}, owns: ownsProperty},
+	"/pb.Sqr/UnpublishProperty":      {roles: []string{util.LandlordRole}, owns: ownsProperty},
+	"/pb.Sqr/MarkPropertyRented":     {roles: []string{util.LandlordRole}, owns: ownsProperty},
+	"/pb.Sqr/ArchiveProperty":        {roles: []string{util.LandlordRole, util.AdminRole}, owns: ownsProperty},
+	"/pb.Sqr/SearchProperties":       {public: true},
+	"/pb.Sqr/SearchPropertiesNearby": {public: true},
+	"/pb.Sqr/GetPropertyMap":         {public: true},
+
+	"/pb.Sqr/SetAgentAvailability": {roles: []string{util.InspectionAgentRole}},
+	"/pb.Sqr/GetAgentAvailability": {roles: []string{util.InspectionAgentRole}},
+	"/pb.Sqr/ListAgentFreeSlots":   {roles: allRoles},
+	"/pb.Sqr/GetInspection":        {roles: []string{util.InspectionAgentRole, util.AdminRole}, owns: assignedToInspection},
+
+	"/pb.Sqr/UnlockUserAccount":   {roles: []string{util.AdminRole}},
+	"/pb.Sqr/ListUsers":           {roles: []string{util.AdminRole}},
+	"/pb.Sqr/GetUser":             {roles: []string{util.AdminRole}},
+	"/pb.Sqr/SetUserActiveStatus": {roles: []string{util.AdminRole}},
+	"/pb.Sqr/ChangeUserType":      {roles: []string{util.AdminRole}},
+	"/pb.Sqr/ForcePasswordReset":  {roles: []string{util.AdminRole}},
+	"/pb.Sqr/GetSearchCacheStats": {roles: []string{util.AdminRole}},
+}
+
+type principalContextKey struct{}
+
+// AuthorizationInterceptor enforces accessPolicies on every unary RPC and hands the
+// authorized principal to the handler through the context.
+func (server *Server) AuthorizationInterceptor(
+	ctx context.Context,
+	req interface{},
+	info *grpc.UnaryServerInfo,
+	handler grpc.UnaryHandler,
+) (interface{}, error) {
+	principal, err := server.authorize(ctx, info.FullMethod, req)
+	if err != nil {
+		return nil, err
+	}
+
+	if principal != nil {
+		ctx = context.WithValue(ctx, principalContextKey{}, principal)
+		ctx = ratelimit.ContextWithUserID(ctx, principal.User.ID)
+	}
+
+	return handler(ctx, req)
+}
+
+// authorizeRequest returns the caller of the RPC handling req. Every call served by the gRPC server,
+// including the ones the HTTP gateway forwards to it, was already checked by AuthorizationInterceptor.
+// The policy is applied again only when a handler is called directly, without the interceptor.
+func (server *Server) authorizeRequest(ctx context.Context, req proto.Message) (*Principal, error) {
+	if principal, ok := ctx.Value(principalContextKey{}).(*Principal); ok {
+		return principal, nil
+	}
+
+	principal, err := server.authorize(ctx, rpcMethodForRequest(req), req)
+	if err != nil {
+		return nil, err
+	}
+	if principal == nil {
+		return nil, status.Errorf(codes.Internal, "rpc does not have an authenticated caller")
+	}
+
+	return principal, nil
+}
+
+// authorize checks the caller against the policy of method. It returns a nil principal for public methods.
+func (server *Server) authorize(ctx context.Context, method string, req interface{}) (*Principal, error) {
+	policy, ok := accessPolicies[method]
+	if !ok {
+		return nil, status.Errorf(codes.PermissionDenied, "no access policy for %s", method)
+	}
+
+	if policy.public {
+		return nil, nil
+	}
+
+	principal, err := server.authorizeUser(ctx, policy.roles)
+	if err != nil {
+		return nil, unauthenticatedError(err)
+	}
+
+	if policy.owns == nil || principal.Payload.Role == util.AdminRole {
+		return principal, nil
+	}
+
+	owns, err := policy.owns(ctx, server, principal, req)
+	if err != nil {
+		return nil, err
+	}
+	if !owns {
+		return nil, status.Errorf(codes.PermissionDenied, "cannot access a resource that belongs to another user")
+	}
+
+	return principal, nil
+}
+
+// rpcMethodForRequest returns the full method name of the RPC that takes req, relying on every
+// Sqr RPC taking a <Method>Request message
+func rpcMethodForRequest(req proto.Message) string {
+	name := string(req.ProtoReflect().Descriptor().Name())
+	return rpcMethodPrefix + strings.TrimSuffix(name, "Request")
+}
+
+// ownsUserID matches requests about a user by id against the caller
+func ownsUserID(ctx context.Context, server *Server, principal *Principal, req interface{}) (bool, error) {
+	r, ok := req.(interface{ GetUserId() int64 })
+	if !ok {
+		return false, nil
+	}
+	return r.GetUserId() == principal.User.ID, nil
+}
+
+// ownsUsername matches requests about a user by username (their email) against the caller
+func ownsUsername(ctx context.Context, server *Server, principal *Principal, req interface{}) (bool, error) {
+	r, ok := req.(interface{ GetUsername() string })
+	if !ok {
+		return false, nil
+	}
+	return r.GetUsername() == principal.User.Email, nil
+}
+
+// ownsProperty lets landlords act on their own properties
+func ownsProperty(ctx context.Context, server *Server, principal *Principal, req interface{}) (bool, error) {
+	r, ok := req.(interface{ GetPropertyId() int64 })
+	if !ok {
+		return false, nil
+	}
+
+	property, err := server.store.GetPropertyByID(ctx, r.GetPropertyId())
+	if err != nil {
+		return false, ownershipLookupError("property", err)
+	}
+
+	return property.LandlordID == principal.User.ID, nil
+}
+
+// ownsRentalApplication lets tenants act on the applications they submitted
+func ownsRentalApplication(ctx context.Context, server *Server, principal *Principal, req interface{}) (bool, error) {
+	r, ok := req.(interface{ GetApplicationId() int64 })
+	if !ok {
+		return false, nil
+	}
+
+	application, err := server.store.GetRentalApplicationByID(ctx, r.GetApplicationId())
+	if err != nil {
+		return false, ownershipLookupError("rental application", err)
+	}
+
+	return application.TenantID == principal.User.ID, nil
+}
+
+// partyToRentalApplication lets the tenant and the landlord of an application see it
+func partyToRentalApplication(ctx context.Context, server *Server, principal *Principal, req interface{}) (bool, error) {
+	r, ok := req.(interface{ GetApplicationId() int64 })
+	if !ok {
+		return false, nil
+	}
+
+	application, err := server.store.GetRentalApplicationByID(ctx, r.GetApplicationId())
+	if err != nil {
+		return false, ownershipLookupError("rental application", err)
+	}
+
+	return application.TenantID == principal.User.ID || application.LandlordID == principal.User.ID, nil
+}
+
+// ownsTenantDocument lets tenants manage the documents they uploaded
+func ownsTenantDocument(ctx context.Context, server *Server, principal *Principal, req interface{}) (bool, error) {
+	r, ok := req.(interface{ GetDocumentId() int64 })
+	if !ok {
+		return false, nil
+	}
+
+	document, err := server.store.GetTenantDocument(ctx, r.GetDocumentId())
+	if err != nil {
+		return false, ownershipLookupError("document", err)
+	}
+
+	return document.OwnerID == principal.User.ID, nil
+}
+
+// canReadTenantDocument lets tenants read their documents and landlords the documents shared with them
+func canReadTenantDocument(ctx context.Context, server *Server, principal *Principal, req interface{}) (bool, error) {
+	r, ok := req.(interface{ GetDocumentId() int64 })
+	if !ok {
+		return false, nil
+	}
+
+	document, err := server.store.GetTenantDocument(ctx, r.GetDocumentId())
+	if err != nil {
+		return false, ownershipLookupError("document", err)
+	}
+
+	return server.canReadDocument(ctx, principal.User, document)
+}
+
+// ownsAccountExport lets users download only their own data exports
+func ownsAccountExport(ctx context.Context, server *Server, principal *Principal, req interface{}) (bool, error) {
+	r, ok := req.(interface{ GetExportId() int64 })
+	if !ok {
+		return false, nil
+	}
+
+	export, err := server.store.GetAccountExport(ctx, r.GetExportId())
+	if err != nil {
+		return false, ownershipLookupError("account export", err)
+	}
+
+	return export.UserID == principal.User.ID, nil
+}
+
+// assignedToInspection lets inspection agents act on the inspections they are assigned to
+func assignedToInspection(ctx context.Context, server *Server, principal *Principal, req interface{}) (bool, error) {
+	r, ok := req.(interface{ GetInspectionRequestId() int64 })
+	if !ok {
+		return false, nil
+	}
+
+	inspection, err := server.store.GetInspectionRequestByID(ctx, r.GetInspectionRequestId())
+	if err != nil {
+		return false, ownershipLookupError("inspection request", err)
+	}
+
+	return inspection.InspectionAgentID.Valid && inspection.InspectionAgentID.Int64 == principal.User.ID, nil
+}
+
+func ownershipLookupError(resource string, err error) error {
+	if errors.Is(err, db.ErrRecordNotFound) {
+		return status.Errorf(codes.NotFound, "%s not found", resource)
+	}
+	return status.Errorf(codes.Internal, "failed to get %s: %s", resource, err)
+}
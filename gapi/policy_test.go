@@ -0,0 +1,254 @@
+package gapi
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+	mockdb "github.com/r-scheele/sqr/internal/db/mock"
+	db "github.com/r-scheele/sqr/internal/db/sqlc"
+	"github.com/r-scheele/sqr/internal/pb"
+	"github.com/r-scheele/sqr/internal/token"
+	"github.com/r-scheele/sqr/internal/util"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestAccessPoliciesCoverAllRPCs(t *testing.T) {
+	service := reflect.TypeOf((*pb.SqrServer)(nil)).Elem()
+
+	for i := 0; i < service.NumMethod(); i++ {
+		name := service.Method(i).Name
+		if strings.HasPrefix(name, "mustEmbed") {
+			continue
+		}
+
+		policy, ok := accessPolicies[rpcMethodPrefix+name]
+		require.True(t, ok, "missing access policy for %s", name)
+		require.True(t, policy.public || len(policy.roles) > 0, "access policy for %s allows no roles", name)
+	}
+}
+
+func TestRPCMethodForRequest(t *testing.T) {
+	require.Equal(t, "/pb.Sqr/GetTenantProfile", rpcMethodForRequest(&pb.GetTenantProfileRequest{}))
+	require.Equal(t, "/pb.Sqr/UnlockUserAccount", rpcMethodForRequest(&pb.UnlockUserAccountRequest{}))
+}
+
+func TestAuthorizationInterceptor(t *testing.T) {
+	tenant, _ := randomUser(t, util.TenantRole)
+	tenant.ID = util.RandomInt(1, 1000)
+	admin, _ := randomUser(t, util.AdminRole)
+	admin.ID = tenant.ID + 1
+
+	testCases := []struct {
+		name          string
+		method        string
+		req           interface{}
+		caller        *db.User
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(t *testing.T, principal *Principal, called bool, err error)
+	}{
+		{
+			name:       "Public",
+			method:     "/pb.Sqr/LoginUser",
+			req:        &pb.LoginUserRequest{},
+			buildStubs: func(store *mockdb.MockStore) {},
+			checkResponse: func(t *testing.T, principal *Principal, called bool, err error) {
+				require.NoError(t, err)
+				require.True(t, called)
+				require.Nil(t, principal)
+			},
+		},
+		{
+			name:       "UnknownMethod",
+			method:     "/pb.Sqr/DoesNotExist",
+			req:        &pb.LoginUserRequest{},
+			caller:     &admin,
+			buildStubs: func(store *mockdb.MockStore) {},
+			checkResponse: func(t *testing.T, principal *Principal, called bool, err error) {
+				require.Error(t, err)
+				require.Equal(t, codes.PermissionDenied, status.Code(err))
+				require.False(t, called)
+			},
+		},
+		{
+			name:       "NoAuthorization",
+			method:     "/pb.Sqr/ListMySessions",
+			req:        &pb.ListMySessionsRequest{},
+			buildStubs: func(store *mockdb.MockStore) {},
+			checkResponse: func(t *testing.T, principal *Principal, called bool, err error) {
+				require.Error(t, err)
+				require.Equal(t, codes.Unauthenticated, status.Code(err))
+				require.False(t, called)
+			},
+		},
+		{
+			name:       "WrongRole",
+			method:     "/pb.Sqr/UnlockUserAccount",
+			req:        &pb.UnlockUserAccountRequest{UserId: admin.ID},
+			caller:     &tenant,
+			buildStubs: func(store *mockdb.MockStore) {},
+			checkResponse: func(t *testing.T, principal *Principal, called bool, err error) {
+				require.Error(t, err)
+				require.Equal(t, codes.Unauthenticated, status.Code(err))
+				require.False(t, called)
+			},
+		},
+		{
+			name:   "Owner",
+			method: "/pb.Sqr/GetTenantProfile",
+			req:    &pb.GetTenantProfileRequest{UserId: tenant.ID},
+			caller: &tenant,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetUserByID(gomock.Any(), gomock.Eq(tenant.ID)).
+					Times(1).
+					Return(tenant, nil)
+			},
+			checkResponse: func(t *testing.T, principal *Principal, called bool, err error) {
+				require.NoError(t, err)
+				require.True(t, called)
+				require.Equal(t, tenant.ID, principal.User.ID)
+			},
+		},
+		{
+			name:   "NotOwner",
+			method: "/pb.Sqr/GetTenantProfile",
+			req:    &pb.GetTenantProfileRequest{UserId: tenant.ID + 100},
+			caller: &tenant,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetUserByID(gomock.Any(), gomock.Eq(tenant.ID)).
+					Times(1).
+					Return(tenant, nil)
+			},
+			checkResponse: func(t *testing.T, principal *Principal, called bool, err error) {
+				require.Error(t, err)
+				require.Equal(t, codes.PermissionDenied, status.Code(err))
+				require.False(t, called)
+			},
+		},
+		{
+			name:   "AdminOverride",
+			method: "/pb.Sqr/GetTenantProfile",
+			req:    &pb.GetTenantProfileRequest{UserId: tenant.ID},
+			caller: &admin,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetUserByID(gomock.Any(), gomock.Eq(admin.ID)).
+					Times(1).
+					Return(admin, nil)
+			},
+			checkResponse: func(t *testing.T, principal *Principal, called bool, err error) {
+				require.NoError(t, err)
+				require.True(t, called)
+				require.Equal(t, admin.ID, principal.User.ID)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			ctx := context.Background()
+			if tc.caller != nil {
+				ctx = newContextWithBearerToken(t, server.tokenMaker, *tc.caller, uuid.New(), time.Minute, token.TokenTypeAccessToken)
+			}
+
+			var principal *Principal
+			called := false
+			handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+				called = true
+				principal, _ = ctx.Value(principalContextKey{}).(*Principal)
+				return nil, nil
+			}
+
+			_, err := server.AuthorizationInterceptor(ctx, tc.req, &grpc.UnaryServerInfo{FullMethod: tc.method}, handler)
+			tc.checkResponse(t, principal, called, err)
+		})
+	}
+}
+
+type testPropertyRequest struct{ propertyID int64 }
+
+func (r testPropertyRequest) GetPropertyId() int64 { return r.propertyID }
+
+type testApplicationRequest struct{ applicationID int64 }
+
+func (r testApplicationRequest) GetApplicationId() int64 { return r.applicationID }
+
+type testInspectionRequest struct{ inspectionRequestID int64 }
+
+func (r testInspectionRequest) GetInspectionRequestId() int64 { return r.inspectionRequestID }
+
+func TestOwnershipPredicates(t *testing.T) {
+	user, _ := randomUser(t, util.LandlordRole)
+	user.ID = util.RandomInt(1, 1000)
+	principal := &Principal{User: user}
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	store := mockdb.NewMockStore(ctrl)
+	server := newTestServer(t, store)
+	ctx := context.Background()
+
+	store.EXPECT().GetPropertyByID(gomock.Any(), int64(1)).Return(db.Property{ID: 1, LandlordID: user.ID}, nil)
+	store.EXPECT().GetPropertyByID(gomock.Any(), int64(2)).Return(db.Property{ID: 2, LandlordID: user.ID + 1}, nil)
+	store.EXPECT().GetPropertyByID(gomock.Any(), int64(3)).Return(db.Property{}, db.ErrRecordNotFound)
+
+	owns, err := ownsProperty(ctx, server, principal, testPropertyRequest{1})
+	require.NoError(t, err)
+	require.True(t, owns)
+
+	owns, err = ownsProperty(ctx, server, principal, testPropertyRequest{2})
+	require.NoError(t, err)
+	require.False(t, owns)
+
+	_, err = ownsProperty(ctx, server, principal, testPropertyRequest{3})
+	require.Equal(t, codes.NotFound, status.Code(err))
+
+	store.EXPECT().GetRentalApplicationByID(gomock.Any(), int64(1)).Return(db.RentalApplication{ID: 1, TenantID: user.ID}, nil)
+	store.EXPECT().GetRentalApplicationByID(gomock.Any(), int64(2)).Return(db.RentalApplication{ID: 2, TenantID: user.ID + 1}, nil)
+
+	owns, err = ownsRentalApplication(ctx, server, principal, testApplicationRequest{1})
+	require.NoError(t, err)
+	require.True(t, owns)
+
+	owns, err = ownsRentalApplication(ctx, server, principal, testApplicationRequest{2})
+	require.NoError(t, err)
+	require.False(t, owns)
+
+	store.EXPECT().GetInspectionRequestByID(gomock.Any(), int64(1)).
+		Return(db.InspectionRequest{ID: 1, InspectionAgentID: pgtype.Int8{Int64: user.ID, Valid: true}}, nil)
+	store.EXPECT().GetInspectionRequestByID(gomock.Any(), int64(2)).
+		Return(db.InspectionRequest{ID: 2}, nil)
+
+	owns, err = assignedToInspection(ctx, server, principal, testInspectionRequest{1})
+	require.NoError(t, err)
+	require.True(t, owns)
+
+	owns, err = assignedToInspection(ctx, server, principal, testInspectionRequest{2})
+	require.NoError(t, err)
+	require.False(t, owns)
+
+	// A request without the id the predicate needs is never owned
+	owns, err = ownsProperty(ctx, server, principal, &pb.LoginUserRequest{})
+	require.NoError(t, err)
+	require.False(t, owns)
+}
@@ -0,0 +1,237 @@
+package gapi
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/hibiken/asynq"
+	"github.com/jackc/pgx/v5/pgtype"
+	db "github.com/r-scheele/sqr/internal/db/sqlc"
+	"github.com/r-scheele/sqr/internal/pb"
+	"github.com/r-scheele/sqr/internal/val"
+	"github.com/r-scheele/sqr/internal/worker"
+	"github.com/rs/zerolog/log"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	defaultAccountDeletionGracePeriod = 30 * 24 * time.Hour
+	// maxAccountExportsPerDay bounds how often a user can have their data assembled
+	maxAccountExportsPerDay = 3
+	// accountExportStaleAfter is when a pending export is given up on and a new one may be requested
+	accountExportStaleAfter = time.Hour
+	// accountExportPurgeMargin leaves room for the export to finish before its purge runs
+	accountExportPurgeMargin = time.Hour
+	accountExportContentType = "application/zip"
+)
+
+func (server *Server) accountDeletionGracePeriod() time.Duration {
+	if server.config.AccountDeletionGracePeriod > 0 {
+		return server.config.AccountDeletionGracePeriod
+	}
+	return defaultAccountDeletionGracePeriod
+}
+
+func (server *Server) RequestAccountExport(ctx context.Context, req *pb.RequestAccountExportRequest) (*pb.RequestAccountExportResponse, error) {
+	principal, err := server.authorizeRequest(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	user := principal.User
+
+	pending, err := server.store.GetPendingAccountExport(ctx, user.ID)
+	switch {
+	case err == nil:
+		if time.Since(pending.CreatedAt) < accountExportStaleAfter {
+			return &pb.RequestAccountExportResponse{
+				Export:  convertAccountExport(pending),
+				Message: "Your data export is already being prepared, we will email you once it is ready",
+			}, nil
+		}
+
+		err = server.store.FailAccountExport(ctx, pending.ID)
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "failed to update stale account export: %s", err)
+		}
+	case !errors.Is(err, db.ErrRecordNotFound):
+		return nil, status.Errorf(codes.Internal, "failed to get pending account export: %s", err)
+	}
+
+	recent, err := server.store.CountRecentAccountExports(ctx, db.CountRecentAccountExportsParams{
+		UserID:    user.ID,
+		CreatedAt: time.Now().Add(-24 * time.Hour),
+	})
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to count account exports: %s", err)
+	}
+	if recent >= maxAccountExportsPerDay {
+		return nil, status.Errorf(codes.ResourceExhausted, "you can request at most %d data exports a day", maxAccountExportsPerDay)
+	}
+
+	txResult, err := server.store.CreateAccountExportTx(ctx, db.CreateAccountExportTxParams{
+		UserID: user.ID,
+		AfterCreate: func(export db.AccountExport) error {
+			err := server.taskDistributor.DistributeTaskGenerateAccountExport(ctx, &worker.PayloadGenerateAccountExport{
+				UserID:   user.ID,
+				ExportID: export.ID,
+			}, asynq.MaxRetry(10), asynq.ProcessIn(10*time.Second), asynq.Queue(worker.QueueDefault))
+			if err != nil {
+				return err
+			}
+
+			// Scheduled now, since nothing else would remove the content once the link expires
+			return server.taskDistributor.DistributeTaskPurgeAccountExport(ctx, &worker.PayloadPurgeAccountExport{
+				ExportID: export.ID,
+			}, asynq.MaxRetry(10), asynq.ProcessIn(worker.AccountExportTTL(server.config)+accountExportPurgeMargin),
+				asynq.Queue(worker.QueueDefault))
+		},
+	})
+	if err != nil {
+		log.Error().Err(err).Int64("user_id", user.ID).Msg("failed to request account export")
+		return nil, status.Errorf(codes.Internal, "failed to request account export")
+	}
+
+	return &pb.RequestAccountExportResponse{
+		Export:  convertAccountExport(txResult.Export),
+		Message: "Your data export is being prepared, we will email you a download link once it is ready",
+	}, nil
+}
+
+func (server *Server) DownloadAccountExport(ctx context.Context, req *pb.DownloadAccountExportRequest) (*pb.DownloadAccountExportResponse, error) {
+	violations := validateDownloadAccountExportRequest(req)
+	if violations != nil {
+		return nil, invalidArgumentError(violations)
+	}
+
+	_, err := server.authorizeRequest(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	export, err := server.store.GetAccountExport(ctx, req.GetExportId())
+	if err != nil {
+		if errors.Is(err, db.ErrRecordNotFound) {
+			return nil, status.Errorf(codes.NotFound, "account export not found")
+		}
+		return nil, status.Errorf(codes.Internal, "failed to get account export: %s", err)
+	}
+
+	if export.Status != db.AccountRequestStatusEnumCompleted {
+		return nil, status.Errorf(codes.FailedPrecondition, "account export is %s", export.Status)
+	}
+	if export.Content == nil || (export.ExpiresAt.Valid && time.Now().After(export.ExpiresAt.Time)) {
+		return nil, status.Errorf(codes.FailedPrecondition, "account export has expired, request a new one")
+	}
+
+	return &pb.DownloadAccountExportResponse{
+		Export:      convertAccountExport(export),
+		FileName:    export.FileName.String,
+		ContentType: accountExportContentType,
+		Content:     export.Content,
+	}, nil
+}
+
+func (server *Server) RequestAccountDeletion(ctx context.Context, req *pb.RequestAccountDeletionRequest) (*pb.RequestAccountDeletionResponse, error) {
+	violations := validateRequestAccountDeletionRequest(req)
+	if violations != nil {
+		return nil, invalidArgumentError(violations)
+	}
+
+	principal, err := server.authorizeRequest(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	user := principal.User
+
+	_, err = server.store.GetPendingAccountDeletion(ctx, user.ID)
+	if err == nil {
+		return nil, status.Errorf(codes.AlreadyExists, "account deletion is already scheduled")
+	}
+	if !errors.Is(err, db.ErrRecordNotFound) {
+		return nil, status.Errorf(codes.Internal, "failed to get pending account deletion: %s", err)
+	}
+
+	gracePeriod := server.accountDeletionGracePeriod()
+	txResult, err := server.store.ScheduleAccountDeletionTx(ctx, db.ScheduleAccountDeletionTxParams{
+		UserID:       user.ID,
+		Reason:       pgtype.Text{String: req.GetReason(), Valid: req.GetReason() != ""},
+		ScheduledFor: time.Now().Add(gracePeriod),
+		AfterCreate: func(deletion db.AccountDeletion) error {
+			// The periodic sweep deletes the account if this task is lost or runs out of retries
+			err := server.taskDistributor.DistributeTaskDeleteAccount(ctx, &worker.PayloadDeleteAccount{
+				UserID:     user.ID,
+				DeletionID: deletion.ID,
+			}, asynq.MaxRetry(10), asynq.ProcessIn(gracePeriod), asynq.Queue(worker.QueueDefault))
+			if err != nil {
+				return err
+			}
+
+			return server.taskDistributor.DistributeTaskSendAccountDeletionEmail(ctx, &worker.PayloadSendAccountDeletionEmail{
+				Username:     user.FirstName + " " + user.LastName,
+				Email:        user.Email,
+				ScheduledFor: deletion.ScheduledFor,
+			}, asynq.MaxRetry(10), asynq.ProcessIn(10*time.Second), asynq.Queue(worker.QueueCritical))
+		},
+	})
+	if err != nil {
+		if db.ErrorCode(err) == db.UniqueViolation {
+			return nil, status.Errorf(codes.AlreadyExists, "account deletion is already scheduled")
+		}
+		log.Error().Err(err).Int64("user_id", user.ID).Msg("failed to schedule account deletion")
+		return nil, status.Errorf(codes.Internal, "failed to schedule account deletion")
+	}
+
+	return &pb.RequestAccountDeletionResponse{
+		Deletion: convertAccountDeletion(txResult.Deletion),
+		Message:  "Your account will be deleted at the end of the grace period, you can cancel until then",
+	}, nil
+}
+
+func (server *Server) CancelAccountDeletion(ctx context.Context, req *pb.CancelAccountDeletionRequest) (*pb.CancelAccountDeletionResponse, error) {
+	principal, err := server.authorizeRequest(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	pending, err := server.store.GetPendingAccountDeletion(ctx, principal.User.ID)
+	if err != nil {
+		if errors.Is(err, db.ErrRecordNotFound) {
+			return nil, status.Errorf(codes.NotFound, "no account deletion is scheduled")
+		}
+		return nil, status.Errorf(codes.Internal, "failed to get pending account deletion: %s", err)
+	}
+
+	// The scheduled task finds the deletion cancelled and does nothing
+	deletion, err := server.store.CancelAccountDeletion(ctx, pending.ID)
+	if err != nil {
+		if errors.Is(err, db.ErrRecordNotFound) {
+			return nil, status.Errorf(codes.FailedPrecondition, "account deletion is no longer pending")
+		}
+		return nil, status.Errorf(codes.Internal, "failed to cancel account deletion: %s", err)
+	}
+
+	return &pb.CancelAccountDeletionResponse{
+		Deletion: convertAccountDeletion(deletion),
+	}, nil
+}
+
+func validateDownloadAccountExportRequest(req *pb.DownloadAccountExportRequest) (violations []*errdetails.BadRequest_FieldViolation) {
+	if err := val.ValidateID(req.GetExportId()); err != nil {
+		violations = append(violations, fieldViolation("export_id", err))
+	}
+
+	return violations
+}
+
+func validateRequestAccountDeletionRequest(req *pb.RequestAccountDeletionRequest) (violations []*errdetails.BadRequest_FieldViolation) {
+	if req.GetReason() != "" {
+		if err := val.ValidateString(req.GetReason(), 1, 500); err != nil {
+			violations = append(violations, fieldViolation("reason", err))
+		}
+	}
+
+	return violations
+}
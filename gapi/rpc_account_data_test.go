@@ -0,0 +1,484 @@
+package gapi
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/hibiken/asynq"
+	"github.com/jackc/pgx/v5/pgtype"
+	mockdb "github.com/r-scheele/sqr/internal/db/mock"
+	db "github.com/r-scheele/sqr/internal/db/sqlc"
+	"github.com/r-scheele/sqr/internal/pb"
+	"github.com/r-scheele/sqr/internal/token"
+	"github.com/r-scheele/sqr/internal/util"
+	"github.com/r-scheele/sqr/internal/worker"
+	mockwk "github.com/r-scheele/sqr/internal/worker/mock"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func randomAccountExport(userID int64, exportStatus db.AccountRequestStatusEnum, createdAt time.Time) db.AccountExport {
+	export := db.AccountExport{
+		ID:        util.RandomInt(1, 1000),
+		UserID:    userID,
+		Status:    exportStatus,
+		CreatedAt: createdAt,
+	}
+	if exportStatus == db.AccountRequestStatusEnumCompleted {
+		export.FileName = pgtype.Text{String: "sqr-export.zip", Valid: true}
+		export.Content = []byte(util.RandomString(32))
+		export.ExpiresAt = pgtype.Timestamptz{Time: time.Now().Add(time.Hour), Valid: true}
+		export.CompletedAt = pgtype.Timestamptz{Time: createdAt, Valid: true}
+	}
+	return export
+}
+
+func randomAccountDeletion(userID int64, deletionStatus db.AccountRequestStatusEnum) db.AccountDeletion {
+	return db.AccountDeletion{
+		ID:           util.RandomInt(1, 1000),
+		UserID:       userID,
+		Status:       deletionStatus,
+		ScheduledFor: time.Now().Add(defaultAccountDeletionGracePeriod),
+		CreatedAt:    time.Now(),
+	}
+}
+
+func TestRequestAccountExportAPI(t *testing.T) {
+	user, _ := randomUser(t, util.TenantRole)
+	user.ID = util.RandomInt(1, 1000)
+
+	testCases := []struct {
+		name          string
+		buildStubs    func(t *testing.T, store *mockdb.MockStore, taskDistributor *mockwk.MockTaskDistributor)
+		checkResponse func(t *testing.T, res *pb.RequestAccountExportResponse, err error)
+	}{
+		{
+			name: "OK",
+			buildStubs: func(t *testing.T, store *mockdb.MockStore, taskDistributor *mockwk.MockTaskDistributor) {
+				store.EXPECT().
+					GetUserByID(gomock.Any(), gomock.Eq(user.ID)).
+					Times(1).
+					Return(user, nil)
+
+				store.EXPECT().
+					GetPendingAccountExport(gomock.Any(), gomock.Eq(user.ID)).
+					Times(1).
+					Return(db.AccountExport{}, db.ErrRecordNotFound)
+
+				store.EXPECT().
+					CountRecentAccountExports(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(int64(1), nil)
+
+				export := randomAccountExport(user.ID, db.AccountRequestStatusEnumPending, time.Now())
+				store.EXPECT().
+					CreateAccountExportTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					DoAndReturn(func(_ context.Context, arg db.CreateAccountExportTxParams) (db.CreateAccountExportTxResult, error) {
+						require.Equal(t, user.ID, arg.UserID)
+						err := arg.AfterCreate(export)
+						return db.CreateAccountExportTxResult{Export: export}, err
+					})
+
+				taskDistributor.EXPECT().
+					DistributeTaskGenerateAccountExport(gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(1).
+					DoAndReturn(func(_ context.Context, payload *worker.PayloadGenerateAccountExport, _ ...asynq.Option) error {
+						require.Equal(t, user.ID, payload.UserID)
+						require.Equal(t, export.ID, payload.ExportID)
+						return nil
+					})
+
+				taskDistributor.EXPECT().
+					DistributeTaskPurgeAccountExport(gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(1).
+					DoAndReturn(func(_ context.Context, payload *worker.PayloadPurgeAccountExport, _ ...asynq.Option) error {
+						require.Equal(t, export.ID, payload.ExportID)
+						return nil
+					})
+			},
+			checkResponse: func(t *testing.T, res *pb.RequestAccountExportResponse, err error) {
+				require.NoError(t, err)
+				require.Equal(t, string(db.AccountRequestStatusEnumPending), res.GetExport().GetStatus())
+			},
+		},
+		{
+			name: "AlreadyPending",
+			buildStubs: func(t *testing.T, store *mockdb.MockStore, taskDistributor *mockwk.MockTaskDistributor) {
+				store.EXPECT().
+					GetUserByID(gomock.Any(), gomock.Eq(user.ID)).
+					Times(1).
+					Return(user, nil)
+
+				store.EXPECT().
+					GetPendingAccountExport(gomock.Any(), gomock.Eq(user.ID)).
+					Times(1).
+					Return(randomAccountExport(user.ID, db.AccountRequestStatusEnumPending, time.Now()), nil)
+
+				store.EXPECT().
+					CreateAccountExportTx(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, res *pb.RequestAccountExportResponse, err error) {
+				require.NoError(t, err)
+				require.Equal(t, string(db.AccountRequestStatusEnumPending), res.GetExport().GetStatus())
+			},
+		},
+		{
+			name: "StalePending",
+			buildStubs: func(t *testing.T, store *mockdb.MockStore, taskDistributor *mockwk.MockTaskDistributor) {
+				store.EXPECT().
+					GetUserByID(gomock.Any(), gomock.Eq(user.ID)).
+					Times(1).
+					Return(user, nil)
+
+				stale := randomAccountExport(user.ID, db.AccountRequestStatusEnumPending, time.Now().Add(-2*accountExportStaleAfter))
+				store.EXPECT().
+					GetPendingAccountExport(gomock.Any(), gomock.Eq(user.ID)).
+					Times(1).
+					Return(stale, nil)
+
+				store.EXPECT().
+					FailAccountExport(gomock.Any(), gomock.Eq(stale.ID)).
+					Times(1).
+					Return(nil)
+
+				store.EXPECT().
+					CountRecentAccountExports(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(int64(1), nil)
+
+				store.EXPECT().
+					CreateAccountExportTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.CreateAccountExportTxResult{
+						Export: randomAccountExport(user.ID, db.AccountRequestStatusEnumPending, time.Now()),
+					}, nil)
+			},
+			checkResponse: func(t *testing.T, res *pb.RequestAccountExportResponse, err error) {
+				require.NoError(t, err)
+			},
+		},
+		{
+			name: "TooManyRequests",
+			buildStubs: func(t *testing.T, store *mockdb.MockStore, taskDistributor *mockwk.MockTaskDistributor) {
+				store.EXPECT().
+					GetUserByID(gomock.Any(), gomock.Eq(user.ID)).
+					Times(1).
+					Return(user, nil)
+
+				store.EXPECT().
+					GetPendingAccountExport(gomock.Any(), gomock.Eq(user.ID)).
+					Times(1).
+					Return(db.AccountExport{}, db.ErrRecordNotFound)
+
+				store.EXPECT().
+					CountRecentAccountExports(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(int64(maxAccountExportsPerDay), nil)
+
+				store.EXPECT().
+					CreateAccountExportTx(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, res *pb.RequestAccountExportResponse, err error) {
+				require.Error(t, err)
+				require.Equal(t, codes.ResourceExhausted, status.Code(err))
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			storeCtrl := gomock.NewController(t)
+			defer storeCtrl.Finish()
+			store := mockdb.NewMockStore(storeCtrl)
+
+			taskCtrl := gomock.NewController(t)
+			defer taskCtrl.Finish()
+			taskDistributor := mockwk.NewMockTaskDistributor(taskCtrl)
+
+			tc.buildStubs(t, store, taskDistributor)
+			server := newTestServerWithTaskDistributor(t, store, taskDistributor)
+			ctx := newContextWithBearerToken(t, server.tokenMaker, user, uuid.New(), time.Minute, token.TokenTypeAccessToken)
+
+			res, err := server.RequestAccountExport(ctx, &pb.RequestAccountExportRequest{})
+			tc.checkResponse(t, res, err)
+		})
+	}
+}
+
+func TestDownloadAccountExportAPI(t *testing.T) {
+	user, _ := randomUser(t, util.TenantRole)
+	user.ID = util.RandomInt(1, 1000)
+
+	completed := randomAccountExport(user.ID, db.AccountRequestStatusEnumCompleted, time.Now())
+
+	expired := randomAccountExport(user.ID, db.AccountRequestStatusEnumCompleted, time.Now().Add(-48*time.Hour))
+	expired.ExpiresAt = pgtype.Timestamptz{Time: time.Now().Add(-time.Hour), Valid: true}
+
+	testCases := []struct {
+		name          string
+		export        db.AccountExport
+		checkResponse func(t *testing.T, res *pb.DownloadAccountExportResponse, err error)
+	}{
+		{
+			name:   "OK",
+			export: completed,
+			checkResponse: func(t *testing.T, res *pb.DownloadAccountExportResponse, err error) {
+				require.NoError(t, err)
+				require.Equal(t, completed.Content, res.GetContent())
+				require.Equal(t, accountExportContentType, res.GetContentType())
+				require.Equal(t, completed.FileName.String, res.GetFileName())
+			},
+		},
+		{
+			name:   "OtherUser",
+			export: randomAccountExport(user.ID+1, db.AccountRequestStatusEnumCompleted, time.Now()),
+			checkResponse: func(t *testing.T, res *pb.DownloadAccountExportResponse, err error) {
+				require.Error(t, err)
+				require.Equal(t, codes.PermissionDenied, status.Code(err))
+			},
+		},
+		{
+			name:   "NotReady",
+			export: randomAccountExport(user.ID, db.AccountRequestStatusEnumPending, time.Now()),
+			checkResponse: func(t *testing.T, res *pb.DownloadAccountExportResponse, err error) {
+				require.Error(t, err)
+				require.Equal(t, codes.FailedPrecondition, status.Code(err))
+			},
+		},
+		{
+			name:   "Expired",
+			export: expired,
+			checkResponse: func(t *testing.T, res *pb.DownloadAccountExportResponse, err error) {
+				require.Error(t, err)
+				require.Equal(t, codes.FailedPrecondition, status.Code(err))
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			store := mockdb.NewMockStore(ctrl)
+
+			store.EXPECT().
+				GetUserByID(gomock.Any(), gomock.Eq(user.ID)).
+				AnyTimes().
+				Return(user, nil)
+
+			store.EXPECT().
+				GetAccountExport(gomock.Any(), gomock.Eq(tc.export.ID)).
+				AnyTimes().
+				Return(tc.export, nil)
+
+			server := newTestServer(t, store)
+			ctx := newContextWithBearerToken(t, server.tokenMaker, user, uuid.New(), time.Minute, token.TokenTypeAccessToken)
+
+			res, err := server.DownloadAccountExport(ctx, &pb.DownloadAccountExportRequest{ExportId: tc.export.ID})
+			tc.checkResponse(t, res, err)
+		})
+	}
+}
+
+func TestRequestAccountDeletionAPI(t *testing.T) {
+	user, _ := randomUser(t, util.LandlordRole)
+	user.ID = util.RandomInt(1, 1000)
+
+	testCases := []struct {
+		name          string
+		reason        string
+		buildStubs    func(t *testing.T, store *mockdb.MockStore, taskDistributor *mockwk.MockTaskDistributor)
+		checkResponse func(t *testing.T, res *pb.RequestAccountDeletionResponse, err error)
+	}{
+		{
+			name:   "OK",
+			reason: "moving abroad",
+			buildStubs: func(t *testing.T, store *mockdb.MockStore, taskDistributor *mockwk.MockTaskDistributor) {
+				store.EXPECT().
+					GetUserByID(gomock.Any(), gomock.Eq(user.ID)).
+					Times(1).
+					Return(user, nil)
+
+				store.EXPECT().
+					GetPendingAccountDeletion(gomock.Any(), gomock.Eq(user.ID)).
+					Times(1).
+					Return(db.AccountDeletion{}, db.ErrRecordNotFound)
+
+				deletion := randomAccountDeletion(user.ID, db.AccountRequestStatusEnumPending)
+				store.EXPECT().
+					ScheduleAccountDeletionTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					DoAndReturn(func(_ context.Context, arg db.ScheduleAccountDeletionTxParams) (db.ScheduleAccountDeletionTxResult, error) {
+						require.Equal(t, user.ID, arg.UserID)
+						require.Equal(t, "moving abroad", arg.Reason.String)
+						require.WithinDuration(t, time.Now().Add(defaultAccountDeletionGracePeriod), arg.ScheduledFor, time.Minute)
+
+						err := arg.AfterCreate(deletion)
+						return db.ScheduleAccountDeletionTxResult{Deletion: deletion}, err
+					})
+
+				taskDistributor.EXPECT().
+					DistributeTaskDeleteAccount(gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(1).
+					DoAndReturn(func(_ context.Context, payload *worker.PayloadDeleteAccount, _ ...asynq.Option) error {
+						require.Equal(t, deletion.ID, payload.DeletionID)
+						return nil
+					})
+
+				taskDistributor.EXPECT().
+					DistributeTaskSendAccountDeletionEmail(gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(1).
+					DoAndReturn(func(_ context.Context, payload *worker.PayloadSendAccountDeletionEmail, _ ...asynq.Option) error {
+						require.Equal(t, user.Email, payload.Email)
+						return nil
+					})
+			},
+			checkResponse: func(t *testing.T, res *pb.RequestAccountDeletionResponse, err error) {
+				require.NoError(t, err)
+				require.Equal(t, string(db.AccountRequestStatusEnumPending), res.GetDeletion().GetStatus())
+			},
+		},
+		{
+			name: "AlreadyScheduled",
+			buildStubs: func(t *testing.T, store *mockdb.MockStore, taskDistributor *mockwk.MockTaskDistributor) {
+				store.EXPECT().
+					GetUserByID(gomock.Any(), gomock.Eq(user.ID)).
+					Times(1).
+					Return(user, nil)
+
+				store.EXPECT().
+					GetPendingAccountDeletion(gomock.Any(), gomock.Eq(user.ID)).
+					Times(1).
+					Return(randomAccountDeletion(user.ID, db.AccountRequestStatusEnumPending), nil)
+
+				store.EXPECT().
+					ScheduleAccountDeletionTx(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, res *pb.RequestAccountDeletionResponse, err error) {
+				require.Error(t, err)
+				require.Equal(t, codes.AlreadyExists, status.Code(err))
+			},
+		},
+		{
+			name:   "ReasonTooLong",
+			reason: strings.Repeat("a", 501),
+			buildStubs: func(t *testing.T, store *mockdb.MockStore, taskDistributor *mockwk.MockTaskDistributor) {
+				store.EXPECT().
+					ScheduleAccountDeletionTx(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, res *pb.RequestAccountDeletionResponse, err error) {
+				require.Error(t, err)
+				require.Equal(t, codes.InvalidArgument, status.Code(err))
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			storeCtrl := gomock.NewController(t)
+			defer storeCtrl.Finish()
+			store := mockdb.NewMockStore(storeCtrl)
+
+			taskCtrl := gomock.NewController(t)
+			defer taskCtrl.Finish()
+			taskDistributor := mockwk.NewMockTaskDistributor(taskCtrl)
+
+			tc.buildStubs(t, store, taskDistributor)
+			server := newTestServerWithTaskDistributor(t, store, taskDistributor)
+			ctx := newContextWithBearerToken(t, server.tokenMaker, user, uuid.New(), time.Minute, token.TokenTypeAccessToken)
+
+			res, err := server.RequestAccountDeletion(ctx, &pb.RequestAccountDeletionRequest{Reason: tc.reason})
+			tc.checkResponse(t, res, err)
+		})
+	}
+}
+
+func TestCancelAccountDeletionAPI(t *testing.T) {
+	user, _ := randomUser(t, util.TenantRole)
+	user.ID = util.RandomInt(1, 1000)
+
+	testCases := []struct {
+		name          string
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(t *testing.T, res *pb.CancelAccountDeletionResponse, err error)
+	}{
+		{
+			name: "OK",
+			buildStubs: func(store *mockdb.MockStore) {
+				pending := randomAccountDeletion(user.ID, db.AccountRequestStatusEnumPending)
+				store.EXPECT().
+					GetPendingAccountDeletion(gomock.Any(), gomock.Eq(user.ID)).
+					Times(1).
+					Return(pending, nil)
+
+				cancelled := pending
+				cancelled.Status = db.AccountRequestStatusEnumCancelled
+				cancelled.CancelledAt = pgtype.Timestamptz{Time: time.Now(), Valid: true}
+				store.EXPECT().
+					CancelAccountDeletion(gomock.Any(), gomock.Eq(pending.ID)).
+					Times(1).
+					Return(cancelled, nil)
+			},
+			checkResponse: func(t *testing.T, res *pb.CancelAccountDeletionResponse, err error) {
+				require.NoError(t, err)
+				require.Equal(t, string(db.AccountRequestStatusEnumCancelled), res.GetDeletion().GetStatus())
+				require.NotNil(t, res.GetDeletion().GetCancelledAt())
+			},
+		},
+		{
+			name: "NotScheduled",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetPendingAccountDeletion(gomock.Any(), gomock.Eq(user.ID)).
+					Times(1).
+					Return(db.AccountDeletion{}, db.ErrRecordNotFound)
+
+				store.EXPECT().
+					CancelAccountDeletion(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, res *pb.CancelAccountDeletionResponse, err error) {
+				require.Error(t, err)
+				require.Equal(t, codes.NotFound, status.Code(err))
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			store := mockdb.NewMockStore(ctrl)
+
+			store.EXPECT().
+				GetUserByID(gomock.Any(), gomock.Eq(user.ID)).
+				Times(1).
+				Return(user, nil)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			ctx := newContextWithBearerToken(t, server.tokenMaker, user, uuid.New(), time.Minute, token.TokenTypeAccessToken)
+
+			res, err := server.CancelAccountDeletion(ctx, &pb.CancelAccountDeletionRequest{})
+			tc.checkResponse(t, res, err)
+		})
+	}
+}
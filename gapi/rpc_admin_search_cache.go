@@ -0,0 +1,92 @@
+package gapi
+
+import (
+	"context"
+	"time"
+
+	db "github.com/r-scheele/sqr/internal/db/sqlc"
+	"github.com/r-scheele/sqr/internal/pb"
+	"github.com/r-scheele/sqr/internal/val"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+const (
+	// defaultSearchStatsDays is the window of popular searches when GetSearchCacheStats is called without days
+	defaultSearchStatsDays = 7
+	// defaultPopularSearches is how many popular searches GetSearchCacheStats returns without page_size
+	defaultPopularSearches = 10
+)
+
+// GetSearchCacheStats reports the size of the property search cache and the searches run most over the last days
+func (server *Server) GetSearchCacheStats(ctx context.Context, req *pb.GetSearchCacheStatsRequest) (*pb.GetSearchCacheStatsResponse, error) {
+	violations := validateGetSearchCacheStatsRequest(req)
+	if violations != nil {
+		return nil, invalidArgumentError(violations)
+	}
+
+	_, err := server.authorizeRequest(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	days := req.GetDays()
+	if days == 0 {
+		days = defaultSearchStatsDays
+	}
+	pageSize := req.GetPageSize()
+	if pageSize == 0 {
+		pageSize = defaultPopularSearches
+	}
+
+	stats, err := server.store.GetCacheStatistics(ctx)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to get search cache statistics: %s", err)
+	}
+
+	searches, err := server.store.GetPopularSearchCaches(ctx, db.GetPopularSearchCachesParams{
+		LastSearchedAt: time.Now().AddDate(0, 0, -int(days)),
+		Limit:          pageSize,
+		Offset:         0,
+	})
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to get popular searches: %s", err)
+	}
+
+	rsp := &pb.GetSearchCacheStatsResponse{
+		TotalEntries:       stats.TotalEntries,
+		ActiveEntries:      stats.ActiveEntries,
+		ExpiredEntries:     stats.ExpiredEntries,
+		TotalSearches:      stats.TotalSearches,
+		AverageResultCount: stats.AverageResultCount,
+		PopularSearches:    make([]*pb.PopularSearch, 0, len(searches)),
+	}
+	for _, search := range searches {
+		rsp.PopularSearches = append(rsp.PopularSearches, &pb.PopularSearch{
+			Params:         search.SearchParams.String,
+			SearchCount:    search.SearchCount,
+			CachedPages:    search.CachedPages,
+			LastSearchedAt: timestamppb.New(search.LastSearchedAt),
+		})
+	}
+
+	return rsp, nil
+}
+
+func validateGetSearchCacheStatsRequest(req *pb.GetSearchCacheStatsRequest) (violations []*errdetails.BadRequest_FieldViolation) {
+	if req.GetDays() != 0 {
+		if err := val.ValidateSearchStatsDays(req.GetDays()); err != nil {
+			violations = append(violations, fieldViolation("days", err))
+		}
+	}
+
+	if req.GetPageSize() != 0 {
+		if err := val.ValidatePageSize(req.GetPageSize()); err != nil {
+			violations = append(violations, fieldViolation("page_size", err))
+		}
+	}
+
+	return violations
+}
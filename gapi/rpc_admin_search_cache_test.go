@@ -0,0 +1,129 @@
+package gapi
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+	mockdb "github.com/r-scheele/sqr/internal/db/mock"
+	db "github.com/r-scheele/sqr/internal/db/sqlc"
+	"github.com/r-scheele/sqr/internal/pb"
+	"github.com/r-scheele/sqr/internal/token"
+	"github.com/r-scheele/sqr/internal/util"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestGetSearchCacheStatsAPI(t *testing.T) {
+	admin, _ := randomUser(t, util.AdminRole)
+	admin.ID = util.RandomInt(1, 1000)
+	tenant, _ := randomUser(t, util.TenantRole)
+	tenant.ID = admin.ID + 1
+
+	stats := db.GetCacheStatisticsRow{
+		TotalEntries:       40,
+		ActiveEntries:      12,
+		ExpiredEntries:     28,
+		AverageResultCount: 8.5,
+		MaxResultCount:     21,
+		TotalSearches:      310,
+	}
+	lastSearchedAt := time.Now().Add(-time.Hour)
+
+	testCases := []struct {
+		name          string
+		caller        db.User
+		req           *pb.GetSearchCacheStatsRequest
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(t *testing.T, res *pb.GetSearchCacheStatsResponse, err error)
+	}{
+		{
+			name:   "Defaults",
+			caller: admin,
+			req:    &pb.GetSearchCacheStatsRequest{},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetUserByID(gomock.Any(), gomock.Eq(admin.ID)).
+					Times(1).
+					Return(admin, nil)
+
+				store.EXPECT().
+					GetCacheStatistics(gomock.Any()).
+					Times(1).
+					Return(stats, nil)
+
+				store.EXPECT().
+					GetPopularSearchCaches(gomock.Any(), gomock.Any()).
+					Times(1).
+					DoAndReturn(func(_ context.Context, arg db.GetPopularSearchCachesParams) ([]db.GetPopularSearchCachesRow, error) {
+						require.WithinDuration(t, time.Now().AddDate(0, 0, -defaultSearchStatsDays), arg.LastSearchedAt, time.Minute)
+						require.Equal(t, int32(defaultPopularSearches), arg.Limit)
+						return []db.GetPopularSearchCachesRow{{
+							SearchParams:   pgtype.Text{String: `{"city":"lekki","sort_by":"newest"}`, Valid: true},
+							SearchCount:    57,
+							CachedPages:    3,
+							LastSearchedAt: lastSearchedAt,
+						}}, nil
+					})
+			},
+			checkResponse: func(t *testing.T, res *pb.GetSearchCacheStatsResponse, err error) {
+				require.NoError(t, err)
+				require.Equal(t, int64(12), res.GetActiveEntries())
+				require.Equal(t, int64(310), res.GetTotalSearches())
+				require.Len(t, res.GetPopularSearches(), 1)
+				require.Equal(t, `{"city":"lekki","sort_by":"newest"}`, res.GetPopularSearches()[0].GetParams())
+				require.Equal(t, int64(57), res.GetPopularSearches()[0].GetSearchCount())
+				require.WithinDuration(t, lastSearchedAt, res.GetPopularSearches()[0].GetLastSearchedAt().AsTime(), time.Second)
+			},
+		},
+		{
+			name:   "DaysBeyondRetention",
+			caller: admin,
+			req:    &pb.GetSearchCacheStatsRequest{Days: 90},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetPopularSearchCaches(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, res *pb.GetSearchCacheStatsResponse, err error) {
+				requireFieldViolation(t, err, "days")
+			},
+		},
+		{
+			name:   "NotAdmin",
+			caller: tenant,
+			req:    &pb.GetSearchCacheStatsRequest{},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetCacheStatistics(gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, res *pb.GetSearchCacheStatsResponse, err error) {
+				require.Error(t, err)
+				require.Equal(t, codes.Unauthenticated, status.Code(err))
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			ctx := newContextWithBearerToken(t, server.tokenMaker, tc.caller, uuid.New(), time.Minute, token.TokenTypeAccessToken)
+
+			res, err := server.GetSearchCacheStats(ctx, tc.req)
+			tc.checkResponse(t, res, err)
+		})
+	}
+}
@@ -0,0 +1,316 @@
+package gapi
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/hibiken/asynq"
+	"github.com/jackc/pgx/v5/pgtype"
+	db "github.com/r-scheele/sqr/internal/db/sqlc"
+	"github.com/r-scheele/sqr/internal/pb"
+	"github.com/r-scheele/sqr/internal/util"
+	"github.com/r-scheele/sqr/internal/val"
+	"github.com/r-scheele/sqr/internal/worker"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// adminUserSessionsLimit caps the sessions returned by GetUser
+const adminUserSessionsLimit = 50
+
+func (server *Server) ListUsers(ctx context.Context, req *pb.ListUsersRequest) (*pb.ListUsersResponse, error) {
+	violations := validateListUsersRequest(req)
+	if violations != nil {
+		return nil, invalidArgumentError(violations)
+	}
+
+	_, err := server.authorizeRequest(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	var search pgtype.Text
+	if req.GetQuery() != "" {
+		search = pgtype.Text{String: "%" + req.GetQuery() + "%", Valid: true}
+	}
+
+	arg := db.FilterUsersParams{
+		Search:   search,
+		UserType: db.NullUserTypeEnum{UserTypeEnum: db.UserTypeEnum(req.GetUserType()), Valid: req.GetUserType() != ""},
+		IsActive: pgtype.Bool{Bool: req.GetIsActive(), Valid: req.IsActive != nil},
+		Limit:    req.GetPageSize(),
+		Offset:   (req.GetPageId() - 1) * req.GetPageSize(),
+	}
+
+	users, err := server.store.FilterUsers(ctx, arg)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to list users: %s", err)
+	}
+
+	totalCount, err := server.store.CountFilteredUsers(ctx, db.CountFilteredUsersParams{
+		Search:   arg.Search,
+		UserType: arg.UserType,
+		IsActive: arg.IsActive,
+	})
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to count users: %s", err)
+	}
+
+	rsp := &pb.ListUsersResponse{
+		Users:      make([]*pb.AdminUser, 0, len(users)),
+		TotalCount: totalCount,
+	}
+	for _, user := range users {
+		rsp.Users = append(rsp.Users, convertAdminUser(user))
+	}
+
+	return rsp, nil
+}
+
+func (server *Server) GetUser(ctx context.Context, req *pb.GetUserRequest) (*pb.GetUserResponse, error) {
+	violations := validateAdminUserIDRequest(req)
+	if violations != nil {
+		return nil, invalidArgumentError(violations)
+	}
+
+	_, err := server.authorizeRequest(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	user, err := server.store.GetUserByID(ctx, req.GetUserId())
+	if err != nil {
+		if errors.Is(err, db.ErrRecordNotFound) {
+			return nil, status.Errorf(codes.NotFound, "user not found")
+		}
+		return nil, status.Errorf(codes.Internal, "failed to get user: %s", err)
+	}
+
+	verifications, err := server.store.GetUserVerificationsByUserID(ctx, user.ID)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to get user verifications: %s", err)
+	}
+
+	sessions, err := server.store.GetUserActiveSessions(ctx, db.GetUserActiveSessionsParams{
+		UserID: user.ID,
+		Limit:  adminUserSessionsLimit,
+		Offset: 0,
+	})
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to get user sessions: %s", err)
+	}
+
+	rsp := &pb.GetUserResponse{
+		User:          convertAdminUser(user),
+		Verifications: make([]*pb.Verification, 0, len(verifications)),
+		Sessions:      make([]*pb.Session, 0, len(sessions)),
+	}
+	for _, verification := range verifications {
+		rsp.Verifications = append(rsp.Verifications, convertVerification(verification))
+	}
+	for _, session := range sessions {
+		// None of them is the session of the admin looking at them
+		rsp.Sessions = append(rsp.Sessions, convertSession(session, uuid.Nil))
+	}
+
+	return rsp, nil
+}
+
+func (server *Server) SetUserActiveStatus(ctx context.Context, req *pb.SetUserActiveStatusRequest) (*pb.SetUserActiveStatusResponse, error) {
+	violations := validateAdminUserIDRequest(req)
+	if violations != nil {
+		return nil, invalidArgumentError(violations)
+	}
+
+	principal, err := server.authorizeRequest(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	if principal.User.ID == req.GetUserId() {
+		return nil, status.Errorf(codes.FailedPrecondition, "admins cannot change the status of their own account")
+	}
+
+	result, err := server.store.SetUserActiveStatusTx(ctx, db.SetUserActiveStatusTxParams{
+		AdminActor: server.adminActor(ctx, principal),
+		UserID:     req.GetUserId(),
+		IsActive:   req.GetIsActive(),
+	})
+	if err != nil {
+		if errors.Is(err, db.ErrRecordNotFound) {
+			return nil, status.Errorf(codes.NotFound, "user not found")
+		}
+		return nil, status.Errorf(codes.Internal, "failed to update user status: %s", err)
+	}
+
+	if !req.GetIsActive() {
+		err = server.revokeUserAccess(ctx, result.User.ID)
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "user deactivated but failed to revoke access: %s", err)
+		}
+	}
+
+	rsp := &pb.SetUserActiveStatusResponse{
+		User: convertAdminUser(result.User),
+	}
+	return rsp, nil
+}
+
+func (server *Server) ChangeUserType(ctx context.Context, req *pb.ChangeUserTypeRequest) (*pb.ChangeUserTypeResponse, error) {
+	violations := validateChangeUserTypeRequest(req)
+	if violations != nil {
+		return nil, invalidArgumentError(violations)
+	}
+
+	principal, err := server.authorizeRequest(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	if principal.User.ID == req.GetUserId() {
+		return nil, status.Errorf(codes.FailedPrecondition, "admins cannot change the type of their own account")
+	}
+
+	result, err := server.store.ChangeUserTypeTx(ctx, db.ChangeUserTypeTxParams{
+		AdminActor: server.adminActor(ctx, principal),
+		UserID:     req.GetUserId(),
+		UserType:   db.UserTypeEnum(req.GetUserType()),
+	})
+	if err != nil {
+		if errors.Is(err, db.ErrRecordNotFound) {
+			return nil, status.Errorf(codes.NotFound, "user not found")
+		}
+		return nil, status.Errorf(codes.Internal, "failed to change user type: %s", err)
+	}
+
+	// Issued tokens still carry the old role
+	err = server.revokeUserAccess(ctx, result.User.ID)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "user type changed but failed to revoke access: %s", err)
+	}
+
+	rsp := &pb.ChangeUserTypeResponse{
+		User: convertAdminUser(result.User),
+	}
+	return rsp, nil
+}
+
+func (server *Server) ForcePasswordReset(ctx context.Context, req *pb.ForcePasswordResetRequest) (*pb.ForcePasswordResetResponse, error) {
+	violations := validateAdminUserIDRequest(req)
+	if violations != nil {
+		return nil, invalidArgumentError(violations)
+	}
+
+	principal, err := server.authorizeRequest(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	// Nobody knows this password, the user has to go through the reset link
+	hashedPassword, err := util.HashPassword(util.RandomString(32))
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to hash password: %s", err)
+	}
+
+	resetToken := util.RandomString(32)
+
+	arg := db.ForcePasswordResetTxParams{
+		AdminActor:   server.adminActor(ctx, principal),
+		UserID:       req.GetUserId(),
+		PasswordHash: hashedPassword,
+		AfterCreate: func(user db.User) error {
+			taskPayload := &worker.PayloadSendPasswordResetEmail{
+				Username:   user.FirstName + " " + user.LastName,
+				Email:      user.Email,
+				ResetToken: resetToken,
+			}
+			opts := []asynq.Option{
+				asynq.MaxRetry(10),
+				asynq.ProcessIn(10 * time.Second),
+				asynq.Queue(worker.QueueCritical),
+			}
+
+			return server.taskDistributor.DistributeTaskSendPasswordResetEmail(ctx, taskPayload, opts...)
+		},
+	}
+
+	arg.VerificationData, err = json.Marshal(map[string]interface{}{
+		"secret_code": resetToken,
+		"user_id":     req.GetUserId(),
+	})
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to marshal verification data")
+	}
+
+	result, err := server.store.ForcePasswordResetTx(ctx, arg)
+	if err != nil {
+		if errors.Is(err, db.ErrRecordNotFound) {
+			return nil, status.Errorf(codes.NotFound, "user not found")
+		}
+		return nil, status.Errorf(codes.Internal, "failed to force password reset: %s", err)
+	}
+
+	err = server.revokeUserAccess(ctx, result.User.ID)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "password reset forced but failed to sign the user out: %s", err)
+	}
+
+	rsp := &pb.ForcePasswordResetResponse{
+		Message: "The user has been signed out and sent a password reset link",
+	}
+	return rsp, nil
+}
+
+// adminActor returns who is making an admin change, for the audit log
+func (server *Server) adminActor(ctx context.Context, principal *Principal) db.AdminActor {
+	mtdt := server.extractMetadata(ctx)
+	return db.AdminActor{
+		AdminID:   principal.User.ID,
+		IpAddress: pgtype.Text{String: mtdt.ClientIP, Valid: mtdt.ClientIP != ""},
+		UserAgent: pgtype.Text{String: mtdt.UserAgent, Valid: mtdt.UserAgent != ""},
+	}
+}
+
+func validateListUsersRequest(req *pb.ListUsersRequest) (violations []*errdetails.BadRequest_FieldViolation) {
+	if err := val.ValidateString(req.GetQuery(), 0, 100); err != nil {
+		violations = append(violations, fieldViolation("query", err))
+	}
+
+	if req.GetUserType() != "" {
+		if err := val.ValidateUserType(req.GetUserType()); err != nil {
+			violations = append(violations, fieldViolation("user_type", err))
+		}
+	}
+
+	if err := val.ValidatePageID(req.GetPageId()); err != nil {
+		violations = append(violations, fieldViolation("page_id", err))
+	}
+
+	if err := val.ValidatePageSize(req.GetPageSize()); err != nil {
+		violations = append(violations, fieldViolation("page_size", err))
+	}
+
+	return violations
+}
+
+func validateAdminUserIDRequest(req interface{ GetUserId() int64 }) (violations []*errdetails.BadRequest_FieldViolation) {
+	if err := val.ValidateID(req.GetUserId()); err != nil {
+		violations = append(violations, fieldViolation("user_id", err))
+	}
+
+	return violations
+}
+
+func validateChangeUserTypeRequest(req *pb.ChangeUserTypeRequest) (violations []*errdetails.BadRequest_FieldViolation) {
+	violations = validateAdminUserIDRequest(req)
+
+	if err := val.ValidateUserType(req.GetUserType()); err != nil {
+		violations = append(violations, fieldViolation("user_type", err))
+	}
+
+	return violations
+}
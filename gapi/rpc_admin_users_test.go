@@ -0,0 +1,487 @@
+package gapi
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/hibiken/asynq"
+	"github.com/jackc/pgx/v5/pgtype"
+	mockdb "github.com/r-scheele/sqr/internal/db/mock"
+	db "github.com/r-scheele/sqr/internal/db/sqlc"
+	"github.com/r-scheele/sqr/internal/pb"
+	"github.com/r-scheele/sqr/internal/token"
+	"github.com/r-scheele/sqr/internal/util"
+	"github.com/r-scheele/sqr/internal/worker"
+	mockwk "github.com/r-scheele/sqr/internal/worker/mock"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestListUsersAPI(t *testing.T) {
+	admin, _ := randomUser(t, util.AdminRole)
+	admin.ID = util.RandomInt(1, 1000)
+	tenant, _ := randomUser(t, util.TenantRole)
+	tenant.ID = admin.ID + 1
+	isActive := false
+
+	testCases := []struct {
+		name          string
+		caller        db.User
+		req           *pb.ListUsersRequest
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(t *testing.T, res *pb.ListUsersResponse, err error)
+	}{
+		{
+			name:   "OK",
+			caller: admin,
+			req: &pb.ListUsersRequest{
+				Query:    "john",
+				UserType: util.TenantRole,
+				IsActive: &isActive,
+				PageId:   2,
+				PageSize: 10,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetUserByID(gomock.Any(), gomock.Eq(admin.ID)).
+					Times(1).
+					Return(admin, nil)
+
+				arg := db.FilterUsersParams{
+					Search:   pgtype.Text{String: "%john%", Valid: true},
+					UserType: db.NullUserTypeEnum{UserTypeEnum: db.UserTypeEnumTenant, Valid: true},
+					IsActive: pgtype.Bool{Bool: false, Valid: true},
+					Limit:    10,
+					Offset:   10,
+				}
+				store.EXPECT().
+					FilterUsers(gomock.Any(), gomock.Eq(arg)).
+					Times(1).
+					Return([]db.User{tenant}, nil)
+
+				store.EXPECT().
+					CountFilteredUsers(gomock.Any(), gomock.Eq(db.CountFilteredUsersParams{
+						Search:   arg.Search,
+						UserType: arg.UserType,
+						IsActive: arg.IsActive,
+					})).
+					Times(1).
+					Return(int64(11), nil)
+			},
+			checkResponse: func(t *testing.T, res *pb.ListUsersResponse, err error) {
+				require.NoError(t, err)
+				require.Len(t, res.GetUsers(), 1)
+				require.Equal(t, tenant.ID, res.GetUsers()[0].GetId())
+				require.Equal(t, int64(11), res.GetTotalCount())
+			},
+		},
+		{
+			name:   "NoFilters",
+			caller: admin,
+			req:    &pb.ListUsersRequest{PageId: 1, PageSize: 5},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetUserByID(gomock.Any(), gomock.Eq(admin.ID)).
+					Times(1).
+					Return(admin, nil)
+
+				store.EXPECT().
+					FilterUsers(gomock.Any(), gomock.Eq(db.FilterUsersParams{Limit: 5, Offset: 0})).
+					Times(1).
+					Return([]db.User{}, nil)
+
+				store.EXPECT().
+					CountFilteredUsers(gomock.Any(), gomock.Eq(db.CountFilteredUsersParams{})).
+					Times(1).
+					Return(int64(0), nil)
+			},
+			checkResponse: func(t *testing.T, res *pb.ListUsersResponse, err error) {
+				require.NoError(t, err)
+				require.Empty(t, res.GetUsers())
+			},
+		},
+		{
+			name:   "NotAdmin",
+			caller: tenant,
+			req:    &pb.ListUsersRequest{PageId: 1, PageSize: 5},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					FilterUsers(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, res *pb.ListUsersResponse, err error) {
+				require.Error(t, err)
+				require.Equal(t, codes.Unauthenticated, status.Code(err))
+			},
+		},
+		{
+			name:   "InvalidUserType",
+			caller: admin,
+			req:    &pb.ListUsersRequest{UserType: "owner", PageId: 1, PageSize: 5},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					FilterUsers(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, res *pb.ListUsersResponse, err error) {
+				require.Error(t, err)
+				require.Equal(t, codes.InvalidArgument, status.Code(err))
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			ctx := newContextWithBearerToken(t, server.tokenMaker, tc.caller, uuid.New(), time.Minute, token.TokenTypeAccessToken)
+
+			res, err := server.ListUsers(ctx, tc.req)
+			tc.checkResponse(t, res, err)
+		})
+	}
+}
+
+func TestGetUserAPI(t *testing.T) {
+	admin, _ := randomUser(t, util.AdminRole)
+	admin.ID = util.RandomInt(1, 1000)
+	tenant, _ := randomUser(t, util.TenantRole)
+	tenant.ID = admin.ID + 1
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	store := mockdb.NewMockStore(ctrl)
+	server := newTestServer(t, store)
+	ctx := newContextWithBearerToken(t, server.tokenMaker, admin, uuid.New(), time.Minute, token.TokenTypeAccessToken)
+
+	store.EXPECT().
+		GetUserByID(gomock.Any(), gomock.Eq(admin.ID)).
+		Times(1).
+		Return(admin, nil)
+
+	store.EXPECT().
+		GetUserByID(gomock.Any(), gomock.Eq(tenant.ID)).
+		Times(1).
+		Return(tenant, nil)
+
+	store.EXPECT().
+		GetUserVerificationsByUserID(gomock.Any(), gomock.Eq(tenant.ID)).
+		Times(1).
+		Return([]db.UserVerification{{
+			ID:                 1,
+			UserID:             tenant.ID,
+			VerificationType:   db.VerificationTypeEnumEmail,
+			VerificationStatus: db.NullVerificationStatusEnum{VerificationStatusEnum: db.VerificationStatusEnumVerified, Valid: true},
+			VerificationData:   []byte(`{"secret_code":"do-not-leak"}`),
+		}}, nil)
+
+	store.EXPECT().
+		GetUserActiveSessions(gomock.Any(), gomock.Eq(db.GetUserActiveSessionsParams{UserID: tenant.ID, Limit: adminUserSessionsLimit})).
+		Times(1).
+		Return([]db.UserSession{{UserID: tenant.ID, SessionID: uuid.New(), ExpiresAt: time.Now().Add(time.Hour)}}, nil)
+
+	res, err := server.GetUser(ctx, &pb.GetUserRequest{UserId: tenant.ID})
+	require.NoError(t, err)
+	require.Equal(t, tenant.Email, res.GetUser().GetEmail())
+	require.Equal(t, util.TenantRole, res.GetUser().GetUserType())
+	require.Len(t, res.GetVerifications(), 1)
+	require.Equal(t, string(db.VerificationTypeEnumEmail), res.GetVerifications()[0].GetVerificationType())
+	require.Len(t, res.GetSessions(), 1)
+	require.False(t, res.GetSessions()[0].GetCurrent())
+}
+
+func TestSetUserActiveStatusAPI(t *testing.T) {
+	admin, _ := randomUser(t, util.AdminRole)
+	admin.ID = util.RandomInt(1, 1000)
+	tenant, _ := randomUser(t, util.TenantRole)
+	tenant.ID = admin.ID + 1
+
+	testCases := []struct {
+		name          string
+		req           *pb.SetUserActiveStatusRequest
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(t *testing.T, res *pb.SetUserActiveStatusResponse, err error)
+	}{
+		{
+			name: "Deactivate",
+			req:  &pb.SetUserActiveStatusRequest{UserId: tenant.ID, IsActive: false},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetUserByID(gomock.Any(), gomock.Eq(admin.ID)).
+					Times(1).
+					Return(admin, nil)
+
+				deactivated := tenant
+				deactivated.IsActive = pgtype.Bool{Bool: false, Valid: true}
+				store.EXPECT().
+					SetUserActiveStatusTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					DoAndReturn(func(_ context.Context, arg db.SetUserActiveStatusTxParams) (db.SetUserActiveStatusTxResult, error) {
+						require.Equal(t, admin.ID, arg.AdminID)
+						require.Equal(t, tenant.ID, arg.UserID)
+						require.False(t, arg.IsActive)
+						return db.SetUserActiveStatusTxResult{User: deactivated}, nil
+					})
+
+				store.EXPECT().
+					DeactivateUserSessions(gomock.Any(), gomock.Eq(tenant.ID)).
+					Times(1).
+					Return(nil)
+			},
+			checkResponse: func(t *testing.T, res *pb.SetUserActiveStatusResponse, err error) {
+				require.NoError(t, err)
+				require.False(t, res.GetUser().GetIsActive())
+			},
+		},
+		{
+			name: "RevokeAccessFails",
+			req:  &pb.SetUserActiveStatusRequest{UserId: tenant.ID, IsActive: false},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetUserByID(gomock.Any(), gomock.Eq(admin.ID)).
+					Times(1).
+					Return(admin, nil)
+
+				store.EXPECT().
+					SetUserActiveStatusTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.SetUserActiveStatusTxResult{User: tenant}, nil)
+
+				store.EXPECT().
+					DeactivateUserSessions(gomock.Any(), gomock.Eq(tenant.ID)).
+					Times(1).
+					Return(sql.ErrConnDone)
+			},
+			checkResponse: func(t *testing.T, res *pb.SetUserActiveStatusResponse, err error) {
+				require.Error(t, err)
+				require.Equal(t, codes.Internal, status.Code(err))
+			},
+		},
+		{
+			name: "Activate",
+			req:  &pb.SetUserActiveStatusRequest{UserId: tenant.ID, IsActive: true},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetUserByID(gomock.Any(), gomock.Eq(admin.ID)).
+					Times(1).
+					Return(admin, nil)
+
+				activated := tenant
+				activated.IsActive = pgtype.Bool{Bool: true, Valid: true}
+				store.EXPECT().
+					SetUserActiveStatusTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.SetUserActiveStatusTxResult{User: activated}, nil)
+
+				store.EXPECT().
+					DeactivateUserSessions(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, res *pb.SetUserActiveStatusResponse, err error) {
+				require.NoError(t, err)
+				require.True(t, res.GetUser().GetIsActive())
+			},
+		},
+		{
+			name: "OwnAccount",
+			req:  &pb.SetUserActiveStatusRequest{UserId: admin.ID, IsActive: false},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetUserByID(gomock.Any(), gomock.Eq(admin.ID)).
+					Times(1).
+					Return(admin, nil)
+
+				store.EXPECT().
+					SetUserActiveStatusTx(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, res *pb.SetUserActiveStatusResponse, err error) {
+				require.Error(t, err)
+				require.Equal(t, codes.FailedPrecondition, status.Code(err))
+			},
+		},
+		{
+			name: "UserNotFound",
+			req:  &pb.SetUserActiveStatusRequest{UserId: tenant.ID, IsActive: false},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetUserByID(gomock.Any(), gomock.Eq(admin.ID)).
+					Times(1).
+					Return(admin, nil)
+
+				store.EXPECT().
+					SetUserActiveStatusTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.SetUserActiveStatusTxResult{}, db.ErrRecordNotFound)
+			},
+			checkResponse: func(t *testing.T, res *pb.SetUserActiveStatusResponse, err error) {
+				require.Error(t, err)
+				require.Equal(t, codes.NotFound, status.Code(err))
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			ctx := newContextWithBearerToken(t, server.tokenMaker, admin, uuid.New(), time.Minute, token.TokenTypeAccessToken)
+
+			res, err := server.SetUserActiveStatus(ctx, tc.req)
+			tc.checkResponse(t, res, err)
+		})
+	}
+}
+
+func TestChangeUserTypeAPI(t *testing.T) {
+	admin, _ := randomUser(t, util.AdminRole)
+	admin.ID = util.RandomInt(1, 1000)
+	tenant, _ := randomUser(t, util.TenantRole)
+	tenant.ID = admin.ID + 1
+
+	testCases := []struct {
+		name          string
+		req           *pb.ChangeUserTypeRequest
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(t *testing.T, res *pb.ChangeUserTypeResponse, err error)
+	}{
+		{
+			name: "OK",
+			req:  &pb.ChangeUserTypeRequest{UserId: tenant.ID, UserType: util.LandlordRole},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetUserByID(gomock.Any(), gomock.Eq(admin.ID)).
+					Times(1).
+					Return(admin, nil)
+
+				landlord := tenant
+				landlord.UserType = db.UserTypeEnumLandlord
+				store.EXPECT().
+					ChangeUserTypeTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					DoAndReturn(func(_ context.Context, arg db.ChangeUserTypeTxParams) (db.ChangeUserTypeTxResult, error) {
+						require.Equal(t, db.UserTypeEnumLandlord, arg.UserType)
+						return db.ChangeUserTypeTxResult{User: landlord}, nil
+					})
+
+				// Tokens carrying the old role are revoked
+				store.EXPECT().
+					DeactivateUserSessions(gomock.Any(), gomock.Eq(tenant.ID)).
+					Times(1).
+					Return(nil)
+			},
+			checkResponse: func(t *testing.T, res *pb.ChangeUserTypeResponse, err error) {
+				require.NoError(t, err)
+				require.Equal(t, util.LandlordRole, res.GetUser().GetUserType())
+			},
+		},
+		{
+			name: "InvalidUserType",
+			req:  &pb.ChangeUserTypeRequest{UserId: tenant.ID, UserType: "owner"},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					ChangeUserTypeTx(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, res *pb.ChangeUserTypeResponse, err error) {
+				require.Error(t, err)
+				require.Equal(t, codes.InvalidArgument, status.Code(err))
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			ctx := newContextWithBearerToken(t, server.tokenMaker, admin, uuid.New(), time.Minute, token.TokenTypeAccessToken)
+
+			res, err := server.ChangeUserType(ctx, tc.req)
+			tc.checkResponse(t, res, err)
+		})
+	}
+}
+
+func TestForcePasswordResetAPI(t *testing.T) {
+	admin, _ := randomUser(t, util.AdminRole)
+	admin.ID = util.RandomInt(1, 1000)
+	tenant, _ := randomUser(t, util.TenantRole)
+	tenant.ID = admin.ID + 1
+
+	storeCtrl := gomock.NewController(t)
+	defer storeCtrl.Finish()
+	store := mockdb.NewMockStore(storeCtrl)
+
+	taskCtrl := gomock.NewController(t)
+	defer taskCtrl.Finish()
+	taskDistributor := mockwk.NewMockTaskDistributor(taskCtrl)
+
+	server := newTestServerWithTaskDistributor(t, store, taskDistributor)
+	ctx := newContextWithBearerToken(t, server.tokenMaker, admin, uuid.New(), time.Minute, token.TokenTypeAccessToken)
+
+	store.EXPECT().
+		GetUserByID(gomock.Any(), gomock.Eq(admin.ID)).
+		Times(1).
+		Return(admin, nil)
+
+	var resetToken string
+	store.EXPECT().
+		ForcePasswordResetTx(gomock.Any(), gomock.Any()).
+		Times(1).
+		DoAndReturn(func(_ context.Context, arg db.ForcePasswordResetTxParams) (db.ForcePasswordResetTxResult, error) {
+			require.Equal(t, tenant.ID, arg.UserID)
+			require.NotEqual(t, tenant.PasswordHash, arg.PasswordHash)
+
+			var data map[string]interface{}
+			require.NoError(t, json.Unmarshal(arg.VerificationData, &data))
+			resetToken = data["secret_code"].(string)
+
+			err := arg.AfterCreate(tenant)
+			return db.ForcePasswordResetTxResult{User: tenant}, err
+		})
+
+	taskDistributor.EXPECT().
+		DistributeTaskSendPasswordResetEmail(gomock.Any(), gomock.Any(), gomock.Any()).
+		Times(1).
+		DoAndReturn(func(_ context.Context, payload *worker.PayloadSendPasswordResetEmail, _ ...asynq.Option) error {
+			require.Equal(t, tenant.Email, payload.Email)
+			require.Equal(t, resetToken, payload.ResetToken)
+			return nil
+		})
+
+	store.EXPECT().
+		DeactivateUserSessions(gomock.Any(), gomock.Eq(tenant.ID)).
+		Times(1).
+		Return(nil)
+
+	_, err := server.ForcePasswordReset(ctx, &pb.ForcePasswordResetRequest{UserId: tenant.ID})
+	require.NoError(t, err)
+}
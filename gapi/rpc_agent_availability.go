@@ -0,0 +1,276 @@
+package gapi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	db "github.com/r-scheele/sqr/internal/db/sqlc"
+	"github.com/r-scheele/sqr/internal/pb"
+	"github.com/r-scheele/sqr/internal/util"
+	"github.com/r-scheele/sqr/internal/val"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// defaultSlotMinutes is the length of the slots offered when a request doesn't ask for one
+const defaultSlotMinutes = 60
+
+func (server *Server) SetAgentAvailability(ctx context.Context, req *pb.SetAgentAvailabilityRequest) (*pb.SetAgentAvailabilityResponse, error) {
+	violations := validateSetAgentAvailabilityRequest(req)
+	if violations != nil {
+		return nil, invalidArgumentError(violations)
+	}
+
+	principal, err := server.authorizeRequest(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	arg := db.SetAgentAvailabilityTxParams{
+		AgentID:    principal.User.ID,
+		Windows:    make([]db.CreateAgentAvailabilityWindowParams, 0, len(req.GetWindows())),
+		Exceptions: make([]db.CreateAgentAvailabilityExceptionParams, 0, len(req.GetExceptions())),
+	}
+	for _, window := range req.GetWindows() {
+		arg.Windows = append(arg.Windows, db.CreateAgentAvailabilityWindowParams{
+			DayOfWeek: int16(window.GetDayOfWeek()),
+			StartTime: clockTimeToPg(window.GetStartTime()),
+			EndTime:   clockTimeToPg(window.GetEndTime()),
+		})
+	}
+	for _, exception := range req.GetExceptions() {
+		arg.Exceptions = append(arg.Exceptions, db.CreateAgentAvailabilityExceptionParams{
+			StartDate:   dateToPg(exception.GetStartDate()),
+			EndDate:     dateToPg(exception.GetEndDate()),
+			StartTime:   clockTimeToPg(exception.GetStartTime()),
+			EndTime:     clockTimeToPg(exception.GetEndTime()),
+			IsAvailable: exception.GetAvailable(),
+			Reason:      pgtype.Text{String: exception.GetReason(), Valid: exception.GetReason() != ""},
+		})
+	}
+
+	result, err := server.store.SetAgentAvailabilityTx(ctx, arg)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to set availability: %s", err)
+	}
+
+	rsp := &pb.SetAgentAvailabilityResponse{
+		Availability: convertAgentAvailability(principal.User.ID, result.Windows, result.Exceptions),
+	}
+	return rsp, nil
+}
+
+func (server *Server) GetAgentAvailability(ctx context.Context, req *pb.GetAgentAvailabilityRequest) (*pb.GetAgentAvailabilityResponse, error) {
+	principal, err := server.authorizeRequest(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	windows, err := server.store.ListAgentAvailabilityWindows(ctx, principal.User.ID)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to list availability windows: %s", err)
+	}
+
+	// exceptions that are over no longer change any slot
+	today := util.FormatDate(time.Now().In(util.AvailabilityLocation()))
+	exceptions, err := server.store.ListAgentAvailabilityExceptions(ctx, db.ListAgentAvailabilityExceptionsParams{
+		AgentID:  principal.User.ID,
+		FromDate: dateToPg(today),
+		ToDate:   pgtype.Date{InfinityModifier: pgtype.Infinity, Valid: true},
+	})
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to list availability exceptions: %s", err)
+	}
+
+	rsp := &pb.GetAgentAvailabilityResponse{
+		Availability: convertAgentAvailability(principal.User.ID, windows, exceptions),
+	}
+	return rsp, nil
+}
+
+func (server *Server) ListAgentFreeSlots(ctx context.Context, req *pb.ListAgentFreeSlotsRequest) (*pb.ListAgentFreeSlotsResponse, error) {
+	violations := validateListAgentFreeSlotsRequest(req)
+	if violations != nil {
+		return nil, invalidArgumentError(violations)
+	}
+
+	_, err := server.authorizeRequest(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	profile, err := server.store.GetInspectionAgentProfileByUserID(ctx, req.GetAgentId())
+	if err != nil {
+		if errors.Is(err, db.ErrRecordNotFound) {
+			return nil, status.Errorf(codes.NotFound, "agent not found")
+		}
+		return nil, status.Errorf(codes.Internal, "failed to get agent: %s", err)
+	}
+	if !profile.IsApproved.Bool {
+		return nil, status.Errorf(codes.FailedPrecondition, "agent is not approved for inspections")
+	}
+
+	fromDate, toDate := dateToPg(req.GetFromDate()), dateToPg(req.GetToDate())
+
+	windows, err := server.store.ListAgentAvailabilityWindows(ctx, req.GetAgentId())
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to list availability windows: %s", err)
+	}
+
+	exceptions, err := server.store.ListAgentAvailabilityExceptions(ctx, db.ListAgentAvailabilityExceptionsParams{
+		AgentID:  req.GetAgentId(),
+		FromDate: fromDate,
+		ToDate:   toDate,
+	})
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to list availability exceptions: %s", err)
+	}
+
+	inspections, err := server.store.ListAgentBookedInspections(ctx, db.ListAgentBookedInspectionsParams{
+		AgentID:  pgtype.Int8{Int64: req.GetAgentId(), Valid: true},
+		FromDate: fromDate,
+		ToDate:   toDate,
+	})
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to list booked inspections: %s", err)
+	}
+
+	booked := make([]util.TimeSlot, 0, len(inspections))
+	for _, inspection := range inspections {
+		start := localDate(inspection.ConfirmedDate).Add(pgClockTime(inspection.ConfirmedTime))
+		booked = append(booked, util.TimeSlot{Start: start, End: start.Add(util.InspectionDuration)})
+	}
+
+	slotMinutes := req.GetSlotMinutes()
+	if slotMinutes == 0 {
+		slotMinutes = defaultSlotMinutes
+	}
+
+	calendar := availabilityCalendar(windows, exceptions)
+	slots := calendar.FreeSlots(localDate(fromDate), localDate(toDate), time.Duration(slotMinutes)*time.Minute, booked, time.Now())
+
+	rsp := &pb.ListAgentFreeSlotsResponse{
+		Timezone: util.AvailabilityTimezone,
+		Slots:    make([]*pb.TimeSlot, 0, len(slots)),
+	}
+	for _, slot := range slots {
+		rsp.Slots = append(rsp.Slots, convertTimeSlot(slot))
+	}
+
+	return rsp, nil
+}
+
+// availabilityCalendar builds the calendar free slots are cut from out of the stored windows and exceptions
+func availabilityCalendar(windows []db.AgentAvailabilityWindow, exceptions []db.AgentAvailabilityException) util.AvailabilityCalendar {
+	var calendar util.AvailabilityCalendar
+	for _, window := range windows {
+		calendar.Windows = append(calendar.Windows, util.WeeklyWindow{
+			Weekday:    time.Weekday(window.DayOfWeek),
+			ClockRange: util.ClockRange{Start: pgClockTime(window.StartTime), End: pgClockTime(window.EndTime)},
+		})
+	}
+
+	for _, exception := range exceptions {
+		availabilityException := util.AvailabilityException{
+			StartDate: localDate(exception.StartDate),
+			EndDate:   localDate(exception.EndDate),
+			Available: exception.IsAvailable,
+		}
+		if exception.StartTime.Valid && exception.EndTime.Valid {
+			availabilityException.Times = &util.ClockRange{Start: pgClockTime(exception.StartTime), End: pgClockTime(exception.EndTime)}
+		}
+		calendar.Exceptions = append(calendar.Exceptions, availabilityException)
+	}
+
+	return calendar
+}
+
+// clockTimeToPg converts a validated "15:04" time of day, leaving it null when empty
+func clockTimeToPg(value string) pgtype.Time {
+	offset, err := util.ParseClockTime(value)
+	if err != nil {
+		return pgtype.Time{}
+	}
+	return pgtype.Time{Microseconds: offset.Microseconds(), Valid: true}
+}
+
+func pgClockTime(value pgtype.Time) time.Duration {
+	return time.Duration(value.Microseconds) * time.Microsecond
+}
+
+// dateToPg converts a validated "2006-01-02" date
+func dateToPg(value string) pgtype.Date {
+	date, err := time.Parse("2006-01-02", value)
+	if err != nil {
+		return pgtype.Date{}
+	}
+	return pgtype.Date{Time: date, Valid: true}
+}
+
+// localDate returns the midnight of a date read from the database in util.AvailabilityTimezone
+func localDate(value pgtype.Date) time.Time {
+	date, _ := util.ParseDate(value.Time.Format("2006-01-02"))
+	return date
+}
+
+func validateSetAgentAvailabilityRequest(req *pb.SetAgentAvailabilityRequest) (violations []*errdetails.BadRequest_FieldViolation) {
+	if len(req.GetWindows()) > val.MaxAvailabilityWindows {
+		violations = append(violations, fieldViolation("windows", fmt.Errorf("must have at most %d windows", val.MaxAvailabilityWindows)))
+	}
+	for i, window := range req.GetWindows() {
+		if err := val.ValidateDayOfWeek(window.GetDayOfWeek()); err != nil {
+			violations = append(violations, fieldViolation(fmt.Sprintf("windows[%d].day_of_week", i), err))
+		}
+		if err := val.ValidateClockRange(window.GetStartTime(), window.GetEndTime()); err != nil {
+			violations = append(violations, fieldViolation(fmt.Sprintf("windows[%d]", i), err))
+		}
+	}
+
+	if len(req.GetExceptions()) > val.MaxAvailabilityExceptions {
+		violations = append(violations, fieldViolation("exceptions", fmt.Errorf("must have at most %d exceptions", val.MaxAvailabilityExceptions)))
+	}
+	for i, exception := range req.GetExceptions() {
+		field := fmt.Sprintf("exceptions[%d]", i)
+		if err := val.ValidateDateRange(exception.GetStartDate(), exception.GetEndDate(), 0); err != nil {
+			violations = append(violations, fieldViolation(field, err))
+		}
+
+		wholeDay := exception.GetStartTime() == "" && exception.GetEndTime() == ""
+		if wholeDay && exception.GetAvailable() {
+			violations = append(violations, fieldViolation(field, fmt.Errorf("extra availability must have a start and end time")))
+		}
+		if !wholeDay {
+			if err := val.ValidateClockRange(exception.GetStartTime(), exception.GetEndTime()); err != nil {
+				violations = append(violations, fieldViolation(field, err))
+			}
+		}
+
+		if err := val.ValidateString(exception.GetReason(), 0, 200); err != nil {
+			violations = append(violations, fieldViolation(field+".reason", err))
+		}
+	}
+
+	return violations
+}
+
+func validateListAgentFreeSlotsRequest(req *pb.ListAgentFreeSlotsRequest) (violations []*errdetails.BadRequest_FieldViolation) {
+	if err := val.ValidateID(req.GetAgentId()); err != nil {
+		violations = append(violations, fieldViolation("agent_id", err))
+	}
+
+	if err := val.ValidateDateRange(req.GetFromDate(), req.GetToDate(), val.MaxSlotSearchDays); err != nil {
+		violations = append(violations, fieldViolation("to_date", err))
+	}
+
+	if req.GetSlotMinutes() != 0 {
+		if err := val.ValidateSlotMinutes(req.GetSlotMinutes()); err != nil {
+			violations = append(violations, fieldViolation("slot_minutes", err))
+		}
+	}
+
+	return violations
+}
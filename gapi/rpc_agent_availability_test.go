@@ -0,0 +1,282 @@
+package gapi
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+	mockdb "github.com/r-scheele/sqr/internal/db/mock"
+	db "github.com/r-scheele/sqr/internal/db/sqlc"
+	"github.com/r-scheele/sqr/internal/pb"
+	"github.com/r-scheele/sqr/internal/token"
+	"github.com/r-scheele/sqr/internal/util"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestSetAgentAvailabilityAPI(t *testing.T) {
+	agent, _ := randomUser(t, util.InspectionAgentRole)
+	agent.ID = util.RandomInt(1, 1000)
+
+	validRequest := func() *pb.SetAgentAvailabilityRequest {
+		return &pb.SetAgentAvailabilityRequest{
+			Windows: []*pb.AvailabilityWindow{
+				{DayOfWeek: 1, StartTime: "09:00", EndTime: "17:00"},
+			},
+			Exceptions: []*pb.AvailabilityException{
+				{StartDate: "2026-12-24", EndDate: "2026-12-26", Reason: "Christmas"},
+			},
+		}
+	}
+
+	testCases := []struct {
+		name          string
+		req           func() *pb.SetAgentAvailabilityRequest
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(t *testing.T, res *pb.SetAgentAvailabilityResponse, err error)
+	}{
+		{
+			name: "OK",
+			req:  validRequest,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetUserByID(gomock.Any(), gomock.Eq(agent.ID)).
+					Times(1).
+					Return(agent, nil)
+
+				store.EXPECT().
+					SetAgentAvailabilityTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					DoAndReturn(func(_ context.Context, arg db.SetAgentAvailabilityTxParams) (db.SetAgentAvailabilityTxResult, error) {
+						require.Equal(t, agent.ID, arg.AgentID)
+						require.Len(t, arg.Windows, 1)
+						require.Equal(t, (9 * time.Hour).Microseconds(), arg.Windows[0].StartTime.Microseconds)
+						require.Len(t, arg.Exceptions, 1)
+						require.False(t, arg.Exceptions[0].StartTime.Valid)
+						require.False(t, arg.Exceptions[0].IsAvailable)
+
+						return db.SetAgentAvailabilityTxResult{
+							Windows: []db.AgentAvailabilityWindow{{
+								AgentID:   agent.ID,
+								DayOfWeek: arg.Windows[0].DayOfWeek,
+								StartTime: arg.Windows[0].StartTime,
+								EndTime:   arg.Windows[0].EndTime,
+							}},
+							Exceptions: []db.AgentAvailabilityException{{
+								AgentID:   agent.ID,
+								StartDate: arg.Exceptions[0].StartDate,
+								EndDate:   arg.Exceptions[0].EndDate,
+								Reason:    arg.Exceptions[0].Reason,
+							}},
+						}, nil
+					})
+			},
+			checkResponse: func(t *testing.T, res *pb.SetAgentAvailabilityResponse, err error) {
+				require.NoError(t, err)
+				availability := res.GetAvailability()
+				require.Equal(t, util.AvailabilityTimezone, availability.GetTimezone())
+				require.Equal(t, "09:00", availability.GetWindows()[0].GetStartTime())
+				require.Equal(t, "17:00", availability.GetWindows()[0].GetEndTime())
+				require.Equal(t, "2026-12-24", availability.GetExceptions()[0].GetStartDate())
+				require.Empty(t, availability.GetExceptions()[0].GetStartTime())
+			},
+		},
+		{
+			name: "WindowEndsBeforeStart",
+			req: func() *pb.SetAgentAvailabilityRequest {
+				req := validRequest()
+				req.Windows[0].EndTime = "08:00"
+				return req
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					SetAgentAvailabilityTx(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, res *pb.SetAgentAvailabilityResponse, err error) {
+				require.Error(t, err)
+				requireFieldViolation(t, err, "windows[0]")
+			},
+		},
+		{
+			name: "ExtraAvailabilityWithoutTimes",
+			req: func() *pb.SetAgentAvailabilityRequest {
+				req := validRequest()
+				req.Exceptions[0].Available = true
+				return req
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					SetAgentAvailabilityTx(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, res *pb.SetAgentAvailabilityResponse, err error) {
+				require.Error(t, err)
+				requireFieldViolation(t, err, "exceptions[0]")
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			ctx := newContextWithBearerToken(t, server.tokenMaker, agent, uuid.New(), time.Minute, token.TokenTypeAccessToken)
+
+			res, err := server.SetAgentAvailability(ctx, tc.req())
+			tc.checkResponse(t, res, err)
+		})
+	}
+}
+
+func TestListAgentFreeSlotsAPI(t *testing.T) {
+	tenant, _ := randomUser(t, util.TenantRole)
+	tenant.ID = util.RandomInt(1, 1000)
+	agentID := tenant.ID + 1
+
+	// a Monday in the future so that no slot is in the past
+	monday := time.Now().In(util.AvailabilityLocation()).AddDate(0, 0, 7)
+	for monday.Weekday() != time.Monday {
+		monday = monday.AddDate(0, 0, 1)
+	}
+	date := util.FormatDate(monday)
+	pgDate := dateToPg(date)
+
+	request := &pb.ListAgentFreeSlotsRequest{
+		AgentId:  agentID,
+		FromDate: date,
+		ToDate:   date,
+	}
+
+	approvedProfile := randomAgentProfile(agentID)
+	approvedProfile.IsApproved = pgtype.Bool{Bool: true, Valid: true}
+
+	testCases := []struct {
+		name          string
+		req           *pb.ListAgentFreeSlotsRequest
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(t *testing.T, res *pb.ListAgentFreeSlotsResponse, err error)
+	}{
+		{
+			name: "OK",
+			req:  request,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetUserByID(gomock.Any(), gomock.Eq(tenant.ID)).
+					Times(1).
+					Return(tenant, nil)
+
+				store.EXPECT().
+					GetInspectionAgentProfileByUserID(gomock.Any(), gomock.Eq(agentID)).
+					Times(1).
+					Return(approvedProfile, nil)
+
+				store.EXPECT().
+					ListAgentAvailabilityWindows(gomock.Any(), gomock.Eq(agentID)).
+					Times(1).
+					Return([]db.AgentAvailabilityWindow{{
+						AgentID:   agentID,
+						DayOfWeek: int16(time.Monday),
+						StartTime: clockTimeToPg("09:00"),
+						EndTime:   clockTimeToPg("12:00"),
+					}}, nil)
+
+				store.EXPECT().
+					ListAgentAvailabilityExceptions(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return([]db.AgentAvailabilityException{}, nil)
+
+				store.EXPECT().
+					ListAgentBookedInspections(gomock.Any(), gomock.Eq(db.ListAgentBookedInspectionsParams{
+						AgentID:  pgtype.Int8{Int64: agentID, Valid: true},
+						FromDate: pgDate,
+						ToDate:   pgDate,
+					})).
+					Times(1).
+					Return([]db.ListAgentBookedInspectionsRow{{
+						ID:            util.RandomInt(1, 1000),
+						ConfirmedDate: pgDate,
+						ConfirmedTime: clockTimeToPg("10:00"),
+					}}, nil)
+			},
+			checkResponse: func(t *testing.T, res *pb.ListAgentFreeSlotsResponse, err error) {
+				require.NoError(t, err)
+				require.Equal(t, util.AvailabilityTimezone, res.GetTimezone())
+				require.Len(t, res.GetSlots(), 2)
+				require.Equal(t, date, res.GetSlots()[0].GetDate())
+				require.Equal(t, "09:00", res.GetSlots()[0].GetStartTime())
+				require.Equal(t, "11:00", res.GetSlots()[1].GetStartTime())
+				require.Equal(t, "12:00", res.GetSlots()[1].GetEndTime())
+			},
+		},
+		{
+			name: "AgentNotApproved",
+			req:  request,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetUserByID(gomock.Any(), gomock.Eq(tenant.ID)).
+					Times(1).
+					Return(tenant, nil)
+
+				store.EXPECT().
+					GetInspectionAgentProfileByUserID(gomock.Any(), gomock.Eq(agentID)).
+					Times(1).
+					Return(randomAgentProfile(agentID), nil)
+
+				store.EXPECT().
+					ListAgentAvailabilityWindows(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, res *pb.ListAgentFreeSlotsResponse, err error) {
+				require.Error(t, err)
+				require.Equal(t, codes.FailedPrecondition, status.Code(err))
+			},
+		},
+		{
+			name: "RangeTooLong",
+			req: &pb.ListAgentFreeSlotsRequest{
+				AgentId:  agentID,
+				FromDate: date,
+				ToDate:   util.FormatDate(monday.AddDate(0, 2, 0)),
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetInspectionAgentProfileByUserID(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, res *pb.ListAgentFreeSlotsResponse, err error) {
+				require.Error(t, err)
+				requireFieldViolation(t, err, "to_date")
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			ctx := newContextWithBearerToken(t, server.tokenMaker, tenant, uuid.New(), time.Minute, token.TokenTypeAccessToken)
+
+			res, err := server.ListAgentFreeSlots(ctx, tc.req)
+			tc.checkResponse(t, res, err)
+		})
+	}
+}
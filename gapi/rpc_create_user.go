@@ -19,17 +19,21 @@ import (
 
 func (server *Server) CreateUser(ctx context.Context, req *pb.CreateUserRequest) (*pb.CreateUserResponse, error) {
 	violations := validateCreateUserRequest(req)
+	firstName, lastName := util.SplitFullName(req.GetFullName())
+	violations = append(violations, server.newPasswordViolations(ctx, "password", req.GetPassword(), val.PasswordOwner{
+		Email:     req.GetEmail(),
+		FirstName: firstName,
+		LastName:  lastName,
+	})...)
 	if violations != nil {
 		return nil, invalidArgumentError(violations)
 	}
 
-	hashedPassword, err := util.HashPassword(req.GetPassword())
+	hashedPassword, err := server.passwordHasher.Hash(req.GetPassword())
 	if err != nil {
 		return nil, status.Errorf(codes.Internal, "failed to hash password: %s", err)
 	}
 
-	firstName, lastName := util.SplitFullName(req.GetFullName())
-
 	arg := db.CreateUserTxParams{
 		CreateUserParams: db.CreateUserParams{
 			Email:        req.GetEmail(),
@@ -96,12 +100,9 @@ func (server *Server) CreateUser(ctx context.Context, req *pb.CreateUserRequest)
 	return rsp, nil
 }
 
-// validateCreateUserRequest validates the create user request
+// validateCreateUserRequest validates the create user request. The password is checked against
+// the password policy by CreateUser.
 func validateCreateUserRequest(req *pb.CreateUserRequest) (violations []*errdetails.BadRequest_FieldViolation) {
-	if err := val.ValidatePassword(req.GetPassword()); err != nil {
-		violations = append(violations, fieldViolation("password", err))
-	}
-
 	if err := val.ValidateFullName(req.GetFullName()); err != nil {
 		violations = append(violations, fieldViolation("full_name", err))
 	}
@@ -53,7 +53,7 @@ func EqCreateUserTxParams(arg db.CreateUserTxParams, password string, user db.Us
 }
 
 func randomUser(t *testing.T, userType string) (user db.User, password string) {
-	password = util.RandomString(6)
+	password = util.RandomPassword()
 	hashedPassword, err := util.HashPassword(password)
 	require.NoError(t, err)
 
@@ -176,6 +176,46 @@ func TestCreateUserAPI(t *testing.T) {
 				require.Equal(t, codes.AlreadyExists, st.Code())
 			},
 		},
+		{
+			name: "WeakPassword",
+			req: &pb.CreateUserRequest{
+				Username:    user.Email,
+				Password:    "password",
+				FullName:    user.FirstName + " " + user.LastName,
+				Email:       user.Email,
+				PhoneNumber: user.Phone,
+				UserType:    string(user.UserType),
+			},
+			buildStubs: func(store *mockdb.MockStore, taskDistributor *mockwk.MockTaskDistributor) {
+				store.EXPECT().
+					CreateUserTx(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, res *pb.CreateUserResponse, err error) {
+				require.Error(t, err)
+				requireFieldViolation(t, err, "password")
+			},
+		},
+		{
+			name: "PasswordContainsName",
+			req: &pb.CreateUserRequest{
+				Username:    user.Email,
+				Password:    "My" + user.LastName + "2024!",
+				FullName:    user.FirstName + " " + user.LastName,
+				Email:       user.Email,
+				PhoneNumber: user.Phone,
+				UserType:    string(user.UserType),
+			},
+			buildStubs: func(store *mockdb.MockStore, taskDistributor *mockwk.MockTaskDistributor) {
+				store.EXPECT().
+					CreateUserTx(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, res *pb.CreateUserResponse, err error) {
+				require.Error(t, err)
+				requireFieldViolation(t, err, "password")
+			},
+		},
 		{
 			name: "InvalidEmail",
 			req: &pb.CreateUserRequest{
@@ -0,0 +1,47 @@
+package gapi
+
+import (
+	"context"
+	"errors"
+
+	db "github.com/r-scheele/sqr/internal/db/sqlc"
+	"github.com/r-scheele/sqr/internal/pb"
+	"github.com/r-scheele/sqr/internal/val"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// GetInspection returns an inspection request to the agent assigned to it
+func (server *Server) GetInspection(ctx context.Context, req *pb.GetInspectionRequest) (*pb.GetInspectionResponse, error) {
+	violations := validateGetInspectionRequest(req)
+	if violations != nil {
+		return nil, invalidArgumentError(violations)
+	}
+
+	_, err := server.authorizeRequest(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	inspection, err := server.store.GetInspectionRequestByID(ctx, req.GetInspectionRequestId())
+	if err != nil {
+		if errors.Is(err, db.ErrRecordNotFound) {
+			return nil, status.Errorf(codes.NotFound, "inspection request not found")
+		}
+		return nil, status.Errorf(codes.Internal, "failed to get inspection request: %s", err)
+	}
+
+	rsp := &pb.GetInspectionResponse{
+		Inspection: convertInspection(inspection),
+	}
+	return rsp, nil
+}
+
+func validateGetInspectionRequest(req *pb.GetInspectionRequest) (violations []*errdetails.BadRequest_FieldViolation) {
+	if err := val.ValidateID(req.GetInspectionRequestId()); err != nil {
+		violations = append(violations, fieldViolation("inspection_request_id", err))
+	}
+
+	return violations
+}
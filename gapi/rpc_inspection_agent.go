@@ -0,0 +1,312 @@
+package gapi
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hibiken/asynq"
+	"github.com/jackc/pgx/v5/pgtype"
+	db "github.com/r-scheele/sqr/internal/db/sqlc"
+	"github.com/r-scheele/sqr/internal/pb"
+	"github.com/r-scheele/sqr/internal/val"
+	"github.com/r-scheele/sqr/internal/worker"
+	"github.com/rs/zerolog/log"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// licenseVerificationData is stored in verification_data of the license verification of an agent application
+type licenseVerificationData struct {
+	LicenseNumber string    `json:"license_number"`
+	Documents     []string  `json:"documents"`
+	SubmittedAt   time.Time `json:"submitted_at"`
+}
+
+func (server *Server) SubmitAgentApplication(ctx context.Context, req *pb.SubmitAgentApplicationRequest) (*pb.SubmitAgentApplicationResponse, error) {
+	violations := validateSubmitAgentApplicationRequest(req)
+	if violations != nil {
+		return nil, invalidArgumentError(violations)
+	}
+
+	principal, err := server.authorizeRequest(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	var hourlyRate pgtype.Numeric
+	err = hourlyRate.Scan(strconv.FormatFloat(req.GetHourlyRate(), 'f', 2, 64))
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to convert hourly rate: %s", err)
+	}
+
+	licenseData, err := json.Marshal(licenseVerificationData{
+		LicenseNumber: req.GetLicenseNumber(),
+		Documents:     req.GetLicenseDocuments(),
+		SubmittedAt:   time.Now(),
+	})
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to marshal license data")
+	}
+
+	result, err := server.store.SubmitAgentApplicationTx(ctx, db.SubmitAgentApplicationTxParams{
+		SubmitInspectionAgentApplicationParams: db.SubmitInspectionAgentApplicationParams{
+			UserID:          principal.User.ID,
+			LicenseNumber:   pgtype.Text{String: req.GetLicenseNumber(), Valid: true},
+			Specializations: pgtype.Text{String: strings.Join(req.GetSpecializations(), ", "), Valid: true},
+			ServiceAreas:    pgtype.Text{String: strings.Join(req.GetServiceAreas(), ", "), Valid: true},
+			HourlyRate:      hourlyRate,
+		},
+		LicenseData: licenseData,
+	})
+	if err != nil {
+		if errors.Is(err, db.ErrAgentAlreadyApproved) {
+			return nil, status.Errorf(codes.FailedPrecondition, "your application has already been approved")
+		}
+		return nil, status.Errorf(codes.Internal, "failed to submit agent application: %s", err)
+	}
+
+	rsp := &pb.SubmitAgentApplicationResponse{
+		Application: convertAgentApplication(principal.User, result.Profile, req.GetLicenseDocuments()),
+		Message:     "Your application has been submitted for review. We'll let you know once an admin has reviewed it.",
+	}
+	return rsp, nil
+}
+
+func (server *Server) GetAgentApplication(ctx context.Context, req *pb.GetAgentApplicationRequest) (*pb.GetAgentApplicationResponse, error) {
+	violations := validateAdminUserIDRequest(req)
+	if violations != nil {
+		return nil, invalidArgumentError(violations)
+	}
+
+	_, err := server.authorizeRequest(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	user, err := server.store.GetUserByID(ctx, req.GetUserId())
+	if err != nil {
+		if errors.Is(err, db.ErrRecordNotFound) {
+			return nil, status.Errorf(codes.NotFound, "user not found")
+		}
+		return nil, status.Errorf(codes.Internal, "failed to get user: %s", err)
+	}
+
+	profile, err := server.store.GetInspectionAgentProfileByUserID(ctx, user.ID)
+	if err != nil {
+		if errors.Is(err, db.ErrRecordNotFound) {
+			return nil, status.Errorf(codes.NotFound, "agent application not found")
+		}
+		return nil, status.Errorf(codes.Internal, "failed to get agent application: %s", err)
+	}
+
+	documents, err := server.licenseDocuments(ctx, user.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	rsp := &pb.GetAgentApplicationResponse{
+		Application: convertAgentApplication(user, profile, documents),
+	}
+	return rsp, nil
+}
+
+func (server *Server) ListAgentApplications(ctx context.Context, req *pb.ListAgentApplicationsRequest) (*pb.ListAgentApplicationsResponse, error) {
+	violations := validateListAgentApplicationsRequest(req)
+	if violations != nil {
+		return nil, invalidArgumentError(violations)
+	}
+
+	_, err := server.authorizeRequest(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	applications, err := server.store.ListPendingAgentApplications(ctx, db.ListPendingAgentApplicationsParams{
+		Limit:  req.GetPageSize(),
+		Offset: (req.GetPageId() - 1) * req.GetPageSize(),
+	})
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to list agent applications: %s", err)
+	}
+
+	totalCount, err := server.store.CountPendingAgentApplications(ctx)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to count agent applications: %s", err)
+	}
+
+	rsp := &pb.ListAgentApplicationsResponse{
+		Applications: make([]*pb.AgentApplication, 0, len(applications)),
+		TotalCount:   totalCount,
+	}
+	for _, application := range applications {
+		rsp.Applications = append(rsp.Applications, convertPendingAgentApplication(application))
+	}
+
+	return rsp, nil
+}
+
+func (server *Server) ApproveAgentApplication(ctx context.Context, req *pb.ApproveAgentApplicationRequest) (*pb.ApproveAgentApplicationResponse, error) {
+	violations := validateAdminUserIDRequest(req)
+	if violations != nil {
+		return nil, invalidArgumentError(violations)
+	}
+
+	principal, err := server.authorizeRequest(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	profile, err := server.reviewAgentApplication(ctx, principal, req.GetUserId(), true, "")
+	if err != nil {
+		return nil, err
+	}
+
+	rsp := &pb.ApproveAgentApplicationResponse{
+		Application: profile,
+	}
+	return rsp, nil
+}
+
+func (server *Server) RejectAgentApplication(ctx context.Context, req *pb.RejectAgentApplicationRequest) (*pb.RejectAgentApplicationResponse, error) {
+	violations := validateRejectAgentApplicationRequest(req)
+	if violations != nil {
+		return nil, invalidArgumentError(violations)
+	}
+
+	principal, err := server.authorizeRequest(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	profile, err := server.reviewAgentApplication(ctx, principal, req.GetUserId(), false, req.GetReason())
+	if err != nil {
+		return nil, err
+	}
+
+	rsp := &pb.RejectAgentApplicationResponse{
+		Application: profile,
+	}
+	return rsp, nil
+}
+
+// reviewAgentApplication records the decision of an admin and notifies the agent of it
+func (server *Server) reviewAgentApplication(ctx context.Context, principal *Principal, agentID int64, approve bool, reason string) (*pb.AgentApplication, error) {
+	user, err := server.store.GetUserByID(ctx, agentID)
+	if err != nil {
+		if errors.Is(err, db.ErrRecordNotFound) {
+			return nil, status.Errorf(codes.NotFound, "user not found")
+		}
+		return nil, status.Errorf(codes.Internal, "failed to get user: %s", err)
+	}
+
+	result, err := server.store.ReviewAgentApplicationTx(ctx, db.ReviewAgentApplicationTxParams{
+		AdminActor:      server.adminActor(ctx, principal),
+		AgentID:         agentID,
+		Approve:         approve,
+		RejectionReason: pgtype.Text{String: reason, Valid: reason != ""},
+		AfterReview: func(profile db.InspectionAgentProfile) error {
+			taskPayload := &worker.PayloadNotifyAgentApplicationReviewed{
+				UserID:   profile.UserID,
+				Approved: approve,
+				Reason:   reason,
+			}
+			opts := []asynq.Option{
+				asynq.MaxRetry(10),
+				asynq.ProcessIn(10 * time.Second),
+				asynq.Queue(worker.QueueDefault),
+			}
+
+			return server.taskDistributor.DistributeTaskNotifyAgentApplicationReviewed(ctx, taskPayload, opts...)
+		},
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, db.ErrRecordNotFound):
+			return nil, status.Errorf(codes.NotFound, "agent application not found")
+		case errors.Is(err, db.ErrApplicationNotPending):
+			return nil, status.Errorf(codes.FailedPrecondition, "agent application is not pending review")
+		}
+		return nil, status.Errorf(codes.Internal, "failed to review agent application: %s", err)
+	}
+
+	documents, err := server.licenseDocuments(ctx, agentID)
+	if err != nil {
+		log.Error().Err(err).Int64("user_id", agentID).Msg("failed to get license documents")
+	}
+
+	return convertAgentApplication(user, result.Profile, documents), nil
+}
+
+// licenseDocuments returns the documents attached to the latest application of an agent
+func (server *Server) licenseDocuments(ctx context.Context, userID int64) ([]string, error) {
+	verification, err := server.store.GetUserVerificationByType(ctx, db.GetUserVerificationByTypeParams{
+		UserID:           userID,
+		VerificationType: db.VerificationTypeEnumLicense,
+	})
+	if err != nil {
+		if errors.Is(err, db.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, status.Errorf(codes.Internal, "failed to get license verification: %s", err)
+	}
+
+	var data licenseVerificationData
+	if len(verification.VerificationData) > 0 {
+		if err := json.Unmarshal(verification.VerificationData, &data); err != nil {
+			return nil, status.Errorf(codes.Internal, "failed to read license verification data")
+		}
+	}
+
+	return data.Documents, nil
+}
+
+func validateSubmitAgentApplicationRequest(req *pb.SubmitAgentApplicationRequest) (violations []*errdetails.BadRequest_FieldViolation) {
+	if err := val.ValidateLicenseNumber(req.GetLicenseNumber()); err != nil {
+		violations = append(violations, fieldViolation("license_number", err))
+	}
+
+	if err := val.ValidateSpecializations(req.GetSpecializations()); err != nil {
+		violations = append(violations, fieldViolation("specializations", err))
+	}
+
+	if err := val.ValidateServiceAreas(req.GetServiceAreas()); err != nil {
+		violations = append(violations, fieldViolation("service_areas", err))
+	}
+
+	if err := val.ValidateHourlyRate(req.GetHourlyRate()); err != nil {
+		violations = append(violations, fieldViolation("hourly_rate", err))
+	}
+
+	if err := val.ValidateLicenseDocuments(req.GetLicenseDocuments()); err != nil {
+		violations = append(violations, fieldViolation("license_documents", err))
+	}
+
+	return violations
+}
+
+func validateListAgentApplicationsRequest(req *pb.ListAgentApplicationsRequest) (violations []*errdetails.BadRequest_FieldViolation) {
+	if err := val.ValidatePageID(req.GetPageId()); err != nil {
+		violations = append(violations, fieldViolation("page_id", err))
+	}
+
+	if err := val.ValidatePageSize(req.GetPageSize()); err != nil {
+		violations = append(violations, fieldViolation("page_size", err))
+	}
+
+	return violations
+}
+
+func validateRejectAgentApplicationRequest(req *pb.RejectAgentApplicationRequest) (violations []*errdetails.BadRequest_FieldViolation) {
+	violations = validateAdminUserIDRequest(req)
+
+	if err := val.ValidateString(req.GetReason(), 10, 500); err != nil {
+		violations = append(violations, fieldViolation("reason", err))
+	}
+
+	return violations
+}
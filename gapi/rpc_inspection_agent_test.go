@@ -0,0 +1,347 @@
+package gapi
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/hibiken/asynq"
+	"github.com/jackc/pgx/v5/pgtype"
+	mockdb "github.com/r-scheele/sqr/internal/db/mock"
+	db "github.com/r-scheele/sqr/internal/db/sqlc"
+	"github.com/r-scheele/sqr/internal/pb"
+	"github.com/r-scheele/sqr/internal/token"
+	"github.com/r-scheele/sqr/internal/util"
+	"github.com/r-scheele/sqr/internal/worker"
+	mockwk "github.com/r-scheele/sqr/internal/worker/mock"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestSubmitAgentApplicationAPI(t *testing.T) {
+	agent, _ := randomUser(t, util.InspectionAgentRole)
+	agent.ID = util.RandomInt(1, 1000)
+
+	validRequest := func() *pb.SubmitAgentApplicationRequest {
+		return &pb.SubmitAgentApplicationRequest{
+			LicenseNumber:    "NIESV/12345",
+			Specializations:  []string{"structural", "electrical"},
+			ServiceAreas:     []string{"Lekki", "Ikeja"},
+			HourlyRate:       15000,
+			LicenseDocuments: []string{"https://files.example.com/license.pdf"},
+		}
+	}
+
+	testCases := []struct {
+		name          string
+		req           func() *pb.SubmitAgentApplicationRequest
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(t *testing.T, res *pb.SubmitAgentApplicationResponse, err error)
+	}{
+		{
+			name: "OK",
+			req:  validRequest,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetUserByID(gomock.Any(), gomock.Eq(agent.ID)).
+					Times(1).
+					Return(agent, nil)
+
+				store.EXPECT().
+					SubmitAgentApplicationTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					DoAndReturn(func(_ context.Context, arg db.SubmitAgentApplicationTxParams) (db.SubmitAgentApplicationTxResult, error) {
+						require.Equal(t, agent.ID, arg.UserID)
+						require.Equal(t, "structural, electrical", arg.Specializations.String)
+						require.Contains(t, string(arg.LicenseData), "license.pdf")
+
+						hourlyRate, err := arg.HourlyRate.Float64Value()
+						require.NoError(t, err)
+						require.Equal(t, 15000.0, hourlyRate.Float64)
+
+						profile := randomAgentProfile(agent.ID)
+						return db.SubmitAgentApplicationTxResult{Profile: profile}, nil
+					})
+			},
+			checkResponse: func(t *testing.T, res *pb.SubmitAgentApplicationResponse, err error) {
+				require.NoError(t, err)
+				require.Equal(t, agentApplicationPending, res.GetApplication().GetStatus())
+				require.Equal(t, []string{"structural", "electrical"}, res.GetApplication().GetSpecializations())
+				require.Len(t, res.GetApplication().GetLicenseDocuments(), 1)
+			},
+		},
+		{
+			name: "AlreadyApproved",
+			req:  validRequest,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetUserByID(gomock.Any(), gomock.Eq(agent.ID)).
+					Times(1).
+					Return(agent, nil)
+
+				store.EXPECT().
+					SubmitAgentApplicationTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.SubmitAgentApplicationTxResult{}, db.ErrAgentAlreadyApproved)
+			},
+			checkResponse: func(t *testing.T, res *pb.SubmitAgentApplicationResponse, err error) {
+				require.Error(t, err)
+				require.Equal(t, codes.FailedPrecondition, status.Code(err))
+			},
+		},
+		{
+			name: "InsecureDocumentURL",
+			req: func() *pb.SubmitAgentApplicationRequest {
+				req := validRequest()
+				req.LicenseDocuments = []string{"http://files.example.com/license.pdf"}
+				return req
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					SubmitAgentApplicationTx(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, res *pb.SubmitAgentApplicationResponse, err error) {
+				require.Error(t, err)
+				requireFieldViolation(t, err, "license_documents")
+			},
+		},
+		{
+			name: "NoServiceAreas",
+			req: func() *pb.SubmitAgentApplicationRequest {
+				req := validRequest()
+				req.ServiceAreas = nil
+				return req
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					SubmitAgentApplicationTx(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, res *pb.SubmitAgentApplicationResponse, err error) {
+				require.Error(t, err)
+				requireFieldViolation(t, err, "service_areas")
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			ctx := newContextWithBearerToken(t, server.tokenMaker, agent, uuid.New(), time.Minute, token.TokenTypeAccessToken)
+
+			res, err := server.SubmitAgentApplication(ctx, tc.req())
+			tc.checkResponse(t, res, err)
+		})
+	}
+}
+
+func TestReviewAgentApplicationAPI(t *testing.T) {
+	admin, _ := randomUser(t, util.AdminRole)
+	admin.ID = util.RandomInt(1, 1000)
+	agent, _ := randomUser(t, util.InspectionAgentRole)
+	agent.ID = admin.ID + 1
+
+	testCases := []struct {
+		name          string
+		approve       bool
+		reason        string
+		buildStubs    func(t *testing.T, store *mockdb.MockStore, taskDistributor *mockwk.MockTaskDistributor)
+		checkResponse func(t *testing.T, application *pb.AgentApplication, err error)
+	}{
+		{
+			name:    "Approve",
+			approve: true,
+			buildStubs: func(t *testing.T, store *mockdb.MockStore, taskDistributor *mockwk.MockTaskDistributor) {
+				store.EXPECT().
+					GetUserByID(gomock.Any(), gomock.Eq(admin.ID)).
+					Times(1).
+					Return(admin, nil)
+
+				store.EXPECT().
+					GetUserByID(gomock.Any(), gomock.Eq(agent.ID)).
+					Times(1).
+					Return(agent, nil)
+
+				profile := randomAgentProfile(agent.ID)
+				profile.IsApproved = pgtype.Bool{Bool: true, Valid: true}
+				profile.ApprovedBy = pgtype.Int8{Int64: admin.ID, Valid: true}
+				store.EXPECT().
+					ReviewAgentApplicationTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					DoAndReturn(func(_ context.Context, arg db.ReviewAgentApplicationTxParams) (db.ReviewAgentApplicationTxResult, error) {
+						require.Equal(t, agent.ID, arg.AgentID)
+						require.Equal(t, admin.ID, arg.AdminID)
+						require.True(t, arg.Approve)
+
+						err := arg.AfterReview(profile)
+						return db.ReviewAgentApplicationTxResult{Profile: profile}, err
+					})
+
+				taskDistributor.EXPECT().
+					DistributeTaskNotifyAgentApplicationReviewed(gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(1).
+					DoAndReturn(func(_ context.Context, payload *worker.PayloadNotifyAgentApplicationReviewed, _ ...asynq.Option) error {
+						require.Equal(t, agent.ID, payload.UserID)
+						require.True(t, payload.Approved)
+						return nil
+					})
+
+				store.EXPECT().
+					GetUserVerificationByType(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.UserVerification{VerificationData: []byte(`{"documents":["https://files.example.com/license.pdf"]}`)}, nil)
+			},
+			checkResponse: func(t *testing.T, application *pb.AgentApplication, err error) {
+				require.NoError(t, err)
+				require.Equal(t, agentApplicationApproved, application.GetStatus())
+				require.Equal(t, admin.ID, application.GetApprovedBy())
+				require.Len(t, application.GetLicenseDocuments(), 1)
+			},
+		},
+		{
+			name:   "Reject",
+			reason: "The license number does not match the documents",
+			buildStubs: func(t *testing.T, store *mockdb.MockStore, taskDistributor *mockwk.MockTaskDistributor) {
+				store.EXPECT().
+					GetUserByID(gomock.Any(), gomock.Eq(admin.ID)).
+					Times(1).
+					Return(admin, nil)
+
+				store.EXPECT().
+					GetUserByID(gomock.Any(), gomock.Eq(agent.ID)).
+					Times(1).
+					Return(agent, nil)
+
+				profile := randomAgentProfile(agent.ID)
+				profile.RejectedAt = pgtype.Timestamptz{Time: time.Now(), Valid: true}
+				profile.RejectionReason = pgtype.Text{String: "The license number does not match the documents", Valid: true}
+				store.EXPECT().
+					ReviewAgentApplicationTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					DoAndReturn(func(_ context.Context, arg db.ReviewAgentApplicationTxParams) (db.ReviewAgentApplicationTxResult, error) {
+						require.False(t, arg.Approve)
+						require.Equal(t, profile.RejectionReason, arg.RejectionReason)
+
+						err := arg.AfterReview(profile)
+						return db.ReviewAgentApplicationTxResult{Profile: profile}, err
+					})
+
+				taskDistributor.EXPECT().
+					DistributeTaskNotifyAgentApplicationReviewed(gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(1).
+					DoAndReturn(func(_ context.Context, payload *worker.PayloadNotifyAgentApplicationReviewed, _ ...asynq.Option) error {
+						require.False(t, payload.Approved)
+						require.Equal(t, profile.RejectionReason.String, payload.Reason)
+						return nil
+					})
+
+				store.EXPECT().
+					GetUserVerificationByType(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.UserVerification{}, db.ErrRecordNotFound)
+			},
+			checkResponse: func(t *testing.T, application *pb.AgentApplication, err error) {
+				require.NoError(t, err)
+				require.Equal(t, agentApplicationRejected, application.GetStatus())
+				require.NotEmpty(t, application.GetRejectionReason())
+			},
+		},
+		{
+			name:    "NotPending",
+			approve: true,
+			buildStubs: func(t *testing.T, store *mockdb.MockStore, taskDistributor *mockwk.MockTaskDistributor) {
+				store.EXPECT().
+					GetUserByID(gomock.Any(), gomock.Eq(admin.ID)).
+					Times(1).
+					Return(admin, nil)
+
+				store.EXPECT().
+					GetUserByID(gomock.Any(), gomock.Eq(agent.ID)).
+					Times(1).
+					Return(agent, nil)
+
+				store.EXPECT().
+					ReviewAgentApplicationTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.ReviewAgentApplicationTxResult{}, db.ErrApplicationNotPending)
+
+				taskDistributor.EXPECT().
+					DistributeTaskNotifyAgentApplicationReviewed(gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, application *pb.AgentApplication, err error) {
+				require.Error(t, err)
+				require.Equal(t, codes.FailedPrecondition, status.Code(err))
+			},
+		},
+		{
+			name: "RejectWithoutReason",
+			buildStubs: func(t *testing.T, store *mockdb.MockStore, taskDistributor *mockwk.MockTaskDistributor) {
+				store.EXPECT().
+					ReviewAgentApplicationTx(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, application *pb.AgentApplication, err error) {
+				require.Error(t, err)
+				requireFieldViolation(t, err, "reason")
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			storeCtrl := gomock.NewController(t)
+			defer storeCtrl.Finish()
+			store := mockdb.NewMockStore(storeCtrl)
+
+			taskCtrl := gomock.NewController(t)
+			defer taskCtrl.Finish()
+			taskDistributor := mockwk.NewMockTaskDistributor(taskCtrl)
+
+			tc.buildStubs(t, store, taskDistributor)
+			server := newTestServerWithTaskDistributor(t, store, taskDistributor)
+			ctx := newContextWithBearerToken(t, server.tokenMaker, admin, uuid.New(), time.Minute, token.TokenTypeAccessToken)
+
+			if tc.approve {
+				res, err := server.ApproveAgentApplication(ctx, &pb.ApproveAgentApplicationRequest{UserId: agent.ID})
+				tc.checkResponse(t, res.GetApplication(), err)
+				return
+			}
+
+			res, err := server.RejectAgentApplication(ctx, &pb.RejectAgentApplicationRequest{UserId: agent.ID, Reason: tc.reason})
+			tc.checkResponse(t, res.GetApplication(), err)
+		})
+	}
+}
+
+func randomAgentProfile(userID int64) db.InspectionAgentProfile {
+	var hourlyRate pgtype.Numeric
+	_ = hourlyRate.Scan("15000.00")
+
+	return db.InspectionAgentProfile{
+		ID:              util.RandomInt(1, 1000),
+		UserID:          userID,
+		LicenseNumber:   pgtype.Text{String: "NIESV/12345", Valid: true},
+		Specializations: pgtype.Text{String: "structural, electrical", Valid: true},
+		ServiceAreas:    pgtype.Text{String: "Lekki, Ikeja", Valid: true},
+		HourlyRate:      hourlyRate,
+		IsApproved:      pgtype.Bool{Bool: false, Valid: true},
+		SubmittedAt:     pgtype.Timestamptz{Time: time.Now(), Valid: true},
+		CreatedAt:       pgtype.Timestamptz{Time: time.Now(), Valid: true},
+		UpdatedAt:       pgtype.Timestamptz{Time: time.Now(), Valid: true},
+	}
+}
@@ -0,0 +1,164 @@
+package gapi
+
+import (
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+	mockdb "github.com/r-scheele/sqr/internal/db/mock"
+	db "github.com/r-scheele/sqr/internal/db/sqlc"
+	"github.com/r-scheele/sqr/internal/pb"
+	"github.com/r-scheele/sqr/internal/token"
+	"github.com/r-scheele/sqr/internal/util"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestGetInspectionAPI(t *testing.T) {
+	agent, _ := randomUser(t, util.InspectionAgentRole)
+	agent.ID = util.RandomInt(1, 1000)
+	otherAgent, _ := randomUser(t, util.InspectionAgentRole)
+	otherAgent.ID = agent.ID + 1
+	admin, _ := randomUser(t, util.AdminRole)
+	admin.ID = agent.ID + 2
+
+	inspection := db.InspectionRequest{
+		ID:                util.RandomInt(1, 1000),
+		PropertyID:        util.RandomInt(1, 1000),
+		TenantID:          util.RandomInt(1, 1000),
+		LandlordID:        util.RandomInt(1, 1000),
+		InspectionAgentID: pgtype.Int8{Int64: agent.ID, Valid: true},
+		InspectionType:    db.InspectionTypeEnumAgentInspection,
+		RequestedDate:     pgtype.Date{Time: time.Date(2026, 11, 2, 0, 0, 0, 0, time.UTC), Valid: true},
+		RequestedTime:     pgtype.Time{Microseconds: (10 * time.Hour).Microseconds(), Valid: true},
+		Status:            db.NullInspectionStatusEnum{InspectionStatusEnum: db.InspectionStatusEnumAgentAssigned, Valid: true},
+	}
+
+	testCases := []struct {
+		name          string
+		caller        db.User
+		req           *pb.GetInspectionRequest
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(t *testing.T, res *pb.GetInspectionResponse, err error)
+	}{
+		{
+			name:   "AssignedAgent",
+			caller: agent,
+			req:    &pb.GetInspectionRequest{InspectionRequestId: inspection.ID},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetUserByID(gomock.Any(), gomock.Eq(agent.ID)).
+					Times(1).
+					Return(agent, nil)
+
+				// once by the policy, once by the handler
+				store.EXPECT().
+					GetInspectionRequestByID(gomock.Any(), gomock.Eq(inspection.ID)).
+					Times(2).
+					Return(inspection, nil)
+			},
+			checkResponse: func(t *testing.T, res *pb.GetInspectionResponse, err error) {
+				require.NoError(t, err)
+				require.Equal(t, inspection.ID, res.GetInspection().GetId())
+				require.Equal(t, agent.ID, res.GetInspection().GetInspectionAgentId())
+				require.Equal(t, "2026-11-02", res.GetInspection().GetRequestedDate())
+				require.Equal(t, "10:00", res.GetInspection().GetRequestedTime())
+				require.Equal(t, string(db.InspectionStatusEnumAgentAssigned), res.GetInspection().GetStatus())
+			},
+		},
+		{
+			name:   "OtherAgent",
+			caller: otherAgent,
+			req:    &pb.GetInspectionRequest{InspectionRequestId: inspection.ID},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetUserByID(gomock.Any(), gomock.Eq(otherAgent.ID)).
+					Times(1).
+					Return(otherAgent, nil)
+
+				store.EXPECT().
+					GetInspectionRequestByID(gomock.Any(), gomock.Eq(inspection.ID)).
+					Times(1).
+					Return(inspection, nil)
+			},
+			checkResponse: func(t *testing.T, res *pb.GetInspectionResponse, err error) {
+				require.Error(t, err)
+				require.Equal(t, codes.PermissionDenied, status.Code(err))
+			},
+		},
+		{
+			name:   "Admin",
+			caller: admin,
+			req:    &pb.GetInspectionRequest{InspectionRequestId: inspection.ID},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetUserByID(gomock.Any(), gomock.Eq(admin.ID)).
+					Times(1).
+					Return(admin, nil)
+
+				store.EXPECT().
+					GetInspectionRequestByID(gomock.Any(), gomock.Eq(inspection.ID)).
+					Times(1).
+					Return(inspection, nil)
+			},
+			checkResponse: func(t *testing.T, res *pb.GetInspectionResponse, err error) {
+				require.NoError(t, err)
+				require.Equal(t, inspection.ID, res.GetInspection().GetId())
+			},
+		},
+		{
+			name:   "NotFound",
+			caller: agent,
+			req:    &pb.GetInspectionRequest{InspectionRequestId: inspection.ID},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetUserByID(gomock.Any(), gomock.Eq(agent.ID)).
+					Times(1).
+					Return(agent, nil)
+
+				store.EXPECT().
+					GetInspectionRequestByID(gomock.Any(), gomock.Eq(inspection.ID)).
+					Times(1).
+					Return(db.InspectionRequest{}, db.ErrRecordNotFound)
+			},
+			checkResponse: func(t *testing.T, res *pb.GetInspectionResponse, err error) {
+				require.Error(t, err)
+				require.Equal(t, codes.NotFound, status.Code(err))
+			},
+		},
+		{
+			name:   "InvalidID",
+			caller: agent,
+			req:    &pb.GetInspectionRequest{},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetInspectionRequestByID(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, res *pb.GetInspectionResponse, err error) {
+				requireFieldViolation(t, err, "inspection_request_id")
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			ctx := newContextWithBearerToken(t, server.tokenMaker, tc.caller, uuid.New(), time.Minute, token.TokenTypeAccessToken)
+
+			res, err := server.GetInspection(ctx, tc.req)
+			tc.checkResponse(t, res, err)
+		})
+	}
+}
@@ -0,0 +1,329 @@
+package gapi
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	db "github.com/r-scheele/sqr/internal/db/sqlc"
+	"github.com/r-scheele/sqr/internal/pb"
+	"github.com/r-scheele/sqr/internal/util"
+	"github.com/r-scheele/sqr/internal/val"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// CreateLandlordProfile fills in the empty profile every landlord gets at signup. Once it has details,
+// they are changed through the update RPCs.
+func (server *Server) CreateLandlordProfile(ctx context.Context, req *pb.CreateLandlordProfileRequest) (*pb.CreateLandlordProfileResponse, error) {
+	violations := validateCreateLandlordProfileRequest(req)
+	if violations != nil {
+		return nil, invalidArgumentError(violations)
+	}
+
+	principal, err := server.authorizeRequest(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	profile, err := server.store.CreateLandlordProfile(ctx, db.CreateLandlordProfileParams{
+		UserID:               principal.User.ID,
+		BusinessName:         optionalText(req.GetBusinessName()),
+		BusinessRegistration: optionalText(normalizeBusinessRegistration(req.GetBusinessRegistration())),
+		TaxID:                optionalText(req.GetTaxId()),
+		BankName:             optionalText(req.GetBankName()),
+		BankAccount:          optionalText(req.GetBankAccount()),
+		BankAccountName:      optionalText(req.GetBankAccountName()),
+		GuarantorName:        optionalText(req.GetGuarantorName()),
+		GuarantorPhone:       optionalText(req.GetGuarantorPhone()),
+		GuarantorAddress:     optionalText(req.GetGuarantorAddress()),
+	})
+	if err != nil {
+		if errors.Is(err, db.ErrRecordNotFound) {
+			return nil, status.Errorf(codes.AlreadyExists, "landlord profile already exists, update it instead")
+		}
+		return nil, status.Errorf(codes.Internal, "failed to create landlord profile: %s", err)
+	}
+
+	rsp := &pb.CreateLandlordProfileResponse{
+		Profile: convertLandlordProfile(profile, landlordProfileOwner),
+	}
+	return rsp, nil
+}
+
+func (server *Server) GetLandlordProfile(ctx context.Context, req *pb.GetLandlordProfileRequest) (*pb.GetLandlordProfileResponse, error) {
+	violations := validateGetLandlordProfileRequest(req)
+	if violations != nil {
+		return nil, invalidArgumentError(violations)
+	}
+
+	principal, err := server.authorizeRequest(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	targetUser, err := server.store.GetUserByID(ctx, req.GetUserId())
+	if err != nil {
+		if errors.Is(err, db.ErrRecordNotFound) {
+			return nil, status.Errorf(codes.NotFound, "user not found")
+		}
+		return nil, status.Errorf(codes.Internal, "failed to get user: %s", err)
+	}
+
+	if targetUser.UserType != db.UserTypeEnumLandlord {
+		return nil, status.Errorf(codes.InvalidArgument, "user is not a landlord")
+	}
+
+	profile, err := server.store.GetLandlordProfileByUserID(ctx, req.GetUserId())
+	if err != nil {
+		if errors.Is(err, db.ErrRecordNotFound) {
+			return nil, status.Errorf(codes.NotFound, "landlord profile not found")
+		}
+		return nil, status.Errorf(codes.Internal, "failed to get landlord profile: %s", err)
+	}
+
+	audience := landlordProfilePublic
+	switch {
+	case principal.User.ID == profile.UserID:
+		audience = landlordProfileOwner
+	case principal.Payload.Role == util.AdminRole:
+		audience = landlordProfileAdmin
+	}
+
+	rsp := &pb.GetLandlordProfileResponse{
+		Profile: convertLandlordProfile(profile, audience),
+	}
+	return rsp, nil
+}
+
+// UpdateLandlordProfile changes the business details; banking and guarantor details have their own RPCs
+func (server *Server) UpdateLandlordProfile(ctx context.Context, req *pb.UpdateLandlordProfileRequest) (*pb.UpdateLandlordProfileResponse, error) {
+	violations := validateUpdateLandlordProfileRequest(req)
+	if violations != nil {
+		return nil, invalidArgumentError(violations)
+	}
+
+	_, err := server.authorizeRequest(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	profile, err := server.store.GetLandlordProfileByUserID(ctx, req.GetUserId())
+	if err != nil {
+		if errors.Is(err, db.ErrRecordNotFound) {
+			return nil, status.Errorf(codes.NotFound, "landlord profile not found")
+		}
+		return nil, status.Errorf(codes.Internal, "failed to get landlord profile: %s", err)
+	}
+
+	arg := db.UpdateLandlordBusinessDetailsParams{
+		UserID:               profile.UserID,
+		BusinessName:         profile.BusinessName,
+		BusinessRegistration: profile.BusinessRegistration,
+		TaxID:                profile.TaxID,
+	}
+
+	if req.BusinessName != nil {
+		arg.BusinessName = optionalText(req.GetBusinessName())
+	}
+
+	if req.BusinessRegistration != nil {
+		arg.BusinessRegistration = optionalText(normalizeBusinessRegistration(req.GetBusinessRegistration()))
+	}
+
+	if req.TaxId != nil {
+		arg.TaxID = optionalText(req.GetTaxId())
+	}
+
+	profile, err = server.store.UpdateLandlordBusinessDetails(ctx, arg)
+	if err != nil {
+		if errors.Is(err, db.ErrRecordNotFound) {
+			return nil, status.Errorf(codes.NotFound, "landlord profile not found")
+		}
+		return nil, status.Errorf(codes.Internal, "failed to update landlord profile: %s", err)
+	}
+
+	rsp := &pb.UpdateLandlordProfileResponse{
+		Profile: convertLandlordProfile(profile, landlordProfileOwner),
+	}
+	return rsp, nil
+}
+
+func (server *Server) UpdateLandlordBankingDetails(ctx context.Context, req *pb.UpdateLandlordBankingDetailsRequest) (*pb.UpdateLandlordBankingDetailsResponse, error) {
+	violations := validateUpdateLandlordBankingDetailsRequest(req)
+	if violations != nil {
+		return nil, invalidArgumentError(violations)
+	}
+
+	_, err := server.authorizeRequest(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	profile, err := server.store.UpdateLandlordBankingDetails(ctx, db.UpdateLandlordBankingDetailsParams{
+		UserID:          req.GetUserId(),
+		BankName:        optionalText(req.GetBankName()),
+		BankAccount:     optionalText(req.GetBankAccount()),
+		BankAccountName: optionalText(req.GetBankAccountName()),
+	})
+	if err != nil {
+		if errors.Is(err, db.ErrRecordNotFound) {
+			return nil, status.Errorf(codes.NotFound, "landlord profile not found")
+		}
+		return nil, status.Errorf(codes.Internal, "failed to update banking details: %s", err)
+	}
+
+	rsp := &pb.UpdateLandlordBankingDetailsResponse{
+		Profile: convertLandlordProfile(profile, landlordProfileOwner),
+	}
+	return rsp, nil
+}
+
+func (server *Server) UpdateLandlordGuarantorDetails(ctx context.Context, req *pb.UpdateLandlordGuarantorDetailsRequest) (*pb.UpdateLandlordGuarantorDetailsResponse, error) {
+	violations := validateUpdateLandlordGuarantorDetailsRequest(req)
+	if violations != nil {
+		return nil, invalidArgumentError(violations)
+	}
+
+	_, err := server.authorizeRequest(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	profile, err := server.store.UpdateLandlordGuarantorDetails(ctx, db.UpdateLandlordGuarantorDetailsParams{
+		UserID:           req.GetUserId(),
+		GuarantorName:    optionalText(req.GetGuarantorName()),
+		GuarantorPhone:   optionalText(req.GetGuarantorPhone()),
+		GuarantorAddress: optionalText(req.GetGuarantorAddress()),
+	})
+	if err != nil {
+		if errors.Is(err, db.ErrRecordNotFound) {
+			return nil, status.Errorf(codes.NotFound, "landlord profile not found")
+		}
+		return nil, status.Errorf(codes.Internal, "failed to update guarantor details: %s", err)
+	}
+
+	rsp := &pb.UpdateLandlordGuarantorDetailsResponse{
+		Profile: convertLandlordProfile(profile, landlordProfileOwner),
+	}
+	return rsp, nil
+}
+
+// optionalText stores an empty string as NULL
+func optionalText(value string) pgtype.Text {
+	return pgtype.Text{String: value, Valid: value != ""}
+}
+
+func normalizeBusinessRegistration(registration string) string {
+	return strings.ToUpper(strings.ReplaceAll(registration, " ", ""))
+}
+
+func validateCreateLandlordProfileRequest(req *pb.CreateLandlordProfileRequest) (violations []*errdetails.BadRequest_FieldViolation) {
+	violations = append(violations, validateLandlordBusinessDetails(req.GetBusinessName(), req.GetBusinessRegistration(), req.GetTaxId())...)
+
+	// Banking and guarantor details are optional at creation, but each set is all or nothing
+	if req.GetBankName() != "" || req.GetBankAccount() != "" || req.GetBankAccountName() != "" {
+		violations = append(violations, validateLandlordBankingDetails(req.GetBankName(), req.GetBankAccount(), req.GetBankAccountName())...)
+	}
+
+	if req.GetGuarantorName() != "" || req.GetGuarantorPhone() != "" || req.GetGuarantorAddress() != "" {
+		violations = append(violations, validateLandlordGuarantorDetails(req.GetGuarantorName(), req.GetGuarantorPhone(), req.GetGuarantorAddress())...)
+	}
+
+	return violations
+}
+
+func validateGetLandlordProfileRequest(req *pb.GetLandlordProfileRequest) (violations []*errdetails.BadRequest_FieldViolation) {
+	if err := val.ValidateID(req.GetUserId()); err != nil {
+		violations = append(violations, fieldViolation("user_id", err))
+	}
+
+	return violations
+}
+
+func validateUpdateLandlordProfileRequest(req *pb.UpdateLandlordProfileRequest) (violations []*errdetails.BadRequest_FieldViolation) {
+	if err := val.ValidateID(req.GetUserId()); err != nil {
+		violations = append(violations, fieldViolation("user_id", err))
+	}
+
+	violations = append(violations, validateLandlordBusinessDetails(req.GetBusinessName(), req.GetBusinessRegistration(), req.GetTaxId())...)
+
+	return violations
+}
+
+func validateUpdateLandlordBankingDetailsRequest(req *pb.UpdateLandlordBankingDetailsRequest) (violations []*errdetails.BadRequest_FieldViolation) {
+	if err := val.ValidateID(req.GetUserId()); err != nil {
+		violations = append(violations, fieldViolation("user_id", err))
+	}
+
+	violations = append(violations, validateLandlordBankingDetails(req.GetBankName(), req.GetBankAccount(), req.GetBankAccountName())...)
+
+	return violations
+}
+
+func validateUpdateLandlordGuarantorDetailsRequest(req *pb.UpdateLandlordGuarantorDetailsRequest) (violations []*errdetails.BadRequest_FieldViolation) {
+	if err := val.ValidateID(req.GetUserId()); err != nil {
+		violations = append(violations, fieldViolation("user_id", err))
+	}
+
+	violations = append(violations, validateLandlordGuarantorDetails(req.GetGuarantorName(), req.GetGuarantorPhone(), req.GetGuarantorAddress())...)
+
+	return violations
+}
+
+// validateLandlordBusinessDetails skips empty fields, which leave the stored value unchanged
+func validateLandlordBusinessDetails(businessName, businessRegistration, taxID string) (violations []*errdetails.BadRequest_FieldViolation) {
+	if businessName != "" {
+		if err := val.ValidateBusinessName(businessName); err != nil {
+			violations = append(violations, fieldViolation("business_name", err))
+		}
+	}
+
+	if businessRegistration != "" {
+		if err := val.ValidateBusinessRegistration(businessRegistration); err != nil {
+			violations = append(violations, fieldViolation("business_registration", err))
+		}
+	}
+
+	if taxID != "" {
+		if err := val.ValidateTaxID(taxID); err != nil {
+			violations = append(violations, fieldViolation("tax_id", err))
+		}
+	}
+
+	return violations
+}
+
+func validateLandlordBankingDetails(bankName, bankAccount, bankAccountName string) (violations []*errdetails.BadRequest_FieldViolation) {
+	if err := val.ValidateBankName(bankName); err != nil {
+		violations = append(violations, fieldViolation("bank_name", err))
+	}
+
+	if err := val.ValidateBankAccount(bankAccount); err != nil {
+		violations = append(violations, fieldViolation("bank_account", err))
+	}
+
+	if err := val.ValidateBankAccountName(bankAccountName); err != nil {
+		violations = append(violations, fieldViolation("bank_account_name", err))
+	}
+
+	return violations
+}
+
+func validateLandlordGuarantorDetails(guarantorName, guarantorPhone, guarantorAddress string) (violations []*errdetails.BadRequest_FieldViolation) {
+	if err := val.ValidateGuarantorName(guarantorName); err != nil {
+		violations = append(violations, fieldViolation("guarantor_name", err))
+	}
+
+	if err := val.ValidateGuarantorPhone(guarantorPhone); err != nil {
+		violations = append(violations, fieldViolation("guarantor_phone", err))
+	}
+
+	if err := val.ValidateGuarantorAddress(guarantorAddress); err != nil {
+		violations = append(violations, fieldViolation("guarantor_address", err))
+	}
+
+	return violations
+}
@@ -0,0 +1,365 @@
+package gapi
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+	mockdb "github.com/r-scheele/sqr/internal/db/mock"
+	db "github.com/r-scheele/sqr/internal/db/sqlc"
+	"github.com/r-scheele/sqr/internal/pb"
+	"github.com/r-scheele/sqr/internal/token"
+	"github.com/r-scheele/sqr/internal/util"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestGetLandlordProfileAPI(t *testing.T) {
+	landlord, _ := randomUser(t, util.LandlordRole)
+	landlord.ID = util.RandomInt(1, 1000)
+	tenant, _ := randomUser(t, util.TenantRole)
+	tenant.ID = landlord.ID + 1
+	admin, _ := randomUser(t, util.AdminRole)
+	admin.ID = landlord.ID + 2
+
+	profile := randomLandlordProfile(landlord.ID)
+
+	testCases := []struct {
+		name          string
+		req           *pb.GetLandlordProfileRequest
+		caller        db.User
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(t *testing.T, res *pb.GetLandlordProfileResponse, err error)
+	}{
+		{
+			name:   "Owner",
+			req:    &pb.GetLandlordProfileRequest{UserId: landlord.ID},
+			caller: landlord,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetUserByID(gomock.Any(), gomock.Eq(landlord.ID)).
+					Times(2).
+					Return(landlord, nil)
+
+				store.EXPECT().
+					GetLandlordProfileByUserID(gomock.Any(), gomock.Eq(landlord.ID)).
+					Times(1).
+					Return(profile, nil)
+			},
+			checkResponse: func(t *testing.T, res *pb.GetLandlordProfileResponse, err error) {
+				require.NoError(t, err)
+				require.Equal(t, profile.BankAccount.String, res.GetProfile().GetBankAccount())
+				require.Equal(t, profile.GuarantorName.String, res.GetProfile().GetGuarantorName())
+			},
+		},
+		{
+			name:   "Admin",
+			req:    &pb.GetLandlordProfileRequest{UserId: landlord.ID},
+			caller: admin,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetUserByID(gomock.Any(), gomock.Eq(admin.ID)).
+					Times(1).
+					Return(admin, nil)
+
+				store.EXPECT().
+					GetUserByID(gomock.Any(), gomock.Eq(landlord.ID)).
+					Times(1).
+					Return(landlord, nil)
+
+				store.EXPECT().
+					GetLandlordProfileByUserID(gomock.Any(), gomock.Eq(landlord.ID)).
+					Times(1).
+					Return(profile, nil)
+			},
+			checkResponse: func(t *testing.T, res *pb.GetLandlordProfileResponse, err error) {
+				require.NoError(t, err)
+				require.Equal(t, "******"+profile.BankAccount.String[6:], res.GetProfile().GetBankAccount())
+				require.Equal(t, profile.GuarantorName.String, res.GetProfile().GetGuarantorName())
+			},
+		},
+		{
+			name:   "Public",
+			req:    &pb.GetLandlordProfileRequest{UserId: landlord.ID},
+			caller: tenant,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetUserByID(gomock.Any(), gomock.Eq(tenant.ID)).
+					Times(1).
+					Return(tenant, nil)
+
+				store.EXPECT().
+					GetUserByID(gomock.Any(), gomock.Eq(landlord.ID)).
+					Times(1).
+					Return(landlord, nil)
+
+				store.EXPECT().
+					GetLandlordProfileByUserID(gomock.Any(), gomock.Eq(landlord.ID)).
+					Times(1).
+					Return(profile, nil)
+			},
+			checkResponse: func(t *testing.T, res *pb.GetLandlordProfileResponse, err error) {
+				require.NoError(t, err)
+				require.Equal(t, profile.BusinessName.String, res.GetProfile().GetBusinessName())
+				require.Empty(t, res.GetProfile().GetBankAccount())
+				require.Empty(t, res.GetProfile().GetBankName())
+				require.Empty(t, res.GetProfile().GetGuarantorPhone())
+			},
+		},
+		{
+			name:   "NotLandlord",
+			req:    &pb.GetLandlordProfileRequest{UserId: tenant.ID},
+			caller: tenant,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetUserByID(gomock.Any(), gomock.Eq(tenant.ID)).
+					Times(2).
+					Return(tenant, nil)
+
+				store.EXPECT().
+					GetLandlordProfileByUserID(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, res *pb.GetLandlordProfileResponse, err error) {
+				require.Error(t, err)
+				require.Equal(t, codes.InvalidArgument, status.Code(err))
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			ctx := newContextWithBearerToken(t, server.tokenMaker, tc.caller, uuid.New(), time.Minute, token.TokenTypeAccessToken)
+
+			res, err := server.GetLandlordProfile(ctx, tc.req)
+			tc.checkResponse(t, res, err)
+		})
+	}
+}
+
+func TestCreateLandlordProfileAPI(t *testing.T) {
+	landlord, _ := randomUser(t, util.LandlordRole)
+	landlord.ID = util.RandomInt(1, 1000)
+
+	profile := randomLandlordProfile(landlord.ID)
+
+	testCases := []struct {
+		name          string
+		req           *pb.CreateLandlordProfileRequest
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(t *testing.T, res *pb.CreateLandlordProfileResponse, err error)
+	}{
+		{
+			name: "OK",
+			req: &pb.CreateLandlordProfileRequest{
+				BusinessName:         profile.BusinessName.String,
+				BusinessRegistration: "rc 123456",
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetUserByID(gomock.Any(), gomock.Eq(landlord.ID)).
+					Times(1).
+					Return(landlord, nil)
+
+				store.EXPECT().
+					CreateLandlordProfile(gomock.Any(), gomock.Any()).
+					Times(1).
+					DoAndReturn(func(_ context.Context, arg db.CreateLandlordProfileParams) (db.LandlordProfile, error) {
+						require.Equal(t, landlord.ID, arg.UserID)
+						require.Equal(t, "RC123456", arg.BusinessRegistration.String)
+						require.False(t, arg.BankAccount.Valid)
+						return profile, nil
+					})
+			},
+			checkResponse: func(t *testing.T, res *pb.CreateLandlordProfileResponse, err error) {
+				require.NoError(t, err)
+				require.Equal(t, landlord.ID, res.GetProfile().GetUserId())
+			},
+		},
+		{
+			name: "AlreadyExists",
+			req:  &pb.CreateLandlordProfileRequest{BusinessName: profile.BusinessName.String},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetUserByID(gomock.Any(), gomock.Eq(landlord.ID)).
+					Times(1).
+					Return(landlord, nil)
+
+				store.EXPECT().
+					CreateLandlordProfile(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.LandlordProfile{}, db.ErrRecordNotFound)
+			},
+			checkResponse: func(t *testing.T, res *pb.CreateLandlordProfileResponse, err error) {
+				require.Error(t, err)
+				require.Equal(t, codes.AlreadyExists, status.Code(err))
+			},
+		},
+		{
+			name: "IncompleteBankingDetails",
+			req:  &pb.CreateLandlordProfileRequest{BankAccount: profile.BankAccount.String},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					CreateLandlordProfile(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, res *pb.CreateLandlordProfileResponse, err error) {
+				require.Error(t, err)
+				requireFieldViolation(t, err, "bank_name")
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			ctx := newContextWithBearerToken(t, server.tokenMaker, landlord, uuid.New(), time.Minute, token.TokenTypeAccessToken)
+
+			res, err := server.CreateLandlordProfile(ctx, tc.req)
+			tc.checkResponse(t, res, err)
+		})
+	}
+}
+
+func TestUpdateLandlordBankingDetailsAPI(t *testing.T) {
+	landlord, _ := randomUser(t, util.LandlordRole)
+	landlord.ID = util.RandomInt(1, 1000)
+
+	profile := randomLandlordProfile(landlord.ID)
+
+	testCases := []struct {
+		name          string
+		req           *pb.UpdateLandlordBankingDetailsRequest
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(t *testing.T, res *pb.UpdateLandlordBankingDetailsResponse, err error)
+	}{
+		{
+			name: "OK",
+			req: &pb.UpdateLandlordBankingDetailsRequest{
+				UserId:          landlord.ID,
+				BankName:        profile.BankName.String,
+				BankAccount:     profile.BankAccount.String,
+				BankAccountName: profile.BankAccountName.String,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetUserByID(gomock.Any(), gomock.Eq(landlord.ID)).
+					Times(1).
+					Return(landlord, nil)
+
+				arg := db.UpdateLandlordBankingDetailsParams{
+					UserID:          landlord.ID,
+					BankName:        profile.BankName,
+					BankAccount:     profile.BankAccount,
+					BankAccountName: profile.BankAccountName,
+				}
+				store.EXPECT().
+					UpdateLandlordBankingDetails(gomock.Any(), gomock.Eq(arg)).
+					Times(1).
+					Return(profile, nil)
+			},
+			checkResponse: func(t *testing.T, res *pb.UpdateLandlordBankingDetailsResponse, err error) {
+				require.NoError(t, err)
+				require.Equal(t, profile.BankAccount.String, res.GetProfile().GetBankAccount())
+			},
+		},
+		{
+			name: "InvalidBankAccount",
+			req: &pb.UpdateLandlordBankingDetailsRequest{
+				UserId:          landlord.ID,
+				BankName:        profile.BankName.String,
+				BankAccount:     "12345",
+				BankAccountName: profile.BankAccountName.String,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					UpdateLandlordBankingDetails(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, res *pb.UpdateLandlordBankingDetailsResponse, err error) {
+				require.Error(t, err)
+				requireFieldViolation(t, err, "bank_account")
+			},
+		},
+		{
+			name: "NotFound",
+			req: &pb.UpdateLandlordBankingDetailsRequest{
+				UserId:          landlord.ID,
+				BankName:        profile.BankName.String,
+				BankAccount:     profile.BankAccount.String,
+				BankAccountName: profile.BankAccountName.String,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetUserByID(gomock.Any(), gomock.Eq(landlord.ID)).
+					Times(1).
+					Return(landlord, nil)
+
+				store.EXPECT().
+					UpdateLandlordBankingDetails(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.LandlordProfile{}, db.ErrRecordNotFound)
+			},
+			checkResponse: func(t *testing.T, res *pb.UpdateLandlordBankingDetailsResponse, err error) {
+				require.Error(t, err)
+				require.Equal(t, codes.NotFound, status.Code(err))
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			ctx := newContextWithBearerToken(t, server.tokenMaker, landlord, uuid.New(), time.Minute, token.TokenTypeAccessToken)
+
+			res, err := server.UpdateLandlordBankingDetails(ctx, tc.req)
+			tc.checkResponse(t, res, err)
+		})
+	}
+}
+
+func randomLandlordProfile(userID int64) db.LandlordProfile {
+	return db.LandlordProfile{
+		ID:               util.RandomInt(1, 1000),
+		UserID:           userID,
+		BusinessName:     pgtype.Text{String: util.RandomOwner() + " Properties", Valid: true},
+		BankName:         pgtype.Text{String: "First Bank", Valid: true},
+		BankAccount:      pgtype.Text{String: "0123456789", Valid: true},
+		BankAccountName:  pgtype.Text{String: util.RandomOwner() + " " + util.RandomOwner(), Valid: true},
+		GuarantorName:    pgtype.Text{String: util.RandomOwner() + " " + util.RandomOwner(), Valid: true},
+		GuarantorPhone:   pgtype.Text{String: "+2348012345678", Valid: true},
+		GuarantorAddress: pgtype.Text{String: "12 Admiralty Way, Lekki, Lagos", Valid: true},
+		TotalProperties:  pgtype.Int4{Int32: 2, Valid: true},
+		CreatedAt:        pgtype.Timestamptz{Time: time.Now(), Valid: true},
+		UpdatedAt:        pgtype.Timestamptz{Time: time.Now(), Valid: true},
+	}
+}
@@ -0,0 +1,306 @@
+package gapi
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/hibiken/asynq"
+	"github.com/jackc/pgx/v5/pgtype"
+	db "github.com/r-scheele/sqr/internal/db/sqlc"
+	"github.com/r-scheele/sqr/internal/pb"
+	"github.com/r-scheele/sqr/internal/val"
+	"github.com/r-scheele/sqr/internal/worker"
+	"github.com/rs/zerolog/log"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// maxLoginCodesPerHour limits how many codes one identity can be sent
+	maxLoginCodesPerHour = 5
+
+	loginCodeSentMessage = "If an account exists, a sign-in code has been sent."
+)
+
+func (server *Server) RequestLoginCode(ctx context.Context, req *pb.RequestLoginCodeRequest) (*pb.RequestLoginCodeResponse, error) {
+	violations := validateLoginCodeIdentity(req.GetEmail(), req.GetPhone())
+	if violations != nil {
+		return nil, invalidArgumentError(violations)
+	}
+
+	rsp := &pb.RequestLoginCodeResponse{
+		Message: loginCodeSentMessage,
+	}
+
+	// Unknown or unusable accounts get the same answer, so the endpoint can't be used to probe for users
+	user, err := server.getLoginCodeUser(ctx, req.GetEmail(), req.GetPhone())
+	if err != nil {
+		if errors.Is(err, db.ErrRecordNotFound) {
+			return rsp, nil
+		}
+		return nil, status.Errorf(codes.Internal, "failed to find user")
+	}
+	if user.IsActive.Valid && !user.IsActive.Bool {
+		return rsp, nil
+	}
+
+	sent, err := server.store.CountRecentUserVerifications(ctx, db.CountRecentUserVerificationsParams{
+		UserID:           user.ID,
+		VerificationType: db.VerificationTypeEnumLoginCode,
+		CreatedAt:        pgtype.Timestamptz{Time: time.Now().Add(-time.Hour), Valid: true},
+	})
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to count login codes: %s", err)
+	}
+	if sent >= maxLoginCodesPerHour {
+		log.Warn().Int64("user_id", user.ID).Msg("login code limit reached")
+		return rsp, nil
+	}
+
+	destination := loginCodeDestination(user, req.GetEmail())
+	verification, data, err := server.getLoginCodeVerification(ctx, user.ID)
+	if err != nil {
+		return nil, err
+	}
+	if verification != nil && verification.VerificationStatus.VerificationStatusEnum == db.VerificationStatusEnumPending &&
+		data.Destination == destination {
+		if server.checkOTPResend(data) != nil {
+			return rsp, nil
+		}
+	}
+
+	code, data, err := server.newOTP(user.ID, destination)
+	if err != nil {
+		return nil, err
+	}
+
+	dataJSON, err := json.Marshal(data)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to marshal verification data")
+	}
+
+	// Earlier codes are kept for the hourly limit, but only the latest one can be exchanged
+	_, err = server.store.CreateUserVerification(ctx, db.CreateUserVerificationParams{
+		UserID:             user.ID,
+		VerificationType:   db.VerificationTypeEnumLoginCode,
+		VerificationStatus: db.NullVerificationStatusEnum{VerificationStatusEnum: db.VerificationStatusEnumPending, Valid: true},
+		VerificationData:   dataJSON,
+	})
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to create login code: %s", err)
+	}
+
+	opts := []asynq.Option{
+		asynq.MaxRetry(3),
+		asynq.Queue(worker.QueueCritical),
+	}
+	expiresInMinutes := int(server.otpDuration().Minutes())
+
+	if req.GetEmail() != "" {
+		err = server.taskDistributor.DistributeTaskSendLoginCodeEmail(ctx, &worker.PayloadSendLoginCodeEmail{
+			Username:         user.FirstName + " " + user.LastName,
+			Email:            user.Email,
+			Code:             code,
+			ExpiresInMinutes: expiresInMinutes,
+		}, opts...)
+	} else {
+		err = server.taskDistributor.DistributeTaskSendPhoneOTP(ctx, &worker.PayloadSendPhoneOTP{
+			Phone:            user.Phone,
+			Code:             code,
+			ExpiresInMinutes: expiresInMinutes,
+		}, opts...)
+	}
+	if err != nil {
+		log.Error().Err(err).Int64("user_id", user.ID).Msg("failed to distribute login code task")
+		return nil, status.Errorf(codes.Internal, "failed to send login code")
+	}
+
+	return rsp, nil
+}
+
+func (server *Server) ExchangeLoginCode(ctx context.Context, req *pb.ExchangeLoginCodeRequest) (*pb.ExchangeLoginCodeResponse, error) {
+	violations := validateExchangeLoginCodeRequest(req)
+	if violations != nil {
+		return nil, invalidArgumentError(violations)
+	}
+
+	mtdt := server.extractMetadata(ctx)
+
+	err := server.checkLoginAllowedFromIP(ctx, mtdt.ClientIP)
+	if err != nil {
+		return nil, err
+	}
+
+	identity := req.GetEmail()
+	if identity == "" {
+		identity = req.GetPhone()
+	}
+
+	user, err := server.getLoginCodeUser(ctx, req.GetEmail(), req.GetPhone())
+	if err != nil {
+		if errors.Is(err, db.ErrRecordNotFound) {
+			server.recordFailedLogin(ctx, identity, nil)
+			return nil, status.Errorf(codes.Unauthenticated, "invalid or expired code")
+		}
+		return nil, status.Errorf(codes.Internal, "failed to find user")
+	}
+
+	err = server.checkAccountNotLocked(ctx, user.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	verification, data, err := server.getLoginCodeVerification(ctx, user.ID)
+	if err != nil {
+		return nil, err
+	}
+	if verification == nil || verification.VerificationStatus.VerificationStatusEnum != db.VerificationStatusEnumPending ||
+		data.Destination != loginCodeDestination(user, req.GetEmail()) {
+		server.recordFailedLogin(ctx, identity, &user)
+		return nil, status.Errorf(codes.Unauthenticated, "invalid or expired code")
+	}
+
+	attempts := data.Attempts
+	err = server.checkOTP(user.ID, &data, req.GetCode())
+	if err != nil {
+		if data.Attempts != attempts {
+			if saveErr := server.saveVerificationData(ctx, verification.ID, data); saveErr != nil {
+				return nil, saveErr
+			}
+			server.recordFailedLogin(ctx, identity, &user)
+		}
+		return nil, err
+	}
+
+	// Completing the row is what makes the code single-use: a second exchange finds it no longer pending
+	dataJSON, err := json.Marshal(otpData{Destination: data.Destination})
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to marshal verification data")
+	}
+
+	_, err = server.store.CompleteUserVerification(ctx, db.CompleteUserVerificationParams{
+		ID:               verification.ID,
+		VerificationData: dataJSON,
+	})
+	if err != nil {
+		if errors.Is(err, db.ErrRecordNotFound) {
+			return nil, status.Errorf(codes.Unauthenticated, "code has already been used")
+		}
+		return nil, status.Errorf(codes.Internal, "failed to use login code: %s", err)
+	}
+
+	if hasPermission(string(user.UserType), mfaRoles) {
+		enrolment, err := server.getMFAEnrolment(ctx, user.ID)
+		if err != nil {
+			return nil, err
+		}
+		if enrolment != nil {
+			pending, err := server.newMFAPendingResponse(user)
+			if err != nil {
+				return nil, err
+			}
+			return convertLoginToExchangeLoginCodeResponse(pending), nil
+		}
+	}
+
+	_, err = server.store.RecordSuccessfulLoginTx(ctx, db.RecordSuccessfulLoginTxParams{
+		UserID:    user.ID,
+		IpAddress: pgtype.Text{String: mtdt.ClientIP, Valid: mtdt.ClientIP != ""},
+		UserAgent: pgtype.Text{String: mtdt.UserAgent, Valid: mtdt.UserAgent != ""},
+	})
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to record login")
+	}
+
+	session, err := server.createLoginSession(ctx, user, uuid.New())
+	if err != nil {
+		return nil, err
+	}
+
+	return convertLoginToExchangeLoginCodeResponse(session), nil
+}
+
+func (server *Server) getLoginCodeUser(ctx context.Context, email string, phone string) (db.User, error) {
+	if email != "" {
+		return server.store.GetUserByEmail(ctx, email)
+	}
+	return server.store.GetUserByPhone(ctx, phone)
+}
+
+// loginCodeDestination is where the code for a login by email, or else by phone, is delivered
+func loginCodeDestination(user db.User, email string) string {
+	if email != "" {
+		return user.Email
+	}
+	return user.Phone
+}
+
+// getLoginCodeVerification returns the latest login code of the user, or nil if there is none
+func (server *Server) getLoginCodeVerification(ctx context.Context, userID int64) (*db.UserVerification, otpData, error) {
+	var data otpData
+
+	verification, err := server.store.GetUserVerificationByType(ctx, db.GetUserVerificationByTypeParams{
+		UserID:           userID,
+		VerificationType: db.VerificationTypeEnumLoginCode,
+	})
+	if err != nil {
+		if errors.Is(err, db.ErrRecordNotFound) {
+			return nil, data, nil
+		}
+		return nil, data, status.Errorf(codes.Internal, "failed to get login code: %s", err)
+	}
+
+	err = json.Unmarshal(verification.VerificationData, &data)
+	if err != nil {
+		return nil, data, status.Errorf(codes.Internal, "failed to read login code: %s", err)
+	}
+
+	return &verification, data, nil
+}
+
+func convertLoginToExchangeLoginCodeResponse(rsp *pb.LoginUserResponse) *pb.ExchangeLoginCodeResponse {
+	return &pb.ExchangeLoginCodeResponse{
+		User:                  rsp.GetUser(),
+		SessionId:             rsp.GetSessionId(),
+		AccessToken:           rsp.GetAccessToken(),
+		RefreshToken:          rsp.GetRefreshToken(),
+		AccessTokenExpiresAt:  rsp.GetAccessTokenExpiresAt(),
+		RefreshTokenExpiresAt: rsp.GetRefreshTokenExpiresAt(),
+		MfaRequired:           rsp.GetMfaRequired(),
+		MfaToken:              rsp.GetMfaToken(),
+		MfaTokenExpiresAt:     rsp.GetMfaTokenExpiresAt(),
+	}
+}
+
+func validateLoginCodeIdentity(email string, phone string) (violations []*errdetails.BadRequest_FieldViolation) {
+	switch {
+	case email == "" && phone == "":
+		violations = append(violations, fieldViolation("email", errors.New("either email or phone is required")))
+	case email != "" && phone != "":
+		violations = append(violations, fieldViolation("phone", errors.New("only one of email or phone can be given")))
+	case email != "":
+		if err := val.ValidateEmail(email); err != nil {
+			violations = append(violations, fieldViolation("email", err))
+		}
+	default:
+		if err := val.ValidatePhoneNumber(phone); err != nil {
+			violations = append(violations, fieldViolation("phone", err))
+		}
+	}
+
+	return violations
+}
+
+func validateExchangeLoginCodeRequest(req *pb.ExchangeLoginCodeRequest) (violations []*errdetails.BadRequest_FieldViolation) {
+	violations = validateLoginCodeIdentity(req.GetEmail(), req.GetPhone())
+
+	if err := val.ValidateOTPCode(req.GetCode()); err != nil {
+		violations = append(violations, fieldViolation("code", err))
+	}
+
+	return violations
+}
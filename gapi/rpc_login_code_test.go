@@ -0,0 +1,409 @@
+package gapi
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/hibiken/asynq"
+	mockdb "github.com/r-scheele/sqr/internal/db/mock"
+	db "github.com/r-scheele/sqr/internal/db/sqlc"
+	"github.com/r-scheele/sqr/internal/pb"
+	"github.com/r-scheele/sqr/internal/util"
+	"github.com/r-scheele/sqr/internal/worker"
+	mockwk "github.com/r-scheele/sqr/internal/worker/mock"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func randomLoginCodeVerification(t *testing.T, userID int64, verificationStatus db.VerificationStatusEnum, data otpData) db.UserVerification {
+	dataJSON, err := json.Marshal(data)
+	require.NoError(t, err)
+
+	return db.UserVerification{
+		ID:                 util.RandomInt(1, 1000),
+		UserID:             userID,
+		VerificationType:   db.VerificationTypeEnumLoginCode,
+		VerificationStatus: db.NullVerificationStatusEnum{VerificationStatusEnum: verificationStatus, Valid: true},
+		VerificationData:   dataJSON,
+	}
+}
+
+func TestRequestLoginCodeAPI(t *testing.T) {
+	user, _ := randomUser(t, util.TenantRole)
+	user.ID = util.RandomInt(1, 1000)
+
+	testCases := []struct {
+		name          string
+		req           *pb.RequestLoginCodeRequest
+		buildStubs    func(store *mockdb.MockStore, taskDistributor *mockwk.MockTaskDistributor)
+		checkResponse func(t *testing.T, res *pb.RequestLoginCodeResponse, err error)
+	}{
+		{
+			name: "Email",
+			req:  &pb.RequestLoginCodeRequest{Email: user.Email},
+			buildStubs: func(store *mockdb.MockStore, taskDistributor *mockwk.MockTaskDistributor) {
+				store.EXPECT().
+					GetUserByEmail(gomock.Any(), gomock.Eq(user.Email)).
+					Times(1).
+					Return(user, nil)
+
+				store.EXPECT().
+					CountRecentUserVerifications(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(int64(0), nil)
+
+				store.EXPECT().
+					GetUserVerificationByType(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.UserVerification{}, db.ErrRecordNotFound)
+
+				var storedData otpData
+				store.EXPECT().
+					CreateUserVerification(gomock.Any(), gomock.Any()).
+					Times(1).
+					DoAndReturn(func(_ context.Context, arg db.CreateUserVerificationParams) (db.UserVerification, error) {
+						require.Equal(t, db.VerificationTypeEnumLoginCode, arg.VerificationType)
+						require.NoError(t, json.Unmarshal(arg.VerificationData, &storedData))
+						require.Equal(t, user.Email, storedData.Destination)
+						return db.UserVerification{}, nil
+					})
+
+				taskDistributor.EXPECT().
+					DistributeTaskSendLoginCodeEmail(gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(1).
+					DoAndReturn(func(_ context.Context, payload *worker.PayloadSendLoginCodeEmail, _ ...asynq.Option) error {
+						require.Equal(t, user.Email, payload.Email)
+						require.Equal(t, util.HashOTPCode(testOTPHashKey, user.ID, payload.Code), storedData.CodeHash)
+						return nil
+					})
+
+				taskDistributor.EXPECT().
+					DistributeTaskSendPhoneOTP(gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, res *pb.RequestLoginCodeResponse, err error) {
+				require.NoError(t, err)
+				require.Equal(t, loginCodeSentMessage, res.GetMessage())
+			},
+		},
+		{
+			name: "Phone",
+			req:  &pb.RequestLoginCodeRequest{Phone: user.Phone},
+			buildStubs: func(store *mockdb.MockStore, taskDistributor *mockwk.MockTaskDistributor) {
+				store.EXPECT().
+					GetUserByPhone(gomock.Any(), gomock.Eq(user.Phone)).
+					Times(1).
+					Return(user, nil)
+
+				store.EXPECT().
+					CountRecentUserVerifications(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(int64(1), nil)
+
+				store.EXPECT().
+					GetUserVerificationByType(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.UserVerification{}, db.ErrRecordNotFound)
+
+				store.EXPECT().
+					CreateUserVerification(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.UserVerification{}, nil)
+
+				taskDistributor.EXPECT().
+					DistributeTaskSendPhoneOTP(gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(nil)
+			},
+			checkResponse: func(t *testing.T, res *pb.RequestLoginCodeResponse, err error) {
+				require.NoError(t, err)
+			},
+		},
+		{
+			name: "UnknownUser",
+			req:  &pb.RequestLoginCodeRequest{Email: user.Email},
+			buildStubs: func(store *mockdb.MockStore, taskDistributor *mockwk.MockTaskDistributor) {
+				store.EXPECT().
+					GetUserByEmail(gomock.Any(), gomock.Eq(user.Email)).
+					Times(1).
+					Return(db.User{}, db.ErrRecordNotFound)
+
+				store.EXPECT().
+					CreateUserVerification(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, res *pb.RequestLoginCodeResponse, err error) {
+				require.NoError(t, err)
+				require.Equal(t, loginCodeSentMessage, res.GetMessage())
+			},
+		},
+		{
+			name: "HourlyLimitReached",
+			req:  &pb.RequestLoginCodeRequest{Email: user.Email},
+			buildStubs: func(store *mockdb.MockStore, taskDistributor *mockwk.MockTaskDistributor) {
+				store.EXPECT().
+					GetUserByEmail(gomock.Any(), gomock.Eq(user.Email)).
+					Times(1).
+					Return(user, nil)
+
+				store.EXPECT().
+					CountRecentUserVerifications(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(int64(maxLoginCodesPerHour), nil)
+
+				store.EXPECT().
+					CreateUserVerification(gomock.Any(), gomock.Any()).
+					Times(0)
+
+				taskDistributor.EXPECT().
+					DistributeTaskSendLoginCodeEmail(gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, res *pb.RequestLoginCodeResponse, err error) {
+				require.NoError(t, err)
+				require.Equal(t, loginCodeSentMessage, res.GetMessage())
+			},
+		},
+		{
+			name: "EmailAndPhone",
+			req:  &pb.RequestLoginCodeRequest{Email: user.Email, Phone: user.Phone},
+			buildStubs: func(store *mockdb.MockStore, taskDistributor *mockwk.MockTaskDistributor) {
+				store.EXPECT().
+					GetUserByEmail(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, res *pb.RequestLoginCodeResponse, err error) {
+				require.Error(t, err)
+				require.Equal(t, codes.InvalidArgument, status.Code(err))
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			storeCtrl := gomock.NewController(t)
+			defer storeCtrl.Finish()
+			store := mockdb.NewMockStore(storeCtrl)
+
+			taskCtrl := gomock.NewController(t)
+			defer taskCtrl.Finish()
+			taskDistributor := mockwk.NewMockTaskDistributor(taskCtrl)
+
+			tc.buildStubs(store, taskDistributor)
+			server := newTestServerWithTaskDistributor(t, store, taskDistributor)
+
+			res, err := server.RequestLoginCode(context.Background(), tc.req)
+			tc.checkResponse(t, res, err)
+		})
+	}
+}
+
+func TestExchangeLoginCodeAPI(t *testing.T) {
+	user, _ := randomUser(t, util.TenantRole)
+	user.ID = util.RandomInt(1, 1000)
+	code := "246810"
+
+	pending := func(t *testing.T, destination string) db.UserVerification {
+		return randomLoginCodeVerification(t, user.ID, db.VerificationStatusEnumPending, otpData{
+			Destination: destination,
+			CodeHash:    util.HashOTPCode(testOTPHashKey, user.ID, code),
+			SentAt:      time.Now(),
+			ExpiresAt:   time.Now().Add(time.Minute),
+		})
+	}
+
+	testCases := []struct {
+		name          string
+		req           *pb.ExchangeLoginCodeRequest
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(t *testing.T, res *pb.ExchangeLoginCodeResponse, err error)
+	}{
+		{
+			name: "OK",
+			req:  &pb.ExchangeLoginCodeRequest{Email: user.Email, Code: code},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetUserByEmail(gomock.Any(), gomock.Eq(user.Email)).
+					Times(1).
+					Return(user, nil)
+
+				store.EXPECT().
+					GetAccountLockout(gomock.Any(), gomock.Eq(user.ID)).
+					Times(1).
+					Return(db.AccountLockout{}, db.ErrRecordNotFound)
+
+				store.EXPECT().
+					GetUserVerificationByType(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(pending(t, user.Email), nil)
+
+				store.EXPECT().
+					CompleteUserVerification(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.UserVerification{}, nil)
+
+				store.EXPECT().
+					RecordSuccessfulLoginTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.RecordSuccessfulLoginTxResult{}, nil)
+
+				store.EXPECT().
+					CreateUserSession(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.UserSession{}, nil)
+			},
+			checkResponse: func(t *testing.T, res *pb.ExchangeLoginCodeResponse, err error) {
+				require.NoError(t, err)
+				require.NotEmpty(t, res.GetAccessToken())
+				require.NotEmpty(t, res.GetRefreshToken())
+				require.False(t, res.GetMfaRequired())
+			},
+		},
+		{
+			name: "CodeSentToOtherChannel",
+			req:  &pb.ExchangeLoginCodeRequest{Phone: user.Phone, Code: code},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetUserByPhone(gomock.Any(), gomock.Eq(user.Phone)).
+					Times(1).
+					Return(user, nil)
+
+				store.EXPECT().
+					GetAccountLockout(gomock.Any(), gomock.Eq(user.ID)).
+					Times(1).
+					Return(db.AccountLockout{}, db.ErrRecordNotFound)
+
+				store.EXPECT().
+					GetUserVerificationByType(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(pending(t, user.Email), nil)
+
+				store.EXPECT().
+					RecordFailedLoginTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.RecordFailedLoginTxResult{}, nil)
+
+				store.EXPECT().
+					CompleteUserVerification(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, res *pb.ExchangeLoginCodeResponse, err error) {
+				require.Error(t, err)
+				require.Equal(t, codes.Unauthenticated, status.Code(err))
+			},
+		},
+		{
+			name: "IncorrectCode",
+			req:  &pb.ExchangeLoginCodeRequest{Email: user.Email, Code: "135790"},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetUserByEmail(gomock.Any(), gomock.Eq(user.Email)).
+					Times(1).
+					Return(user, nil)
+
+				store.EXPECT().
+					GetAccountLockout(gomock.Any(), gomock.Eq(user.ID)).
+					Times(1).
+					Return(db.AccountLockout{}, db.ErrRecordNotFound)
+
+				store.EXPECT().
+					GetUserVerificationByType(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(pending(t, user.Email), nil)
+
+				store.EXPECT().
+					UpdateVerificationData(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.UserVerification{}, nil)
+
+				store.EXPECT().
+					RecordFailedLoginTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.RecordFailedLoginTxResult{}, nil)
+
+				store.EXPECT().
+					CompleteUserVerification(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, res *pb.ExchangeLoginCodeResponse, err error) {
+				require.Error(t, err)
+				require.Equal(t, codes.InvalidArgument, status.Code(err))
+			},
+		},
+		{
+			name: "AlreadyUsed",
+			req:  &pb.ExchangeLoginCodeRequest{Email: user.Email, Code: code},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetUserByEmail(gomock.Any(), gomock.Eq(user.Email)).
+					Times(1).
+					Return(user, nil)
+
+				store.EXPECT().
+					GetAccountLockout(gomock.Any(), gomock.Eq(user.ID)).
+					Times(1).
+					Return(db.AccountLockout{}, db.ErrRecordNotFound)
+
+				store.EXPECT().
+					GetUserVerificationByType(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(pending(t, user.Email), nil)
+
+				store.EXPECT().
+					CompleteUserVerification(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.UserVerification{}, db.ErrRecordNotFound)
+
+				store.EXPECT().
+					CreateUserSession(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, res *pb.ExchangeLoginCodeResponse, err error) {
+				require.Error(t, err)
+				require.Equal(t, codes.Unauthenticated, status.Code(err))
+			},
+		},
+		{
+			name: "UnknownUser",
+			req:  &pb.ExchangeLoginCodeRequest{Email: user.Email, Code: code},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetUserByEmail(gomock.Any(), gomock.Eq(user.Email)).
+					Times(1).
+					Return(db.User{}, db.ErrRecordNotFound)
+
+				store.EXPECT().
+					RecordFailedLoginTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.RecordFailedLoginTxResult{}, nil)
+			},
+			checkResponse: func(t *testing.T, res *pb.ExchangeLoginCodeResponse, err error) {
+				require.Error(t, err)
+				require.Equal(t, codes.Unauthenticated, status.Code(err))
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+
+			res, err := server.ExchangeLoginCode(context.Background(), tc.req)
+			tc.checkResponse(t, res, err)
+		})
+	}
+}
@@ -4,12 +4,13 @@ import (
 	"context"
 	"errors"
 
+	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5/pgtype"
 	db "github.com/r-scheele/sqr/internal/db/sqlc"
 	"github.com/r-scheele/sqr/internal/pb"
 	"github.com/r-scheele/sqr/internal/token"
-	"github.com/r-scheele/sqr/internal/util"
 	"github.com/r-scheele/sqr/internal/val"
+	"github.com/rs/zerolog/log"
 	"google.golang.org/genproto/googleapis/rpc/errdetails"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
@@ -22,24 +23,84 @@ func (server *Server) LoginUser(ctx context.Context, req *pb.LoginUserRequest) (
 		return nil, invalidArgumentError(violations)
 	}
 
+	mtdt := server.extractMetadata(ctx)
+
+	err := server.checkLoginAllowedFromIP(ctx, mtdt.ClientIP)
+	if err != nil {
+		return nil, err
+	}
+
 	user, err := server.store.GetUserByEmail(ctx, req.GetUsername())
 	if err != nil {
 		if errors.Is(err, db.ErrRecordNotFound) {
+			server.recordFailedLogin(ctx, req.GetUsername(), nil)
 			return nil, status.Errorf(codes.NotFound, "user not found")
 		}
 		return nil, status.Errorf(codes.Internal, "failed to find user")
 	}
 
-	err = util.CheckPassword(req.Password, user.PasswordHash)
+	err = server.checkAccountNotLocked(ctx, user.ID)
 	if err != nil {
+		return nil, err
+	}
+
+	needsRehash, err := server.passwordHasher.Verify(req.Password, user.PasswordHash)
+	if err != nil {
+		server.recordFailedLogin(ctx, req.GetUsername(), &user)
 		return nil, status.Errorf(codes.NotFound, "incorrect password")
 	}
+	if needsRehash {
+		server.rehashPassword(ctx, user.ID, req.GetPassword())
+	}
+
+	if hasPermission(string(user.UserType), mfaRoles) {
+		enrolment, err := server.getMFAEnrolment(ctx, user.ID)
+		if err != nil {
+			return nil, err
+		}
+		if enrolment != nil {
+			return server.newMFAPendingResponse(user)
+		}
+	}
 
-	username := user.FirstName + "_" + user.LastName
+	_, err = server.store.RecordSuccessfulLoginTx(ctx, db.RecordSuccessfulLoginTxParams{
+		UserID:    user.ID,
+		IpAddress: pgtype.Text{String: mtdt.ClientIP, Valid: mtdt.ClientIP != ""},
+		UserAgent: pgtype.Text{String: mtdt.UserAgent, Valid: mtdt.UserAgent != ""},
+	})
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to record login")
+	}
+
+	return server.createLoginSession(ctx, user, uuid.New())
+}
+
+// rehashPassword moves a stored hash to the configured algorithm and cost while the password is at hand.
+// The login goes ahead if it fails; the next one tries again.
+func (server *Server) rehashPassword(ctx context.Context, userID int64, password string) {
+	hashedPassword, err := server.passwordHasher.Hash(password)
+	if err != nil {
+		log.Error().Err(err).Int64("user_id", userID).Msg("failed to rehash password")
+		return
+	}
+
+	err = server.store.UpdateUserPassword(ctx, db.UpdateUserPasswordParams{
+		ID:           userID,
+		PasswordHash: hashedPassword,
+	})
+	if err != nil {
+		log.Error().Err(err).Int64("user_id", userID).Msg("failed to store rehashed password")
+	}
+}
 
+// createLoginSession issues the access/refresh token pair for a user who has
+// fully authenticated and stores the refresh session.
+func (server *Server) createLoginSession(ctx context.Context, user db.User, sessionID uuid.UUID) (*pb.LoginUserResponse, error) {
 	accessToken, accessPayload, err := server.tokenMaker.CreateToken(
-		username,
+		user.ID,
+		user.Email,
 		string(user.UserType),
+		sessionID,
 		server.config.AccessTokenDuration,
 		token.TokenTypeAccessToken,
 	)
@@ -48,8 +109,10 @@ func (server *Server) LoginUser(ctx context.Context, req *pb.LoginUserRequest) (
 	}
 
 	refreshToken, refreshPayload, err := server.tokenMaker.CreateToken(
-		username,
+		user.ID,
+		user.Email,
 		string(user.UserType),
+		sessionID,
 		server.config.RefreshTokenDuration,
 		token.TokenTypeRefreshToken,
 	)
@@ -60,6 +123,7 @@ func (server *Server) LoginUser(ctx context.Context, req *pb.LoginUserRequest) (
 	mtdt := server.extractMetadata(ctx)
 	session, err := server.store.CreateUserSession(ctx, db.CreateUserSessionParams{
 		UserID:       user.ID,
+		SessionID:    sessionID,
 		SessionToken: refreshToken,
 		IpAddress:    pgtype.Text{String: mtdt.ClientIP, Valid: mtdt.ClientIP != ""},
 		DeviceInfo:   pgtype.Text{String: mtdt.UserAgent, Valid: mtdt.UserAgent != ""},
@@ -0,0 +1,428 @@
+package gapi
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/jackc/pgx/v5/pgtype"
+	mockdb "github.com/r-scheele/sqr/internal/db/mock"
+	db "github.com/r-scheele/sqr/internal/db/sqlc"
+	"github.com/r-scheele/sqr/internal/pb"
+	"github.com/r-scheele/sqr/internal/util"
+	mockwk "github.com/r-scheele/sqr/internal/worker/mock"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+func TestLoginUserAPI(t *testing.T) {
+	user, password := randomUser(t, util.TenantRole)
+	user.ID = util.RandomInt(1, 1000)
+	landlord, landlordPassword := randomUser(t, util.LandlordRole)
+	landlord.ID = user.ID + 1
+	clientIP := "203.0.113.7"
+
+	testCases := []struct {
+		name          string
+		req           *pb.LoginUserRequest
+		buildStubs    func(store *mockdb.MockStore, taskDistributor *mockwk.MockTaskDistributor)
+		checkResponse func(t *testing.T, res *pb.LoginUserResponse, err error)
+	}{
+		{
+			name: "OK",
+			req: &pb.LoginUserRequest{
+				Username: user.Email,
+				Password: password,
+			},
+			buildStubs: func(store *mockdb.MockStore, taskDistributor *mockwk.MockTaskDistributor) {
+				store.EXPECT().
+					GetFailedLoginAttempts(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return([]db.AuditLog{}, nil)
+
+				store.EXPECT().
+					GetUserByEmail(gomock.Any(), gomock.Eq(user.Email)).
+					Times(1).
+					Return(user, nil)
+
+				store.EXPECT().
+					GetAccountLockout(gomock.Any(), gomock.Eq(user.ID)).
+					Times(1).
+					Return(db.AccountLockout{}, db.ErrRecordNotFound)
+
+				store.EXPECT().
+					RecordSuccessfulLoginTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					DoAndReturn(func(_ context.Context, arg db.RecordSuccessfulLoginTxParams) (db.RecordSuccessfulLoginTxResult, error) {
+						require.Equal(t, user.ID, arg.UserID)
+						require.Equal(t, clientIP, arg.IpAddress.String)
+						return db.RecordSuccessfulLoginTxResult{}, nil
+					})
+
+				store.EXPECT().
+					CreateUserSession(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.UserSession{}, nil)
+			},
+			checkResponse: func(t *testing.T, res *pb.LoginUserResponse, err error) {
+				require.NoError(t, err)
+				require.NotEmpty(t, res.GetAccessToken())
+				require.NotEmpty(t, res.GetRefreshToken())
+			},
+		},
+		{
+			name: "MFARequired",
+			req: &pb.LoginUserRequest{
+				Username: landlord.Email,
+				Password: landlordPassword,
+			},
+			buildStubs: func(store *mockdb.MockStore, taskDistributor *mockwk.MockTaskDistributor) {
+				store.EXPECT().
+					GetFailedLoginAttempts(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return([]db.AuditLog{}, nil)
+
+				store.EXPECT().
+					GetUserByEmail(gomock.Any(), gomock.Eq(landlord.Email)).
+					Times(1).
+					Return(landlord, nil)
+
+				store.EXPECT().
+					GetAccountLockout(gomock.Any(), gomock.Eq(landlord.ID)).
+					Times(1).
+					Return(db.AccountLockout{}, db.ErrRecordNotFound)
+
+				store.EXPECT().
+					GetUserVerificationByType(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(randomTOTPVerification(t, landlord.ID, db.VerificationStatusEnumVerified, totpData{Secret: "JBSWY3DPEHPK3PXP"}), nil)
+
+				store.EXPECT().
+					RecordSuccessfulLoginTx(gomock.Any(), gomock.Any()).
+					Times(0)
+
+				store.EXPECT().
+					CreateUserSession(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, res *pb.LoginUserResponse, err error) {
+				require.NoError(t, err)
+				require.True(t, res.GetMfaRequired())
+				require.NotEmpty(t, res.GetMfaToken())
+				require.Empty(t, res.GetAccessToken())
+				require.Empty(t, res.GetRefreshToken())
+			},
+		},
+		{
+			name: "UserNotFound",
+			req: &pb.LoginUserRequest{
+				Username: user.Email,
+				Password: password,
+			},
+			buildStubs: func(store *mockdb.MockStore, taskDistributor *mockwk.MockTaskDistributor) {
+				store.EXPECT().
+					GetFailedLoginAttempts(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return([]db.AuditLog{}, nil)
+
+				store.EXPECT().
+					GetUserByEmail(gomock.Any(), gomock.Eq(user.Email)).
+					Times(1).
+					Return(db.User{}, db.ErrRecordNotFound)
+
+				store.EXPECT().
+					RecordFailedLoginTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					DoAndReturn(func(_ context.Context, arg db.RecordFailedLoginTxParams) (db.RecordFailedLoginTxResult, error) {
+						require.False(t, arg.UserID.Valid)
+						require.Equal(t, user.Email, arg.Email)
+						return db.RecordFailedLoginTxResult{}, nil
+					})
+			},
+			checkResponse: func(t *testing.T, res *pb.LoginUserResponse, err error) {
+				require.Error(t, err)
+				require.Equal(t, codes.NotFound, status.Code(err))
+			},
+		},
+		{
+			name: "IncorrectPassword",
+			req: &pb.LoginUserRequest{
+				Username: user.Email,
+				Password: "incorrect",
+			},
+			buildStubs: func(store *mockdb.MockStore, taskDistributor *mockwk.MockTaskDistributor) {
+				store.EXPECT().
+					GetFailedLoginAttempts(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return([]db.AuditLog{}, nil)
+
+				store.EXPECT().
+					GetUserByEmail(gomock.Any(), gomock.Eq(user.Email)).
+					Times(1).
+					Return(user, nil)
+
+				store.EXPECT().
+					GetAccountLockout(gomock.Any(), gomock.Eq(user.ID)).
+					Times(1).
+					Return(db.AccountLockout{UserID: user.ID, FailedAttempts: 1}, nil)
+
+				store.EXPECT().
+					RecordFailedLoginTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					DoAndReturn(func(_ context.Context, arg db.RecordFailedLoginTxParams) (db.RecordFailedLoginTxResult, error) {
+						require.Equal(t, user.ID, arg.UserID.Int64)
+						require.Equal(t, int32(defaultLoginMaxFailedAttempts), arg.MaxAttempts)
+						return db.RecordFailedLoginTxResult{}, nil
+					})
+
+				taskDistributor.EXPECT().
+					DistributeTaskSendAccountLockedEmail(gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(0)
+
+				store.EXPECT().
+					CreateUserSession(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, res *pb.LoginUserResponse, err error) {
+				require.Error(t, err)
+				require.Equal(t, codes.NotFound, status.Code(err))
+			},
+		},
+		{
+			name: "IncorrectPasswordLocksAccount",
+			req: &pb.LoginUserRequest{
+				Username: user.Email,
+				Password: "incorrect",
+			},
+			buildStubs: func(store *mockdb.MockStore, taskDistributor *mockwk.MockTaskDistributor) {
+				lockedUntil := time.Now().Add(defaultLoginLockoutDuration)
+
+				store.EXPECT().
+					GetFailedLoginAttempts(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return([]db.AuditLog{}, nil)
+
+				store.EXPECT().
+					GetUserByEmail(gomock.Any(), gomock.Eq(user.Email)).
+					Times(1).
+					Return(user, nil)
+
+				store.EXPECT().
+					GetAccountLockout(gomock.Any(), gomock.Eq(user.ID)).
+					Times(1).
+					Return(db.AccountLockout{UserID: user.ID, FailedAttempts: 4}, nil)
+
+				store.EXPECT().
+					RecordFailedLoginTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.RecordFailedLoginTxResult{
+						Lockout: db.AccountLockout{
+							UserID:       user.ID,
+							LockoutCount: 1,
+							LockedUntil:  pgtype.Timestamptz{Time: lockedUntil, Valid: true},
+						},
+						Locked: true,
+					}, nil)
+
+				taskDistributor.EXPECT().
+					DistributeTaskSendAccountLockedEmail(gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(nil)
+			},
+			checkResponse: func(t *testing.T, res *pb.LoginUserResponse, err error) {
+				require.Error(t, err)
+				require.Equal(t, codes.NotFound, status.Code(err))
+			},
+		},
+		{
+			name: "AccountLocked",
+			req: &pb.LoginUserRequest{
+				Username: user.Email,
+				Password: password,
+			},
+			buildStubs: func(store *mockdb.MockStore, taskDistributor *mockwk.MockTaskDistributor) {
+				store.EXPECT().
+					GetFailedLoginAttempts(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return([]db.AuditLog{}, nil)
+
+				store.EXPECT().
+					GetUserByEmail(gomock.Any(), gomock.Eq(user.Email)).
+					Times(1).
+					Return(user, nil)
+
+				store.EXPECT().
+					GetAccountLockout(gomock.Any(), gomock.Eq(user.ID)).
+					Times(1).
+					Return(db.AccountLockout{
+						UserID:      user.ID,
+						LockedUntil: pgtype.Timestamptz{Time: time.Now().Add(time.Minute), Valid: true},
+					}, nil)
+
+				store.EXPECT().
+					RecordSuccessfulLoginTx(gomock.Any(), gomock.Any()).
+					Times(0)
+
+				store.EXPECT().
+					CreateUserSession(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, res *pb.LoginUserResponse, err error) {
+				require.Error(t, err)
+				require.Equal(t, codes.PermissionDenied, status.Code(err))
+			},
+		},
+		{
+			name: "ExpiredLockout",
+			req: &pb.LoginUserRequest{
+				Username: user.Email,
+				Password: password,
+			},
+			buildStubs: func(store *mockdb.MockStore, taskDistributor *mockwk.MockTaskDistributor) {
+				store.EXPECT().
+					GetFailedLoginAttempts(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return([]db.AuditLog{}, nil)
+
+				store.EXPECT().
+					GetUserByEmail(gomock.Any(), gomock.Eq(user.Email)).
+					Times(1).
+					Return(user, nil)
+
+				store.EXPECT().
+					GetAccountLockout(gomock.Any(), gomock.Eq(user.ID)).
+					Times(1).
+					Return(db.AccountLockout{
+						UserID:      user.ID,
+						LockedUntil: pgtype.Timestamptz{Time: time.Now().Add(-time.Minute), Valid: true},
+					}, nil)
+
+				store.EXPECT().
+					RecordSuccessfulLoginTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.RecordSuccessfulLoginTxResult{}, nil)
+
+				store.EXPECT().
+					CreateUserSession(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.UserSession{}, nil)
+			},
+			checkResponse: func(t *testing.T, res *pb.LoginUserResponse, err error) {
+				require.NoError(t, err)
+				require.NotEmpty(t, res.GetAccessToken())
+			},
+		},
+		{
+			name: "TooManyFailuresFromIP",
+			req: &pb.LoginUserRequest{
+				Username: user.Email,
+				Password: password,
+			},
+			buildStubs: func(store *mockdb.MockStore, taskDistributor *mockwk.MockTaskDistributor) {
+				store.EXPECT().
+					GetFailedLoginAttempts(gomock.Any(), gomock.Any()).
+					Times(1).
+					DoAndReturn(func(_ context.Context, arg db.GetFailedLoginAttemptsParams) ([]db.AuditLog, error) {
+						require.Equal(t, clientIP, arg.Column1)
+						return make([]db.AuditLog, arg.Limit), nil
+					})
+
+				store.EXPECT().
+					GetUserByEmail(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, res *pb.LoginUserResponse, err error) {
+				require.Error(t, err)
+				require.Equal(t, codes.ResourceExhausted, status.Code(err))
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			storeCtrl := gomock.NewController(t)
+			defer storeCtrl.Finish()
+			store := mockdb.NewMockStore(storeCtrl)
+
+			taskCtrl := gomock.NewController(t)
+			defer taskCtrl.Finish()
+			taskDistributor := mockwk.NewMockTaskDistributor(taskCtrl)
+
+			tc.buildStubs(store, taskDistributor)
+			server := newTestServerWithTaskDistributor(t, store, taskDistributor)
+
+			ctx := metadata.NewIncomingContext(context.Background(), metadata.MD{
+				xForwardedForHeader: []string{clientIP},
+			})
+			res, err := server.LoginUser(ctx, tc.req)
+			tc.checkResponse(t, res, err)
+		})
+	}
+}
+
+func TestLoginUserRehashesPassword(t *testing.T) {
+	user, password := randomUser(t, util.TenantRole)
+	user.ID = util.RandomInt(1, 1000)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	store := mockdb.NewMockStore(ctrl)
+
+	store.EXPECT().
+		GetFailedLoginAttempts(gomock.Any(), gomock.Any()).
+		AnyTimes().
+		Return([]db.AuditLog{}, nil)
+
+	store.EXPECT().
+		GetUserByEmail(gomock.Any(), gomock.Eq(user.Email)).
+		Times(1).
+		Return(user, nil)
+
+	store.EXPECT().
+		GetAccountLockout(gomock.Any(), gomock.Eq(user.ID)).
+		Times(1).
+		Return(db.AccountLockout{}, db.ErrRecordNotFound)
+
+	// randomUser hashes with bcrypt, which an argon2id server upgrades on the first login
+	store.EXPECT().
+		UpdateUserPassword(gomock.Any(), gomock.Any()).
+		Times(1).
+		DoAndReturn(func(_ context.Context, arg db.UpdateUserPasswordParams) error {
+			require.Equal(t, user.ID, arg.ID)
+			require.True(t, strings.HasPrefix(arg.PasswordHash, "$argon2id$"))
+			require.NoError(t, util.CheckPassword(password, arg.PasswordHash))
+			return nil
+		})
+
+	store.EXPECT().
+		RecordSuccessfulLoginTx(gomock.Any(), gomock.Any()).
+		Times(1).
+		Return(db.RecordSuccessfulLoginTxResult{}, nil)
+
+	store.EXPECT().
+		CreateUserSession(gomock.Any(), gomock.Any()).
+		Times(1).
+		Return(db.UserSession{}, nil)
+
+	config := util.Config{
+		TokenSymmetricKey:     util.RandomString(32),
+		AccessTokenDuration:   time.Minute,
+		RefreshTokenDuration:  time.Hour,
+		PasswordHashAlgorithm: util.PasswordHashArgon2id,
+	}
+	server, err := NewServer(config, store, newTestCacheManager(t), mockwk.NewMockTaskDistributor(ctrl), nil)
+	require.NoError(t, err)
+
+	res, err := server.LoginUser(context.Background(), &pb.LoginUserRequest{
+		Username: user.Email,
+		Password: password,
+	})
+	require.NoError(t, err)
+	require.NotEmpty(t, res.GetAccessToken())
+}
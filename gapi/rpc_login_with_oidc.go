@@ -0,0 +1,214 @@
+package gapi
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/hibiken/asynq"
+	"github.com/jackc/pgx/v5/pgtype"
+	db "github.com/r-scheele/sqr/internal/db/sqlc"
+	"github.com/r-scheele/sqr/internal/oidc"
+	"github.com/r-scheele/sqr/internal/pb"
+	"github.com/r-scheele/sqr/internal/util"
+	"github.com/r-scheele/sqr/internal/val"
+	"github.com/r-scheele/sqr/internal/worker"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (server *Server) LoginWithOIDC(ctx context.Context, req *pb.LoginWithOIDCRequest) (*pb.LoginWithOIDCResponse, error) {
+	violations := validateLoginWithOIDCRequest(req)
+	if violations != nil {
+		return nil, invalidArgumentError(violations)
+	}
+
+	provider, ok := server.oidcProviders[req.GetProvider()]
+	if !ok {
+		return nil, status.Errorf(codes.FailedPrecondition, "sign in with %s is not available", req.GetProvider())
+	}
+
+	mtdt := server.extractMetadata(ctx)
+
+	err := server.checkLoginAllowedFromIP(ctx, mtdt.ClientIP)
+	if err != nil {
+		return nil, err
+	}
+
+	rawIDToken := req.GetIdToken()
+	if req.GetCode() != "" {
+		rawIDToken, err = provider.ExchangeCode(ctx, req.GetCode(), req.GetRedirectUri())
+		if err != nil {
+			return nil, oidcError(err)
+		}
+	}
+
+	claims, err := provider.VerifyIDToken(ctx, rawIDToken, req.GetNonce())
+	if err != nil {
+		return nil, oidcError(err)
+	}
+
+	// Federated accounts sign in through their provider; nobody knows this password
+	hashedPassword, err := util.HashPassword(util.RandomString(32))
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to hash password: %s", err)
+	}
+
+	arg := db.FederatedLoginTxParams{
+		Provider:      provider.Name(),
+		Subject:       claims.Subject,
+		Email:         claims.Email,
+		EmailVerified: claims.EmailVerified,
+		PasswordHash:  hashedPassword,
+		AfterCreate: func(user db.User) error {
+			taskPayload := &worker.PayloadSendWelcomeEmail{
+				Username: user.FirstName + " " + user.LastName,
+				Email:    user.Email,
+			}
+			opts := []asynq.Option{
+				asynq.MaxRetry(10),
+				asynq.ProcessIn(10 * time.Second),
+				asynq.Queue(worker.QueueDefault),
+			}
+
+			return server.taskDistributor.DistributeTaskSendWelcomeEmail(ctx, taskPayload, opts...)
+		},
+	}
+
+	// Signing up needs the details the provider doesn't give us
+	if req.GetPhoneNumber() != "" && req.GetUserType() != "" {
+		arg.NewUser = &db.CreateUserParams{
+			Email:        claims.Email,
+			Phone:        req.GetPhoneNumber(),
+			PasswordHash: hashedPassword,
+			FirstName:    claims.GivenName,
+			LastName:     claims.FamilyName,
+			UserType:     db.UserTypeEnum(req.GetUserType()),
+		}
+		if arg.NewUser.FirstName == "" {
+			arg.NewUser.FirstName, arg.NewUser.LastName = util.SplitFullName(claims.Name)
+		}
+		if claims.Picture != "" {
+			arg.NewUser.ProfilePictureUrl = pgtype.Text{String: claims.Picture, Valid: true}
+		}
+	}
+
+	result, err := server.store.FederatedLoginTx(ctx, arg)
+	if err != nil {
+		switch {
+		case errors.Is(err, db.ErrIdentityEmailNotVerified):
+			return nil, status.Errorf(codes.FailedPrecondition, "the email of this %s account is not verified", provider.Name())
+		case errors.Is(err, db.ErrRecordNotFound):
+			return nil, status.Errorf(codes.NotFound, "no account is linked to this %s account, provide phone_number and user_type to sign up", provider.Name())
+		case db.ErrorCode(err) == db.UniqueViolation:
+			return nil, status.Errorf(codes.AlreadyExists, "failed to create user: %s", err)
+		}
+		return nil, status.Errorf(codes.Internal, "failed to sign in with %s: %s", provider.Name(), err)
+	}
+
+	user := result.User
+	if result.PasswordReplaced {
+		// Whoever registered the unverified address may still be signed in with the old password,
+		// so the sign-in fails rather than leaving them signed in next to the owner of the address
+		err = server.revokeUserAccess(ctx, user.ID)
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "account linked but failed to sign out its other sessions: %s", err)
+		}
+	}
+
+	if user.IsActive.Valid && !user.IsActive.Bool {
+		return nil, status.Errorf(codes.PermissionDenied, "account is deactivated")
+	}
+
+	err = server.checkAccountNotLocked(ctx, user.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	if hasPermission(string(user.UserType), mfaRoles) {
+		enrolment, err := server.getMFAEnrolment(ctx, user.ID)
+		if err != nil {
+			return nil, err
+		}
+		if enrolment != nil {
+			pending, err := server.newMFAPendingResponse(user)
+			if err != nil {
+				return nil, err
+			}
+			return convertLoginToLoginWithOIDCResponse(pending, result.Created), nil
+		}
+	}
+
+	_, err = server.store.RecordSuccessfulLoginTx(ctx, db.RecordSuccessfulLoginTxParams{
+		UserID:    user.ID,
+		IpAddress: pgtype.Text{String: mtdt.ClientIP, Valid: mtdt.ClientIP != ""},
+		UserAgent: pgtype.Text{String: mtdt.UserAgent, Valid: mtdt.UserAgent != ""},
+	})
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to record login")
+	}
+
+	session, err := server.createLoginSession(ctx, user, uuid.New())
+	if err != nil {
+		return nil, err
+	}
+
+	return convertLoginToLoginWithOIDCResponse(session, result.Created), nil
+}
+
+func oidcError(err error) error {
+	if errors.Is(err, oidc.ErrProviderUnavailable) {
+		return status.Errorf(codes.Unavailable, "identity provider is unavailable, try again later")
+	}
+	return status.Errorf(codes.Unauthenticated, "invalid identity token: %s", err)
+}
+
+func convertLoginToLoginWithOIDCResponse(rsp *pb.LoginUserResponse, created bool) *pb.LoginWithOIDCResponse {
+	return &pb.LoginWithOIDCResponse{
+		User:                  rsp.GetUser(),
+		SessionId:             rsp.GetSessionId(),
+		AccessToken:           rsp.GetAccessToken(),
+		RefreshToken:          rsp.GetRefreshToken(),
+		AccessTokenExpiresAt:  rsp.GetAccessTokenExpiresAt(),
+		RefreshTokenExpiresAt: rsp.GetRefreshTokenExpiresAt(),
+		MfaRequired:           rsp.GetMfaRequired(),
+		MfaToken:              rsp.GetMfaToken(),
+		MfaTokenExpiresAt:     rsp.GetMfaTokenExpiresAt(),
+		Created:               created,
+	}
+}
+
+func validateLoginWithOIDCRequest(req *pb.LoginWithOIDCRequest) (violations []*errdetails.BadRequest_FieldViolation) {
+	if err := val.ValidateString(req.GetProvider(), 1, 50); err != nil {
+		violations = append(violations, fieldViolation("provider", err))
+	}
+
+	switch {
+	case req.GetIdToken() == "" && req.GetCode() == "":
+		violations = append(violations, fieldViolation("id_token", errors.New("either id_token or code is required")))
+	case req.GetIdToken() != "" && req.GetCode() != "":
+		violations = append(violations, fieldViolation("code", errors.New("only one of id_token or code can be given")))
+	case req.GetCode() != "":
+		if err := val.ValidateString(req.GetRedirectUri(), 1, 500); err != nil {
+			violations = append(violations, fieldViolation("redirect_uri", err))
+		}
+	}
+
+	if req.GetPhoneNumber() != "" {
+		if err := val.ValidatePhoneNumber(req.GetPhoneNumber()); err != nil {
+			violations = append(violations, fieldViolation("phone_number", err))
+		}
+	}
+
+	if req.GetUserType() != "" {
+		if err := val.ValidateUserType(req.GetUserType()); err != nil {
+			violations = append(violations, fieldViolation("user_type", err))
+		} else if req.GetUserType() == util.AdminRole {
+			violations = append(violations, fieldViolation("user_type", errors.New("cannot sign up as an admin")))
+		}
+	}
+
+	return violations
+}
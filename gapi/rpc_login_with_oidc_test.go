@@ -0,0 +1,425 @@
+package gapi
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"database/sql"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/golang/mock/gomock"
+	"github.com/jackc/pgx/v5/pgtype"
+	mockdb "github.com/r-scheele/sqr/internal/db/mock"
+	db "github.com/r-scheele/sqr/internal/db/sqlc"
+	"github.com/r-scheele/sqr/internal/oidc"
+	"github.com/r-scheele/sqr/internal/pb"
+	"github.com/r-scheele/sqr/internal/util"
+	mockwk "github.com/r-scheele/sqr/internal/worker/mock"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const testGoogleClientID = "sqr-test.apps.googleusercontent.com"
+
+type eqFederatedLoginTxParamsMatcher struct {
+	arg  db.FederatedLoginTxParams
+	user db.User
+}
+
+func (expected eqFederatedLoginTxParamsMatcher) Matches(x interface{}) bool {
+	actualArg, ok := x.(db.FederatedLoginTxParams)
+	if !ok {
+		return false
+	}
+
+	if actualArg.Provider != expected.arg.Provider || actualArg.Subject != expected.arg.Subject ||
+		actualArg.Email != expected.arg.Email || actualArg.EmailVerified != expected.arg.EmailVerified {
+		return false
+	}
+
+	if (actualArg.NewUser == nil) != (expected.arg.NewUser == nil) {
+		return false
+	}
+	if actualArg.NewUser != nil {
+		newUser := *actualArg.NewUser
+		newUser.PasswordHash = ""
+		if newUser != *expected.arg.NewUser {
+			return false
+		}
+
+		return actualArg.AfterCreate(expected.user) == nil
+	}
+
+	return true
+}
+
+func (e eqFederatedLoginTxParamsMatcher) String() string {
+	return fmt.Sprintf("matches arg %v", e.arg)
+}
+
+func EqFederatedLoginTxParams(arg db.FederatedLoginTxParams, user db.User) gomock.Matcher {
+	return eqFederatedLoginTxParamsMatcher{arg, user}
+}
+
+// newTestGoogleProvider returns a google provider trusting key through a local key set
+func newTestGoogleProvider(t *testing.T, key *rsa.PrivateKey, tokenURL string) oidc.OIDCProvider {
+	jwks := map[string]interface{}{
+		"keys": []map[string]string{{
+			"kid": "test-key",
+			"kty": "RSA",
+			"use": "sig",
+			"n":   base64.RawURLEncoding.EncodeToString(key.N.Bytes()),
+			"e":   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes()),
+		}},
+	}
+	data, err := json.Marshal(jwks)
+	require.NoError(t, err)
+
+	path := filepath.Join(t.TempDir(), "jwks.json")
+	require.NoError(t, os.WriteFile(path, data, 0o600))
+
+	provider, err := oidc.NewGoogleProvider(testGoogleClientID, "secret", "file://"+path, tokenURL)
+	require.NoError(t, err)
+	return provider
+}
+
+func newTestIDToken(t *testing.T, key *rsa.PrivateKey, user db.User, emailVerified bool) string {
+	now := time.Now()
+	token := jwt.NewWithClaims(jwt.SigningMethodRS256, jwt.MapClaims{
+		"iss":            "https://accounts.google.com",
+		"aud":            testGoogleClientID,
+		"sub":            fmt.Sprintf("google-%d", user.ID),
+		"email":          user.Email,
+		"email_verified": emailVerified,
+		"given_name":     user.FirstName,
+		"family_name":    user.LastName,
+		"iat":            now.Unix(),
+		"exp":            now.Add(time.Hour).Unix(),
+	})
+	token.Header["kid"] = "test-key"
+
+	raw, err := token.SignedString(key)
+	require.NoError(t, err)
+	return raw
+}
+
+func TestLoginWithOIDCAPI(t *testing.T) {
+	user, _ := randomUser(t, util.TenantRole)
+	user.ID = util.RandomInt(1, 1000)
+	user.IsActive = pgtype.Bool{Bool: true, Valid: true}
+
+	inactiveUser := user
+	inactiveUser.IsActive = pgtype.Bool{Bool: false, Valid: true}
+
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	otherKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	idToken := newTestIDToken(t, key, user, true)
+
+	tokenServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.FormValue("code") != "good-code" {
+			w.WriteHeader(http.StatusBadRequest)
+			json.NewEncoder(w).Encode(map[string]string{"error": "invalid_grant"})
+			return
+		}
+		json.NewEncoder(w).Encode(map[string]string{"id_token": idToken})
+	}))
+	defer tokenServer.Close()
+
+	provider := newTestGoogleProvider(t, key, tokenServer.URL)
+
+	signIn := db.FederatedLoginTxParams{
+		Provider:      oidc.GoogleProviderName,
+		Subject:       fmt.Sprintf("google-%d", user.ID),
+		Email:         user.Email,
+		EmailVerified: true,
+	}
+	signUp := signIn
+	signUp.NewUser = &db.CreateUserParams{
+		Email:     user.Email,
+		Phone:     user.Phone,
+		FirstName: user.FirstName,
+		LastName:  user.LastName,
+		UserType:  db.UserTypeEnumTenant,
+	}
+
+	loginStubs := func(store *mockdb.MockStore) {
+		store.EXPECT().
+			GetAccountLockout(gomock.Any(), gomock.Eq(user.ID)).
+			Times(1).
+			Return(db.AccountLockout{}, db.ErrRecordNotFound)
+
+		store.EXPECT().
+			RecordSuccessfulLoginTx(gomock.Any(), gomock.Any()).
+			Times(1).
+			Return(db.RecordSuccessfulLoginTxResult{}, nil)
+
+		store.EXPECT().
+			CreateUserSession(gomock.Any(), gomock.Any()).
+			Times(1).
+			Return(db.UserSession{}, nil)
+	}
+
+	testCases := []struct {
+		name          string
+		req           *pb.LoginWithOIDCRequest
+		buildStubs    func(store *mockdb.MockStore, taskDistributor *mockwk.MockTaskDistributor)
+		checkResponse func(t *testing.T, res *pb.LoginWithOIDCResponse, err error)
+	}{
+		{
+			name: "OK",
+			req:  &pb.LoginWithOIDCRequest{Provider: "google", IdToken: idToken},
+			buildStubs: func(store *mockdb.MockStore, taskDistributor *mockwk.MockTaskDistributor) {
+				store.EXPECT().
+					FederatedLoginTx(gomock.Any(), EqFederatedLoginTxParams(signIn, user)).
+					Times(1).
+					Return(db.FederatedLoginTxResult{User: user}, nil)
+
+				loginStubs(store)
+			},
+			checkResponse: func(t *testing.T, res *pb.LoginWithOIDCResponse, err error) {
+				require.NoError(t, err)
+				require.NotEmpty(t, res.GetAccessToken())
+				require.NotEmpty(t, res.GetRefreshToken())
+				require.Equal(t, user.Email, res.GetUser().GetEmail())
+				require.False(t, res.GetCreated())
+			},
+		},
+		{
+			name: "AuthorizationCode",
+			req:  &pb.LoginWithOIDCRequest{Provider: "google", Code: "good-code", RedirectUri: "https://sqr.example.com/callback"},
+			buildStubs: func(store *mockdb.MockStore, taskDistributor *mockwk.MockTaskDistributor) {
+				store.EXPECT().
+					FederatedLoginTx(gomock.Any(), EqFederatedLoginTxParams(signIn, user)).
+					Times(1).
+					Return(db.FederatedLoginTxResult{User: user}, nil)
+
+				loginStubs(store)
+			},
+			checkResponse: func(t *testing.T, res *pb.LoginWithOIDCResponse, err error) {
+				require.NoError(t, err)
+				require.NotEmpty(t, res.GetAccessToken())
+			},
+		},
+		{
+			name: "SignUp",
+			req: &pb.LoginWithOIDCRequest{
+				Provider:    "google",
+				IdToken:     idToken,
+				PhoneNumber: user.Phone,
+				UserType:    util.TenantRole,
+			},
+			buildStubs: func(store *mockdb.MockStore, taskDistributor *mockwk.MockTaskDistributor) {
+				store.EXPECT().
+					FederatedLoginTx(gomock.Any(), EqFederatedLoginTxParams(signUp, user)).
+					Times(1).
+					Return(db.FederatedLoginTxResult{User: user, Created: true, Linked: true}, nil)
+
+				taskDistributor.EXPECT().
+					DistributeTaskSendWelcomeEmail(gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(nil)
+
+				loginStubs(store)
+			},
+			checkResponse: func(t *testing.T, res *pb.LoginWithOIDCResponse, err error) {
+				require.NoError(t, err)
+				require.NotEmpty(t, res.GetAccessToken())
+				require.True(t, res.GetCreated())
+			},
+		},
+		{
+			name: "LinkedToUnverifiedAccount",
+			req:  &pb.LoginWithOIDCRequest{Provider: "google", IdToken: idToken},
+			buildStubs: func(store *mockdb.MockStore, taskDistributor *mockwk.MockTaskDistributor) {
+				store.EXPECT().
+					FederatedLoginTx(gomock.Any(), EqFederatedLoginTxParams(signIn, user)).
+					Times(1).
+					Return(db.FederatedLoginTxResult{User: user, Linked: true, PasswordReplaced: true}, nil)
+
+				store.EXPECT().
+					DeactivateUserSessions(gomock.Any(), gomock.Eq(user.ID)).
+					Times(1).
+					Return(nil)
+
+				loginStubs(store)
+			},
+			checkResponse: func(t *testing.T, res *pb.LoginWithOIDCResponse, err error) {
+				require.NoError(t, err)
+				require.NotEmpty(t, res.GetAccessToken())
+			},
+		},
+		{
+			name: "LinkedRevokeAccessFails",
+			req:  &pb.LoginWithOIDCRequest{Provider: "google", IdToken: idToken},
+			buildStubs: func(store *mockdb.MockStore, taskDistributor *mockwk.MockTaskDistributor) {
+				store.EXPECT().
+					FederatedLoginTx(gomock.Any(), EqFederatedLoginTxParams(signIn, user)).
+					Times(1).
+					Return(db.FederatedLoginTxResult{User: user, Linked: true, PasswordReplaced: true}, nil)
+
+				store.EXPECT().
+					DeactivateUserSessions(gomock.Any(), gomock.Eq(user.ID)).
+					Times(1).
+					Return(sql.ErrConnDone)
+
+				store.EXPECT().
+					CreateUserSession(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, res *pb.LoginWithOIDCResponse, err error) {
+				require.Error(t, err)
+				require.Equal(t, codes.Internal, status.Code(err))
+			},
+		},
+		{
+			name: "NoAccount",
+			req:  &pb.LoginWithOIDCRequest{Provider: "google", IdToken: idToken},
+			buildStubs: func(store *mockdb.MockStore, taskDistributor *mockwk.MockTaskDistributor) {
+				store.EXPECT().
+					FederatedLoginTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.FederatedLoginTxResult{}, db.ErrRecordNotFound)
+			},
+			checkResponse: func(t *testing.T, res *pb.LoginWithOIDCResponse, err error) {
+				require.Error(t, err)
+				require.Equal(t, codes.NotFound, status.Code(err))
+			},
+		},
+		{
+			name: "EmailNotVerified",
+			req:  &pb.LoginWithOIDCRequest{Provider: "google", IdToken: newTestIDToken(t, key, user, false)},
+			buildStubs: func(store *mockdb.MockStore, taskDistributor *mockwk.MockTaskDistributor) {
+				store.EXPECT().
+					FederatedLoginTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.FederatedLoginTxResult{}, db.ErrIdentityEmailNotVerified)
+			},
+			checkResponse: func(t *testing.T, res *pb.LoginWithOIDCResponse, err error) {
+				require.Error(t, err)
+				require.Equal(t, codes.FailedPrecondition, status.Code(err))
+			},
+		},
+		{
+			name: "DeactivatedAccount",
+			req:  &pb.LoginWithOIDCRequest{Provider: "google", IdToken: idToken},
+			buildStubs: func(store *mockdb.MockStore, taskDistributor *mockwk.MockTaskDistributor) {
+				store.EXPECT().
+					FederatedLoginTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.FederatedLoginTxResult{User: inactiveUser}, nil)
+
+				store.EXPECT().
+					CreateUserSession(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, res *pb.LoginWithOIDCResponse, err error) {
+				require.Error(t, err)
+				require.Equal(t, codes.PermissionDenied, status.Code(err))
+			},
+		},
+		{
+			name: "UntrustedSignature",
+			req:  &pb.LoginWithOIDCRequest{Provider: "google", IdToken: newTestIDToken(t, otherKey, user, true)},
+			buildStubs: func(store *mockdb.MockStore, taskDistributor *mockwk.MockTaskDistributor) {
+				store.EXPECT().
+					FederatedLoginTx(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, res *pb.LoginWithOIDCResponse, err error) {
+				require.Error(t, err)
+				require.Equal(t, codes.Unauthenticated, status.Code(err))
+			},
+		},
+		{
+			name: "InvalidCode",
+			req:  &pb.LoginWithOIDCRequest{Provider: "google", Code: "bad-code", RedirectUri: "https://sqr.example.com/callback"},
+			buildStubs: func(store *mockdb.MockStore, taskDistributor *mockwk.MockTaskDistributor) {
+				store.EXPECT().
+					FederatedLoginTx(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, res *pb.LoginWithOIDCResponse, err error) {
+				require.Error(t, err)
+				require.Equal(t, codes.Unauthenticated, status.Code(err))
+			},
+		},
+		{
+			name: "ProviderNotConfigured",
+			req:  &pb.LoginWithOIDCRequest{Provider: "apple", IdToken: idToken},
+			buildStubs: func(store *mockdb.MockStore, taskDistributor *mockwk.MockTaskDistributor) {
+				store.EXPECT().
+					FederatedLoginTx(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, res *pb.LoginWithOIDCResponse, err error) {
+				require.Error(t, err)
+				require.Equal(t, codes.FailedPrecondition, status.Code(err))
+			},
+		},
+		{
+			name: "TokenAndCode",
+			req:  &pb.LoginWithOIDCRequest{Provider: "google", IdToken: idToken, Code: "good-code"},
+			buildStubs: func(store *mockdb.MockStore, taskDistributor *mockwk.MockTaskDistributor) {
+				store.EXPECT().
+					FederatedLoginTx(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, res *pb.LoginWithOIDCResponse, err error) {
+				require.Error(t, err)
+				require.Equal(t, codes.InvalidArgument, status.Code(err))
+			},
+		},
+		{
+			name: "AdminSignUp",
+			req: &pb.LoginWithOIDCRequest{
+				Provider:    "google",
+				IdToken:     idToken,
+				PhoneNumber: user.Phone,
+				UserType:    util.AdminRole,
+			},
+			buildStubs: func(store *mockdb.MockStore, taskDistributor *mockwk.MockTaskDistributor) {
+				store.EXPECT().
+					FederatedLoginTx(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, res *pb.LoginWithOIDCResponse, err error) {
+				require.Error(t, err)
+				require.Equal(t, codes.InvalidArgument, status.Code(err))
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			taskDistributor := mockwk.NewMockTaskDistributor(ctrl)
+			tc.buildStubs(store, taskDistributor)
+
+			server := newTestServerWithTaskDistributor(t, store, taskDistributor)
+			server.oidcProviders[provider.Name()] = provider
+
+			res, err := server.LoginWithOIDC(context.Background(), tc.req)
+			tc.checkResponse(t, res, err)
+		})
+	}
+}
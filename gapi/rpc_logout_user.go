@@ -19,7 +19,7 @@ func (server *Server) LogoutUser(ctx context.Context, req *pb.LogoutUserRequest)
 		return nil, invalidArgumentError(violations)
 	}
 
-	_, err := server.tokenMaker.VerifyToken(req.GetRefreshToken(), token.TokenTypeRefreshToken)
+	refreshPayload, err := server.tokenMaker.VerifyToken(req.GetRefreshToken(), token.TokenTypeRefreshToken)
 	if err != nil {
 		return nil, status.Errorf(codes.Unauthenticated, "invalid refresh token")
 	}
@@ -41,6 +41,17 @@ func (server *Server) LogoutUser(ctx context.Context, req *pb.LogoutUserRequest)
 		return nil, status.Errorf(codes.Internal, "failed to deactivate session: %v", err)
 	}
 
+	// Access tokens issued for this session must stop working as well
+	err = server.denylist.RevokeToken(ctx, refreshPayload)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to revoke refresh token: %v", err)
+	}
+
+	err = server.denylist.RevokeSession(ctx, refreshPayload.SessionID, server.config.AccessTokenDuration)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to revoke access tokens: %v", err)
+	}
+
 	rsp := &pb.LogoutUserResponse{
 		Message: "Successfully logged out",
 	}
@@ -6,6 +6,7 @@ import (
 	"time"
 
 	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5/pgtype"
 	mockdb "github.com/r-scheele/sqr/internal/db/mock"
 	db "github.com/r-scheele/sqr/internal/db/sqlc"
@@ -27,10 +28,11 @@ func TestLogoutUserAPI(t *testing.T) {
 		{
 			name: "OK",
 			setupToken: func(tokenMaker token.Maker) string {
-				username := user.FirstName + "_" + user.LastName
 				refreshToken, _, err := tokenMaker.CreateToken(
-					username,
+					user.ID,
+					user.Email,
 					string(user.UserType),
+					uuid.New(),
 					time.Hour,
 					token.TokenTypeRefreshToken,
 				)
@@ -78,10 +80,11 @@ func TestLogoutUserAPI(t *testing.T) {
 		{
 			name: "SessionNotFound",
 			setupToken: func(tokenMaker token.Maker) string {
-				username := user.FirstName + "_" + user.LastName
 				refreshToken, _, err := tokenMaker.CreateToken(
-					username,
+					user.ID,
+					user.Email,
 					string(user.UserType),
+					uuid.New(),
 					time.Hour,
 					token.TokenTypeRefreshToken,
 				)
@@ -102,10 +105,11 @@ func TestLogoutUserAPI(t *testing.T) {
 		{
 			name: "InactiveSession",
 			setupToken: func(tokenMaker token.Maker) string {
-				username := user.FirstName + "_" + user.LastName
 				refreshToken, _, err := tokenMaker.CreateToken(
-					username,
+					user.ID,
+					user.Email,
 					string(user.UserType),
+					uuid.New(),
 					time.Hour,
 					token.TokenTypeRefreshToken,
 				)
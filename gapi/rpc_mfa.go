@@ -0,0 +1,197 @@
+package gapi
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	db "github.com/r-scheele/sqr/internal/db/sqlc"
+	"github.com/r-scheele/sqr/internal/pb"
+	"github.com/r-scheele/sqr/internal/util"
+	"github.com/r-scheele/sqr/internal/val"
+	"github.com/rs/zerolog/log"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (server *Server) EnrollMFA(ctx context.Context, req *pb.EnrollMFARequest) (*pb.EnrollMFAResponse, error) {
+	principal, err := server.authorizeRequest(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	enrolment, err := server.getMFAEnrolment(ctx, principal.User.ID)
+	if err != nil {
+		return nil, err
+	}
+	if enrolment != nil {
+		return nil, status.Errorf(codes.FailedPrecondition, "two-factor authentication is already enabled")
+	}
+
+	secret, err := util.GenerateTOTPSecret()
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to generate secret")
+	}
+
+	encryptedSecret, err := util.EncryptField(server.config.IdentityEncryptionKey, secret)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to encrypt secret")
+	}
+
+	dataJSON, err := json.Marshal(totpData{Secret: encryptedSecret})
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to marshal mfa enrolment")
+	}
+
+	// Starting over discards any enrolment that was never confirmed
+	err = server.store.DeleteUserVerificationsByType(ctx, db.DeleteUserVerificationsByTypeParams{
+		UserID:           principal.User.ID,
+		VerificationType: db.VerificationTypeEnumTotp,
+	})
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to reset mfa enrolment: %s", err)
+	}
+
+	_, err = server.store.CreateUserVerification(ctx, db.CreateUserVerificationParams{
+		UserID:             principal.User.ID,
+		VerificationType:   db.VerificationTypeEnumTotp,
+		VerificationStatus: db.NullVerificationStatusEnum{VerificationStatusEnum: db.VerificationStatusEnumPending, Valid: true},
+		VerificationData:   dataJSON,
+	})
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to create mfa enrolment: %s", err)
+	}
+
+	rsp := &pb.EnrollMFAResponse{
+		Secret:          secret,
+		ProvisioningUri: util.TOTPProvisioningURI(secret, server.mfaIssuer(), principal.User.Email),
+	}
+	return rsp, nil
+}
+
+func (server *Server) ConfirmMFA(ctx context.Context, req *pb.ConfirmMFARequest) (*pb.ConfirmMFAResponse, error) {
+	violations := validateConfirmMFARequest(req)
+	if violations != nil {
+		return nil, invalidArgumentError(violations)
+	}
+
+	principal, err := server.authorizeRequest(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	enrolment, err := server.getTOTPVerification(ctx, principal.User.ID)
+	if err != nil {
+		return nil, err
+	}
+	if enrolment == nil || enrolment.Verification.VerificationStatus.VerificationStatusEnum != db.VerificationStatusEnumPending {
+		return nil, status.Errorf(codes.FailedPrecondition, "no pending two-factor enrolment")
+	}
+
+	step, ok := util.ValidateTOTPCode(enrolment.Secret, req.GetCode(), time.Now())
+	if !ok {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid verification code")
+	}
+
+	recoveryCodes, err := util.GenerateRecoveryCodes(recoveryCodeCount)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to generate recovery codes")
+	}
+
+	data := totpData{
+		Secret:        enrolment.Data.Secret,
+		RecoveryCodes: make([]string, 0, len(recoveryCodes)),
+		LastUsedStep:  step,
+	}
+	for _, code := range recoveryCodes {
+		data.RecoveryCodes = append(data.RecoveryCodes, util.HashRecoveryCode(code))
+	}
+
+	dataJSON, err := json.Marshal(data)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to marshal mfa enrolment")
+	}
+
+	_, err = server.store.CompleteUserVerification(ctx, db.CompleteUserVerificationParams{
+		ID:               enrolment.Verification.ID,
+		VerificationData: dataJSON,
+	})
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to enable two-factor authentication: %s", err)
+	}
+
+	log.Info().Int64("user_id", principal.User.ID).Msg("two-factor authentication enabled")
+
+	rsp := &pb.ConfirmMFAResponse{
+		RecoveryCodes: recoveryCodes,
+		Message:       "Two-factor authentication enabled. Store the recovery codes somewhere safe, they will not be shown again.",
+	}
+	return rsp, nil
+}
+
+func (server *Server) DisableMFA(ctx context.Context, req *pb.DisableMFARequest) (*pb.DisableMFAResponse, error) {
+	violations := validateDisableMFARequest(req)
+	if violations != nil {
+		return nil, invalidArgumentError(violations)
+	}
+
+	principal, err := server.authorizeRequest(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	enrolment, err := server.getMFAEnrolment(ctx, principal.User.ID)
+	if err != nil {
+		return nil, err
+	}
+	if enrolment == nil {
+		return nil, status.Errorf(codes.FailedPrecondition, "two-factor authentication is not enabled")
+	}
+
+	err = server.checkAccountNotLocked(ctx, principal.User.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Wrong codes count towards the account lockout, as in VerifyMFA, so a stolen session can't guess them
+	ok, err := server.useMFACode(ctx, enrolment, req.GetCode())
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		server.recordFailedLogin(ctx, principal.User.Email, &principal.User)
+		return nil, status.Errorf(codes.InvalidArgument, "invalid verification code")
+	}
+
+	err = server.store.DeleteUserVerificationsByType(ctx, db.DeleteUserVerificationsByTypeParams{
+		UserID:           principal.User.ID,
+		VerificationType: db.VerificationTypeEnumTotp,
+	})
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to disable two-factor authentication: %s", err)
+	}
+
+	log.Info().Int64("user_id", principal.User.ID).Msg("two-factor authentication disabled")
+
+	rsp := &pb.DisableMFAResponse{
+		Message: "Two-factor authentication disabled",
+	}
+	return rsp, nil
+}
+
+func validateConfirmMFARequest(req *pb.ConfirmMFARequest) (violations []*errdetails.BadRequest_FieldViolation) {
+	if err := val.ValidateOTPCode(req.GetCode()); err != nil {
+		violations = append(violations, fieldViolation("code", err))
+	}
+
+	return violations
+}
+
+func validateDisableMFARequest(req *pb.DisableMFARequest) (violations []*errdetails.BadRequest_FieldViolation) {
+	if err := val.ValidateMFACode(req.GetCode()); err != nil {
+		violations = append(violations, fieldViolation("code", err))
+	}
+
+	return violations
+}
@@ -0,0 +1,675 @@
+package gapi
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+	mockdb "github.com/r-scheele/sqr/internal/db/mock"
+	db "github.com/r-scheele/sqr/internal/db/sqlc"
+	"github.com/r-scheele/sqr/internal/pb"
+	"github.com/r-scheele/sqr/internal/token"
+	"github.com/r-scheele/sqr/internal/util"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// randomTOTPVerification stores data with its plaintext secret encrypted, as EnrollMFA does
+func randomTOTPVerification(t *testing.T, userID int64, verificationStatus db.VerificationStatusEnum, data totpData) db.UserVerification {
+	encryptedSecret, err := util.EncryptField(testIdentityEncryptionKey, data.Secret)
+	require.NoError(t, err)
+	data.Secret = encryptedSecret
+
+	dataJSON, err := json.Marshal(data)
+	require.NoError(t, err)
+
+	return db.UserVerification{
+		ID:                 util.RandomInt(1, 1000),
+		UserID:             userID,
+		VerificationType:   db.VerificationTypeEnumTotp,
+		VerificationStatus: db.NullVerificationStatusEnum{VerificationStatusEnum: verificationStatus, Valid: true},
+		VerificationData:   dataJSON,
+	}
+}
+
+func currentTOTPCode(t *testing.T, secret string) string {
+	code, err := util.GenerateTOTPCode(secret, util.TOTPTimeStep(time.Now()))
+	require.NoError(t, err)
+	return code
+}
+
+func TestEnrollMFAAPI(t *testing.T) {
+	landlord, _ := randomUser(t, util.LandlordRole)
+	landlord.ID = util.RandomInt(1, 1000)
+
+	secret, err := util.GenerateTOTPSecret()
+	require.NoError(t, err)
+
+	testCases := []struct {
+		name          string
+		user          db.User
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(t *testing.T, res *pb.EnrollMFAResponse, err error)
+	}{
+		{
+			name: "OK",
+			user: landlord,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetUserByID(gomock.Any(), gomock.Eq(landlord.ID)).
+					Times(1).
+					Return(landlord, nil)
+
+				store.EXPECT().
+					GetUserVerificationByType(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.UserVerification{}, db.ErrRecordNotFound)
+
+				store.EXPECT().
+					DeleteUserVerificationsByType(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(nil)
+
+				store.EXPECT().
+					CreateUserVerification(gomock.Any(), gomock.Any()).
+					Times(1).
+					DoAndReturn(func(_ context.Context, arg db.CreateUserVerificationParams) (db.UserVerification, error) {
+						require.Equal(t, landlord.ID, arg.UserID)
+						require.Equal(t, db.VerificationTypeEnumTotp, arg.VerificationType)
+						require.Equal(t, db.VerificationStatusEnumPending, arg.VerificationStatus.VerificationStatusEnum)
+
+						var data totpData
+						require.NoError(t, json.Unmarshal(arg.VerificationData, &data))
+						decrypted, err := util.DecryptField(testIdentityEncryptionKey, data.Secret)
+						require.NoError(t, err)
+						require.NotEqual(t, decrypted, data.Secret)
+						require.NotEmpty(t, decrypted)
+						return db.UserVerification{}, nil
+					})
+			},
+			checkResponse: func(t *testing.T, res *pb.EnrollMFAResponse, err error) {
+				require.NoError(t, err)
+				require.NotEmpty(t, res.GetSecret())
+				require.Contains(t, res.GetProvisioningUri(), "otpauth://totp/")
+				require.Contains(t, res.GetProvisioningUri(), "secret="+res.GetSecret())
+			},
+		},
+		{
+			name: "AlreadyEnabled",
+			user: landlord,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetUserByID(gomock.Any(), gomock.Eq(landlord.ID)).
+					Times(1).
+					Return(landlord, nil)
+
+				store.EXPECT().
+					GetUserVerificationByType(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(randomTOTPVerification(t, landlord.ID, db.VerificationStatusEnumVerified, totpData{Secret: secret}), nil)
+
+				store.EXPECT().
+					CreateUserVerification(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, res *pb.EnrollMFAResponse, err error) {
+				require.Error(t, err)
+				require.Equal(t, codes.FailedPrecondition, status.Code(err))
+			},
+		},
+		{
+			name: "TenantNotAllowed",
+			user: func() db.User {
+				tenant, _ := randomUser(t, util.TenantRole)
+				tenant.ID = landlord.ID + 1
+				return tenant
+			}(),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					CreateUserVerification(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, res *pb.EnrollMFAResponse, err error) {
+				require.Error(t, err)
+				require.Equal(t, codes.Unauthenticated, status.Code(err))
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			ctx := newContextWithBearerToken(t, server.tokenMaker, tc.user, uuid.New(), time.Minute, token.TokenTypeAccessToken)
+
+			res, err := server.EnrollMFA(ctx, &pb.EnrollMFARequest{})
+			tc.checkResponse(t, res, err)
+		})
+	}
+}
+
+func TestConfirmMFAAPI(t *testing.T) {
+	landlord, _ := randomUser(t, util.LandlordRole)
+	landlord.ID = util.RandomInt(1, 1000)
+
+	secret, err := util.GenerateTOTPSecret()
+	require.NoError(t, err)
+	pending := randomTOTPVerification(t, landlord.ID, db.VerificationStatusEnumPending, totpData{Secret: secret})
+
+	testCases := []struct {
+		name          string
+		code          func(t *testing.T) string
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(t *testing.T, res *pb.ConfirmMFAResponse, err error)
+	}{
+		{
+			name: "OK",
+			code: func(t *testing.T) string { return currentTOTPCode(t, secret) },
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetUserByID(gomock.Any(), gomock.Eq(landlord.ID)).
+					Times(1).
+					Return(landlord, nil)
+
+				store.EXPECT().
+					GetUserVerificationByType(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(pending, nil)
+
+				store.EXPECT().
+					CompleteUserVerification(gomock.Any(), gomock.Any()).
+					Times(1).
+					DoAndReturn(func(_ context.Context, arg db.CompleteUserVerificationParams) (db.UserVerification, error) {
+						require.Equal(t, pending.ID, arg.ID)
+
+						var data totpData
+						require.NoError(t, json.Unmarshal(arg.VerificationData, &data))
+						decrypted, err := util.DecryptField(testIdentityEncryptionKey, data.Secret)
+						require.NoError(t, err)
+						require.Equal(t, secret, decrypted)
+						require.Len(t, data.RecoveryCodes, recoveryCodeCount)
+						require.Equal(t, util.TOTPTimeStep(time.Now()), data.LastUsedStep)
+						return db.UserVerification{}, nil
+					})
+			},
+			checkResponse: func(t *testing.T, res *pb.ConfirmMFAResponse, err error) {
+				require.NoError(t, err)
+				require.Len(t, res.GetRecoveryCodes(), recoveryCodeCount)
+			},
+		},
+		{
+			name: "InvalidCode",
+			code: func(t *testing.T) string {
+				code, err := util.GenerateTOTPCode(secret, util.TOTPTimeStep(time.Now())-10)
+				require.NoError(t, err)
+				return code
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetUserByID(gomock.Any(), gomock.Eq(landlord.ID)).
+					Times(1).
+					Return(landlord, nil)
+
+				store.EXPECT().
+					GetUserVerificationByType(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(pending, nil)
+
+				store.EXPECT().
+					CompleteUserVerification(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, res *pb.ConfirmMFAResponse, err error) {
+				require.Error(t, err)
+				require.Equal(t, codes.InvalidArgument, status.Code(err))
+			},
+		},
+		{
+			name: "NoPendingEnrolment",
+			code: func(t *testing.T) string { return currentTOTPCode(t, secret) },
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetUserByID(gomock.Any(), gomock.Eq(landlord.ID)).
+					Times(1).
+					Return(landlord, nil)
+
+				store.EXPECT().
+					GetUserVerificationByType(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.UserVerification{}, db.ErrRecordNotFound)
+
+				store.EXPECT().
+					CompleteUserVerification(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, res *pb.ConfirmMFAResponse, err error) {
+				require.Error(t, err)
+				require.Equal(t, codes.FailedPrecondition, status.Code(err))
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			ctx := newContextWithBearerToken(t, server.tokenMaker, landlord, uuid.New(), time.Minute, token.TokenTypeAccessToken)
+
+			res, err := server.ConfirmMFA(ctx, &pb.ConfirmMFARequest{Code: tc.code(t)})
+			tc.checkResponse(t, res, err)
+		})
+	}
+}
+
+func TestDisableMFAAPI(t *testing.T) {
+	landlord, _ := randomUser(t, util.LandlordRole)
+	landlord.ID = util.RandomInt(1, 1000)
+
+	secret, err := util.GenerateTOTPSecret()
+	require.NoError(t, err)
+	enabled := randomTOTPVerification(t, landlord.ID, db.VerificationStatusEnumVerified, totpData{Secret: secret})
+
+	testCases := []struct {
+		name          string
+		code          func(t *testing.T) string
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(t *testing.T, res *pb.DisableMFAResponse, err error)
+	}{
+		{
+			name: "OK",
+			code: func(t *testing.T) string { return currentTOTPCode(t, secret) },
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetUserByID(gomock.Any(), gomock.Eq(landlord.ID)).
+					Times(1).
+					Return(landlord, nil)
+
+				store.EXPECT().
+					GetUserVerificationByType(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(enabled, nil)
+
+				store.EXPECT().
+					GetAccountLockout(gomock.Any(), gomock.Eq(landlord.ID)).
+					Times(1).
+					Return(db.AccountLockout{}, db.ErrRecordNotFound)
+
+				store.EXPECT().
+					SwapVerificationData(gomock.Any(), gomock.Any()).
+					Times(1).
+					DoAndReturn(func(_ context.Context, arg db.SwapVerificationDataParams) (int64, error) {
+						require.Equal(t, enabled.ID, arg.ID)
+						require.Equal(t, string(enabled.VerificationData), arg.PreviousData)
+						return 1, nil
+					})
+
+				store.EXPECT().
+					DeleteUserVerificationsByType(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(nil)
+			},
+			checkResponse: func(t *testing.T, res *pb.DisableMFAResponse, err error) {
+				require.NoError(t, err)
+				require.NotEmpty(t, res.GetMessage())
+			},
+		},
+		{
+			name: "WrongCodeRecordsFailure",
+			code: func(t *testing.T) string {
+				code, err := util.GenerateTOTPCode(secret, util.TOTPTimeStep(time.Now())-10)
+				require.NoError(t, err)
+				return code
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetUserByID(gomock.Any(), gomock.Eq(landlord.ID)).
+					Times(1).
+					Return(landlord, nil)
+
+				store.EXPECT().
+					GetUserVerificationByType(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(enabled, nil)
+
+				store.EXPECT().
+					GetAccountLockout(gomock.Any(), gomock.Eq(landlord.ID)).
+					Times(1).
+					Return(db.AccountLockout{}, db.ErrRecordNotFound)
+
+				store.EXPECT().
+					RecordFailedLoginTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					DoAndReturn(func(_ context.Context, arg db.RecordFailedLoginTxParams) (db.RecordFailedLoginTxResult, error) {
+						require.Equal(t, landlord.Email, arg.Email)
+						require.Equal(t, landlord.ID, arg.UserID.Int64)
+						return db.RecordFailedLoginTxResult{}, nil
+					})
+
+				store.EXPECT().
+					DeleteUserVerificationsByType(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, res *pb.DisableMFAResponse, err error) {
+				require.Error(t, err)
+				require.Equal(t, codes.InvalidArgument, status.Code(err))
+			},
+		},
+		{
+			name: "AccountLocked",
+			code: func(t *testing.T) string { return currentTOTPCode(t, secret) },
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetUserByID(gomock.Any(), gomock.Eq(landlord.ID)).
+					Times(1).
+					Return(landlord, nil)
+
+				store.EXPECT().
+					GetUserVerificationByType(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(enabled, nil)
+
+				store.EXPECT().
+					GetAccountLockout(gomock.Any(), gomock.Eq(landlord.ID)).
+					Times(1).
+					Return(db.AccountLockout{
+						UserID:      landlord.ID,
+						LockedUntil: pgtype.Timestamptz{Time: time.Now().Add(time.Hour), Valid: true},
+					}, nil)
+
+				store.EXPECT().
+					SwapVerificationData(gomock.Any(), gomock.Any()).
+					Times(0)
+
+				store.EXPECT().
+					DeleteUserVerificationsByType(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, res *pb.DisableMFAResponse, err error) {
+				require.Error(t, err)
+				require.Equal(t, codes.PermissionDenied, status.Code(err))
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			ctx := newContextWithBearerToken(t, server.tokenMaker, landlord, uuid.New(), time.Minute, token.TokenTypeAccessToken)
+
+			res, err := server.DisableMFA(ctx, &pb.DisableMFARequest{Code: tc.code(t)})
+			tc.checkResponse(t, res, err)
+		})
+	}
+}
+
+func TestVerifyMFAAPI(t *testing.T) {
+	admin, _ := randomUser(t, util.AdminRole)
+	admin.ID = util.RandomInt(1, 1000)
+
+	secret, err := util.GenerateTOTPSecret()
+	require.NoError(t, err)
+	recoveryCode := "abcde-fghjk"
+	enabled := func(t *testing.T, lastUsedStep int64) db.UserVerification {
+		return randomTOTPVerification(t, admin.ID, db.VerificationStatusEnumVerified, totpData{
+			Secret:        secret,
+			RecoveryCodes: []string{util.HashRecoveryCode(recoveryCode)},
+			LastUsedStep:  lastUsedStep,
+		})
+	}
+
+	testCases := []struct {
+		name          string
+		tokenType     token.TokenType
+		code          func(t *testing.T) string
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(t *testing.T, res *pb.VerifyMFAResponse, err error)
+	}{
+		{
+			name:      "OK",
+			tokenType: token.TokenTypeMFAPending,
+			code:      func(t *testing.T) string { return currentTOTPCode(t, secret) },
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetUserByID(gomock.Any(), gomock.Eq(admin.ID)).
+					Times(1).
+					Return(admin, nil)
+
+				store.EXPECT().
+					GetAccountLockout(gomock.Any(), gomock.Eq(admin.ID)).
+					Times(1).
+					Return(db.AccountLockout{}, db.ErrRecordNotFound)
+
+				store.EXPECT().
+					GetUserVerificationByType(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(enabled(t, 0), nil)
+
+				store.EXPECT().
+					SwapVerificationData(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(int64(1), nil)
+
+				store.EXPECT().
+					RecordSuccessfulLoginTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.RecordSuccessfulLoginTxResult{}, nil)
+
+				store.EXPECT().
+					CreateUserSession(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.UserSession{}, nil)
+			},
+			checkResponse: func(t *testing.T, res *pb.VerifyMFAResponse, err error) {
+				require.NoError(t, err)
+				require.NotEmpty(t, res.GetAccessToken())
+				require.NotEmpty(t, res.GetRefreshToken())
+				require.Equal(t, int32(1), res.GetRecoveryCodesLeft())
+			},
+		},
+		{
+			name:      "RecoveryCode",
+			tokenType: token.TokenTypeMFAPending,
+			code:      func(t *testing.T) string { return recoveryCode },
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetUserByID(gomock.Any(), gomock.Eq(admin.ID)).
+					Times(1).
+					Return(admin, nil)
+
+				store.EXPECT().
+					GetAccountLockout(gomock.Any(), gomock.Eq(admin.ID)).
+					Times(1).
+					Return(db.AccountLockout{}, db.ErrRecordNotFound)
+
+				store.EXPECT().
+					GetUserVerificationByType(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(enabled(t, 0), nil)
+
+				store.EXPECT().
+					SwapVerificationData(gomock.Any(), gomock.Any()).
+					Times(1).
+					DoAndReturn(func(_ context.Context, arg db.SwapVerificationDataParams) (int64, error) {
+						var data totpData
+						require.NoError(t, json.Unmarshal(arg.VerificationData, &data))
+						require.Empty(t, data.RecoveryCodes)
+						require.Contains(t, arg.PreviousData, util.HashRecoveryCode(recoveryCode))
+						return 1, nil
+					})
+
+				store.EXPECT().
+					RecordSuccessfulLoginTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.RecordSuccessfulLoginTxResult{}, nil)
+
+				store.EXPECT().
+					CreateUserSession(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.UserSession{}, nil)
+			},
+			checkResponse: func(t *testing.T, res *pb.VerifyMFAResponse, err error) {
+				require.NoError(t, err)
+				require.Equal(t, int32(0), res.GetRecoveryCodesLeft())
+			},
+		},
+		{
+			name:      "ReplayedCode",
+			tokenType: token.TokenTypeMFAPending,
+			code:      func(t *testing.T) string { return currentTOTPCode(t, secret) },
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetUserByID(gomock.Any(), gomock.Eq(admin.ID)).
+					Times(1).
+					Return(admin, nil)
+
+				store.EXPECT().
+					GetAccountLockout(gomock.Any(), gomock.Eq(admin.ID)).
+					Times(1).
+					Return(db.AccountLockout{}, db.ErrRecordNotFound)
+
+				store.EXPECT().
+					GetUserVerificationByType(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(enabled(t, util.TOTPTimeStep(time.Now())), nil)
+
+				store.EXPECT().
+					RecordFailedLoginTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.RecordFailedLoginTxResult{}, nil)
+
+				store.EXPECT().
+					CreateUserSession(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, res *pb.VerifyMFAResponse, err error) {
+				require.Error(t, err)
+				require.Equal(t, codes.Unauthenticated, status.Code(err))
+			},
+		},
+		{
+			name:      "CodeUsedConcurrently",
+			tokenType: token.TokenTypeMFAPending,
+			code:      func(t *testing.T) string { return currentTOTPCode(t, secret) },
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetUserByID(gomock.Any(), gomock.Eq(admin.ID)).
+					Times(1).
+					Return(admin, nil)
+
+				store.EXPECT().
+					GetAccountLockout(gomock.Any(), gomock.Eq(admin.ID)).
+					Times(1).
+					Return(db.AccountLockout{}, db.ErrRecordNotFound)
+
+				store.EXPECT().
+					GetUserVerificationByType(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(enabled(t, 0), nil)
+
+				store.EXPECT().
+					SwapVerificationData(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(int64(0), nil)
+
+				store.EXPECT().
+					RecordFailedLoginTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.RecordFailedLoginTxResult{}, nil)
+
+				store.EXPECT().
+					CreateUserSession(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, res *pb.VerifyMFAResponse, err error) {
+				require.Error(t, err)
+				require.Equal(t, codes.Unauthenticated, status.Code(err))
+			},
+		},
+		{
+			name:      "AccessTokenRejected",
+			tokenType: token.TokenTypeAccessToken,
+			code:      func(t *testing.T) string { return currentTOTPCode(t, secret) },
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetUserByID(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, res *pb.VerifyMFAResponse, err error) {
+				require.Error(t, err)
+				require.Equal(t, codes.Unauthenticated, status.Code(err))
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			mfaToken, _, err := server.tokenMaker.CreateToken(admin.ID, admin.Email, string(admin.UserType), uuid.New(), time.Minute, tc.tokenType)
+			require.NoError(t, err)
+
+			res, err := server.VerifyMFA(context.Background(), &pb.VerifyMFARequest{
+				MfaToken: mfaToken,
+				Code:     tc.code(t),
+			})
+			tc.checkResponse(t, res, err)
+		})
+	}
+}
+
+func TestVerifyMFATokenIsSingleUse(t *testing.T) {
+	admin, _ := randomUser(t, util.AdminRole)
+	admin.ID = util.RandomInt(1, 1000)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	store := mockdb.NewMockStore(ctrl)
+	server := newTestServer(t, store)
+
+	mfaToken, mfaPayload, err := server.tokenMaker.CreateToken(admin.ID, admin.Email, string(admin.UserType), uuid.New(), time.Minute, token.TokenTypeMFAPending)
+	require.NoError(t, err)
+	require.NoError(t, server.denylist.RevokeToken(context.Background(), mfaPayload))
+
+	store.EXPECT().
+		GetUserByID(gomock.Any(), gomock.Any()).
+		Times(0)
+
+	_, err = server.VerifyMFA(context.Background(), &pb.VerifyMFARequest{MfaToken: mfaToken, Code: "123456"})
+	require.Error(t, err)
+	require.Equal(t, codes.Unauthenticated, status.Code(err))
+}
@@ -0,0 +1,183 @@
+package gapi
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+
+	"github.com/hibiken/asynq"
+	db "github.com/r-scheele/sqr/internal/db/sqlc"
+	"github.com/r-scheele/sqr/internal/pb"
+	"github.com/r-scheele/sqr/internal/val"
+	"github.com/r-scheele/sqr/internal/worker"
+	"github.com/rs/zerolog/log"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func (server *Server) RequestPhoneVerification(ctx context.Context, req *pb.RequestPhoneVerificationRequest) (*pb.RequestPhoneVerificationResponse, error) {
+	principal, err := server.authorizeRequest(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	user := principal.User
+
+	verification, data, err := server.getPhoneVerification(ctx, user.ID)
+	if err != nil {
+		return nil, err
+	}
+	if verification != nil && data.Destination == user.Phone {
+		switch verification.VerificationStatus.VerificationStatusEnum {
+		case db.VerificationStatusEnumVerified:
+			return nil, status.Errorf(codes.FailedPrecondition, "phone number is already verified")
+		case db.VerificationStatusEnumPending:
+			err = server.checkOTPResend(data)
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	code, data, err := server.newOTP(user.ID, user.Phone)
+	if err != nil {
+		return nil, err
+	}
+
+	dataJSON, err := json.Marshal(data)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to marshal verification data")
+	}
+
+	// Only the latest code is valid, and a verification of a previous number no longer applies
+	err = server.store.DeleteUserVerificationsByType(ctx, db.DeleteUserVerificationsByTypeParams{
+		UserID:           user.ID,
+		VerificationType: db.VerificationTypeEnumPhone,
+	})
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to reset phone verification: %s", err)
+	}
+
+	_, err = server.store.CreateUserVerification(ctx, db.CreateUserVerificationParams{
+		UserID:             user.ID,
+		VerificationType:   db.VerificationTypeEnumPhone,
+		VerificationStatus: db.NullVerificationStatusEnum{VerificationStatusEnum: db.VerificationStatusEnumPending, Valid: true},
+		VerificationData:   dataJSON,
+	})
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to create phone verification: %s", err)
+	}
+
+	taskPayload := &worker.PayloadSendPhoneOTP{
+		Phone:            user.Phone,
+		Code:             code,
+		ExpiresInMinutes: int(server.otpDuration().Minutes()),
+	}
+	opts := []asynq.Option{
+		asynq.MaxRetry(3),
+		asynq.Queue(worker.QueueCritical),
+	}
+
+	err = server.taskDistributor.DistributeTaskSendPhoneOTP(ctx, taskPayload, opts...)
+	if err != nil {
+		log.Error().Err(err).Int64("user_id", user.ID).Msg("failed to distribute send phone otp task")
+		return nil, status.Errorf(codes.Internal, "failed to send verification code")
+	}
+
+	rsp := &pb.RequestPhoneVerificationResponse{
+		Message:   "A verification code has been sent to your phone",
+		ExpiresAt: timestamppb.New(data.ExpiresAt),
+	}
+	return rsp, nil
+}
+
+func (server *Server) ConfirmPhoneVerification(ctx context.Context, req *pb.ConfirmPhoneVerificationRequest) (*pb.ConfirmPhoneVerificationResponse, error) {
+	violations := validateConfirmPhoneVerificationRequest(req)
+	if violations != nil {
+		return nil, invalidArgumentError(violations)
+	}
+
+	principal, err := server.authorizeRequest(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	user := principal.User
+
+	verification, data, err := server.getPhoneVerification(ctx, user.ID)
+	if err != nil {
+		return nil, err
+	}
+	if verification == nil || verification.VerificationStatus.VerificationStatusEnum != db.VerificationStatusEnumPending {
+		return nil, status.Errorf(codes.FailedPrecondition, "no pending phone verification")
+	}
+
+	if data.Destination != user.Phone {
+		return nil, status.Errorf(codes.FailedPrecondition, "phone number has changed, request a new code")
+	}
+
+	attempts := data.Attempts
+	err = server.checkOTP(user.ID, &data, req.GetCode())
+	if err != nil {
+		if data.Attempts != attempts {
+			if saveErr := server.saveVerificationData(ctx, verification.ID, data); saveErr != nil {
+				return nil, saveErr
+			}
+		}
+		return nil, err
+	}
+
+	// The code is spent, only the verified number is kept
+	dataJSON, err := json.Marshal(otpData{Destination: data.Destination})
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to marshal verification data")
+	}
+
+	_, err = server.store.CompleteUserVerification(ctx, db.CompleteUserVerificationParams{
+		ID:               verification.ID,
+		VerificationData: dataJSON,
+	})
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to verify phone number: %s", err)
+	}
+
+	log.Info().Int64("user_id", user.ID).Msg("phone number verified")
+
+	rsp := &pb.ConfirmPhoneVerificationResponse{
+		Message: "Phone number verified successfully",
+	}
+	return rsp, nil
+}
+
+// getPhoneVerification returns the latest phone verification of the user, or nil if there is none
+func (server *Server) getPhoneVerification(ctx context.Context, userID int64) (*db.UserVerification, otpData, error) {
+	var data otpData
+
+	verification, err := server.store.GetUserVerificationByType(ctx, db.GetUserVerificationByTypeParams{
+		UserID:           userID,
+		VerificationType: db.VerificationTypeEnumPhone,
+	})
+	if err != nil {
+		if errors.Is(err, db.ErrRecordNotFound) {
+			return nil, data, nil
+		}
+		return nil, data, status.Errorf(codes.Internal, "failed to get phone verification: %s", err)
+	}
+
+	if len(verification.VerificationData) > 0 {
+		err = json.Unmarshal(verification.VerificationData, &data)
+		if err != nil {
+			return nil, data, status.Errorf(codes.Internal, "failed to read phone verification: %s", err)
+		}
+	}
+
+	return &verification, data, nil
+}
+
+func validateConfirmPhoneVerificationRequest(req *pb.ConfirmPhoneVerificationRequest) (violations []*errdetails.BadRequest_FieldViolation) {
+	if err := val.ValidateOTPCode(req.GetCode()); err != nil {
+		violations = append(violations, fieldViolation("code", err))
+	}
+
+	return violations
+}
@@ -0,0 +1,323 @@
+package gapi
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/hibiken/asynq"
+	mockdb "github.com/r-scheele/sqr/internal/db/mock"
+	db "github.com/r-scheele/sqr/internal/db/sqlc"
+	"github.com/r-scheele/sqr/internal/pb"
+	"github.com/r-scheele/sqr/internal/token"
+	"github.com/r-scheele/sqr/internal/util"
+	"github.com/r-scheele/sqr/internal/worker"
+	mockwk "github.com/r-scheele/sqr/internal/worker/mock"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func randomPhoneVerification(t *testing.T, userID int64, verificationStatus db.VerificationStatusEnum, data otpData) db.UserVerification {
+	dataJSON, err := json.Marshal(data)
+	require.NoError(t, err)
+
+	return db.UserVerification{
+		ID:                 util.RandomInt(1, 1000),
+		UserID:             userID,
+		VerificationType:   db.VerificationTypeEnumPhone,
+		VerificationStatus: db.NullVerificationStatusEnum{VerificationStatusEnum: verificationStatus, Valid: true},
+		VerificationData:   dataJSON,
+	}
+}
+
+func TestRequestPhoneVerificationAPI(t *testing.T) {
+	user, _ := randomUser(t, util.TenantRole)
+	user.ID = util.RandomInt(1, 1000)
+
+	testCases := []struct {
+		name          string
+		buildStubs    func(store *mockdb.MockStore, taskDistributor *mockwk.MockTaskDistributor)
+		checkResponse func(t *testing.T, res *pb.RequestPhoneVerificationResponse, err error)
+	}{
+		{
+			name: "OK",
+			buildStubs: func(store *mockdb.MockStore, taskDistributor *mockwk.MockTaskDistributor) {
+				store.EXPECT().
+					GetUserByID(gomock.Any(), gomock.Eq(user.ID)).
+					Times(1).
+					Return(user, nil)
+
+				store.EXPECT().
+					GetUserVerificationByType(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.UserVerification{}, db.ErrRecordNotFound)
+
+				store.EXPECT().
+					DeleteUserVerificationsByType(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(nil)
+
+				var storedData otpData
+				store.EXPECT().
+					CreateUserVerification(gomock.Any(), gomock.Any()).
+					Times(1).
+					DoAndReturn(func(_ context.Context, arg db.CreateUserVerificationParams) (db.UserVerification, error) {
+						require.Equal(t, db.VerificationTypeEnumPhone, arg.VerificationType)
+						require.NoError(t, json.Unmarshal(arg.VerificationData, &storedData))
+						require.Equal(t, user.Phone, storedData.Destination)
+						return db.UserVerification{}, nil
+					})
+
+				taskDistributor.EXPECT().
+					DistributeTaskSendPhoneOTP(gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(1).
+					DoAndReturn(func(_ context.Context, payload *worker.PayloadSendPhoneOTP, _ ...asynq.Option) error {
+						require.Equal(t, user.Phone, payload.Phone)
+						require.Len(t, payload.Code, otpLength)
+						require.Equal(t, util.HashOTPCode(testOTPHashKey, user.ID, payload.Code), storedData.CodeHash)
+						return nil
+					})
+			},
+			checkResponse: func(t *testing.T, res *pb.RequestPhoneVerificationResponse, err error) {
+				require.NoError(t, err)
+				require.True(t, res.GetExpiresAt().AsTime().After(time.Now()))
+			},
+		},
+		{
+			name: "AlreadyVerified",
+			buildStubs: func(store *mockdb.MockStore, taskDistributor *mockwk.MockTaskDistributor) {
+				store.EXPECT().
+					GetUserByID(gomock.Any(), gomock.Eq(user.ID)).
+					Times(1).
+					Return(user, nil)
+
+				store.EXPECT().
+					GetUserVerificationByType(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(randomPhoneVerification(t, user.ID, db.VerificationStatusEnumVerified, otpData{Destination: user.Phone}), nil)
+
+				taskDistributor.EXPECT().
+					DistributeTaskSendPhoneOTP(gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, res *pb.RequestPhoneVerificationResponse, err error) {
+				require.Error(t, err)
+				require.Equal(t, codes.FailedPrecondition, status.Code(err))
+			},
+		},
+		{
+			name: "ResentTooSoon",
+			buildStubs: func(store *mockdb.MockStore, taskDistributor *mockwk.MockTaskDistributor) {
+				store.EXPECT().
+					GetUserByID(gomock.Any(), gomock.Eq(user.ID)).
+					Times(1).
+					Return(user, nil)
+
+				store.EXPECT().
+					GetUserVerificationByType(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(randomPhoneVerification(t, user.ID, db.VerificationStatusEnumPending, otpData{
+						Destination: user.Phone,
+						SentAt:      time.Now().Add(-10 * time.Second),
+						ExpiresAt:   time.Now().Add(defaultOTPDuration),
+					}), nil)
+
+				taskDistributor.EXPECT().
+					DistributeTaskSendPhoneOTP(gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, res *pb.RequestPhoneVerificationResponse, err error) {
+				require.Error(t, err)
+				require.Equal(t, codes.ResourceExhausted, status.Code(err))
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			storeCtrl := gomock.NewController(t)
+			defer storeCtrl.Finish()
+			store := mockdb.NewMockStore(storeCtrl)
+
+			taskCtrl := gomock.NewController(t)
+			defer taskCtrl.Finish()
+			taskDistributor := mockwk.NewMockTaskDistributor(taskCtrl)
+
+			tc.buildStubs(store, taskDistributor)
+			server := newTestServerWithTaskDistributor(t, store, taskDistributor)
+			ctx := newContextWithBearerToken(t, server.tokenMaker, user, uuid.New(), time.Minute, token.TokenTypeAccessToken)
+
+			res, err := server.RequestPhoneVerification(ctx, &pb.RequestPhoneVerificationRequest{})
+			tc.checkResponse(t, res, err)
+		})
+	}
+}
+
+func TestConfirmPhoneVerificationAPI(t *testing.T) {
+	user, _ := randomUser(t, util.TenantRole)
+	user.ID = util.RandomInt(1, 1000)
+	code := "123456"
+
+	pending := func(t *testing.T, attempts int32, expiresAt time.Time) db.UserVerification {
+		return randomPhoneVerification(t, user.ID, db.VerificationStatusEnumPending, otpData{
+			Destination: user.Phone,
+			CodeHash:    util.HashOTPCode(testOTPHashKey, user.ID, code),
+			SentAt:      time.Now(),
+			ExpiresAt:   expiresAt,
+			Attempts:    attempts,
+		})
+	}
+
+	testCases := []struct {
+		name          string
+		code          string
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(t *testing.T, res *pb.ConfirmPhoneVerificationResponse, err error)
+	}{
+		{
+			name: "OK",
+			code: code,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetUserByID(gomock.Any(), gomock.Eq(user.ID)).
+					Times(1).
+					Return(user, nil)
+
+				store.EXPECT().
+					GetUserVerificationByType(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(pending(t, 0, time.Now().Add(time.Minute)), nil)
+
+				store.EXPECT().
+					CompleteUserVerification(gomock.Any(), gomock.Any()).
+					Times(1).
+					DoAndReturn(func(_ context.Context, arg db.CompleteUserVerificationParams) (db.UserVerification, error) {
+						var data otpData
+						require.NoError(t, json.Unmarshal(arg.VerificationData, &data))
+						require.Equal(t, user.Phone, data.Destination)
+						require.Empty(t, data.CodeHash)
+						return db.UserVerification{}, nil
+					})
+			},
+			checkResponse: func(t *testing.T, res *pb.ConfirmPhoneVerificationResponse, err error) {
+				require.NoError(t, err)
+			},
+		},
+		{
+			name: "IncorrectCode",
+			code: "654321",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetUserByID(gomock.Any(), gomock.Eq(user.ID)).
+					Times(1).
+					Return(user, nil)
+
+				store.EXPECT().
+					GetUserVerificationByType(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(pending(t, 1, time.Now().Add(time.Minute)), nil)
+
+				store.EXPECT().
+					UpdateVerificationData(gomock.Any(), gomock.Any()).
+					Times(1).
+					DoAndReturn(func(_ context.Context, arg db.UpdateVerificationDataParams) (db.UserVerification, error) {
+						var data otpData
+						require.NoError(t, json.Unmarshal(arg.VerificationData, &data))
+						require.Equal(t, int32(2), data.Attempts)
+						return db.UserVerification{}, nil
+					})
+
+				store.EXPECT().
+					CompleteUserVerification(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, res *pb.ConfirmPhoneVerificationResponse, err error) {
+				require.Error(t, err)
+				require.Equal(t, codes.InvalidArgument, status.Code(err))
+			},
+		},
+		{
+			name: "TooManyAttempts",
+			code: code,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetUserByID(gomock.Any(), gomock.Eq(user.ID)).
+					Times(1).
+					Return(user, nil)
+
+				store.EXPECT().
+					GetUserVerificationByType(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(pending(t, defaultOTPMaxAttempts, time.Now().Add(time.Minute)), nil)
+
+				store.EXPECT().
+					CompleteUserVerification(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, res *pb.ConfirmPhoneVerificationResponse, err error) {
+				require.Error(t, err)
+				require.Equal(t, codes.ResourceExhausted, status.Code(err))
+			},
+		},
+		{
+			name: "Expired",
+			code: code,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetUserByID(gomock.Any(), gomock.Eq(user.ID)).
+					Times(1).
+					Return(user, nil)
+
+				store.EXPECT().
+					GetUserVerificationByType(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(pending(t, 0, time.Now().Add(-time.Minute)), nil)
+
+				store.EXPECT().
+					CompleteUserVerification(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, res *pb.ConfirmPhoneVerificationResponse, err error) {
+				require.Error(t, err)
+				require.Equal(t, codes.FailedPrecondition, status.Code(err))
+			},
+		},
+		{
+			name: "InvalidCodeFormat",
+			code: "12ab",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetUserVerificationByType(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, res *pb.ConfirmPhoneVerificationResponse, err error) {
+				require.Error(t, err)
+				require.Equal(t, codes.InvalidArgument, status.Code(err))
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			ctx := newContextWithBearerToken(t, server.tokenMaker, user, uuid.New(), time.Minute, token.TokenTypeAccessToken)
+
+			res, err := server.ConfirmPhoneVerification(ctx, &pb.ConfirmPhoneVerificationRequest{Code: tc.code})
+			tc.checkResponse(t, res, err)
+		})
+	}
+}
@@ -0,0 +1,92 @@
+package gapi
+
+import (
+	"context"
+	"slices"
+	"strings"
+
+	db "github.com/r-scheele/sqr/internal/db/sqlc"
+	"github.com/r-scheele/sqr/internal/pb"
+	"github.com/r-scheele/sqr/internal/util"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// DefaultLandlordPublishMinCompletion is used when LANDLORD_PUBLISH_MIN_COMPLETION is not set
+const DefaultLandlordPublishMinCompletion = 60
+
+// publishRequiredItems are the checklist items a landlord must complete to publish whatever their score,
+// so that every listing comes from a verified landlord
+var publishRequiredItems = []string{"email_verified", "nin_verified"}
+
+// landlordPublishMinCompletion is the profile completion score a landlord needs to publish properties
+func landlordPublishMinCompletion(config util.Config) int32 {
+	if config.LandlordPublishMinCompletion > 0 {
+		return config.LandlordPublishMinCompletion
+	}
+	return DefaultLandlordPublishMinCompletion
+}
+
+func (server *Server) GetProfileCompletion(ctx context.Context, req *pb.GetProfileCompletionRequest) (*pb.GetProfileCompletionResponse, error) {
+	principal, err := server.authorizeRequest(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	completion, err := db.GetProfileCompletion(ctx, server.store, principal.User)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to get profile completion: %s", err)
+	}
+
+	rsp := &pb.GetProfileCompletionResponse{
+		Score:        completion.Score,
+		Checklist:    make([]*pb.ProfileChecklistItem, 0, len(completion.Items)),
+		MissingItems: make([]*pb.ProfileChecklistItem, 0, len(completion.Items)),
+	}
+	for _, item := range completion.Items {
+		rsp.Checklist = append(rsp.Checklist, convertProfileChecklistItem(item))
+		if !item.Completed {
+			rsp.MissingItems = append(rsp.MissingItems, convertProfileChecklistItem(item))
+		}
+	}
+
+	if principal.User.UserType == db.UserTypeEnumLandlord {
+		rsp.CanPublishProperties = server.checkLandlordCanPublish(completion) == nil
+	}
+
+	return rsp, nil
+}
+
+// ensureLandlordCanPublish refuses to publish properties for landlords who aren't verified or whose
+// profile is below the threshold
+func (server *Server) ensureLandlordCanPublish(ctx context.Context, landlord db.User) error {
+	completion, err := db.GetProfileCompletion(ctx, server.store, landlord)
+	if err != nil {
+		return status.Errorf(codes.Internal, "failed to get profile completion: %s", err)
+	}
+
+	return server.checkLandlordCanPublish(completion)
+}
+
+// checkLandlordCanPublish is the rule for publishing properties that GetProfileCompletion reports and
+// PublishProperty enforces: the score must reach the threshold and publishRequiredItems must be completed
+func (server *Server) checkLandlordCanPublish(completion db.ProfileCompletion) error {
+	unverified := []string{}
+	missing := make([]string, 0, len(completion.Items))
+	for _, item := range completion.Missing() {
+		missing = append(missing, item.Label)
+		if slices.Contains(publishRequiredItems, item.Key) {
+			unverified = append(unverified, item.Label)
+		}
+	}
+
+	threshold := landlordPublishMinCompletion(server.config)
+	if completion.Score < threshold {
+		return status.Errorf(codes.FailedPrecondition, "your profile is %d%% complete and must be at least %d%% complete to publish properties: %s",
+			completion.Score, threshold, strings.Join(missing, "; "))
+	}
+	if len(unverified) > 0 {
+		return status.Errorf(codes.FailedPrecondition, "you must be verified to publish properties: %s", strings.Join(unverified, "; "))
+	}
+	return nil
+}
@@ -0,0 +1,223 @@
+package gapi
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+	mockdb "github.com/r-scheele/sqr/internal/db/mock"
+	db "github.com/r-scheele/sqr/internal/db/sqlc"
+	"github.com/r-scheele/sqr/internal/pb"
+	"github.com/r-scheele/sqr/internal/token"
+	"github.com/r-scheele/sqr/internal/util"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestGetProfileCompletionAPI(t *testing.T) {
+	landlord, _ := randomUser(t, util.LandlordRole)
+	landlord.ID = util.RandomInt(1, 1000)
+	landlord.IsVerified = pgtype.Bool{Bool: true, Valid: true}
+	landlord.ProfilePictureUrl = pgtype.Text{}
+	verifiedLandlord := []db.UserVerification{
+		randomEmailVerification(landlord.ID, db.VerificationStatusEnumVerified),
+		randomPhoneVerification(t, landlord.ID, db.VerificationStatusEnumVerified, otpData{Destination: landlord.Phone}),
+		randomNINVerification(landlord.ID, db.VerificationStatusEnumVerified, time.Now()),
+	}
+
+	tenant, _ := randomUser(t, util.TenantRole)
+	tenant.ID = landlord.ID + 1
+	tenant.IsVerified = pgtype.Bool{Bool: false, Valid: true}
+	tenant.ProfilePictureUrl = pgtype.Text{String: util.RandomString(12), Valid: true}
+
+	testCases := []struct {
+		name          string
+		user          db.User
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(t *testing.T, res *pb.GetProfileCompletionResponse, err error)
+	}{
+		{
+			name: "CompleteLandlord",
+			user: landlord,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetUserByID(gomock.Any(), gomock.Eq(landlord.ID)).
+					Times(1).
+					Return(landlord, nil)
+
+				store.EXPECT().
+					GetUserVerificationsByUserID(gomock.Any(), gomock.Eq(landlord.ID)).
+					Times(1).
+					Return(verifiedLandlord, nil)
+
+				store.EXPECT().
+					GetLandlordProfileByUserID(gomock.Any(), gomock.Eq(landlord.ID)).
+					Times(1).
+					Return(randomLandlordProfile(landlord.ID), nil)
+			},
+			checkResponse: func(t *testing.T, res *pb.GetProfileCompletionResponse, err error) {
+				require.NoError(t, err)
+				require.Equal(t, int32(95), res.GetScore())
+				require.True(t, res.GetCanPublishProperties())
+				require.Len(t, res.GetMissingItems(), 1)
+				require.Equal(t, "profile_picture", res.GetMissingItems()[0].GetKey())
+			},
+		},
+		{
+			// only a succeeded email verification completes the item
+			name: "EmailNotVerified",
+			user: landlord,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetUserByID(gomock.Any(), gomock.Eq(landlord.ID)).
+					Times(1).
+					Return(landlord, nil)
+
+				store.EXPECT().
+					GetUserVerificationsByUserID(gomock.Any(), gomock.Eq(landlord.ID)).
+					Times(1).
+					Return(verifiedLandlord[1:], nil)
+
+				store.EXPECT().
+					GetLandlordProfileByUserID(gomock.Any(), gomock.Eq(landlord.ID)).
+					Times(1).
+					Return(randomLandlordProfile(landlord.ID), nil)
+			},
+			checkResponse: func(t *testing.T, res *pb.GetProfileCompletionResponse, err error) {
+				require.NoError(t, err)
+				require.Equal(t, int32(80), res.GetScore())
+				require.False(t, res.GetCanPublishProperties())
+				require.Len(t, res.GetMissingItems(), 2)
+				require.Equal(t, "email_verified", res.GetMissingItems()[0].GetKey())
+			},
+		},
+		{
+			// a pending verification from resending the email doesn't undo the earlier one
+			name: "EmailVerificationResent",
+			user: landlord,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetUserByID(gomock.Any(), gomock.Eq(landlord.ID)).
+					Times(1).
+					Return(landlord, nil)
+
+				store.EXPECT().
+					GetUserVerificationsByUserID(gomock.Any(), gomock.Eq(landlord.ID)).
+					Times(1).
+					Return(append([]db.UserVerification{
+						randomEmailVerification(landlord.ID, db.VerificationStatusEnumPending),
+					}, verifiedLandlord...), nil)
+
+				store.EXPECT().
+					GetLandlordProfileByUserID(gomock.Any(), gomock.Eq(landlord.ID)).
+					Times(1).
+					Return(randomLandlordProfile(landlord.ID), nil)
+			},
+			checkResponse: func(t *testing.T, res *pb.GetProfileCompletionResponse, err error) {
+				require.NoError(t, err)
+				require.Equal(t, int32(95), res.GetScore())
+				require.Len(t, res.GetMissingItems(), 1)
+				require.Equal(t, "profile_picture", res.GetMissingItems()[0].GetKey())
+			},
+		},
+		{
+			name: "NewTenant",
+			user: tenant,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetUserByID(gomock.Any(), gomock.Eq(tenant.ID)).
+					Times(1).
+					Return(tenant, nil)
+
+				store.EXPECT().
+					GetUserVerificationsByUserID(gomock.Any(), gomock.Eq(tenant.ID)).
+					Times(1).
+					Return([]db.UserVerification{
+						// the latest attempt counts
+						randomNINVerification(tenant.ID, db.VerificationStatusEnumRejected, time.Now()),
+						randomNINVerification(tenant.ID, db.VerificationStatusEnumVerified, time.Now().Add(-time.Hour)),
+					}, nil)
+
+				store.EXPECT().
+					GetTenantProfileByUserID(gomock.Any(), gomock.Eq(tenant.ID)).
+					Times(1).
+					Return(db.TenantProfile{}, db.ErrRecordNotFound)
+			},
+			checkResponse: func(t *testing.T, res *pb.GetProfileCompletionResponse, err error) {
+				require.NoError(t, err)
+				require.False(t, res.GetCanPublishProperties())
+				require.Len(t, res.GetChecklist(), 7)
+				require.Equal(t, len(res.GetChecklist()), len(res.GetMissingItems())+countCompleted(res.GetChecklist()))
+				for _, item := range res.GetMissingItems() {
+					require.NotEqual(t, "profile_picture", item.GetKey())
+				}
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			ctx := newContextWithBearerToken(t, server.tokenMaker, tc.user, uuid.New(), time.Minute, token.TokenTypeAccessToken)
+
+			res, err := server.GetProfileCompletion(ctx, &pb.GetProfileCompletionRequest{})
+			tc.checkResponse(t, res, err)
+		})
+	}
+}
+
+func TestEnsureLandlordCanPublish(t *testing.T) {
+	landlord, _ := randomUser(t, util.LandlordRole)
+	landlord.ID = util.RandomInt(1, 1000)
+	landlord.IsVerified = pgtype.Bool{Bool: true, Valid: true}
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	store := mockdb.NewMockStore(ctrl)
+	store.EXPECT().
+		GetUserVerificationsByUserID(gomock.Any(), gomock.Eq(landlord.ID)).
+		Times(1).
+		Return([]db.UserVerification{}, nil)
+	store.EXPECT().
+		GetLandlordProfileByUserID(gomock.Any(), gomock.Eq(landlord.ID)).
+		Times(1).
+		Return(db.LandlordProfile{}, db.ErrRecordNotFound)
+
+	server := newTestServer(t, store)
+	err := server.ensureLandlordCanPublish(context.Background(), landlord)
+	require.Error(t, err)
+	require.Equal(t, codes.FailedPrecondition, status.Code(err))
+	require.Contains(t, err.Error(), "Add a guarantor")
+}
+
+func randomEmailVerification(userID int64, verificationStatus db.VerificationStatusEnum) db.UserVerification {
+	return db.UserVerification{
+		ID:                 util.RandomInt(1, 1000),
+		UserID:             userID,
+		VerificationType:   db.VerificationTypeEnumEmail,
+		VerificationStatus: db.NullVerificationStatusEnum{VerificationStatusEnum: verificationStatus, Valid: true},
+	}
+}
+
+func countCompleted(items []*pb.ProfileChecklistItem) int {
+	completed := 0
+	for _, item := range items {
+		if item.GetCompleted() {
+			completed++
+		}
+	}
+	return completed
+}
@@ -0,0 +1,706 @@
+package gapi
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"strings"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	db "github.com/r-scheele/sqr/internal/db/sqlc"
+	"github.com/r-scheele/sqr/internal/pb"
+	"github.com/r-scheele/sqr/internal/util"
+	"github.com/r-scheele/sqr/internal/val"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (server *Server) CreateProperty(ctx context.Context, req *pb.CreatePropertyRequest) (*pb.CreatePropertyResponse, error) {
+	violations := validateCreatePropertyRequest(req)
+	if violations != nil {
+		return nil, invalidArgumentError(violations)
+	}
+
+	principal, err := server.authorizeRequest(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	arg := db.CreatePropertyParams{
+		LandlordID:       principal.User.ID,
+		Title:            strings.TrimSpace(req.GetTitle()),
+		Description:      optionalText(strings.TrimSpace(req.GetDescription())),
+		PropertyType:     db.PropertyTypeEnum(req.GetPropertyType()),
+		Address:          strings.TrimSpace(req.GetAddress()),
+		City:             strings.TrimSpace(req.GetCity()),
+		State:            strings.TrimSpace(req.GetState()),
+		Country:          optionalText(strings.TrimSpace(req.GetCountry())),
+		Bedrooms:         req.GetBedrooms(),
+		Bathrooms:        req.GetBathrooms(),
+		RentAmount:       numericFromFloat(req.GetRentAmount(), 2),
+		RentPeriod:       db.NullRentPeriodEnum{RentPeriodEnum: db.RentPeriodEnumAnnually, Valid: true},
+		SecurityDeposit:  optionalNumeric(req.GetSecurityDeposit(), 2),
+		AgencyFee:        optionalNumeric(req.GetAgencyFee(), 2),
+		LegalFee:         optionalNumeric(req.GetLegalFee(), 2),
+		Amenities:        optionalText(joinAmenities(req.GetAmenities())),
+		FurnishingStatus: db.NullFurnishingStatusEnum{FurnishingStatusEnum: db.FurnishingStatusEnum(req.GetFurnishingStatus()), Valid: req.GetFurnishingStatus() != ""},
+		ParkingSpaces:    pgtype.Int4{Int32: req.GetParkingSpaces(), Valid: true},
+		TotalArea:        optionalNumeric(req.GetTotalArea(), 2),
+		PetFriendly:      req.GetPetFriendly(),
+	}
+
+	if req.GetRentPeriod() != "" {
+		arg.RentPeriod.RentPeriodEnum = db.RentPeriodEnum(req.GetRentPeriod())
+	}
+
+	if req.Latitude != nil && req.Longitude != nil {
+		arg.Latitude = numericFromFloat(req.GetLatitude(), 8)
+		arg.Longitude = numericFromFloat(req.GetLongitude(), 8)
+	}
+
+	property, err := server.store.CreateProperty(ctx, arg)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to create property: %s", err)
+	}
+
+	rsp := &pb.CreatePropertyResponse{
+		Property: convertProperty(property),
+	}
+	return rsp, nil
+}
+
+// GetProperty shows published properties to everyone; drafts and archived listings only to their landlord and admins
+func (server *Server) GetProperty(ctx context.Context, req *pb.GetPropertyRequest) (*pb.GetPropertyResponse, error) {
+	violations := validatePropertyID(req.GetPropertyId())
+	if violations != nil {
+		return nil, invalidArgumentError(violations)
+	}
+
+	principal, err := server.authorizeRequest(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	property, err := server.store.GetPropertyByID(ctx, req.GetPropertyId())
+	if err != nil {
+		if errors.Is(err, db.ErrRecordNotFound) {
+			return nil, status.Errorf(codes.NotFound, "property not found")
+		}
+		return nil, status.Errorf(codes.Internal, "failed to get property: %s", err)
+	}
+
+	canSeeUnlisted := property.LandlordID == principal.User.ID || principal.Payload.Role == util.AdminRole
+	switch db.PropertyStatus(property) {
+	case db.PropertyStatusEnumActive, db.PropertyStatusEnumRented:
+	default:
+		if !canSeeUnlisted {
+			return nil, status.Errorf(codes.NotFound, "property not found")
+		}
+	}
+
+	rsp := &pb.GetPropertyResponse{
+		Property: convertProperty(property),
+	}
+	return rsp, nil
+}
+
+// ListMyProperties lists the properties of the caller in every status, newest first
+func (server *Server) ListMyProperties(ctx context.Context, req *pb.ListMyPropertiesRequest) (*pb.ListMyPropertiesResponse, error) {
+	violations := validateListMyPropertiesRequest(req)
+	if violations != nil {
+		return nil, invalidArgumentError(violations)
+	}
+
+	principal, err := server.authorizeRequest(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	properties, err := server.store.ListPropertiesByLandlord(ctx, db.ListPropertiesByLandlordParams{
+		LandlordID: principal.User.ID,
+		Limit:      req.GetPageSize(),
+		Offset:     (req.GetPageId() - 1) * req.GetPageSize(),
+	})
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to list properties: %s", err)
+	}
+
+	totalCount, err := server.store.CountPropertiesByLandlord(ctx, principal.User.ID)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to count properties: %s", err)
+	}
+
+	rsp := &pb.ListMyPropertiesResponse{
+		Properties: make([]*pb.Property, 0, len(properties)),
+		TotalCount: totalCount,
+	}
+	for _, property := range properties {
+		rsp.Properties = append(rsp.Properties, convertProperty(property))
+	}
+
+	return rsp, nil
+}
+
+// UpdateProperty edits the details of a property that isn't archived. Fields that are not set are left unchanged.
+func (server *Server) UpdateProperty(ctx context.Context, req *pb.UpdatePropertyRequest) (*pb.UpdatePropertyResponse, error) {
+	violations := validateUpdatePropertyRequest(req)
+	if violations != nil {
+		return nil, invalidArgumentError(violations)
+	}
+
+	_, err := server.authorizeRequest(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	property, err := server.store.GetPropertyByID(ctx, req.GetPropertyId())
+	if err != nil {
+		if errors.Is(err, db.ErrRecordNotFound) {
+			return nil, status.Errorf(codes.NotFound, "property not found")
+		}
+		return nil, status.Errorf(codes.Internal, "failed to get property: %s", err)
+	}
+
+	propertyStatus := db.PropertyStatus(property)
+	if propertyStatus == db.PropertyStatusEnumInactive {
+		return nil, status.Errorf(codes.FailedPrecondition, "archived properties cannot be edited")
+	}
+
+	edited := applyPropertyUpdate(property, req)
+
+	// A published listing must keep the details it was published with
+	if propertyStatus != db.PropertyStatusEnumDraft {
+		missing := missingListingDetails(edited)
+		if len(missing) > 0 {
+			return nil, status.Errorf(codes.FailedPrecondition, "a published property must have %s, unpublish it first to remove them",
+				strings.Join(missing, ", "))
+		}
+	}
+
+	property, err = server.store.UpdateProperty(ctx, db.UpdatePropertyParams{
+		ID:               edited.ID,
+		Title:            edited.Title,
+		Description:      edited.Description,
+		PropertyType:     edited.PropertyType,
+		Address:          edited.Address,
+		City:             edited.City,
+		State:            edited.State,
+		Latitude:         edited.Latitude,
+		Longitude:        edited.Longitude,
+		Bedrooms:         edited.Bedrooms,
+		Bathrooms:        edited.Bathrooms,
+		RentAmount:       edited.RentAmount,
+		RentPeriod:       edited.RentPeriod,
+		SecurityDeposit:  edited.SecurityDeposit,
+		AgencyFee:        edited.AgencyFee,
+		LegalFee:         edited.LegalFee,
+		Amenities:        edited.Amenities,
+		FurnishingStatus: edited.FurnishingStatus,
+		ParkingSpaces:    edited.ParkingSpaces,
+		TotalArea:        edited.TotalArea,
+		PetFriendly:      edited.PetFriendly,
+	})
+	if err != nil {
+		if errors.Is(err, db.ErrRecordNotFound) {
+			return nil, status.Errorf(codes.NotFound, "property not found")
+		}
+		return nil, status.Errorf(codes.Internal, "failed to update property: %s", err)
+	}
+
+	rsp := &pb.UpdatePropertyResponse{
+		Property: convertProperty(property),
+	}
+	return rsp, nil
+}
+
+// PublishProperty lists a draft, or a rented property that is vacant again, once the property and its landlord are ready
+func (server *Server) PublishProperty(ctx context.Context, req *pb.PublishPropertyRequest) (*pb.PublishPropertyResponse, error) {
+	violations := validatePropertyID(req.GetPropertyId())
+	if violations != nil {
+		return nil, invalidArgumentError(violations)
+	}
+
+	principal, err := server.authorizeRequest(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	property, err := server.changePropertyStatus(ctx, req.GetPropertyId(), db.PropertyStatusEnumActive, "published", func(property db.Property) error {
+		return server.ensurePropertyCanBePublished(ctx, principal.User, property)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	rsp := &pb.PublishPropertyResponse{
+		Property: convertProperty(property),
+	}
+	return rsp, nil
+}
+
+// UnpublishProperty takes a listing out of search and back to a draft
+func (server *Server) UnpublishProperty(ctx context.Context, req *pb.UnpublishPropertyRequest) (*pb.UnpublishPropertyResponse, error) {
+	violations := validatePropertyID(req.GetPropertyId())
+	if violations != nil {
+		return nil, invalidArgumentError(violations)
+	}
+
+	_, err := server.authorizeRequest(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	property, err := server.changePropertyStatus(ctx, req.GetPropertyId(), db.PropertyStatusEnumDraft, "unpublished", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	rsp := &pb.UnpublishPropertyResponse{
+		Property: convertProperty(property),
+	}
+	return rsp, nil
+}
+
+func (server *Server) MarkPropertyRented(ctx context.Context, req *pb.MarkPropertyRentedRequest) (*pb.MarkPropertyRentedResponse, error) {
+	violations := validatePropertyID(req.GetPropertyId())
+	if violations != nil {
+		return nil, invalidArgumentError(violations)
+	}
+
+	_, err := server.authorizeRequest(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	property, err := server.changePropertyStatus(ctx, req.GetPropertyId(), db.PropertyStatusEnumRented, "marked as rented", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	rsp := &pb.MarkPropertyRentedResponse{
+		Property: convertProperty(property),
+	}
+	return rsp, nil
+}
+
+// ArchiveProperty takes a property down for good; admins can archive any property
+func (server *Server) ArchiveProperty(ctx context.Context, req *pb.ArchivePropertyRequest) (*pb.ArchivePropertyResponse, error) {
+	violations := validatePropertyID(req.GetPropertyId())
+	if violations != nil {
+		return nil, invalidArgumentError(violations)
+	}
+
+	_, err := server.authorizeRequest(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	property, err := server.changePropertyStatus(ctx, req.GetPropertyId(), db.PropertyStatusEnumInactive, "archived", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	rsp := &pb.ArchivePropertyResponse{
+		Property: convertProperty(property),
+	}
+	return rsp, nil
+}
+
+// changePropertyStatus runs ChangePropertyStatusTx and turns its errors into status errors; action describes
+// the change in the error shown when the property can't make it
+func (server *Server) changePropertyStatus(ctx context.Context, propertyID int64, propertyStatus db.PropertyStatusEnum, action string, beforeChange func(property db.Property) error) (db.Property, error) {
+	result, err := server.store.ChangePropertyStatusTx(ctx, db.ChangePropertyStatusTxParams{
+		PropertyID:   propertyID,
+		Status:       propertyStatus,
+		BeforeChange: beforeChange,
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, db.ErrRecordNotFound):
+			return db.Property{}, status.Errorf(codes.NotFound, "property not found")
+		case errors.Is(err, db.ErrPropertyStatusTransition):
+			return db.Property{}, status.Errorf(codes.FailedPrecondition, "a property that is %s cannot be %s",
+				propertyStatusLabel(result.OldStatus), action)
+		}
+		// beforeChange refuses with a status error of its own
+		if _, ok := status.FromError(err); ok {
+			return db.Property{}, err
+		}
+		return db.Property{}, status.Errorf(codes.Internal, "failed to change property status: %s", err)
+	}
+
+	return result.Property, nil
+}
+
+// ensurePropertyCanBePublished checks that a property has what a listing needs and that its landlord may publish
+func (server *Server) ensurePropertyCanBePublished(ctx context.Context, landlord db.User, property db.Property) error {
+	err := server.ensureLandlordCanPublish(ctx, landlord)
+	if err != nil {
+		return err
+	}
+
+	missing := missingListingDetails(property)
+
+	images, err := server.store.CountPropertyMediaByType(ctx, db.CountPropertyMediaByTypeParams{
+		PropertyID: property.ID,
+		MediaType:  db.MediaTypeEnumImage,
+	})
+	if err != nil {
+		return status.Errorf(codes.Internal, "failed to count property images: %s", err)
+	}
+	if images == 0 {
+		missing = append(missing, "at least one image")
+	}
+
+	if len(missing) > 0 {
+		return status.Errorf(codes.FailedPrecondition, "add %s before publishing this property", strings.Join(missing, ", "))
+	}
+	return nil
+}
+
+// missingListingDetails lists the optional details of a draft that a published property must have
+func missingListingDetails(property db.Property) []string {
+	missing := []string{}
+	if property.Description.String == "" {
+		missing = append(missing, "a description")
+	}
+	if !property.Latitude.Valid || !property.Longitude.Valid {
+		missing = append(missing, "the coordinates")
+	}
+	if !property.RentPeriod.Valid {
+		missing = append(missing, "the rent period")
+	}
+	if !property.FurnishingStatus.Valid {
+		missing = append(missing, "the furnishing status")
+	}
+	return missing
+}
+
+// applyPropertyUpdate returns property with the fields set in req
+func applyPropertyUpdate(property db.Property, req *pb.UpdatePropertyRequest) db.Property {
+	if req.Title != nil {
+		property.Title = strings.TrimSpace(req.GetTitle())
+	}
+	if req.Description != nil {
+		property.Description = optionalText(strings.TrimSpace(req.GetDescription()))
+	}
+	if req.PropertyType != nil {
+		property.PropertyType = db.PropertyTypeEnum(req.GetPropertyType())
+	}
+	if req.Address != nil {
+		property.Address = strings.TrimSpace(req.GetAddress())
+	}
+	if req.City != nil {
+		property.City = strings.TrimSpace(req.GetCity())
+	}
+	if req.State != nil {
+		property.State = strings.TrimSpace(req.GetState())
+	}
+	if req.Latitude != nil && req.Longitude != nil {
+		property.Latitude = numericFromFloat(req.GetLatitude(), 8)
+		property.Longitude = numericFromFloat(req.GetLongitude(), 8)
+	}
+	if req.Bedrooms != nil {
+		property.Bedrooms = req.GetBedrooms()
+	}
+	if req.Bathrooms != nil {
+		property.Bathrooms = req.GetBathrooms()
+	}
+	if req.RentAmount != nil {
+		property.RentAmount = numericFromFloat(req.GetRentAmount(), 2)
+	}
+	if req.RentPeriod != nil {
+		property.RentPeriod = db.NullRentPeriodEnum{RentPeriodEnum: db.RentPeriodEnum(req.GetRentPeriod()), Valid: true}
+	}
+	if req.SecurityDeposit != nil {
+		property.SecurityDeposit = optionalNumeric(req.GetSecurityDeposit(), 2)
+	}
+	if req.AgencyFee != nil {
+		property.AgencyFee = optionalNumeric(req.GetAgencyFee(), 2)
+	}
+	if req.LegalFee != nil {
+		property.LegalFee = optionalNumeric(req.GetLegalFee(), 2)
+	}
+	if len(req.GetAmenities()) > 0 {
+		property.Amenities = optionalText(joinAmenities(req.GetAmenities()))
+	}
+	if req.FurnishingStatus != nil {
+		property.FurnishingStatus = db.NullFurnishingStatusEnum{FurnishingStatusEnum: db.FurnishingStatusEnum(req.GetFurnishingStatus()), Valid: true}
+	}
+	if req.ParkingSpaces != nil {
+		property.ParkingSpaces = pgtype.Int4{Int32: req.GetParkingSpaces(), Valid: true}
+	}
+	if req.TotalArea != nil {
+		property.TotalArea = optionalNumeric(req.GetTotalArea(), 2)
+	}
+	if req.PetFriendly != nil {
+		property.PetFriendly = req.GetPetFriendly()
+	}
+	return property
+}
+
+func propertyStatusLabel(propertyStatus db.PropertyStatusEnum) string {
+	switch propertyStatus {
+	case db.PropertyStatusEnumActive:
+		return "published"
+	case db.PropertyStatusEnumInactive:
+		return "archived"
+	}
+	return string(propertyStatus)
+}
+
+func joinAmenities(amenities []string) string {
+	trimmed := make([]string, 0, len(amenities))
+	for _, amenity := range amenities {
+		trimmed = append(trimmed, strings.TrimSpace(amenity))
+	}
+	return strings.Join(trimmed, ", ")
+}
+
+// numericFromFloat rounds value to the scale of the decimal column it is stored in
+func numericFromFloat(value float64, scale int) pgtype.Numeric {
+	var numeric pgtype.Numeric
+	if err := numeric.Scan(strconv.FormatFloat(value, 'f', scale, 64)); err != nil {
+		return pgtype.Numeric{}
+	}
+	return numeric
+}
+
+// optionalNumeric stores 0 as NULL, for amounts that don't apply to every property
+func optionalNumeric(value float64, scale int) pgtype.Numeric {
+	if value == 0 {
+		return pgtype.Numeric{}
+	}
+	return numericFromFloat(value, scale)
+}
+
+func numericToFloat(value pgtype.Numeric) float64 {
+	number, _ := value.Float64Value()
+	return number.Float64
+}
+
+func validateCreatePropertyRequest(req *pb.CreatePropertyRequest) (violations []*errdetails.BadRequest_FieldViolation) {
+	if err := val.ValidatePropertyTitle(req.GetTitle()); err != nil {
+		violations = append(violations, fieldViolation("title", err))
+	}
+
+	if req.GetDescription() != "" {
+		if err := val.ValidatePropertyDescription(req.GetDescription()); err != nil {
+			violations = append(violations, fieldViolation("description", err))
+		}
+	}
+
+	if err := val.ValidatePropertyType(req.GetPropertyType()); err != nil {
+		violations = append(violations, fieldViolation("property_type", err))
+	}
+
+	if err := val.ValidatePropertyAddress(req.GetAddress()); err != nil {
+		violations = append(violations, fieldViolation("address", err))
+	}
+
+	if err := val.ValidatePropertyLocality(req.GetCity()); err != nil {
+		violations = append(violations, fieldViolation("city", err))
+	}
+
+	if err := val.ValidatePropertyLocality(req.GetState()); err != nil {
+		violations = append(violations, fieldViolation("state", err))
+	}
+
+	if req.GetCountry() != "" {
+		if err := val.ValidatePropertyLocality(req.GetCountry()); err != nil {
+			violations = append(violations, fieldViolation("country", err))
+		}
+	}
+
+	violations = append(violations, validatePropertyCoordinates(req.Latitude, req.Longitude)...)
+
+	if err := val.ValidateRoomCount(req.GetBedrooms()); err != nil {
+		violations = append(violations, fieldViolation("bedrooms", err))
+	}
+
+	if err := val.ValidateRoomCount(req.GetBathrooms()); err != nil {
+		violations = append(violations, fieldViolation("bathrooms", err))
+	}
+
+	if err := val.ValidateRentAmount(req.GetRentAmount()); err != nil {
+		violations = append(violations, fieldViolation("rent_amount", err))
+	}
+
+	if req.GetRentPeriod() != "" {
+		if err := val.ValidateRentPeriod(req.GetRentPeriod()); err != nil {
+			violations = append(violations, fieldViolation("rent_period", err))
+		}
+	}
+
+	violations = append(violations, validatePropertyFees(req.GetSecurityDeposit(), req.GetAgencyFee(), req.GetLegalFee())...)
+
+	if err := val.ValidateAmenities(req.GetAmenities()); err != nil {
+		violations = append(violations, fieldViolation("amenities", err))
+	}
+
+	if req.GetFurnishingStatus() != "" {
+		if err := val.ValidateFurnishingStatus(req.GetFurnishingStatus()); err != nil {
+			violations = append(violations, fieldViolation("furnishing_status", err))
+		}
+	}
+
+	if err := val.ValidateParkingSpaces(req.GetParkingSpaces()); err != nil {
+		violations = append(violations, fieldViolation("parking_spaces", err))
+	}
+
+	if err := val.ValidateTotalArea(req.GetTotalArea()); err != nil {
+		violations = append(violations, fieldViolation("total_area", err))
+	}
+
+	return violations
+}
+
+func validateUpdatePropertyRequest(req *pb.UpdatePropertyRequest) (violations []*errdetails.BadRequest_FieldViolation) {
+	violations = append(violations, validatePropertyID(req.GetPropertyId())...)
+
+	if req.Title != nil {
+		if err := val.ValidatePropertyTitle(req.GetTitle()); err != nil {
+			violations = append(violations, fieldViolation("title", err))
+		}
+	}
+
+	if req.Description != nil && req.GetDescription() != "" {
+		if err := val.ValidatePropertyDescription(req.GetDescription()); err != nil {
+			violations = append(violations, fieldViolation("description", err))
+		}
+	}
+
+	if req.PropertyType != nil {
+		if err := val.ValidatePropertyType(req.GetPropertyType()); err != nil {
+			violations = append(violations, fieldViolation("property_type", err))
+		}
+	}
+
+	if req.Address != nil {
+		if err := val.ValidatePropertyAddress(req.GetAddress()); err != nil {
+			violations = append(violations, fieldViolation("address", err))
+		}
+	}
+
+	if req.City != nil {
+		if err := val.ValidatePropertyLocality(req.GetCity()); err != nil {
+			violations = append(violations, fieldViolation("city", err))
+		}
+	}
+
+	if req.State != nil {
+		if err := val.ValidatePropertyLocality(req.GetState()); err != nil {
+			violations = append(violations, fieldViolation("state", err))
+		}
+	}
+
+	violations = append(violations, validatePropertyCoordinates(req.Latitude, req.Longitude)...)
+
+	if req.Bedrooms != nil {
+		if err := val.ValidateRoomCount(req.GetBedrooms()); err != nil {
+			violations = append(violations, fieldViolation("bedrooms", err))
+		}
+	}
+
+	if req.Bathrooms != nil {
+		if err := val.ValidateRoomCount(req.GetBathrooms()); err != nil {
+			violations = append(violations, fieldViolation("bathrooms", err))
+		}
+	}
+
+	if req.RentAmount != nil {
+		if err := val.ValidateRentAmount(req.GetRentAmount()); err != nil {
+			violations = append(violations, fieldViolation("rent_amount", err))
+		}
+	}
+
+	if req.RentPeriod != nil {
+		if err := val.ValidateRentPeriod(req.GetRentPeriod()); err != nil {
+			violations = append(violations, fieldViolation("rent_period", err))
+		}
+	}
+
+	violations = append(violations, validatePropertyFees(req.GetSecurityDeposit(), req.GetAgencyFee(), req.GetLegalFee())...)
+
+	if err := val.ValidateAmenities(req.GetAmenities()); err != nil {
+		violations = append(violations, fieldViolation("amenities", err))
+	}
+
+	if req.FurnishingStatus != nil {
+		if err := val.ValidateFurnishingStatus(req.GetFurnishingStatus()); err != nil {
+			violations = append(violations, fieldViolation("furnishing_status", err))
+		}
+	}
+
+	if err := val.ValidateParkingSpaces(req.GetParkingSpaces()); err != nil {
+		violations = append(violations, fieldViolation("parking_spaces", err))
+	}
+
+	if err := val.ValidateTotalArea(req.GetTotalArea()); err != nil {
+		violations = append(violations, fieldViolation("total_area", err))
+	}
+
+	return violations
+}
+
+func validateListMyPropertiesRequest(req *pb.ListMyPropertiesRequest) (violations []*errdetails.BadRequest_FieldViolation) {
+	if err := val.ValidatePageID(req.GetPageId()); err != nil {
+		violations = append(violations, fieldViolation("page_id", err))
+	}
+
+	if err := val.ValidatePageSize(req.GetPageSize()); err != nil {
+		violations = append(violations, fieldViolation("page_size", err))
+	}
+
+	return violations
+}
+
+func validatePropertyID(propertyID int64) (violations []*errdetails.BadRequest_FieldViolation) {
+	if err := val.ValidateID(propertyID); err != nil {
+		violations = append(violations, fieldViolation("property_id", err))
+	}
+
+	return violations
+}
+
+// validatePropertyCoordinates requires latitude and longitude to be set together
+func validatePropertyCoordinates(latitude, longitude *float64) (violations []*errdetails.BadRequest_FieldViolation) {
+	if (latitude == nil) != (longitude == nil) {
+		field := "latitude"
+		if latitude != nil {
+			field = "longitude"
+		}
+		return append(violations, fieldViolation(field, errors.New("must be set together with the other coordinate")))
+	}
+
+	if latitude == nil {
+		return violations
+	}
+
+	if err := val.ValidateLatitude(*latitude); err != nil {
+		violations = append(violations, fieldViolation("latitude", err))
+	}
+
+	if err := val.ValidateLongitude(*longitude); err != nil {
+		violations = append(violations, fieldViolation("longitude", err))
+	}
+
+	return violations
+}
+
+func validatePropertyFees(securityDeposit, agencyFee, legalFee float64) (violations []*errdetails.BadRequest_FieldViolation) {
+	if err := val.ValidatePropertyFee(securityDeposit); err != nil {
+		violations = append(violations, fieldViolation("security_deposit", err))
+	}
+
+	if err := val.ValidatePropertyFee(agencyFee); err != nil {
+		violations = append(violations, fieldViolation("agency_fee", err))
+	}
+
+	if err := val.ValidatePropertyFee(legalFee); err != nil {
+		violations = append(violations, fieldViolation("legal_fee", err))
+	}
+
+	return violations
+}
@@ -0,0 +1,189 @@
+package gapi
+
+import (
+	"context"
+	"fmt"
+	"math"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	db "github.com/r-scheele/sqr/internal/db/sqlc"
+	"github.com/r-scheele/sqr/internal/pb"
+	"github.com/r-scheele/sqr/internal/val"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// propertyPinMinZoom is the zoom level from which GetPropertyMap returns single pins instead of clusters
+	propertyPinMinZoom = 14
+	// clusterCellsPerTile splits each 256px map tile into cells of 32px, so clusters don't overlap on screen
+	clusterCellsPerTile = 8
+	// propertyMapLimit caps the pins or clusters of one viewport
+	propertyMapLimit = 500
+)
+
+// SearchPropertiesNearby finds published, available properties within a radius of a point, nearest first
+func (server *Server) SearchPropertiesNearby(ctx context.Context, req *pb.SearchPropertiesNearbyRequest) (*pb.SearchPropertiesNearbyResponse, error) {
+	violations := validateSearchPropertiesNearbyRequest(req)
+	if violations != nil {
+		return nil, invalidArgumentError(violations)
+	}
+
+	rows, err := server.store.SearchPropertiesNearby(ctx, db.SearchPropertiesNearbyParams{
+		Latitude:     req.GetLatitude(),
+		Longitude:    req.GetLongitude(),
+		RadiusMeters: req.GetRadiusKm() * 1000,
+		PropertyType: db.NullPropertyTypeEnum{PropertyTypeEnum: db.PropertyTypeEnum(req.GetPropertyType()), Valid: req.GetPropertyType() != ""},
+		MinRent:      optionalNumeric(req.GetMinRent(), 2),
+		MaxRent:      optionalNumeric(req.GetMaxRent(), 2),
+		MinBedrooms:  pgtype.Int4{Int32: req.GetMinBedrooms(), Valid: req.GetMinBedrooms() > 0},
+		Limit:        req.GetPageSize(),
+		Offset:       (req.GetPageId() - 1) * req.GetPageSize(),
+	})
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to search nearby properties: %s", err)
+	}
+
+	rsp := &pb.SearchPropertiesNearbyResponse{
+		Results: make([]*pb.NearbyProperty, 0, len(rows)),
+	}
+	for _, row := range rows {
+		rsp.Results = append(rsp.Results, &pb.NearbyProperty{
+			Property:   convertProperty(row.Property),
+			DistanceKm: math.Round(row.DistanceMeters/10) / 100,
+		})
+	}
+
+	return rsp, nil
+}
+
+// GetPropertyMap returns what a map viewport shows of the published, available properties: single pins once the map
+// is zoomed in to propertyPinMinZoom, and clusters of nearby properties below it
+func (server *Server) GetPropertyMap(ctx context.Context, req *pb.GetPropertyMapRequest) (*pb.GetPropertyMapResponse, error) {
+	violations := validateGetPropertyMapRequest(req)
+	if violations != nil {
+		return nil, invalidArgumentError(violations)
+	}
+
+	minLatitude := numericFromFloat(req.GetMinLatitude(), 8)
+	maxLatitude := numericFromFloat(req.GetMaxLatitude(), 8)
+	minLongitude := numericFromFloat(req.GetMinLongitude(), 8)
+	maxLongitude := numericFromFloat(req.GetMaxLongitude(), 8)
+
+	rsp := &pb.GetPropertyMapResponse{}
+	if req.GetZoom() >= propertyPinMinZoom {
+		pins, err := server.store.ListPropertyPinsInBounds(ctx, db.ListPropertyPinsInBoundsParams{
+			MinLatitude:  minLatitude,
+			MaxLatitude:  maxLatitude,
+			MinLongitude: minLongitude,
+			MaxLongitude: maxLongitude,
+			Limit:        propertyMapLimit,
+		})
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "failed to list property pins: %s", err)
+		}
+
+		rsp.Pins = make([]*pb.PropertyPin, 0, len(pins))
+		for _, pin := range pins {
+			rsp.Pins = append(rsp.Pins, convertPropertyPin(pin))
+		}
+		rsp.Truncated = len(pins) == propertyMapLimit
+		return rsp, nil
+	}
+
+	clusters, err := server.store.ClusterPropertiesInBounds(ctx, db.ClusterPropertiesInBoundsParams{
+		MinLatitude:  minLatitude,
+		MaxLatitude:  maxLatitude,
+		MinLongitude: minLongitude,
+		MaxLongitude: maxLongitude,
+		CellDegrees:  clusterCellDegrees(req.GetZoom()),
+		Limit:        propertyMapLimit,
+	})
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to cluster properties: %s", err)
+	}
+
+	rsp.Clusters = make([]*pb.PropertyCluster, 0, len(clusters))
+	for _, cluster := range clusters {
+		rsp.Clusters = append(rsp.Clusters, convertPropertyCluster(cluster))
+	}
+	rsp.Truncated = len(clusters) == propertyMapLimit
+	return rsp, nil
+}
+
+// clusterCellDegrees is the side of a cluster cell at zoom; the world is 2^zoom tiles wide
+func clusterCellDegrees(zoom int32) float64 {
+	return 360 / (math.Exp2(float64(zoom)) * clusterCellsPerTile)
+}
+
+func validateSearchPropertiesNearbyRequest(req *pb.SearchPropertiesNearbyRequest) (violations []*errdetails.BadRequest_FieldViolation) {
+	if err := val.ValidateLatitude(req.GetLatitude()); err != nil {
+		violations = append(violations, fieldViolation("latitude", err))
+	}
+
+	if err := val.ValidateLongitude(req.GetLongitude()); err != nil {
+		violations = append(violations, fieldViolation("longitude", err))
+	}
+
+	if err := val.ValidateSearchRadius(req.GetRadiusKm()); err != nil {
+		violations = append(violations, fieldViolation("radius_km", err))
+	}
+
+	if req.GetPropertyType() != "" {
+		if err := val.ValidatePropertyType(req.GetPropertyType()); err != nil {
+			violations = append(violations, fieldViolation("property_type", err))
+		}
+	}
+
+	if err := val.ValidatePropertyFee(req.GetMinRent()); err != nil {
+		violations = append(violations, fieldViolation("min_rent", err))
+	}
+
+	if err := val.ValidatePropertyFee(req.GetMaxRent()); err != nil {
+		violations = append(violations, fieldViolation("max_rent", err))
+	} else if req.GetMaxRent() > 0 && req.GetMaxRent() < req.GetMinRent() {
+		violations = append(violations, fieldViolation("max_rent", fmt.Errorf("must not be less than min_rent")))
+	}
+
+	if err := val.ValidateRoomCount(req.GetMinBedrooms()); err != nil {
+		violations = append(violations, fieldViolation("min_bedrooms", err))
+	}
+
+	if err := val.ValidatePageID(req.GetPageId()); err != nil {
+		violations = append(violations, fieldViolation("page_id", err))
+	}
+
+	if err := val.ValidatePageSize(req.GetPageSize()); err != nil {
+		violations = append(violations, fieldViolation("page_size", err))
+	}
+
+	return violations
+}
+
+func validateGetPropertyMapRequest(req *pb.GetPropertyMapRequest) (violations []*errdetails.BadRequest_FieldViolation) {
+	if err := val.ValidateLatitude(req.GetMinLatitude()); err != nil {
+		violations = append(violations, fieldViolation("min_latitude", err))
+	}
+
+	if err := val.ValidateLatitude(req.GetMaxLatitude()); err != nil {
+		violations = append(violations, fieldViolation("max_latitude", err))
+	} else if req.GetMaxLatitude() < req.GetMinLatitude() {
+		violations = append(violations, fieldViolation("max_latitude", fmt.Errorf("must not be less than min_latitude")))
+	}
+
+	// min_longitude may be greater than max_longitude when the viewport crosses the antimeridian
+	if err := val.ValidateLongitude(req.GetMinLongitude()); err != nil {
+		violations = append(violations, fieldViolation("min_longitude", err))
+	}
+
+	if err := val.ValidateLongitude(req.GetMaxLongitude()); err != nil {
+		violations = append(violations, fieldViolation("max_longitude", err))
+	}
+
+	if err := val.ValidateMapZoom(req.GetZoom()); err != nil {
+		violations = append(violations, fieldViolation("zoom", err))
+	}
+
+	return violations
+}
@@ -0,0 +1,216 @@
+package gapi
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	mockdb "github.com/r-scheele/sqr/internal/db/mock"
+	db "github.com/r-scheele/sqr/internal/db/sqlc"
+	"github.com/r-scheele/sqr/internal/pb"
+	"github.com/r-scheele/sqr/internal/util"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSearchPropertiesNearbyAPI(t *testing.T) {
+	property := randomProperty(util.RandomInt(1, 1000), db.PropertyStatusEnumActive)
+
+	validRequest := func() *pb.SearchPropertiesNearbyRequest {
+		return &pb.SearchPropertiesNearbyRequest{
+			Latitude:  6.5244,
+			Longitude: 3.3792,
+			RadiusKm:  5,
+			PageId:    1,
+			PageSize:  10,
+		}
+	}
+
+	testCases := []struct {
+		name          string
+		req           func() *pb.SearchPropertiesNearbyRequest
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(t *testing.T, res *pb.SearchPropertiesNearbyResponse, err error)
+	}{
+		{
+			name: "OK",
+			req:  validRequest,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					SearchPropertiesNearby(gomock.Any(), gomock.Any()).
+					Times(1).
+					DoAndReturn(func(_ context.Context, arg db.SearchPropertiesNearbyParams) ([]db.SearchPropertiesNearbyRow, error) {
+						require.Equal(t, 5000.0, arg.RadiusMeters)
+						require.False(t, arg.PropertyType.Valid)
+						require.Equal(t, int32(10), arg.Limit)
+						require.Zero(t, arg.Offset)
+						return []db.SearchPropertiesNearbyRow{{Property: property, DistanceMeters: 1834.6}}, nil
+					})
+			},
+			checkResponse: func(t *testing.T, res *pb.SearchPropertiesNearbyResponse, err error) {
+				require.NoError(t, err)
+				require.Len(t, res.GetResults(), 1)
+				require.Equal(t, property.ID, res.GetResults()[0].GetProperty().GetId())
+				require.Equal(t, 1.83, res.GetResults()[0].GetDistanceKm())
+			},
+		},
+		{
+			name: "RadiusTooLarge",
+			req: func() *pb.SearchPropertiesNearbyRequest {
+				req := validRequest()
+				req.RadiusKm = 120
+				return req
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					SearchPropertiesNearby(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, res *pb.SearchPropertiesNearbyResponse, err error) {
+				requireFieldViolation(t, err, "radius_km")
+			},
+		},
+		{
+			name: "InvalidLatitude",
+			req: func() *pb.SearchPropertiesNearbyRequest {
+				req := validRequest()
+				req.Latitude = 95
+				return req
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					SearchPropertiesNearby(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, res *pb.SearchPropertiesNearbyResponse, err error) {
+				requireFieldViolation(t, err, "latitude")
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			res, err := server.SearchPropertiesNearby(context.Background(), tc.req())
+			tc.checkResponse(t, res, err)
+		})
+	}
+}
+
+func TestGetPropertyMapAPI(t *testing.T) {
+	viewport := func(zoom int32) *pb.GetPropertyMapRequest {
+		return &pb.GetPropertyMapRequest{
+			MinLatitude:  6.40,
+			MinLongitude: 3.30,
+			MaxLatitude:  6.70,
+			MaxLongitude: 3.60,
+			Zoom:         zoom,
+		}
+	}
+
+	testCases := []struct {
+		name          string
+		req           *pb.GetPropertyMapRequest
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(t *testing.T, res *pb.GetPropertyMapResponse, err error)
+	}{
+		{
+			name: "PinsWhenZoomedIn",
+			req:  viewport(propertyPinMinZoom),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					ListPropertyPinsInBounds(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return([]db.ListPropertyPinsInBoundsRow{{
+						ID:           7,
+						Title:        "Two bedroom flat in Yaba",
+						PropertyType: db.PropertyTypeEnumApartment,
+						Bedrooms:     2,
+						RentAmount:   numericFromFloat(1800000, 2),
+						Latitude:     6.5095,
+						Longitude:    3.3711,
+					}}, nil)
+
+				store.EXPECT().
+					ClusterPropertiesInBounds(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, res *pb.GetPropertyMapResponse, err error) {
+				require.NoError(t, err)
+				require.Len(t, res.GetPins(), 1)
+				require.Empty(t, res.GetClusters())
+				require.Equal(t, int64(7), res.GetPins()[0].GetPropertyId())
+				require.False(t, res.GetTruncated())
+			},
+		},
+		{
+			name: "ClustersWhenZoomedOut",
+			req:  viewport(10),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					ListPropertyPinsInBounds(gomock.Any(), gomock.Any()).
+					Times(0)
+
+				store.EXPECT().
+					ClusterPropertiesInBounds(gomock.Any(), gomock.Any()).
+					Times(1).
+					DoAndReturn(func(_ context.Context, arg db.ClusterPropertiesInBoundsParams) ([]db.ClusterPropertiesInBoundsRow, error) {
+						require.InDelta(t, 360.0/8192, arg.CellDegrees, 1e-12)
+						require.Equal(t, 3.3, numericToFloat(arg.MinLongitude))
+						return []db.ClusterPropertiesInBoundsRow{
+							{PropertyCount: 12, Latitude: 6.45, Longitude: 3.42, MinRent: numericFromFloat(900000, 2), PropertyID: 3},
+							{PropertyCount: 1, Latitude: 6.6, Longitude: 3.35, MinRent: numericFromFloat(2500000, 2), PropertyID: 9},
+						}, nil
+					})
+			},
+			checkResponse: func(t *testing.T, res *pb.GetPropertyMapResponse, err error) {
+				require.NoError(t, err)
+				require.Empty(t, res.GetPins())
+				require.Len(t, res.GetClusters(), 2)
+				require.Zero(t, res.GetClusters()[0].GetPropertyId())
+				require.Equal(t, int64(9), res.GetClusters()[1].GetPropertyId())
+			},
+		},
+		{
+			name: "InvertedLatitudes",
+			req: &pb.GetPropertyMapRequest{
+				MinLatitude:  6.70,
+				MinLongitude: 3.30,
+				MaxLatitude:  6.40,
+				MaxLongitude: 3.60,
+				Zoom:         12,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					ClusterPropertiesInBounds(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, res *pb.GetPropertyMapResponse, err error) {
+				requireFieldViolation(t, err, "max_latitude")
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			res, err := server.GetPropertyMap(context.Background(), tc.req)
+			tc.checkResponse(t, res, err)
+		})
+	}
+}
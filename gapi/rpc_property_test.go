@@ -0,0 +1,460 @@
+package gapi
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+	mockdb "github.com/r-scheele/sqr/internal/db/mock"
+	db "github.com/r-scheele/sqr/internal/db/sqlc"
+	"github.com/r-scheele/sqr/internal/pb"
+	"github.com/r-scheele/sqr/internal/token"
+	"github.com/r-scheele/sqr/internal/util"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+func randomProperty(landlordID int64, propertyStatus db.PropertyStatusEnum) db.Property {
+	return db.Property{
+		ID:               util.RandomInt(1, 1000),
+		LandlordID:       landlordID,
+		Title:            "Two bedroom flat in Yaba",
+		Description:      pgtype.Text{String: util.RandomString(40), Valid: true},
+		PropertyType:     db.PropertyTypeEnumApartment,
+		Address:          "12 Herbert Macaulay Way",
+		City:             "Lagos",
+		State:            "Lagos",
+		Latitude:         numericFromFloat(6.5095, 8),
+		Longitude:        numericFromFloat(3.3711, 8),
+		Bedrooms:         2,
+		Bathrooms:        2,
+		RentAmount:       numericFromFloat(1800000, 2),
+		RentPeriod:       db.NullRentPeriodEnum{RentPeriodEnum: db.RentPeriodEnumAnnually, Valid: true},
+		FurnishingStatus: db.NullFurnishingStatusEnum{FurnishingStatusEnum: db.FurnishingStatusEnumUnfurnished, Valid: true},
+		Status:           db.NullPropertyStatusEnum{PropertyStatusEnum: propertyStatus, Valid: true},
+		CreatedAt:        pgtype.Timestamptz{Time: time.Now(), Valid: true},
+		UpdatedAt:        pgtype.Timestamptz{Time: time.Now(), Valid: true},
+	}
+}
+
+func TestCreatePropertyAPI(t *testing.T) {
+	landlord, _ := randomUser(t, util.LandlordRole)
+
+	validRequest := func() *pb.CreatePropertyRequest {
+		return &pb.CreatePropertyRequest{
+			Title:        "Two bedroom flat in Yaba",
+			PropertyType: "apartment",
+			Address:      "12 Herbert Macaulay Way",
+			City:         "Lagos",
+			State:        "Lagos",
+			Latitude:     proto.Float64(6.5095),
+			Longitude:    proto.Float64(3.3711),
+			Bedrooms:     2,
+			Bathrooms:    2,
+			RentAmount:   1800000,
+			AgencyFee:    180000,
+			Amenities:    []string{"borehole", "prepaid meter"},
+		}
+	}
+
+	testCases := []struct {
+		name          string
+		req           func() *pb.CreatePropertyRequest
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(t *testing.T, res *pb.CreatePropertyResponse, err error)
+	}{
+		{
+			name: "OK",
+			req:  validRequest,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetUserByID(gomock.Any(), gomock.Eq(landlord.ID)).
+					Times(1).
+					Return(landlord, nil)
+
+				store.EXPECT().
+					CreateProperty(gomock.Any(), gomock.Any()).
+					Times(1).
+					DoAndReturn(func(_ context.Context, arg db.CreatePropertyParams) (db.Property, error) {
+						require.Equal(t, landlord.ID, arg.LandlordID)
+						require.Equal(t, db.RentPeriodEnumAnnually, arg.RentPeriod.RentPeriodEnum)
+						require.False(t, arg.SecurityDeposit.Valid)
+						require.Equal(t, 180000.0, numericToFloat(arg.AgencyFee))
+						require.Equal(t, "borehole, prepaid meter", arg.Amenities.String)
+
+						return db.Property{
+							ID:           1,
+							LandlordID:   arg.LandlordID,
+							Title:        arg.Title,
+							PropertyType: arg.PropertyType,
+							RentAmount:   arg.RentAmount,
+							RentPeriod:   arg.RentPeriod,
+							Amenities:    arg.Amenities,
+						}, nil
+					})
+			},
+			checkResponse: func(t *testing.T, res *pb.CreatePropertyResponse, err error) {
+				require.NoError(t, err)
+				require.Equal(t, "draft", res.GetProperty().GetStatus())
+				require.Equal(t, 1800000.0, res.GetProperty().GetRentAmount())
+				require.Equal(t, []string{"borehole", "prepaid meter"}, res.GetProperty().GetAmenities())
+			},
+		},
+		{
+			name: "InvalidRentAmount",
+			req: func() *pb.CreatePropertyRequest {
+				req := validRequest()
+				req.RentAmount = 0
+				return req
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					CreateProperty(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, res *pb.CreatePropertyResponse, err error) {
+				requireFieldViolation(t, err, "rent_amount")
+			},
+		},
+		{
+			name: "LatitudeWithoutLongitude",
+			req: func() *pb.CreatePropertyRequest {
+				req := validRequest()
+				req.Longitude = nil
+				return req
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					CreateProperty(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, res *pb.CreatePropertyResponse, err error) {
+				requireFieldViolation(t, err, "longitude")
+			},
+		},
+		{
+			name: "InvalidPropertyType",
+			req: func() *pb.CreatePropertyRequest {
+				req := validRequest()
+				req.PropertyType = "castle"
+				return req
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					CreateProperty(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, res *pb.CreatePropertyResponse, err error) {
+				requireFieldViolation(t, err, "property_type")
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			ctx := newContextWithBearerToken(t, server.tokenMaker, landlord, uuid.New(), time.Minute, token.TokenTypeAccessToken)
+
+			res, err := server.CreateProperty(ctx, tc.req())
+			tc.checkResponse(t, res, err)
+		})
+	}
+}
+
+func TestPublishPropertyAPI(t *testing.T) {
+	landlord, _ := randomUser(t, util.LandlordRole)
+	landlord.IsVerified = pgtype.Bool{Bool: true, Valid: true}
+
+	verified := []db.UserVerification{
+		randomEmailVerification(landlord.ID, db.VerificationStatusEnumVerified),
+		randomPhoneVerification(t, landlord.ID, db.VerificationStatusEnumVerified, otpData{Destination: landlord.Phone}),
+		randomNINVerification(landlord.ID, db.VerificationStatusEnumVerified, time.Now()),
+	}
+	withoutNIN := verified[:2]
+
+	// runTx stands in for ChangePropertyStatusTx, publishing property unless BeforeChange refuses
+	runTx := func(property db.Property) func(context.Context, db.ChangePropertyStatusTxParams) (db.ChangePropertyStatusTxResult, error) {
+		return func(_ context.Context, arg db.ChangePropertyStatusTxParams) (db.ChangePropertyStatusTxResult, error) {
+			result := db.ChangePropertyStatusTxResult{OldStatus: db.PropertyStatus(property)}
+			if err := arg.BeforeChange(property); err != nil {
+				return result, err
+			}
+
+			property.Status = db.NullPropertyStatusEnum{PropertyStatusEnum: arg.Status, Valid: true}
+			property.IsAvailable = pgtype.Bool{Bool: true, Valid: true}
+			result.Property = property
+			return result, nil
+		}
+	}
+
+	testCases := []struct {
+		name          string
+		property      db.Property
+		buildStubs    func(store *mockdb.MockStore, property db.Property)
+		checkResponse func(t *testing.T, res *pb.PublishPropertyResponse, err error)
+	}{
+		{
+			name:     "OK",
+			property: randomProperty(landlord.ID, db.PropertyStatusEnumDraft),
+			buildStubs: func(store *mockdb.MockStore, property db.Property) {
+				store.EXPECT().
+					ChangePropertyStatusTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					DoAndReturn(runTx(property))
+
+				store.EXPECT().
+					GetUserVerificationsByUserID(gomock.Any(), gomock.Eq(landlord.ID)).
+					Times(1).
+					Return(verified, nil)
+
+				store.EXPECT().
+					GetLandlordProfileByUserID(gomock.Any(), gomock.Eq(landlord.ID)).
+					Times(1).
+					Return(randomLandlordProfile(landlord.ID), nil)
+
+				store.EXPECT().
+					CountPropertyMediaByType(gomock.Any(), gomock.Eq(db.CountPropertyMediaByTypeParams{
+						PropertyID: property.ID,
+						MediaType:  db.MediaTypeEnumImage,
+					})).
+					Times(1).
+					Return(int64(3), nil)
+			},
+			checkResponse: func(t *testing.T, res *pb.PublishPropertyResponse, err error) {
+				require.NoError(t, err)
+				require.Equal(t, "active", res.GetProperty().GetStatus())
+				require.True(t, res.GetProperty().GetIsAvailable())
+			},
+		},
+		{
+			name: "MissingImagesAndDetails",
+			property: func() db.Property {
+				property := randomProperty(landlord.ID, db.PropertyStatusEnumDraft)
+				property.Description = pgtype.Text{}
+				return property
+			}(),
+			buildStubs: func(store *mockdb.MockStore, property db.Property) {
+				store.EXPECT().
+					ChangePropertyStatusTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					DoAndReturn(runTx(property))
+
+				store.EXPECT().
+					GetUserVerificationsByUserID(gomock.Any(), gomock.Eq(landlord.ID)).
+					Times(1).
+					Return(verified, nil)
+
+				store.EXPECT().
+					GetLandlordProfileByUserID(gomock.Any(), gomock.Eq(landlord.ID)).
+					Times(1).
+					Return(randomLandlordProfile(landlord.ID), nil)
+
+				store.EXPECT().
+					CountPropertyMediaByType(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(int64(0), nil)
+			},
+			checkResponse: func(t *testing.T, res *pb.PublishPropertyResponse, err error) {
+				require.Error(t, err)
+				require.Equal(t, codes.FailedPrecondition, status.Code(err))
+				require.Contains(t, err.Error(), "a description")
+				require.Contains(t, err.Error(), "at least one image")
+			},
+		},
+		{
+			name:     "UnverifiedLandlord",
+			property: randomProperty(landlord.ID, db.PropertyStatusEnumDraft),
+			buildStubs: func(store *mockdb.MockStore, property db.Property) {
+				store.EXPECT().
+					ChangePropertyStatusTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					DoAndReturn(runTx(property))
+
+				store.EXPECT().
+					GetUserVerificationsByUserID(gomock.Any(), gomock.Eq(landlord.ID)).
+					Times(1).
+					Return(withoutNIN, nil)
+
+				store.EXPECT().
+					GetLandlordProfileByUserID(gomock.Any(), gomock.Eq(landlord.ID)).
+					Times(1).
+					Return(randomLandlordProfile(landlord.ID), nil)
+
+				store.EXPECT().
+					CountPropertyMediaByType(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, res *pb.PublishPropertyResponse, err error) {
+				require.Error(t, err)
+				require.Equal(t, codes.FailedPrecondition, status.Code(err))
+				require.Contains(t, err.Error(), "Verify your NIN")
+			},
+		},
+		{
+			name:     "Archived",
+			property: randomProperty(landlord.ID, db.PropertyStatusEnumInactive),
+			buildStubs: func(store *mockdb.MockStore, property db.Property) {
+				store.EXPECT().
+					ChangePropertyStatusTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.ChangePropertyStatusTxResult{OldStatus: db.PropertyStatusEnumInactive}, db.ErrPropertyStatusTransition)
+			},
+			checkResponse: func(t *testing.T, res *pb.PublishPropertyResponse, err error) {
+				require.Error(t, err)
+				require.Equal(t, codes.FailedPrecondition, status.Code(err))
+				require.Contains(t, err.Error(), "archived")
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			store.EXPECT().
+				GetUserByID(gomock.Any(), gomock.Eq(landlord.ID)).
+				Times(1).
+				Return(landlord, nil)
+			store.EXPECT().
+				GetPropertyByID(gomock.Any(), gomock.Eq(tc.property.ID)).
+				Times(1).
+				Return(tc.property, nil)
+			tc.buildStubs(store, tc.property)
+
+			server := newTestServer(t, store)
+			ctx := newContextWithBearerToken(t, server.tokenMaker, landlord, uuid.New(), time.Minute, token.TokenTypeAccessToken)
+
+			res, err := server.PublishProperty(ctx, &pb.PublishPropertyRequest{PropertyId: tc.property.ID})
+			tc.checkResponse(t, res, err)
+		})
+	}
+}
+
+func TestUpdatePropertyAPI(t *testing.T) {
+	landlord, _ := randomUser(t, util.LandlordRole)
+
+	testCases := []struct {
+		name          string
+		property      db.Property
+		req           func(property db.Property) *pb.UpdatePropertyRequest
+		buildStubs    func(store *mockdb.MockStore, property db.Property)
+		checkResponse func(t *testing.T, res *pb.UpdatePropertyResponse, err error)
+	}{
+		{
+			name:     "OK",
+			property: randomProperty(landlord.ID, db.PropertyStatusEnumActive),
+			req: func(property db.Property) *pb.UpdatePropertyRequest {
+				return &pb.UpdatePropertyRequest{
+					PropertyId: property.ID,
+					RentAmount: proto.Float64(2000000),
+				}
+			},
+			buildStubs: func(store *mockdb.MockStore, property db.Property) {
+				store.EXPECT().
+					UpdateProperty(gomock.Any(), gomock.Any()).
+					Times(1).
+					DoAndReturn(func(_ context.Context, arg db.UpdatePropertyParams) (db.Property, error) {
+						require.Equal(t, property.Title, arg.Title)
+						require.Equal(t, property.Description, arg.Description)
+						require.Equal(t, 2000000.0, numericToFloat(arg.RentAmount))
+
+						property.RentAmount = arg.RentAmount
+						return property, nil
+					})
+			},
+			checkResponse: func(t *testing.T, res *pb.UpdatePropertyResponse, err error) {
+				require.NoError(t, err)
+				require.Equal(t, 2000000.0, res.GetProperty().GetRentAmount())
+			},
+		},
+		{
+			name:     "PublishedWithoutDescription",
+			property: randomProperty(landlord.ID, db.PropertyStatusEnumActive),
+			req: func(property db.Property) *pb.UpdatePropertyRequest {
+				return &pb.UpdatePropertyRequest{
+					PropertyId:  property.ID,
+					Description: proto.String(""),
+				}
+			},
+			buildStubs: func(store *mockdb.MockStore, property db.Property) {
+				store.EXPECT().
+					UpdateProperty(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, res *pb.UpdatePropertyResponse, err error) {
+				require.Error(t, err)
+				require.Equal(t, codes.FailedPrecondition, status.Code(err))
+			},
+		},
+		{
+			name:     "Archived",
+			property: randomProperty(landlord.ID, db.PropertyStatusEnumInactive),
+			req: func(property db.Property) *pb.UpdatePropertyRequest {
+				return &pb.UpdatePropertyRequest{
+					PropertyId: property.ID,
+					Title:      proto.String("Renovated two bedroom flat"),
+				}
+			},
+			buildStubs: func(store *mockdb.MockStore, property db.Property) {
+				store.EXPECT().
+					UpdateProperty(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, res *pb.UpdatePropertyResponse, err error) {
+				require.Error(t, err)
+				require.Equal(t, codes.FailedPrecondition, status.Code(err))
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			store.EXPECT().
+				GetUserByID(gomock.Any(), gomock.Eq(landlord.ID)).
+				Times(1).
+				Return(landlord, nil)
+			store.EXPECT().
+				GetPropertyByID(gomock.Any(), gomock.Eq(tc.property.ID)).
+				Times(2).
+				Return(tc.property, nil)
+			tc.buildStubs(store, tc.property)
+
+			server := newTestServer(t, store)
+			ctx := newContextWithBearerToken(t, server.tokenMaker, landlord, uuid.New(), time.Minute, token.TokenTypeAccessToken)
+
+			res, err := server.UpdateProperty(ctx, tc.req(tc.property))
+			tc.checkResponse(t, res, err)
+		})
+	}
+}
+
+func TestPropertyStatusTransitions(t *testing.T) {
+	require.True(t, db.CanChangePropertyStatus(db.PropertyStatusEnumDraft, db.PropertyStatusEnumActive))
+	require.True(t, db.CanChangePropertyStatus(db.PropertyStatusEnumRented, db.PropertyStatusEnumActive))
+	require.False(t, db.CanChangePropertyStatus(db.PropertyStatusEnumDraft, db.PropertyStatusEnumRented))
+	require.False(t, db.CanChangePropertyStatus(db.PropertyStatusEnumInactive, db.PropertyStatusEnumActive))
+	require.Equal(t, db.PropertyStatusEnumDraft, db.PropertyStatus(db.Property{}))
+}
@@ -3,7 +3,9 @@ package gapi
 import (
 	"context"
 	"errors"
+	"time"
 
+	"github.com/jackc/pgx/v5/pgtype"
 	db "github.com/r-scheele/sqr/internal/db/sqlc"
 	"github.com/r-scheele/sqr/internal/pb"
 	"github.com/r-scheele/sqr/internal/token"
@@ -28,7 +30,7 @@ func (server *Server) RefreshToken(ctx context.Context, req *pb.RefreshTokenRequ
 	session, err := server.store.GetUserSessionByToken(ctx, req.GetRefreshToken())
 	if err != nil {
 		if errors.Is(err, db.ErrRecordNotFound) {
-			return nil, status.Errorf(codes.Unauthenticated, "session not found")
+			return nil, server.detectRefreshTokenReuse(ctx, req.GetRefreshToken())
 		}
 		return nil, status.Errorf(codes.Internal, "failed to get session")
 	}
@@ -46,8 +48,10 @@ func (server *Server) RefreshToken(ctx context.Context, req *pb.RefreshTokenRequ
 	}
 
 	accessToken, accessPayload, err := server.tokenMaker.CreateToken(
-		refreshPayload.Username,
+		user.ID,
+		user.Email,
 		string(user.UserType),
+		session.SessionID,
 		server.config.AccessTokenDuration,
 		token.TokenTypeAccessToken,
 	)
@@ -55,23 +59,75 @@ func (server *Server) RefreshToken(ctx context.Context, req *pb.RefreshTokenRequ
 		return nil, status.Errorf(codes.Internal, "failed to create access token")
 	}
 
-	err = server.store.UpdateSessionActivity(ctx, db.UpdateSessionActivityParams{
-		SessionToken: req.GetRefreshToken(),
-		ExpiresAt:    refreshPayload.ExpiredAt, // Keep the same expiration
-	})
+	// The rotated refresh token keeps the expiration of the one it replaces,
+	// so a token family can't outlive the original login
+	refreshToken, newRefreshPayload, err := server.tokenMaker.CreateToken(
+		user.ID,
+		user.Email,
+		string(user.UserType),
+		session.SessionID,
+		time.Until(refreshPayload.ExpiredAt),
+		token.TokenTypeRefreshToken,
+	)
 	if err != nil {
-		// Log this error but don't fail the request
+		return nil, status.Errorf(codes.Internal, "failed to create refresh token")
+	}
 
-		// The token refresh can still succeed even if we can't update the session
+	mtdt := server.extractMetadata(ctx)
+	_, err = server.store.RotateSessionTx(ctx, db.RotateSessionTxParams{
+		Session:         session,
+		NewSessionToken: refreshToken,
+		IpAddress:       pgtype.Text{String: mtdt.ClientIP, Valid: mtdt.ClientIP != ""},
+		DeviceInfo:      pgtype.Text{String: mtdt.UserAgent, Valid: mtdt.UserAgent != ""},
+		ExpiresAt:       newRefreshPayload.ExpiredAt,
+	})
+	if err != nil {
+		if errors.Is(err, db.ErrSessionAlreadyRotated) {
+			return nil, server.detectRefreshTokenReuse(ctx, req.GetRefreshToken())
+		}
+		return nil, status.Errorf(codes.Internal, "failed to rotate session")
 	}
 
 	rsp := &pb.RefreshTokenResponse{
-		AccessToken:          accessToken,
-		AccessTokenExpiresAt: timestamppb.New(accessPayload.ExpiredAt),
+		AccessToken:           accessToken,
+		AccessTokenExpiresAt:  timestamppb.New(accessPayload.ExpiredAt),
+		RefreshToken:          refreshToken,
+		RefreshTokenExpiresAt: timestamppb.New(newRefreshPayload.ExpiredAt),
 	}
 	return rsp, nil
 }
 
+// detectRefreshTokenReuse is called when a refresh token has no active session.
+// If the token belongs to a session that was already rotated, it has been
+// presented twice and the whole token family is revoked, access tokens included.
+func (server *Server) detectRefreshTokenReuse(ctx context.Context, refreshToken string) error {
+	session, err := server.store.GetRotatedUserSessionByToken(ctx, refreshToken)
+	if err != nil {
+		if errors.Is(err, db.ErrRecordNotFound) {
+			return status.Errorf(codes.Unauthenticated, "session not found")
+		}
+		return status.Errorf(codes.Internal, "failed to get session")
+	}
+
+	mtdt := server.extractMetadata(ctx)
+	_, err = server.store.RevokeSessionFamilyTx(ctx, db.RevokeSessionFamilyTxParams{
+		ReusedSession: session,
+		IpAddress:     pgtype.Text{String: mtdt.ClientIP, Valid: mtdt.ClientIP != ""},
+		UserAgent:     pgtype.Text{String: mtdt.UserAgent, Valid: mtdt.UserAgent != ""},
+	})
+	if err != nil {
+		return status.Errorf(codes.Internal, "failed to revoke sessions")
+	}
+
+	// Access tokens already issued to the family, possibly to an attacker, must stop working as well
+	err = server.denylist.RevokeUser(ctx, session.UserID, server.config.AccessTokenDuration)
+	if err != nil {
+		return status.Errorf(codes.Internal, "failed to revoke access tokens")
+	}
+
+	return status.Errorf(codes.Unauthenticated, "refresh token has already been used")
+}
+
 func validateRefreshTokenRequest(req *pb.RefreshTokenRequest) (violations []*errdetails.BadRequest_FieldViolation) {
 	if err := val.ValidateString(req.GetRefreshToken(), 1, 500); err != nil {
 		violations = append(violations, fieldViolation("refresh_token", err))
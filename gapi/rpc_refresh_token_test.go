@@ -6,6 +6,7 @@ import (
 	"time"
 
 	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5/pgtype"
 	mockdb "github.com/r-scheele/sqr/internal/db/mock"
 	db "github.com/r-scheele/sqr/internal/db/sqlc"
@@ -13,6 +14,8 @@ import (
 	"github.com/r-scheele/sqr/internal/token"
 	"github.com/r-scheele/sqr/internal/util"
 	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 func TestRefreshTokenAPI(t *testing.T) {
@@ -27,10 +30,11 @@ func TestRefreshTokenAPI(t *testing.T) {
 		{
 			name: "OK",
 			setupToken: func(tokenMaker token.Maker) string {
-				username := user.FirstName + "_" + user.LastName
 				refreshToken, _, err := tokenMaker.CreateToken(
-					username,
+					user.ID,
+					user.Email,
 					string(user.UserType),
+					uuid.New(),
 					time.Hour,
 					token.TokenTypeRefreshToken,
 				)
@@ -57,15 +61,115 @@ func TestRefreshTokenAPI(t *testing.T) {
 					Return(user, nil)
 
 				store.EXPECT().
-					UpdateSessionActivity(gomock.Any(), gomock.Any()).
+					RotateSessionTx(gomock.Any(), gomock.Any()).
 					Times(1).
-					Return(nil)
+					DoAndReturn(func(_ context.Context, arg db.RotateSessionTxParams) (db.RotateSessionTxResult, error) {
+						require.Equal(t, session, arg.Session)
+						require.NotEqual(t, refreshToken, arg.NewSessionToken)
+						return db.RotateSessionTxResult{
+							OldSession: session,
+							NewSession: db.UserSession{ID: 2, UserID: user.ID, SessionToken: arg.NewSessionToken},
+						}, nil
+					})
 			},
 			checkResponse: func(t *testing.T, res *pb.RefreshTokenResponse, err error) {
 				require.NoError(t, err)
 				require.NotNil(t, res)
 				require.NotEmpty(t, res.AccessToken)
 				require.NotNil(t, res.AccessTokenExpiresAt)
+				require.NotEmpty(t, res.RefreshToken)
+				require.NotNil(t, res.RefreshTokenExpiresAt)
+			},
+		},
+		{
+			name: "RefreshTokenReused",
+			setupToken: func(tokenMaker token.Maker) string {
+				refreshToken, _, err := tokenMaker.CreateToken(
+					user.ID,
+					user.Email,
+					string(user.UserType),
+					uuid.New(),
+					time.Hour,
+					token.TokenTypeRefreshToken,
+				)
+				require.NoError(t, err)
+				return refreshToken
+			},
+			buildStubs: func(store *mockdb.MockStore, refreshToken string) {
+				rotated := db.UserSession{
+					ID:           1,
+					UserID:       user.ID,
+					SessionToken: refreshToken,
+					IsActive:     pgtype.Bool{Bool: false, Valid: true},
+					RotatedAt:    pgtype.Timestamptz{Time: time.Now(), Valid: true},
+					ReplacedBy:   pgtype.Int8{Int64: 2, Valid: true},
+				}
+
+				store.EXPECT().
+					GetUserSessionByToken(gomock.Any(), refreshToken).
+					Times(1).
+					Return(db.UserSession{}, db.ErrRecordNotFound)
+
+				store.EXPECT().
+					GetRotatedUserSessionByToken(gomock.Any(), refreshToken).
+					Times(1).
+					Return(rotated, nil)
+
+				store.EXPECT().
+					RevokeSessionFamilyTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					DoAndReturn(func(_ context.Context, arg db.RevokeSessionFamilyTxParams) (db.RevokeSessionFamilyTxResult, error) {
+						require.Equal(t, rotated, arg.ReusedSession)
+						return db.RevokeSessionFamilyTxResult{}, nil
+					})
+
+				store.EXPECT().
+					RotateSessionTx(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, res *pb.RefreshTokenResponse, err error) {
+				require.Error(t, err)
+				require.Nil(t, res)
+				st, ok := status.FromError(err)
+				require.True(t, ok)
+				require.Equal(t, codes.Unauthenticated, st.Code())
+			},
+		},
+		{
+			name: "SessionNotFound",
+			setupToken: func(tokenMaker token.Maker) string {
+				refreshToken, _, err := tokenMaker.CreateToken(
+					user.ID,
+					user.Email,
+					string(user.UserType),
+					uuid.New(),
+					time.Hour,
+					token.TokenTypeRefreshToken,
+				)
+				require.NoError(t, err)
+				return refreshToken
+			},
+			buildStubs: func(store *mockdb.MockStore, refreshToken string) {
+				store.EXPECT().
+					GetUserSessionByToken(gomock.Any(), refreshToken).
+					Times(1).
+					Return(db.UserSession{}, db.ErrRecordNotFound)
+
+				store.EXPECT().
+					GetRotatedUserSessionByToken(gomock.Any(), refreshToken).
+					Times(1).
+					Return(db.UserSession{}, db.ErrRecordNotFound)
+
+				store.EXPECT().
+					RevokeSessionFamilyTx(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, res *pb.RefreshTokenResponse, err error) {
+				require.Error(t, err)
+				require.Nil(t, res)
+				st, ok := status.FromError(err)
+				require.True(t, ok)
+				require.Equal(t, codes.Unauthenticated, st.Code())
 			},
 		},
 		{
@@ -106,3 +210,40 @@ func TestRefreshTokenAPI(t *testing.T) {
 		})
 	}
 }
+
+func TestRefreshTokenReuseRevokesAccessTokens(t *testing.T) {
+	user, _ := randomUser(t, util.TenantRole)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	store := mockdb.NewMockStore(ctrl)
+	server := newTestServer(t, store)
+
+	refreshToken, _, err := server.tokenMaker.CreateToken(user.ID, user.Email, string(user.UserType), uuid.New(), time.Hour, token.TokenTypeRefreshToken)
+	require.NoError(t, err)
+	accessToken, _, err := server.tokenMaker.CreateToken(user.ID, user.Email, string(user.UserType), uuid.New(), time.Minute, token.TokenTypeAccessToken)
+	require.NoError(t, err)
+
+	store.EXPECT().
+		GetUserSessionByToken(gomock.Any(), refreshToken).
+		Times(1).
+		Return(db.UserSession{}, db.ErrRecordNotFound)
+	store.EXPECT().
+		GetRotatedUserSessionByToken(gomock.Any(), refreshToken).
+		Times(1).
+		Return(db.UserSession{UserID: user.ID, SessionToken: refreshToken}, nil)
+	store.EXPECT().
+		RevokeSessionFamilyTx(gomock.Any(), gomock.Any()).
+		Times(1).
+		Return(db.RevokeSessionFamilyTxResult{}, nil)
+
+	_, err = server.RefreshToken(context.Background(), &pb.RefreshTokenRequest{RefreshToken: refreshToken})
+	require.Equal(t, codes.Unauthenticated, status.Code(err))
+
+	payload, err := server.tokenMaker.VerifyToken(accessToken, token.TokenTypeAccessToken)
+	require.NoError(t, err)
+	revoked, err := server.denylist.IsRevoked(context.Background(), payload)
+	require.NoError(t, err)
+	require.True(t, revoked)
+}
@@ -7,7 +7,6 @@ import (
 	"github.com/jackc/pgx/v5/pgtype"
 	db "github.com/r-scheele/sqr/internal/db/sqlc"
 	"github.com/r-scheele/sqr/internal/pb"
-	"github.com/r-scheele/sqr/internal/util"
 	"github.com/r-scheele/sqr/internal/val"
 	"github.com/rs/zerolog/log"
 	"google.golang.org/genproto/googleapis/rpc/errdetails"
@@ -29,7 +28,21 @@ func (server *Server) ResetPassword(ctx context.Context, req *pb.ResetPasswordRe
 		return nil, status.Errorf(codes.Internal, "failed to verify reset token: %s", err)
 	}
 
-	hashedPassword, err := util.HashPassword(req.GetNewPassword())
+	user, err := server.store.GetUserByID(ctx, verification.UserID)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to get user: %s", err)
+	}
+
+	violations = server.newPasswordViolations(ctx, "new_password", req.GetNewPassword(), val.PasswordOwner{
+		Email:     user.Email,
+		FirstName: user.FirstName,
+		LastName:  user.LastName,
+	})
+	if violations != nil {
+		return nil, invalidArgumentError(violations)
+	}
+
+	hashedPassword, err := server.passwordHasher.Hash(req.GetNewPassword())
 	if err != nil {
 		return nil, status.Errorf(codes.Internal, "failed to hash password: %s", err)
 	}
@@ -42,6 +55,13 @@ func (server *Server) ResetPassword(ctx context.Context, req *pb.ResetPasswordRe
 		return nil, status.Errorf(codes.Internal, "failed to update password: %s", err)
 	}
 
+	// Anyone holding the old password may still be signed in
+	// The link stays unused, so the user can retry the reset if this fails
+	err = server.revokeUserAccess(ctx, verification.UserID)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "password updated but failed to sign out other sessions: %s", err)
+	}
+
 	_, err = server.store.UpdateVerificationStatus(ctx, db.UpdateVerificationStatusParams{
 		ID: verification.ID,
 		VerificationStatus: db.NullVerificationStatusEnum{
@@ -70,8 +90,9 @@ func validateResetPasswordRequest(req *pb.ResetPasswordRequest) (violations []*e
 		violations = append(violations, fieldViolation("reset_token", errors.New("reset token is required")))
 	}
 
-	if err := val.ValidatePassword(req.GetNewPassword()); err != nil {
-		violations = append(violations, fieldViolation("new_password", err))
+	// The password policy needs the account, so ResetPassword checks it once the token is resolved
+	if req.GetNewPassword() == "" {
+		violations = append(violations, fieldViolation("new_password", errors.New("new password is required")))
 	}
 
 	if req.GetNewPassword() != req.GetConfirmPassword() {
@@ -3,6 +3,7 @@ package gapi
 import (
 	"context"
 	"database/sql"
+	"strings"
 	"testing"
 
 	"github.com/golang/mock/gomock"
@@ -18,7 +19,7 @@ import (
 
 func TestResetPasswordAPI(t *testing.T) {
 	user, _ := randomUser(t, util.TenantRole)
-	newPassword := util.RandomString(8)
+	newPassword := util.RandomPassword()
 	resetToken := util.RandomString(32)
 
 	verification := db.UserVerification{
@@ -47,11 +48,21 @@ func TestResetPasswordAPI(t *testing.T) {
 					Times(1).
 					Return(verification, nil)
 
+				store.EXPECT().
+					GetUserByID(gomock.Any(), gomock.Eq(user.ID)).
+					Times(1).
+					Return(user, nil)
+
 				store.EXPECT().
 					UpdateUserPassword(gomock.Any(), gomock.Any()).
 					Times(1).
 					Return(nil)
 
+				store.EXPECT().
+					DeactivateUserSessions(gomock.Any(), gomock.Eq(user.ID)).
+					Times(1).
+					Return(nil)
+
 				store.EXPECT().
 					UpdateVerificationStatus(gomock.Any(), gomock.Any()).
 					Times(1).
@@ -128,8 +139,14 @@ func TestResetPasswordAPI(t *testing.T) {
 			},
 			buildStubs: func(store *mockdb.MockStore, taskDistributor *mockwk.MockTaskDistributor) {
 				store.EXPECT().
-					GetPasswordResetVerification(gomock.Any(), gomock.Any()).
-					Times(0)
+					GetPasswordResetVerification(gomock.Any(), gomock.Eq(resetToken)).
+					Times(1).
+					Return(verification, nil)
+
+				store.EXPECT().
+					GetUserByID(gomock.Any(), gomock.Eq(user.ID)).
+					Times(1).
+					Return(user, nil)
 
 				store.EXPECT().
 					UpdateUserPassword(gomock.Any(), gomock.Any()).
@@ -146,6 +163,33 @@ func TestResetPasswordAPI(t *testing.T) {
 				require.Equal(t, codes.InvalidArgument, st.Code())
 			},
 		},
+		{
+			name: "PasswordContainsName",
+			req: &pb.ResetPasswordRequest{
+				ResetToken:      resetToken,
+				NewPassword:     strings.ToUpper(user.FirstName) + "2024!",
+				ConfirmPassword: strings.ToUpper(user.FirstName) + "2024!",
+			},
+			buildStubs: func(store *mockdb.MockStore, taskDistributor *mockwk.MockTaskDistributor) {
+				store.EXPECT().
+					GetPasswordResetVerification(gomock.Any(), gomock.Eq(resetToken)).
+					Times(1).
+					Return(verification, nil)
+
+				store.EXPECT().
+					GetUserByID(gomock.Any(), gomock.Eq(user.ID)).
+					Times(1).
+					Return(user, nil)
+
+				store.EXPECT().
+					UpdateUserPassword(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, res *pb.ResetPasswordResponse, err error) {
+				require.Error(t, err)
+				requireFieldViolation(t, err, "new_password")
+			},
+		},
 		{
 			name: "InternalError",
 			req: &pb.ResetPasswordRequest{
@@ -159,6 +203,11 @@ func TestResetPasswordAPI(t *testing.T) {
 					Times(1).
 					Return(verification, nil)
 
+				store.EXPECT().
+					GetUserByID(gomock.Any(), gomock.Eq(user.ID)).
+					Times(1).
+					Return(user, nil)
+
 				store.EXPECT().
 					UpdateUserPassword(gomock.Any(), gomock.Any()).
 					Times(1).
@@ -175,6 +224,43 @@ func TestResetPasswordAPI(t *testing.T) {
 				require.Equal(t, codes.Internal, st.Code())
 			},
 		},
+		{
+			name: "RevokeAccessFails",
+			req: &pb.ResetPasswordRequest{
+				ResetToken:      resetToken,
+				NewPassword:     newPassword,
+				ConfirmPassword: newPassword,
+			},
+			buildStubs: func(store *mockdb.MockStore, taskDistributor *mockwk.MockTaskDistributor) {
+				store.EXPECT().
+					GetPasswordResetVerification(gomock.Any(), gomock.Eq(resetToken)).
+					Times(1).
+					Return(verification, nil)
+
+				store.EXPECT().
+					GetUserByID(gomock.Any(), gomock.Eq(user.ID)).
+					Times(1).
+					Return(user, nil)
+
+				store.EXPECT().
+					UpdateUserPassword(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(nil)
+
+				store.EXPECT().
+					DeactivateUserSessions(gomock.Any(), gomock.Eq(user.ID)).
+					Times(1).
+					Return(sql.ErrConnDone)
+
+				store.EXPECT().
+					UpdateVerificationStatus(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, res *pb.ResetPasswordResponse, err error) {
+				require.Error(t, err)
+				require.Equal(t, codes.Internal, status.Code(err))
+			},
+		},
 	}
 
 	for i := range testCases {
@@ -0,0 +1,326 @@
+package gapi
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"html"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	db "github.com/r-scheele/sqr/internal/db/sqlc"
+	"github.com/r-scheele/sqr/internal/pb"
+	"github.com/r-scheele/sqr/internal/val"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	defaultPropertySearchSort = "newest"
+	// minSearchTermLength leaves out the words of a query too short to tell a misspelled area name by
+	minSearchTermLength = 4
+	maxSearchTerms      = 10
+)
+
+// SearchProperties pages through published, available properties, best matches first when there is a free-text query.
+// The first page, requested without a cursor, also counts the matches by type, bedroom count and city so clients
+// can offer them as filters.
+func (server *Server) SearchProperties(ctx context.Context, req *pb.SearchPropertiesRequest) (*pb.SearchPropertiesResponse, error) {
+	violations := validateSearchPropertiesRequest(req)
+	if violations != nil {
+		return nil, invalidArgumentError(violations)
+	}
+
+	sortBy := propertySearchSort(req)
+	cursor, err := decodePropertySearchCursor(req.GetCursor(), sortBy)
+	if err != nil {
+		return nil, invalidArgumentError([]*errdetails.BadRequest_FieldViolation{fieldViolation("cursor", err)})
+	}
+
+	filters := propertySearchFilters(req)
+	arg := db.SearchPropertiesParams{
+		Query:            filters.Query,
+		Terms:            filters.Terms,
+		City:             filters.City,
+		State:            filters.State,
+		PropertyType:     filters.PropertyType,
+		MinRent:          filters.MinRent,
+		MaxRent:          filters.MaxRent,
+		MinBedrooms:      filters.MinBedrooms,
+		MinBathrooms:     filters.MinBathrooms,
+		FurnishingStatus: filters.FurnishingStatus,
+		Amenities:        filters.Amenities,
+		PetFriendly:      filters.PetFriendly,
+		VerifiedOnly:     filters.VerifiedOnly,
+		MinParkingSpaces: filters.MinParkingSpaces,
+		MaxAgencyFee:     filters.MaxAgencyFee,
+		SortBy:           sortBy,
+		// One extra row tells whether there is a next page
+		Limit: req.GetPageSize() + 1,
+	}
+	if cursor != nil {
+		arg.CursorID = pgtype.Int8{Int64: cursor.ID, Valid: true}
+		arg.CursorRank = pgtype.Float8{Float64: cursor.Rank, Valid: true}
+		arg.CursorRent = numericFromFloat(cursor.RentAmount, 2)
+		arg.CursorCreatedAt = pgtype.Timestamptz{Time: cursor.CreatedAt, Valid: true}
+		arg.CursorViews = pgtype.Int4{Int32: cursor.ViewsCount, Valid: true}
+	}
+
+	rows, err := server.store.SearchProperties(ctx, arg)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to search properties: %s", err)
+	}
+
+	rsp := &pb.SearchPropertiesResponse{}
+	if len(rows) > int(req.GetPageSize()) {
+		rows = rows[:req.GetPageSize()]
+		rsp.NextCursor = encodePropertySearchCursor(sortBy, rows[len(rows)-1])
+	}
+
+	rsp.Results = make([]*pb.PropertySearchResult, 0, len(rows))
+	for _, row := range rows {
+		rsp.Results = append(rsp.Results, &pb.PropertySearchResult{
+			Property:  convertProperty(row.Property),
+			Snippet:   highlightSnippet(row.Snippet),
+			Relevance: row.Rank,
+		})
+	}
+
+	if cursor == nil {
+		facets, err := server.store.SearchPropertyFacets(ctx, filters)
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "failed to count search facets: %s", err)
+		}
+		rsp.Facets, rsp.TotalCount = convertPropertySearchFacets(facets)
+	}
+
+	return rsp, nil
+}
+
+// propertySearchCursor is the position after the last property of a page: its sort key and id.
+// Clients get it as an opaque token and pass it back unchanged.
+type propertySearchCursor struct {
+	SortBy     string    `json:"sort_by"`
+	ID         int64     `json:"id"`
+	Rank       float64   `json:"rank,omitempty"`
+	RentAmount float64   `json:"rent_amount,omitempty"`
+	CreatedAt  time.Time `json:"created_at"`
+	ViewsCount int32     `json:"views_count,omitempty"`
+}
+
+func encodePropertySearchCursor(sortBy string, last db.SearchPropertiesRow) string {
+	cursor := propertySearchCursor{
+		SortBy: sortBy,
+		ID:     last.Property.ID,
+	}
+	switch sortBy {
+	case "relevance":
+		cursor.Rank = last.Rank
+	case "price_asc", "price_desc":
+		cursor.RentAmount = numericToFloat(last.Property.RentAmount)
+	case "popular":
+		cursor.ViewsCount = last.Property.ViewsCount.Int32
+	default:
+		cursor.CreatedAt = last.Property.CreatedAt.Time
+	}
+
+	data, _ := json.Marshal(cursor)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// decodePropertySearchCursor returns nil for the first page. A cursor only continues the sort order it was made for.
+func decodePropertySearchCursor(token string, sortBy string) (*propertySearchCursor, error) {
+	if token == "" {
+		return nil, nil
+	}
+
+	data, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return nil, fmt.Errorf("is not a valid cursor")
+	}
+
+	var cursor propertySearchCursor
+	if err := json.Unmarshal(data, &cursor); err != nil || cursor.ID <= 0 {
+		return nil, fmt.Errorf("is not a valid cursor")
+	}
+	if cursor.SortBy != sortBy {
+		return nil, fmt.Errorf("was returned for another sort order")
+	}
+
+	return &cursor, nil
+}
+
+// propertySearchSort orders by relevance when there is a query and the client didn't pick an order
+func propertySearchSort(req *pb.SearchPropertiesRequest) string {
+	switch {
+	case req.GetSortBy() != "":
+		return req.GetSortBy()
+	case strings.TrimSpace(req.GetQuery()) != "":
+		return "relevance"
+	}
+	return defaultPropertySearchSort
+}
+
+// propertySearchFilters maps the filters of req to the parameters SearchProperties and SearchPropertyFacets share
+func propertySearchFilters(req *pb.SearchPropertiesRequest) db.SearchPropertyFacetsParams {
+	query := strings.TrimSpace(req.GetQuery())
+	filters := db.SearchPropertyFacetsParams{
+		Query:            optionalText(query),
+		Terms:            searchTerms(query),
+		City:             optionalText(strings.TrimSpace(req.GetCity())),
+		State:            optionalText(strings.TrimSpace(req.GetState())),
+		PropertyType:     db.NullPropertyTypeEnum{PropertyTypeEnum: db.PropertyTypeEnum(req.GetPropertyType()), Valid: req.GetPropertyType() != ""},
+		MinRent:          optionalNumeric(req.GetMinRent(), 2),
+		MaxRent:          optionalNumeric(req.GetMaxRent(), 2),
+		MinBedrooms:      pgtype.Int4{Int32: req.GetMinBedrooms(), Valid: req.GetMinBedrooms() > 0},
+		MinBathrooms:     pgtype.Int4{Int32: req.GetMinBathrooms(), Valid: req.GetMinBathrooms() > 0},
+		FurnishingStatus: db.NullFurnishingStatusEnum{FurnishingStatusEnum: db.FurnishingStatusEnum(req.GetFurnishingStatus()), Valid: req.GetFurnishingStatus() != ""},
+		Amenities:        make([]string, 0, len(req.GetAmenities())),
+		PetFriendly:      req.GetPetFriendly(),
+		VerifiedOnly:     req.GetVerifiedOnly(),
+		MinParkingSpaces: pgtype.Int4{Int32: req.GetMinParkingSpaces(), Valid: req.GetMinParkingSpaces() > 0},
+	}
+
+	// Amenities are matched whole and regardless of case
+	for _, amenity := range req.GetAmenities() {
+		filters.Amenities = append(filters.Amenities, strings.ToLower(strings.TrimSpace(amenity)))
+	}
+
+	// 0 is a meaningful maximum here, it asks for listings without an agency fee
+	if req.MaxAgencyFee != nil {
+		filters.MaxAgencyFee = numericFromFloat(req.GetMaxAgencyFee(), 2)
+	}
+
+	return filters
+}
+
+// searchTerms picks the words of a free-text query that are worth matching against area names by similarity,
+// since a misspelled area name doesn't match the full-text document
+func searchTerms(query string) []string {
+	terms := []string{}
+	seen := map[string]bool{}
+	words := strings.FieldsFunc(strings.ToLower(query), func(r rune) bool {
+		return !unicode.IsLetter(r)
+	})
+	for _, word := range words {
+		if utf8.RuneCountInString(word) < minSearchTermLength || seen[word] {
+			continue
+		}
+		seen[word] = true
+		terms = append(terms, word)
+		if len(terms) == maxSearchTerms {
+			break
+		}
+	}
+	return terms
+}
+
+// highlightSnippet escapes a snippet for HTML, keeping only the <mark> tags the database wrapped matches in.
+// Descriptions are plain text written by landlords, so they must not reach clients as markup.
+func highlightSnippet(snippet string) string {
+	escaped := html.EscapeString(snippet)
+	return strings.NewReplacer("&lt;mark&gt;", "<mark>", "&lt;/mark&gt;", "</mark>").Replace(escaped)
+}
+
+// convertPropertySearchFacets groups the facet rows by field. Every match has one property type, so their counts add up to the total.
+func convertPropertySearchFacets(rows []db.SearchPropertyFacetsRow) (*pb.PropertySearchFacets, int64) {
+	facets := &pb.PropertySearchFacets{}
+	var totalCount int64
+	for _, row := range rows {
+		count := &pb.FacetCount{
+			Value: row.Value,
+			Count: row.Count,
+		}
+		switch row.Facet {
+		case "property_type":
+			facets.PropertyTypes = append(facets.PropertyTypes, count)
+			totalCount += row.Count
+		case "bedrooms":
+			facets.Bedrooms = append(facets.Bedrooms, count)
+		case "city":
+			facets.Cities = append(facets.Cities, count)
+		}
+	}
+	return facets, totalCount
+}
+
+func validateSearchPropertiesRequest(req *pb.SearchPropertiesRequest) (violations []*errdetails.BadRequest_FieldViolation) {
+	if req.GetQuery() != "" {
+		if err := val.ValidateSearchQuery(req.GetQuery()); err != nil {
+			violations = append(violations, fieldViolation("query", err))
+		}
+	}
+
+	if req.GetCity() != "" {
+		if err := val.ValidatePropertyLocality(req.GetCity()); err != nil {
+			violations = append(violations, fieldViolation("city", err))
+		}
+	}
+
+	if req.GetState() != "" {
+		if err := val.ValidatePropertyLocality(req.GetState()); err != nil {
+			violations = append(violations, fieldViolation("state", err))
+		}
+	}
+
+	if req.GetPropertyType() != "" {
+		if err := val.ValidatePropertyType(req.GetPropertyType()); err != nil {
+			violations = append(violations, fieldViolation("property_type", err))
+		}
+	}
+
+	if err := val.ValidatePropertyFee(req.GetMinRent()); err != nil {
+		violations = append(violations, fieldViolation("min_rent", err))
+	}
+
+	if err := val.ValidatePropertyFee(req.GetMaxRent()); err != nil {
+		violations = append(violations, fieldViolation("max_rent", err))
+	} else if req.GetMaxRent() > 0 && req.GetMaxRent() < req.GetMinRent() {
+		violations = append(violations, fieldViolation("max_rent", fmt.Errorf("must not be less than min_rent")))
+	}
+
+	if err := val.ValidateRoomCount(req.GetMinBedrooms()); err != nil {
+		violations = append(violations, fieldViolation("min_bedrooms", err))
+	}
+
+	if err := val.ValidateRoomCount(req.GetMinBathrooms()); err != nil {
+		violations = append(violations, fieldViolation("min_bathrooms", err))
+	}
+
+	if req.GetFurnishingStatus() != "" {
+		if err := val.ValidateFurnishingStatus(req.GetFurnishingStatus()); err != nil {
+			violations = append(violations, fieldViolation("furnishing_status", err))
+		}
+	}
+
+	if err := val.ValidateAmenities(req.GetAmenities()); err != nil {
+		violations = append(violations, fieldViolation("amenities", err))
+	}
+
+	if err := val.ValidateParkingSpaces(req.GetMinParkingSpaces()); err != nil {
+		violations = append(violations, fieldViolation("min_parking_spaces", err))
+	}
+
+	if err := val.ValidatePropertyFee(req.GetMaxAgencyFee()); err != nil {
+		violations = append(violations, fieldViolation("max_agency_fee", err))
+	}
+
+	if req.GetSortBy() != "" {
+		if err := val.ValidatePropertySearchSort(req.GetSortBy()); err != nil {
+			violations = append(violations, fieldViolation("sort_by", err))
+		} else if req.GetSortBy() == "relevance" && strings.TrimSpace(req.GetQuery()) == "" {
+			violations = append(violations, fieldViolation("sort_by", fmt.Errorf("relevance needs a query")))
+		}
+	}
+
+	if err := val.ValidatePageSize(req.GetPageSize()); err != nil {
+		violations = append(violations, fieldViolation("page_size", err))
+	}
+
+	return violations
+}
@@ -0,0 +1,242 @@
+package gapi
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	mockdb "github.com/r-scheele/sqr/internal/db/mock"
+	db "github.com/r-scheele/sqr/internal/db/sqlc"
+	"github.com/r-scheele/sqr/internal/pb"
+	"github.com/r-scheele/sqr/internal/util"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestSearchPropertiesAPI(t *testing.T) {
+	rows := make([]db.SearchPropertiesRow, 3)
+	for i := range rows {
+		rows[i].Property = randomProperty(util.RandomInt(1, 1000), db.PropertyStatusEnumActive)
+		rows[i].Property.ID = int64(30 - i)
+		rows[i].Property.RentAmount = numericFromFloat(float64(1000000*(i+1)), 2)
+	}
+
+	facetRows := []db.SearchPropertyFacetsRow{
+		{Facet: "bedrooms", Value: "2", Count: 3},
+		{Facet: "city", Value: "Lagos", Count: 3},
+		{Facet: "property_type", Value: "apartment", Count: 2},
+		{Facet: "property_type", Value: "duplex", Count: 1},
+	}
+
+	priceCursor := encodePropertySearchCursor("price_asc", rows[1])
+
+	testCases := []struct {
+		name          string
+		req           *pb.SearchPropertiesRequest
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(t *testing.T, res *pb.SearchPropertiesResponse, err error)
+	}{
+		{
+			name: "FirstPage",
+			req: &pb.SearchPropertiesRequest{
+				City:         " Lagos ",
+				Amenities:    []string{"Borehole"},
+				PetFriendly:  true,
+				MaxAgencyFee: proto.Float64(0),
+				SortBy:       "price_asc",
+				PageSize:     2,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					SearchProperties(gomock.Any(), gomock.Any()).
+					Times(1).
+					DoAndReturn(func(_ context.Context, arg db.SearchPropertiesParams) ([]db.SearchPropertiesRow, error) {
+						require.Equal(t, "Lagos", arg.City.String)
+						require.Equal(t, []string{"borehole"}, arg.Amenities)
+						require.True(t, arg.PetFriendly)
+						require.True(t, arg.MaxAgencyFee.Valid)
+						require.False(t, arg.MinBedrooms.Valid)
+						require.False(t, arg.CursorID.Valid)
+						require.Equal(t, "price_asc", arg.SortBy)
+						require.Equal(t, int32(3), arg.Limit)
+						return rows, nil
+					})
+
+				store.EXPECT().
+					SearchPropertyFacets(gomock.Any(), gomock.Any()).
+					Times(1).
+					DoAndReturn(func(_ context.Context, arg db.SearchPropertyFacetsParams) ([]db.SearchPropertyFacetsRow, error) {
+						require.Equal(t, []string{"borehole"}, arg.Amenities)
+						return facetRows, nil
+					})
+			},
+			checkResponse: func(t *testing.T, res *pb.SearchPropertiesResponse, err error) {
+				require.NoError(t, err)
+				require.Len(t, res.GetResults(), 2)
+				require.Equal(t, priceCursor, res.GetNextCursor())
+				require.Equal(t, int64(3), res.GetTotalCount())
+				require.Len(t, res.GetFacets().GetPropertyTypes(), 2)
+				require.Equal(t, "Lagos", res.GetFacets().GetCities()[0].GetValue())
+				require.Equal(t, int64(3), res.GetFacets().GetBedrooms()[0].GetCount())
+			},
+		},
+		{
+			name: "NextPage",
+			req: &pb.SearchPropertiesRequest{
+				SortBy:   "price_asc",
+				PageSize: 2,
+				Cursor:   priceCursor,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					SearchProperties(gomock.Any(), gomock.Any()).
+					Times(1).
+					DoAndReturn(func(_ context.Context, arg db.SearchPropertiesParams) ([]db.SearchPropertiesRow, error) {
+						require.Equal(t, rows[1].Property.ID, arg.CursorID.Int64)
+						require.Equal(t, 2000000.0, numericToFloat(arg.CursorRent))
+						require.False(t, arg.MaxAgencyFee.Valid)
+						return rows[2:], nil
+					})
+
+				store.EXPECT().
+					SearchPropertyFacets(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, res *pb.SearchPropertiesResponse, err error) {
+				require.NoError(t, err)
+				require.Len(t, res.GetResults(), 1)
+				require.Empty(t, res.GetNextCursor())
+				require.Nil(t, res.GetFacets())
+			},
+		},
+		{
+			name: "CursorForAnotherSort",
+			req: &pb.SearchPropertiesRequest{
+				SortBy:   "popular",
+				PageSize: 2,
+				Cursor:   priceCursor,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					SearchProperties(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, res *pb.SearchPropertiesResponse, err error) {
+				requireFieldViolation(t, err, "cursor")
+			},
+		},
+		{
+			name: "MalformedCursor",
+			req: &pb.SearchPropertiesRequest{
+				PageSize: 2,
+				Cursor:   "not-a-cursor",
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					SearchProperties(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, res *pb.SearchPropertiesResponse, err error) {
+				requireFieldViolation(t, err, "cursor")
+			},
+		},
+		{
+			name: "InvalidSort",
+			req: &pb.SearchPropertiesRequest{
+				SortBy:   "cheapest",
+				PageSize: 2,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					SearchProperties(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, res *pb.SearchPropertiesResponse, err error) {
+				requireFieldViolation(t, err, "sort_by")
+			},
+		},
+		{
+			name: "FreeTextQuery",
+			req: &pb.SearchPropertiesRequest{
+				Query:    "2 bed flat lekky with BQ",
+				PageSize: 2,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					SearchProperties(gomock.Any(), gomock.Any()).
+					Times(1).
+					DoAndReturn(func(_ context.Context, arg db.SearchPropertiesParams) ([]db.SearchPropertiesRow, error) {
+						require.Equal(t, "2 bed flat lekky with BQ", arg.Query.String)
+						require.Equal(t, []string{"flat", "lekky", "with"}, arg.Terms)
+						require.Equal(t, "relevance", arg.SortBy)
+
+						row := rows[0]
+						row.Rank = 0.83
+						row.Snippet = "Spacious <mark>flat</mark> in <b>Lekki</b> with a <mark>BQ</mark>"
+						return []db.SearchPropertiesRow{row}, nil
+					})
+
+				store.EXPECT().
+					SearchPropertyFacets(gomock.Any(), gomock.Any()).
+					Times(1).
+					DoAndReturn(func(_ context.Context, arg db.SearchPropertyFacetsParams) ([]db.SearchPropertyFacetsRow, error) {
+						require.Equal(t, "2 bed flat lekky with BQ", arg.Query.String)
+						return nil, nil
+					})
+			},
+			checkResponse: func(t *testing.T, res *pb.SearchPropertiesResponse, err error) {
+				require.NoError(t, err)
+				require.Len(t, res.GetResults(), 1)
+				require.Equal(t, 0.83, res.GetResults()[0].GetRelevance())
+				require.Equal(t, "Spacious <mark>flat</mark> in &lt;b&gt;Lekki&lt;/b&gt; with a <mark>BQ</mark>", res.GetResults()[0].GetSnippet())
+			},
+		},
+		{
+			name: "RelevanceWithoutQuery",
+			req: &pb.SearchPropertiesRequest{
+				SortBy:   "relevance",
+				PageSize: 2,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					SearchProperties(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, res *pb.SearchPropertiesResponse, err error) {
+				requireFieldViolation(t, err, "sort_by")
+			},
+		},
+		{
+			name: "MaxRentBelowMinRent",
+			req: &pb.SearchPropertiesRequest{
+				MinRent:  2000000,
+				MaxRent:  1000000,
+				PageSize: 2,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					SearchProperties(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, res *pb.SearchPropertiesResponse, err error) {
+				requireFieldViolation(t, err, "max_rent")
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			res, err := server.SearchProperties(context.Background(), tc.req)
+			tc.checkResponse(t, res, err)
+		})
+	}
+}
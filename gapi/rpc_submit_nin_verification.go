@@ -0,0 +1,106 @@
+package gapi
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/hibiken/asynq"
+	db "github.com/r-scheele/sqr/internal/db/sqlc"
+	"github.com/r-scheele/sqr/internal/pb"
+	"github.com/r-scheele/sqr/internal/util"
+	"github.com/r-scheele/sqr/internal/val"
+	"github.com/r-scheele/sqr/internal/worker"
+	"github.com/rs/zerolog/log"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// ninVerificationTimeout is how long a pending nin verification blocks a new submission,
+// so a verification stuck behind a provider outage does not lock the user out for good
+const ninVerificationTimeout = 24 * time.Hour
+
+func (server *Server) SubmitNINVerification(ctx context.Context, req *pb.SubmitNINVerificationRequest) (*pb.SubmitNINVerificationResponse, error) {
+	violations := validateSubmitNINVerificationRequest(req)
+	if violations != nil {
+		return nil, invalidArgumentError(violations)
+	}
+
+	principal, err := server.authorizeRequest(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	user := principal.User
+
+	verification, err := server.store.GetUserVerificationByType(ctx, db.GetUserVerificationByTypeParams{
+		UserID:           user.ID,
+		VerificationType: db.VerificationTypeEnumNin,
+	})
+	if err != nil && !errors.Is(err, db.ErrRecordNotFound) {
+		return nil, status.Errorf(codes.Internal, "failed to get nin verification: %s", err)
+	}
+	if err == nil {
+		switch verification.VerificationStatus.VerificationStatusEnum {
+		case db.VerificationStatusEnumVerified:
+			return nil, status.Errorf(codes.FailedPrecondition, "nin is already verified")
+		case db.VerificationStatusEnumPending:
+			if time.Since(verification.CreatedAt.Time) < ninVerificationTimeout {
+				return nil, status.Errorf(codes.FailedPrecondition, "nin verification is already in progress")
+			}
+		}
+	}
+
+	encryptedNIN, err := util.EncryptField(server.config.IdentityEncryptionKey, req.GetNin())
+	if err != nil {
+		log.Error().Err(err).Msg("failed to encrypt nin")
+		return nil, status.Errorf(codes.Internal, "failed to store nin")
+	}
+
+	dataJSON, err := json.Marshal(worker.NINVerificationData{
+		SubmittedAt: time.Now(),
+	})
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to marshal verification data")
+	}
+
+	arg := db.SubmitNINVerificationTxParams{
+		UserID:           user.ID,
+		EncryptedNIN:     encryptedNIN,
+		VerificationData: dataJSON,
+		AfterCreate: func(verification db.UserVerification) error {
+			taskPayload := &worker.PayloadVerifyNIN{
+				UserID:         user.ID,
+				VerificationID: verification.ID,
+			}
+			opts := []asynq.Option{
+				asynq.MaxRetry(10),
+				asynq.ProcessIn(10 * time.Second),
+				asynq.Queue(worker.QueueDefault),
+			}
+
+			return server.taskDistributor.DistributeTaskVerifyNIN(ctx, taskPayload, opts...)
+		},
+	}
+
+	txResult, err := server.store.SubmitNINVerificationTx(ctx, arg)
+	if err != nil {
+		log.Error().Err(err).Int64("user_id", user.ID).Msg("failed to submit nin verification")
+		return nil, status.Errorf(codes.Internal, "failed to submit nin verification")
+	}
+
+	rsp := &pb.SubmitNINVerificationResponse{
+		Message:            "Your NIN has been submitted, we will notify you once it is verified",
+		VerificationStatus: string(txResult.Verification.VerificationStatus.VerificationStatusEnum),
+	}
+	return rsp, nil
+}
+
+func validateSubmitNINVerificationRequest(req *pb.SubmitNINVerificationRequest) (violations []*errdetails.BadRequest_FieldViolation) {
+	if err := val.ValidateNIN(req.GetNin()); err != nil {
+		violations = append(violations, fieldViolation("nin", err))
+	}
+
+	return violations
+}
@@ -0,0 +1,200 @@
+package gapi
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/hibiken/asynq"
+	"github.com/jackc/pgx/v5/pgtype"
+	mockdb "github.com/r-scheele/sqr/internal/db/mock"
+	db "github.com/r-scheele/sqr/internal/db/sqlc"
+	"github.com/r-scheele/sqr/internal/pb"
+	"github.com/r-scheele/sqr/internal/token"
+	"github.com/r-scheele/sqr/internal/util"
+	"github.com/r-scheele/sqr/internal/worker"
+	mockwk "github.com/r-scheele/sqr/internal/worker/mock"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func randomNINVerification(userID int64, verificationStatus db.VerificationStatusEnum, createdAt time.Time) db.UserVerification {
+	return db.UserVerification{
+		ID:                 util.RandomInt(1, 1000),
+		UserID:             userID,
+		VerificationType:   db.VerificationTypeEnumNin,
+		VerificationStatus: db.NullVerificationStatusEnum{VerificationStatusEnum: verificationStatus, Valid: true},
+		CreatedAt:          pgtype.Timestamptz{Time: createdAt, Valid: true},
+	}
+}
+
+func TestSubmitNINVerificationAPI(t *testing.T) {
+	user, _ := randomUser(t, util.TenantRole)
+	user.ID = util.RandomInt(1, 1000)
+	nin := "12345678902"
+
+	testCases := []struct {
+		name          string
+		nin           string
+		buildStubs    func(t *testing.T, server *Server, store *mockdb.MockStore, taskDistributor *mockwk.MockTaskDistributor)
+		checkResponse func(t *testing.T, res *pb.SubmitNINVerificationResponse, err error)
+	}{
+		{
+			name: "OK",
+			nin:  nin,
+			buildStubs: func(t *testing.T, server *Server, store *mockdb.MockStore, taskDistributor *mockwk.MockTaskDistributor) {
+				store.EXPECT().
+					GetUserByID(gomock.Any(), gomock.Eq(user.ID)).
+					Times(1).
+					Return(user, nil)
+
+				store.EXPECT().
+					GetUserVerificationByType(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.UserVerification{}, db.ErrRecordNotFound)
+
+				verification := randomNINVerification(user.ID, db.VerificationStatusEnumPending, time.Now())
+				store.EXPECT().
+					SubmitNINVerificationTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					DoAndReturn(func(_ context.Context, arg db.SubmitNINVerificationTxParams) (db.SubmitNINVerificationTxResult, error) {
+						require.Equal(t, user.ID, arg.UserID)
+						require.NotContains(t, arg.EncryptedNIN, nin)
+
+						decrypted, err := util.DecryptField(server.config.IdentityEncryptionKey, arg.EncryptedNIN)
+						require.NoError(t, err)
+						require.Equal(t, nin, decrypted)
+						require.NotContains(t, string(arg.VerificationData), nin)
+
+						err = arg.AfterCreate(verification)
+						return db.SubmitNINVerificationTxResult{Verification: verification}, err
+					})
+
+				taskDistributor.EXPECT().
+					DistributeTaskVerifyNIN(gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(1).
+					DoAndReturn(func(_ context.Context, payload *worker.PayloadVerifyNIN, _ ...asynq.Option) error {
+						require.Equal(t, user.ID, payload.UserID)
+						require.Equal(t, verification.ID, payload.VerificationID)
+						return nil
+					})
+			},
+			checkResponse: func(t *testing.T, res *pb.SubmitNINVerificationResponse, err error) {
+				require.NoError(t, err)
+				require.Equal(t, string(db.VerificationStatusEnumPending), res.GetVerificationStatus())
+			},
+		},
+		{
+			name: "RetryAfterStalePending",
+			nin:  nin,
+			buildStubs: func(t *testing.T, server *Server, store *mockdb.MockStore, taskDistributor *mockwk.MockTaskDistributor) {
+				store.EXPECT().
+					GetUserByID(gomock.Any(), gomock.Eq(user.ID)).
+					Times(1).
+					Return(user, nil)
+
+				store.EXPECT().
+					GetUserVerificationByType(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(randomNINVerification(user.ID, db.VerificationStatusEnumPending, time.Now().Add(-2*ninVerificationTimeout)), nil)
+
+				store.EXPECT().
+					SubmitNINVerificationTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.SubmitNINVerificationTxResult{
+						Verification: randomNINVerification(user.ID, db.VerificationStatusEnumPending, time.Now()),
+					}, nil)
+			},
+			checkResponse: func(t *testing.T, res *pb.SubmitNINVerificationResponse, err error) {
+				require.NoError(t, err)
+			},
+		},
+		{
+			name: "AlreadyVerified",
+			nin:  nin,
+			buildStubs: func(t *testing.T, server *Server, store *mockdb.MockStore, taskDistributor *mockwk.MockTaskDistributor) {
+				store.EXPECT().
+					GetUserByID(gomock.Any(), gomock.Eq(user.ID)).
+					Times(1).
+					Return(user, nil)
+
+				store.EXPECT().
+					GetUserVerificationByType(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(randomNINVerification(user.ID, db.VerificationStatusEnumVerified, time.Now()), nil)
+
+				store.EXPECT().
+					SubmitNINVerificationTx(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, res *pb.SubmitNINVerificationResponse, err error) {
+				require.Error(t, err)
+				require.Equal(t, codes.FailedPrecondition, status.Code(err))
+			},
+		},
+		{
+			name: "InProgress",
+			nin:  nin,
+			buildStubs: func(t *testing.T, server *Server, store *mockdb.MockStore, taskDistributor *mockwk.MockTaskDistributor) {
+				store.EXPECT().
+					GetUserByID(gomock.Any(), gomock.Eq(user.ID)).
+					Times(1).
+					Return(user, nil)
+
+				store.EXPECT().
+					GetUserVerificationByType(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(randomNINVerification(user.ID, db.VerificationStatusEnumPending, time.Now()), nil)
+
+				store.EXPECT().
+					SubmitNINVerificationTx(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, res *pb.SubmitNINVerificationResponse, err error) {
+				require.Error(t, err)
+				require.Equal(t, codes.FailedPrecondition, status.Code(err))
+			},
+		},
+		{
+			name: "InvalidNIN",
+			nin:  "1234",
+			buildStubs: func(t *testing.T, server *Server, store *mockdb.MockStore, taskDistributor *mockwk.MockTaskDistributor) {
+				store.EXPECT().
+					GetUserByID(gomock.Any(), gomock.Any()).
+					Times(0)
+
+				store.EXPECT().
+					SubmitNINVerificationTx(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, res *pb.SubmitNINVerificationResponse, err error) {
+				require.Error(t, err)
+				require.Equal(t, codes.InvalidArgument, status.Code(err))
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			storeCtrl := gomock.NewController(t)
+			defer storeCtrl.Finish()
+			store := mockdb.NewMockStore(storeCtrl)
+
+			taskCtrl := gomock.NewController(t)
+			defer taskCtrl.Finish()
+			taskDistributor := mockwk.NewMockTaskDistributor(taskCtrl)
+
+			server := newTestServerWithTaskDistributor(t, store, taskDistributor)
+			tc.buildStubs(t, server, store, taskDistributor)
+			ctx := newContextWithBearerToken(t, server.tokenMaker, user, uuid.New(), time.Minute, token.TokenTypeAccessToken)
+
+			res, err := server.SubmitNINVerification(ctx, &pb.SubmitNINVerificationRequest{Nin: tc.nin})
+			tc.checkResponse(t, res, err)
+		})
+	}
+}
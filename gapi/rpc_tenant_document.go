@@ -0,0 +1,394 @@
+package gapi
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"mime"
+	"net/url"
+	"time"
+
+	"github.com/google/uuid"
+	db "github.com/r-scheele/sqr/internal/db/sqlc"
+	"github.com/r-scheele/sqr/internal/pb"
+	"github.com/r-scheele/sqr/internal/util"
+	"github.com/r-scheele/sqr/internal/val"
+	"github.com/rs/zerolog/log"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+const (
+	// DefaultDocumentMaxSize is used when DOCUMENT_MAX_SIZE is not set
+	DefaultDocumentMaxSize = 10 << 20
+	// DefaultDocumentURLTTL is used when DOCUMENT_URL_TTL is not set
+	DefaultDocumentURLTTL = 15 * time.Minute
+	// DocumentDownloadPath is served by ServeDocumentDownload on the HTTP gateway
+	DocumentDownloadPath = "/v1/documents/download"
+	// requestSizeMargin leaves room for the fields that come with the content of a document
+	requestSizeMargin = 1 << 20
+)
+
+func documentMaxSize(config util.Config) int64 {
+	if config.DocumentMaxSize > 0 {
+		return config.DocumentMaxSize
+	}
+	return DefaultDocumentMaxSize
+}
+
+func documentURLTTL(config util.Config) time.Duration {
+	if config.DocumentURLTTL > 0 {
+		return config.DocumentURLTTL
+	}
+	return DefaultDocumentURLTTL
+}
+
+// MaxRequestSize is the largest message the gRPC server has to accept, an upload of the largest document
+func MaxRequestSize(config util.Config) int {
+	return int(documentMaxSize(config)) + requestSizeMargin
+}
+
+func (server *Server) UploadTenantDocument(ctx context.Context, req *pb.UploadTenantDocumentRequest) (*pb.UploadTenantDocumentResponse, error) {
+	violations := validateUploadTenantDocumentRequest(req, documentMaxSize(server.config))
+	if violations != nil {
+		return nil, invalidArgumentError(violations)
+	}
+
+	principal, err := server.authorizeRequest(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	tenant := principal.User
+
+	count, err := server.store.CountTenantDocuments(ctx, tenant.ID)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to count documents: %s", err)
+	}
+	if count >= val.MaxTenantDocuments {
+		return nil, status.Errorf(codes.ResourceExhausted, "you can keep at most %d documents, delete some before uploading more", val.MaxTenantDocuments)
+	}
+
+	contentType, _, _ := mime.ParseMediaType(req.GetContentType())
+	checksum := sha256.Sum256(req.GetContent())
+	storageKey := fmt.Sprintf("documents/%d/%s", tenant.ID, uuid.New())
+
+	err = server.blobStore.Put(ctx, storageKey, req.GetContent())
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to store document: %s", err)
+	}
+
+	document, err := server.store.CreateTenantDocument(ctx, db.CreateTenantDocumentParams{
+		OwnerID:      tenant.ID,
+		DocumentType: db.DocumentTypeEnum(req.GetDocumentType()),
+		FileName:     req.GetFileName(),
+		ContentType:  contentType,
+		SizeBytes:    int64(len(req.GetContent())),
+		Checksum:     hex.EncodeToString(checksum[:]),
+		StorageKey:   storageKey,
+	})
+	if err != nil {
+		server.deleteDocumentContent(ctx, storageKey)
+		return nil, status.Errorf(codes.Internal, "failed to create document: %s", err)
+	}
+
+	rsp := &pb.UploadTenantDocumentResponse{
+		Document: convertTenantDocument(document),
+	}
+	return rsp, nil
+}
+
+func (server *Server) ListTenantDocuments(ctx context.Context, req *pb.ListTenantDocumentsRequest) (*pb.ListTenantDocumentsResponse, error) {
+	principal, err := server.authorizeRequest(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	documents, err := server.store.ListTenantDocuments(ctx, principal.User.ID)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to list documents: %s", err)
+	}
+
+	rsp := &pb.ListTenantDocumentsResponse{
+		Documents: make([]*pb.TenantDocument, 0, len(documents)),
+	}
+	for _, document := range documents {
+		rsp.Documents = append(rsp.Documents, convertTenantDocument(document))
+	}
+	return rsp, nil
+}
+
+func (server *Server) DeleteTenantDocument(ctx context.Context, req *pb.DeleteTenantDocumentRequest) (*pb.DeleteTenantDocumentResponse, error) {
+	violations := validateDocumentIDRequest(req)
+	if violations != nil {
+		return nil, invalidArgumentError(violations)
+	}
+
+	_, err := server.authorizeRequest(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	// The access grants of the document are deleted with it
+	document, err := server.store.DeleteTenantDocument(ctx, req.GetDocumentId())
+	if err != nil {
+		if errors.Is(err, db.ErrRecordNotFound) {
+			return nil, status.Errorf(codes.NotFound, "document not found")
+		}
+		return nil, status.Errorf(codes.Internal, "failed to delete document: %s", err)
+	}
+
+	server.deleteDocumentContent(ctx, document.StorageKey)
+
+	return &pb.DeleteTenantDocumentResponse{}, nil
+}
+
+func (server *Server) ShareApplicationDocuments(ctx context.Context, req *pb.ShareApplicationDocumentsRequest) (*pb.ShareApplicationDocumentsResponse, error) {
+	violations := validateShareApplicationDocumentsRequest(req)
+	if violations != nil {
+		return nil, invalidArgumentError(violations)
+	}
+
+	_, err := server.authorizeRequest(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	application, err := server.store.GetRentalApplicationByID(ctx, req.GetApplicationId())
+	if err != nil {
+		if errors.Is(err, db.ErrRecordNotFound) {
+			return nil, status.Errorf(codes.NotFound, "rental application not found")
+		}
+		return nil, status.Errorf(codes.Internal, "failed to get rental application: %s", err)
+	}
+
+	if !applicationIsOpen(application) {
+		return nil, status.Errorf(codes.FailedPrecondition, "documents can only be shared for applications that are still being reviewed")
+	}
+
+	_, err = server.store.ShareApplicationDocumentsTx(ctx, db.ShareApplicationDocumentsTxParams{
+		Application: application,
+		DocumentIDs: req.GetDocumentIds(),
+	})
+	if err != nil {
+		if errors.Is(err, db.ErrDocumentNotOwned) {
+			return nil, status.Errorf(codes.NotFound, "document not found: %s", err)
+		}
+		return nil, status.Errorf(codes.Internal, "failed to share documents: %s", err)
+	}
+
+	documents, err := server.applicationDocuments(ctx, application, false)
+	if err != nil {
+		return nil, err
+	}
+
+	rsp := &pb.ShareApplicationDocumentsResponse{
+		Documents: documents,
+	}
+	return rsp, nil
+}
+
+func (server *Server) RevokeApplicationDocument(ctx context.Context, req *pb.RevokeApplicationDocumentRequest) (*pb.RevokeApplicationDocumentResponse, error) {
+	violations := validateRevokeApplicationDocumentRequest(req)
+	if violations != nil {
+		return nil, invalidArgumentError(violations)
+	}
+
+	_, err := server.authorizeRequest(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = server.store.RevokeDocumentAccessGrant(ctx, db.RevokeDocumentAccessGrantParams{
+		DocumentID:          req.GetDocumentId(),
+		RentalApplicationID: req.GetApplicationId(),
+	})
+	if err != nil {
+		if errors.Is(err, db.ErrRecordNotFound) {
+			return nil, status.Errorf(codes.NotFound, "document is not shared for this application")
+		}
+		return nil, status.Errorf(codes.Internal, "failed to revoke document access: %s", err)
+	}
+
+	return &pb.RevokeApplicationDocumentResponse{}, nil
+}
+
+func (server *Server) ListApplicationDocuments(ctx context.Context, req *pb.ListApplicationDocumentsRequest) (*pb.ListApplicationDocumentsResponse, error) {
+	violations := validateApplicationIDRequest(req)
+	if violations != nil {
+		return nil, invalidArgumentError(violations)
+	}
+
+	principal, err := server.authorizeRequest(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	application, err := server.store.GetRentalApplicationByID(ctx, req.GetApplicationId())
+	if err != nil {
+		if errors.Is(err, db.ErrRecordNotFound) {
+			return nil, status.Errorf(codes.NotFound, "rental application not found")
+		}
+		return nil, status.Errorf(codes.Internal, "failed to get rental application: %s", err)
+	}
+
+	// Landlords only see what they currently have access to
+	landlordView := principal.User.ID == application.LandlordID
+	documents, err := server.applicationDocuments(ctx, application, landlordView)
+	if err != nil {
+		return nil, err
+	}
+
+	rsp := &pb.ListApplicationDocumentsResponse{
+		Documents: documents,
+	}
+	return rsp, nil
+}
+
+func (server *Server) GetDocumentDownloadURL(ctx context.Context, req *pb.GetDocumentDownloadURLRequest) (*pb.GetDocumentDownloadURLResponse, error) {
+	violations := validateDocumentIDRequest(req)
+	if violations != nil {
+		return nil, invalidArgumentError(violations)
+	}
+
+	principal, err := server.authorizeRequest(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	downloadToken, payload, err := server.downloadSigner.CreateDownloadToken(req.GetDocumentId(), principal.User.ID, documentURLTTL(server.config))
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to create download link: %s", err)
+	}
+
+	rsp := &pb.GetDocumentDownloadURLResponse{
+		DownloadUrl: DocumentDownloadPath + "?" + url.Values{"token": {downloadToken}}.Encode(),
+		ExpiresAt:   timestamppb.New(payload.ExpiredAt),
+	}
+	return rsp, nil
+}
+
+// applicationDocuments lists the documents shared for an application. With accessibleOnly, revoked documents
+// and the documents of closed applications are left out.
+func (server *Server) applicationDocuments(ctx context.Context, application db.RentalApplication, accessibleOnly bool) ([]*pb.ApplicationDocument, error) {
+	rows, err := server.store.ListRentalApplicationDocuments(ctx, application.ID)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to list application documents: %s", err)
+	}
+
+	documents := make([]*pb.ApplicationDocument, 0, len(rows))
+	if accessibleOnly && applicationIsClosed(application) {
+		return documents, nil
+	}
+	for _, row := range rows {
+		if accessibleOnly && row.RevokedAt.Valid {
+			continue
+		}
+		documents = append(documents, convertApplicationDocument(row))
+	}
+	return documents, nil
+}
+
+// canReadDocument lets tenants read their own documents, and others the documents shared with them for
+// an application that is open or approved
+func (server *Server) canReadDocument(ctx context.Context, user db.User, document db.TenantDocument) (bool, error) {
+	if document.OwnerID == user.ID || user.UserType == db.UserTypeEnumAdmin {
+		return true, nil
+	}
+
+	shared, err := server.store.HasDocumentAccess(ctx, db.HasDocumentAccessParams{
+		DocumentID: document.ID,
+		GranteeID:  user.ID,
+	})
+	if err != nil {
+		return false, status.Errorf(codes.Internal, "failed to check document access: %s", err)
+	}
+	return shared, nil
+}
+
+// deleteDocumentContent removes content whose row is gone; a failure leaves an orphaned blob, which is logged
+func (server *Server) deleteDocumentContent(ctx context.Context, storageKey string) {
+	err := server.blobStore.Delete(ctx, storageKey)
+	if err != nil {
+		log.Error().Err(err).Str("storage_key", storageKey).Msg("failed to delete document content")
+	}
+}
+
+// applicationIsOpen reports whether an application is still waiting for a decision
+func applicationIsOpen(application db.RentalApplication) bool {
+	switch application.Status.ApplicationStatusEnum {
+	case db.ApplicationStatusEnumSubmitted, db.ApplicationStatusEnumUnderReview:
+		return true
+	}
+	return !application.Status.Valid
+}
+
+// applicationIsClosed reports whether an application was turned down or given up, which ends document access
+func applicationIsClosed(application db.RentalApplication) bool {
+	switch application.Status.ApplicationStatusEnum {
+	case db.ApplicationStatusEnumRejected, db.ApplicationStatusEnumWithdrawn:
+		return application.Status.Valid
+	}
+	return false
+}
+
+func validateUploadTenantDocumentRequest(req *pb.UploadTenantDocumentRequest, maxSize int64) (violations []*errdetails.BadRequest_FieldViolation) {
+	if err := val.ValidateDocumentType(req.GetDocumentType()); err != nil {
+		violations = append(violations, fieldViolation("document_type", err))
+	}
+
+	if err := val.ValidateDocumentFileName(req.GetFileName()); err != nil {
+		violations = append(violations, fieldViolation("file_name", err))
+	}
+
+	if err := val.ValidateDocumentContent(req.GetContentType(), req.GetContent(), maxSize); err != nil {
+		violations = append(violations, fieldViolation("content", err))
+	}
+
+	return violations
+}
+
+func validateDocumentIDRequest(req interface{ GetDocumentId() int64 }) (violations []*errdetails.BadRequest_FieldViolation) {
+	if err := val.ValidateID(req.GetDocumentId()); err != nil {
+		violations = append(violations, fieldViolation("document_id", err))
+	}
+	return violations
+}
+
+func validateApplicationIDRequest(req interface{ GetApplicationId() int64 }) (violations []*errdetails.BadRequest_FieldViolation) {
+	if err := val.ValidateID(req.GetApplicationId()); err != nil {
+		violations = append(violations, fieldViolation("application_id", err))
+	}
+	return violations
+}
+
+func validateShareApplicationDocumentsRequest(req *pb.ShareApplicationDocumentsRequest) (violations []*errdetails.BadRequest_FieldViolation) {
+	violations = validateApplicationIDRequest(req)
+
+	documentIDs := req.GetDocumentIds()
+	if len(documentIDs) == 0 || len(documentIDs) > val.MaxSharedDocuments {
+		violations = append(violations, fieldViolation("document_ids", fmt.Errorf("must share between 1-%d documents", val.MaxSharedDocuments)))
+	}
+
+	seen := make(map[int64]bool, len(documentIDs))
+	for i, documentID := range documentIDs {
+		if err := val.ValidateID(documentID); err != nil {
+			violations = append(violations, fieldViolation(fmt.Sprintf("document_ids[%d]", i), err))
+		}
+		if seen[documentID] {
+			violations = append(violations, fieldViolation(fmt.Sprintf("document_ids[%d]", i), fmt.Errorf("is listed more than once")))
+		}
+		seen[documentID] = true
+	}
+
+	return violations
+}
+
+func validateRevokeApplicationDocumentRequest(req *pb.RevokeApplicationDocumentRequest) (violations []*errdetails.BadRequest_FieldViolation) {
+	violations = validateApplicationIDRequest(req)
+	violations = append(violations, validateDocumentIDRequest(req)...)
+	return violations
+}
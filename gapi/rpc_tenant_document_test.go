@@ -0,0 +1,435 @@
+package gapi
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+	mockdb "github.com/r-scheele/sqr/internal/db/mock"
+	db "github.com/r-scheele/sqr/internal/db/sqlc"
+	"github.com/r-scheele/sqr/internal/pb"
+	"github.com/r-scheele/sqr/internal/token"
+	"github.com/r-scheele/sqr/internal/util"
+	"github.com/r-scheele/sqr/internal/val"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+var testPDF = []byte("%PDF-1.4\n1 0 obj\n<< /Type /Catalog >>\nendobj\ntrailer\n<< /Root 1 0 R >>\n%%EOF\n")
+
+func randomTenantDocument(ownerID int64) db.TenantDocument {
+	return db.TenantDocument{
+		ID:           util.RandomInt(1, 1000),
+		OwnerID:      ownerID,
+		DocumentType: db.DocumentTypeEnumPayslip,
+		FileName:     "payslip.pdf",
+		ContentType:  "application/pdf",
+		SizeBytes:    int64(len(testPDF)),
+		Checksum:     util.RandomString(64),
+		StorageKey:   fmt.Sprintf("documents/%d/%s", ownerID, uuid.New()),
+		CreatedAt:    time.Now(),
+	}
+}
+
+func TestUploadTenantDocumentAPI(t *testing.T) {
+	tenant, _ := randomUser(t, util.TenantRole)
+
+	testCases := []struct {
+		name          string
+		req           *pb.UploadTenantDocumentRequest
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(t *testing.T, res *pb.UploadTenantDocumentResponse, err error)
+	}{
+		{
+			name: "OK",
+			req: &pb.UploadTenantDocumentRequest{
+				DocumentType: "payslip",
+				FileName:     "payslip.pdf",
+				ContentType:  "application/pdf",
+				Content:      testPDF,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetUserByID(gomock.Any(), gomock.Eq(tenant.ID)).
+					Times(1).
+					Return(tenant, nil)
+
+				store.EXPECT().
+					CountTenantDocuments(gomock.Any(), gomock.Eq(tenant.ID)).
+					Times(1).
+					Return(int64(0), nil)
+
+				store.EXPECT().
+					CreateTenantDocument(gomock.Any(), gomock.Any()).
+					Times(1).
+					DoAndReturn(func(_ context.Context, arg db.CreateTenantDocumentParams) (db.TenantDocument, error) {
+						require.Equal(t, tenant.ID, arg.OwnerID)
+						require.Equal(t, db.DocumentTypeEnumPayslip, arg.DocumentType)
+						require.Equal(t, int64(len(testPDF)), arg.SizeBytes)
+						require.Len(t, arg.Checksum, 64)
+
+						return db.TenantDocument{
+							ID:           1,
+							OwnerID:      arg.OwnerID,
+							DocumentType: arg.DocumentType,
+							FileName:     arg.FileName,
+							ContentType:  arg.ContentType,
+							SizeBytes:    arg.SizeBytes,
+							Checksum:     arg.Checksum,
+							StorageKey:   arg.StorageKey,
+							CreatedAt:    time.Now(),
+						}, nil
+					})
+			},
+			checkResponse: func(t *testing.T, res *pb.UploadTenantDocumentResponse, err error) {
+				require.NoError(t, err)
+				require.Equal(t, "payslip", res.GetDocument().GetDocumentType())
+				require.Equal(t, "application/pdf", res.GetDocument().GetContentType())
+			},
+		},
+		{
+			name: "ContentTypeMismatch",
+			req: &pb.UploadTenantDocumentRequest{
+				DocumentType: "payslip",
+				FileName:     "payslip.png",
+				ContentType:  "image/png",
+				Content:      testPDF,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetUserByID(gomock.Any(), gomock.Any()).
+					Times(0)
+
+				store.EXPECT().
+					CreateTenantDocument(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, res *pb.UploadTenantDocumentResponse, err error) {
+				require.Error(t, err)
+				requireFieldViolation(t, err, "content")
+			},
+		},
+		{
+			name: "TooManyDocuments",
+			req: &pb.UploadTenantDocumentRequest{
+				DocumentType: "payslip",
+				FileName:     "payslip.pdf",
+				ContentType:  "application/pdf",
+				Content:      testPDF,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetUserByID(gomock.Any(), gomock.Eq(tenant.ID)).
+					Times(1).
+					Return(tenant, nil)
+
+				store.EXPECT().
+					CountTenantDocuments(gomock.Any(), gomock.Eq(tenant.ID)).
+					Times(1).
+					Return(int64(val.MaxTenantDocuments), nil)
+
+				store.EXPECT().
+					CreateTenantDocument(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, res *pb.UploadTenantDocumentResponse, err error) {
+				require.Error(t, err)
+				st, ok := status.FromError(err)
+				require.True(t, ok)
+				require.Equal(t, codes.ResourceExhausted, st.Code())
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			ctx := newContextWithBearerToken(t, server.tokenMaker, tenant, uuid.New(), time.Minute, token.TokenTypeAccessToken)
+
+			res, err := server.UploadTenantDocument(ctx, tc.req)
+			tc.checkResponse(t, res, err)
+		})
+	}
+}
+
+func TestShareApplicationDocumentsAPI(t *testing.T) {
+	tenant, _ := randomUser(t, util.TenantRole)
+	landlord, _ := randomUser(t, util.LandlordRole)
+	landlord.ID = tenant.ID + 1
+	document := randomTenantDocument(tenant.ID)
+
+	application := db.RentalApplication{
+		ID:         util.RandomInt(1, 1000),
+		PropertyID: util.RandomInt(1, 1000),
+		TenantID:   tenant.ID,
+		LandlordID: landlord.ID,
+		Status:     db.NullApplicationStatusEnum{ApplicationStatusEnum: db.ApplicationStatusEnumSubmitted, Valid: true},
+	}
+	rejected := application
+	rejected.Status = db.NullApplicationStatusEnum{ApplicationStatusEnum: db.ApplicationStatusEnumRejected, Valid: true}
+
+	testCases := []struct {
+		name          string
+		application   db.RentalApplication
+		buildStubs    func(store *mockdb.MockStore, application db.RentalApplication)
+		checkResponse func(t *testing.T, res *pb.ShareApplicationDocumentsResponse, err error)
+	}{
+		{
+			name:        "OK",
+			application: application,
+			buildStubs: func(store *mockdb.MockStore, application db.RentalApplication) {
+				store.EXPECT().
+					GetUserByID(gomock.Any(), gomock.Eq(tenant.ID)).
+					Times(1).
+					Return(tenant, nil)
+
+				store.EXPECT().
+					GetRentalApplicationByID(gomock.Any(), gomock.Eq(application.ID)).
+					Times(2).
+					Return(application, nil)
+
+				store.EXPECT().
+					ShareApplicationDocumentsTx(gomock.Any(), gomock.Eq(db.ShareApplicationDocumentsTxParams{
+						Application: application,
+						DocumentIDs: []int64{document.ID},
+					})).
+					Times(1).
+					Return(db.ShareApplicationDocumentsTxResult{}, nil)
+
+				store.EXPECT().
+					ListRentalApplicationDocuments(gomock.Any(), gomock.Eq(application.ID)).
+					Times(1).
+					Return([]db.ListRentalApplicationDocumentsRow{
+						{
+							ID:           document.ID,
+							OwnerID:      document.OwnerID,
+							DocumentType: document.DocumentType,
+							FileName:     document.FileName,
+							ContentType:  document.ContentType,
+							SizeBytes:    document.SizeBytes,
+							Checksum:     document.Checksum,
+							StorageKey:   document.StorageKey,
+							CreatedAt:    document.CreatedAt,
+							GranteeID:    landlord.ID,
+							SharedAt:     time.Now(),
+						},
+					}, nil)
+			},
+			checkResponse: func(t *testing.T, res *pb.ShareApplicationDocumentsResponse, err error) {
+				require.NoError(t, err)
+				require.Len(t, res.GetDocuments(), 1)
+				require.Equal(t, document.ID, res.GetDocuments()[0].GetDocument().GetId())
+				require.False(t, res.GetDocuments()[0].GetRevoked())
+			},
+		},
+		{
+			name:        "ClosedApplication",
+			application: rejected,
+			buildStubs: func(store *mockdb.MockStore, application db.RentalApplication) {
+				store.EXPECT().
+					GetUserByID(gomock.Any(), gomock.Eq(tenant.ID)).
+					Times(1).
+					Return(tenant, nil)
+
+				store.EXPECT().
+					GetRentalApplicationByID(gomock.Any(), gomock.Eq(application.ID)).
+					Times(2).
+					Return(application, nil)
+
+				store.EXPECT().
+					ShareApplicationDocumentsTx(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, res *pb.ShareApplicationDocumentsResponse, err error) {
+				require.Error(t, err)
+				st, ok := status.FromError(err)
+				require.True(t, ok)
+				require.Equal(t, codes.FailedPrecondition, st.Code())
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store, tc.application)
+
+			server := newTestServer(t, store)
+			ctx := newContextWithBearerToken(t, server.tokenMaker, tenant, uuid.New(), time.Minute, token.TokenTypeAccessToken)
+
+			res, err := server.ShareApplicationDocuments(ctx, &pb.ShareApplicationDocumentsRequest{
+				ApplicationId: tc.application.ID,
+				DocumentIds:   []int64{document.ID},
+			})
+			tc.checkResponse(t, res, err)
+		})
+	}
+}
+
+func TestServeDocumentDownload(t *testing.T) {
+	tenant, _ := randomUser(t, util.TenantRole)
+	landlord, _ := randomUser(t, util.LandlordRole)
+	landlord.ID = tenant.ID + 1
+	document := randomTenantDocument(tenant.ID)
+
+	access := db.HasDocumentAccessParams{
+		DocumentID: document.ID,
+		GranteeID:  landlord.ID,
+	}
+
+	testCases := []struct {
+		name          string
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "OK",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					HasDocumentAccess(gomock.Any(), gomock.Eq(access)).
+					Times(2).
+					Return(true, nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				require.Equal(t, "application/pdf", recorder.Header().Get("Content-Type"))
+				require.Contains(t, recorder.Header().Get("Content-Disposition"), "attachment")
+				require.Equal(t, testPDF, recorder.Body.Bytes())
+			},
+		},
+		{
+			name: "AccessRevoked",
+			buildStubs: func(store *mockdb.MockStore) {
+				gomock.InOrder(
+					store.EXPECT().
+						HasDocumentAccess(gomock.Any(), gomock.Eq(access)).
+						Times(1).
+						Return(true, nil),
+					store.EXPECT().
+						HasDocumentAccess(gomock.Any(), gomock.Eq(access)).
+						Times(1).
+						Return(false, nil),
+				)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+				require.NotContains(t, recorder.Body.String(), "%PDF")
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			store.EXPECT().
+				GetUserByID(gomock.Any(), gomock.Eq(landlord.ID)).
+				Times(2).
+				Return(landlord, nil)
+			store.EXPECT().
+				GetTenantDocument(gomock.Any(), gomock.Eq(document.ID)).
+				Times(2).
+				Return(document, nil)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			require.NoError(t, server.blobStore.Put(context.Background(), document.StorageKey, testPDF))
+
+			ctx := newContextWithBearerToken(t, server.tokenMaker, landlord, uuid.New(), time.Minute, token.TokenTypeAccessToken)
+			res, err := server.GetDocumentDownloadURL(ctx, &pb.GetDocumentDownloadURLRequest{DocumentId: document.ID})
+			require.NoError(t, err)
+
+			recorder := httptest.NewRecorder()
+			request := httptest.NewRequest(http.MethodGet, res.GetDownloadUrl(), nil)
+			server.ServeDocumentDownload(recorder, request)
+			tc.checkResponse(t, recorder)
+		})
+	}
+}
+
+func TestServeDocumentDownloadInvalidToken(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	store := mockdb.NewMockStore(ctrl)
+	store.EXPECT().
+		GetTenantDocument(gomock.Any(), gomock.Any()).
+		Times(0)
+
+	server := newTestServer(t, store)
+
+	recorder := httptest.NewRecorder()
+	request := httptest.NewRequest(http.MethodGet, DocumentDownloadPath+"?token="+util.RandomString(40), nil)
+	server.ServeDocumentDownload(recorder, request)
+	require.Equal(t, http.StatusForbidden, recorder.Code)
+}
+
+func TestServeDocumentDownloadUserDeactivated(t *testing.T) {
+	tenant, _ := randomUser(t, util.TenantRole)
+	landlord, _ := randomUser(t, util.LandlordRole)
+	landlord.ID = tenant.ID + 1
+	document := randomTenantDocument(tenant.ID)
+
+	deactivated := landlord
+	deactivated.IsActive = pgtype.Bool{Bool: false, Valid: true}
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	store := mockdb.NewMockStore(ctrl)
+	gomock.InOrder(
+		store.EXPECT().
+			GetUserByID(gomock.Any(), gomock.Eq(landlord.ID)).
+			Times(1).
+			Return(landlord, nil),
+		store.EXPECT().
+			GetUserByID(gomock.Any(), gomock.Eq(landlord.ID)).
+			Times(1).
+			Return(deactivated, nil),
+	)
+	store.EXPECT().
+		GetTenantDocument(gomock.Any(), gomock.Eq(document.ID)).
+		Times(2).
+		Return(document, nil)
+	store.EXPECT().
+		HasDocumentAccess(gomock.Any(), gomock.Any()).
+		Times(1).
+		Return(true, nil)
+
+	server := newTestServer(t, store)
+	require.NoError(t, server.blobStore.Put(context.Background(), document.StorageKey, testPDF))
+
+	ctx := newContextWithBearerToken(t, server.tokenMaker, landlord, uuid.New(), time.Minute, token.TokenTypeAccessToken)
+	res, err := server.GetDocumentDownloadURL(ctx, &pb.GetDocumentDownloadURLRequest{DocumentId: document.ID})
+	require.NoError(t, err)
+
+	recorder := httptest.NewRecorder()
+	request := httptest.NewRequest(http.MethodGet, res.GetDownloadUrl(), nil)
+	server.ServeDocumentDownload(recorder, request)
+	require.Equal(t, http.StatusForbidden, recorder.Code)
+	require.NotContains(t, recorder.Body.String(), "%PDF")
+}
@@ -7,7 +7,6 @@ import (
 	"github.com/jackc/pgx/v5/pgtype"
 	db "github.com/r-scheele/sqr/internal/db/sqlc"
 	"github.com/r-scheele/sqr/internal/pb"
-	"github.com/r-scheele/sqr/internal/util"
 	"github.com/r-scheele/sqr/internal/val"
 	"google.golang.org/genproto/googleapis/rpc/errdetails"
 	"google.golang.org/grpc/codes"
@@ -20,18 +19,9 @@ var (
 
 func (server *Server) GetTenantProfile(ctx context.Context, req *pb.GetTenantProfileRequest) (*pb.GetTenantProfileResponse, error) {
 
-	authPayload, err := server.authorizeUser(ctx, []string{util.TenantRole, util.AdminRole})
+	_, err := server.authorizeRequest(ctx, req)
 	if err != nil {
-		return nil, unauthenticatedError(err)
-	}
-
-	authUser, err := server.store.GetUserByEmail(ctx, authPayload.Username)
-	if err != nil {
-		return nil, status.Errorf(codes.Internal, "failed to get authenticated user: %s", err)
-	}
-
-	if authUser.ID != req.GetUserId() && authUser.UserType != db.UserTypeEnumAdmin {
-		return nil, status.Errorf(codes.PermissionDenied, "cannot access other user's profile")
+		return nil, err
 	}
 
 	targetUser, err := server.store.GetUserByID(ctx, req.GetUserId())
@@ -65,19 +55,12 @@ func (server *Server) UpdateTenantProfile(ctx context.Context, req *pb.UpdateTen
 		return nil, invalidArgumentError(violations)
 	}
 
-	authPayload, err := server.authorizeUser(ctx, []string{util.TenantRole})
+	principal, err := server.authorizeRequest(ctx, req)
 	if err != nil {
-		return nil, unauthenticatedError(err)
+		return nil, err
 	}
 
-	authUser, err := server.store.GetUserByEmail(ctx, authPayload.Username)
-	if err != nil {
-		return nil, status.Errorf(codes.Internal, "failed to get authenticated user: %s", err)
-	}
-
-	if authUser.ID != req.GetUserId() {
-		return nil, status.Errorf(codes.PermissionDenied, "cannot update other user's profile")
-	}
+	authUser := principal.User
 
 	if authUser.UserType != db.UserTypeEnumTenant {
 		return nil, status.Errorf(codes.InvalidArgument, "user is not a tenant")
@@ -0,0 +1,53 @@
+package gapi
+
+import (
+	"context"
+	"errors"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	db "github.com/r-scheele/sqr/internal/db/sqlc"
+	"github.com/r-scheele/sqr/internal/pb"
+	"github.com/r-scheele/sqr/internal/val"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (server *Server) UnlockUserAccount(ctx context.Context, req *pb.UnlockUserAccountRequest) (*pb.UnlockUserAccountResponse, error) {
+	violations := validateUnlockUserAccountRequest(req)
+	if violations != nil {
+		return nil, invalidArgumentError(violations)
+	}
+
+	principal, err := server.authorizeRequest(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	mtdt := server.extractMetadata(ctx)
+	_, err = server.store.UnlockAccountTx(ctx, db.UnlockAccountTxParams{
+		UserID:    req.GetUserId(),
+		AdminID:   principal.User.ID,
+		IpAddress: pgtype.Text{String: mtdt.ClientIP, Valid: mtdt.ClientIP != ""},
+		UserAgent: pgtype.Text{String: mtdt.UserAgent, Valid: mtdt.UserAgent != ""},
+	})
+	if err != nil {
+		if errors.Is(err, db.ErrRecordNotFound) {
+			return nil, status.Errorf(codes.NotFound, "account is not locked")
+		}
+		return nil, status.Errorf(codes.Internal, "failed to unlock account: %s", err)
+	}
+
+	rsp := &pb.UnlockUserAccountResponse{
+		Message: "Account unlocked successfully",
+	}
+	return rsp, nil
+}
+
+func validateUnlockUserAccountRequest(req *pb.UnlockUserAccountRequest) (violations []*errdetails.BadRequest_FieldViolation) {
+	if err := val.ValidateID(req.GetUserId()); err != nil {
+		violations = append(violations, fieldViolation("user_id", err))
+	}
+
+	return violations
+}
@@ -19,6 +19,11 @@ func (server *Server) UpdateUser(ctx context.Context, req *pb.UpdateUserRequest)
 		return nil, invalidArgumentError(violations)
 	}
 
+	_, err := server.authorizeRequest(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
 	user, err := server.store.GetUserByEmail(ctx, req.GetUsername())
 	if err != nil {
 		if errors.Is(err, db.ErrRecordNotFound) {
@@ -48,14 +53,14 @@ func (server *Server) UpdateUser(ctx context.Context, req *pb.UpdateUserRequest)
 	}
 
 	if req.Password != nil {
-		hashedPassword, err := util.HashPassword(req.GetPassword())
-		if err != nil {
-			return nil, status.Errorf(codes.Internal, "failed to hash password: %s", err)
+		violations := server.newPasswordViolations(ctx, "password", req.GetPassword(), val.PasswordOwner{
+			Email:     arg.Email,
+			FirstName: arg.FirstName,
+			LastName:  arg.LastName,
+		})
+		if violations != nil {
+			return nil, invalidArgumentError(violations)
 		}
-
-		// We need to use a separate query for password update since UpdateUserParams doesn't include password
-		// For now, we'll update other fields first, then handle password separately
-		_ = hashedPassword // TODO: Implement password update query
 	}
 
 	updatedUser, err := server.store.UpdateUser(ctx, arg)
@@ -66,6 +71,21 @@ func (server *Server) UpdateUser(ctx context.Context, req *pb.UpdateUserRequest)
 		return nil, status.Errorf(codes.Internal, "failed to update user: %s", err)
 	}
 
+	if req.Password != nil {
+		hashedPassword, err := server.passwordHasher.Hash(req.GetPassword())
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "failed to hash password: %s", err)
+		}
+
+		err = server.store.UpdateUserPassword(ctx, db.UpdateUserPasswordParams{
+			ID:           updatedUser.ID,
+			PasswordHash: hashedPassword,
+		})
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "failed to update password: %s", err)
+		}
+	}
+
 	rsp := &pb.UpdateUserResponse{
 		User: convertUser(updatedUser),
 	}
@@ -77,12 +97,6 @@ func validateUpdateUserRequest(req *pb.UpdateUserRequest) (violations []*errdeta
 		violations = append(violations, fieldViolation("username", err))
 	}
 
-	if req.Password != nil {
-		if err := val.ValidatePassword(req.GetPassword()); err != nil {
-			violations = append(violations, fieldViolation("password", err))
-		}
-	}
-
 	if req.FullName != nil {
 		if err := val.ValidateFullName(req.GetFullName()); err != nil {
 			violations = append(violations, fieldViolation("full_name", err))
@@ -3,11 +3,14 @@ package gapi
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
 	mockdb "github.com/r-scheele/sqr/internal/db/mock"
 	db "github.com/r-scheele/sqr/internal/db/sqlc"
 	"github.com/r-scheele/sqr/internal/pb"
+	"github.com/r-scheele/sqr/internal/token"
 	"github.com/r-scheele/sqr/internal/util"
 	"github.com/stretchr/testify/require"
 	"google.golang.org/grpc/codes"
@@ -16,14 +19,22 @@ import (
 
 func TestUpdateUserAPI(t *testing.T) {
 	user, _ := randomUser(t, util.TenantRole)
+	user.ID = util.RandomInt(1, 1000)
+	otherUser, _ := randomUser(t, util.TenantRole)
+	otherUser.ID = user.ID + 1
+	admin, _ := randomUser(t, util.AdminRole)
+	admin.ID = user.ID + 2
 
 	newName := util.RandomOwner()
 	newEmail := util.RandomEmail()
 	invalidEmail := "invalid-email"
+	newPassword := util.RandomPassword()
+	weakPassword := "password"
 
 	testCases := []struct {
 		name          string
 		req           *pb.UpdateUserRequest
+		caller        *db.User
 		buildStubs    func(store *mockdb.MockStore)
 		checkResponse func(t *testing.T, res *pb.UpdateUserResponse, err error)
 	}{
@@ -34,7 +45,13 @@ func TestUpdateUserAPI(t *testing.T) {
 				FullName: &newName,
 				Email:    &newEmail,
 			},
+			caller: &user,
 			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetUserByID(gomock.Any(), gomock.Eq(user.ID)).
+					Times(1).
+					Return(user, nil)
+
 				// First expect to get the user by email
 				store.EXPECT().
 					GetUserByEmail(gomock.Any(), user.Email).
@@ -90,7 +107,13 @@ func TestUpdateUserAPI(t *testing.T) {
 				FullName: &newName,
 				Email:    &newEmail,
 			},
+			caller: &admin,
 			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetUserByID(gomock.Any(), gomock.Eq(admin.ID)).
+					Times(1).
+					Return(admin, nil)
+
 				store.EXPECT().
 					GetUserByEmail(gomock.Any(), user.Email).
 					Times(1).
@@ -103,6 +126,114 @@ func TestUpdateUserAPI(t *testing.T) {
 				require.Equal(t, codes.NotFound, st.Code())
 			},
 		},
+		{
+			name: "OtherUser",
+			req: &pb.UpdateUserRequest{
+				Username: user.Email,
+				FullName: &newName,
+			},
+			caller: &otherUser,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetUserByID(gomock.Any(), gomock.Eq(otherUser.ID)).
+					Times(1).
+					Return(otherUser, nil)
+
+				store.EXPECT().
+					UpdateUser(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, res *pb.UpdateUserResponse, err error) {
+				require.Error(t, err)
+				require.Equal(t, codes.PermissionDenied, status.Code(err))
+			},
+		},
+		{
+			name: "NoAuthorization",
+			req: &pb.UpdateUserRequest{
+				Username: user.Email,
+				FullName: &newName,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetUserByEmail(gomock.Any(), gomock.Any()).
+					Times(0)
+				store.EXPECT().
+					UpdateUser(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, res *pb.UpdateUserResponse, err error) {
+				require.Error(t, err)
+				require.Equal(t, codes.Unauthenticated, status.Code(err))
+			},
+		},
+		{
+			name: "ChangePassword",
+			req: &pb.UpdateUserRequest{
+				Username: user.Email,
+				Password: &newPassword,
+			},
+			caller: &user,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetUserByID(gomock.Any(), gomock.Eq(user.ID)).
+					Times(1).
+					Return(user, nil)
+
+				store.EXPECT().
+					GetUserByEmail(gomock.Any(), user.Email).
+					Times(1).
+					Return(user, nil)
+
+				store.EXPECT().
+					UpdateUser(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(user, nil)
+
+				store.EXPECT().
+					UpdateUserPassword(gomock.Any(), gomock.Any()).
+					Times(1).
+					DoAndReturn(func(_ context.Context, arg db.UpdateUserPasswordParams) error {
+						require.Equal(t, user.ID, arg.ID)
+						require.NoError(t, util.CheckPassword(newPassword, arg.PasswordHash))
+						return nil
+					})
+			},
+			checkResponse: func(t *testing.T, res *pb.UpdateUserResponse, err error) {
+				require.NoError(t, err)
+			},
+		},
+		{
+			name: "WeakPassword",
+			req: &pb.UpdateUserRequest{
+				Username: user.Email,
+				Password: &weakPassword,
+			},
+			caller: &user,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetUserByID(gomock.Any(), gomock.Eq(user.ID)).
+					Times(1).
+					Return(user, nil)
+
+				store.EXPECT().
+					GetUserByEmail(gomock.Any(), user.Email).
+					Times(1).
+					Return(user, nil)
+
+				store.EXPECT().
+					UpdateUser(gomock.Any(), gomock.Any()).
+					Times(0)
+
+				store.EXPECT().
+					UpdateUserPassword(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, res *pb.UpdateUserResponse, err error) {
+				require.Error(t, err)
+				requireFieldViolation(t, err, "password")
+			},
+		},
 		{
 			name: "InvalidEmail",
 			req: &pb.UpdateUserRequest{
@@ -138,7 +269,12 @@ func TestUpdateUserAPI(t *testing.T) {
 			tc.buildStubs(store)
 			server := newTestServer(t, store) // Use simplified signature
 
-			res, err := server.UpdateUser(context.Background(), tc.req)
+			ctx := context.Background()
+			if tc.caller != nil {
+				ctx = newContextWithBearerToken(t, server.tokenMaker, *tc.caller, uuid.New(), time.Minute, token.TokenTypeAccessToken)
+			}
+
+			res, err := server.UpdateUser(ctx, tc.req)
 			tc.checkResponse(t, res, err)
 		})
 	}
@@ -0,0 +1,159 @@
+package gapi
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	db "github.com/r-scheele/sqr/internal/db/sqlc"
+	"github.com/r-scheele/sqr/internal/pb"
+	"github.com/r-scheele/sqr/internal/val"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func (server *Server) ListMySessions(ctx context.Context, req *pb.ListMySessionsRequest) (*pb.ListMySessionsResponse, error) {
+	violations := validateListMySessionsRequest(req)
+	if violations != nil {
+		return nil, invalidArgumentError(violations)
+	}
+
+	principal, err := server.authorizeRequest(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	sessions, err := server.store.GetUserActiveSessions(ctx, db.GetUserActiveSessionsParams{
+		UserID: principal.User.ID,
+		Limit:  req.GetPageSize(),
+		Offset: (req.GetPageId() - 1) * req.GetPageSize(),
+	})
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to list sessions: %s", err)
+	}
+
+	totalCount, err := server.store.CountUserActiveSessions(ctx, principal.User.ID)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to count sessions: %s", err)
+	}
+
+	summary, err := server.store.GetUserSessionSummary(ctx, principal.User.ID)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to get session summary: %s", err)
+	}
+
+	rsp := &pb.ListMySessionsResponse{
+		Sessions:   make([]*pb.Session, 0, len(sessions)),
+		TotalCount: totalCount,
+		UniqueIps:  summary.UniqueIps,
+	}
+	if lastSessionTime, ok := summary.LastSessionTime.(time.Time); ok {
+		rsp.LastSessionAt = timestamppb.New(lastSessionTime)
+	}
+
+	for _, session := range sessions {
+		rsp.Sessions = append(rsp.Sessions, convertSession(session, principal.Payload.SessionID))
+	}
+
+	return rsp, nil
+}
+
+func (server *Server) RevokeSession(ctx context.Context, req *pb.RevokeSessionRequest) (*pb.RevokeSessionResponse, error) {
+	sessionID, err := uuid.Parse(req.GetSessionId())
+	if err != nil {
+		return nil, invalidArgumentError([]*errdetails.BadRequest_FieldViolation{
+			fieldViolation("session_id", err),
+		})
+	}
+
+	principal, err := server.authorizeRequest(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	sessions, err := server.store.DeactivateUserSessionBySessionID(ctx, db.DeactivateUserSessionBySessionIDParams{
+		UserID:    principal.User.ID,
+		SessionID: sessionID,
+	})
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to revoke session: %s", err)
+	}
+
+	if len(sessions) == 0 {
+		return nil, status.Errorf(codes.NotFound, "session not found")
+	}
+
+	// Access tokens issued for the session must stop working as well, as in LogoutUser
+	for _, session := range sessions {
+		err = server.denylist.RevokeSession(ctx, session.SessionID, server.config.AccessTokenDuration)
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "failed to revoke access tokens: %v", err)
+		}
+	}
+
+	rsp := &pb.RevokeSessionResponse{
+		Message: "Session revoked",
+	}
+	return rsp, nil
+}
+
+func (server *Server) RevokeOtherSessions(ctx context.Context, req *pb.RevokeOtherSessionsRequest) (*pb.RevokeOtherSessionsResponse, error) {
+	principal, err := server.authorizeRequest(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	sessions, err := server.store.GetAllUserActiveSessions(ctx, principal.User.ID)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to get sessions: %s", err)
+	}
+
+	// Find the refresh token of the session the caller is signed in with
+	var currentSession *db.UserSession
+	for i := range sessions {
+		if sessions[i].SessionID == principal.Payload.SessionID {
+			currentSession = &sessions[i]
+			break
+		}
+	}
+	if currentSession == nil {
+		return nil, status.Errorf(codes.Unauthenticated, "current session is no longer active")
+	}
+
+	err = server.store.DeactivateOtherUserSessions(ctx, db.DeactivateOtherUserSessionsParams{
+		UserID:       principal.User.ID,
+		SessionToken: currentSession.SessionToken,
+	})
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to revoke sessions: %s", err)
+	}
+
+	for _, session := range sessions {
+		if session.SessionID == principal.Payload.SessionID {
+			continue
+		}
+		err = server.denylist.RevokeSession(ctx, session.SessionID, server.config.AccessTokenDuration)
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "failed to revoke access tokens: %v", err)
+		}
+	}
+
+	rsp := &pb.RevokeOtherSessionsResponse{
+		RevokedCount: int64(len(sessions) - 1),
+	}
+	return rsp, nil
+}
+
+func validateListMySessionsRequest(req *pb.ListMySessionsRequest) (violations []*errdetails.BadRequest_FieldViolation) {
+	if err := val.ValidatePageID(req.GetPageId()); err != nil {
+		violations = append(violations, fieldViolation("page_id", err))
+	}
+
+	if err := val.ValidatePageSize(req.GetPageSize()); err != nil {
+		violations = append(violations, fieldViolation("page_size", err))
+	}
+
+	return violations
+}
@@ -0,0 +1,420 @@
+package gapi
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+	mockdb "github.com/r-scheele/sqr/internal/db/mock"
+	db "github.com/r-scheele/sqr/internal/db/sqlc"
+	"github.com/r-scheele/sqr/internal/pb"
+	"github.com/r-scheele/sqr/internal/token"
+	"github.com/r-scheele/sqr/internal/util"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func randomSession(userID int64) db.UserSession {
+	return db.UserSession{
+		ID:           util.RandomInt(1, 1000),
+		UserID:       userID,
+		SessionID:    uuid.New(),
+		SessionToken: util.RandomString(32),
+		DeviceInfo:   pgtype.Text{String: "Mozilla/5.0", Valid: true},
+		IpAddress:    pgtype.Text{String: "127.0.0.1", Valid: true},
+		LocationData: pgtype.Text{String: "Lagos, NG", Valid: true},
+		ExpiresAt:    time.Now().Add(time.Hour),
+		IsActive:     pgtype.Bool{Bool: true, Valid: true},
+	}
+}
+
+func TestListMySessionsAPI(t *testing.T) {
+	user, _ := randomUser(t, util.TenantRole)
+	user.ID = util.RandomInt(1, 1000)
+
+	current := randomSession(user.ID)
+	other := randomSession(user.ID)
+
+	testCases := []struct {
+		name          string
+		req           *pb.ListMySessionsRequest
+		buildStubs    func(store *mockdb.MockStore)
+		buildContext  func(t *testing.T, tokenMaker token.Maker) context.Context
+		checkResponse func(t *testing.T, res *pb.ListMySessionsResponse, err error)
+	}{
+		{
+			name: "OK",
+			req: &pb.ListMySessionsRequest{
+				PageId:   1,
+				PageSize: 10,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetUserByID(gomock.Any(), user.ID).
+					Times(1).
+					Return(user, nil)
+
+				arg := db.GetUserActiveSessionsParams{
+					UserID: user.ID,
+					Limit:  10,
+					Offset: 0,
+				}
+				store.EXPECT().
+					GetUserActiveSessions(gomock.Any(), gomock.Eq(arg)).
+					Times(1).
+					Return([]db.UserSession{current, other}, nil)
+
+				store.EXPECT().
+					CountUserActiveSessions(gomock.Any(), user.ID).
+					Times(1).
+					Return(int64(2), nil)
+
+				store.EXPECT().
+					GetUserSessionSummary(gomock.Any(), user.ID).
+					Times(1).
+					Return(db.GetUserSessionSummaryRow{TotalSessions: 3, ActiveSessions: 2, UniqueIps: 1}, nil)
+			},
+			buildContext: func(t *testing.T, tokenMaker token.Maker) context.Context {
+				return newContextWithBearerToken(t, tokenMaker, user, current.SessionID, time.Minute, token.TokenTypeAccessToken)
+			},
+			checkResponse: func(t *testing.T, res *pb.ListMySessionsResponse, err error) {
+				require.NoError(t, err)
+				require.Len(t, res.Sessions, 2)
+				require.Equal(t, int64(2), res.TotalCount)
+
+				require.Equal(t, current.SessionID.String(), res.Sessions[0].SessionId)
+				require.Equal(t, current.DeviceInfo.String, res.Sessions[0].DeviceInfo)
+				require.Equal(t, current.IpAddress.String, res.Sessions[0].IpAddress)
+				require.Equal(t, current.LocationData.String, res.Sessions[0].LocationData)
+				require.True(t, res.Sessions[0].Current)
+				require.False(t, res.Sessions[1].Current)
+			},
+		},
+		{
+			name: "InvalidPageSize",
+			req: &pb.ListMySessionsRequest{
+				PageId:   1,
+				PageSize: 100,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetUserActiveSessions(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			buildContext: func(t *testing.T, tokenMaker token.Maker) context.Context {
+				return newContextWithBearerToken(t, tokenMaker, user, current.SessionID, time.Minute, token.TokenTypeAccessToken)
+			},
+			checkResponse: func(t *testing.T, res *pb.ListMySessionsResponse, err error) {
+				require.Error(t, err)
+				st, ok := status.FromError(err)
+				require.True(t, ok)
+				require.Equal(t, codes.InvalidArgument, st.Code())
+			},
+		},
+		{
+			name: "NoAuthorization",
+			req: &pb.ListMySessionsRequest{
+				PageId:   1,
+				PageSize: 10,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetUserActiveSessions(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			buildContext: func(t *testing.T, tokenMaker token.Maker) context.Context {
+				return context.Background()
+			},
+			checkResponse: func(t *testing.T, res *pb.ListMySessionsResponse, err error) {
+				require.Error(t, err)
+				st, ok := status.FromError(err)
+				require.True(t, ok)
+				require.Equal(t, codes.Unauthenticated, st.Code())
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			ctx := tc.buildContext(t, server.tokenMaker)
+
+			res, err := server.ListMySessions(ctx, tc.req)
+			tc.checkResponse(t, res, err)
+		})
+	}
+}
+
+func TestRevokeSessionAPI(t *testing.T) {
+	user, _ := randomUser(t, util.TenantRole)
+	user.ID = util.RandomInt(1, 1000)
+
+	current := randomSession(user.ID)
+	other := randomSession(user.ID)
+
+	testCases := []struct {
+		name          string
+		req           *pb.RevokeSessionRequest
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(t *testing.T, res *pb.RevokeSessionResponse, err error)
+	}{
+		{
+			name: "OK",
+			req: &pb.RevokeSessionRequest{
+				SessionId: other.SessionID.String(),
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetUserByID(gomock.Any(), user.ID).
+					Times(1).
+					Return(user, nil)
+
+				arg := db.DeactivateUserSessionBySessionIDParams{
+					UserID:    user.ID,
+					SessionID: other.SessionID,
+				}
+				store.EXPECT().
+					DeactivateUserSessionBySessionID(gomock.Any(), gomock.Eq(arg)).
+					Times(1).
+					Return([]db.UserSession{other}, nil)
+			},
+			checkResponse: func(t *testing.T, res *pb.RevokeSessionResponse, err error) {
+				require.NoError(t, err)
+				require.NotNil(t, res)
+			},
+		},
+		{
+			name: "NotFound",
+			req: &pb.RevokeSessionRequest{
+				SessionId: uuid.NewString(),
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetUserByID(gomock.Any(), user.ID).
+					Times(1).
+					Return(user, nil)
+
+				store.EXPECT().
+					DeactivateUserSessionBySessionID(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return([]db.UserSession{}, nil)
+			},
+			checkResponse: func(t *testing.T, res *pb.RevokeSessionResponse, err error) {
+				require.Error(t, err)
+				st, ok := status.FromError(err)
+				require.True(t, ok)
+				require.Equal(t, codes.NotFound, st.Code())
+			},
+		},
+		{
+			name: "InvalidSessionID",
+			req: &pb.RevokeSessionRequest{
+				SessionId: "not-a-uuid",
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					DeactivateUserSessionBySessionID(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, res *pb.RevokeSessionResponse, err error) {
+				require.Error(t, err)
+				st, ok := status.FromError(err)
+				require.True(t, ok)
+				require.Equal(t, codes.InvalidArgument, st.Code())
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			ctx := newContextWithBearerToken(t, server.tokenMaker, user, current.SessionID, time.Minute, token.TokenTypeAccessToken)
+
+			res, err := server.RevokeSession(ctx, tc.req)
+			tc.checkResponse(t, res, err)
+		})
+	}
+}
+
+func TestRevokeOtherSessionsAPI(t *testing.T) {
+	user, _ := randomUser(t, util.TenantRole)
+	user.ID = util.RandomInt(1, 1000)
+
+	current := randomSession(user.ID)
+	other := randomSession(user.ID)
+
+	testCases := []struct {
+		name          string
+		sessionID     uuid.UUID
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(t *testing.T, res *pb.RevokeOtherSessionsResponse, err error)
+	}{
+		{
+			name:      "OK",
+			sessionID: current.SessionID,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetUserByID(gomock.Any(), user.ID).
+					Times(1).
+					Return(user, nil)
+
+				store.EXPECT().
+					GetAllUserActiveSessions(gomock.Any(), user.ID).
+					Times(1).
+					Return([]db.UserSession{current, other}, nil)
+
+				arg := db.DeactivateOtherUserSessionsParams{
+					UserID:       user.ID,
+					SessionToken: current.SessionToken,
+				}
+				store.EXPECT().
+					DeactivateOtherUserSessions(gomock.Any(), gomock.Eq(arg)).
+					Times(1).
+					Return(nil)
+			},
+			checkResponse: func(t *testing.T, res *pb.RevokeOtherSessionsResponse, err error) {
+				require.NoError(t, err)
+				require.Equal(t, int64(1), res.RevokedCount)
+			},
+		},
+		{
+			name:      "CurrentSessionInactive",
+			sessionID: uuid.New(),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetUserByID(gomock.Any(), user.ID).
+					Times(1).
+					Return(user, nil)
+
+				store.EXPECT().
+					GetAllUserActiveSessions(gomock.Any(), user.ID).
+					Times(1).
+					Return([]db.UserSession{current, other}, nil)
+
+				store.EXPECT().
+					DeactivateOtherUserSessions(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, res *pb.RevokeOtherSessionsResponse, err error) {
+				require.Error(t, err)
+				st, ok := status.FromError(err)
+				require.True(t, ok)
+				require.Equal(t, codes.Unauthenticated, st.Code())
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			ctx := newContextWithBearerToken(t, server.tokenMaker, user, tc.sessionID, time.Minute, token.TokenTypeAccessToken)
+
+			res, err := server.RevokeOtherSessions(ctx, &pb.RevokeOtherSessionsRequest{})
+			tc.checkResponse(t, res, err)
+		})
+	}
+}
+
+// requireSessionRevoked checks that access tokens of the session are rejected by the denylist
+func requireSessionRevoked(t *testing.T, server *Server, user db.User, sessionID uuid.UUID, revoked bool) {
+	accessToken, _, err := server.tokenMaker.CreateToken(user.ID, user.Email, string(user.UserType), sessionID, time.Minute, token.TokenTypeAccessToken)
+	require.NoError(t, err)
+	payload, err := server.tokenMaker.VerifyToken(accessToken, token.TokenTypeAccessToken)
+	require.NoError(t, err)
+
+	isRevoked, err := server.denylist.IsRevoked(context.Background(), payload)
+	require.NoError(t, err)
+	require.Equal(t, revoked, isRevoked)
+}
+
+func TestRevokeSessionRevokesAccessTokens(t *testing.T) {
+	user, _ := randomUser(t, util.TenantRole)
+	user.ID = util.RandomInt(1, 1000)
+
+	current := randomSession(user.ID)
+	other := randomSession(user.ID)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	store := mockdb.NewMockStore(ctrl)
+	store.EXPECT().
+		GetUserByID(gomock.Any(), user.ID).
+		Times(1).
+		Return(user, nil)
+	store.EXPECT().
+		DeactivateUserSessionBySessionID(gomock.Any(), gomock.Any()).
+		Times(1).
+		Return([]db.UserSession{other}, nil)
+
+	server := newTestServer(t, store)
+	ctx := newContextWithBearerToken(t, server.tokenMaker, user, current.SessionID, time.Minute, token.TokenTypeAccessToken)
+
+	_, err := server.RevokeSession(ctx, &pb.RevokeSessionRequest{SessionId: other.SessionID.String()})
+	require.NoError(t, err)
+
+	requireSessionRevoked(t, server, user, other.SessionID, true)
+	requireSessionRevoked(t, server, user, current.SessionID, false)
+}
+
+func TestRevokeOtherSessionsRevokesAccessTokens(t *testing.T) {
+	user, _ := randomUser(t, util.TenantRole)
+	user.ID = util.RandomInt(1, 1000)
+
+	current := randomSession(user.ID)
+	other := randomSession(user.ID)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	store := mockdb.NewMockStore(ctrl)
+	store.EXPECT().
+		GetUserByID(gomock.Any(), user.ID).
+		Times(1).
+		Return(user, nil)
+	store.EXPECT().
+		GetAllUserActiveSessions(gomock.Any(), user.ID).
+		Times(1).
+		Return([]db.UserSession{current, other}, nil)
+	store.EXPECT().
+		DeactivateOtherUserSessions(gomock.Any(), gomock.Any()).
+		Times(1).
+		Return(nil)
+
+	server := newTestServer(t, store)
+	ctx := newContextWithBearerToken(t, server.tokenMaker, user, current.SessionID, time.Minute, token.TokenTypeAccessToken)
+
+	_, err := server.RevokeOtherSessions(ctx, &pb.RevokeOtherSessionsRequest{})
+	require.NoError(t, err)
+
+	requireSessionRevoked(t, server, user, other.SessionID, true)
+	requireSessionRevoked(t, server, user, current.SessionID, false)
+}
@@ -0,0 +1,109 @@
+package gapi
+
+import (
+	"context"
+	"errors"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	db "github.com/r-scheele/sqr/internal/db/sqlc"
+	"github.com/r-scheele/sqr/internal/pb"
+	"github.com/r-scheele/sqr/internal/token"
+	"github.com/r-scheele/sqr/internal/val"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (server *Server) VerifyMFA(ctx context.Context, req *pb.VerifyMFARequest) (*pb.VerifyMFAResponse, error) {
+	violations := validateVerifyMFARequest(req)
+	if violations != nil {
+		return nil, invalidArgumentError(violations)
+	}
+
+	mfaPayload, err := server.tokenMaker.VerifyToken(req.GetMfaToken(), token.TokenTypeMFAPending)
+	if err != nil {
+		return nil, status.Errorf(codes.Unauthenticated, "invalid mfa token")
+	}
+
+	revoked, err := server.denylist.IsRevoked(ctx, mfaPayload)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to check mfa token")
+	}
+	if revoked {
+		return nil, status.Errorf(codes.Unauthenticated, "mfa token has already been used")
+	}
+
+	user, err := server.store.GetUserByID(ctx, mfaPayload.UserID)
+	if err != nil {
+		if errors.Is(err, db.ErrRecordNotFound) {
+			return nil, status.Errorf(codes.Unauthenticated, "user not found")
+		}
+		return nil, status.Errorf(codes.Internal, "failed to find user")
+	}
+
+	err = server.checkAccountNotLocked(ctx, user.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	enrolment, err := server.getMFAEnrolment(ctx, user.ID)
+	if err != nil {
+		return nil, err
+	}
+	if enrolment == nil {
+		return nil, status.Errorf(codes.FailedPrecondition, "two-factor authentication is not enabled")
+	}
+
+	// Wrong codes count towards the account lockout just like wrong passwords
+	ok, err := server.useMFACode(ctx, enrolment, req.GetCode())
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		server.recordFailedLogin(ctx, user.Email, &user)
+		return nil, status.Errorf(codes.Unauthenticated, "invalid verification code")
+	}
+
+	err = server.denylist.RevokeToken(ctx, mfaPayload)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to revoke mfa token")
+	}
+
+	mtdt := server.extractMetadata(ctx)
+	_, err = server.store.RecordSuccessfulLoginTx(ctx, db.RecordSuccessfulLoginTxParams{
+		UserID:    user.ID,
+		IpAddress: pgtype.Text{String: mtdt.ClientIP, Valid: mtdt.ClientIP != ""},
+		UserAgent: pgtype.Text{String: mtdt.UserAgent, Valid: mtdt.UserAgent != ""},
+	})
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to record login")
+	}
+
+	session, err := server.createLoginSession(ctx, user, mfaPayload.SessionID)
+	if err != nil {
+		return nil, err
+	}
+
+	rsp := &pb.VerifyMFAResponse{
+		User:                  session.User,
+		SessionId:             session.SessionId,
+		AccessToken:           session.AccessToken,
+		RefreshToken:          session.RefreshToken,
+		AccessTokenExpiresAt:  session.AccessTokenExpiresAt,
+		RefreshTokenExpiresAt: session.RefreshTokenExpiresAt,
+		RecoveryCodesLeft:     int32(len(enrolment.Data.RecoveryCodes)),
+	}
+	return rsp, nil
+}
+
+func validateVerifyMFARequest(req *pb.VerifyMFARequest) (violations []*errdetails.BadRequest_FieldViolation) {
+	if req.GetMfaToken() == "" {
+		violations = append(violations, fieldViolation("mfa_token", errors.New("mfa token is required")))
+	}
+
+	if err := val.ValidateMFACode(req.GetCode()); err != nil {
+		violations = append(violations, fieldViolation("code", err))
+	}
+
+	return violations
+}
@@ -3,11 +3,15 @@ package gapi
 import (
 	"fmt"
 
+	"github.com/r-scheele/sqr/internal/blob"
+	"github.com/r-scheele/sqr/internal/cache"
 	db "github.com/r-scheele/sqr/internal/db/sqlc"
+	"github.com/r-scheele/sqr/internal/oidc"
 	"github.com/r-scheele/sqr/internal/pb"
 	"github.com/r-scheele/sqr/internal/ratelimit"
 	"github.com/r-scheele/sqr/internal/token"
 	"github.com/r-scheele/sqr/internal/util"
+	"github.com/r-scheele/sqr/internal/val"
 	"github.com/r-scheele/sqr/internal/worker"
 )
 
@@ -17,23 +21,63 @@ type Server struct {
 	config          util.Config
 	store           db.Store
 	tokenMaker      token.Maker
+	denylist        token.Denylist
 	taskDistributor worker.TaskDistributor
 	rateLimiter     *ratelimit.GRPCRateLimiter
+	oidcProviders   map[string]oidc.OIDCProvider // keyed by provider name, only the configured ones
+	passwordPolicy  val.PasswordPolicy
+	passwordHasher  util.PasswordHasher
+	blobStore       blob.BlobStore
+	downloadSigner  *token.DownloadSigner
 }
 
 // NewServer creates a new gRPC server.
-func NewServer(config util.Config, store db.Store, taskDistributor worker.TaskDistributor, rateLimiter *ratelimit.GRPCRateLimiter) (*Server, error) {
+func NewServer(config util.Config, store db.Store, cacheManager cache.CacheManager, taskDistributor worker.TaskDistributor, rateLimiter *ratelimit.GRPCRateLimiter) (*Server, error) {
 	tokenMaker, err := token.NewPasetoMaker(config.TokenSymmetricKey)
 	if err != nil {
 		return nil, fmt.Errorf("cannot create token maker: %w", err)
 	}
 
+	passwordPolicy, err := newPasswordPolicy(config)
+	if err != nil {
+		return nil, err
+	}
+
+	passwordHasher, err := util.NewPasswordHasher(config.PasswordHashAlgorithm, config.PasswordBcryptCost)
+	if err != nil {
+		return nil, fmt.Errorf("cannot create password hasher: %w", err)
+	}
+
+	blobStore, err := blob.NewBlobStore(config.BlobStoreType, config.BlobStorePath)
+	if err != nil {
+		return nil, fmt.Errorf("cannot create blob store: %w", err)
+	}
+
+	downloadSigner, err := token.NewDownloadSigner(config.TokenSymmetricKey)
+	if err != nil {
+		return nil, fmt.Errorf("cannot create download signer: %w", err)
+	}
+
 	server := &Server{
 		config:          config,
 		store:           store,
 		tokenMaker:      tokenMaker,
+		denylist:        token.NewCacheDenylist(cacheManager),
 		taskDistributor: taskDistributor,
 		rateLimiter:     rateLimiter,
+		oidcProviders:   make(map[string]oidc.OIDCProvider),
+		passwordPolicy:  passwordPolicy,
+		passwordHasher:  passwordHasher,
+		blobStore:       blobStore,
+		downloadSigner:  downloadSigner,
+	}
+
+	if config.GoogleClientID != "" {
+		google, err := oidc.NewGoogleProvider(config.GoogleClientID, config.GoogleClientSecret, config.GoogleJWKSURL, config.GoogleTokenURL)
+		if err != nil {
+			return nil, fmt.Errorf("cannot create google sign-in provider: %w", err)
+		}
+		server.oidcProviders[google.Name()] = google
 	}
 
 	return server, nil
@@ -0,0 +1,54 @@
+package blob
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestLocalStore(t *testing.T) {
+	store := NewLocalStore(t.TempDir())
+	ctx := context.Background()
+	key := "documents/42/payslip"
+
+	_, err := store.Get(ctx, key)
+	require.ErrorIs(t, err, ErrBlobNotFound)
+
+	require.NoError(t, store.Put(ctx, key, []byte("first")))
+	require.NoError(t, store.Put(ctx, key, []byte("second")))
+
+	content, err := store.Get(ctx, key)
+	require.NoError(t, err)
+	require.Equal(t, []byte("second"), content)
+
+	require.NoError(t, store.Delete(ctx, key))
+	require.NoError(t, store.Delete(ctx, key))
+
+	_, err = store.Get(ctx, key)
+	require.ErrorIs(t, err, ErrBlobNotFound)
+}
+
+func TestStoreRejectsEscapingKeys(t *testing.T) {
+	stores := []BlobStore{NewLocalStore(t.TempDir()), NewMemoryStore()}
+	keys := []string{"", "/etc/passwd", "../outside", "documents/../../outside", "documents//42", `documents\42`}
+
+	for _, store := range stores {
+		for _, key := range keys {
+			require.Error(t, store.Put(context.Background(), key, []byte("content")), key)
+		}
+	}
+}
+
+func TestNewBlobStore(t *testing.T) {
+	store, err := NewBlobStore("", "")
+	require.NoError(t, err)
+	require.IsType(t, &LocalStore{}, store)
+
+	store, err = NewBlobStore("memory", "")
+	require.NoError(t, err)
+	require.IsType(t, &MemoryStore{}, store)
+
+	_, err = NewBlobStore("s3", "")
+	require.Error(t, err)
+}
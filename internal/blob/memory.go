@@ -0,0 +1,49 @@
+package blob
+
+import (
+	"context"
+	"sync"
+)
+
+// MemoryStore keeps blobs in memory. Content is lost on restart, so it is meant for development and tests.
+type MemoryStore struct {
+	blobs map[string][]byte
+	mutex sync.RWMutex
+}
+
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{
+		blobs: make(map[string][]byte),
+	}
+}
+
+func (store *MemoryStore) Put(ctx context.Context, key string, content []byte) error {
+	if err := validateKey(key); err != nil {
+		return err
+	}
+
+	store.mutex.Lock()
+	defer store.mutex.Unlock()
+
+	store.blobs[key] = append([]byte(nil), content...)
+	return nil
+}
+
+func (store *MemoryStore) Get(ctx context.Context, key string) ([]byte, error) {
+	store.mutex.RLock()
+	defer store.mutex.RUnlock()
+
+	content, ok := store.blobs[key]
+	if !ok {
+		return nil, ErrBlobNotFound
+	}
+	return append([]byte(nil), content...), nil
+}
+
+func (store *MemoryStore) Delete(ctx context.Context, key string) error {
+	store.mutex.Lock()
+	defer store.mutex.Unlock()
+
+	delete(store.blobs, key)
+	return nil
+}
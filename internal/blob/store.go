@@ -0,0 +1,51 @@
+package blob
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ErrBlobNotFound is returned by Get for keys that were never stored or have been deleted
+var ErrBlobNotFound = errors.New("blob: not found")
+
+// DefaultLocalPath is where the local store keeps its files when BLOB_STORE_PATH is not set
+const DefaultLocalPath = "data/blobs"
+
+// BlobStore keeps file content outside the database. Keys are slash separated paths such as
+// "documents/42/<uuid>" and are chosen by the caller.
+type BlobStore interface {
+	Put(ctx context.Context, key string, content []byte) error
+	Get(ctx context.Context, key string) ([]byte, error)
+	// Delete removes the content of key; deleting a missing key is not an error
+	Delete(ctx context.Context, key string) error
+}
+
+// NewBlobStore returns the store configured by storeType: "memory", or "local" (the default) rooted at path
+func NewBlobStore(storeType string, path string) (BlobStore, error) {
+	switch strings.ToLower(storeType) {
+	case "", "local":
+		if path == "" {
+			path = DefaultLocalPath
+		}
+		return NewLocalStore(path), nil
+	case "memory":
+		return NewMemoryStore(), nil
+	default:
+		return nil, fmt.Errorf("unsupported blob store type: %s", storeType)
+	}
+}
+
+// validateKey rejects keys that could escape the root of a store
+func validateKey(key string) error {
+	if key == "" || strings.HasPrefix(key, "/") || strings.Contains(key, "\\") {
+		return fmt.Errorf("blob: invalid key %q", key)
+	}
+	for _, part := range strings.Split(key, "/") {
+		if part == "" || part == "." || part == ".." {
+			return fmt.Errorf("blob: invalid key %q", key)
+		}
+	}
+	return nil
+}
@@ -2,6 +2,9 @@
 package cache
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
 	"fmt"
 	"time"
 )
@@ -49,25 +52,45 @@ func TenantProfileKey(userID int64) string {
 	return fmt.Sprintf("tenant_profile:user:%d", userID)
 }
 
+func LandlordProfileKey(userID int64) string {
+	return fmt.Sprintf("landlord_profile:user:%d", userID)
+}
+
+func RevokedTokenKey(tokenID string) string {
+	return fmt.Sprintf("revoked:token:%s", tokenID)
+}
+
+func RevokedSessionKey(sessionID string) string {
+	return fmt.Sprintf("revoked:session:%s", sessionID)
+}
+
+func RevokedUserKey(userID int64) string {
+	return fmt.Sprintf("revoked:user:%d", userID)
+}
+
 // Tags for cache invalidation
 const (
-	UserTag          = "user"
-	PropertyTag      = "property"
-	SearchTag        = "search"
-	SessionTag       = "session"
-	VerificationTag  = "verification"
-	StatsTag         = "stats"
-	TenantProfileTag = "tenant_profile"
+	UserTag            = "user"
+	PropertyTag        = "property"
+	SearchTag          = "search"
+	SessionTag         = "session"
+	VerificationTag    = "verification"
+	StatsTag           = "stats"
+	TenantProfileTag   = "tenant_profile"
+	LandlordProfileTag = "landlord_profile"
 )
 
-func hashParams(query string, filters map[string]interface{}) string {
-	return fmt.Sprintf("%d", hash(query+fmt.Sprintf("%v", filters)))
+// SearchHash is the hex SHA-256 of normalized search parameters
+func SearchHash(params []byte) string {
+	sum := sha256.Sum256(params)
+	return hex.EncodeToString(sum[:])
 }
 
-func hash(s string) uint32 {
-	h := uint32(0)
-	for _, c := range s {
-		h = h*31 + uint32(c)
+// hashParams marshals filters with sorted keys, so equal filters hash the same whatever order they were set in
+func hashParams(query string, filters map[string]interface{}) string {
+	params, err := json.Marshal(filters)
+	if err != nil {
+		params = []byte(fmt.Sprintf("%v", filters))
 	}
-	return h
+	return SearchHash(append([]byte(query+":"), params...))
 }
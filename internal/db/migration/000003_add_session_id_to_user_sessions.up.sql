@@ -0,0 +1,3 @@
+ALTER TABLE "user_sessions" ADD COLUMN "session_id" uuid NOT NULL DEFAULT (gen_random_uuid());
+
+CREATE INDEX ON "user_sessions" ("session_id");
@@ -0,0 +1,3 @@
+ALTER TABLE "user_sessions" DROP COLUMN IF EXISTS "replaced_by";
+
+ALTER TABLE "user_sessions" DROP COLUMN IF EXISTS "rotated_at";
@@ -0,0 +1,5 @@
+ALTER TABLE "user_sessions" ADD COLUMN "rotated_at" timestamptz;
+
+ALTER TABLE "user_sessions" ADD COLUMN "replaced_by" bigint;
+
+ALTER TABLE "user_sessions" ADD FOREIGN KEY ("replaced_by") REFERENCES "user_sessions" ("id") ON DELETE SET NULL;
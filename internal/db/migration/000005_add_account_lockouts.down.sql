@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS "audit_logs_action_entity_type_ip_address_created_at_idx";
+
+DROP TABLE IF EXISTS "account_lockouts";
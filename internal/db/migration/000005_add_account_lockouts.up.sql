@@ -0,0 +1,11 @@
+CREATE TABLE "account_lockouts" (
+  "user_id" bigint PRIMARY KEY,
+  "failed_attempts" int NOT NULL DEFAULT 0,
+  "lockout_count" int NOT NULL DEFAULT 0,
+  "locked_until" timestamptz,
+  "updated_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+ALTER TABLE "account_lockouts" ADD FOREIGN KEY ("user_id") REFERENCES "users" ("id") ON DELETE CASCADE;
+
+CREATE INDEX ON "audit_logs" ("action", "entity_type", "ip_address", "created_at");
@@ -0,0 +1 @@
+ALTER TYPE verification_type_enum ADD VALUE 'totp';
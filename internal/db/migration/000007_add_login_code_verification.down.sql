@@ -0,0 +1,3 @@
+-- This migration cannot be easily reversed as PostgreSQL doesn't support removing enum values
+-- Remove the login codes so nothing depends on the value any more
+DELETE FROM user_verifications WHERE verification_type = 'login_code';
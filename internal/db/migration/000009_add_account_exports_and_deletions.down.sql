@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS "account_deletions";
+DROP TABLE IF EXISTS "account_exports";
+DROP TYPE IF EXISTS account_request_status_enum;
@@ -0,0 +1,32 @@
+CREATE TYPE account_request_status_enum AS ENUM ('pending', 'completed', 'cancelled', 'failed');
+
+CREATE TABLE "account_exports" (
+  "id" bigserial PRIMARY KEY,
+  "user_id" bigint NOT NULL,
+  "status" account_request_status_enum NOT NULL DEFAULT 'pending',
+  "file_name" varchar,
+  "content" bytea,
+  "expires_at" timestamptz,
+  "created_at" timestamptz NOT NULL DEFAULT (now()),
+  "completed_at" timestamptz
+);
+
+CREATE TABLE "account_deletions" (
+  "id" bigserial PRIMARY KEY,
+  "user_id" bigint NOT NULL,
+  "status" account_request_status_enum NOT NULL DEFAULT 'pending',
+  "reason" text,
+  "scheduled_for" timestamptz NOT NULL,
+  "created_at" timestamptz NOT NULL DEFAULT (now()),
+  "completed_at" timestamptz,
+  "cancelled_at" timestamptz
+);
+
+ALTER TABLE "account_exports" ADD FOREIGN KEY ("user_id") REFERENCES "users" ("id") ON DELETE CASCADE;
+
+ALTER TABLE "account_deletions" ADD FOREIGN KEY ("user_id") REFERENCES "users" ("id");
+
+CREATE INDEX ON "account_exports" ("user_id", "created_at");
+
+-- A user can only have one deletion waiting for its grace period to end
+CREATE UNIQUE INDEX ON "account_deletions" ("user_id") WHERE "status" = 'pending';
@@ -0,0 +1 @@
+DROP INDEX IF EXISTS "landlord_profiles_user_id_key";
@@ -0,0 +1,17 @@
+-- Landlord profiles are now created with the account, a second one for the same user is a bug.
+-- Keep the most complete profile of each user, the most recently updated one on a tie.
+DELETE FROM "landlord_profiles" lp
+USING (
+  SELECT "id", row_number() OVER (
+    PARTITION BY "user_id"
+    ORDER BY num_nonnulls(
+      NULLIF("business_name", ''), NULLIF("business_registration", ''), NULLIF("tax_id", ''),
+      NULLIF("bank_name", ''), NULLIF("bank_account", ''), NULLIF("bank_account_name", ''),
+      NULLIF("guarantor_name", ''), NULLIF("guarantor_phone", ''), NULLIF("guarantor_address", '')
+    ) DESC, "updated_at" DESC NULLS LAST, "id" DESC
+  ) AS "rank"
+  FROM "landlord_profiles"
+) ranked
+WHERE lp."id" = ranked."id" AND ranked."rank" > 1;
+
+CREATE UNIQUE INDEX "landlord_profiles_user_id_key" ON "landlord_profiles" ("user_id");
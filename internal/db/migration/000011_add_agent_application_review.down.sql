@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS "inspection_agent_profiles_user_id_key";
+
+ALTER TABLE "inspection_agent_profiles" DROP COLUMN IF EXISTS "rejection_reason";
+ALTER TABLE "inspection_agent_profiles" DROP COLUMN IF EXISTS "rejected_at";
+ALTER TABLE "inspection_agent_profiles" DROP COLUMN IF EXISTS "submitted_at";
@@ -0,0 +1,24 @@
+ALTER TABLE "inspection_agent_profiles" ADD COLUMN "submitted_at" timestamptz;
+ALTER TABLE "inspection_agent_profiles" ADD COLUMN "rejected_at" timestamptz;
+ALTER TABLE "inspection_agent_profiles" ADD COLUMN "rejection_reason" text;
+
+-- Applications are upserted per agent, so only one profile of each user is kept:
+-- an approved one first, then the most complete, then the most recently updated
+DELETE FROM "inspection_agent_profiles" iap
+USING (
+  SELECT "id", row_number() OVER (
+    PARTITION BY "user_id"
+    ORDER BY COALESCE("is_approved", false) DESC,
+      num_nonnulls(
+        NULLIF("license_number", ''), NULLIF("specializations", ''), NULLIF("service_areas", ''),
+        "hourly_rate", NULLIF("availability_schedule", ''),
+        NULLIF("bank_name", ''), NULLIF("bank_account", ''), NULLIF("bank_account_name", '')
+      ) DESC, "updated_at" DESC NULLS LAST, "id" DESC
+  ) AS "rank"
+  FROM "inspection_agent_profiles"
+) ranked
+WHERE iap."id" = ranked."id" AND ranked."rank" > 1;
+
+CREATE UNIQUE INDEX "inspection_agent_profiles_user_id_key" ON "inspection_agent_profiles" ("user_id");
+
+CREATE INDEX ON "inspection_agent_profiles" ("submitted_at") WHERE "is_approved" = false AND "rejected_at" IS NULL;
@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS "inspection_requests_inspection_agent_id_confirmed_date_idx";
+DROP TABLE IF EXISTS "agent_availability_exceptions";
+DROP TABLE IF EXISTS "agent_availability_windows";
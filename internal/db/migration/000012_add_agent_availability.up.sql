@@ -0,0 +1,36 @@
+-- Recurring weekly windows, in Africa/Lagos time. day_of_week follows EXTRACT(DOW): 0 is Sunday.
+CREATE TABLE "agent_availability_windows" (
+  "id" bigserial PRIMARY KEY,
+  "agent_id" bigint NOT NULL,
+  "day_of_week" smallint NOT NULL CHECK ("day_of_week" BETWEEN 0 AND 6),
+  "start_time" time NOT NULL,
+  "end_time" time NOT NULL,
+  "created_at" timestamptz NOT NULL DEFAULT (now()),
+  CHECK ("start_time" < "end_time")
+);
+
+-- Changes to the weekly windows for a range of dates. Without times an exception covers whole days,
+-- which is how blackout dates are stored.
+CREATE TABLE "agent_availability_exceptions" (
+  "id" bigserial PRIMARY KEY,
+  "agent_id" bigint NOT NULL,
+  "start_date" date NOT NULL,
+  "end_date" date NOT NULL,
+  "start_time" time,
+  "end_time" time,
+  "is_available" boolean NOT NULL DEFAULT false,
+  "reason" text,
+  "created_at" timestamptz NOT NULL DEFAULT (now()),
+  CHECK ("start_date" <= "end_date"),
+  CHECK (("start_time" IS NULL AND "end_time" IS NULL) OR "start_time" < "end_time")
+);
+
+ALTER TABLE "agent_availability_windows" ADD FOREIGN KEY ("agent_id") REFERENCES "users" ("id") ON DELETE CASCADE;
+
+ALTER TABLE "agent_availability_exceptions" ADD FOREIGN KEY ("agent_id") REFERENCES "users" ("id") ON DELETE CASCADE;
+
+CREATE INDEX ON "agent_availability_windows" ("agent_id");
+
+CREATE INDEX ON "agent_availability_exceptions" ("agent_id", "start_date", "end_date");
+
+CREATE INDEX ON "inspection_requests" ("inspection_agent_id", "confirmed_date");
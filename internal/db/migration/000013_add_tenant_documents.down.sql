@@ -0,0 +1,9 @@
+ALTER TABLE "rental_applications" ADD COLUMN "application_documents" text;
+UPDATE "rental_applications" ra
+SET "application_documents" = ld."application_documents"
+FROM "rental_application_legacy_documents" ld
+WHERE ld."rental_application_id" = ra."id";
+DROP TABLE IF EXISTS "rental_application_legacy_documents";
+DROP TABLE IF EXISTS "document_access_grants";
+DROP TABLE IF EXISTS "tenant_documents";
+DROP TYPE IF EXISTS document_type_enum;
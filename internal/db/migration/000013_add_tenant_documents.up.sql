@@ -0,0 +1,57 @@
+CREATE TYPE document_type_enum AS ENUM ('government_id', 'payslip', 'bank_statement', 'employment_letter', 'reference_letter', 'other');
+
+-- Files a tenant uploaded. The content lives in the blob store under storage_key.
+CREATE TABLE "tenant_documents" (
+  "id" bigserial PRIMARY KEY,
+  "owner_id" bigint NOT NULL,
+  "document_type" document_type_enum NOT NULL,
+  "file_name" varchar(255) NOT NULL,
+  "content_type" varchar(100) NOT NULL,
+  "size_bytes" bigint NOT NULL CHECK ("size_bytes" > 0),
+  "checksum" varchar(64) NOT NULL,
+  "storage_key" varchar UNIQUE NOT NULL,
+  "created_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+-- A document shared with the landlord of one rental application. The grant is how an application
+-- references its documents, and access ends when it is revoked or the application is withdrawn or rejected.
+CREATE TABLE "document_access_grants" (
+  "id" bigserial PRIMARY KEY,
+  "document_id" bigint NOT NULL,
+  "rental_application_id" bigint NOT NULL,
+  "grantee_id" bigint NOT NULL,
+  "revoked_at" timestamptz,
+  "created_at" timestamptz NOT NULL DEFAULT (now()),
+  UNIQUE ("document_id", "rental_application_id")
+);
+
+ALTER TABLE "tenant_documents" ADD FOREIGN KEY ("owner_id") REFERENCES "users" ("id") ON DELETE CASCADE;
+
+ALTER TABLE "document_access_grants" ADD FOREIGN KEY ("document_id") REFERENCES "tenant_documents" ("id") ON DELETE CASCADE;
+
+ALTER TABLE "document_access_grants" ADD FOREIGN KEY ("rental_application_id") REFERENCES "rental_applications" ("id") ON DELETE CASCADE;
+
+ALTER TABLE "document_access_grants" ADD FOREIGN KEY ("grantee_id") REFERENCES "users" ("id") ON DELETE CASCADE;
+
+CREATE INDEX ON "tenant_documents" ("owner_id", "created_at");
+
+CREATE INDEX ON "document_access_grants" ("rental_application_id");
+
+CREATE INDEX ON "document_access_grants" ("grantee_id", "document_id");
+
+-- Applications now reference documents through document_access_grants. What applicants wrote in the old
+-- free-text column can't be turned into uploaded files, so it is archived rather than lost.
+CREATE TABLE "rental_application_legacy_documents" (
+  "rental_application_id" bigint PRIMARY KEY,
+  "application_documents" text NOT NULL,
+  "archived_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+ALTER TABLE "rental_application_legacy_documents" ADD FOREIGN KEY ("rental_application_id") REFERENCES "rental_applications" ("id") ON DELETE CASCADE;
+
+INSERT INTO "rental_application_legacy_documents" ("rental_application_id", "application_documents")
+SELECT "id", "application_documents"
+FROM "rental_applications"
+WHERE "application_documents" IS NOT NULL AND "application_documents" <> '';
+
+ALTER TABLE "rental_applications" DROP COLUMN "application_documents";
@@ -0,0 +1,2 @@
+ALTER TABLE "properties" ADD COLUMN "pet_friendly" boolean NOT NULL DEFAULT false;
+
@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS "properties_coordinates_idx";
+DROP INDEX IF EXISTS "properties_location_idx";
+DROP EXTENSION IF EXISTS earthdistance;
+DROP EXTENSION IF EXISTS cube;
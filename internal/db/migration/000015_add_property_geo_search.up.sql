@@ -0,0 +1,8 @@
+CREATE EXTENSION IF NOT EXISTS cube;
+CREATE EXTENSION IF NOT EXISTS earthdistance;
+
+-- Radius search looks properties up by their point on the earth
+CREATE INDEX "properties_location_idx" ON "properties" USING gist (ll_to_earth("latitude"::float8, "longitude"::float8));
+
+-- Map viewports look properties up by a range of coordinates
+CREATE INDEX "properties_coordinates_idx" ON "properties" ("latitude", "longitude") WHERE "status" = 'active' AND "is_available" = true;
@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS "properties_search_vector_idx";
+DROP FUNCTION IF EXISTS property_search_query(text);
+DROP FUNCTION IF EXISTS property_search_vector(text, text, text, text, text);
+DROP EXTENSION IF EXISTS pg_trgm;
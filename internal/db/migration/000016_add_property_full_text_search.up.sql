@@ -0,0 +1,18 @@
+CREATE EXTENSION IF NOT EXISTS pg_trgm;
+
+-- The weighted document that free-text search matches a property against
+CREATE FUNCTION property_search_vector(title text, description text, address text, city text, amenities text)
+RETURNS tsvector LANGUAGE sql IMMUTABLE PARALLEL SAFE AS $$
+  SELECT setweight(to_tsvector('english', COALESCE(title, '')), 'A') ||
+    setweight(to_tsvector('english', COALESCE(city, '') || ' ' || COALESCE(address, '')), 'B') ||
+    setweight(to_tsvector('english', COALESCE(amenities, '')), 'C') ||
+    setweight(to_tsvector('english', COALESCE(description, '')), 'D')
+$$;
+
+-- Match any word of a free-text query, leaving it to ranking to prefer the properties that match more of them
+CREATE FUNCTION property_search_query(query text)
+RETURNS tsquery LANGUAGE sql IMMUTABLE PARALLEL SAFE AS $$
+  SELECT replace(plainto_tsquery('english', query)::text, ' & ', ' | ')::tsquery
+$$;
+
+CREATE INDEX "properties_search_vector_idx" ON "properties" USING gin (property_search_vector("title", "description", "address", "city", "amenities"));
@@ -0,0 +1,2 @@
+ALTER TABLE "property_search_cache" DROP COLUMN IF EXISTS "last_searched_at";
+ALTER TABLE "property_search_cache" DROP COLUMN IF EXISTS "search_count";
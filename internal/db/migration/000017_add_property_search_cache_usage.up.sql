@@ -0,0 +1,5 @@
+-- A cache entry is one page of one search; search_count counts how often it was served, from the database or from cache
+ALTER TABLE "property_search_cache" ADD COLUMN "search_count" integer NOT NULL DEFAULT 1;
+ALTER TABLE "property_search_cache" ADD COLUMN "last_searched_at" timestamptz NOT NULL DEFAULT (now());
+
+CREATE INDEX ON "property_search_cache" ("last_searched_at");
@@ -0,0 +1 @@
+DROP INDEX IF EXISTS "properties_area_trgm_idx";
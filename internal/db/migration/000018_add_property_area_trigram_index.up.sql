@@ -0,0 +1,2 @@
+-- Typo-tolerant area matching compares search terms against exactly this expression with <%
+CREATE INDEX "properties_area_trgm_idx" ON "properties" USING gin (("city" || ' ' || "state" || ' ' || "address") gin_trgm_ops);
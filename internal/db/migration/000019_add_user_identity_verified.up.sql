@@ -0,0 +1,11 @@
+-- is_verified is the email flag, a verified NIN is recorded on its own
+ALTER TABLE "users" ADD COLUMN "is_identity_verified" boolean NOT NULL DEFAULT false;
+
+-- Users whose latest NIN verification already succeeded
+UPDATE "users" u SET "is_identity_verified" = true
+WHERE (
+  SELECT "verification_status" FROM "user_verifications" v
+  WHERE v."user_id" = u."id" AND v."verification_type" = 'nin'
+  ORDER BY v."created_at" DESC, v."id" DESC
+  LIMIT 1
+) = 'verified';
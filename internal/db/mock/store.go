@@ -7,6 +7,7 @@ package mockdb
 import (
 	context "context"
 	reflect "reflect"
+	time "time"
 
 	gomock "github.com/golang/mock/gomock"
 	pgtype "github.com/jackc/pgx/v5/pgtype"
@@ -81,6 +82,63 @@ func (mr *MockStoreMockRecorder) AddResponseToRating(arg0, arg1 interface{}) *go
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddResponseToRating", reflect.TypeOf((*MockStore)(nil).AddResponseToRating), arg0, arg1)
 }
 
+// AnonymizeAccountTx mocks base method.
+func (m *MockStore) AnonymizeAccountTx(arg0 context.Context, arg1 db.AnonymizeAccountTxParams) (db.AnonymizeAccountTxResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AnonymizeAccountTx", arg0, arg1)
+	ret0, _ := ret[0].(db.AnonymizeAccountTxResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AnonymizeAccountTx indicates an expected call of AnonymizeAccountTx.
+func (mr *MockStoreMockRecorder) AnonymizeAccountTx(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AnonymizeAccountTx", reflect.TypeOf((*MockStore)(nil).AnonymizeAccountTx), arg0, arg1)
+}
+
+// AnonymizeInspectionAgentProfile mocks base method.
+func (m *MockStore) AnonymizeInspectionAgentProfile(arg0 context.Context, arg1 int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AnonymizeInspectionAgentProfile", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AnonymizeInspectionAgentProfile indicates an expected call of AnonymizeInspectionAgentProfile.
+func (mr *MockStoreMockRecorder) AnonymizeInspectionAgentProfile(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AnonymizeInspectionAgentProfile", reflect.TypeOf((*MockStore)(nil).AnonymizeInspectionAgentProfile), arg0, arg1)
+}
+
+// AnonymizeLandlordProfile mocks base method.
+func (m *MockStore) AnonymizeLandlordProfile(arg0 context.Context, arg1 int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AnonymizeLandlordProfile", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AnonymizeLandlordProfile indicates an expected call of AnonymizeLandlordProfile.
+func (mr *MockStoreMockRecorder) AnonymizeLandlordProfile(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AnonymizeLandlordProfile", reflect.TypeOf((*MockStore)(nil).AnonymizeLandlordProfile), arg0, arg1)
+}
+
+// AnonymizeUser mocks base method.
+func (m *MockStore) AnonymizeUser(arg0 context.Context, arg1 db.AnonymizeUserParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AnonymizeUser", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AnonymizeUser indicates an expected call of AnonymizeUser.
+func (mr *MockStoreMockRecorder) AnonymizeUser(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AnonymizeUser", reflect.TypeOf((*MockStore)(nil).AnonymizeUser), arg0, arg1)
+}
+
 // ApproveInspectionAgent mocks base method.
 func (m *MockStore) ApproveInspectionAgent(arg0 context.Context, arg1 db.ApproveInspectionAgentParams) (db.InspectionAgentProfile, error) {
 	m.ctrl.T.Helper()
@@ -156,6 +214,21 @@ func (mr *MockStoreMockRecorder) AssignInspectionAgent(arg0, arg1 interface{}) *
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AssignInspectionAgent", reflect.TypeOf((*MockStore)(nil).AssignInspectionAgent), arg0, arg1)
 }
 
+// CancelAccountDeletion mocks base method.
+func (m *MockStore) CancelAccountDeletion(arg0 context.Context, arg1 int64) (db.AccountDeletion, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CancelAccountDeletion", arg0, arg1)
+	ret0, _ := ret[0].(db.AccountDeletion)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CancelAccountDeletion indicates an expected call of CancelAccountDeletion.
+func (mr *MockStoreMockRecorder) CancelAccountDeletion(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CancelAccountDeletion", reflect.TypeOf((*MockStore)(nil).CancelAccountDeletion), arg0, arg1)
+}
+
 // CancelInspection mocks base method.
 func (m *MockStore) CancelInspection(arg0 context.Context, arg1 db.CancelInspectionParams) (db.InspectionRequest, error) {
 	m.ctrl.T.Helper()
@@ -186,6 +259,36 @@ func (mr *MockStoreMockRecorder) CancelPayment(arg0, arg1 interface{}) *gomock.C
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CancelPayment", reflect.TypeOf((*MockStore)(nil).CancelPayment), arg0, arg1)
 }
 
+// ChangePropertyStatusTx mocks base method.
+func (m *MockStore) ChangePropertyStatusTx(arg0 context.Context, arg1 db.ChangePropertyStatusTxParams) (db.ChangePropertyStatusTxResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ChangePropertyStatusTx", arg0, arg1)
+	ret0, _ := ret[0].(db.ChangePropertyStatusTxResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ChangePropertyStatusTx indicates an expected call of ChangePropertyStatusTx.
+func (mr *MockStoreMockRecorder) ChangePropertyStatusTx(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ChangePropertyStatusTx", reflect.TypeOf((*MockStore)(nil).ChangePropertyStatusTx), arg0, arg1)
+}
+
+// ChangeUserTypeTx mocks base method.
+func (m *MockStore) ChangeUserTypeTx(arg0 context.Context, arg1 db.ChangeUserTypeTxParams) (db.ChangeUserTypeTxResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ChangeUserTypeTx", arg0, arg1)
+	ret0, _ := ret[0].(db.ChangeUserTypeTxResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ChangeUserTypeTx indicates an expected call of ChangeUserTypeTx.
+func (mr *MockStoreMockRecorder) ChangeUserTypeTx(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ChangeUserTypeTx", reflect.TypeOf((*MockStore)(nil).ChangeUserTypeTx), arg0, arg1)
+}
+
 // CheckRatingExists mocks base method.
 func (m *MockStore) CheckRatingExists(arg0 context.Context, arg1 db.CheckRatingExistsParams) (bool, error) {
 	m.ctrl.T.Helper()
@@ -330,6 +433,21 @@ func (mr *MockStoreMockRecorder) CleanupOldSessions(arg0, arg1 interface{}) *gom
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CleanupOldSessions", reflect.TypeOf((*MockStore)(nil).CleanupOldSessions), arg0, arg1)
 }
 
+// CleanupStaleSearchCache mocks base method.
+func (m *MockStore) CleanupStaleSearchCache(arg0 context.Context, arg1 time.Time) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CleanupStaleSearchCache", arg0, arg1)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CleanupStaleSearchCache indicates an expected call of CleanupStaleSearchCache.
+func (mr *MockStoreMockRecorder) CleanupStaleSearchCache(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CleanupStaleSearchCache", reflect.TypeOf((*MockStore)(nil).CleanupStaleSearchCache), arg0, arg1)
+}
+
 // CloseDispute mocks base method.
 func (m *MockStore) CloseDispute(arg0 context.Context, arg1 db.CloseDisputeParams) (db.DisputeCase, error) {
 	m.ctrl.T.Helper()
@@ -345,6 +463,51 @@ func (mr *MockStoreMockRecorder) CloseDispute(arg0, arg1 interface{}) *gomock.Ca
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CloseDispute", reflect.TypeOf((*MockStore)(nil).CloseDispute), arg0, arg1)
 }
 
+// ClusterPropertiesInBounds mocks base method.
+func (m *MockStore) ClusterPropertiesInBounds(arg0 context.Context, arg1 db.ClusterPropertiesInBoundsParams) ([]db.ClusterPropertiesInBoundsRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ClusterPropertiesInBounds", arg0, arg1)
+	ret0, _ := ret[0].([]db.ClusterPropertiesInBoundsRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ClusterPropertiesInBounds indicates an expected call of ClusterPropertiesInBounds.
+func (mr *MockStoreMockRecorder) ClusterPropertiesInBounds(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClusterPropertiesInBounds", reflect.TypeOf((*MockStore)(nil).ClusterPropertiesInBounds), arg0, arg1)
+}
+
+// CompleteAccountDeletion mocks base method.
+func (m *MockStore) CompleteAccountDeletion(arg0 context.Context, arg1 int64) (db.AccountDeletion, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CompleteAccountDeletion", arg0, arg1)
+	ret0, _ := ret[0].(db.AccountDeletion)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CompleteAccountDeletion indicates an expected call of CompleteAccountDeletion.
+func (mr *MockStoreMockRecorder) CompleteAccountDeletion(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CompleteAccountDeletion", reflect.TypeOf((*MockStore)(nil).CompleteAccountDeletion), arg0, arg1)
+}
+
+// CompleteAccountExport mocks base method.
+func (m *MockStore) CompleteAccountExport(arg0 context.Context, arg1 db.CompleteAccountExportParams) (db.AccountExport, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CompleteAccountExport", arg0, arg1)
+	ret0, _ := ret[0].(db.AccountExport)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CompleteAccountExport indicates an expected call of CompleteAccountExport.
+func (mr *MockStoreMockRecorder) CompleteAccountExport(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CompleteAccountExport", reflect.TypeOf((*MockStore)(nil).CompleteAccountExport), arg0, arg1)
+}
+
 // CompleteAgreement mocks base method.
 func (m *MockStore) CompleteAgreement(arg0 context.Context, arg1 int64) (db.RentalAgreement, error) {
 	m.ctrl.T.Helper()
@@ -375,6 +538,36 @@ func (mr *MockStoreMockRecorder) CompleteInspection(arg0, arg1 interface{}) *gom
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CompleteInspection", reflect.TypeOf((*MockStore)(nil).CompleteInspection), arg0, arg1)
 }
 
+// CompleteNINVerificationTx mocks base method.
+func (m *MockStore) CompleteNINVerificationTx(arg0 context.Context, arg1 db.CompleteNINVerificationTxParams) (db.CompleteNINVerificationTxResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CompleteNINVerificationTx", arg0, arg1)
+	ret0, _ := ret[0].(db.CompleteNINVerificationTxResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CompleteNINVerificationTx indicates an expected call of CompleteNINVerificationTx.
+func (mr *MockStoreMockRecorder) CompleteNINVerificationTx(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CompleteNINVerificationTx", reflect.TypeOf((*MockStore)(nil).CompleteNINVerificationTx), arg0, arg1)
+}
+
+// CompleteUserVerification mocks base method.
+func (m *MockStore) CompleteUserVerification(arg0 context.Context, arg1 db.CompleteUserVerificationParams) (db.UserVerification, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CompleteUserVerification", arg0, arg1)
+	ret0, _ := ret[0].(db.UserVerification)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CompleteUserVerification indicates an expected call of CompleteUserVerification.
+func (mr *MockStoreMockRecorder) CompleteUserVerification(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CompleteUserVerification", reflect.TypeOf((*MockStore)(nil).CompleteUserVerification), arg0, arg1)
+}
+
 // ConfirmInspection mocks base method.
 func (m *MockStore) ConfirmInspection(arg0 context.Context, arg1 db.ConfirmInspectionParams) (db.InspectionRequest, error) {
 	m.ctrl.T.Helper()
@@ -645,6 +838,21 @@ func (mr *MockStoreMockRecorder) CountExpiredCacheEntries(arg0 interface{}) *gom
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountExpiredCacheEntries", reflect.TypeOf((*MockStore)(nil).CountExpiredCacheEntries), arg0)
 }
 
+// CountFilteredUsers mocks base method.
+func (m *MockStore) CountFilteredUsers(arg0 context.Context, arg1 db.CountFilteredUsersParams) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountFilteredUsers", arg0, arg1)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountFilteredUsers indicates an expected call of CountFilteredUsers.
+func (mr *MockStoreMockRecorder) CountFilteredUsers(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountFilteredUsers", reflect.TypeOf((*MockStore)(nil).CountFilteredUsers), arg0, arg1)
+}
+
 // CountInspectionRequestsByStatus mocks base method.
 func (m *MockStore) CountInspectionRequestsByStatus(arg0 context.Context, arg1 db.NullInspectionStatusEnum) (int64, error) {
 	m.ctrl.T.Helper()
@@ -735,6 +943,21 @@ func (mr *MockStoreMockRecorder) CountPaymentsByStatus(arg0, arg1 interface{}) *
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountPaymentsByStatus", reflect.TypeOf((*MockStore)(nil).CountPaymentsByStatus), arg0, arg1)
 }
 
+// CountPendingAgentApplications mocks base method.
+func (m *MockStore) CountPendingAgentApplications(arg0 context.Context) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountPendingAgentApplications", arg0)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountPendingAgentApplications indicates an expected call of CountPendingAgentApplications.
+func (mr *MockStoreMockRecorder) CountPendingAgentApplications(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountPendingAgentApplications", reflect.TypeOf((*MockStore)(nil).CountPendingAgentApplications), arg0)
+}
+
 // CountPendingApprovalReports mocks base method.
 func (m *MockStore) CountPendingApprovalReports(arg0 context.Context) (int64, error) {
 	m.ctrl.T.Helper()
@@ -915,6 +1138,36 @@ func (mr *MockStoreMockRecorder) CountRatingsForUser(arg0, arg1 interface{}) *go
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountRatingsForUser", reflect.TypeOf((*MockStore)(nil).CountRatingsForUser), arg0, arg1)
 }
 
+// CountRecentAccountExports mocks base method.
+func (m *MockStore) CountRecentAccountExports(arg0 context.Context, arg1 db.CountRecentAccountExportsParams) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountRecentAccountExports", arg0, arg1)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountRecentAccountExports indicates an expected call of CountRecentAccountExports.
+func (mr *MockStoreMockRecorder) CountRecentAccountExports(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountRecentAccountExports", reflect.TypeOf((*MockStore)(nil).CountRecentAccountExports), arg0, arg1)
+}
+
+// CountRecentUserVerifications mocks base method.
+func (m *MockStore) CountRecentUserVerifications(arg0 context.Context, arg1 db.CountRecentUserVerificationsParams) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountRecentUserVerifications", arg0, arg1)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountRecentUserVerifications indicates an expected call of CountRecentUserVerifications.
+func (mr *MockStoreMockRecorder) CountRecentUserVerifications(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountRecentUserVerifications", reflect.TypeOf((*MockStore)(nil).CountRecentUserVerifications), arg0, arg1)
+}
+
 // CountRentalAgreementsByStatus mocks base method.
 func (m *MockStore) CountRentalAgreementsByStatus(arg0 context.Context, arg1 db.NullAgreementStatusEnum) (int64, error) {
 	m.ctrl.T.Helper()
@@ -990,6 +1243,21 @@ func (mr *MockStoreMockRecorder) CountSystemSettings(arg0 interface{}) *gomock.C
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountSystemSettings", reflect.TypeOf((*MockStore)(nil).CountSystemSettings), arg0)
 }
 
+// CountTenantDocuments mocks base method.
+func (m *MockStore) CountTenantDocuments(arg0 context.Context, arg1 int64) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountTenantDocuments", arg0, arg1)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountTenantDocuments indicates an expected call of CountTenantDocuments.
+func (mr *MockStoreMockRecorder) CountTenantDocuments(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountTenantDocuments", reflect.TypeOf((*MockStore)(nil).CountTenantDocuments), arg0, arg1)
+}
+
 // CountTenantInspectionRequests mocks base method.
 func (m *MockStore) CountTenantInspectionRequests(arg0 context.Context, arg1 int64) (int64, error) {
 	m.ctrl.T.Helper()
@@ -1200,21 +1468,6 @@ func (mr *MockStoreMockRecorder) CountUserTotalSessions(arg0, arg1 interface{})
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountUserTotalSessions", reflect.TypeOf((*MockStore)(nil).CountUserTotalSessions), arg0, arg1)
 }
 
-// CountUsersByType mocks base method.
-func (m *MockStore) CountUsersByType(arg0 context.Context, arg1 db.UserTypeEnum) (int64, error) {
-	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CountUsersByType", arg0, arg1)
-	ret0, _ := ret[0].(int64)
-	ret1, _ := ret[1].(error)
-	return ret0, ret1
-}
-
-// CountUsersByType indicates an expected call of CountUsersByType.
-func (mr *MockStoreMockRecorder) CountUsersByType(arg0, arg1 interface{}) *gomock.Call {
-	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountUsersByType", reflect.TypeOf((*MockStore)(nil).CountUsersByType), arg0, arg1)
-}
-
 // CountVerifiedPropertyReviews mocks base method.
 func (m *MockStore) CountVerifiedPropertyReviews(arg0 context.Context, arg1 int64) (int64, error) {
 	m.ctrl.T.Helper()
@@ -1245,6 +1498,81 @@ func (mr *MockStoreMockRecorder) CountVerifiedRatingsForUser(arg0, arg1 interfac
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountVerifiedRatingsForUser", reflect.TypeOf((*MockStore)(nil).CountVerifiedRatingsForUser), arg0, arg1)
 }
 
+// CreateAccountDeletion mocks base method.
+func (m *MockStore) CreateAccountDeletion(arg0 context.Context, arg1 db.CreateAccountDeletionParams) (db.AccountDeletion, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateAccountDeletion", arg0, arg1)
+	ret0, _ := ret[0].(db.AccountDeletion)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateAccountDeletion indicates an expected call of CreateAccountDeletion.
+func (mr *MockStoreMockRecorder) CreateAccountDeletion(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateAccountDeletion", reflect.TypeOf((*MockStore)(nil).CreateAccountDeletion), arg0, arg1)
+}
+
+// CreateAccountExport mocks base method.
+func (m *MockStore) CreateAccountExport(arg0 context.Context, arg1 int64) (db.AccountExport, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateAccountExport", arg0, arg1)
+	ret0, _ := ret[0].(db.AccountExport)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateAccountExport indicates an expected call of CreateAccountExport.
+func (mr *MockStoreMockRecorder) CreateAccountExport(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateAccountExport", reflect.TypeOf((*MockStore)(nil).CreateAccountExport), arg0, arg1)
+}
+
+// CreateAccountExportTx mocks base method.
+func (m *MockStore) CreateAccountExportTx(arg0 context.Context, arg1 db.CreateAccountExportTxParams) (db.CreateAccountExportTxResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateAccountExportTx", arg0, arg1)
+	ret0, _ := ret[0].(db.CreateAccountExportTxResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateAccountExportTx indicates an expected call of CreateAccountExportTx.
+func (mr *MockStoreMockRecorder) CreateAccountExportTx(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateAccountExportTx", reflect.TypeOf((*MockStore)(nil).CreateAccountExportTx), arg0, arg1)
+}
+
+// CreateAgentAvailabilityException mocks base method.
+func (m *MockStore) CreateAgentAvailabilityException(arg0 context.Context, arg1 db.CreateAgentAvailabilityExceptionParams) (db.AgentAvailabilityException, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateAgentAvailabilityException", arg0, arg1)
+	ret0, _ := ret[0].(db.AgentAvailabilityException)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateAgentAvailabilityException indicates an expected call of CreateAgentAvailabilityException.
+func (mr *MockStoreMockRecorder) CreateAgentAvailabilityException(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateAgentAvailabilityException", reflect.TypeOf((*MockStore)(nil).CreateAgentAvailabilityException), arg0, arg1)
+}
+
+// CreateAgentAvailabilityWindow mocks base method.
+func (m *MockStore) CreateAgentAvailabilityWindow(arg0 context.Context, arg1 db.CreateAgentAvailabilityWindowParams) (db.AgentAvailabilityWindow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateAgentAvailabilityWindow", arg0, arg1)
+	ret0, _ := ret[0].(db.AgentAvailabilityWindow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateAgentAvailabilityWindow indicates an expected call of CreateAgentAvailabilityWindow.
+func (mr *MockStoreMockRecorder) CreateAgentAvailabilityWindow(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateAgentAvailabilityWindow", reflect.TypeOf((*MockStore)(nil).CreateAgentAvailabilityWindow), arg0, arg1)
+}
+
 // CreateAuditLog mocks base method.
 func (m *MockStore) CreateAuditLog(arg0 context.Context, arg1 db.CreateAuditLogParams) (db.AuditLog, error) {
 	m.ctrl.T.Helper()
@@ -1290,6 +1618,21 @@ func (mr *MockStoreMockRecorder) CreateDisputeCase(arg0, arg1 interface{}) *gomo
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateDisputeCase", reflect.TypeOf((*MockStore)(nil).CreateDisputeCase), arg0, arg1)
 }
 
+// CreateDocumentAccessGrant mocks base method.
+func (m *MockStore) CreateDocumentAccessGrant(arg0 context.Context, arg1 db.CreateDocumentAccessGrantParams) (db.DocumentAccessGrant, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateDocumentAccessGrant", arg0, arg1)
+	ret0, _ := ret[0].(db.DocumentAccessGrant)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateDocumentAccessGrant indicates an expected call of CreateDocumentAccessGrant.
+func (mr *MockStoreMockRecorder) CreateDocumentAccessGrant(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateDocumentAccessGrant", reflect.TypeOf((*MockStore)(nil).CreateDocumentAccessGrant), arg0, arg1)
+}
+
 // CreateInspectionAgentProfile mocks base method.
 func (m *MockStore) CreateInspectionAgentProfile(arg0 context.Context, arg1 db.CreateInspectionAgentProfileParams) (db.InspectionAgentProfile, error) {
 	m.ctrl.T.Helper()
@@ -1515,6 +1858,21 @@ func (mr *MockStoreMockRecorder) CreateSystemSetting(arg0, arg1 interface{}) *go
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateSystemSetting", reflect.TypeOf((*MockStore)(nil).CreateSystemSetting), arg0, arg1)
 }
 
+// CreateTenantDocument mocks base method.
+func (m *MockStore) CreateTenantDocument(arg0 context.Context, arg1 db.CreateTenantDocumentParams) (db.TenantDocument, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateTenantDocument", arg0, arg1)
+	ret0, _ := ret[0].(db.TenantDocument)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateTenantDocument indicates an expected call of CreateTenantDocument.
+func (mr *MockStoreMockRecorder) CreateTenantDocument(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateTenantDocument", reflect.TypeOf((*MockStore)(nil).CreateTenantDocument), arg0, arg1)
+}
+
 // CreateTenantProfile mocks base method.
 func (m *MockStore) CreateTenantProfile(arg0 context.Context, arg1 db.CreateTenantProfileParams) (db.TenantProfile, error) {
 	m.ctrl.T.Helper()
@@ -1545,6 +1903,21 @@ func (mr *MockStoreMockRecorder) CreateUser(arg0, arg1 interface{}) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateUser", reflect.TypeOf((*MockStore)(nil).CreateUser), arg0, arg1)
 }
 
+// CreateUserIdentity mocks base method.
+func (m *MockStore) CreateUserIdentity(arg0 context.Context, arg1 db.CreateUserIdentityParams) (db.UserIdentity, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateUserIdentity", arg0, arg1)
+	ret0, _ := ret[0].(db.UserIdentity)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateUserIdentity indicates an expected call of CreateUserIdentity.
+func (mr *MockStoreMockRecorder) CreateUserIdentity(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateUserIdentity", reflect.TypeOf((*MockStore)(nil).CreateUserIdentity), arg0, arg1)
+}
+
 // CreateUserRating mocks base method.
 func (m *MockStore) CreateUserRating(arg0 context.Context, arg1 db.CreateUserRatingParams) (db.UserRating, error) {
 	m.ctrl.T.Helper()
@@ -1605,6 +1978,20 @@ func (mr *MockStoreMockRecorder) CreateUserVerification(arg0, arg1 interface{})
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateUserVerification", reflect.TypeOf((*MockStore)(nil).CreateUserVerification), arg0, arg1)
 }
 
+// DeactivateLandlordProperties mocks base method.
+func (m *MockStore) DeactivateLandlordProperties(arg0 context.Context, arg1 int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeactivateLandlordProperties", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeactivateLandlordProperties indicates an expected call of DeactivateLandlordProperties.
+func (mr *MockStoreMockRecorder) DeactivateLandlordProperties(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeactivateLandlordProperties", reflect.TypeOf((*MockStore)(nil).DeactivateLandlordProperties), arg0, arg1)
+}
+
 // DeactivateOtherUserSessions mocks base method.
 func (m *MockStore) DeactivateOtherUserSessions(arg0 context.Context, arg1 db.DeactivateOtherUserSessionsParams) error {
 	m.ctrl.T.Helper()
@@ -1633,6 +2020,21 @@ func (mr *MockStoreMockRecorder) DeactivateSession(arg0, arg1 interface{}) *gomo
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeactivateSession", reflect.TypeOf((*MockStore)(nil).DeactivateSession), arg0, arg1)
 }
 
+// DeactivateUserSessionBySessionID mocks base method.
+func (m *MockStore) DeactivateUserSessionBySessionID(arg0 context.Context, arg1 db.DeactivateUserSessionBySessionIDParams) ([]db.UserSession, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeactivateUserSessionBySessionID", arg0, arg1)
+	ret0, _ := ret[0].([]db.UserSession)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeactivateUserSessionBySessionID indicates an expected call of DeactivateUserSessionBySessionID.
+func (mr *MockStoreMockRecorder) DeactivateUserSessionBySessionID(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeactivateUserSessionBySessionID", reflect.TypeOf((*MockStore)(nil).DeactivateUserSessionBySessionID), arg0, arg1)
+}
+
 // DeactivateUserSessions mocks base method.
 func (m *MockStore) DeactivateUserSessions(arg0 context.Context, arg1 int64) error {
 	m.ctrl.T.Helper()
@@ -1675,37 +2077,65 @@ func (mr *MockStoreMockRecorder) DecrementReviewHelpfulVotes(arg0, arg1 interfac
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DecrementReviewHelpfulVotes", reflect.TypeOf((*MockStore)(nil).DecrementReviewHelpfulVotes), arg0, arg1)
 }
 
-// DeleteAllPropertyMedia mocks base method.
-func (m *MockStore) DeleteAllPropertyMedia(arg0 context.Context, arg1 int64) error {
+// DeleteAgentAvailabilityExceptions mocks base method.
+func (m *MockStore) DeleteAgentAvailabilityExceptions(arg0 context.Context, arg1 int64) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeleteAllPropertyMedia", arg0, arg1)
+	ret := m.ctrl.Call(m, "DeleteAgentAvailabilityExceptions", arg0, arg1)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
-// DeleteAllPropertyMedia indicates an expected call of DeleteAllPropertyMedia.
-func (mr *MockStoreMockRecorder) DeleteAllPropertyMedia(arg0, arg1 interface{}) *gomock.Call {
+// DeleteAgentAvailabilityExceptions indicates an expected call of DeleteAgentAvailabilityExceptions.
+func (mr *MockStoreMockRecorder) DeleteAgentAvailabilityExceptions(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteAllPropertyMedia", reflect.TypeOf((*MockStore)(nil).DeleteAllPropertyMedia), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteAgentAvailabilityExceptions", reflect.TypeOf((*MockStore)(nil).DeleteAgentAvailabilityExceptions), arg0, arg1)
 }
 
-// DeleteAllUserNotifications mocks base method.
-func (m *MockStore) DeleteAllUserNotifications(arg0 context.Context, arg1 int64) error {
+// DeleteAgentAvailabilityWindows mocks base method.
+func (m *MockStore) DeleteAgentAvailabilityWindows(arg0 context.Context, arg1 int64) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeleteAllUserNotifications", arg0, arg1)
+	ret := m.ctrl.Call(m, "DeleteAgentAvailabilityWindows", arg0, arg1)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
-// DeleteAllUserNotifications indicates an expected call of DeleteAllUserNotifications.
-func (mr *MockStoreMockRecorder) DeleteAllUserNotifications(arg0, arg1 interface{}) *gomock.Call {
+// DeleteAgentAvailabilityWindows indicates an expected call of DeleteAgentAvailabilityWindows.
+func (mr *MockStoreMockRecorder) DeleteAgentAvailabilityWindows(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteAllUserNotifications", reflect.TypeOf((*MockStore)(nil).DeleteAllUserNotifications), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteAgentAvailabilityWindows", reflect.TypeOf((*MockStore)(nil).DeleteAgentAvailabilityWindows), arg0, arg1)
 }
 
-// DeleteAllUserSavedProperties mocks base method.
-func (m *MockStore) DeleteAllUserSavedProperties(arg0 context.Context, arg1 int64) error {
-	m.ctrl.T.Helper()
+// DeleteAllPropertyMedia mocks base method.
+func (m *MockStore) DeleteAllPropertyMedia(arg0 context.Context, arg1 int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteAllPropertyMedia", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteAllPropertyMedia indicates an expected call of DeleteAllPropertyMedia.
+func (mr *MockStoreMockRecorder) DeleteAllPropertyMedia(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteAllPropertyMedia", reflect.TypeOf((*MockStore)(nil).DeleteAllPropertyMedia), arg0, arg1)
+}
+
+// DeleteAllUserNotifications mocks base method.
+func (m *MockStore) DeleteAllUserNotifications(arg0 context.Context, arg1 int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteAllUserNotifications", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteAllUserNotifications indicates an expected call of DeleteAllUserNotifications.
+func (mr *MockStoreMockRecorder) DeleteAllUserNotifications(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteAllUserNotifications", reflect.TypeOf((*MockStore)(nil).DeleteAllUserNotifications), arg0, arg1)
+}
+
+// DeleteAllUserSavedProperties mocks base method.
+func (m *MockStore) DeleteAllUserSavedProperties(arg0 context.Context, arg1 int64) error {
+	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "DeleteAllUserSavedProperties", arg0, arg1)
 	ret0, _ := ret[0].(error)
 	return ret0
@@ -2067,6 +2497,36 @@ func (mr *MockStoreMockRecorder) DeleteSystemSetting(arg0, arg1 interface{}) *go
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteSystemSetting", reflect.TypeOf((*MockStore)(nil).DeleteSystemSetting), arg0, arg1)
 }
 
+// DeleteTenantDocument mocks base method.
+func (m *MockStore) DeleteTenantDocument(arg0 context.Context, arg1 int64) (db.TenantDocument, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteTenantDocument", arg0, arg1)
+	ret0, _ := ret[0].(db.TenantDocument)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteTenantDocument indicates an expected call of DeleteTenantDocument.
+func (mr *MockStoreMockRecorder) DeleteTenantDocument(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteTenantDocument", reflect.TypeOf((*MockStore)(nil).DeleteTenantDocument), arg0, arg1)
+}
+
+// DeleteTenantDocumentsByOwner mocks base method.
+func (m *MockStore) DeleteTenantDocumentsByOwner(arg0 context.Context, arg1 int64) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteTenantDocumentsByOwner", arg0, arg1)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteTenantDocumentsByOwner indicates an expected call of DeleteTenantDocumentsByOwner.
+func (mr *MockStoreMockRecorder) DeleteTenantDocumentsByOwner(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteTenantDocumentsByOwner", reflect.TypeOf((*MockStore)(nil).DeleteTenantDocumentsByOwner), arg0, arg1)
+}
+
 // DeleteTenantProfile mocks base method.
 func (m *MockStore) DeleteTenantProfile(arg0 context.Context, arg1 int64) error {
 	m.ctrl.T.Helper()
@@ -2095,6 +2555,34 @@ func (mr *MockStoreMockRecorder) DeleteUser(arg0, arg1 interface{}) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteUser", reflect.TypeOf((*MockStore)(nil).DeleteUser), arg0, arg1)
 }
 
+// DeleteUserChatbotConversations mocks base method.
+func (m *MockStore) DeleteUserChatbotConversations(arg0 context.Context, arg1 int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteUserChatbotConversations", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteUserChatbotConversations indicates an expected call of DeleteUserChatbotConversations.
+func (mr *MockStoreMockRecorder) DeleteUserChatbotConversations(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteUserChatbotConversations", reflect.TypeOf((*MockStore)(nil).DeleteUserChatbotConversations), arg0, arg1)
+}
+
+// DeleteUserIdentitiesByUserID mocks base method.
+func (m *MockStore) DeleteUserIdentitiesByUserID(arg0 context.Context, arg1 int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteUserIdentitiesByUserID", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteUserIdentitiesByUserID indicates an expected call of DeleteUserIdentitiesByUserID.
+func (mr *MockStoreMockRecorder) DeleteUserIdentitiesByUserID(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteUserIdentitiesByUserID", reflect.TypeOf((*MockStore)(nil).DeleteUserIdentitiesByUserID), arg0, arg1)
+}
+
 // DeleteUserRating mocks base method.
 func (m *MockStore) DeleteUserRating(arg0 context.Context, arg1 int64) error {
 	m.ctrl.T.Helper()
@@ -2137,6 +2625,34 @@ func (mr *MockStoreMockRecorder) DeleteUserVerification(arg0, arg1 interface{})
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteUserVerification", reflect.TypeOf((*MockStore)(nil).DeleteUserVerification), arg0, arg1)
 }
 
+// DeleteUserVerificationsByType mocks base method.
+func (m *MockStore) DeleteUserVerificationsByType(arg0 context.Context, arg1 db.DeleteUserVerificationsByTypeParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteUserVerificationsByType", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteUserVerificationsByType indicates an expected call of DeleteUserVerificationsByType.
+func (mr *MockStoreMockRecorder) DeleteUserVerificationsByType(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteUserVerificationsByType", reflect.TypeOf((*MockStore)(nil).DeleteUserVerificationsByType), arg0, arg1)
+}
+
+// DeleteUserVerificationsByUserID mocks base method.
+func (m *MockStore) DeleteUserVerificationsByUserID(arg0 context.Context, arg1 int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteUserVerificationsByUserID", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteUserVerificationsByUserID indicates an expected call of DeleteUserVerificationsByUserID.
+func (mr *MockStoreMockRecorder) DeleteUserVerificationsByUserID(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteUserVerificationsByUserID", reflect.TypeOf((*MockStore)(nil).DeleteUserVerificationsByUserID), arg0, arg1)
+}
+
 // EscalateConversation mocks base method.
 func (m *MockStore) EscalateConversation(arg0 context.Context, arg1 db.EscalateConversationParams) (db.ChatbotConversation, error) {
 	m.ctrl.T.Helper()
@@ -2152,6 +2668,21 @@ func (mr *MockStoreMockRecorder) EscalateConversation(arg0, arg1 interface{}) *g
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EscalateConversation", reflect.TypeOf((*MockStore)(nil).EscalateConversation), arg0, arg1)
 }
 
+// ExpirePropertySearchCaches mocks base method.
+func (m *MockStore) ExpirePropertySearchCaches(arg0 context.Context, arg1 int64) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExpirePropertySearchCaches", arg0, arg1)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ExpirePropertySearchCaches indicates an expected call of ExpirePropertySearchCaches.
+func (mr *MockStoreMockRecorder) ExpirePropertySearchCaches(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExpirePropertySearchCaches", reflect.TypeOf((*MockStore)(nil).ExpirePropertySearchCaches), arg0, arg1)
+}
+
 // ExtendCacheExpiry mocks base method.
 func (m *MockStore) ExtendCacheExpiry(arg0 context.Context, arg1 db.ExtendCacheExpiryParams) (db.PropertySearchCache, error) {
 	m.ctrl.T.Helper()
@@ -2167,6 +2698,20 @@ func (mr *MockStoreMockRecorder) ExtendCacheExpiry(arg0, arg1 interface{}) *gomo
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExtendCacheExpiry", reflect.TypeOf((*MockStore)(nil).ExtendCacheExpiry), arg0, arg1)
 }
 
+// FailAccountExport mocks base method.
+func (m *MockStore) FailAccountExport(arg0 context.Context, arg1 int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FailAccountExport", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// FailAccountExport indicates an expected call of FailAccountExport.
+func (mr *MockStoreMockRecorder) FailAccountExport(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FailAccountExport", reflect.TypeOf((*MockStore)(nil).FailAccountExport), arg0, arg1)
+}
+
 // FailPayment mocks base method.
 func (m *MockStore) FailPayment(arg0 context.Context, arg1 db.FailPaymentParams) (db.Payment, error) {
 	m.ctrl.T.Helper()
@@ -2182,6 +2727,111 @@ func (mr *MockStoreMockRecorder) FailPayment(arg0, arg1 interface{}) *gomock.Cal
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FailPayment", reflect.TypeOf((*MockStore)(nil).FailPayment), arg0, arg1)
 }
 
+// FederatedLoginTx mocks base method.
+func (m *MockStore) FederatedLoginTx(arg0 context.Context, arg1 db.FederatedLoginTxParams) (db.FederatedLoginTxResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FederatedLoginTx", arg0, arg1)
+	ret0, _ := ret[0].(db.FederatedLoginTxResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FederatedLoginTx indicates an expected call of FederatedLoginTx.
+func (mr *MockStoreMockRecorder) FederatedLoginTx(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FederatedLoginTx", reflect.TypeOf((*MockStore)(nil).FederatedLoginTx), arg0, arg1)
+}
+
+// FilterUsers mocks base method.
+func (m *MockStore) FilterUsers(arg0 context.Context, arg1 db.FilterUsersParams) ([]db.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FilterUsers", arg0, arg1)
+	ret0, _ := ret[0].([]db.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FilterUsers indicates an expected call of FilterUsers.
+func (mr *MockStoreMockRecorder) FilterUsers(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FilterUsers", reflect.TypeOf((*MockStore)(nil).FilterUsers), arg0, arg1)
+}
+
+// ForcePasswordResetTx mocks base method.
+func (m *MockStore) ForcePasswordResetTx(arg0 context.Context, arg1 db.ForcePasswordResetTxParams) (db.ForcePasswordResetTxResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ForcePasswordResetTx", arg0, arg1)
+	ret0, _ := ret[0].(db.ForcePasswordResetTxResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ForcePasswordResetTx indicates an expected call of ForcePasswordResetTx.
+func (mr *MockStoreMockRecorder) ForcePasswordResetTx(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ForcePasswordResetTx", reflect.TypeOf((*MockStore)(nil).ForcePasswordResetTx), arg0, arg1)
+}
+
+// GetAccountDeletion mocks base method.
+func (m *MockStore) GetAccountDeletion(arg0 context.Context, arg1 int64) (db.AccountDeletion, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAccountDeletion", arg0, arg1)
+	ret0, _ := ret[0].(db.AccountDeletion)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAccountDeletion indicates an expected call of GetAccountDeletion.
+func (mr *MockStoreMockRecorder) GetAccountDeletion(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAccountDeletion", reflect.TypeOf((*MockStore)(nil).GetAccountDeletion), arg0, arg1)
+}
+
+// GetAccountExport mocks base method.
+func (m *MockStore) GetAccountExport(arg0 context.Context, arg1 int64) (db.AccountExport, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAccountExport", arg0, arg1)
+	ret0, _ := ret[0].(db.AccountExport)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAccountExport indicates an expected call of GetAccountExport.
+func (mr *MockStoreMockRecorder) GetAccountExport(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAccountExport", reflect.TypeOf((*MockStore)(nil).GetAccountExport), arg0, arg1)
+}
+
+// GetAccountExportData mocks base method.
+func (m *MockStore) GetAccountExportData(arg0 context.Context, arg1 int64) ([]byte, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAccountExportData", arg0, arg1)
+	ret0, _ := ret[0].([]byte)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAccountExportData indicates an expected call of GetAccountExportData.
+func (mr *MockStoreMockRecorder) GetAccountExportData(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAccountExportData", reflect.TypeOf((*MockStore)(nil).GetAccountExportData), arg0, arg1)
+}
+
+// GetAccountLockout mocks base method.
+func (m *MockStore) GetAccountLockout(arg0 context.Context, arg1 int64) (db.AccountLockout, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAccountLockout", arg0, arg1)
+	ret0, _ := ret[0].(db.AccountLockout)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAccountLockout indicates an expected call of GetAccountLockout.
+func (mr *MockStoreMockRecorder) GetAccountLockout(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAccountLockout", reflect.TypeOf((*MockStore)(nil).GetAccountLockout), arg0, arg1)
+}
+
 // GetActiveCacheEntries mocks base method.
 func (m *MockStore) GetActiveCacheEntries(arg0 context.Context, arg1 db.GetActiveCacheEntriesParams) ([]db.PropertySearchCache, error) {
 	m.ctrl.T.Helper()
@@ -2332,6 +2982,21 @@ func (mr *MockStoreMockRecorder) GetAllSystemSettings(arg0, arg1 interface{}) *g
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllSystemSettings", reflect.TypeOf((*MockStore)(nil).GetAllSystemSettings), arg0, arg1)
 }
 
+// GetAllUserActiveSessions mocks base method.
+func (m *MockStore) GetAllUserActiveSessions(arg0 context.Context, arg1 int64) ([]db.UserSession, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAllUserActiveSessions", arg0, arg1)
+	ret0, _ := ret[0].([]db.UserSession)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAllUserActiveSessions indicates an expected call of GetAllUserActiveSessions.
+func (mr *MockStoreMockRecorder) GetAllUserActiveSessions(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllUserActiveSessions", reflect.TypeOf((*MockStore)(nil).GetAllUserActiveSessions), arg0, arg1)
+}
+
 // GetApplicationConversation mocks base method.
 func (m *MockStore) GetApplicationConversation(arg0 context.Context, arg1 db.GetApplicationConversationParams) ([]db.GetApplicationConversationRow, error) {
 	m.ctrl.T.Helper()
@@ -3502,6 +4167,36 @@ func (mr *MockStoreMockRecorder) GetPaymentsByType(arg0, arg1 interface{}) *gomo
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPaymentsByType", reflect.TypeOf((*MockStore)(nil).GetPaymentsByType), arg0, arg1)
 }
 
+// GetPendingAccountDeletion mocks base method.
+func (m *MockStore) GetPendingAccountDeletion(arg0 context.Context, arg1 int64) (db.AccountDeletion, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPendingAccountDeletion", arg0, arg1)
+	ret0, _ := ret[0].(db.AccountDeletion)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPendingAccountDeletion indicates an expected call of GetPendingAccountDeletion.
+func (mr *MockStoreMockRecorder) GetPendingAccountDeletion(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPendingAccountDeletion", reflect.TypeOf((*MockStore)(nil).GetPendingAccountDeletion), arg0, arg1)
+}
+
+// GetPendingAccountExport mocks base method.
+func (m *MockStore) GetPendingAccountExport(arg0 context.Context, arg1 int64) (db.AccountExport, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPendingAccountExport", arg0, arg1)
+	ret0, _ := ret[0].(db.AccountExport)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPendingAccountExport indicates an expected call of GetPendingAccountExport.
+func (mr *MockStoreMockRecorder) GetPendingAccountExport(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPendingAccountExport", reflect.TypeOf((*MockStore)(nil).GetPendingAccountExport), arg0, arg1)
+}
+
 // GetPendingApplicationsForLandlord mocks base method.
 func (m *MockStore) GetPendingApplicationsForLandlord(arg0 context.Context, arg1 db.GetPendingApplicationsForLandlordParams) ([]db.GetPendingApplicationsForLandlordRow, error) {
 	m.ctrl.T.Helper()
@@ -3712,6 +4407,21 @@ func (mr *MockStoreMockRecorder) GetPropertyConversation(arg0, arg1 interface{})
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPropertyConversation", reflect.TypeOf((*MockStore)(nil).GetPropertyConversation), arg0, arg1)
 }
 
+// GetPropertyForUpdate mocks base method.
+func (m *MockStore) GetPropertyForUpdate(arg0 context.Context, arg1 int64) (db.Property, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPropertyForUpdate", arg0, arg1)
+	ret0, _ := ret[0].(db.Property)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPropertyForUpdate indicates an expected call of GetPropertyForUpdate.
+func (mr *MockStoreMockRecorder) GetPropertyForUpdate(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPropertyForUpdate", reflect.TypeOf((*MockStore)(nil).GetPropertyForUpdate), arg0, arg1)
+}
+
 // GetPropertyInquiries mocks base method.
 func (m *MockStore) GetPropertyInquiries(arg0 context.Context, arg1 db.GetPropertyInquiriesParams) ([]db.GetPropertyInquiriesRow, error) {
 	m.ctrl.T.Helper()
@@ -4117,6 +4827,21 @@ func (mr *MockStoreMockRecorder) GetReportsByAgent(arg0, arg1 interface{}) *gomo
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetReportsByAgent", reflect.TypeOf((*MockStore)(nil).GetReportsByAgent), arg0, arg1)
 }
 
+// GetRotatedUserSessionByToken mocks base method.
+func (m *MockStore) GetRotatedUserSessionByToken(arg0 context.Context, arg1 string) (db.UserSession, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRotatedUserSessionByToken", arg0, arg1)
+	ret0, _ := ret[0].(db.UserSession)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRotatedUserSessionByToken indicates an expected call of GetRotatedUserSessionByToken.
+func (mr *MockStoreMockRecorder) GetRotatedUserSessionByToken(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRotatedUserSessionByToken", reflect.TypeOf((*MockStore)(nil).GetRotatedUserSessionByToken), arg0, arg1)
+}
+
 // GetSavedPropertyByID mocks base method.
 func (m *MockStore) GetSavedPropertyByID(arg0 context.Context, arg1 int64) (db.SavedProperty, error) {
 	m.ctrl.T.Helper()
@@ -4252,6 +4977,21 @@ func (mr *MockStoreMockRecorder) GetSystemSettingByKey(arg0, arg1 interface{}) *
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSystemSettingByKey", reflect.TypeOf((*MockStore)(nil).GetSystemSettingByKey), arg0, arg1)
 }
 
+// GetTenantDocument mocks base method.
+func (m *MockStore) GetTenantDocument(arg0 context.Context, arg1 int64) (db.TenantDocument, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTenantDocument", arg0, arg1)
+	ret0, _ := ret[0].(db.TenantDocument)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTenantDocument indicates an expected call of GetTenantDocument.
+func (mr *MockStoreMockRecorder) GetTenantDocument(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTenantDocument", reflect.TypeOf((*MockStore)(nil).GetTenantDocument), arg0, arg1)
+}
+
 // GetTenantInquiries mocks base method.
 func (m *MockStore) GetTenantInquiries(arg0 context.Context, arg1 db.GetTenantInquiriesParams) ([]db.GetTenantInquiriesRow, error) {
 	m.ctrl.T.Helper()
@@ -4567,6 +5307,21 @@ func (mr *MockStoreMockRecorder) GetUserEarnings(arg0, arg1 interface{}) *gomock
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserEarnings", reflect.TypeOf((*MockStore)(nil).GetUserEarnings), arg0, arg1)
 }
 
+// GetUserIdentity mocks base method.
+func (m *MockStore) GetUserIdentity(arg0 context.Context, arg1 db.GetUserIdentityParams) (db.UserIdentity, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserIdentity", arg0, arg1)
+	ret0, _ := ret[0].(db.UserIdentity)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserIdentity indicates an expected call of GetUserIdentity.
+func (mr *MockStoreMockRecorder) GetUserIdentity(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserIdentity", reflect.TypeOf((*MockStore)(nil).GetUserIdentity), arg0, arg1)
+}
+
 // GetUserLoginLogs mocks base method.
 func (m *MockStore) GetUserLoginLogs(arg0 context.Context, arg1 db.GetUserLoginLogsParams) ([]db.GetUserLoginLogsRow, error) {
 	m.ctrl.T.Helper()
@@ -4882,6 +5637,21 @@ func (mr *MockStoreMockRecorder) GetVerifiedRatingsForUser(arg0, arg1 interface{
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetVerifiedRatingsForUser", reflect.TypeOf((*MockStore)(nil).GetVerifiedRatingsForUser), arg0, arg1)
 }
 
+// HasDocumentAccess mocks base method.
+func (m *MockStore) HasDocumentAccess(arg0 context.Context, arg1 db.HasDocumentAccessParams) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HasDocumentAccess", arg0, arg1)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// HasDocumentAccess indicates an expected call of HasDocumentAccess.
+func (mr *MockStoreMockRecorder) HasDocumentAccess(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HasDocumentAccess", reflect.TypeOf((*MockStore)(nil).HasDocumentAccess), arg0, arg1)
+}
+
 // IncrementAgentInspectionCount mocks base method.
 func (m *MockStore) IncrementAgentInspectionCount(arg0 context.Context, arg1 int64) error {
 	m.ctrl.T.Helper()
@@ -4896,6 +5666,21 @@ func (mr *MockStoreMockRecorder) IncrementAgentInspectionCount(arg0, arg1 interf
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IncrementAgentInspectionCount", reflect.TypeOf((*MockStore)(nil).IncrementAgentInspectionCount), arg0, arg1)
 }
 
+// IncrementFailedLoginAttempts mocks base method.
+func (m *MockStore) IncrementFailedLoginAttempts(arg0 context.Context, arg1 int64) (db.AccountLockout, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IncrementFailedLoginAttempts", arg0, arg1)
+	ret0, _ := ret[0].(db.AccountLockout)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// IncrementFailedLoginAttempts indicates an expected call of IncrementFailedLoginAttempts.
+func (mr *MockStoreMockRecorder) IncrementFailedLoginAttempts(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IncrementFailedLoginAttempts", reflect.TypeOf((*MockStore)(nil).IncrementFailedLoginAttempts), arg0, arg1)
+}
+
 // IncrementLandlordPropertyCount mocks base method.
 func (m *MockStore) IncrementLandlordPropertyCount(arg0 context.Context, arg1 int64) error {
 	m.ctrl.T.Helper()
@@ -4968,61 +5753,136 @@ func (mr *MockStoreMockRecorder) LandlordSignAgreement(arg0, arg1 interface{}) *
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LandlordSignAgreement", reflect.TypeOf((*MockStore)(nil).LandlordSignAgreement), arg0, arg1)
 }
 
-// ListApprovedAgentsByArea mocks base method.
-func (m *MockStore) ListApprovedAgentsByArea(arg0 context.Context, arg1 db.ListApprovedAgentsByAreaParams) ([]db.ListApprovedAgentsByAreaRow, error) {
+// ListActiveUsersAfterID mocks base method.
+func (m *MockStore) ListActiveUsersAfterID(arg0 context.Context, arg1 db.ListActiveUsersAfterIDParams) ([]db.User, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ListApprovedAgentsByArea", arg0, arg1)
-	ret0, _ := ret[0].([]db.ListApprovedAgentsByAreaRow)
+	ret := m.ctrl.Call(m, "ListActiveUsersAfterID", arg0, arg1)
+	ret0, _ := ret[0].([]db.User)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// ListApprovedAgentsByArea indicates an expected call of ListApprovedAgentsByArea.
-func (mr *MockStoreMockRecorder) ListApprovedAgentsByArea(arg0, arg1 interface{}) *gomock.Call {
+// ListActiveUsersAfterID indicates an expected call of ListActiveUsersAfterID.
+func (mr *MockStoreMockRecorder) ListActiveUsersAfterID(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListApprovedAgentsByArea", reflect.TypeOf((*MockStore)(nil).ListApprovedAgentsByArea), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListActiveUsersAfterID", reflect.TypeOf((*MockStore)(nil).ListActiveUsersAfterID), arg0, arg1)
 }
 
-// ListFeaturedProperties mocks base method.
-func (m *MockStore) ListFeaturedProperties(arg0 context.Context, arg1 db.ListFeaturedPropertiesParams) ([]db.ListFeaturedPropertiesRow, error) {
+// ListAgentAvailabilityExceptions mocks base method.
+func (m *MockStore) ListAgentAvailabilityExceptions(arg0 context.Context, arg1 db.ListAgentAvailabilityExceptionsParams) ([]db.AgentAvailabilityException, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ListFeaturedProperties", arg0, arg1)
-	ret0, _ := ret[0].([]db.ListFeaturedPropertiesRow)
+	ret := m.ctrl.Call(m, "ListAgentAvailabilityExceptions", arg0, arg1)
+	ret0, _ := ret[0].([]db.AgentAvailabilityException)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// ListFeaturedProperties indicates an expected call of ListFeaturedProperties.
-func (mr *MockStoreMockRecorder) ListFeaturedProperties(arg0, arg1 interface{}) *gomock.Call {
+// ListAgentAvailabilityExceptions indicates an expected call of ListAgentAvailabilityExceptions.
+func (mr *MockStoreMockRecorder) ListAgentAvailabilityExceptions(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListFeaturedProperties", reflect.TypeOf((*MockStore)(nil).ListFeaturedProperties), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAgentAvailabilityExceptions", reflect.TypeOf((*MockStore)(nil).ListAgentAvailabilityExceptions), arg0, arg1)
 }
 
-// ListLandlordsByPropertyCount mocks base method.
-func (m *MockStore) ListLandlordsByPropertyCount(arg0 context.Context, arg1 db.ListLandlordsByPropertyCountParams) ([]db.ListLandlordsByPropertyCountRow, error) {
+// ListAgentAvailabilityWindows mocks base method.
+func (m *MockStore) ListAgentAvailabilityWindows(arg0 context.Context, arg1 int64) ([]db.AgentAvailabilityWindow, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ListLandlordsByPropertyCount", arg0, arg1)
-	ret0, _ := ret[0].([]db.ListLandlordsByPropertyCountRow)
+	ret := m.ctrl.Call(m, "ListAgentAvailabilityWindows", arg0, arg1)
+	ret0, _ := ret[0].([]db.AgentAvailabilityWindow)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// ListLandlordsByPropertyCount indicates an expected call of ListLandlordsByPropertyCount.
-func (mr *MockStoreMockRecorder) ListLandlordsByPropertyCount(arg0, arg1 interface{}) *gomock.Call {
+// ListAgentAvailabilityWindows indicates an expected call of ListAgentAvailabilityWindows.
+func (mr *MockStoreMockRecorder) ListAgentAvailabilityWindows(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListLandlordsByPropertyCount", reflect.TypeOf((*MockStore)(nil).ListLandlordsByPropertyCount), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAgentAvailabilityWindows", reflect.TypeOf((*MockStore)(nil).ListAgentAvailabilityWindows), arg0, arg1)
 }
 
-// ListPendingAgentApplications mocks base method.
-func (m *MockStore) ListPendingAgentApplications(arg0 context.Context, arg1 db.ListPendingAgentApplicationsParams) ([]db.ListPendingAgentApplicationsRow, error) {
+// ListAgentBookedInspections mocks base method.
+func (m *MockStore) ListAgentBookedInspections(arg0 context.Context, arg1 db.ListAgentBookedInspectionsParams) ([]db.ListAgentBookedInspectionsRow, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ListPendingAgentApplications", arg0, arg1)
-	ret0, _ := ret[0].([]db.ListPendingAgentApplicationsRow)
+	ret := m.ctrl.Call(m, "ListAgentBookedInspections", arg0, arg1)
+	ret0, _ := ret[0].([]db.ListAgentBookedInspectionsRow)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// ListPendingAgentApplications indicates an expected call of ListPendingAgentApplications.
+// ListAgentBookedInspections indicates an expected call of ListAgentBookedInspections.
+func (mr *MockStoreMockRecorder) ListAgentBookedInspections(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAgentBookedInspections", reflect.TypeOf((*MockStore)(nil).ListAgentBookedInspections), arg0, arg1)
+}
+
+// ListApprovedAgentsByArea mocks base method.
+func (m *MockStore) ListApprovedAgentsByArea(arg0 context.Context, arg1 db.ListApprovedAgentsByAreaParams) ([]db.ListApprovedAgentsByAreaRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListApprovedAgentsByArea", arg0, arg1)
+	ret0, _ := ret[0].([]db.ListApprovedAgentsByAreaRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListApprovedAgentsByArea indicates an expected call of ListApprovedAgentsByArea.
+func (mr *MockStoreMockRecorder) ListApprovedAgentsByArea(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListApprovedAgentsByArea", reflect.TypeOf((*MockStore)(nil).ListApprovedAgentsByArea), arg0, arg1)
+}
+
+// ListDueAccountDeletions mocks base method.
+func (m *MockStore) ListDueAccountDeletions(arg0 context.Context, arg1 int32) ([]db.AccountDeletion, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListDueAccountDeletions", arg0, arg1)
+	ret0, _ := ret[0].([]db.AccountDeletion)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListDueAccountDeletions indicates an expected call of ListDueAccountDeletions.
+func (mr *MockStoreMockRecorder) ListDueAccountDeletions(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListDueAccountDeletions", reflect.TypeOf((*MockStore)(nil).ListDueAccountDeletions), arg0, arg1)
+}
+
+// ListFeaturedProperties mocks base method.
+func (m *MockStore) ListFeaturedProperties(arg0 context.Context, arg1 db.ListFeaturedPropertiesParams) ([]db.ListFeaturedPropertiesRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListFeaturedProperties", arg0, arg1)
+	ret0, _ := ret[0].([]db.ListFeaturedPropertiesRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListFeaturedProperties indicates an expected call of ListFeaturedProperties.
+func (mr *MockStoreMockRecorder) ListFeaturedProperties(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListFeaturedProperties", reflect.TypeOf((*MockStore)(nil).ListFeaturedProperties), arg0, arg1)
+}
+
+// ListLandlordsByPropertyCount mocks base method.
+func (m *MockStore) ListLandlordsByPropertyCount(arg0 context.Context, arg1 db.ListLandlordsByPropertyCountParams) ([]db.ListLandlordsByPropertyCountRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListLandlordsByPropertyCount", arg0, arg1)
+	ret0, _ := ret[0].([]db.ListLandlordsByPropertyCountRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListLandlordsByPropertyCount indicates an expected call of ListLandlordsByPropertyCount.
+func (mr *MockStoreMockRecorder) ListLandlordsByPropertyCount(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListLandlordsByPropertyCount", reflect.TypeOf((*MockStore)(nil).ListLandlordsByPropertyCount), arg0, arg1)
+}
+
+// ListPendingAgentApplications mocks base method.
+func (m *MockStore) ListPendingAgentApplications(arg0 context.Context, arg1 db.ListPendingAgentApplicationsParams) ([]db.ListPendingAgentApplicationsRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListPendingAgentApplications", arg0, arg1)
+	ret0, _ := ret[0].([]db.ListPendingAgentApplicationsRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListPendingAgentApplications indicates an expected call of ListPendingAgentApplications.
 func (mr *MockStoreMockRecorder) ListPendingAgentApplications(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPendingAgentApplications", reflect.TypeOf((*MockStore)(nil).ListPendingAgentApplications), arg0, arg1)
@@ -5088,6 +5948,36 @@ func (mr *MockStoreMockRecorder) ListPropertiesByLocation(arg0, arg1 interface{}
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPropertiesByLocation", reflect.TypeOf((*MockStore)(nil).ListPropertiesByLocation), arg0, arg1)
 }
 
+// ListPropertyPinsInBounds mocks base method.
+func (m *MockStore) ListPropertyPinsInBounds(arg0 context.Context, arg1 db.ListPropertyPinsInBoundsParams) ([]db.ListPropertyPinsInBoundsRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListPropertyPinsInBounds", arg0, arg1)
+	ret0, _ := ret[0].([]db.ListPropertyPinsInBoundsRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListPropertyPinsInBounds indicates an expected call of ListPropertyPinsInBounds.
+func (mr *MockStoreMockRecorder) ListPropertyPinsInBounds(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPropertyPinsInBounds", reflect.TypeOf((*MockStore)(nil).ListPropertyPinsInBounds), arg0, arg1)
+}
+
+// ListPropertySearchResults mocks base method.
+func (m *MockStore) ListPropertySearchResults(arg0 context.Context, arg1 db.ListPropertySearchResultsParams) ([]db.ListPropertySearchResultsRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListPropertySearchResults", arg0, arg1)
+	ret0, _ := ret[0].([]db.ListPropertySearchResultsRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListPropertySearchResults indicates an expected call of ListPropertySearchResults.
+func (mr *MockStoreMockRecorder) ListPropertySearchResults(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPropertySearchResults", reflect.TypeOf((*MockStore)(nil).ListPropertySearchResults), arg0, arg1)
+}
+
 // ListRecentProperties mocks base method.
 func (m *MockStore) ListRecentProperties(arg0 context.Context, arg1 db.ListRecentPropertiesParams) ([]db.ListRecentPropertiesRow, error) {
 	m.ctrl.T.Helper()
@@ -5118,6 +6008,36 @@ func (mr *MockStoreMockRecorder) ListRecentPropertyReviews(arg0, arg1 interface{
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListRecentPropertyReviews", reflect.TypeOf((*MockStore)(nil).ListRecentPropertyReviews), arg0, arg1)
 }
 
+// ListRentalApplicationDocuments mocks base method.
+func (m *MockStore) ListRentalApplicationDocuments(arg0 context.Context, arg1 int64) ([]db.ListRentalApplicationDocumentsRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListRentalApplicationDocuments", arg0, arg1)
+	ret0, _ := ret[0].([]db.ListRentalApplicationDocumentsRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListRentalApplicationDocuments indicates an expected call of ListRentalApplicationDocuments.
+func (mr *MockStoreMockRecorder) ListRentalApplicationDocuments(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListRentalApplicationDocuments", reflect.TypeOf((*MockStore)(nil).ListRentalApplicationDocuments), arg0, arg1)
+}
+
+// ListTenantDocuments mocks base method.
+func (m *MockStore) ListTenantDocuments(arg0 context.Context, arg1 int64) ([]db.TenantDocument, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListTenantDocuments", arg0, arg1)
+	ret0, _ := ret[0].([]db.TenantDocument)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListTenantDocuments indicates an expected call of ListTenantDocuments.
+func (mr *MockStoreMockRecorder) ListTenantDocuments(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListTenantDocuments", reflect.TypeOf((*MockStore)(nil).ListTenantDocuments), arg0, arg1)
+}
+
 // ListTopAgentsByRating mocks base method.
 func (m *MockStore) ListTopAgentsByRating(arg0 context.Context, arg1 db.ListTopAgentsByRatingParams) ([]db.ListTopAgentsByRatingRow, error) {
 	m.ctrl.T.Helper()
@@ -5148,19 +6068,19 @@ func (mr *MockStoreMockRecorder) ListTopLandlordsByRating(arg0, arg1 interface{}
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListTopLandlordsByRating", reflect.TypeOf((*MockStore)(nil).ListTopLandlordsByRating), arg0, arg1)
 }
 
-// ListUsersByType mocks base method.
-func (m *MockStore) ListUsersByType(arg0 context.Context, arg1 db.ListUsersByTypeParams) ([]db.User, error) {
+// ListUserIdentitiesByUserID mocks base method.
+func (m *MockStore) ListUserIdentitiesByUserID(arg0 context.Context, arg1 int64) ([]db.UserIdentity, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ListUsersByType", arg0, arg1)
-	ret0, _ := ret[0].([]db.User)
+	ret := m.ctrl.Call(m, "ListUserIdentitiesByUserID", arg0, arg1)
+	ret0, _ := ret[0].([]db.UserIdentity)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// ListUsersByType indicates an expected call of ListUsersByType.
-func (mr *MockStoreMockRecorder) ListUsersByType(arg0, arg1 interface{}) *gomock.Call {
+// ListUserIdentitiesByUserID indicates an expected call of ListUserIdentitiesByUserID.
+func (mr *MockStoreMockRecorder) ListUserIdentitiesByUserID(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListUsersByType", reflect.TypeOf((*MockStore)(nil).ListUsersByType), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListUserIdentitiesByUserID", reflect.TypeOf((*MockStore)(nil).ListUserIdentitiesByUserID), arg0, arg1)
 }
 
 // ListVerificationsByTypeAndStatus mocks base method.
@@ -5178,6 +6098,21 @@ func (mr *MockStoreMockRecorder) ListVerificationsByTypeAndStatus(arg0, arg1 int
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListVerificationsByTypeAndStatus", reflect.TypeOf((*MockStore)(nil).ListVerificationsByTypeAndStatus), arg0, arg1)
 }
 
+// LockAccount mocks base method.
+func (m *MockStore) LockAccount(arg0 context.Context, arg1 db.LockAccountParams) (db.AccountLockout, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LockAccount", arg0, arg1)
+	ret0, _ := ret[0].(db.AccountLockout)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LockAccount indicates an expected call of LockAccount.
+func (mr *MockStoreMockRecorder) LockAccount(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LockAccount", reflect.TypeOf((*MockStore)(nil).LockAccount), arg0, arg1)
+}
+
 // MarkAllUserNotificationsAsRead mocks base method.
 func (m *MockStore) MarkAllUserNotificationsAsRead(arg0 context.Context, arg1 int64) error {
 	m.ctrl.T.Helper()
@@ -5262,6 +6197,21 @@ func (mr *MockStoreMockRecorder) MarkNotificationsAsRead(arg0, arg1 interface{})
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkNotificationsAsRead", reflect.TypeOf((*MockStore)(nil).MarkNotificationsAsRead), arg0, arg1)
 }
 
+// MarkUserSessionRotated mocks base method.
+func (m *MockStore) MarkUserSessionRotated(arg0 context.Context, arg1 db.MarkUserSessionRotatedParams) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkUserSessionRotated", arg0, arg1)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MarkUserSessionRotated indicates an expected call of MarkUserSessionRotated.
+func (mr *MockStoreMockRecorder) MarkUserSessionRotated(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkUserSessionRotated", reflect.TypeOf((*MockStore)(nil).MarkUserSessionRotated), arg0, arg1)
+}
+
 // ProcessPayment mocks base method.
 func (m *MockStore) ProcessPayment(arg0 context.Context, arg1 db.ProcessPaymentParams) (db.Payment, error) {
 	m.ctrl.T.Helper()
@@ -5277,6 +6227,92 @@ func (mr *MockStoreMockRecorder) ProcessPayment(arg0, arg1 interface{}) *gomock.
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProcessPayment", reflect.TypeOf((*MockStore)(nil).ProcessPayment), arg0, arg1)
 }
 
+// PurgeAccountExport mocks base method.
+func (m *MockStore) PurgeAccountExport(arg0 context.Context, arg1 int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PurgeAccountExport", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// PurgeAccountExport indicates an expected call of PurgeAccountExport.
+func (mr *MockStoreMockRecorder) PurgeAccountExport(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PurgeAccountExport", reflect.TypeOf((*MockStore)(nil).PurgeAccountExport), arg0, arg1)
+}
+
+// RecordFailedLoginTx mocks base method.
+func (m *MockStore) RecordFailedLoginTx(arg0 context.Context, arg1 db.RecordFailedLoginTxParams) (db.RecordFailedLoginTxResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RecordFailedLoginTx", arg0, arg1)
+	ret0, _ := ret[0].(db.RecordFailedLoginTxResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RecordFailedLoginTx indicates an expected call of RecordFailedLoginTx.
+func (mr *MockStoreMockRecorder) RecordFailedLoginTx(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecordFailedLoginTx", reflect.TypeOf((*MockStore)(nil).RecordFailedLoginTx), arg0, arg1)
+}
+
+// RecordSearchCacheHits mocks base method.
+func (m *MockStore) RecordSearchCacheHits(arg0 context.Context, arg1 db.RecordSearchCacheHitsParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RecordSearchCacheHits", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RecordSearchCacheHits indicates an expected call of RecordSearchCacheHits.
+func (mr *MockStoreMockRecorder) RecordSearchCacheHits(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecordSearchCacheHits", reflect.TypeOf((*MockStore)(nil).RecordSearchCacheHits), arg0, arg1)
+}
+
+// RecordSuccessfulLoginTx mocks base method.
+func (m *MockStore) RecordSuccessfulLoginTx(arg0 context.Context, arg1 db.RecordSuccessfulLoginTxParams) (db.RecordSuccessfulLoginTxResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RecordSuccessfulLoginTx", arg0, arg1)
+	ret0, _ := ret[0].(db.RecordSuccessfulLoginTxResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RecordSuccessfulLoginTx indicates an expected call of RecordSuccessfulLoginTx.
+func (mr *MockStoreMockRecorder) RecordSuccessfulLoginTx(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecordSuccessfulLoginTx", reflect.TypeOf((*MockStore)(nil).RecordSuccessfulLoginTx), arg0, arg1)
+}
+
+// RedactMessagesBySender mocks base method.
+func (m *MockStore) RedactMessagesBySender(arg0 context.Context, arg1 int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RedactMessagesBySender", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RedactMessagesBySender indicates an expected call of RedactMessagesBySender.
+func (mr *MockStoreMockRecorder) RedactMessagesBySender(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RedactMessagesBySender", reflect.TypeOf((*MockStore)(nil).RedactMessagesBySender), arg0, arg1)
+}
+
+// RedactTenantRentalApplications mocks base method.
+func (m *MockStore) RedactTenantRentalApplications(arg0 context.Context, arg1 int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RedactTenantRentalApplications", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RedactTenantRentalApplications indicates an expected call of RedactTenantRentalApplications.
+func (mr *MockStoreMockRecorder) RedactTenantRentalApplications(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RedactTenantRentalApplications", reflect.TypeOf((*MockStore)(nil).RedactTenantRentalApplications), arg0, arg1)
+}
+
 // RefundPayment mocks base method.
 func (m *MockStore) RefundPayment(arg0 context.Context, arg1 db.RefundPaymentParams) (db.Payment, error) {
 	m.ctrl.T.Helper()
@@ -5293,11 +6329,12 @@ func (mr *MockStoreMockRecorder) RefundPayment(arg0, arg1 interface{}) *gomock.C
 }
 
 // RejectInspectionAgent mocks base method.
-func (m *MockStore) RejectInspectionAgent(arg0 context.Context, arg1 int64) error {
+func (m *MockStore) RejectInspectionAgent(arg0 context.Context, arg1 db.RejectInspectionAgentParams) (db.InspectionAgentProfile, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "RejectInspectionAgent", arg0, arg1)
-	ret0, _ := ret[0].(error)
-	return ret0
+	ret0, _ := ret[0].(db.InspectionAgentProfile)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
 // RejectInspectionAgent indicates an expected call of RejectInspectionAgent.
@@ -5335,6 +6372,20 @@ func (mr *MockStoreMockRecorder) RejectRentalApplication(arg0, arg1 interface{})
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RejectRentalApplication", reflect.TypeOf((*MockStore)(nil).RejectRentalApplication), arg0, arg1)
 }
 
+// ResetAccountLockout mocks base method.
+func (m *MockStore) ResetAccountLockout(arg0 context.Context, arg1 int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResetAccountLockout", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ResetAccountLockout indicates an expected call of ResetAccountLockout.
+func (mr *MockStoreMockRecorder) ResetAccountLockout(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResetAccountLockout", reflect.TypeOf((*MockStore)(nil).ResetAccountLockout), arg0, arg1)
+}
+
 // ResolveDispute mocks base method.
 func (m *MockStore) ResolveDispute(arg0 context.Context, arg1 db.ResolveDisputeParams) (db.DisputeCase, error) {
 	m.ctrl.T.Helper()
@@ -5365,6 +6416,66 @@ func (mr *MockStoreMockRecorder) RespondToInquiry(arg0, arg1 interface{}) *gomoc
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RespondToInquiry", reflect.TypeOf((*MockStore)(nil).RespondToInquiry), arg0, arg1)
 }
 
+// ReviewAgentApplicationTx mocks base method.
+func (m *MockStore) ReviewAgentApplicationTx(arg0 context.Context, arg1 db.ReviewAgentApplicationTxParams) (db.ReviewAgentApplicationTxResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReviewAgentApplicationTx", arg0, arg1)
+	ret0, _ := ret[0].(db.ReviewAgentApplicationTxResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReviewAgentApplicationTx indicates an expected call of ReviewAgentApplicationTx.
+func (mr *MockStoreMockRecorder) ReviewAgentApplicationTx(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReviewAgentApplicationTx", reflect.TypeOf((*MockStore)(nil).ReviewAgentApplicationTx), arg0, arg1)
+}
+
+// RevokeDocumentAccessGrant mocks base method.
+func (m *MockStore) RevokeDocumentAccessGrant(arg0 context.Context, arg1 db.RevokeDocumentAccessGrantParams) (db.DocumentAccessGrant, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeDocumentAccessGrant", arg0, arg1)
+	ret0, _ := ret[0].(db.DocumentAccessGrant)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RevokeDocumentAccessGrant indicates an expected call of RevokeDocumentAccessGrant.
+func (mr *MockStoreMockRecorder) RevokeDocumentAccessGrant(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeDocumentAccessGrant", reflect.TypeOf((*MockStore)(nil).RevokeDocumentAccessGrant), arg0, arg1)
+}
+
+// RevokeSessionFamilyTx mocks base method.
+func (m *MockStore) RevokeSessionFamilyTx(arg0 context.Context, arg1 db.RevokeSessionFamilyTxParams) (db.RevokeSessionFamilyTxResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeSessionFamilyTx", arg0, arg1)
+	ret0, _ := ret[0].(db.RevokeSessionFamilyTxResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RevokeSessionFamilyTx indicates an expected call of RevokeSessionFamilyTx.
+func (mr *MockStoreMockRecorder) RevokeSessionFamilyTx(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeSessionFamilyTx", reflect.TypeOf((*MockStore)(nil).RevokeSessionFamilyTx), arg0, arg1)
+}
+
+// RotateSessionTx mocks base method.
+func (m *MockStore) RotateSessionTx(arg0 context.Context, arg1 db.RotateSessionTxParams) (db.RotateSessionTxResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RotateSessionTx", arg0, arg1)
+	ret0, _ := ret[0].(db.RotateSessionTxResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RotateSessionTx indicates an expected call of RotateSessionTx.
+func (mr *MockStoreMockRecorder) RotateSessionTx(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RotateSessionTx", reflect.TypeOf((*MockStore)(nil).RotateSessionTx), arg0, arg1)
+}
+
 // SaveProperty mocks base method.
 func (m *MockStore) SaveProperty(arg0 context.Context, arg1 db.SavePropertyParams) (db.SavedProperty, error) {
 	m.ctrl.T.Helper()
@@ -5380,6 +6491,21 @@ func (mr *MockStoreMockRecorder) SaveProperty(arg0, arg1 interface{}) *gomock.Ca
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveProperty", reflect.TypeOf((*MockStore)(nil).SaveProperty), arg0, arg1)
 }
 
+// ScheduleAccountDeletionTx mocks base method.
+func (m *MockStore) ScheduleAccountDeletionTx(arg0 context.Context, arg1 db.ScheduleAccountDeletionTxParams) (db.ScheduleAccountDeletionTxResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ScheduleAccountDeletionTx", arg0, arg1)
+	ret0, _ := ret[0].(db.ScheduleAccountDeletionTxResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ScheduleAccountDeletionTx indicates an expected call of ScheduleAccountDeletionTx.
+func (mr *MockStoreMockRecorder) ScheduleAccountDeletionTx(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ScheduleAccountDeletionTx", reflect.TypeOf((*MockStore)(nil).ScheduleAccountDeletionTx), arg0, arg1)
+}
+
 // SearchCacheEntries mocks base method.
 func (m *MockStore) SearchCacheEntries(arg0 context.Context, arg1 db.SearchCacheEntriesParams) ([]db.PropertySearchCache, error) {
 	m.ctrl.T.Helper()
@@ -5440,6 +6566,36 @@ func (mr *MockStoreMockRecorder) SearchProperties(arg0, arg1 interface{}) *gomoc
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SearchProperties", reflect.TypeOf((*MockStore)(nil).SearchProperties), arg0, arg1)
 }
 
+// SearchPropertiesNearby mocks base method.
+func (m *MockStore) SearchPropertiesNearby(arg0 context.Context, arg1 db.SearchPropertiesNearbyParams) ([]db.SearchPropertiesNearbyRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SearchPropertiesNearby", arg0, arg1)
+	ret0, _ := ret[0].([]db.SearchPropertiesNearbyRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SearchPropertiesNearby indicates an expected call of SearchPropertiesNearby.
+func (mr *MockStoreMockRecorder) SearchPropertiesNearby(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SearchPropertiesNearby", reflect.TypeOf((*MockStore)(nil).SearchPropertiesNearby), arg0, arg1)
+}
+
+// SearchPropertyFacets mocks base method.
+func (m *MockStore) SearchPropertyFacets(arg0 context.Context, arg1 db.SearchPropertyFacetsParams) ([]db.SearchPropertyFacetsRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SearchPropertyFacets", arg0, arg1)
+	ret0, _ := ret[0].([]db.SearchPropertyFacetsRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SearchPropertyFacets indicates an expected call of SearchPropertyFacets.
+func (mr *MockStoreMockRecorder) SearchPropertyFacets(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SearchPropertyFacets", reflect.TypeOf((*MockStore)(nil).SearchPropertyFacets), arg0, arg1)
+}
+
 // SearchSettings mocks base method.
 func (m *MockStore) SearchSettings(arg0 context.Context, arg1 db.SearchSettingsParams) ([]db.SystemSetting, error) {
 	m.ctrl.T.Helper()
@@ -5470,19 +6626,19 @@ func (mr *MockStoreMockRecorder) SearchTenantProfiles(arg0, arg1 interface{}) *g
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SearchTenantProfiles", reflect.TypeOf((*MockStore)(nil).SearchTenantProfiles), arg0, arg1)
 }
 
-// SearchUsers mocks base method.
-func (m *MockStore) SearchUsers(arg0 context.Context, arg1 db.SearchUsersParams) ([]db.User, error) {
+// SetAgentAvailabilityTx mocks base method.
+func (m *MockStore) SetAgentAvailabilityTx(arg0 context.Context, arg1 db.SetAgentAvailabilityTxParams) (db.SetAgentAvailabilityTxResult, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "SearchUsers", arg0, arg1)
-	ret0, _ := ret[0].([]db.User)
+	ret := m.ctrl.Call(m, "SetAgentAvailabilityTx", arg0, arg1)
+	ret0, _ := ret[0].(db.SetAgentAvailabilityTxResult)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// SearchUsers indicates an expected call of SearchUsers.
-func (mr *MockStoreMockRecorder) SearchUsers(arg0, arg1 interface{}) *gomock.Call {
+// SetAgentAvailabilityTx indicates an expected call of SetAgentAvailabilityTx.
+func (mr *MockStoreMockRecorder) SetAgentAvailabilityTx(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SearchUsers", reflect.TypeOf((*MockStore)(nil).SearchUsers), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetAgentAvailabilityTx", reflect.TypeOf((*MockStore)(nil).SetAgentAvailabilityTx), arg0, arg1)
 }
 
 // SetPrimaryMedia mocks base method.
@@ -5499,6 +6655,96 @@ func (mr *MockStoreMockRecorder) SetPrimaryMedia(arg0, arg1 interface{}) *gomock
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetPrimaryMedia", reflect.TypeOf((*MockStore)(nil).SetPrimaryMedia), arg0, arg1)
 }
 
+// SetUserActiveStatusTx mocks base method.
+func (m *MockStore) SetUserActiveStatusTx(arg0 context.Context, arg1 db.SetUserActiveStatusTxParams) (db.SetUserActiveStatusTxResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetUserActiveStatusTx", arg0, arg1)
+	ret0, _ := ret[0].(db.SetUserActiveStatusTxResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetUserActiveStatusTx indicates an expected call of SetUserActiveStatusTx.
+func (mr *MockStoreMockRecorder) SetUserActiveStatusTx(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetUserActiveStatusTx", reflect.TypeOf((*MockStore)(nil).SetUserActiveStatusTx), arg0, arg1)
+}
+
+// ShareApplicationDocumentsTx mocks base method.
+func (m *MockStore) ShareApplicationDocumentsTx(arg0 context.Context, arg1 db.ShareApplicationDocumentsTxParams) (db.ShareApplicationDocumentsTxResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ShareApplicationDocumentsTx", arg0, arg1)
+	ret0, _ := ret[0].(db.ShareApplicationDocumentsTxResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ShareApplicationDocumentsTx indicates an expected call of ShareApplicationDocumentsTx.
+func (mr *MockStoreMockRecorder) ShareApplicationDocumentsTx(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ShareApplicationDocumentsTx", reflect.TypeOf((*MockStore)(nil).ShareApplicationDocumentsTx), arg0, arg1)
+}
+
+// SubmitAgentApplicationTx mocks base method.
+func (m *MockStore) SubmitAgentApplicationTx(arg0 context.Context, arg1 db.SubmitAgentApplicationTxParams) (db.SubmitAgentApplicationTxResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SubmitAgentApplicationTx", arg0, arg1)
+	ret0, _ := ret[0].(db.SubmitAgentApplicationTxResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SubmitAgentApplicationTx indicates an expected call of SubmitAgentApplicationTx.
+func (mr *MockStoreMockRecorder) SubmitAgentApplicationTx(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SubmitAgentApplicationTx", reflect.TypeOf((*MockStore)(nil).SubmitAgentApplicationTx), arg0, arg1)
+}
+
+// SubmitInspectionAgentApplication mocks base method.
+func (m *MockStore) SubmitInspectionAgentApplication(arg0 context.Context, arg1 db.SubmitInspectionAgentApplicationParams) (db.InspectionAgentProfile, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SubmitInspectionAgentApplication", arg0, arg1)
+	ret0, _ := ret[0].(db.InspectionAgentProfile)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SubmitInspectionAgentApplication indicates an expected call of SubmitInspectionAgentApplication.
+func (mr *MockStoreMockRecorder) SubmitInspectionAgentApplication(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SubmitInspectionAgentApplication", reflect.TypeOf((*MockStore)(nil).SubmitInspectionAgentApplication), arg0, arg1)
+}
+
+// SubmitNINVerificationTx mocks base method.
+func (m *MockStore) SubmitNINVerificationTx(arg0 context.Context, arg1 db.SubmitNINVerificationTxParams) (db.SubmitNINVerificationTxResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SubmitNINVerificationTx", arg0, arg1)
+	ret0, _ := ret[0].(db.SubmitNINVerificationTxResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SubmitNINVerificationTx indicates an expected call of SubmitNINVerificationTx.
+func (mr *MockStoreMockRecorder) SubmitNINVerificationTx(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SubmitNINVerificationTx", reflect.TypeOf((*MockStore)(nil).SubmitNINVerificationTx), arg0, arg1)
+}
+
+// SwapVerificationData mocks base method.
+func (m *MockStore) SwapVerificationData(arg0 context.Context, arg1 db.SwapVerificationDataParams) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SwapVerificationData", arg0, arg1)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SwapVerificationData indicates an expected call of SwapVerificationData.
+func (mr *MockStoreMockRecorder) SwapVerificationData(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SwapVerificationData", reflect.TypeOf((*MockStore)(nil).SwapVerificationData), arg0, arg1)
+}
+
 // TenantSignAgreement mocks base method.
 func (m *MockStore) TenantSignAgreement(arg0 context.Context, arg1 int64) (db.RentalAgreement, error) {
 	m.ctrl.T.Helper()
@@ -5529,6 +6775,36 @@ func (mr *MockStoreMockRecorder) TerminateAgreement(arg0, arg1 interface{}) *gom
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TerminateAgreement", reflect.TypeOf((*MockStore)(nil).TerminateAgreement), arg0, arg1)
 }
 
+// TouchUserIdentity mocks base method.
+func (m *MockStore) TouchUserIdentity(arg0 context.Context, arg1 db.TouchUserIdentityParams) (db.UserIdentity, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TouchUserIdentity", arg0, arg1)
+	ret0, _ := ret[0].(db.UserIdentity)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// TouchUserIdentity indicates an expected call of TouchUserIdentity.
+func (mr *MockStoreMockRecorder) TouchUserIdentity(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TouchUserIdentity", reflect.TypeOf((*MockStore)(nil).TouchUserIdentity), arg0, arg1)
+}
+
+// UnlockAccountTx mocks base method.
+func (m *MockStore) UnlockAccountTx(arg0 context.Context, arg1 db.UnlockAccountTxParams) (db.UnlockAccountTxResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UnlockAccountTx", arg0, arg1)
+	ret0, _ := ret[0].(db.UnlockAccountTxResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UnlockAccountTx indicates an expected call of UnlockAccountTx.
+func (mr *MockStoreMockRecorder) UnlockAccountTx(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UnlockAccountTx", reflect.TypeOf((*MockStore)(nil).UnlockAccountTx), arg0, arg1)
+}
+
 // UnsaveProperty mocks base method.
 func (m *MockStore) UnsaveProperty(arg0 context.Context, arg1 db.UnsavePropertyParams) error {
 	m.ctrl.T.Helper()
@@ -5632,21 +6908,6 @@ func (mr *MockStoreMockRecorder) UpdateAgreementStatus(arg0, arg1 interface{}) *
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateAgreementStatus", reflect.TypeOf((*MockStore)(nil).UpdateAgreementStatus), arg0, arg1)
 }
 
-// UpdateApplicationDocuments mocks base method.
-func (m *MockStore) UpdateApplicationDocuments(arg0 context.Context, arg1 db.UpdateApplicationDocumentsParams) (db.RentalApplication, error) {
-	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UpdateApplicationDocuments", arg0, arg1)
-	ret0, _ := ret[0].(db.RentalApplication)
-	ret1, _ := ret[1].(error)
-	return ret0, ret1
-}
-
-// UpdateApplicationDocuments indicates an expected call of UpdateApplicationDocuments.
-func (mr *MockStoreMockRecorder) UpdateApplicationDocuments(arg0, arg1 interface{}) *gomock.Call {
-	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateApplicationDocuments", reflect.TypeOf((*MockStore)(nil).UpdateApplicationDocuments), arg0, arg1)
-}
-
 // UpdateApplicationEmploymentDetails mocks base method.
 func (m *MockStore) UpdateApplicationEmploymentDetails(arg0 context.Context, arg1 db.UpdateApplicationEmploymentDetailsParams) (db.RentalApplication, error) {
 	m.ctrl.T.Helper()
@@ -6268,6 +7529,20 @@ func (mr *MockStoreMockRecorder) UpdateUserActiveStatus(arg0, arg1 interface{})
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateUserActiveStatus", reflect.TypeOf((*MockStore)(nil).UpdateUserActiveStatus), arg0, arg1)
 }
 
+// UpdateUserIdentityVerified mocks base method.
+func (m *MockStore) UpdateUserIdentityVerified(arg0 context.Context, arg1 db.UpdateUserIdentityVerifiedParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateUserIdentityVerified", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateUserIdentityVerified indicates an expected call of UpdateUserIdentityVerified.
+func (mr *MockStoreMockRecorder) UpdateUserIdentityVerified(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateUserIdentityVerified", reflect.TypeOf((*MockStore)(nil).UpdateUserIdentityVerified), arg0, arg1)
+}
+
 // UpdateUserLastLogin mocks base method.
 func (m *MockStore) UpdateUserLastLogin(arg0 context.Context, arg1 int64) error {
 	m.ctrl.T.Helper()
@@ -6282,6 +7557,20 @@ func (mr *MockStoreMockRecorder) UpdateUserLastLogin(arg0, arg1 interface{}) *go
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateUserLastLogin", reflect.TypeOf((*MockStore)(nil).UpdateUserLastLogin), arg0, arg1)
 }
 
+// UpdateUserNIN mocks base method.
+func (m *MockStore) UpdateUserNIN(arg0 context.Context, arg1 db.UpdateUserNINParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateUserNIN", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateUserNIN indicates an expected call of UpdateUserNIN.
+func (mr *MockStoreMockRecorder) UpdateUserNIN(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateUserNIN", reflect.TypeOf((*MockStore)(nil).UpdateUserNIN), arg0, arg1)
+}
+
 // UpdateUserPassword mocks base method.
 func (m *MockStore) UpdateUserPassword(arg0 context.Context, arg1 db.UpdateUserPasswordParams) error {
 	m.ctrl.T.Helper()
@@ -6311,6 +7600,20 @@ func (mr *MockStoreMockRecorder) UpdateUserRating(arg0, arg1 interface{}) *gomoc
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateUserRating", reflect.TypeOf((*MockStore)(nil).UpdateUserRating), arg0, arg1)
 }
 
+// UpdateUserType mocks base method.
+func (m *MockStore) UpdateUserType(arg0 context.Context, arg1 db.UpdateUserTypeParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateUserType", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateUserType indicates an expected call of UpdateUserType.
+func (mr *MockStoreMockRecorder) UpdateUserType(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateUserType", reflect.TypeOf((*MockStore)(nil).UpdateUserType), arg0, arg1)
+}
+
 // UpdateUserVerificationStatus mocks base method.
 func (m *MockStore) UpdateUserVerificationStatus(arg0 context.Context, arg1 db.UpdateUserVerificationStatusParams) error {
 	m.ctrl.T.Helper()
@@ -6355,6 +7658,21 @@ func (mr *MockStoreMockRecorder) UpdateVerificationStatus(arg0, arg1 interface{}
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateVerificationStatus", reflect.TypeOf((*MockStore)(nil).UpdateVerificationStatus), arg0, arg1)
 }
 
+// UpsertPropertySearchCache mocks base method.
+func (m *MockStore) UpsertPropertySearchCache(arg0 context.Context, arg1 db.UpsertPropertySearchCacheParams) (db.PropertySearchCache, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpsertPropertySearchCache", arg0, arg1)
+	ret0, _ := ret[0].(db.PropertySearchCache)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpsertPropertySearchCache indicates an expected call of UpsertPropertySearchCache.
+func (mr *MockStoreMockRecorder) UpsertPropertySearchCache(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpsertPropertySearchCache", reflect.TypeOf((*MockStore)(nil).UpsertPropertySearchCache), arg0, arg1)
+}
+
 // VerifyEmailTx mocks base method.
 func (m *MockStore) VerifyEmailTx(arg0 context.Context, arg1 db.VerifyEmailTxParams) (db.VerifyEmailTxResult, error) {
 	m.ctrl.T.Helper()
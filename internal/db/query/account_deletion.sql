@@ -0,0 +1,39 @@
+-- Schedule the deletion of an account
+-- name: CreateAccountDeletion :one
+INSERT INTO account_deletions (
+  user_id, reason, scheduled_for
+) VALUES (
+  $1, $2, $3
+) RETURNING *;
+
+-- Get account deletion by ID
+-- name: GetAccountDeletion :one
+SELECT * FROM account_deletions
+WHERE id = $1 LIMIT 1;
+
+-- Get the deletion of a user that is still in its grace period
+-- name: GetPendingAccountDeletion :one
+SELECT * FROM account_deletions
+WHERE user_id = $1 AND status = 'pending'
+LIMIT 1;
+
+-- Cancel a deletion that is still in its grace period
+-- name: CancelAccountDeletion :one
+UPDATE account_deletions
+SET status = 'cancelled', cancelled_at = NOW()
+WHERE id = $1 AND status = 'pending'
+RETURNING *;
+
+-- Mark a deletion as carried out
+-- name: CompleteAccountDeletion :one
+UPDATE account_deletions
+SET status = 'completed', completed_at = NOW()
+WHERE id = $1 AND status = 'pending'
+RETURNING *;
+
+-- List the deletions whose grace period has ended, oldest first
+-- name: ListDueAccountDeletions :many
+SELECT * FROM account_deletions
+WHERE status = 'pending' AND scheduled_for <= NOW()
+ORDER BY scheduled_for
+LIMIT $1;
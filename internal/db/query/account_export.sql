@@ -0,0 +1,108 @@
+-- Create a pending account export
+-- name: CreateAccountExport :one
+INSERT INTO account_exports (
+  user_id
+) VALUES (
+  $1
+) RETURNING *;
+
+-- Get account export by ID
+-- name: GetAccountExport :one
+SELECT * FROM account_exports
+WHERE id = $1 LIMIT 1;
+
+-- Get the export of a user that is still being assembled
+-- name: GetPendingAccountExport :one
+SELECT * FROM account_exports
+WHERE user_id = $1 AND status = 'pending'
+ORDER BY created_at DESC
+LIMIT 1;
+
+-- Count the exports a user requested since the given time
+-- name: CountRecentAccountExports :one
+SELECT COUNT(*) FROM account_exports
+WHERE user_id = $1 AND created_at > $2;
+
+-- Store the assembled archive of a pending export
+-- name: CompleteAccountExport :one
+UPDATE account_exports
+SET status = 'completed', file_name = $2, content = $3, expires_at = $4, completed_at = NOW()
+WHERE id = $1 AND status = 'pending'
+RETURNING *;
+
+-- Mark a pending export as failed
+-- name: FailAccountExport :exec
+UPDATE account_exports
+SET status = 'failed', completed_at = NOW()
+WHERE id = $1 AND status = 'pending';
+
+-- Drop the archive of an export once its download link has expired
+-- name: PurgeAccountExport :exec
+UPDATE account_exports
+SET content = NULL
+WHERE id = $1;
+
+-- Collect everything we hold about a user as one JSON document
+-- name: GetAccountExportData :one
+SELECT json_build_object(
+  'user', (
+    SELECT row_to_json(u) FROM (
+      SELECT id, email, phone, first_name, last_name, user_type, is_verified, is_identity_verified, is_active,
+             profile_picture_url, created_at, updated_at, last_login
+      FROM users WHERE id = $1
+    ) u
+  ),
+  'identities', (
+    SELECT COALESCE(json_agg(i ORDER BY i.created_at), '[]'::json) FROM (
+      SELECT provider, email, created_at, last_used_at FROM user_identities WHERE user_id = $1
+    ) i
+  ),
+  'verifications', (
+    SELECT COALESCE(json_agg(v ORDER BY v.created_at), '[]'::json) FROM (
+      SELECT verification_type, verification_status, verified_at, created_at FROM user_verifications WHERE user_id = $1
+    ) v
+  ),
+  'tenant_profile', (SELECT row_to_json(tp) FROM tenant_profiles tp WHERE tp.user_id = $1),
+  'landlord_profile', (SELECT row_to_json(lp) FROM landlord_profiles lp WHERE lp.user_id = $1),
+  'inspection_agent_profile', (SELECT row_to_json(ap) FROM inspection_agent_profiles ap WHERE ap.user_id = $1),
+  'properties', (SELECT COALESCE(json_agg(p ORDER BY p.id), '[]'::json) FROM properties p WHERE p.landlord_id = $1),
+  'saved_properties', (SELECT COALESCE(json_agg(sp ORDER BY sp.id), '[]'::json) FROM saved_properties sp WHERE sp.tenant_id = $1),
+  'property_inquiries', (
+    SELECT COALESCE(json_agg(pi ORDER BY pi.id), '[]'::json) FROM property_inquiries pi
+    WHERE pi.tenant_id = $1 OR pi.landlord_id = $1
+  ),
+  'inspection_requests', (
+    SELECT COALESCE(json_agg(ir ORDER BY ir.id), '[]'::json) FROM inspection_requests ir
+    WHERE ir.tenant_id = $1 OR ir.landlord_id = $1 OR ir.inspection_agent_id = $1
+  ),
+  'rental_applications', (
+    SELECT COALESCE(json_agg(ra ORDER BY ra.id), '[]'::json) FROM rental_applications ra
+    WHERE ra.tenant_id = $1 OR ra.landlord_id = $1
+  ),
+  'documents', (
+    SELECT COALESCE(json_agg(d ORDER BY d.created_at), '[]'::json) FROM (
+      SELECT id, document_type, file_name, content_type, size_bytes, checksum, created_at FROM tenant_documents WHERE owner_id = $1
+    ) d
+  ),
+  'rental_agreements', (
+    SELECT COALESCE(json_agg(rg ORDER BY rg.id), '[]'::json) FROM rental_agreements rg
+    WHERE rg.tenant_id = $1 OR rg.landlord_id = $1
+  ),
+  'messages', (
+    SELECT COALESCE(json_agg(m ORDER BY m.id), '[]'::json) FROM messages m
+    WHERE m.sender_id = $1 OR m.recipient_id = $1
+  ),
+  'payments', (
+    SELECT COALESCE(json_agg(pm ORDER BY pm.id), '[]'::json) FROM (
+      SELECT id, payer_id, payee_id, payment_type, related_entity_type, related_entity_id, amount, currency,
+             payment_method, payment_reference, status, processed_at, refunded_at, refund_reason, created_at
+      FROM payments WHERE payer_id = $1 OR payee_id = $1
+    ) pm
+  ),
+  'ratings_given', (SELECT COALESCE(json_agg(rt ORDER BY rt.id), '[]'::json) FROM user_ratings rt WHERE rt.rater_id = $1),
+  'ratings_received', (SELECT COALESCE(json_agg(rr ORDER BY rr.id), '[]'::json) FROM user_ratings rr WHERE rr.rated_user_id = $1),
+  'community_reviews', (
+    SELECT COALESCE(json_agg(cr ORDER BY cr.id), '[]'::json) FROM property_community_reviews cr WHERE cr.user_id = $1
+  ),
+  'notifications', (SELECT COALESCE(json_agg(n ORDER BY n.id), '[]'::json) FROM notifications n WHERE n.user_id = $1)
+)::jsonb AS data;
@@ -0,0 +1,28 @@
+-- Get account lockout by user ID
+-- name: GetAccountLockout :one
+SELECT * FROM account_lockouts 
+WHERE user_id = $1 LIMIT 1;
+
+-- Increment failed login attempts
+-- name: IncrementFailedLoginAttempts :one
+INSERT INTO account_lockouts (
+  user_id, failed_attempts
+) VALUES (
+  $1, 1
+)
+ON CONFLICT (user_id) DO UPDATE
+SET failed_attempts = account_lockouts.failed_attempts + 1, updated_at = NOW()
+RETURNING *;
+
+-- Lock account until the given time
+-- name: LockAccount :one
+UPDATE account_lockouts 
+SET failed_attempts = 0, lockout_count = lockout_count + 1, locked_until = $2, updated_at = NOW()
+WHERE user_id = $1
+RETURNING *;
+
+-- Reset account lockout
+-- name: ResetAccountLockout :exec
+UPDATE account_lockouts 
+SET failed_attempts = 0, lockout_count = 0, locked_until = NULL, updated_at = NOW()
+WHERE user_id = $1;
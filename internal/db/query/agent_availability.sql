@@ -0,0 +1,39 @@
+-- Add a weekly availability window
+-- name: CreateAgentAvailabilityWindow :one
+INSERT INTO agent_availability_windows (
+  agent_id, day_of_week, start_time, end_time
+) VALUES (
+  $1, $2, $3, $4
+) RETURNING *;
+
+-- List the weekly availability windows of an agent
+-- name: ListAgentAvailabilityWindows :many
+SELECT * FROM agent_availability_windows
+WHERE agent_id = $1
+ORDER BY day_of_week, start_time;
+
+-- Delete all weekly availability windows of an agent
+-- name: DeleteAgentAvailabilityWindows :exec
+DELETE FROM agent_availability_windows
+WHERE agent_id = $1;
+
+-- Add an availability exception or blackout
+-- name: CreateAgentAvailabilityException :one
+INSERT INTO agent_availability_exceptions (
+  agent_id, start_date, end_date, start_time, end_time, is_available, reason
+) VALUES (
+  $1, $2, $3, $4, $5, $6, $7
+) RETURNING *;
+
+-- Delete all availability exceptions of an agent
+-- name: DeleteAgentAvailabilityExceptions :exec
+DELETE FROM agent_availability_exceptions
+WHERE agent_id = $1;
+
+-- List the availability exceptions of an agent that overlap a range of dates
+-- name: ListAgentAvailabilityExceptions :many
+SELECT * FROM agent_availability_exceptions
+WHERE agent_id = sqlc.arg('agent_id')
+  AND start_date <= sqlc.arg('to_date')::date
+  AND end_date >= sqlc.arg('from_date')::date
+ORDER BY start_date, start_time;
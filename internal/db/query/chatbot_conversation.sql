@@ -179,3 +179,8 @@ WHERE session_id = $1;
 -- name: DeleteChatbotConversation :exec
 DELETE FROM chatbot_conversations 
 WHERE id = $1;
+
+-- Delete every chatbot conversation of a user
+-- name: DeleteUserChatbotConversations :exec
+DELETE FROM chatbot_conversations 
+WHERE user_id = $1;
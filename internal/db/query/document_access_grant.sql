@@ -0,0 +1,34 @@
+-- Share a document with the landlord of a rental application. Sharing a revoked document again restores the access.
+-- name: CreateDocumentAccessGrant :one
+INSERT INTO document_access_grants (
+  document_id, rental_application_id, grantee_id
+) VALUES (
+  $1, $2, $3
+)
+ON CONFLICT (document_id, rental_application_id) DO UPDATE
+SET grantee_id = EXCLUDED.grantee_id, revoked_at = NULL
+RETURNING *;
+
+-- Revoke the access to a document that was shared for a rental application
+-- name: RevokeDocumentAccessGrant :one
+UPDATE document_access_grants
+SET revoked_at = NOW()
+WHERE document_id = $1 AND rental_application_id = $2 AND revoked_at IS NULL
+RETURNING *;
+
+-- List the documents shared for a rental application
+-- name: ListRentalApplicationDocuments :many
+SELECT d.*, g.id AS grant_id, g.grantee_id, g.revoked_at, g.created_at AS shared_at
+FROM document_access_grants g
+JOIN tenant_documents d ON g.document_id = d.id
+WHERE g.rental_application_id = $1
+ORDER BY g.created_at, g.id;
+
+-- Check whether a document was shared with a user for an application that is still open or approved
+-- name: HasDocumentAccess :one
+SELECT EXISTS(
+  SELECT 1 FROM document_access_grants g
+  JOIN rental_applications ra ON g.rental_application_id = ra.id
+  WHERE g.document_id = $1 AND g.grantee_id = $2 AND g.revoked_at IS NULL
+    AND ra.status NOT IN ('rejected', 'withdrawn')
+);
@@ -64,15 +64,18 @@ WHERE user_id = $1;
 -- Approve inspection agent
 -- name: ApproveInspectionAgent :one
 UPDATE inspection_agent_profiles 
-SET is_approved = true, approved_at = NOW(), approved_by = $2, updated_at = NOW()
+SET is_approved = true, approved_at = NOW(), approved_by = $2,
+    rejected_at = NULL, rejection_reason = NULL, updated_at = NOW()
 WHERE user_id = $1 
 RETURNING *;
 
 -- Reject inspection agent
--- name: RejectInspectionAgent :exec
+-- name: RejectInspectionAgent :one
 UPDATE inspection_agent_profiles 
-SET is_approved = false, approved_at = NULL, approved_by = NULL, updated_at = NOW()
-WHERE user_id = $1;
+SET is_approved = false, approved_at = NULL, approved_by = NULL,
+    rejected_at = NOW(), rejection_reason = $2, updated_at = NOW()
+WHERE user_id = $1 
+RETURNING *;
 
 -- List approved agents by area
 -- name: ListApprovedAgentsByArea :many
@@ -90,8 +93,9 @@ LIMIT $2 OFFSET $3;
 SELECT iap.*, u.first_name, u.last_name, u.email, u.phone 
 FROM inspection_agent_profiles iap
 JOIN users u ON iap.user_id = u.id
-WHERE iap.is_approved = false AND u.is_active = true
-ORDER BY iap.created_at ASC
+WHERE iap.is_approved = false AND iap.submitted_at IS NOT NULL AND iap.rejected_at IS NULL
+  AND u.is_active = true
+ORDER BY iap.submitted_at ASC
 LIMIT $1 OFFSET $2;
 
 -- List top agents by rating
@@ -107,3 +111,31 @@ LIMIT $1 OFFSET $2;
 -- name: DeleteInspectionAgentProfile :exec
 DELETE FROM inspection_agent_profiles 
 WHERE user_id = $1;
+
+-- Clear the license, banking and availability details of a deleted agent
+-- name: AnonymizeInspectionAgentProfile :exec
+UPDATE inspection_agent_profiles 
+SET license_number = NULL, availability_schedule = NULL,
+    bank_name = NULL, bank_account = NULL, bank_account_name = NULL, updated_at = NOW()
+WHERE user_id = $1;
+
+-- Submit or resubmit an agent application for review
+-- name: SubmitInspectionAgentApplication :one
+INSERT INTO inspection_agent_profiles (
+  user_id, license_number, specializations, service_areas, hourly_rate, submitted_at
+) VALUES (
+  $1, $2, $3, $4, $5, NOW()
+)
+ON CONFLICT (user_id) DO UPDATE
+SET license_number = EXCLUDED.license_number, specializations = EXCLUDED.specializations,
+    service_areas = EXCLUDED.service_areas, hourly_rate = EXCLUDED.hourly_rate,
+    is_approved = false, approved_at = NULL, approved_by = NULL,
+    submitted_at = NOW(), rejected_at = NULL, rejection_reason = NULL, updated_at = NOW()
+RETURNING *;
+
+-- Count pending agent applications
+-- name: CountPendingAgentApplications :one
+SELECT COUNT(*) FROM inspection_agent_profiles iap
+JOIN users u ON iap.user_id = u.id
+WHERE iap.is_approved = false AND iap.submitted_at IS NOT NULL AND iap.rejected_at IS NULL
+  AND u.is_active = true;
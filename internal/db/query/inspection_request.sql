@@ -32,11 +32,15 @@ SET status = $2, updated_at = NOW()
 WHERE id = $1 
 RETURNING *;
 
--- Assign inspection agent
+-- Assign inspection agent, only if the agent has been approved
 -- name: AssignInspectionAgent :one
 UPDATE inspection_requests 
 SET inspection_agent_id = $2, status = 'agent_assigned', updated_at = NOW()
 WHERE id = $1 
+  AND EXISTS (
+    SELECT 1 FROM inspection_agent_profiles
+    WHERE user_id = $2 AND is_approved = true
+  )
 RETURNING *;
 
 -- Confirm inspection
@@ -156,4 +160,13 @@ WHERE inspection_agent_id = $1;
 -- Delete inspection request
 -- name: DeleteInspectionRequest :exec
 DELETE FROM inspection_requests 
-WHERE id = $1;
\ No newline at end of file
+WHERE id = $1;
+
+-- List the confirmed inspections of an agent in a range of dates, which take up their time
+-- name: ListAgentBookedInspections :many
+SELECT id, confirmed_date, confirmed_time FROM inspection_requests
+WHERE inspection_agent_id = sqlc.arg('agent_id')
+  AND status IN ('confirmed', 'agent_assigned')
+  AND confirmed_date BETWEEN sqlc.arg('from_date')::date AND sqlc.arg('to_date')::date
+  AND confirmed_time IS NOT NULL
+ORDER BY confirmed_date, confirmed_time;
@@ -1,4 +1,4 @@
--- Create a new landlord profile
+-- Create a landlord profile, or fill in the one created without details at signup
 -- name: CreateLandlordProfile :one
 INSERT INTO landlord_profiles (
   user_id, business_name, business_registration, tax_id, bank_name,
@@ -6,7 +6,19 @@ INSERT INTO landlord_profiles (
   guarantor_address
 ) VALUES (
   $1, $2, $3, $4, $5, $6, $7, $8, $9, $10
-) RETURNING *;
+)
+ON CONFLICT (user_id) DO UPDATE
+SET business_name = EXCLUDED.business_name, business_registration = EXCLUDED.business_registration,
+    tax_id = EXCLUDED.tax_id, bank_name = EXCLUDED.bank_name, bank_account = EXCLUDED.bank_account,
+    bank_account_name = EXCLUDED.bank_account_name, guarantor_name = EXCLUDED.guarantor_name,
+    guarantor_phone = EXCLUDED.guarantor_phone, guarantor_address = EXCLUDED.guarantor_address,
+    updated_at = NOW()
+WHERE num_nonnulls(
+    landlord_profiles.business_name, landlord_profiles.business_registration, landlord_profiles.tax_id,
+    landlord_profiles.bank_name, landlord_profiles.bank_account, landlord_profiles.bank_account_name,
+    landlord_profiles.guarantor_name, landlord_profiles.guarantor_phone, landlord_profiles.guarantor_address
+  ) = 0
+RETURNING *;
 
 -- Get landlord profile by ID
 -- name: GetLandlordProfileByID :one
@@ -56,17 +68,19 @@ SET total_properties = $2, average_rating = $3, updated_at = NOW()
 WHERE user_id = $1 
 RETURNING *;
 
--- Increment landlord property count
+-- Increment landlord property count, creating the profile of a landlord who has none
 -- name: IncrementLandlordPropertyCount :exec
-UPDATE landlord_profiles 
-SET total_properties = total_properties + 1, updated_at = NOW()
-WHERE user_id = $1;
+INSERT INTO landlord_profiles (user_id, total_properties)
+VALUES ($1, 1)
+ON CONFLICT (user_id) DO UPDATE
+SET total_properties = COALESCE(landlord_profiles.total_properties, 0) + 1, updated_at = NOW();
 
--- Decrement landlord property count
+-- Decrement landlord property count, creating the profile of a landlord who has none
 -- name: DecrementLandlordPropertyCount :exec
-UPDATE landlord_profiles 
-SET total_properties = GREATEST(total_properties - 1, 0), updated_at = NOW()
-WHERE user_id = $1;
+INSERT INTO landlord_profiles (user_id, total_properties)
+VALUES ($1, 0)
+ON CONFLICT (user_id) DO UPDATE
+SET total_properties = GREATEST(COALESCE(landlord_profiles.total_properties, 0) - 1, 0), updated_at = NOW();
 
 -- List top landlords by rating
 -- name: ListTopLandlordsByRating :many
@@ -90,3 +104,11 @@ LIMIT $1 OFFSET $2;
 -- name: DeleteLandlordProfile :exec
 DELETE FROM landlord_profiles 
 WHERE user_id = $1;
+
+-- Clear the business, banking and guarantor details of a deleted landlord
+-- name: AnonymizeLandlordProfile :exec
+UPDATE landlord_profiles 
+SET business_name = NULL, business_registration = NULL, tax_id = NULL,
+    bank_name = NULL, bank_account = NULL, bank_account_name = NULL,
+    guarantor_name = NULL, guarantor_phone = NULL, guarantor_address = NULL, updated_at = NOW()
+WHERE user_id = $1;
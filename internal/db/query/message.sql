@@ -160,3 +160,9 @@ WHERE id = $1;
 DELETE FROM messages 
 WHERE (sender_id = $1 AND recipient_id = $2) 
    OR (sender_id = $2 AND recipient_id = $1);
+
+-- Remove the content of every message a user sent
+-- name: RedactMessagesBySender :exec
+UPDATE messages 
+SET content = '[deleted]', media_url = NULL
+WHERE sender_id = $1;
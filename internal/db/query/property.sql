@@ -4,9 +4,9 @@ INSERT INTO properties (
   landlord_id, title, description, property_type, address, city, state, country,
   latitude, longitude, bedrooms, bathrooms, rent_amount, rent_period,
   security_deposit, agency_fee, legal_fee, amenities, furnishing_status,
-  parking_spaces, total_area, expires_at
+  parking_spaces, total_area, expires_at, pet_friendly
 ) VALUES (
-  $1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19, $20, $21, $22
+  $1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19, $20, $21, $22, $23
 ) RETURNING *;
 
 -- Get property by ID
@@ -14,6 +14,12 @@ INSERT INTO properties (
 SELECT * FROM properties 
 WHERE id = $1 LIMIT 1;
 
+-- Lock a property while its status changes
+-- name: GetPropertyForUpdate :one
+SELECT * FROM properties 
+WHERE id = $1 LIMIT 1
+FOR NO KEY UPDATE;
+
 -- Get property with landlord details
 -- name: GetPropertyWithLandlord :one
 SELECT p.*, u.first_name, u.last_name, u.email, u.phone,
@@ -30,7 +36,7 @@ SET title = $2, description = $3, property_type = $4, address = $5,
     city = $6, state = $7, latitude = $8, longitude = $9, bedrooms = $10,
     bathrooms = $11, rent_amount = $12, rent_period = $13, security_deposit = $14,
     agency_fee = $15, legal_fee = $16, amenities = $17, furnishing_status = $18,
-    parking_spaces = $19, total_area = $20, updated_at = NOW()
+    parking_spaces = $19, total_area = $20, pet_friendly = $21, updated_at = NOW()
 WHERE id = $1 
 RETURNING *;
 
@@ -77,22 +83,153 @@ WHERE status = 'active' AND is_available = true
 ORDER BY created_at DESC
 LIMIT $1 OFFSET $2;
 
--- Search properties with filters
+-- Search available properties with filters and an optional free-text query, one keyset page at a time.
+-- Amenities and terms are lowercase; amenities must all be listed, while terms are the words of query
+-- that may be misspelled area names. The cursor is the sort key and id of the last row of the previous page,
+-- and only the cursor column of sort_by is read. Snippets highlight the matches of query with <mark> tags.
 -- name: SearchProperties :many
-SELECT p.*, u.first_name, u.last_name
+SELECT sqlc.embed(p), r.rank,
+  (CASE WHEN sqlc.narg('query')::text IS NULL THEN ''
+    ELSE ts_headline('english', COALESCE(p.description, p.title), property_search_query(sqlc.narg('query')),
+      'StartSel=<mark>, StopSel=</mark>, MinWords=10, MaxWords=30, MaxFragments=2')
+  END)::text AS snippet
 FROM properties p
-JOIN users u ON p.landlord_id = u.id
+CROSS JOIN LATERAL (
+  SELECT (CASE WHEN sqlc.narg('query') IS NULL THEN 0
+    ELSE ts_rank_cd(property_search_vector(p.title, p.description, p.address, p.city, p.amenities), property_search_query(sqlc.narg('query')))
+      + (SELECT COALESCE(MAX(word_similarity(term, p.city || ' ' || p.state || ' ' || p.address)), 0) FROM unnest(sqlc.arg('terms')::text[]) AS term)
+  END)::float8 AS rank
+) r
 WHERE p.status = 'active' AND p.is_available = true
-  AND ($1::text IS NULL OR p.city ILIKE '%' || $1 || '%')
-  AND ($2::text IS NULL OR p.state ILIKE '%' || $2 || '%')
-  AND ($3::property_type_enum IS NULL OR p.property_type = $3)
-  AND ($4::decimal IS NULL OR p.rent_amount >= $4)
-  AND ($5::decimal IS NULL OR p.rent_amount <= $5)
-  AND ($6::integer IS NULL OR p.bedrooms >= $6)
-  AND ($7::integer IS NULL OR p.bathrooms >= $7)
-  AND ($8::furnishing_status_enum IS NULL OR p.furnishing_status = $8)
-ORDER BY p.created_at DESC
-LIMIT $9 OFFSET $10;
+  AND (sqlc.narg('city')::text IS NULL OR p.city ILIKE '%' || sqlc.narg('city') || '%')
+  AND (sqlc.narg('state')::text IS NULL OR p.state ILIKE '%' || sqlc.narg('state') || '%')
+  AND (sqlc.narg('property_type')::property_type_enum IS NULL OR p.property_type = sqlc.narg('property_type'))
+  AND (sqlc.narg('min_rent')::decimal IS NULL OR p.rent_amount >= sqlc.narg('min_rent'))
+  AND (sqlc.narg('max_rent')::decimal IS NULL OR p.rent_amount <= sqlc.narg('max_rent'))
+  AND (sqlc.narg('min_bedrooms')::integer IS NULL OR p.bedrooms >= sqlc.narg('min_bedrooms'))
+  AND (sqlc.narg('min_bathrooms')::integer IS NULL OR p.bathrooms >= sqlc.narg('min_bathrooms'))
+  AND (sqlc.narg('furnishing_status')::furnishing_status_enum IS NULL OR p.furnishing_status = sqlc.narg('furnishing_status'))
+  AND (cardinality(sqlc.arg('amenities')::text[]) = 0
+    OR regexp_split_to_array(lower(COALESCE(p.amenities, '')), '\s*,\s*') @> sqlc.arg('amenities')::text[])
+  AND (NOT sqlc.arg('pet_friendly')::boolean OR p.pet_friendly)
+  AND (NOT sqlc.arg('verified_only')::boolean OR p.is_verified = true)
+  AND (sqlc.narg('min_parking_spaces')::integer IS NULL OR COALESCE(p.parking_spaces, 0) >= sqlc.narg('min_parking_spaces'))
+  AND (sqlc.narg('max_agency_fee')::decimal IS NULL OR COALESCE(p.agency_fee, 0) <= sqlc.narg('max_agency_fee'))
+  AND (sqlc.narg('query')::text IS NULL
+    OR property_search_vector(p.title, p.description, p.address, p.city, p.amenities) @@ property_search_query(sqlc.narg('query'))
+    OR EXISTS (SELECT 1 FROM unnest(sqlc.arg('terms')::text[]) AS term WHERE term <% (p.city || ' ' || p.state || ' ' || p.address)))
+  AND (sqlc.narg('cursor_id')::bigint IS NULL
+    OR (sqlc.arg('sort_by')::text = 'relevance' AND (r.rank, p.id) < (sqlc.narg('cursor_rank')::float8, sqlc.narg('cursor_id')))
+    OR (sqlc.arg('sort_by') = 'price_asc' AND (p.rent_amount, p.id) > (sqlc.narg('cursor_rent')::decimal, sqlc.narg('cursor_id')))
+    OR (sqlc.arg('sort_by') = 'price_desc' AND (p.rent_amount, p.id) < (sqlc.narg('cursor_rent'), sqlc.narg('cursor_id')))
+    OR (sqlc.arg('sort_by') = 'newest' AND (p.created_at, p.id) < (sqlc.narg('cursor_created_at')::timestamptz, sqlc.narg('cursor_id')))
+    OR (sqlc.arg('sort_by') = 'popular' AND (COALESCE(p.views_count, 0), p.id) < (sqlc.narg('cursor_views')::integer, sqlc.narg('cursor_id'))))
+ORDER BY
+  CASE WHEN sqlc.arg('sort_by') = 'relevance' THEN r.rank END DESC,
+  CASE WHEN sqlc.arg('sort_by') = 'price_asc' THEN p.rent_amount END ASC,
+  CASE WHEN sqlc.arg('sort_by') = 'price_desc' THEN p.rent_amount END DESC,
+  CASE WHEN sqlc.arg('sort_by') = 'newest' THEN p.created_at END DESC,
+  CASE WHEN sqlc.arg('sort_by') = 'popular' THEN COALESCE(p.views_count, 0) END DESC,
+  CASE WHEN sqlc.arg('sort_by') = 'price_asc' THEN p.id END ASC,
+  p.id DESC
+LIMIT sqlc.arg('limit');
+
+-- Get the SearchProperties rows of cached result ids, ranked and highlighted for the same query and terms.
+-- Properties that were unpublished or rented since are left out; the caller puts the rows back in cached order.
+-- name: ListPropertySearchResults :many
+SELECT sqlc.embed(p), r.rank,
+  (CASE WHEN sqlc.narg('query')::text IS NULL THEN ''
+    ELSE ts_headline('english', COALESCE(p.description, p.title), property_search_query(sqlc.narg('query')),
+      'StartSel=<mark>, StopSel=</mark>, MinWords=10, MaxWords=30, MaxFragments=2')
+  END)::text AS snippet
+FROM properties p
+CROSS JOIN LATERAL (
+  SELECT (CASE WHEN sqlc.narg('query') IS NULL THEN 0
+    ELSE ts_rank_cd(property_search_vector(p.title, p.description, p.address, p.city, p.amenities), property_search_query(sqlc.narg('query')))
+      + (SELECT COALESCE(MAX(word_similarity(term, p.city || ' ' || p.state || ' ' || p.address)), 0) FROM unnest(sqlc.arg('terms')::text[]) AS term)
+  END)::float8 AS rank
+) r
+WHERE p.id = ANY(sqlc.arg('ids')::bigint[])
+  AND p.status = 'active' AND p.is_available = true;
+
+-- Count the properties matching the SearchProperties filters and query by type, bedroom count and city
+-- name: SearchPropertyFacets :many
+WITH matches AS (
+  SELECT p.property_type, p.bedrooms, p.city FROM properties p
+  WHERE p.status = 'active' AND p.is_available = true
+    AND (sqlc.narg('city')::text IS NULL OR p.city ILIKE '%' || sqlc.narg('city') || '%')
+    AND (sqlc.narg('state')::text IS NULL OR p.state ILIKE '%' || sqlc.narg('state') || '%')
+    AND (sqlc.narg('property_type')::property_type_enum IS NULL OR p.property_type = sqlc.narg('property_type'))
+    AND (sqlc.narg('min_rent')::decimal IS NULL OR p.rent_amount >= sqlc.narg('min_rent'))
+    AND (sqlc.narg('max_rent')::decimal IS NULL OR p.rent_amount <= sqlc.narg('max_rent'))
+    AND (sqlc.narg('min_bedrooms')::integer IS NULL OR p.bedrooms >= sqlc.narg('min_bedrooms'))
+    AND (sqlc.narg('min_bathrooms')::integer IS NULL OR p.bathrooms >= sqlc.narg('min_bathrooms'))
+    AND (sqlc.narg('furnishing_status')::furnishing_status_enum IS NULL OR p.furnishing_status = sqlc.narg('furnishing_status'))
+    AND (cardinality(sqlc.arg('amenities')::text[]) = 0
+      OR regexp_split_to_array(lower(COALESCE(p.amenities, '')), '\s*,\s*') @> sqlc.arg('amenities')::text[])
+    AND (NOT sqlc.arg('pet_friendly')::boolean OR p.pet_friendly)
+    AND (NOT sqlc.arg('verified_only')::boolean OR p.is_verified = true)
+    AND (sqlc.narg('min_parking_spaces')::integer IS NULL OR COALESCE(p.parking_spaces, 0) >= sqlc.narg('min_parking_spaces'))
+    AND (sqlc.narg('max_agency_fee')::decimal IS NULL OR COALESCE(p.agency_fee, 0) <= sqlc.narg('max_agency_fee'))
+    AND (sqlc.narg('query')::text IS NULL
+      OR property_search_vector(p.title, p.description, p.address, p.city, p.amenities) @@ property_search_query(sqlc.narg('query'))
+      OR EXISTS (SELECT 1 FROM unnest(sqlc.arg('terms')::text[]) AS term WHERE term <% (p.city || ' ' || p.state || ' ' || p.address)))
+)
+SELECT 'property_type'::text AS facet, property_type::text AS value, COUNT(*) AS count FROM matches GROUP BY property_type
+UNION ALL
+SELECT 'bedrooms', bedrooms::text, COUNT(*) FROM matches GROUP BY bedrooms
+UNION ALL
+SELECT 'city', city, COUNT(*) FROM matches GROUP BY city
+ORDER BY facet, count DESC, value;
+
+-- Search available properties within radius_meters of a point, nearest first
+-- name: SearchPropertiesNearby :many
+SELECT sqlc.embed(p),
+  earth_distance(ll_to_earth(p.latitude::float8, p.longitude::float8), ll_to_earth(sqlc.arg('latitude')::float8, sqlc.arg('longitude')::float8))::float8 AS distance_meters
+FROM properties p
+WHERE p.status = 'active' AND p.is_available = true
+  AND earth_box(ll_to_earth(sqlc.arg('latitude'), sqlc.arg('longitude')), sqlc.arg('radius_meters')::float8) @> ll_to_earth(p.latitude::float8, p.longitude::float8)
+  AND earth_distance(ll_to_earth(p.latitude::float8, p.longitude::float8), ll_to_earth(sqlc.arg('latitude'), sqlc.arg('longitude'))) <= sqlc.arg('radius_meters')
+  AND (sqlc.narg('property_type')::property_type_enum IS NULL OR p.property_type = sqlc.narg('property_type'))
+  AND (sqlc.narg('min_rent')::decimal IS NULL OR p.rent_amount >= sqlc.narg('min_rent'))
+  AND (sqlc.narg('max_rent')::decimal IS NULL OR p.rent_amount <= sqlc.narg('max_rent'))
+  AND (sqlc.narg('min_bedrooms')::integer IS NULL OR p.bedrooms >= sqlc.narg('min_bedrooms'))
+ORDER BY distance_meters, p.id
+LIMIT sqlc.arg('limit') OFFSET sqlc.arg('offset');
+
+-- List the map pins of available properties inside a viewport, most viewed first
+-- name: ListPropertyPinsInBounds :many
+SELECT p.id, p.title, p.property_type, p.bedrooms, p.rent_amount,
+  p.latitude::float8 AS latitude, p.longitude::float8 AS longitude
+FROM properties p
+WHERE p.status = 'active' AND p.is_available = true
+  AND p.latitude BETWEEN sqlc.arg('min_latitude')::decimal AND sqlc.arg('max_latitude')::decimal
+  -- A viewport that crosses the antimeridian has a west edge greater than its east edge
+  AND CASE WHEN sqlc.arg('min_longitude')::decimal <= sqlc.arg('max_longitude')::decimal
+    THEN p.longitude BETWEEN sqlc.arg('min_longitude') AND sqlc.arg('max_longitude')
+    ELSE p.longitude >= sqlc.arg('min_longitude') OR p.longitude <= sqlc.arg('max_longitude')
+  END
+ORDER BY p.views_count DESC NULLS LAST, p.id
+LIMIT sqlc.arg('limit');
+
+-- Group the available properties inside a viewport into grid cells of cell_degrees, for maps zoomed out too far to show pins
+-- name: ClusterPropertiesInBounds :many
+SELECT COUNT(*) AS property_count,
+  AVG(p.latitude::float8)::float8 AS latitude,
+  AVG(p.longitude::float8)::float8 AS longitude,
+  MIN(p.rent_amount)::decimal AS min_rent,
+  MIN(p.id)::bigint AS property_id
+FROM properties p
+WHERE p.status = 'active' AND p.is_available = true
+  AND p.latitude BETWEEN sqlc.arg('min_latitude')::decimal AND sqlc.arg('max_latitude')::decimal
+  -- A viewport that crosses the antimeridian has a west edge greater than its east edge
+  AND CASE WHEN sqlc.arg('min_longitude')::decimal <= sqlc.arg('max_longitude')::decimal
+    THEN p.longitude BETWEEN sqlc.arg('min_longitude') AND sqlc.arg('max_longitude')
+    ELSE p.longitude >= sqlc.arg('min_longitude') OR p.longitude <= sqlc.arg('max_longitude')
+  END
+GROUP BY floor(p.latitude::float8 / sqlc.arg('cell_degrees')::float8), floor(p.longitude::float8 / sqlc.arg('cell_degrees'))
+ORDER BY property_count DESC
+LIMIT sqlc.arg('limit');
 
 -- List featured properties
 -- name: ListFeaturedProperties :many
@@ -133,4 +270,10 @@ WHERE status = 'active' AND is_available = true;
 -- name: DeleteProperty :exec
 UPDATE properties 
 SET status = 'inactive', updated_at = NOW()
-WHERE id = $1;
\ No newline at end of file
+WHERE id = $1;
+
+-- Take down every listing of a landlord
+-- name: DeactivateLandlordProperties :exec
+UPDATE properties 
+SET status = 'inactive', is_available = false, updated_at = NOW()
+WHERE landlord_id = $1;
@@ -6,6 +6,29 @@ INSERT INTO property_search_cache (
   $1, $2, $3, $4, $5
 ) RETURNING *;
 
+-- Cache one page of a search, or refresh it when the search ran again after the entry expired or was invalidated
+-- name: UpsertPropertySearchCache :one
+INSERT INTO property_search_cache (
+  search_hash, search_params, property_ids, result_count, expires_at
+) VALUES (
+  $1, $2, $3, $4, $5
+)
+ON CONFLICT (search_hash) DO UPDATE
+SET search_params = EXCLUDED.search_params,
+  property_ids = EXCLUDED.property_ids,
+  result_count = EXCLUDED.result_count,
+  expires_at = EXCLUDED.expires_at,
+  search_count = property_search_cache.search_count + 1,
+  last_searched_at = NOW()
+RETURNING *;
+
+-- Count searches served from cache, batched as the number of hits of each entry
+-- name: RecordSearchCacheHits :exec
+UPDATE property_search_cache AS c
+SET search_count = c.search_count + h.hits, last_searched_at = NOW()
+FROM unnest(sqlc.arg(ids)::bigint[], sqlc.arg(hits)::int[]) AS h(id, hits)
+WHERE c.id = h.id;
+
 -- Get property search cache by ID
 -- name: GetPropertySearchCacheByID :one
 SELECT * FROM property_search_cache 
@@ -52,13 +75,16 @@ WHERE result_count >= $1 AND expires_at > NOW()
 ORDER BY result_count DESC, created_at DESC
 LIMIT $2 OFFSET $3;
 
--- Get popular search caches
+-- Get the most run searches since a time, adding up the cached pages of each
 -- name: GetPopularSearchCaches :many
-SELECT search_hash, search_params, result_count, COUNT(*) as access_count
-FROM property_search_cache 
-WHERE created_at >= $1
-GROUP BY search_hash, search_params, result_count
-ORDER BY access_count DESC, result_count DESC
+SELECT search_params,
+  SUM(search_count)::bigint AS search_count,
+  COUNT(*) AS cached_pages,
+  MAX(last_searched_at)::timestamptz AS last_searched_at
+FROM property_search_cache
+WHERE last_searched_at >= $1
+GROUP BY search_params
+ORDER BY search_count DESC, last_searched_at DESC
 LIMIT $2 OFFSET $3;
 
 -- Search cache entries
@@ -88,9 +114,10 @@ SELECT
   COUNT(*) as total_entries,
   COUNT(CASE WHEN expires_at > NOW() THEN 1 END) as active_entries,
   COUNT(CASE WHEN expires_at <= NOW() THEN 1 END) as expired_entries,
-  AVG(result_count) as average_result_count,
-  MAX(result_count) as max_result_count,
-  MIN(result_count) as min_result_count
+  COALESCE(AVG(result_count), 0)::float8 as average_result_count,
+  COALESCE(MAX(result_count), 0)::integer as max_result_count,
+  COALESCE(MIN(result_count), 0)::integer as min_result_count,
+  COALESCE(SUM(search_count), 0)::bigint as total_searches
 FROM property_search_cache;
 
 -- Get cache hit statistics
@@ -128,6 +155,46 @@ SELECT EXISTS(
 DELETE FROM property_search_cache 
 WHERE expires_at <= NOW();
 
+-- Clean up expired cache entries that haven't been searched since a time; the others are kept for search statistics
+-- name: CleanupStaleSearchCache :execrows
+DELETE FROM property_search_cache
+WHERE expires_at <= NOW() AND last_searched_at < $1;
+
+-- Expire the cached searches a property is in, or would now be in with its current values.
+-- search_params holds the filters that SearchProperties was called with, see the query of SearchProperties.
+-- name: ExpirePropertySearchCaches :execrows
+UPDATE property_search_cache
+SET expires_at = NOW()
+WHERE id IN (
+  SELECT c.id
+  FROM property_search_cache c
+  JOIN properties p ON p.id = sqlc.arg('property_id')
+  CROSS JOIN LATERAL (SELECT COALESCE(c.search_params, '{}')::jsonb AS f) s
+  WHERE c.expires_at > NOW()
+    AND (',' || COALESCE(c.property_ids, '') || ',' LIKE '%,' || p.id || ',%'
+      OR (p.status = 'active' AND p.is_available = true
+        AND (s.f->>'city' IS NULL OR p.city ILIKE '%' || (s.f->>'city') || '%')
+        AND (s.f->>'state' IS NULL OR p.state ILIKE '%' || (s.f->>'state') || '%')
+        AND (s.f->>'property_type' IS NULL OR p.property_type::text = s.f->>'property_type')
+        AND (s.f->>'min_rent' IS NULL OR p.rent_amount >= (s.f->>'min_rent')::decimal)
+        AND (s.f->>'max_rent' IS NULL OR p.rent_amount <= (s.f->>'max_rent')::decimal)
+        AND (s.f->>'min_bedrooms' IS NULL OR p.bedrooms >= (s.f->>'min_bedrooms')::integer)
+        AND (s.f->>'min_bathrooms' IS NULL OR p.bathrooms >= (s.f->>'min_bathrooms')::integer)
+        AND (s.f->>'furnishing_status' IS NULL OR p.furnishing_status::text = s.f->>'furnishing_status')
+        AND (s.f->'amenities' IS NULL
+          OR regexp_split_to_array(lower(COALESCE(p.amenities, '')), '\s*,\s*')
+            @> ARRAY(SELECT jsonb_array_elements_text(s.f->'amenities')))
+        AND (NOT COALESCE((s.f->>'pet_friendly')::boolean, false) OR p.pet_friendly)
+        AND (NOT COALESCE((s.f->>'verified_only')::boolean, false) OR p.is_verified = true)
+        AND (s.f->>'min_parking_spaces' IS NULL OR COALESCE(p.parking_spaces, 0) >= (s.f->>'min_parking_spaces')::integer)
+        AND (s.f->>'max_agency_fee' IS NULL OR COALESCE(p.agency_fee, 0) <= (s.f->>'max_agency_fee')::decimal)
+        AND (s.f->>'query' IS NULL
+          OR property_search_vector(p.title, p.description, p.address, p.city, p.amenities) @@ property_search_query(s.f->>'query')
+          OR EXISTS (SELECT 1 FROM jsonb_array_elements_text(COALESCE(s.f->'terms', '[]')) AS term
+            WHERE term <% (p.city || ' ' || p.state || ' ' || p.address))))
+    )
+);
+
 -- Clean up old cache entries
 -- name: CleanupOldCache :exec
 DELETE FROM property_search_cache 
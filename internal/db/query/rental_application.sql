@@ -1,10 +1,10 @@
 -- Create rental application
 -- name: CreateRentalApplication :one
 INSERT INTO rental_applications (
-  property_id, tenant_id, landlord_id, application_documents, employment_details,
+  property_id, tenant_id, landlord_id, employment_details,
   "references", preferred_move_in_date, additional_notes
 ) VALUES (
-  $1, $2, $3, $4, $5, $6, $7, $8
+  $1, $2, $3, $4, $5, $6, $7
 ) RETURNING *;
 
 -- Get rental application by ID
@@ -34,13 +34,6 @@ SET status = $2, decision_reason = $3, decided_at = NOW(), decided_by = $4, upda
 WHERE id = $1 
 RETURNING *;
 
--- Update application documents
--- name: UpdateApplicationDocuments :one
-UPDATE rental_applications 
-SET application_documents = $2, updated_at = NOW()
-WHERE id = $1 
-RETURNING *;
-
 -- Update employment details
 -- name: UpdateApplicationEmploymentDetails :one
 UPDATE rental_applications 
@@ -164,4 +157,10 @@ SELECT EXISTS(
 -- Delete rental application
 -- name: DeleteRentalApplication :exec
 DELETE FROM rental_applications 
-WHERE id = $1;
\ No newline at end of file
+WHERE id = $1;
+
+-- Remove the employment details, references and notes a tenant attached to their applications
+-- name: RedactTenantRentalApplications :exec
+UPDATE rental_applications 
+SET employment_details = NULL, "references" = NULL, additional_notes = NULL, updated_at = NOW()
+WHERE tenant_id = $1;
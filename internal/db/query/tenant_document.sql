@@ -0,0 +1,35 @@
+-- Record an uploaded document
+-- name: CreateTenantDocument :one
+INSERT INTO tenant_documents (
+  owner_id, document_type, file_name, content_type, size_bytes, checksum, storage_key
+) VALUES (
+  $1, $2, $3, $4, $5, $6, $7
+) RETURNING *;
+
+-- Get a document by ID
+-- name: GetTenantDocument :one
+SELECT * FROM tenant_documents
+WHERE id = $1 LIMIT 1;
+
+-- List the documents of a tenant, newest first
+-- name: ListTenantDocuments :many
+SELECT * FROM tenant_documents
+WHERE owner_id = $1
+ORDER BY created_at DESC, id DESC;
+
+-- Count the documents of a tenant
+-- name: CountTenantDocuments :one
+SELECT COUNT(*) FROM tenant_documents
+WHERE owner_id = $1;
+
+-- Delete a document, returning it so that its content can be removed from the blob store
+-- name: DeleteTenantDocument :one
+DELETE FROM tenant_documents
+WHERE id = $1
+RETURNING *;
+
+-- Delete all documents of a tenant, returning the blob store keys of their content
+-- name: DeleteTenantDocumentsByOwner :many
+DELETE FROM tenant_documents
+WHERE owner_id = $1
+RETURNING storage_key;
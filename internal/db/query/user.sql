@@ -53,28 +53,57 @@ UPDATE users
 SET last_login = NOW() 
 WHERE id = $1;
 
--- List users by type
--- name: ListUsersByType :many
+-- Delete user (soft delete by setting inactive)
+-- name: DeleteUser :exec
+UPDATE users 
+SET is_active = false, updated_at = NOW()
+WHERE id = $1;
+
+-- Update whether the nin of the user was verified by the identity provider
+-- name: UpdateUserIdentityVerified :exec
+UPDATE users 
+SET is_identity_verified = $2, updated_at = NOW()
+WHERE id = $1;
+
+-- Update user nin, which has to be verified again
+-- name: UpdateUserNIN :exec
+UPDATE users 
+SET nin = $2, is_identity_verified = false, updated_at = NOW()
+WHERE id = $1;
+
+-- Filter users by an optional search term, type and active status
+-- name: FilterUsers :many
 SELECT * FROM users 
-WHERE user_type = $1 AND is_active = true
+WHERE (sqlc.narg('search')::text IS NULL OR first_name ILIKE sqlc.narg('search') OR last_name ILIKE sqlc.narg('search') OR email ILIKE sqlc.narg('search'))
+  AND (sqlc.narg('user_type')::user_type_enum IS NULL OR user_type = sqlc.narg('user_type'))
+  AND (sqlc.narg('is_active')::boolean IS NULL OR is_active = sqlc.narg('is_active'))
 ORDER BY created_at DESC
-LIMIT $2 OFFSET $3;
+LIMIT sqlc.arg('limit') OFFSET sqlc.arg('offset');
 
--- Count users by type
--- name: CountUsersByType :one
+-- Count users matching FilterUsers
+-- name: CountFilteredUsers :one
 SELECT COUNT(*) FROM users 
-WHERE user_type = $1 AND is_active = true;
+WHERE (sqlc.narg('search')::text IS NULL OR first_name ILIKE sqlc.narg('search') OR last_name ILIKE sqlc.narg('search') OR email ILIKE sqlc.narg('search'))
+  AND (sqlc.narg('user_type')::user_type_enum IS NULL OR user_type = sqlc.narg('user_type'))
+  AND (sqlc.narg('is_active')::boolean IS NULL OR is_active = sqlc.narg('is_active'));
 
--- Search users
--- name: SearchUsers :many
-SELECT * FROM users 
-WHERE (first_name ILIKE $1 OR last_name ILIKE $1 OR email ILIKE $1) 
-  AND is_active = true
-ORDER BY created_at DESC
-LIMIT $2 OFFSET $3;
+-- Update user type
+-- name: UpdateUserType :exec
+UPDATE users 
+SET user_type = $2, updated_at = NOW()
+WHERE id = $1;
 
--- Delete user (soft delete by setting inactive)
--- name: DeleteUser :exec
+-- Replace the personal data of a deleted user, keeping the row for the records that reference it
+-- name: AnonymizeUser :exec
 UPDATE users 
-SET is_active = false, updated_at = NOW()
-WHERE id = $1;
\ No newline at end of file
+SET email = $2, phone = $3, password_hash = $4, first_name = 'Deleted', last_name = 'User',
+    nin = NULL, profile_picture_url = NULL, is_verified = false, is_identity_verified = false,
+    is_active = false, updated_at = NOW()
+WHERE id = $1;
+
+-- Page through active users by id, for periodic jobs
+-- name: ListActiveUsersAfterID :many
+SELECT * FROM users 
+WHERE is_active = true AND id > sqlc.arg('after_id')
+ORDER BY id
+LIMIT sqlc.arg('limit');
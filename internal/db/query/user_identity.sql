@@ -0,0 +1,30 @@
+-- Link a provider identity to a user
+-- name: CreateUserIdentity :one
+INSERT INTO user_identities (
+  user_id, provider, subject, email
+) VALUES (
+  $1, $2, $3, $4
+) RETURNING *;
+
+-- Get the identity a provider knows by subject
+-- name: GetUserIdentity :one
+SELECT * FROM user_identities
+WHERE provider = $1 AND subject = $2 LIMIT 1;
+
+-- List the provider identities linked to a user
+-- name: ListUserIdentitiesByUserID :many
+SELECT * FROM user_identities
+WHERE user_id = $1
+ORDER BY created_at;
+
+-- Record a sign-in with an identity and the email the provider reported
+-- name: TouchUserIdentity :one
+UPDATE user_identities
+SET email = $2, last_used_at = NOW()
+WHERE id = $1
+RETURNING *;
+
+-- Unlink every provider identity of a user
+-- name: DeleteUserIdentitiesByUserID :exec
+DELETE FROM user_identities
+WHERE user_id = $1;
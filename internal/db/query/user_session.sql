@@ -1,9 +1,9 @@
 -- Create user session
 -- name: CreateUserSession :one
 INSERT INTO user_sessions (
-  user_id, session_token, device_info, ip_address, location_data, expires_at
+  user_id, session_id, session_token, device_info, ip_address, location_data, expires_at
 ) VALUES (
-  $1, $2, $3, $4, $5, $6
+  $1, $2, $3, $4, $5, $6, $7
 ) RETURNING *;
 
 -- Get user session by ID
@@ -134,3 +134,28 @@ WHERE id = $1;
 -- name: DeleteAllUserSessions :exec
 DELETE FROM user_sessions 
 WHERE user_id = $1;
+
+-- Get rotated user session by token
+-- name: GetRotatedUserSessionByToken :one
+SELECT * FROM user_sessions 
+WHERE session_token = $1 AND rotated_at IS NOT NULL
+LIMIT 1;
+
+-- Mark user session as rotated
+-- name: MarkUserSessionRotated :execrows
+UPDATE user_sessions 
+SET is_active = false, rotated_at = NOW(), replaced_by = $2
+WHERE id = $1 AND is_active = true AND rotated_at IS NULL;
+
+-- Get all user active sessions
+-- name: GetAllUserActiveSessions :many
+SELECT * FROM user_sessions 
+WHERE user_id = $1 AND is_active = true
+ORDER BY created_at DESC;
+
+-- Deactivate user session by session ID
+-- name: DeactivateUserSessionBySessionID :many
+UPDATE user_sessions 
+SET is_active = false
+WHERE user_id = $1 AND session_id = $2 AND is_active = true
+RETURNING *;
@@ -76,3 +76,31 @@ WHERE verification_type = 'password_reset'
   AND created_at > NOW() - INTERVAL '1 hour'
 ORDER BY created_at DESC
 LIMIT 1;
+
+-- Mark a pending verification as verified together with its final data
+-- name: CompleteUserVerification :one
+UPDATE user_verifications 
+SET verification_status = 'verified', verified_at = NOW(), verification_data = $2
+WHERE id = $1 AND verification_status = 'pending'
+RETURNING *;
+
+-- Delete all verifications of a type for a user
+-- name: DeleteUserVerificationsByType :exec
+DELETE FROM user_verifications 
+WHERE user_id = $1 AND verification_type = $2;
+
+-- Count verifications of a type created for a user since a point in time
+-- name: CountRecentUserVerifications :one
+SELECT COUNT(*) FROM user_verifications 
+WHERE user_id = $1 AND verification_type = $2 AND created_at >= $3;
+
+-- Delete every verification of a user
+-- name: DeleteUserVerificationsByUserID :exec
+DELETE FROM user_verifications 
+WHERE user_id = $1;
+
+-- Replace verification data only if nobody changed it since it was read
+-- name: SwapVerificationData :execrows
+UPDATE user_verifications
+SET verification_data = sqlc.arg(verification_data)
+WHERE id = sqlc.arg(id) AND verification_data::text = sqlc.arg(previous_data)::text;
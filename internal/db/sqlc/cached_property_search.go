@@ -0,0 +1,143 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/r-scheele/sqr/internal/cache"
+	"github.com/rs/zerolog/log"
+)
+
+// propertySearchQuerier is the part of Querier that property searches are cached with
+type propertySearchQuerier interface {
+	SearchProperties(ctx context.Context, arg SearchPropertiesParams) ([]SearchPropertiesRow, error)
+	ListPropertySearchResults(ctx context.Context, arg ListPropertySearchResultsParams) ([]ListPropertySearchResultsRow, error)
+	GetCacheBySearchHash(ctx context.Context, searchHash string) (PropertySearchCache, error)
+	UpsertPropertySearchCache(ctx context.Context, arg UpsertPropertySearchCacheParams) (PropertySearchCache, error)
+	RecordSearchCacheHits(ctx context.Context, arg RecordSearchCacheHitsParams) error
+	ExpirePropertySearchCaches(ctx context.Context, propertyID int64) (int64, error)
+}
+
+// propertySearchCache keeps the results of property searches in property_search_cache
+type propertySearchCache struct {
+	querier propertySearchQuerier
+	hits    *searchHitCounter
+}
+
+func newPropertySearchCache(querier propertySearchQuerier) *propertySearchCache {
+	return &propertySearchCache{
+		querier: querier,
+		hits:    newSearchHitCounter(time.Now()),
+	}
+}
+
+func (c *propertySearchCache) search(ctx context.Context, arg SearchPropertiesParams) ([]SearchPropertiesRow, error) {
+	if !isFirstSearchPage(arg) {
+		return c.querier.SearchProperties(ctx, arg)
+	}
+
+	key, err := newPropertySearchKey(arg)
+	if err != nil {
+		return c.querier.SearchProperties(ctx, arg)
+	}
+
+	if rows, ok := c.getCached(ctx, key, arg); ok {
+		return rows, nil
+	}
+
+	rows, err := c.querier.SearchProperties(ctx, arg)
+	if err != nil {
+		return rows, err
+	}
+
+	ids := make([]string, len(rows))
+	for i, row := range rows {
+		ids[i] = strconv.FormatInt(row.Property.ID, 10)
+	}
+	_, err = c.querier.UpsertPropertySearchCache(ctx, UpsertPropertySearchCacheParams{
+		SearchHash:   key.Hash,
+		SearchParams: pgtype.Text{String: key.Params, Valid: true},
+		PropertyIds:  pgtype.Text{String: strings.Join(ids, ","), Valid: true},
+		ResultCount:  int32(len(rows)),
+		ExpiresAt:    time.Now().Add(cache.SearchCacheTTL),
+	})
+	if err != nil {
+		log.Error().Err(err).Str("search_hash", key.Hash).Msg("failed to cache property search")
+	}
+
+	return rows, nil
+}
+
+// getCached returns the cached page of a search in its cached order. A page with a property that is
+// no longer listed is a miss: listings taken down without going through CachedStore don't expire the entry.
+func (c *propertySearchCache) getCached(ctx context.Context, key propertySearchKey, arg SearchPropertiesParams) ([]SearchPropertiesRow, bool) {
+	entry, err := c.querier.GetCacheBySearchHash(ctx, key.Hash)
+	if err != nil {
+		if !errors.Is(err, ErrRecordNotFound) {
+			log.Error().Err(err).Str("search_hash", key.Hash).Msg("failed to get cached property search")
+		}
+		return nil, false
+	}
+
+	ids, err := parsePropertySearchIDs(entry.PropertyIds.String)
+	if err != nil {
+		return nil, false
+	}
+
+	rows := make([]SearchPropertiesRow, 0, len(ids))
+	if len(ids) > 0 {
+		results, err := c.querier.ListPropertySearchResults(ctx, ListPropertySearchResultsParams{
+			Query: arg.Query,
+			Terms: arg.Terms,
+			Ids:   ids,
+		})
+		if err != nil || len(results) != len(ids) {
+			return nil, false
+		}
+
+		resultByID := make(map[int64]ListPropertySearchResultsRow, len(results))
+		for _, result := range results {
+			resultByID[result.Property.ID] = result
+		}
+		for _, id := range ids {
+			rows = append(rows, SearchPropertiesRow(resultByID[id]))
+		}
+	}
+
+	c.recordHit(entry.ID)
+
+	return rows, true
+}
+
+// recordHit counts a search served from cache. The hits are written in batches, in the background.
+func (c *propertySearchCache) recordHit(id int64) {
+	hits := c.hits.record(id, time.Now())
+	if hits == nil {
+		return
+	}
+
+	go c.flushHits(hits)
+}
+
+func (c *propertySearchCache) flushHits(hits map[int64]int32) {
+	ctx, cancel := context.WithTimeout(context.Background(), searchHitFlushTimeout)
+	defer cancel()
+
+	err := c.querier.RecordSearchCacheHits(ctx, newRecordSearchCacheHitsParams(hits))
+	if err != nil {
+		log.Error().Err(err).Int("entries", len(hits)).Msg("failed to record search cache hits")
+	}
+}
+
+// expire makes the cached searches the property may appear in run again. A failure is only
+// logged since the change is already saved; those searches stay stale until cache.SearchCacheTTL.
+func (c *propertySearchCache) expire(ctx context.Context, propertyID int64) {
+	_, err := c.querier.ExpirePropertySearchCaches(ctx, propertyID)
+	if err != nil {
+		log.Error().Err(err).Int64("property_id", propertyID).Msg("failed to expire property search caches")
+	}
+}
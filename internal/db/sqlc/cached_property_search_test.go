@@ -0,0 +1,185 @@
+package db
+
+import (
+	"context"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeSearchQuerier keeps listed properties and cache entries in memory and counts the searches it runs
+type fakeSearchQuerier struct {
+	listed   map[int64]Property
+	entries  map[string]PropertySearchCache
+	searches int
+	hits     []RecordSearchCacheHitsParams
+	expired  []int64
+}
+
+func newFakeSearchQuerier(ids ...int64) *fakeSearchQuerier {
+	querier := &fakeSearchQuerier{
+		listed:  make(map[int64]Property),
+		entries: make(map[string]PropertySearchCache),
+	}
+	for _, id := range ids {
+		querier.listed[id] = Property{ID: id, Title: "Flat"}
+	}
+	return querier
+}
+
+func (q *fakeSearchQuerier) SearchProperties(ctx context.Context, arg SearchPropertiesParams) ([]SearchPropertiesRow, error) {
+	q.searches++
+
+	rows := make([]SearchPropertiesRow, 0, len(q.listed))
+	for _, property := range q.listed {
+		rows = append(rows, SearchPropertiesRow{Property: property})
+	}
+	// Newest first
+	sort.Slice(rows, func(i, j int) bool { return rows[i].Property.ID > rows[j].Property.ID })
+	return rows, nil
+}
+
+func (q *fakeSearchQuerier) ListPropertySearchResults(ctx context.Context, arg ListPropertySearchResultsParams) ([]ListPropertySearchResultsRow, error) {
+	rows := make([]ListPropertySearchResultsRow, 0, len(arg.Ids))
+	for _, id := range arg.Ids {
+		if property, ok := q.listed[id]; ok {
+			rows = append(rows, ListPropertySearchResultsRow{Property: property})
+		}
+	}
+	return rows, nil
+}
+
+func (q *fakeSearchQuerier) GetCacheBySearchHash(ctx context.Context, searchHash string) (PropertySearchCache, error) {
+	entry, ok := q.entries[searchHash]
+	if !ok || !entry.ExpiresAt.After(time.Now()) {
+		return PropertySearchCache{}, ErrRecordNotFound
+	}
+	return entry, nil
+}
+
+func (q *fakeSearchQuerier) UpsertPropertySearchCache(ctx context.Context, arg UpsertPropertySearchCacheParams) (PropertySearchCache, error) {
+	entry := PropertySearchCache{
+		ID:           int64(len(q.entries) + 1),
+		SearchHash:   arg.SearchHash,
+		SearchParams: arg.SearchParams,
+		PropertyIds:  arg.PropertyIds,
+		ResultCount:  arg.ResultCount,
+		ExpiresAt:    arg.ExpiresAt,
+	}
+	q.entries[arg.SearchHash] = entry
+	return entry, nil
+}
+
+func (q *fakeSearchQuerier) RecordSearchCacheHits(ctx context.Context, arg RecordSearchCacheHitsParams) error {
+	q.hits = append(q.hits, arg)
+	return nil
+}
+
+func (q *fakeSearchQuerier) ExpirePropertySearchCaches(ctx context.Context, propertyID int64) (int64, error) {
+	q.expired = append(q.expired, propertyID)
+	return 0, nil
+}
+
+func TestPropertySearchCacheHitAndMiss(t *testing.T) {
+	querier := newFakeSearchQuerier(1, 2, 3)
+	searches := newPropertySearchCache(querier)
+	arg := SearchPropertiesParams{City: pgtype.Text{String: "Lagos", Valid: true}, SortBy: "newest", Limit: 10}
+
+	rows, err := searches.search(context.Background(), arg)
+	require.NoError(t, err)
+	require.Len(t, rows, 3)
+	require.Equal(t, 1, querier.searches)
+	require.Len(t, querier.entries, 1)
+
+	// The same search, written differently, is served from cache in the cached order
+	arg.City.String = " lagos"
+	cached, err := searches.search(context.Background(), arg)
+	require.NoError(t, err)
+	require.Equal(t, 1, querier.searches)
+	require.Equal(t, rows, cached)
+
+	// A property that was taken down makes the entry a miss
+	delete(querier.listed, 2)
+	rows, err = searches.search(context.Background(), arg)
+	require.NoError(t, err)
+	require.Equal(t, 2, querier.searches)
+	require.Len(t, rows, 2)
+}
+
+func TestPropertySearchCacheSkipsLaterPages(t *testing.T) {
+	querier := newFakeSearchQuerier(1, 2, 3)
+	searches := newPropertySearchCache(querier)
+	arg := SearchPropertiesParams{
+		SortBy:   "newest",
+		CursorID: pgtype.Int8{Int64: 3, Valid: true},
+		Limit:    10,
+	}
+
+	for i := 0; i < 2; i++ {
+		_, err := searches.search(context.Background(), arg)
+		require.NoError(t, err)
+	}
+	require.Equal(t, 2, querier.searches)
+	require.Empty(t, querier.entries)
+}
+
+func TestPropertySearchCacheExpiredEntry(t *testing.T) {
+	querier := newFakeSearchQuerier(1)
+	searches := newPropertySearchCache(querier)
+	arg := SearchPropertiesParams{SortBy: "newest", Limit: 10}
+
+	_, err := searches.search(context.Background(), arg)
+	require.NoError(t, err)
+
+	// What ExpirePropertySearchCaches does to the entries a changed property appears in
+	for hash, entry := range querier.entries {
+		entry.ExpiresAt = time.Now()
+		querier.entries[hash] = entry
+	}
+
+	_, err = searches.search(context.Background(), arg)
+	require.NoError(t, err)
+	require.Equal(t, 2, querier.searches)
+}
+
+func TestPropertySearchCacheExpire(t *testing.T) {
+	querier := newFakeSearchQuerier()
+	searches := newPropertySearchCache(querier)
+
+	searches.expire(context.Background(), 7)
+	require.Equal(t, []int64{7}, querier.expired)
+}
+
+func TestSearchHitCounter(t *testing.T) {
+	start := time.Now()
+	counter := newSearchHitCounter(start)
+
+	require.Nil(t, counter.record(1, start.Add(time.Second)))
+	require.Nil(t, counter.record(1, start.Add(2*time.Second)))
+	require.Nil(t, counter.record(2, start.Add(3*time.Second)))
+
+	hits := counter.record(1, start.Add(searchHitFlushInterval))
+	require.Equal(t, map[int64]int32{1: 3, 2: 1}, hits)
+
+	// Counting starts over after a flush
+	require.Nil(t, counter.record(2, start.Add(searchHitFlushInterval+time.Second)))
+	hits = counter.record(2, start.Add(2*searchHitFlushInterval))
+	require.Equal(t, map[int64]int32{2: 2}, hits)
+}
+
+func TestPropertySearchCacheFlushHits(t *testing.T) {
+	querier := newFakeSearchQuerier()
+	searches := newPropertySearchCache(querier)
+
+	searches.flushHits(map[int64]int32{4: 2, 9: 5})
+	require.Len(t, querier.hits, 1)
+
+	flushed := make(map[int64]int32)
+	for i, id := range querier.hits[0].Ids {
+		flushed[id] = querier.hits[0].Hits[i]
+	}
+	require.Equal(t, map[int64]int32{4: 2, 9: 5}, flushed)
+}
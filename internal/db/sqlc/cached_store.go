@@ -9,13 +9,15 @@ import (
 
 type CachedStore struct {
 	*SQLStore
-	cache cache.CacheManager
+	cache    cache.CacheManager
+	searches *propertySearchCache
 }
 
 func NewCachedStore(store *SQLStore, cache cache.CacheManager) *CachedStore {
 	return &CachedStore{
 		SQLStore: store,
 		cache:    cache,
+		searches: newPropertySearchCache(store.Queries),
 	}
 }
 
@@ -73,6 +75,157 @@ func (s *CachedStore) UpdateUser(ctx context.Context, arg UpdateUserParams) (Use
 	return user, nil
 }
 
+func (s *CachedStore) UpdateUserPassword(ctx context.Context, arg UpdateUserPasswordParams) error {
+	err := s.SQLStore.UpdateUserPassword(ctx, arg)
+	if err != nil {
+		return err
+	}
+
+	// The cached user still carries the old password hash
+	s.invalidateUser(ctx, arg.ID)
+
+	return nil
+}
+
+func (s *CachedStore) UpdateUserActiveStatus(ctx context.Context, arg UpdateUserActiveStatusParams) error {
+	err := s.SQLStore.UpdateUserActiveStatus(ctx, arg)
+	if err != nil {
+		return err
+	}
+
+	// Deactivation must be visible to authorization checks right away
+	s.invalidateUser(ctx, arg.ID)
+
+	return nil
+}
+
+func (s *CachedStore) UpdateUserNIN(ctx context.Context, arg UpdateUserNINParams) error {
+	err := s.SQLStore.UpdateUserNIN(ctx, arg)
+	if err != nil {
+		return err
+	}
+
+	s.invalidateUser(ctx, arg.ID)
+
+	return nil
+}
+
+func (s *CachedStore) SubmitNINVerificationTx(ctx context.Context, arg SubmitNINVerificationTxParams) (SubmitNINVerificationTxResult, error) {
+	result, err := s.SQLStore.SubmitNINVerificationTx(ctx, arg)
+	if err != nil {
+		return result, err
+	}
+
+	// The cached user still carries the previous nin
+	s.invalidateUser(ctx, arg.UserID)
+
+	return result, nil
+}
+
+func (s *CachedStore) CompleteNINVerificationTx(ctx context.Context, arg CompleteNINVerificationTxParams) (CompleteNINVerificationTxResult, error) {
+	result, err := s.SQLStore.CompleteNINVerificationTx(ctx, arg)
+	if err != nil {
+		return result, err
+	}
+
+	// is_identity_verified may have changed
+	s.invalidateUser(ctx, result.User.ID)
+
+	return result, nil
+}
+
+func (s *CachedStore) UpdateUserType(ctx context.Context, arg UpdateUserTypeParams) error {
+	err := s.SQLStore.UpdateUserType(ctx, arg)
+	if err != nil {
+		return err
+	}
+
+	s.invalidateUser(ctx, arg.ID)
+
+	return nil
+}
+
+func (s *CachedStore) SetUserActiveStatusTx(ctx context.Context, arg SetUserActiveStatusTxParams) (SetUserActiveStatusTxResult, error) {
+	result, err := s.SQLStore.SetUserActiveStatusTx(ctx, arg)
+	if err != nil {
+		return result, err
+	}
+
+	// Deactivation must be visible to authorization checks right away
+	s.invalidateUser(ctx, arg.UserID)
+
+	return result, nil
+}
+
+func (s *CachedStore) ChangeUserTypeTx(ctx context.Context, arg ChangeUserTypeTxParams) (ChangeUserTypeTxResult, error) {
+	result, err := s.SQLStore.ChangeUserTypeTx(ctx, arg)
+	if err != nil {
+		return result, err
+	}
+
+	s.invalidateUser(ctx, arg.UserID)
+
+	return result, nil
+}
+
+func (s *CachedStore) ForcePasswordResetTx(ctx context.Context, arg ForcePasswordResetTxParams) (ForcePasswordResetTxResult, error) {
+	result, err := s.SQLStore.ForcePasswordResetTx(ctx, arg)
+	if err != nil {
+		return result, err
+	}
+
+	// The cached user still carries the old password hash
+	s.invalidateUser(ctx, arg.UserID)
+
+	return result, nil
+}
+
+func (s *CachedStore) FederatedLoginTx(ctx context.Context, arg FederatedLoginTxParams) (FederatedLoginTxResult, error) {
+	result, err := s.SQLStore.FederatedLoginTx(ctx, arg)
+	if err != nil {
+		return result, err
+	}
+
+	// Linking can verify the email and replace the password of an existing account
+	if result.Linked && !result.Created {
+		s.invalidateUser(ctx, result.User.ID)
+	}
+
+	return result, nil
+}
+
+func (s *CachedStore) AnonymizeAccountTx(ctx context.Context, arg AnonymizeAccountTxParams) (AnonymizeAccountTxResult, error) {
+	var sessions []UserSession
+	deletion, err := s.SQLStore.GetAccountDeletion(ctx, arg.DeletionID)
+	if err == nil {
+		sessions, _ = s.SQLStore.GetAllUserActiveSessions(ctx, deletion.UserID)
+	}
+
+	result, err := s.SQLStore.AnonymizeAccountTx(ctx, arg)
+	if err != nil {
+		return result, err
+	}
+
+	// invalidateUser would only find the placeholder email, the old one has to go as well
+	s.cache.Delete(ctx, cache.UserByEmailKey(result.User.Email))
+	s.invalidateUser(ctx, result.User.ID)
+	s.cache.Delete(ctx, cache.TenantProfileKey(result.User.ID))
+	s.cache.Delete(ctx, cache.LandlordProfileKey(result.User.ID))
+	for _, session := range sessions {
+		s.invalidateSession(ctx, session)
+	}
+
+	return result, nil
+}
+
+func (s *CachedStore) invalidateUser(ctx context.Context, userID int64) {
+	user, err := s.SQLStore.GetUserByID(ctx, userID)
+	if err == nil {
+		s.cache.Delete(ctx, cache.UserByEmailKey(user.Email))
+	}
+	s.cache.Delete(ctx, cache.UserKey(userID))
+}
+
 // Cached property operations
 func (s *CachedStore) GetPropertyByID(ctx context.Context, id int64) (Property, error) {
 	cacheKey := cache.PropertyKey(id)
@@ -114,6 +267,50 @@ func (s *CachedStore) ListProperties(ctx context.Context, arg ListPropertiesPara
 	return properties, nil
 }
 
+func (s *CachedStore) CreateProperty(ctx context.Context, arg CreatePropertyParams) (Property, error) {
+	property, err := s.SQLStore.CreateProperty(ctx, arg)
+	if err != nil {
+		return property, err
+	}
+
+	s.searches.expire(ctx, property.ID)
+
+	return property, nil
+}
+
+func (s *CachedStore) UpdateProperty(ctx context.Context, arg UpdatePropertyParams) (Property, error) {
+	property, err := s.SQLStore.UpdateProperty(ctx, arg)
+	if err != nil {
+		return property, err
+	}
+
+	s.cache.Delete(ctx, cache.PropertyKey(property.ID))
+	// The property may have left searches it was cached in, or now match others
+	s.searches.expire(ctx, property.ID)
+
+	return property, nil
+}
+
+func (s *CachedStore) ChangePropertyStatusTx(ctx context.Context, arg ChangePropertyStatusTxParams) (ChangePropertyStatusTxResult, error) {
+	result, err := s.SQLStore.ChangePropertyStatusTx(ctx, arg)
+	if err != nil {
+		return result, err
+	}
+
+	// The transaction may also have changed the property count on the landlord profile
+	s.cache.Delete(ctx, cache.PropertyKey(result.Property.ID))
+	s.cache.Delete(ctx, cache.LandlordProfileKey(result.Property.LandlordID))
+	s.searches.expire(ctx, result.Property.ID)
+
+	return result, nil
+}
+
+// SearchProperties serves the first page of search results from property_search_cache when the same search ran less than
+// cache.SearchCacheTTL ago. Only the ids are cached; the rows are read again, so a hit returns current listings.
+func (s *CachedStore) SearchProperties(ctx context.Context, arg SearchPropertiesParams) ([]SearchPropertiesRow, error) {
+	return s.searches.search(ctx, arg)
+}
+
 // Session caching
 func (s *CachedStore) GetUserSessionByID(ctx context.Context, id int64) (UserSession, error) {
 	cacheKey := cache.UserSessionKey(fmt.Sprintf("%d", id))
@@ -195,6 +392,124 @@ func (s *CachedStore) UpdateTenantProfile(ctx context.Context, arg UpdateTenantP
 }
 
 // Session management with cache invalidation
+func (s *CachedStore) GetLandlordProfileByUserID(ctx context.Context, userID int64) (LandlordProfile, error) {
+	cacheKey := cache.LandlordProfileKey(userID)
+
+	var profile LandlordProfile
+	if err := s.cache.GetJSON(ctx, cacheKey, &profile); err == nil {
+		return profile, nil
+	}
+
+	profile, err := s.SQLStore.GetLandlordProfileByUserID(ctx, userID)
+	if err != nil {
+		return profile, err
+	}
+
+	s.cache.SetJSON(ctx, cacheKey, profile, cache.UserCacheTTL)
+
+	return profile, nil
+}
+
+func (s *CachedStore) UpdateLandlordProfile(ctx context.Context, arg UpdateLandlordProfileParams) (LandlordProfile, error) {
+	profile, err := s.SQLStore.UpdateLandlordProfile(ctx, arg)
+	if err != nil {
+		return profile, err
+	}
+
+	s.cache.Delete(ctx, cache.LandlordProfileKey(arg.UserID))
+
+	return profile, nil
+}
+
+func (s *CachedStore) UpdateLandlordBusinessDetails(ctx context.Context, arg UpdateLandlordBusinessDetailsParams) (LandlordProfile, error) {
+	profile, err := s.SQLStore.UpdateLandlordBusinessDetails(ctx, arg)
+	if err != nil {
+		return profile, err
+	}
+
+	s.cache.Delete(ctx, cache.LandlordProfileKey(arg.UserID))
+
+	return profile, nil
+}
+
+func (s *CachedStore) UpdateLandlordBankingDetails(ctx context.Context, arg UpdateLandlordBankingDetailsParams) (LandlordProfile, error) {
+	profile, err := s.SQLStore.UpdateLandlordBankingDetails(ctx, arg)
+	if err != nil {
+		return profile, err
+	}
+
+	s.cache.Delete(ctx, cache.LandlordProfileKey(arg.UserID))
+
+	return profile, nil
+}
+
+func (s *CachedStore) UpdateLandlordGuarantorDetails(ctx context.Context, arg UpdateLandlordGuarantorDetailsParams) (LandlordProfile, error) {
+	profile, err := s.SQLStore.UpdateLandlordGuarantorDetails(ctx, arg)
+	if err != nil {
+		return profile, err
+	}
+
+	s.cache.Delete(ctx, cache.LandlordProfileKey(arg.UserID))
+
+	return profile, nil
+}
+
+func (s *CachedStore) UpdateLandlordStats(ctx context.Context, arg UpdateLandlordStatsParams) (LandlordProfile, error) {
+	profile, err := s.SQLStore.UpdateLandlordStats(ctx, arg)
+	if err != nil {
+		return profile, err
+	}
+
+	s.cache.Delete(ctx, cache.LandlordProfileKey(arg.UserID))
+
+	return profile, nil
+}
+
+func (s *CachedStore) IncrementLandlordPropertyCount(ctx context.Context, userID int64) error {
+	err := s.SQLStore.IncrementLandlordPropertyCount(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	s.cache.Delete(ctx, cache.LandlordProfileKey(userID))
+
+	return nil
+}
+
+func (s *CachedStore) DecrementLandlordPropertyCount(ctx context.Context, userID int64) error {
+	err := s.SQLStore.DecrementLandlordPropertyCount(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	s.cache.Delete(ctx, cache.LandlordProfileKey(userID))
+
+	return nil
+}
+
+func (s *CachedStore) CreateLandlordProfile(ctx context.Context, arg CreateLandlordProfileParams) (LandlordProfile, error) {
+	profile, err := s.SQLStore.CreateLandlordProfile(ctx, arg)
+	if err != nil {
+		return profile, err
+	}
+
+	// Creating fills in the empty profile made at signup, which may be cached
+	s.cache.Delete(ctx, cache.LandlordProfileKey(arg.UserID))
+
+	return profile, nil
+}
+
+func (s *CachedStore) DeleteLandlordProfile(ctx context.Context, userID int64) error {
+	err := s.SQLStore.DeleteLandlordProfile(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	s.cache.Delete(ctx, cache.LandlordProfileKey(userID))
+
+	return nil
+}
+
 func (s *CachedStore) DeactivateSession(ctx context.Context, sessionToken string) error {
 	// First, get the session info before deactivating (for cache invalidation)
 	session, sessionErr := s.SQLStore.GetUserSessionByToken(ctx, sessionToken)
@@ -219,22 +534,91 @@ func (s *CachedStore) DeactivateSession(ctx context.Context, sessionToken string
 }
 
 func (s *CachedStore) DeactivateUserSessions(ctx context.Context, userID int64) error {
+	// Get the sessions about to be deactivated so their cache entries can be dropped
+	sessions, sessionsErr := s.SQLStore.GetAllUserActiveSessions(ctx, userID)
+
 	err := s.SQLStore.DeactivateUserSessions(ctx, userID)
 	if err != nil {
 		return err
 	}
 
-	// Note: For bulk operations like this, we could implement cache pattern matching
-	// or use cache tags, but for now we'll rely on TTL expiration
-	// Individual session invalidation would require knowing all session tokens for a user
+	if sessionsErr == nil {
+		for _, session := range sessions {
+			s.invalidateSession(ctx, session)
+		}
+	}
+
+	return nil
+}
+
+func (s *CachedStore) DeactivateUserSessionBySessionID(ctx context.Context, arg DeactivateUserSessionBySessionIDParams) ([]UserSession, error) {
+	sessions, err := s.SQLStore.DeactivateUserSessionBySessionID(ctx, arg)
+	if err != nil {
+		return sessions, err
+	}
+
+	for _, session := range sessions {
+		s.invalidateSession(ctx, session)
+	}
+
+	return sessions, nil
+}
+
+func (s *CachedStore) DeactivateOtherUserSessions(ctx context.Context, arg DeactivateOtherUserSessionsParams) error {
+	// Get the sessions about to be deactivated so their cache entries can be dropped
+	sessions, sessionsErr := s.SQLStore.GetAllUserActiveSessions(ctx, arg.UserID)
+
+	err := s.SQLStore.DeactivateOtherUserSessions(ctx, arg)
+	if err != nil {
+		return err
+	}
+
+	if sessionsErr == nil {
+		for _, session := range sessions {
+			if session.SessionToken != arg.SessionToken {
+				s.invalidateSession(ctx, session)
+			}
+		}
+	}
 
 	return nil
 }
 
+func (s *CachedStore) RotateSessionTx(ctx context.Context, arg RotateSessionTxParams) (RotateSessionTxResult, error) {
+	result, err := s.SQLStore.RotateSessionTx(ctx, arg)
+	if err != nil {
+		return result, err
+	}
+
+	// The old refresh token must not be served from cache as an active session
+	s.invalidateSession(ctx, result.OldSession)
+
+	return result, nil
+}
+
+func (s *CachedStore) RevokeSessionFamilyTx(ctx context.Context, arg RevokeSessionFamilyTxParams) (RevokeSessionFamilyTxResult, error) {
+	result, err := s.SQLStore.RevokeSessionFamilyTx(ctx, arg)
+	if err != nil {
+		return result, err
+	}
+
+	for _, session := range result.RevokedSessions {
+		s.invalidateSession(ctx, session)
+	}
+
+	return result, nil
+}
+
+func (s *CachedStore) invalidateSession(ctx context.Context, session UserSession) {
+	s.cache.Delete(ctx, cache.UserSessionKey(session.SessionToken))
+	s.cache.Delete(ctx, cache.UserSessionKey(fmt.Sprintf("%d", session.ID)))
+}
+
 // Cache management methods
 func (s *CachedStore) InvalidateUserCache(ctx context.Context, userID int64) {
 	// Invalidate user-related caches
 	s.cache.Delete(ctx, cache.UserKey(userID))
 	s.cache.Delete(ctx, cache.TenantProfileKey(userID))
+	s.cache.Delete(ctx, cache.LandlordProfileKey(userID))
 	// Note: We could add more user-related cache invalidations here
 }
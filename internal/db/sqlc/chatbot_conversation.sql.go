@@ -142,6 +142,17 @@ func (q *Queries) DeleteConversationsBySession(ctx context.Context, sessionID st
 	return err
 }
 
+const deleteUserChatbotConversations = `-- name: DeleteUserChatbotConversations :exec
+DELETE FROM chatbot_conversations 
+WHERE user_id = $1
+`
+
+// Delete every chatbot conversation of a user
+func (q *Queries) DeleteUserChatbotConversations(ctx context.Context, userID int64) error {
+	_, err := q.db.Exec(ctx, deleteUserChatbotConversations, userID)
+	return err
+}
+
 const escalateConversation = `-- name: EscalateConversation :one
 UPDATE chatbot_conversations 
 SET is_escalated = true, escalated_to = $2
@@ -11,11 +11,25 @@ import (
 	"github.com/jackc/pgx/v5/pgtype"
 )
 
+const anonymizeInspectionAgentProfile = `-- name: AnonymizeInspectionAgentProfile :exec
+UPDATE inspection_agent_profiles 
+SET license_number = NULL, availability_schedule = NULL,
+    bank_name = NULL, bank_account = NULL, bank_account_name = NULL, updated_at = NOW()
+WHERE user_id = $1
+`
+
+// Clear the license, banking and availability details of a deleted agent
+func (q *Queries) AnonymizeInspectionAgentProfile(ctx context.Context, userID int64) error {
+	_, err := q.db.Exec(ctx, anonymizeInspectionAgentProfile, userID)
+	return err
+}
+
 const approveInspectionAgent = `-- name: ApproveInspectionAgent :one
 UPDATE inspection_agent_profiles 
-SET is_approved = true, approved_at = NOW(), approved_by = $2, updated_at = NOW()
+SET is_approved = true, approved_at = NOW(), approved_by = $2,
+    rejected_at = NULL, rejection_reason = NULL, updated_at = NOW()
 WHERE user_id = $1 
-RETURNING id, user_id, license_number, specializations, service_areas, hourly_rate, availability_schedule, total_inspections, average_rating, completion_rate, total_earnings, bank_name, bank_account, bank_account_name, is_approved, approved_at, approved_by, created_at, updated_at
+RETURNING id, user_id, license_number, specializations, service_areas, hourly_rate, availability_schedule, total_inspections, average_rating, completion_rate, total_earnings, bank_name, bank_account, bank_account_name, is_approved, approved_at, approved_by, created_at, updated_at, submitted_at, rejected_at, rejection_reason
 `
 
 type ApproveInspectionAgentParams struct {
@@ -47,17 +61,35 @@ func (q *Queries) ApproveInspectionAgent(ctx context.Context, arg ApproveInspect
 		&i.ApprovedBy,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.SubmittedAt,
+		&i.RejectedAt,
+		&i.RejectionReason,
 	)
 	return i, err
 }
 
+const countPendingAgentApplications = `-- name: CountPendingAgentApplications :one
+SELECT COUNT(*) FROM inspection_agent_profiles iap
+JOIN users u ON iap.user_id = u.id
+WHERE iap.is_approved = false AND iap.submitted_at IS NOT NULL AND iap.rejected_at IS NULL
+  AND u.is_active = true
+`
+
+// Count pending agent applications
+func (q *Queries) CountPendingAgentApplications(ctx context.Context) (int64, error) {
+	row := q.db.QueryRow(ctx, countPendingAgentApplications)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
 const createInspectionAgentProfile = `-- name: CreateInspectionAgentProfile :one
 INSERT INTO inspection_agent_profiles (
   user_id, license_number, specializations, service_areas, hourly_rate,
   availability_schedule, bank_name, bank_account, bank_account_name
 ) VALUES (
   $1, $2, $3, $4, $5, $6, $7, $8, $9
-) RETURNING id, user_id, license_number, specializations, service_areas, hourly_rate, availability_schedule, total_inspections, average_rating, completion_rate, total_earnings, bank_name, bank_account, bank_account_name, is_approved, approved_at, approved_by, created_at, updated_at
+) RETURNING id, user_id, license_number, specializations, service_areas, hourly_rate, availability_schedule, total_inspections, average_rating, completion_rate, total_earnings, bank_name, bank_account, bank_account_name, is_approved, approved_at, approved_by, created_at, updated_at, submitted_at, rejected_at, rejection_reason
 `
 
 type CreateInspectionAgentProfileParams struct {
@@ -106,6 +138,9 @@ func (q *Queries) CreateInspectionAgentProfile(ctx context.Context, arg CreateIn
 		&i.ApprovedBy,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.SubmittedAt,
+		&i.RejectedAt,
+		&i.RejectionReason,
 	)
 	return i, err
 }
@@ -122,7 +157,7 @@ func (q *Queries) DeleteInspectionAgentProfile(ctx context.Context, userID int64
 }
 
 const getInspectionAgentProfileByID = `-- name: GetInspectionAgentProfileByID :one
-SELECT id, user_id, license_number, specializations, service_areas, hourly_rate, availability_schedule, total_inspections, average_rating, completion_rate, total_earnings, bank_name, bank_account, bank_account_name, is_approved, approved_at, approved_by, created_at, updated_at FROM inspection_agent_profiles 
+SELECT id, user_id, license_number, specializations, service_areas, hourly_rate, availability_schedule, total_inspections, average_rating, completion_rate, total_earnings, bank_name, bank_account, bank_account_name, is_approved, approved_at, approved_by, created_at, updated_at, submitted_at, rejected_at, rejection_reason FROM inspection_agent_profiles 
 WHERE id = $1 LIMIT 1
 `
 
@@ -150,12 +185,15 @@ func (q *Queries) GetInspectionAgentProfileByID(ctx context.Context, id int64) (
 		&i.ApprovedBy,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.SubmittedAt,
+		&i.RejectedAt,
+		&i.RejectionReason,
 	)
 	return i, err
 }
 
 const getInspectionAgentProfileByUserID = `-- name: GetInspectionAgentProfileByUserID :one
-SELECT id, user_id, license_number, specializations, service_areas, hourly_rate, availability_schedule, total_inspections, average_rating, completion_rate, total_earnings, bank_name, bank_account, bank_account_name, is_approved, approved_at, approved_by, created_at, updated_at FROM inspection_agent_profiles 
+SELECT id, user_id, license_number, specializations, service_areas, hourly_rate, availability_schedule, total_inspections, average_rating, completion_rate, total_earnings, bank_name, bank_account, bank_account_name, is_approved, approved_at, approved_by, created_at, updated_at, submitted_at, rejected_at, rejection_reason FROM inspection_agent_profiles 
 WHERE user_id = $1 LIMIT 1
 `
 
@@ -183,6 +221,9 @@ func (q *Queries) GetInspectionAgentProfileByUserID(ctx context.Context, userID
 		&i.ApprovedBy,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.SubmittedAt,
+		&i.RejectedAt,
+		&i.RejectionReason,
 	)
 	return i, err
 }
@@ -200,7 +241,7 @@ func (q *Queries) IncrementAgentInspectionCount(ctx context.Context, userID int6
 }
 
 const listApprovedAgentsByArea = `-- name: ListApprovedAgentsByArea :many
-SELECT iap.id, iap.user_id, iap.license_number, iap.specializations, iap.service_areas, iap.hourly_rate, iap.availability_schedule, iap.total_inspections, iap.average_rating, iap.completion_rate, iap.total_earnings, iap.bank_name, iap.bank_account, iap.bank_account_name, iap.is_approved, iap.approved_at, iap.approved_by, iap.created_at, iap.updated_at, u.first_name, u.last_name, u.email, u.phone 
+SELECT iap.id, iap.user_id, iap.license_number, iap.specializations, iap.service_areas, iap.hourly_rate, iap.availability_schedule, iap.total_inspections, iap.average_rating, iap.completion_rate, iap.total_earnings, iap.bank_name, iap.bank_account, iap.bank_account_name, iap.is_approved, iap.approved_at, iap.approved_by, iap.created_at, iap.updated_at, iap.submitted_at, iap.rejected_at, iap.rejection_reason, u.first_name, u.last_name, u.email, u.phone 
 FROM inspection_agent_profiles iap
 JOIN users u ON iap.user_id = u.id
 WHERE iap.is_approved = true 
@@ -236,6 +277,9 @@ type ListApprovedAgentsByAreaRow struct {
 	ApprovedBy           pgtype.Int8        `json:"approved_by"`
 	CreatedAt            pgtype.Timestamptz `json:"created_at"`
 	UpdatedAt            pgtype.Timestamptz `json:"updated_at"`
+	SubmittedAt          pgtype.Timestamptz `json:"submitted_at"`
+	RejectedAt           pgtype.Timestamptz `json:"rejected_at"`
+	RejectionReason      pgtype.Text        `json:"rejection_reason"`
 	FirstName            string             `json:"first_name"`
 	LastName             string             `json:"last_name"`
 	Email                string             `json:"email"`
@@ -272,6 +316,9 @@ func (q *Queries) ListApprovedAgentsByArea(ctx context.Context, arg ListApproved
 			&i.ApprovedBy,
 			&i.CreatedAt,
 			&i.UpdatedAt,
+			&i.SubmittedAt,
+			&i.RejectedAt,
+			&i.RejectionReason,
 			&i.FirstName,
 			&i.LastName,
 			&i.Email,
@@ -288,11 +335,12 @@ func (q *Queries) ListApprovedAgentsByArea(ctx context.Context, arg ListApproved
 }
 
 const listPendingAgentApplications = `-- name: ListPendingAgentApplications :many
-SELECT iap.id, iap.user_id, iap.license_number, iap.specializations, iap.service_areas, iap.hourly_rate, iap.availability_schedule, iap.total_inspections, iap.average_rating, iap.completion_rate, iap.total_earnings, iap.bank_name, iap.bank_account, iap.bank_account_name, iap.is_approved, iap.approved_at, iap.approved_by, iap.created_at, iap.updated_at, u.first_name, u.last_name, u.email, u.phone 
+SELECT iap.id, iap.user_id, iap.license_number, iap.specializations, iap.service_areas, iap.hourly_rate, iap.availability_schedule, iap.total_inspections, iap.average_rating, iap.completion_rate, iap.total_earnings, iap.bank_name, iap.bank_account, iap.bank_account_name, iap.is_approved, iap.approved_at, iap.approved_by, iap.created_at, iap.updated_at, iap.submitted_at, iap.rejected_at, iap.rejection_reason, u.first_name, u.last_name, u.email, u.phone 
 FROM inspection_agent_profiles iap
 JOIN users u ON iap.user_id = u.id
-WHERE iap.is_approved = false AND u.is_active = true
-ORDER BY iap.created_at ASC
+WHERE iap.is_approved = false AND iap.submitted_at IS NOT NULL AND iap.rejected_at IS NULL
+  AND u.is_active = true
+ORDER BY iap.submitted_at ASC
 LIMIT $1 OFFSET $2
 `
 
@@ -321,6 +369,9 @@ type ListPendingAgentApplicationsRow struct {
 	ApprovedBy           pgtype.Int8        `json:"approved_by"`
 	CreatedAt            pgtype.Timestamptz `json:"created_at"`
 	UpdatedAt            pgtype.Timestamptz `json:"updated_at"`
+	SubmittedAt          pgtype.Timestamptz `json:"submitted_at"`
+	RejectedAt           pgtype.Timestamptz `json:"rejected_at"`
+	RejectionReason      pgtype.Text        `json:"rejection_reason"`
 	FirstName            string             `json:"first_name"`
 	LastName             string             `json:"last_name"`
 	Email                string             `json:"email"`
@@ -357,6 +408,9 @@ func (q *Queries) ListPendingAgentApplications(ctx context.Context, arg ListPend
 			&i.ApprovedBy,
 			&i.CreatedAt,
 			&i.UpdatedAt,
+			&i.SubmittedAt,
+			&i.RejectedAt,
+			&i.RejectionReason,
 			&i.FirstName,
 			&i.LastName,
 			&i.Email,
@@ -373,7 +427,7 @@ func (q *Queries) ListPendingAgentApplications(ctx context.Context, arg ListPend
 }
 
 const listTopAgentsByRating = `-- name: ListTopAgentsByRating :many
-SELECT iap.id, iap.user_id, iap.license_number, iap.specializations, iap.service_areas, iap.hourly_rate, iap.availability_schedule, iap.total_inspections, iap.average_rating, iap.completion_rate, iap.total_earnings, iap.bank_name, iap.bank_account, iap.bank_account_name, iap.is_approved, iap.approved_at, iap.approved_by, iap.created_at, iap.updated_at, u.first_name, u.last_name, u.email 
+SELECT iap.id, iap.user_id, iap.license_number, iap.specializations, iap.service_areas, iap.hourly_rate, iap.availability_schedule, iap.total_inspections, iap.average_rating, iap.completion_rate, iap.total_earnings, iap.bank_name, iap.bank_account, iap.bank_account_name, iap.is_approved, iap.approved_at, iap.approved_by, iap.created_at, iap.updated_at, iap.submitted_at, iap.rejected_at, iap.rejection_reason, u.first_name, u.last_name, u.email 
 FROM inspection_agent_profiles iap
 JOIN users u ON iap.user_id = u.id
 WHERE iap.is_approved = true AND u.is_active = true
@@ -406,6 +460,9 @@ type ListTopAgentsByRatingRow struct {
 	ApprovedBy           pgtype.Int8        `json:"approved_by"`
 	CreatedAt            pgtype.Timestamptz `json:"created_at"`
 	UpdatedAt            pgtype.Timestamptz `json:"updated_at"`
+	SubmittedAt          pgtype.Timestamptz `json:"submitted_at"`
+	RejectedAt           pgtype.Timestamptz `json:"rejected_at"`
+	RejectionReason      pgtype.Text        `json:"rejection_reason"`
 	FirstName            string             `json:"first_name"`
 	LastName             string             `json:"last_name"`
 	Email                string             `json:"email"`
@@ -441,6 +498,9 @@ func (q *Queries) ListTopAgentsByRating(ctx context.Context, arg ListTopAgentsBy
 			&i.ApprovedBy,
 			&i.CreatedAt,
 			&i.UpdatedAt,
+			&i.SubmittedAt,
+			&i.RejectedAt,
+			&i.RejectionReason,
 			&i.FirstName,
 			&i.LastName,
 			&i.Email,
@@ -455,23 +515,114 @@ func (q *Queries) ListTopAgentsByRating(ctx context.Context, arg ListTopAgentsBy
 	return items, nil
 }
 
-const rejectInspectionAgent = `-- name: RejectInspectionAgent :exec
+const rejectInspectionAgent = `-- name: RejectInspectionAgent :one
 UPDATE inspection_agent_profiles 
-SET is_approved = false, approved_at = NULL, approved_by = NULL, updated_at = NOW()
-WHERE user_id = $1
+SET is_approved = false, approved_at = NULL, approved_by = NULL,
+    rejected_at = NOW(), rejection_reason = $2, updated_at = NOW()
+WHERE user_id = $1 
+RETURNING id, user_id, license_number, specializations, service_areas, hourly_rate, availability_schedule, total_inspections, average_rating, completion_rate, total_earnings, bank_name, bank_account, bank_account_name, is_approved, approved_at, approved_by, created_at, updated_at, submitted_at, rejected_at, rejection_reason
 `
 
+type RejectInspectionAgentParams struct {
+	UserID          int64       `json:"user_id"`
+	RejectionReason pgtype.Text `json:"rejection_reason"`
+}
+
 // Reject inspection agent
-func (q *Queries) RejectInspectionAgent(ctx context.Context, userID int64) error {
-	_, err := q.db.Exec(ctx, rejectInspectionAgent, userID)
-	return err
+func (q *Queries) RejectInspectionAgent(ctx context.Context, arg RejectInspectionAgentParams) (InspectionAgentProfile, error) {
+	row := q.db.QueryRow(ctx, rejectInspectionAgent, arg.UserID, arg.RejectionReason)
+	var i InspectionAgentProfile
+	err := row.Scan(
+		&i.ID,
+		&i.UserID,
+		&i.LicenseNumber,
+		&i.Specializations,
+		&i.ServiceAreas,
+		&i.HourlyRate,
+		&i.AvailabilitySchedule,
+		&i.TotalInspections,
+		&i.AverageRating,
+		&i.CompletionRate,
+		&i.TotalEarnings,
+		&i.BankName,
+		&i.BankAccount,
+		&i.BankAccountName,
+		&i.IsApproved,
+		&i.ApprovedAt,
+		&i.ApprovedBy,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.SubmittedAt,
+		&i.RejectedAt,
+		&i.RejectionReason,
+	)
+	return i, err
+}
+
+const submitInspectionAgentApplication = `-- name: SubmitInspectionAgentApplication :one
+INSERT INTO inspection_agent_profiles (
+  user_id, license_number, specializations, service_areas, hourly_rate, submitted_at
+) VALUES (
+  $1, $2, $3, $4, $5, NOW()
+)
+ON CONFLICT (user_id) DO UPDATE
+SET license_number = EXCLUDED.license_number, specializations = EXCLUDED.specializations,
+    service_areas = EXCLUDED.service_areas, hourly_rate = EXCLUDED.hourly_rate,
+    is_approved = false, approved_at = NULL, approved_by = NULL,
+    submitted_at = NOW(), rejected_at = NULL, rejection_reason = NULL, updated_at = NOW()
+RETURNING id, user_id, license_number, specializations, service_areas, hourly_rate, availability_schedule, total_inspections, average_rating, completion_rate, total_earnings, bank_name, bank_account, bank_account_name, is_approved, approved_at, approved_by, created_at, updated_at, submitted_at, rejected_at, rejection_reason
+`
+
+type SubmitInspectionAgentApplicationParams struct {
+	UserID          int64          `json:"user_id"`
+	LicenseNumber   pgtype.Text    `json:"license_number"`
+	Specializations pgtype.Text    `json:"specializations"`
+	ServiceAreas    pgtype.Text    `json:"service_areas"`
+	HourlyRate      pgtype.Numeric `json:"hourly_rate"`
+}
+
+// Submit or resubmit an agent application for review
+func (q *Queries) SubmitInspectionAgentApplication(ctx context.Context, arg SubmitInspectionAgentApplicationParams) (InspectionAgentProfile, error) {
+	row := q.db.QueryRow(ctx, submitInspectionAgentApplication,
+		arg.UserID,
+		arg.LicenseNumber,
+		arg.Specializations,
+		arg.ServiceAreas,
+		arg.HourlyRate,
+	)
+	var i InspectionAgentProfile
+	err := row.Scan(
+		&i.ID,
+		&i.UserID,
+		&i.LicenseNumber,
+		&i.Specializations,
+		&i.ServiceAreas,
+		&i.HourlyRate,
+		&i.AvailabilitySchedule,
+		&i.TotalInspections,
+		&i.AverageRating,
+		&i.CompletionRate,
+		&i.TotalEarnings,
+		&i.BankName,
+		&i.BankAccount,
+		&i.BankAccountName,
+		&i.IsApproved,
+		&i.ApprovedAt,
+		&i.ApprovedBy,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.SubmittedAt,
+		&i.RejectedAt,
+		&i.RejectionReason,
+	)
+	return i, err
 }
 
 const updateAgentBankingDetails = `-- name: UpdateAgentBankingDetails :one
 UPDATE inspection_agent_profiles 
 SET bank_name = $2, bank_account = $3, bank_account_name = $4, updated_at = NOW()
 WHERE user_id = $1 
-RETURNING id, user_id, license_number, specializations, service_areas, hourly_rate, availability_schedule, total_inspections, average_rating, completion_rate, total_earnings, bank_name, bank_account, bank_account_name, is_approved, approved_at, approved_by, created_at, updated_at
+RETURNING id, user_id, license_number, specializations, service_areas, hourly_rate, availability_schedule, total_inspections, average_rating, completion_rate, total_earnings, bank_name, bank_account, bank_account_name, is_approved, approved_at, approved_by, created_at, updated_at, submitted_at, rejected_at, rejection_reason
 `
 
 type UpdateAgentBankingDetailsParams struct {
@@ -510,6 +661,9 @@ func (q *Queries) UpdateAgentBankingDetails(ctx context.Context, arg UpdateAgent
 		&i.ApprovedBy,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.SubmittedAt,
+		&i.RejectedAt,
+		&i.RejectionReason,
 	)
 	return i, err
 }
@@ -536,7 +690,7 @@ UPDATE inspection_agent_profiles
 SET specializations = $2, service_areas = $3, hourly_rate = $4,
     availability_schedule = $5, updated_at = NOW()
 WHERE user_id = $1 
-RETURNING id, user_id, license_number, specializations, service_areas, hourly_rate, availability_schedule, total_inspections, average_rating, completion_rate, total_earnings, bank_name, bank_account, bank_account_name, is_approved, approved_at, approved_by, created_at, updated_at
+RETURNING id, user_id, license_number, specializations, service_areas, hourly_rate, availability_schedule, total_inspections, average_rating, completion_rate, total_earnings, bank_name, bank_account, bank_account_name, is_approved, approved_at, approved_by, created_at, updated_at, submitted_at, rejected_at, rejection_reason
 `
 
 type UpdateAgentServiceDetailsParams struct {
@@ -577,6 +731,9 @@ func (q *Queries) UpdateAgentServiceDetails(ctx context.Context, arg UpdateAgent
 		&i.ApprovedBy,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.SubmittedAt,
+		&i.RejectedAt,
+		&i.RejectionReason,
 	)
 	return i, err
 }
@@ -586,7 +743,7 @@ UPDATE inspection_agent_profiles
 SET total_inspections = $2, average_rating = $3, completion_rate = $4,
     total_earnings = $5, updated_at = NOW()
 WHERE user_id = $1 
-RETURNING id, user_id, license_number, specializations, service_areas, hourly_rate, availability_schedule, total_inspections, average_rating, completion_rate, total_earnings, bank_name, bank_account, bank_account_name, is_approved, approved_at, approved_by, created_at, updated_at
+RETURNING id, user_id, license_number, specializations, service_areas, hourly_rate, availability_schedule, total_inspections, average_rating, completion_rate, total_earnings, bank_name, bank_account, bank_account_name, is_approved, approved_at, approved_by, created_at, updated_at, submitted_at, rejected_at, rejection_reason
 `
 
 type UpdateAgentStatsParams struct {
@@ -627,6 +784,9 @@ func (q *Queries) UpdateAgentStats(ctx context.Context, arg UpdateAgentStatsPara
 		&i.ApprovedBy,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.SubmittedAt,
+		&i.RejectedAt,
+		&i.RejectionReason,
 	)
 	return i, err
 }
@@ -637,7 +797,7 @@ SET license_number = $2, specializations = $3, service_areas = $4,
     hourly_rate = $5, availability_schedule = $6, bank_name = $7,
     bank_account = $8, bank_account_name = $9, updated_at = NOW()
 WHERE user_id = $1 
-RETURNING id, user_id, license_number, specializations, service_areas, hourly_rate, availability_schedule, total_inspections, average_rating, completion_rate, total_earnings, bank_name, bank_account, bank_account_name, is_approved, approved_at, approved_by, created_at, updated_at
+RETURNING id, user_id, license_number, specializations, service_areas, hourly_rate, availability_schedule, total_inspections, average_rating, completion_rate, total_earnings, bank_name, bank_account, bank_account_name, is_approved, approved_at, approved_by, created_at, updated_at, submitted_at, rejected_at, rejection_reason
 `
 
 type UpdateInspectionAgentProfileParams struct {
@@ -686,6 +846,9 @@ func (q *Queries) UpdateInspectionAgentProfile(ctx context.Context, arg UpdateIn
 		&i.ApprovedBy,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.SubmittedAt,
+		&i.RejectedAt,
+		&i.RejectionReason,
 	)
 	return i, err
 }
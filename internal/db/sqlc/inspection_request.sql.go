@@ -15,6 +15,10 @@ const assignInspectionAgent = `-- name: AssignInspectionAgent :one
 UPDATE inspection_requests 
 SET inspection_agent_id = $2, status = 'agent_assigned', updated_at = NOW()
 WHERE id = $1 
+  AND EXISTS (
+    SELECT 1 FROM inspection_agent_profiles
+    WHERE user_id = $2 AND is_approved = true
+  )
 RETURNING id, property_id, tenant_id, landlord_id, inspection_agent_id, inspection_type, requested_date, requested_time, special_requirements, inspection_fee, status, payment_status, payment_reference, confirmed_date, confirmed_time, completed_at, cancellation_reason, cancelled_at, created_at, updated_at
 `
 
@@ -23,7 +27,7 @@ type AssignInspectionAgentParams struct {
 	InspectionAgentID pgtype.Int8 `json:"inspection_agent_id"`
 }
 
-// Assign inspection agent
+// Assign inspection agent, only if the agent has been approved
 func (q *Queries) AssignInspectionAgent(ctx context.Context, arg AssignInspectionAgentParams) (InspectionRequest, error) {
 	row := q.db.QueryRow(ctx, assignInspectionAgent, arg.ID, arg.InspectionAgentID)
 	var i InspectionRequest
@@ -909,6 +913,52 @@ func (q *Queries) GetTenantInspectionRequests(ctx context.Context, arg GetTenant
 	return items, nil
 }
 
+const listAgentBookedInspections = `-- name: ListAgentBookedInspections :many
+SELECT id, confirmed_date, confirmed_time FROM inspection_requests
+WHERE inspection_agent_id = $1
+  AND status IN ('confirmed', 'agent_assigned')
+  AND confirmed_date BETWEEN $2::date AND $3::date
+  AND confirmed_time IS NOT NULL
+ORDER BY confirmed_date, confirmed_time
+`
+
+type ListAgentBookedInspectionsParams struct {
+	AgentID  pgtype.Int8 `json:"agent_id"`
+	FromDate pgtype.Date `json:"from_date"`
+	ToDate   pgtype.Date `json:"to_date"`
+}
+
+type ListAgentBookedInspectionsRow struct {
+	ID            int64       `json:"id"`
+	ConfirmedDate pgtype.Date `json:"confirmed_date"`
+	ConfirmedTime pgtype.Time `json:"confirmed_time"`
+}
+
+// List the confirmed inspections of an agent in a range of dates, which take up their time
+func (q *Queries) ListAgentBookedInspections(ctx context.Context, arg ListAgentBookedInspectionsParams) ([]ListAgentBookedInspectionsRow, error) {
+	rows, err := q.db.Query(ctx, listAgentBookedInspections, arg.AgentID, arg.FromDate, arg.ToDate)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ListAgentBookedInspectionsRow{}
+	for rows.Next() {
+		var i ListAgentBookedInspectionsRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.ConfirmedDate,
+			&i.ConfirmedTime,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const updateInspectionPaymentStatus = `-- name: UpdateInspectionPaymentStatus :one
 UPDATE inspection_requests 
 SET payment_status = $2, payment_reference = $3, updated_at = NOW()
@@ -810,6 +810,18 @@ func (q *Queries) MarkMessagesAsRead(ctx context.Context, dollar_1 []int64) erro
 	return err
 }
 
+const redactMessagesBySender = `-- name: RedactMessagesBySender :exec
+UPDATE messages 
+SET content = '[deleted]', media_url = NULL
+WHERE sender_id = $1
+`
+
+// Remove the content of every message a user sent
+func (q *Queries) RedactMessagesBySender(ctx context.Context, senderID int64) error {
+	_, err := q.db.Exec(ctx, redactMessagesBySender, senderID)
+	return err
+}
+
 const searchMessages = `-- name: SearchMessages :many
 SELECT m.id, m.sender_id, m.recipient_id, m.property_id, m.inspection_request_id, m.application_id, m.message_type, m.content, m.media_url, m.is_read, m.read_at, m.created_at, s.first_name as sender_first_name, s.last_name as sender_last_name
 FROM messages m
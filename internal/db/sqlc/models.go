@@ -9,9 +9,54 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5/pgtype"
 )
 
+type AccountRequestStatusEnum string
+
+const (
+	AccountRequestStatusEnumPending   AccountRequestStatusEnum = "pending"
+	AccountRequestStatusEnumCompleted AccountRequestStatusEnum = "completed"
+	AccountRequestStatusEnumCancelled AccountRequestStatusEnum = "cancelled"
+	AccountRequestStatusEnumFailed    AccountRequestStatusEnum = "failed"
+)
+
+func (e *AccountRequestStatusEnum) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = AccountRequestStatusEnum(s)
+	case string:
+		*e = AccountRequestStatusEnum(s)
+	default:
+		return fmt.Errorf("unsupported scan type for AccountRequestStatusEnum: %T", src)
+	}
+	return nil
+}
+
+type NullAccountRequestStatusEnum struct {
+	AccountRequestStatusEnum AccountRequestStatusEnum `json:"account_request_status_enum"`
+	Valid                    bool                     `json:"valid"` // Valid is true if AccountRequestStatusEnum is not NULL
+}
+
+// Scan implements the Scanner interface.
+func (ns *NullAccountRequestStatusEnum) Scan(value interface{}) error {
+	if value == nil {
+		ns.AccountRequestStatusEnum, ns.Valid = "", false
+		return nil
+	}
+	ns.Valid = true
+	return ns.AccountRequestStatusEnum.Scan(value)
+}
+
+// Value implements the driver Valuer interface.
+func (ns NullAccountRequestStatusEnum) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	return string(ns.AccountRequestStatusEnum), nil
+}
+
 type AgreementStatusEnum string
 
 const (
@@ -281,6 +326,52 @@ func (ns NullDisputeTypeEnum) Value() (driver.Value, error) {
 	return string(ns.DisputeTypeEnum), nil
 }
 
+type DocumentTypeEnum string
+
+const (
+	DocumentTypeEnumGovernmentId     DocumentTypeEnum = "government_id"
+	DocumentTypeEnumPayslip          DocumentTypeEnum = "payslip"
+	DocumentTypeEnumBankStatement    DocumentTypeEnum = "bank_statement"
+	DocumentTypeEnumEmploymentLetter DocumentTypeEnum = "employment_letter"
+	DocumentTypeEnumReferenceLetter  DocumentTypeEnum = "reference_letter"
+	DocumentTypeEnumOther            DocumentTypeEnum = "other"
+)
+
+func (e *DocumentTypeEnum) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = DocumentTypeEnum(s)
+	case string:
+		*e = DocumentTypeEnum(s)
+	default:
+		return fmt.Errorf("unsupported scan type for DocumentTypeEnum: %T", src)
+	}
+	return nil
+}
+
+type NullDocumentTypeEnum struct {
+	DocumentTypeEnum DocumentTypeEnum `json:"document_type_enum"`
+	Valid            bool             `json:"valid"` // Valid is true if DocumentTypeEnum is not NULL
+}
+
+// Scan implements the Scanner interface.
+func (ns *NullDocumentTypeEnum) Scan(value interface{}) error {
+	if value == nil {
+		ns.DocumentTypeEnum, ns.Valid = "", false
+		return nil
+	}
+	ns.Valid = true
+	return ns.DocumentTypeEnum.Scan(value)
+}
+
+// Value implements the driver Valuer interface.
+func (ns NullDocumentTypeEnum) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	return string(ns.DocumentTypeEnum), nil
+}
+
 type FurnishingStatusEnum string
 
 const (
@@ -1255,6 +1346,8 @@ const (
 	VerificationTypeEnumLicense         VerificationTypeEnum = "license"
 	VerificationTypeEnumBackgroundCheck VerificationTypeEnum = "background_check"
 	VerificationTypeEnumPasswordReset   VerificationTypeEnum = "password_reset"
+	VerificationTypeEnumTotp            VerificationTypeEnum = "totp"
+	VerificationTypeEnumLoginCode       VerificationTypeEnum = "login_code"
 )
 
 func (e *VerificationTypeEnum) Scan(src interface{}) error {
@@ -1292,6 +1385,57 @@ func (ns NullVerificationTypeEnum) Value() (driver.Value, error) {
 	return string(ns.VerificationTypeEnum), nil
 }
 
+type AccountDeletion struct {
+	ID           int64                    `json:"id"`
+	UserID       int64                    `json:"user_id"`
+	Status       AccountRequestStatusEnum `json:"status"`
+	Reason       pgtype.Text              `json:"reason"`
+	ScheduledFor time.Time                `json:"scheduled_for"`
+	CreatedAt    time.Time                `json:"created_at"`
+	CompletedAt  pgtype.Timestamptz       `json:"completed_at"`
+	CancelledAt  pgtype.Timestamptz       `json:"cancelled_at"`
+}
+
+type AccountExport struct {
+	ID          int64                    `json:"id"`
+	UserID      int64                    `json:"user_id"`
+	Status      AccountRequestStatusEnum `json:"status"`
+	FileName    pgtype.Text              `json:"file_name"`
+	Content     []byte                   `json:"content"`
+	ExpiresAt   pgtype.Timestamptz       `json:"expires_at"`
+	CreatedAt   time.Time                `json:"created_at"`
+	CompletedAt pgtype.Timestamptz       `json:"completed_at"`
+}
+
+type AccountLockout struct {
+	UserID         int64              `json:"user_id"`
+	FailedAttempts int32              `json:"failed_attempts"`
+	LockoutCount   int32              `json:"lockout_count"`
+	LockedUntil    pgtype.Timestamptz `json:"locked_until"`
+	UpdatedAt      time.Time          `json:"updated_at"`
+}
+
+type AgentAvailabilityException struct {
+	ID          int64       `json:"id"`
+	AgentID     int64       `json:"agent_id"`
+	StartDate   pgtype.Date `json:"start_date"`
+	EndDate     pgtype.Date `json:"end_date"`
+	StartTime   pgtype.Time `json:"start_time"`
+	EndTime     pgtype.Time `json:"end_time"`
+	IsAvailable bool        `json:"is_available"`
+	Reason      pgtype.Text `json:"reason"`
+	CreatedAt   time.Time   `json:"created_at"`
+}
+
+type AgentAvailabilityWindow struct {
+	ID        int64       `json:"id"`
+	AgentID   int64       `json:"agent_id"`
+	DayOfWeek int16       `json:"day_of_week"`
+	StartTime pgtype.Time `json:"start_time"`
+	EndTime   pgtype.Time `json:"end_time"`
+	CreatedAt time.Time   `json:"created_at"`
+}
+
 type AuditLog struct {
 	ID         int64              `json:"id"`
 	UserID     pgtype.Int8        `json:"user_id"`
@@ -1336,6 +1480,15 @@ type DisputeCase struct {
 	UpdatedAt         pgtype.Timestamptz    `json:"updated_at"`
 }
 
+type DocumentAccessGrant struct {
+	ID                  int64              `json:"id"`
+	DocumentID          int64              `json:"document_id"`
+	RentalApplicationID int64              `json:"rental_application_id"`
+	GranteeID           int64              `json:"grantee_id"`
+	RevokedAt           pgtype.Timestamptz `json:"revoked_at"`
+	CreatedAt           time.Time          `json:"created_at"`
+}
+
 type InspectionAgentProfile struct {
 	ID                   int64              `json:"id"`
 	UserID               int64              `json:"user_id"`
@@ -1356,6 +1509,9 @@ type InspectionAgentProfile struct {
 	ApprovedBy           pgtype.Int8        `json:"approved_by"`
 	CreatedAt            pgtype.Timestamptz `json:"created_at"`
 	UpdatedAt            pgtype.Timestamptz `json:"updated_at"`
+	SubmittedAt          pgtype.Timestamptz `json:"submitted_at"`
+	RejectedAt           pgtype.Timestamptz `json:"rejected_at"`
+	RejectionReason      pgtype.Text        `json:"rejection_reason"`
 }
 
 type InspectionReport struct {
@@ -1507,6 +1663,7 @@ type Property struct {
 	ExpiresAt              pgtype.Timestamptz       `json:"expires_at"`
 	CreatedAt              pgtype.Timestamptz       `json:"created_at"`
 	UpdatedAt              pgtype.Timestamptz       `json:"updated_at"`
+	PetFriendly            bool                     `json:"pet_friendly"`
 }
 
 type PropertyCommunityReview struct {
@@ -1554,13 +1711,15 @@ type PropertyMedium struct {
 }
 
 type PropertySearchCache struct {
-	ID           int64              `json:"id"`
-	SearchHash   string             `json:"search_hash"`
-	SearchParams pgtype.Text        `json:"search_params"`
-	PropertyIds  pgtype.Text        `json:"property_ids"`
-	ResultCount  int32              `json:"result_count"`
-	ExpiresAt    time.Time          `json:"expires_at"`
-	CreatedAt    pgtype.Timestamptz `json:"created_at"`
+	ID             int64              `json:"id"`
+	SearchHash     string             `json:"search_hash"`
+	SearchParams   pgtype.Text        `json:"search_params"`
+	PropertyIds    pgtype.Text        `json:"property_ids"`
+	ResultCount    int32              `json:"result_count"`
+	ExpiresAt      time.Time          `json:"expires_at"`
+	CreatedAt      pgtype.Timestamptz `json:"created_at"`
+	SearchCount    int32              `json:"search_count"`
+	LastSearchedAt time.Time          `json:"last_searched_at"`
 }
 
 type RentalAgreement struct {
@@ -1585,21 +1744,26 @@ type RentalAgreement struct {
 }
 
 type RentalApplication struct {
-	ID                   int64                     `json:"id"`
-	PropertyID           int64                     `json:"property_id"`
-	TenantID             int64                     `json:"tenant_id"`
-	LandlordID           int64                     `json:"landlord_id"`
-	ApplicationDocuments pgtype.Text               `json:"application_documents"`
-	EmploymentDetails    pgtype.Text               `json:"employment_details"`
-	References           pgtype.Text               `json:"references"`
-	PreferredMoveInDate  pgtype.Date               `json:"preferred_move_in_date"`
-	AdditionalNotes      pgtype.Text               `json:"additional_notes"`
-	Status               NullApplicationStatusEnum `json:"status"`
-	DecisionReason       pgtype.Text               `json:"decision_reason"`
-	DecidedAt            pgtype.Timestamptz        `json:"decided_at"`
-	DecidedBy            pgtype.Int8               `json:"decided_by"`
-	CreatedAt            pgtype.Timestamptz        `json:"created_at"`
-	UpdatedAt            pgtype.Timestamptz        `json:"updated_at"`
+	ID                  int64                     `json:"id"`
+	PropertyID          int64                     `json:"property_id"`
+	TenantID            int64                     `json:"tenant_id"`
+	LandlordID          int64                     `json:"landlord_id"`
+	EmploymentDetails   pgtype.Text               `json:"employment_details"`
+	References          pgtype.Text               `json:"references"`
+	PreferredMoveInDate pgtype.Date               `json:"preferred_move_in_date"`
+	AdditionalNotes     pgtype.Text               `json:"additional_notes"`
+	Status              NullApplicationStatusEnum `json:"status"`
+	DecisionReason      pgtype.Text               `json:"decision_reason"`
+	DecidedAt           pgtype.Timestamptz        `json:"decided_at"`
+	DecidedBy           pgtype.Int8               `json:"decided_by"`
+	CreatedAt           pgtype.Timestamptz        `json:"created_at"`
+	UpdatedAt           pgtype.Timestamptz        `json:"updated_at"`
+}
+
+type RentalApplicationLegacyDocument struct {
+	RentalApplicationID  int64     `json:"rental_application_id"`
+	ApplicationDocuments string    `json:"application_documents"`
+	ArchivedAt           time.Time `json:"archived_at"`
 }
 
 type SavedProperty struct {
@@ -1621,6 +1785,18 @@ type SystemSetting struct {
 	UpdatedAt    pgtype.Timestamptz  `json:"updated_at"`
 }
 
+type TenantDocument struct {
+	ID           int64            `json:"id"`
+	OwnerID      int64            `json:"owner_id"`
+	DocumentType DocumentTypeEnum `json:"document_type"`
+	FileName     string           `json:"file_name"`
+	ContentType  string           `json:"content_type"`
+	SizeBytes    int64            `json:"size_bytes"`
+	Checksum     string           `json:"checksum"`
+	StorageKey   string           `json:"storage_key"`
+	CreatedAt    time.Time        `json:"created_at"`
+}
+
 type TenantProfile struct {
 	ID                 int64              `json:"id"`
 	UserID             int64              `json:"user_id"`
@@ -1641,20 +1817,31 @@ type TenantProfile struct {
 }
 
 type User struct {
-	ID                int64              `json:"id"`
-	Email             string             `json:"email"`
-	Phone             string             `json:"phone"`
-	PasswordHash      string             `json:"password_hash"`
-	FirstName         string             `json:"first_name"`
-	LastName          string             `json:"last_name"`
-	UserType          UserTypeEnum       `json:"user_type"`
-	Nin               pgtype.Text        `json:"nin"`
-	IsVerified        pgtype.Bool        `json:"is_verified"`
-	IsActive          pgtype.Bool        `json:"is_active"`
-	ProfilePictureUrl pgtype.Text        `json:"profile_picture_url"`
-	CreatedAt         pgtype.Timestamptz `json:"created_at"`
-	UpdatedAt         pgtype.Timestamptz `json:"updated_at"`
-	LastLogin         pgtype.Timestamptz `json:"last_login"`
+	ID                 int64              `json:"id"`
+	Email              string             `json:"email"`
+	Phone              string             `json:"phone"`
+	PasswordHash       string             `json:"password_hash"`
+	FirstName          string             `json:"first_name"`
+	LastName           string             `json:"last_name"`
+	UserType           UserTypeEnum       `json:"user_type"`
+	Nin                pgtype.Text        `json:"nin"`
+	IsVerified         pgtype.Bool        `json:"is_verified"`
+	IsActive           pgtype.Bool        `json:"is_active"`
+	ProfilePictureUrl  pgtype.Text        `json:"profile_picture_url"`
+	CreatedAt          pgtype.Timestamptz `json:"created_at"`
+	UpdatedAt          pgtype.Timestamptz `json:"updated_at"`
+	LastLogin          pgtype.Timestamptz `json:"last_login"`
+	IsIdentityVerified bool               `json:"is_identity_verified"`
+}
+
+type UserIdentity struct {
+	ID         int64     `json:"id"`
+	UserID     int64     `json:"user_id"`
+	Provider   string    `json:"provider"`
+	Subject    string    `json:"subject"`
+	Email      string    `json:"email"`
+	CreatedAt  time.Time `json:"created_at"`
+	LastUsedAt time.Time `json:"last_used_at"`
 }
 
 type UserRating struct {
@@ -1682,6 +1869,9 @@ type UserSession struct {
 	ExpiresAt    time.Time          `json:"expires_at"`
 	IsActive     pgtype.Bool        `json:"is_active"`
 	CreatedAt    pgtype.Timestamptz `json:"created_at"`
+	SessionID    uuid.UUID          `json:"session_id"`
+	RotatedAt    pgtype.Timestamptz `json:"rotated_at"`
+	ReplacedBy   pgtype.Int8        `json:"replaced_by"`
 }
 
 type UserVerification struct {
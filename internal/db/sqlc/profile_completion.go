@@ -0,0 +1,120 @@
+package db
+
+import (
+	"context"
+	"errors"
+)
+
+// ProfileItem is one step of the onboarding checklist of a user
+type ProfileItem struct {
+	Key       string
+	Label     string
+	Weight    int32
+	Completed bool
+}
+
+// ProfileCompletion is the onboarding checklist of a user with its weighted score
+type ProfileCompletion struct {
+	Score int32 // percentage of the total weight that is completed
+	Items []ProfileItem
+}
+
+// Missing returns the items that are still to be done
+func (completion ProfileCompletion) Missing() []ProfileItem {
+	missing := []ProfileItem{}
+	for _, item := range completion.Items {
+		if !item.Completed {
+			missing = append(missing, item)
+		}
+	}
+	return missing
+}
+
+// GetProfileCompletion builds the checklist of user from their verifications and the profile of their role.
+// A role profile that was never created counts as empty.
+func GetProfileCompletion(ctx context.Context, q Querier, user User) (ProfileCompletion, error) {
+	verifications, err := q.GetUserVerificationsByUserID(ctx, user.ID)
+	if err != nil {
+		return ProfileCompletion{}, err
+	}
+
+	items := []ProfileItem{
+		{Key: "email_verified", Label: "Verify your email address", Weight: 15, Completed: everVerified(verifications, VerificationTypeEnumEmail)},
+		{Key: "phone_verified", Label: "Verify your phone number", Weight: 15, Completed: isVerified(verifications, VerificationTypeEnumPhone)},
+		{Key: "nin_verified", Label: "Verify your NIN", Weight: 20, Completed: isVerified(verifications, VerificationTypeEnumNin)},
+		{Key: "profile_picture", Label: "Add a profile picture", Weight: 5, Completed: user.ProfilePictureUrl.String != ""},
+	}
+
+	switch user.UserType {
+	case UserTypeEnumTenant:
+		profile, err := q.GetTenantProfileByUserID(ctx, user.ID)
+		if err != nil && !errors.Is(err, ErrRecordNotFound) {
+			return ProfileCompletion{}, err
+		}
+		items = append(items,
+			ProfileItem{Key: "rental_preferences", Label: "Tell us where and what you want to rent", Weight: 15,
+				Completed: profile.PreferredLocations.String != "" && profile.BudgetMax.Valid},
+			ProfileItem{Key: "employment_details", Label: "Add your employment details", Weight: 15,
+				Completed: profile.Occupation.String != "" && profile.Employer.String != "" && profile.MonthlyIncome.Valid},
+			ProfileItem{Key: "references", Label: "Add your references", Weight: 15, Completed: profile.References.String != ""},
+		)
+	case UserTypeEnumLandlord:
+		profile, err := q.GetLandlordProfileByUserID(ctx, user.ID)
+		if err != nil && !errors.Is(err, ErrRecordNotFound) {
+			return ProfileCompletion{}, err
+		}
+		items = append(items,
+			ProfileItem{Key: "landlord_profile", Label: "Create your landlord profile", Weight: 10, Completed: err == nil},
+			ProfileItem{Key: "bank_details", Label: "Add the bank account rent is paid into", Weight: 20,
+				Completed: profile.BankName.String != "" && profile.BankAccount.String != "" && profile.BankAccountName.String != ""},
+			ProfileItem{Key: "guarantor", Label: "Add a guarantor", Weight: 15,
+				Completed: profile.GuarantorName.String != "" && profile.GuarantorPhone.String != ""},
+		)
+	case UserTypeEnumInspectionAgent:
+		profile, err := q.GetInspectionAgentProfileByUserID(ctx, user.ID)
+		if err != nil && !errors.Is(err, ErrRecordNotFound) {
+			return ProfileCompletion{}, err
+		}
+		items = append(items,
+			ProfileItem{Key: "agent_application", Label: "Apply with your license details", Weight: 15, Completed: profile.LicenseNumber.String != ""},
+			ProfileItem{Key: "agent_approved", Label: "Get your application approved", Weight: 10, Completed: profile.IsApproved.Bool},
+			ProfileItem{Key: "bank_details", Label: "Add the bank account you are paid into", Weight: 20,
+				Completed: profile.BankName.String != "" && profile.BankAccount.String != "" && profile.BankAccountName.String != ""},
+		)
+	}
+
+	var total, completed int32
+	for _, item := range items {
+		total += item.Weight
+		if item.Completed {
+			completed += item.Weight
+		}
+	}
+
+	return ProfileCompletion{Score: completed * 100 / total, Items: items}, nil
+}
+
+// everVerified reports whether any verification of a type succeeded. Resending the email opens a new pending
+// verification, which doesn't undo an earlier one.
+func everVerified(verifications []UserVerification, verificationType VerificationTypeEnum) bool {
+	for _, verification := range verifications {
+		if verification.VerificationType == verificationType &&
+			verification.VerificationStatus.Valid &&
+			verification.VerificationStatus.VerificationStatusEnum == VerificationStatusEnumVerified {
+			return true
+		}
+	}
+	return false
+}
+
+// isVerified reports whether the latest verification of a type succeeded; verifications are newest first.
+// A new phone number or NIN has to be verified again, so older verifications of them don't count.
+func isVerified(verifications []UserVerification, verificationType VerificationTypeEnum) bool {
+	for _, verification := range verifications {
+		if verification.VerificationType == verificationType {
+			return verification.VerificationStatus.Valid &&
+				verification.VerificationStatus.VerificationStatusEnum == VerificationStatusEnumVerified
+		}
+	}
+	return false
+}
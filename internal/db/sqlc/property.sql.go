@@ -11,6 +11,76 @@ import (
 	"github.com/jackc/pgx/v5/pgtype"
 )
 
+const clusterPropertiesInBounds = `-- name: ClusterPropertiesInBounds :many
+SELECT COUNT(*) AS property_count,
+  AVG(p.latitude::float8)::float8 AS latitude,
+  AVG(p.longitude::float8)::float8 AS longitude,
+  MIN(p.rent_amount)::decimal AS min_rent,
+  MIN(p.id)::bigint AS property_id
+FROM properties p
+WHERE p.status = 'active' AND p.is_available = true
+  AND p.latitude BETWEEN $1::decimal AND $2::decimal
+  -- A viewport that crosses the antimeridian has a west edge greater than its east edge
+  AND CASE WHEN $3::decimal <= $4::decimal
+    THEN p.longitude BETWEEN $3 AND $4
+    ELSE p.longitude >= $3 OR p.longitude <= $4
+  END
+GROUP BY floor(p.latitude::float8 / $5::float8), floor(p.longitude::float8 / $5)
+ORDER BY property_count DESC
+LIMIT $6
+`
+
+type ClusterPropertiesInBoundsParams struct {
+	MinLatitude  pgtype.Numeric `json:"min_latitude"`
+	MaxLatitude  pgtype.Numeric `json:"max_latitude"`
+	MinLongitude pgtype.Numeric `json:"min_longitude"`
+	MaxLongitude pgtype.Numeric `json:"max_longitude"`
+	CellDegrees  float64        `json:"cell_degrees"`
+	Limit        int32          `json:"limit"`
+}
+
+type ClusterPropertiesInBoundsRow struct {
+	PropertyCount int64          `json:"property_count"`
+	Latitude      float64        `json:"latitude"`
+	Longitude     float64        `json:"longitude"`
+	MinRent       pgtype.Numeric `json:"min_rent"`
+	PropertyID    int64          `json:"property_id"`
+}
+
+// Group the available properties inside a viewport into grid cells of cell_degrees, for maps zoomed out too far to show pins
+func (q *Queries) ClusterPropertiesInBounds(ctx context.Context, arg ClusterPropertiesInBoundsParams) ([]ClusterPropertiesInBoundsRow, error) {
+	rows, err := q.db.Query(ctx, clusterPropertiesInBounds,
+		arg.MinLatitude,
+		arg.MaxLatitude,
+		arg.MinLongitude,
+		arg.MaxLongitude,
+		arg.CellDegrees,
+		arg.Limit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ClusterPropertiesInBoundsRow{}
+	for rows.Next() {
+		var i ClusterPropertiesInBoundsRow
+		if err := rows.Scan(
+			&i.PropertyCount,
+			&i.Latitude,
+			&i.Longitude,
+			&i.MinRent,
+			&i.PropertyID,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const countAvailableProperties = `-- name: CountAvailableProperties :one
 SELECT COUNT(*) FROM properties 
 WHERE status = 'active' AND is_available = true
@@ -42,10 +112,10 @@ INSERT INTO properties (
   landlord_id, title, description, property_type, address, city, state, country,
   latitude, longitude, bedrooms, bathrooms, rent_amount, rent_period,
   security_deposit, agency_fee, legal_fee, amenities, furnishing_status,
-  parking_spaces, total_area, expires_at
+  parking_spaces, total_area, expires_at, pet_friendly
 ) VALUES (
-  $1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19, $20, $21, $22
-) RETURNING id, landlord_id, title, description, property_type, address, city, state, country, latitude, longitude, bedrooms, bathrooms, rent_amount, rent_period, security_deposit, agency_fee, legal_fee, amenities, furnishing_status, parking_spaces, total_area, is_verified, verification_badge, verified_at, verified_by, is_available, last_confirmed_available, views_count, status, expires_at, created_at, updated_at
+  $1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19, $20, $21, $22, $23
+) RETURNING id, landlord_id, title, description, property_type, address, city, state, country, latitude, longitude, bedrooms, bathrooms, rent_amount, rent_period, security_deposit, agency_fee, legal_fee, amenities, furnishing_status, parking_spaces, total_area, is_verified, verification_badge, verified_at, verified_by, is_available, last_confirmed_available, views_count, status, expires_at, created_at, updated_at, pet_friendly
 `
 
 type CreatePropertyParams struct {
@@ -71,6 +141,7 @@ type CreatePropertyParams struct {
 	ParkingSpaces    pgtype.Int4              `json:"parking_spaces"`
 	TotalArea        pgtype.Numeric           `json:"total_area"`
 	ExpiresAt        pgtype.Timestamptz       `json:"expires_at"`
+	PetFriendly      bool                     `json:"pet_friendly"`
 }
 
 // Create a new property
@@ -98,6 +169,7 @@ func (q *Queries) CreateProperty(ctx context.Context, arg CreatePropertyParams)
 		arg.ParkingSpaces,
 		arg.TotalArea,
 		arg.ExpiresAt,
+		arg.PetFriendly,
 	)
 	var i Property
 	err := row.Scan(
@@ -134,10 +206,23 @@ func (q *Queries) CreateProperty(ctx context.Context, arg CreatePropertyParams)
 		&i.ExpiresAt,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.PetFriendly,
 	)
 	return i, err
 }
 
+const deactivateLandlordProperties = `-- name: DeactivateLandlordProperties :exec
+UPDATE properties 
+SET status = 'inactive', is_available = false, updated_at = NOW()
+WHERE landlord_id = $1
+`
+
+// Take down every listing of a landlord
+func (q *Queries) DeactivateLandlordProperties(ctx context.Context, landlordID int64) error {
+	_, err := q.db.Exec(ctx, deactivateLandlordProperties, landlordID)
+	return err
+}
+
 const deleteProperty = `-- name: DeleteProperty :exec
 UPDATE properties 
 SET status = 'inactive', updated_at = NOW()
@@ -151,7 +236,7 @@ func (q *Queries) DeleteProperty(ctx context.Context, id int64) error {
 }
 
 const getPropertyByID = `-- name: GetPropertyByID :one
-SELECT id, landlord_id, title, description, property_type, address, city, state, country, latitude, longitude, bedrooms, bathrooms, rent_amount, rent_period, security_deposit, agency_fee, legal_fee, amenities, furnishing_status, parking_spaces, total_area, is_verified, verification_badge, verified_at, verified_by, is_available, last_confirmed_available, views_count, status, expires_at, created_at, updated_at FROM properties 
+SELECT id, landlord_id, title, description, property_type, address, city, state, country, latitude, longitude, bedrooms, bathrooms, rent_amount, rent_period, security_deposit, agency_fee, legal_fee, amenities, furnishing_status, parking_spaces, total_area, is_verified, verification_badge, verified_at, verified_by, is_available, last_confirmed_available, views_count, status, expires_at, created_at, updated_at, pet_friendly FROM properties 
 WHERE id = $1 LIMIT 1
 `
 
@@ -193,12 +278,62 @@ func (q *Queries) GetPropertyByID(ctx context.Context, id int64) (Property, erro
 		&i.ExpiresAt,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.PetFriendly,
+	)
+	return i, err
+}
+
+const getPropertyForUpdate = `-- name: GetPropertyForUpdate :one
+SELECT id, landlord_id, title, description, property_type, address, city, state, country, latitude, longitude, bedrooms, bathrooms, rent_amount, rent_period, security_deposit, agency_fee, legal_fee, amenities, furnishing_status, parking_spaces, total_area, is_verified, verification_badge, verified_at, verified_by, is_available, last_confirmed_available, views_count, status, expires_at, created_at, updated_at, pet_friendly FROM properties 
+WHERE id = $1 LIMIT 1
+FOR NO KEY UPDATE
+`
+
+// Lock a property while its status changes
+func (q *Queries) GetPropertyForUpdate(ctx context.Context, id int64) (Property, error) {
+	row := q.db.QueryRow(ctx, getPropertyForUpdate, id)
+	var i Property
+	err := row.Scan(
+		&i.ID,
+		&i.LandlordID,
+		&i.Title,
+		&i.Description,
+		&i.PropertyType,
+		&i.Address,
+		&i.City,
+		&i.State,
+		&i.Country,
+		&i.Latitude,
+		&i.Longitude,
+		&i.Bedrooms,
+		&i.Bathrooms,
+		&i.RentAmount,
+		&i.RentPeriod,
+		&i.SecurityDeposit,
+		&i.AgencyFee,
+		&i.LegalFee,
+		&i.Amenities,
+		&i.FurnishingStatus,
+		&i.ParkingSpaces,
+		&i.TotalArea,
+		&i.IsVerified,
+		&i.VerificationBadge,
+		&i.VerifiedAt,
+		&i.VerifiedBy,
+		&i.IsAvailable,
+		&i.LastConfirmedAvailable,
+		&i.ViewsCount,
+		&i.Status,
+		&i.ExpiresAt,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.PetFriendly,
 	)
 	return i, err
 }
 
 const getPropertyWithLandlord = `-- name: GetPropertyWithLandlord :one
-SELECT p.id, p.landlord_id, p.title, p.description, p.property_type, p.address, p.city, p.state, p.country, p.latitude, p.longitude, p.bedrooms, p.bathrooms, p.rent_amount, p.rent_period, p.security_deposit, p.agency_fee, p.legal_fee, p.amenities, p.furnishing_status, p.parking_spaces, p.total_area, p.is_verified, p.verification_badge, p.verified_at, p.verified_by, p.is_available, p.last_confirmed_available, p.views_count, p.status, p.expires_at, p.created_at, p.updated_at, u.first_name, u.last_name, u.email, u.phone,
+SELECT p.id, p.landlord_id, p.title, p.description, p.property_type, p.address, p.city, p.state, p.country, p.latitude, p.longitude, p.bedrooms, p.bathrooms, p.rent_amount, p.rent_period, p.security_deposit, p.agency_fee, p.legal_fee, p.amenities, p.furnishing_status, p.parking_spaces, p.total_area, p.is_verified, p.verification_badge, p.verified_at, p.verified_by, p.is_available, p.last_confirmed_available, p.views_count, p.status, p.expires_at, p.created_at, p.updated_at, p.pet_friendly, u.first_name, u.last_name, u.email, u.phone,
        lp.business_name, lp.average_rating as landlord_rating
 FROM properties p
 JOIN users u ON p.landlord_id = u.id
@@ -240,6 +375,7 @@ type GetPropertyWithLandlordRow struct {
 	ExpiresAt              pgtype.Timestamptz       `json:"expires_at"`
 	CreatedAt              pgtype.Timestamptz       `json:"created_at"`
 	UpdatedAt              pgtype.Timestamptz       `json:"updated_at"`
+	PetFriendly            bool                     `json:"pet_friendly"`
 	FirstName              string                   `json:"first_name"`
 	LastName               string                   `json:"last_name"`
 	Email                  string                   `json:"email"`
@@ -286,6 +422,7 @@ func (q *Queries) GetPropertyWithLandlord(ctx context.Context, id int64) (GetPro
 		&i.ExpiresAt,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.PetFriendly,
 		&i.FirstName,
 		&i.LastName,
 		&i.Email,
@@ -309,7 +446,7 @@ func (q *Queries) IncrementPropertyViews(ctx context.Context, id int64) error {
 }
 
 const listFeaturedProperties = `-- name: ListFeaturedProperties :many
-SELECT p.id, p.landlord_id, p.title, p.description, p.property_type, p.address, p.city, p.state, p.country, p.latitude, p.longitude, p.bedrooms, p.bathrooms, p.rent_amount, p.rent_period, p.security_deposit, p.agency_fee, p.legal_fee, p.amenities, p.furnishing_status, p.parking_spaces, p.total_area, p.is_verified, p.verification_badge, p.verified_at, p.verified_by, p.is_available, p.last_confirmed_available, p.views_count, p.status, p.expires_at, p.created_at, p.updated_at, u.first_name, u.last_name
+SELECT p.id, p.landlord_id, p.title, p.description, p.property_type, p.address, p.city, p.state, p.country, p.latitude, p.longitude, p.bedrooms, p.bathrooms, p.rent_amount, p.rent_period, p.security_deposit, p.agency_fee, p.legal_fee, p.amenities, p.furnishing_status, p.parking_spaces, p.total_area, p.is_verified, p.verification_badge, p.verified_at, p.verified_by, p.is_available, p.last_confirmed_available, p.views_count, p.status, p.expires_at, p.created_at, p.updated_at, p.pet_friendly, u.first_name, u.last_name
 FROM properties p
 JOIN users u ON p.landlord_id = u.id
 WHERE p.status = 'active' AND p.is_available = true AND p.verification_badge = true
@@ -356,6 +493,7 @@ type ListFeaturedPropertiesRow struct {
 	ExpiresAt              pgtype.Timestamptz       `json:"expires_at"`
 	CreatedAt              pgtype.Timestamptz       `json:"created_at"`
 	UpdatedAt              pgtype.Timestamptz       `json:"updated_at"`
+	PetFriendly            bool                     `json:"pet_friendly"`
 	FirstName              string                   `json:"first_name"`
 	LastName               string                   `json:"last_name"`
 }
@@ -404,6 +542,7 @@ func (q *Queries) ListFeaturedProperties(ctx context.Context, arg ListFeaturedPr
 			&i.ExpiresAt,
 			&i.CreatedAt,
 			&i.UpdatedAt,
+			&i.PetFriendly,
 			&i.FirstName,
 			&i.LastName,
 		); err != nil {
@@ -418,7 +557,7 @@ func (q *Queries) ListFeaturedProperties(ctx context.Context, arg ListFeaturedPr
 }
 
 const listProperties = `-- name: ListProperties :many
-SELECT id, landlord_id, title, description, property_type, address, city, state, country, latitude, longitude, bedrooms, bathrooms, rent_amount, rent_period, security_deposit, agency_fee, legal_fee, amenities, furnishing_status, parking_spaces, total_area, is_verified, verification_badge, verified_at, verified_by, is_available, last_confirmed_available, views_count, status, expires_at, created_at, updated_at FROM properties 
+SELECT id, landlord_id, title, description, property_type, address, city, state, country, latitude, longitude, bedrooms, bathrooms, rent_amount, rent_period, security_deposit, agency_fee, legal_fee, amenities, furnishing_status, parking_spaces, total_area, is_verified, verification_badge, verified_at, verified_by, is_available, last_confirmed_available, views_count, status, expires_at, created_at, updated_at, pet_friendly FROM properties 
 WHERE status = 'active' AND is_available = true
 ORDER BY created_at DESC
 LIMIT $1 OFFSET $2
@@ -473,6 +612,7 @@ func (q *Queries) ListProperties(ctx context.Context, arg ListPropertiesParams)
 			&i.ExpiresAt,
 			&i.CreatedAt,
 			&i.UpdatedAt,
+			&i.PetFriendly,
 		); err != nil {
 			return nil, err
 		}
@@ -485,7 +625,7 @@ func (q *Queries) ListProperties(ctx context.Context, arg ListPropertiesParams)
 }
 
 const listPropertiesByLandlord = `-- name: ListPropertiesByLandlord :many
-SELECT id, landlord_id, title, description, property_type, address, city, state, country, latitude, longitude, bedrooms, bathrooms, rent_amount, rent_period, security_deposit, agency_fee, legal_fee, amenities, furnishing_status, parking_spaces, total_area, is_verified, verification_badge, verified_at, verified_by, is_available, last_confirmed_available, views_count, status, expires_at, created_at, updated_at FROM properties 
+SELECT id, landlord_id, title, description, property_type, address, city, state, country, latitude, longitude, bedrooms, bathrooms, rent_amount, rent_period, security_deposit, agency_fee, legal_fee, amenities, furnishing_status, parking_spaces, total_area, is_verified, verification_badge, verified_at, verified_by, is_available, last_confirmed_available, views_count, status, expires_at, created_at, updated_at, pet_friendly FROM properties 
 WHERE landlord_id = $1
 ORDER BY created_at DESC
 LIMIT $2 OFFSET $3
@@ -541,6 +681,7 @@ func (q *Queries) ListPropertiesByLandlord(ctx context.Context, arg ListProperti
 			&i.ExpiresAt,
 			&i.CreatedAt,
 			&i.UpdatedAt,
+			&i.PetFriendly,
 		); err != nil {
 			return nil, err
 		}
@@ -553,7 +694,7 @@ func (q *Queries) ListPropertiesByLandlord(ctx context.Context, arg ListProperti
 }
 
 const listPropertiesByLocation = `-- name: ListPropertiesByLocation :many
-SELECT id, landlord_id, title, description, property_type, address, city, state, country, latitude, longitude, bedrooms, bathrooms, rent_amount, rent_period, security_deposit, agency_fee, legal_fee, amenities, furnishing_status, parking_spaces, total_area, is_verified, verification_badge, verified_at, verified_by, is_available, last_confirmed_available, views_count, status, expires_at, created_at, updated_at FROM properties 
+SELECT id, landlord_id, title, description, property_type, address, city, state, country, latitude, longitude, bedrooms, bathrooms, rent_amount, rent_period, security_deposit, agency_fee, legal_fee, amenities, furnishing_status, parking_spaces, total_area, is_verified, verification_badge, verified_at, verified_by, is_available, last_confirmed_available, views_count, status, expires_at, created_at, updated_at, pet_friendly FROM properties 
 WHERE city = $1 AND state = $2 AND status = 'active' AND is_available = true
 ORDER BY rent_amount ASC
 LIMIT $3 OFFSET $4
@@ -615,6 +756,163 @@ func (q *Queries) ListPropertiesByLocation(ctx context.Context, arg ListProperti
 			&i.ExpiresAt,
 			&i.CreatedAt,
 			&i.UpdatedAt,
+			&i.PetFriendly,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listPropertyPinsInBounds = `-- name: ListPropertyPinsInBounds :many
+SELECT p.id, p.title, p.property_type, p.bedrooms, p.rent_amount,
+  p.latitude::float8 AS latitude, p.longitude::float8 AS longitude
+FROM properties p
+WHERE p.status = 'active' AND p.is_available = true
+  AND p.latitude BETWEEN $1::decimal AND $2::decimal
+  -- A viewport that crosses the antimeridian has a west edge greater than its east edge
+  AND CASE WHEN $3::decimal <= $4::decimal
+    THEN p.longitude BETWEEN $3 AND $4
+    ELSE p.longitude >= $3 OR p.longitude <= $4
+  END
+ORDER BY p.views_count DESC NULLS LAST, p.id
+LIMIT $5
+`
+
+type ListPropertyPinsInBoundsParams struct {
+	MinLatitude  pgtype.Numeric `json:"min_latitude"`
+	MaxLatitude  pgtype.Numeric `json:"max_latitude"`
+	MinLongitude pgtype.Numeric `json:"min_longitude"`
+	MaxLongitude pgtype.Numeric `json:"max_longitude"`
+	Limit        int32          `json:"limit"`
+}
+
+type ListPropertyPinsInBoundsRow struct {
+	ID           int64            `json:"id"`
+	Title        string           `json:"title"`
+	PropertyType PropertyTypeEnum `json:"property_type"`
+	Bedrooms     int32            `json:"bedrooms"`
+	RentAmount   pgtype.Numeric   `json:"rent_amount"`
+	Latitude     float64          `json:"latitude"`
+	Longitude    float64          `json:"longitude"`
+}
+
+// List the map pins of available properties inside a viewport, most viewed first
+func (q *Queries) ListPropertyPinsInBounds(ctx context.Context, arg ListPropertyPinsInBoundsParams) ([]ListPropertyPinsInBoundsRow, error) {
+	rows, err := q.db.Query(ctx, listPropertyPinsInBounds,
+		arg.MinLatitude,
+		arg.MaxLatitude,
+		arg.MinLongitude,
+		arg.MaxLongitude,
+		arg.Limit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ListPropertyPinsInBoundsRow{}
+	for rows.Next() {
+		var i ListPropertyPinsInBoundsRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.Title,
+			&i.PropertyType,
+			&i.Bedrooms,
+			&i.RentAmount,
+			&i.Latitude,
+			&i.Longitude,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listPropertySearchResults = `-- name: ListPropertySearchResults :many
+SELECT p.id, p.landlord_id, p.title, p.description, p.property_type, p.address, p.city, p.state, p.country, p.latitude, p.longitude, p.bedrooms, p.bathrooms, p.rent_amount, p.rent_period, p.security_deposit, p.agency_fee, p.legal_fee, p.amenities, p.furnishing_status, p.parking_spaces, p.total_area, p.is_verified, p.verification_badge, p.verified_at, p.verified_by, p.is_available, p.last_confirmed_available, p.views_count, p.status, p.expires_at, p.created_at, p.updated_at, p.pet_friendly, r.rank,
+  (CASE WHEN $1::text IS NULL THEN ''
+    ELSE ts_headline('english', COALESCE(p.description, p.title), property_search_query($1),
+      'StartSel=<mark>, StopSel=</mark>, MinWords=10, MaxWords=30, MaxFragments=2')
+  END)::text AS snippet
+FROM properties p
+CROSS JOIN LATERAL (
+  SELECT (CASE WHEN $1 IS NULL THEN 0
+    ELSE ts_rank_cd(property_search_vector(p.title, p.description, p.address, p.city, p.amenities), property_search_query($1))
+      + (SELECT COALESCE(MAX(word_similarity(term, p.city || ' ' || p.state || ' ' || p.address)), 0) FROM unnest($2::text[]) AS term)
+  END)::float8 AS rank
+) r
+WHERE p.id = ANY($3::bigint[])
+  AND p.status = 'active' AND p.is_available = true
+`
+
+type ListPropertySearchResultsParams struct {
+	Query pgtype.Text `json:"query"`
+	Terms []string    `json:"terms"`
+	Ids   []int64     `json:"ids"`
+}
+
+type ListPropertySearchResultsRow struct {
+	Property Property `json:"property"`
+	Rank     float64  `json:"rank"`
+	Snippet  string   `json:"snippet"`
+}
+
+// Get the SearchProperties rows of cached result ids, ranked and highlighted for the same query and terms.
+// Properties that were unpublished or rented since are left out; the caller puts the rows back in cached order.
+func (q *Queries) ListPropertySearchResults(ctx context.Context, arg ListPropertySearchResultsParams) ([]ListPropertySearchResultsRow, error) {
+	rows, err := q.db.Query(ctx, listPropertySearchResults, arg.Query, arg.Terms, arg.Ids)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ListPropertySearchResultsRow{}
+	for rows.Next() {
+		var i ListPropertySearchResultsRow
+		if err := rows.Scan(
+			&i.Property.ID,
+			&i.Property.LandlordID,
+			&i.Property.Title,
+			&i.Property.Description,
+			&i.Property.PropertyType,
+			&i.Property.Address,
+			&i.Property.City,
+			&i.Property.State,
+			&i.Property.Country,
+			&i.Property.Latitude,
+			&i.Property.Longitude,
+			&i.Property.Bedrooms,
+			&i.Property.Bathrooms,
+			&i.Property.RentAmount,
+			&i.Property.RentPeriod,
+			&i.Property.SecurityDeposit,
+			&i.Property.AgencyFee,
+			&i.Property.LegalFee,
+			&i.Property.Amenities,
+			&i.Property.FurnishingStatus,
+			&i.Property.ParkingSpaces,
+			&i.Property.TotalArea,
+			&i.Property.IsVerified,
+			&i.Property.VerificationBadge,
+			&i.Property.VerifiedAt,
+			&i.Property.VerifiedBy,
+			&i.Property.IsAvailable,
+			&i.Property.LastConfirmedAvailable,
+			&i.Property.ViewsCount,
+			&i.Property.Status,
+			&i.Property.ExpiresAt,
+			&i.Property.CreatedAt,
+			&i.Property.UpdatedAt,
+			&i.Property.PetFriendly,
+			&i.Rank,
+			&i.Snippet,
 		); err != nil {
 			return nil, err
 		}
@@ -627,7 +925,7 @@ func (q *Queries) ListPropertiesByLocation(ctx context.Context, arg ListProperti
 }
 
 const listRecentProperties = `-- name: ListRecentProperties :many
-SELECT p.id, p.landlord_id, p.title, p.description, p.property_type, p.address, p.city, p.state, p.country, p.latitude, p.longitude, p.bedrooms, p.bathrooms, p.rent_amount, p.rent_period, p.security_deposit, p.agency_fee, p.legal_fee, p.amenities, p.furnishing_status, p.parking_spaces, p.total_area, p.is_verified, p.verification_badge, p.verified_at, p.verified_by, p.is_available, p.last_confirmed_available, p.views_count, p.status, p.expires_at, p.created_at, p.updated_at, u.first_name, u.last_name
+SELECT p.id, p.landlord_id, p.title, p.description, p.property_type, p.address, p.city, p.state, p.country, p.latitude, p.longitude, p.bedrooms, p.bathrooms, p.rent_amount, p.rent_period, p.security_deposit, p.agency_fee, p.legal_fee, p.amenities, p.furnishing_status, p.parking_spaces, p.total_area, p.is_verified, p.verification_badge, p.verified_at, p.verified_by, p.is_available, p.last_confirmed_available, p.views_count, p.status, p.expires_at, p.created_at, p.updated_at, p.pet_friendly, u.first_name, u.last_name
 FROM properties p
 JOIN users u ON p.landlord_id = u.id
 WHERE p.status = 'active' AND p.is_available = true
@@ -674,6 +972,7 @@ type ListRecentPropertiesRow struct {
 	ExpiresAt              pgtype.Timestamptz       `json:"expires_at"`
 	CreatedAt              pgtype.Timestamptz       `json:"created_at"`
 	UpdatedAt              pgtype.Timestamptz       `json:"updated_at"`
+	PetFriendly            bool                     `json:"pet_friendly"`
 	FirstName              string                   `json:"first_name"`
 	LastName               string                   `json:"last_name"`
 }
@@ -722,6 +1021,7 @@ func (q *Queries) ListRecentProperties(ctx context.Context, arg ListRecentProper
 			&i.ExpiresAt,
 			&i.CreatedAt,
 			&i.UpdatedAt,
+			&i.PetFriendly,
 			&i.FirstName,
 			&i.LastName,
 		); err != nil {
@@ -736,86 +1036,112 @@ func (q *Queries) ListRecentProperties(ctx context.Context, arg ListRecentProper
 }
 
 const searchProperties = `-- name: SearchProperties :many
-SELECT p.id, p.landlord_id, p.title, p.description, p.property_type, p.address, p.city, p.state, p.country, p.latitude, p.longitude, p.bedrooms, p.bathrooms, p.rent_amount, p.rent_period, p.security_deposit, p.agency_fee, p.legal_fee, p.amenities, p.furnishing_status, p.parking_spaces, p.total_area, p.is_verified, p.verification_badge, p.verified_at, p.verified_by, p.is_available, p.last_confirmed_available, p.views_count, p.status, p.expires_at, p.created_at, p.updated_at, u.first_name, u.last_name
+SELECT p.id, p.landlord_id, p.title, p.description, p.property_type, p.address, p.city, p.state, p.country, p.latitude, p.longitude, p.bedrooms, p.bathrooms, p.rent_amount, p.rent_period, p.security_deposit, p.agency_fee, p.legal_fee, p.amenities, p.furnishing_status, p.parking_spaces, p.total_area, p.is_verified, p.verification_badge, p.verified_at, p.verified_by, p.is_available, p.last_confirmed_available, p.views_count, p.status, p.expires_at, p.created_at, p.updated_at, p.pet_friendly, r.rank,
+  (CASE WHEN $1::text IS NULL THEN ''
+    ELSE ts_headline('english', COALESCE(p.description, p.title), property_search_query($1),
+      'StartSel=<mark>, StopSel=</mark>, MinWords=10, MaxWords=30, MaxFragments=2')
+  END)::text AS snippet
 FROM properties p
-JOIN users u ON p.landlord_id = u.id
+CROSS JOIN LATERAL (
+  SELECT (CASE WHEN $1 IS NULL THEN 0
+    ELSE ts_rank_cd(property_search_vector(p.title, p.description, p.address, p.city, p.amenities), property_search_query($1))
+      + (SELECT COALESCE(MAX(word_similarity(term, p.city || ' ' || p.state || ' ' || p.address)), 0) FROM unnest($2::text[]) AS term)
+  END)::float8 AS rank
+) r
 WHERE p.status = 'active' AND p.is_available = true
-  AND ($1::text IS NULL OR p.city ILIKE '%' || $1 || '%')
-  AND ($2::text IS NULL OR p.state ILIKE '%' || $2 || '%')
-  AND ($3::property_type_enum IS NULL OR p.property_type = $3)
-  AND ($4::decimal IS NULL OR p.rent_amount >= $4)
-  AND ($5::decimal IS NULL OR p.rent_amount <= $5)
-  AND ($6::integer IS NULL OR p.bedrooms >= $6)
-  AND ($7::integer IS NULL OR p.bathrooms >= $7)
-  AND ($8::furnishing_status_enum IS NULL OR p.furnishing_status = $8)
-ORDER BY p.created_at DESC
-LIMIT $9 OFFSET $10
+  AND ($3::text IS NULL OR p.city ILIKE '%' || $3 || '%')
+  AND ($4::text IS NULL OR p.state ILIKE '%' || $4 || '%')
+  AND ($5::property_type_enum IS NULL OR p.property_type = $5)
+  AND ($6::decimal IS NULL OR p.rent_amount >= $6)
+  AND ($7::decimal IS NULL OR p.rent_amount <= $7)
+  AND ($8::integer IS NULL OR p.bedrooms >= $8)
+  AND ($9::integer IS NULL OR p.bathrooms >= $9)
+  AND ($10::furnishing_status_enum IS NULL OR p.furnishing_status = $10)
+  AND (cardinality($11::text[]) = 0
+    OR regexp_split_to_array(lower(COALESCE(p.amenities, '')), '\s*,\s*') @> $11::text[])
+  AND (NOT $12::boolean OR p.pet_friendly)
+  AND (NOT $13::boolean OR p.is_verified = true)
+  AND ($14::integer IS NULL OR COALESCE(p.parking_spaces, 0) >= $14)
+  AND ($15::decimal IS NULL OR COALESCE(p.agency_fee, 0) <= $15)
+  AND ($1::text IS NULL
+    OR property_search_vector(p.title, p.description, p.address, p.city, p.amenities) @@ property_search_query($1)
+    OR EXISTS (SELECT 1 FROM unnest($2::text[]) AS term WHERE term <% (p.city || ' ' || p.state || ' ' || p.address)))
+  AND ($16::bigint IS NULL
+    OR ($17::text = 'relevance' AND (r.rank, p.id) < ($18::float8, $16))
+    OR ($17 = 'price_asc' AND (p.rent_amount, p.id) > ($19::decimal, $16))
+    OR ($17 = 'price_desc' AND (p.rent_amount, p.id) < ($19, $16))
+    OR ($17 = 'newest' AND (p.created_at, p.id) < ($20::timestamptz, $16))
+    OR ($17 = 'popular' AND (COALESCE(p.views_count, 0), p.id) < ($21::integer, $16)))
+ORDER BY
+  CASE WHEN $17 = 'relevance' THEN r.rank END DESC,
+  CASE WHEN $17 = 'price_asc' THEN p.rent_amount END ASC,
+  CASE WHEN $17 = 'price_desc' THEN p.rent_amount END DESC,
+  CASE WHEN $17 = 'newest' THEN p.created_at END DESC,
+  CASE WHEN $17 = 'popular' THEN COALESCE(p.views_count, 0) END DESC,
+  CASE WHEN $17 = 'price_asc' THEN p.id END ASC,
+  p.id DESC
+LIMIT $22
 `
 
 type SearchPropertiesParams struct {
-	Column1 string               `json:"column_1"`
-	Column2 string               `json:"column_2"`
-	Column3 PropertyTypeEnum     `json:"column_3"`
-	Column4 pgtype.Numeric       `json:"column_4"`
-	Column5 pgtype.Numeric       `json:"column_5"`
-	Column6 int32                `json:"column_6"`
-	Column7 int32                `json:"column_7"`
-	Column8 FurnishingStatusEnum `json:"column_8"`
-	Limit   int32                `json:"limit"`
-	Offset  int32                `json:"offset"`
+	Query            pgtype.Text              `json:"query"`
+	Terms            []string                 `json:"terms"`
+	City             pgtype.Text              `json:"city"`
+	State            pgtype.Text              `json:"state"`
+	PropertyType     NullPropertyTypeEnum     `json:"property_type"`
+	MinRent          pgtype.Numeric           `json:"min_rent"`
+	MaxRent          pgtype.Numeric           `json:"max_rent"`
+	MinBedrooms      pgtype.Int4              `json:"min_bedrooms"`
+	MinBathrooms     pgtype.Int4              `json:"min_bathrooms"`
+	FurnishingStatus NullFurnishingStatusEnum `json:"furnishing_status"`
+	Amenities        []string                 `json:"amenities"`
+	PetFriendly      bool                     `json:"pet_friendly"`
+	VerifiedOnly     bool                     `json:"verified_only"`
+	MinParkingSpaces pgtype.Int4              `json:"min_parking_spaces"`
+	MaxAgencyFee     pgtype.Numeric           `json:"max_agency_fee"`
+	CursorID         pgtype.Int8              `json:"cursor_id"`
+	SortBy           string                   `json:"sort_by"`
+	CursorRank       pgtype.Float8            `json:"cursor_rank"`
+	CursorRent       pgtype.Numeric           `json:"cursor_rent"`
+	CursorCreatedAt  pgtype.Timestamptz       `json:"cursor_created_at"`
+	CursorViews      pgtype.Int4              `json:"cursor_views"`
+	Limit            int32                    `json:"limit"`
 }
 
 type SearchPropertiesRow struct {
-	ID                     int64                    `json:"id"`
-	LandlordID             int64                    `json:"landlord_id"`
-	Title                  string                   `json:"title"`
-	Description            pgtype.Text              `json:"description"`
-	PropertyType           PropertyTypeEnum         `json:"property_type"`
-	Address                string                   `json:"address"`
-	City                   string                   `json:"city"`
-	State                  string                   `json:"state"`
-	Country                pgtype.Text              `json:"country"`
-	Latitude               pgtype.Numeric           `json:"latitude"`
-	Longitude              pgtype.Numeric           `json:"longitude"`
-	Bedrooms               int32                    `json:"bedrooms"`
-	Bathrooms              int32                    `json:"bathrooms"`
-	RentAmount             pgtype.Numeric           `json:"rent_amount"`
-	RentPeriod             NullRentPeriodEnum       `json:"rent_period"`
-	SecurityDeposit        pgtype.Numeric           `json:"security_deposit"`
-	AgencyFee              pgtype.Numeric           `json:"agency_fee"`
-	LegalFee               pgtype.Numeric           `json:"legal_fee"`
-	Amenities              pgtype.Text              `json:"amenities"`
-	FurnishingStatus       NullFurnishingStatusEnum `json:"furnishing_status"`
-	ParkingSpaces          pgtype.Int4              `json:"parking_spaces"`
-	TotalArea              pgtype.Numeric           `json:"total_area"`
-	IsVerified             pgtype.Bool              `json:"is_verified"`
-	VerificationBadge      pgtype.Bool              `json:"verification_badge"`
-	VerifiedAt             pgtype.Timestamp         `json:"verified_at"`
-	VerifiedBy             pgtype.Int8              `json:"verified_by"`
-	IsAvailable            pgtype.Bool              `json:"is_available"`
-	LastConfirmedAvailable pgtype.Timestamp         `json:"last_confirmed_available"`
-	ViewsCount             pgtype.Int4              `json:"views_count"`
-	Status                 NullPropertyStatusEnum   `json:"status"`
-	ExpiresAt              pgtype.Timestamptz       `json:"expires_at"`
-	CreatedAt              pgtype.Timestamptz       `json:"created_at"`
-	UpdatedAt              pgtype.Timestamptz       `json:"updated_at"`
-	FirstName              string                   `json:"first_name"`
-	LastName               string                   `json:"last_name"`
+	Property Property `json:"property"`
+	Rank     float64  `json:"rank"`
+	Snippet  string   `json:"snippet"`
 }
 
-// Search properties with filters
+// Search available properties with filters and an optional free-text query, one keyset page at a time.
+// Amenities and terms are lowercase; amenities must all be listed, while terms are the words of query
+// that may be misspelled area names. The cursor is the sort key and id of the last row of the previous page,
+// and only the cursor column of sort_by is read. Snippets highlight the matches of query with <mark> tags.
 func (q *Queries) SearchProperties(ctx context.Context, arg SearchPropertiesParams) ([]SearchPropertiesRow, error) {
 	rows, err := q.db.Query(ctx, searchProperties,
-		arg.Column1,
-		arg.Column2,
-		arg.Column3,
-		arg.Column4,
-		arg.Column5,
-		arg.Column6,
-		arg.Column7,
-		arg.Column8,
+		arg.Query,
+		arg.Terms,
+		arg.City,
+		arg.State,
+		arg.PropertyType,
+		arg.MinRent,
+		arg.MaxRent,
+		arg.MinBedrooms,
+		arg.MinBathrooms,
+		arg.FurnishingStatus,
+		arg.Amenities,
+		arg.PetFriendly,
+		arg.VerifiedOnly,
+		arg.MinParkingSpaces,
+		arg.MaxAgencyFee,
+		arg.CursorID,
+		arg.SortBy,
+		arg.CursorRank,
+		arg.CursorRent,
+		arg.CursorCreatedAt,
+		arg.CursorViews,
 		arg.Limit,
-		arg.Offset,
 	)
 	if err != nil {
 		return nil, err
@@ -825,41 +1151,236 @@ func (q *Queries) SearchProperties(ctx context.Context, arg SearchPropertiesPara
 	for rows.Next() {
 		var i SearchPropertiesRow
 		if err := rows.Scan(
-			&i.ID,
-			&i.LandlordID,
-			&i.Title,
-			&i.Description,
-			&i.PropertyType,
-			&i.Address,
-			&i.City,
-			&i.State,
-			&i.Country,
-			&i.Latitude,
-			&i.Longitude,
-			&i.Bedrooms,
-			&i.Bathrooms,
-			&i.RentAmount,
-			&i.RentPeriod,
-			&i.SecurityDeposit,
-			&i.AgencyFee,
-			&i.LegalFee,
-			&i.Amenities,
-			&i.FurnishingStatus,
-			&i.ParkingSpaces,
-			&i.TotalArea,
-			&i.IsVerified,
-			&i.VerificationBadge,
-			&i.VerifiedAt,
-			&i.VerifiedBy,
-			&i.IsAvailable,
-			&i.LastConfirmedAvailable,
-			&i.ViewsCount,
-			&i.Status,
-			&i.ExpiresAt,
-			&i.CreatedAt,
-			&i.UpdatedAt,
-			&i.FirstName,
-			&i.LastName,
+			&i.Property.ID,
+			&i.Property.LandlordID,
+			&i.Property.Title,
+			&i.Property.Description,
+			&i.Property.PropertyType,
+			&i.Property.Address,
+			&i.Property.City,
+			&i.Property.State,
+			&i.Property.Country,
+			&i.Property.Latitude,
+			&i.Property.Longitude,
+			&i.Property.Bedrooms,
+			&i.Property.Bathrooms,
+			&i.Property.RentAmount,
+			&i.Property.RentPeriod,
+			&i.Property.SecurityDeposit,
+			&i.Property.AgencyFee,
+			&i.Property.LegalFee,
+			&i.Property.Amenities,
+			&i.Property.FurnishingStatus,
+			&i.Property.ParkingSpaces,
+			&i.Property.TotalArea,
+			&i.Property.IsVerified,
+			&i.Property.VerificationBadge,
+			&i.Property.VerifiedAt,
+			&i.Property.VerifiedBy,
+			&i.Property.IsAvailable,
+			&i.Property.LastConfirmedAvailable,
+			&i.Property.ViewsCount,
+			&i.Property.Status,
+			&i.Property.ExpiresAt,
+			&i.Property.CreatedAt,
+			&i.Property.UpdatedAt,
+			&i.Property.PetFriendly,
+			&i.Rank,
+			&i.Snippet,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const searchPropertiesNearby = `-- name: SearchPropertiesNearby :many
+SELECT p.id, p.landlord_id, p.title, p.description, p.property_type, p.address, p.city, p.state, p.country, p.latitude, p.longitude, p.bedrooms, p.bathrooms, p.rent_amount, p.rent_period, p.security_deposit, p.agency_fee, p.legal_fee, p.amenities, p.furnishing_status, p.parking_spaces, p.total_area, p.is_verified, p.verification_badge, p.verified_at, p.verified_by, p.is_available, p.last_confirmed_available, p.views_count, p.status, p.expires_at, p.created_at, p.updated_at, p.pet_friendly,
+  earth_distance(ll_to_earth(p.latitude::float8, p.longitude::float8), ll_to_earth($1::float8, $2::float8))::float8 AS distance_meters
+FROM properties p
+WHERE p.status = 'active' AND p.is_available = true
+  AND earth_box(ll_to_earth($1, $2), $3::float8) @> ll_to_earth(p.latitude::float8, p.longitude::float8)
+  AND earth_distance(ll_to_earth(p.latitude::float8, p.longitude::float8), ll_to_earth($1, $2)) <= $3
+  AND ($4::property_type_enum IS NULL OR p.property_type = $4)
+  AND ($5::decimal IS NULL OR p.rent_amount >= $5)
+  AND ($6::decimal IS NULL OR p.rent_amount <= $6)
+  AND ($7::integer IS NULL OR p.bedrooms >= $7)
+ORDER BY distance_meters, p.id
+LIMIT $8 OFFSET $9
+`
+
+type SearchPropertiesNearbyParams struct {
+	Latitude     float64              `json:"latitude"`
+	Longitude    float64              `json:"longitude"`
+	RadiusMeters float64              `json:"radius_meters"`
+	PropertyType NullPropertyTypeEnum `json:"property_type"`
+	MinRent      pgtype.Numeric       `json:"min_rent"`
+	MaxRent      pgtype.Numeric       `json:"max_rent"`
+	MinBedrooms  pgtype.Int4          `json:"min_bedrooms"`
+	Limit        int32                `json:"limit"`
+	Offset       int32                `json:"offset"`
+}
+
+type SearchPropertiesNearbyRow struct {
+	Property       Property `json:"property"`
+	DistanceMeters float64  `json:"distance_meters"`
+}
+
+// Search available properties within radius_meters of a point, nearest first
+func (q *Queries) SearchPropertiesNearby(ctx context.Context, arg SearchPropertiesNearbyParams) ([]SearchPropertiesNearbyRow, error) {
+	rows, err := q.db.Query(ctx, searchPropertiesNearby,
+		arg.Latitude,
+		arg.Longitude,
+		arg.RadiusMeters,
+		arg.PropertyType,
+		arg.MinRent,
+		arg.MaxRent,
+		arg.MinBedrooms,
+		arg.Limit,
+		arg.Offset,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []SearchPropertiesNearbyRow{}
+	for rows.Next() {
+		var i SearchPropertiesNearbyRow
+		if err := rows.Scan(
+			&i.Property.ID,
+			&i.Property.LandlordID,
+			&i.Property.Title,
+			&i.Property.Description,
+			&i.Property.PropertyType,
+			&i.Property.Address,
+			&i.Property.City,
+			&i.Property.State,
+			&i.Property.Country,
+			&i.Property.Latitude,
+			&i.Property.Longitude,
+			&i.Property.Bedrooms,
+			&i.Property.Bathrooms,
+			&i.Property.RentAmount,
+			&i.Property.RentPeriod,
+			&i.Property.SecurityDeposit,
+			&i.Property.AgencyFee,
+			&i.Property.LegalFee,
+			&i.Property.Amenities,
+			&i.Property.FurnishingStatus,
+			&i.Property.ParkingSpaces,
+			&i.Property.TotalArea,
+			&i.Property.IsVerified,
+			&i.Property.VerificationBadge,
+			&i.Property.VerifiedAt,
+			&i.Property.VerifiedBy,
+			&i.Property.IsAvailable,
+			&i.Property.LastConfirmedAvailable,
+			&i.Property.ViewsCount,
+			&i.Property.Status,
+			&i.Property.ExpiresAt,
+			&i.Property.CreatedAt,
+			&i.Property.UpdatedAt,
+			&i.Property.PetFriendly,
+			&i.DistanceMeters,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const searchPropertyFacets = `-- name: SearchPropertyFacets :many
+WITH matches AS (
+  SELECT p.property_type, p.bedrooms, p.city FROM properties p
+  WHERE p.status = 'active' AND p.is_available = true
+    AND ($1::text IS NULL OR p.city ILIKE '%' || $1 || '%')
+    AND ($2::text IS NULL OR p.state ILIKE '%' || $2 || '%')
+    AND ($3::property_type_enum IS NULL OR p.property_type = $3)
+    AND ($4::decimal IS NULL OR p.rent_amount >= $4)
+    AND ($5::decimal IS NULL OR p.rent_amount <= $5)
+    AND ($6::integer IS NULL OR p.bedrooms >= $6)
+    AND ($7::integer IS NULL OR p.bathrooms >= $7)
+    AND ($8::furnishing_status_enum IS NULL OR p.furnishing_status = $8)
+    AND (cardinality($9::text[]) = 0
+      OR regexp_split_to_array(lower(COALESCE(p.amenities, '')), '\s*,\s*') @> $9::text[])
+    AND (NOT $10::boolean OR p.pet_friendly)
+    AND (NOT $11::boolean OR p.is_verified = true)
+    AND ($12::integer IS NULL OR COALESCE(p.parking_spaces, 0) >= $12)
+    AND ($13::decimal IS NULL OR COALESCE(p.agency_fee, 0) <= $13)
+    AND ($14::text IS NULL
+      OR property_search_vector(p.title, p.description, p.address, p.city, p.amenities) @@ property_search_query($14)
+      OR EXISTS (SELECT 1 FROM unnest($15::text[]) AS term WHERE term <% (p.city || ' ' || p.state || ' ' || p.address)))
+)
+SELECT 'property_type'::text AS facet, property_type::text AS value, COUNT(*) AS count FROM matches GROUP BY property_type
+UNION ALL
+SELECT 'bedrooms', bedrooms::text, COUNT(*) FROM matches GROUP BY bedrooms
+UNION ALL
+SELECT 'city', city, COUNT(*) FROM matches GROUP BY city
+ORDER BY facet, count DESC, value
+`
+
+type SearchPropertyFacetsParams struct {
+	City             pgtype.Text              `json:"city"`
+	State            pgtype.Text              `json:"state"`
+	PropertyType     NullPropertyTypeEnum     `json:"property_type"`
+	MinRent          pgtype.Numeric           `json:"min_rent"`
+	MaxRent          pgtype.Numeric           `json:"max_rent"`
+	MinBedrooms      pgtype.Int4              `json:"min_bedrooms"`
+	MinBathrooms     pgtype.Int4              `json:"min_bathrooms"`
+	FurnishingStatus NullFurnishingStatusEnum `json:"furnishing_status"`
+	Amenities        []string                 `json:"amenities"`
+	PetFriendly      bool                     `json:"pet_friendly"`
+	VerifiedOnly     bool                     `json:"verified_only"`
+	MinParkingSpaces pgtype.Int4              `json:"min_parking_spaces"`
+	MaxAgencyFee     pgtype.Numeric           `json:"max_agency_fee"`
+	Query            pgtype.Text              `json:"query"`
+	Terms            []string                 `json:"terms"`
+}
+
+type SearchPropertyFacetsRow struct {
+	Facet string `json:"facet"`
+	Value string `json:"value"`
+	Count int64  `json:"count"`
+}
+
+// Count the properties matching the SearchProperties filters and query by type, bedroom count and city
+func (q *Queries) SearchPropertyFacets(ctx context.Context, arg SearchPropertyFacetsParams) ([]SearchPropertyFacetsRow, error) {
+	rows, err := q.db.Query(ctx, searchPropertyFacets,
+		arg.City,
+		arg.State,
+		arg.PropertyType,
+		arg.MinRent,
+		arg.MaxRent,
+		arg.MinBedrooms,
+		arg.MinBathrooms,
+		arg.FurnishingStatus,
+		arg.Amenities,
+		arg.PetFriendly,
+		arg.VerifiedOnly,
+		arg.MinParkingSpaces,
+		arg.MaxAgencyFee,
+		arg.Query,
+		arg.Terms,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []SearchPropertyFacetsRow{}
+	for rows.Next() {
+		var i SearchPropertyFacetsRow
+		if err := rows.Scan(
+			&i.Facet,
+			&i.Value,
+			&i.Count,
 		); err != nil {
 			return nil, err
 		}
@@ -877,9 +1398,9 @@ SET title = $2, description = $3, property_type = $4, address = $5,
     city = $6, state = $7, latitude = $8, longitude = $9, bedrooms = $10,
     bathrooms = $11, rent_amount = $12, rent_period = $13, security_deposit = $14,
     agency_fee = $15, legal_fee = $16, amenities = $17, furnishing_status = $18,
-    parking_spaces = $19, total_area = $20, updated_at = NOW()
+    parking_spaces = $19, total_area = $20, pet_friendly = $21, updated_at = NOW()
 WHERE id = $1 
-RETURNING id, landlord_id, title, description, property_type, address, city, state, country, latitude, longitude, bedrooms, bathrooms, rent_amount, rent_period, security_deposit, agency_fee, legal_fee, amenities, furnishing_status, parking_spaces, total_area, is_verified, verification_badge, verified_at, verified_by, is_available, last_confirmed_available, views_count, status, expires_at, created_at, updated_at
+RETURNING id, landlord_id, title, description, property_type, address, city, state, country, latitude, longitude, bedrooms, bathrooms, rent_amount, rent_period, security_deposit, agency_fee, legal_fee, amenities, furnishing_status, parking_spaces, total_area, is_verified, verification_badge, verified_at, verified_by, is_available, last_confirmed_available, views_count, status, expires_at, created_at, updated_at, pet_friendly
 `
 
 type UpdatePropertyParams struct {
@@ -903,6 +1424,7 @@ type UpdatePropertyParams struct {
 	FurnishingStatus NullFurnishingStatusEnum `json:"furnishing_status"`
 	ParkingSpaces    pgtype.Int4              `json:"parking_spaces"`
 	TotalArea        pgtype.Numeric           `json:"total_area"`
+	PetFriendly      bool                     `json:"pet_friendly"`
 }
 
 // Update property
@@ -928,6 +1450,7 @@ func (q *Queries) UpdateProperty(ctx context.Context, arg UpdatePropertyParams)
 		arg.FurnishingStatus,
 		arg.ParkingSpaces,
 		arg.TotalArea,
+		arg.PetFriendly,
 	)
 	var i Property
 	err := row.Scan(
@@ -964,6 +1487,7 @@ func (q *Queries) UpdateProperty(ctx context.Context, arg UpdatePropertyParams)
 		&i.ExpiresAt,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.PetFriendly,
 	)
 	return i, err
 }
@@ -973,7 +1497,7 @@ UPDATE properties
 SET is_available = $2, last_confirmed_available = CASE WHEN $2 = true THEN NOW() ELSE last_confirmed_available END,
     updated_at = NOW()
 WHERE id = $1 
-RETURNING id, landlord_id, title, description, property_type, address, city, state, country, latitude, longitude, bedrooms, bathrooms, rent_amount, rent_period, security_deposit, agency_fee, legal_fee, amenities, furnishing_status, parking_spaces, total_area, is_verified, verification_badge, verified_at, verified_by, is_available, last_confirmed_available, views_count, status, expires_at, created_at, updated_at
+RETURNING id, landlord_id, title, description, property_type, address, city, state, country, latitude, longitude, bedrooms, bathrooms, rent_amount, rent_period, security_deposit, agency_fee, legal_fee, amenities, furnishing_status, parking_spaces, total_area, is_verified, verification_badge, verified_at, verified_by, is_available, last_confirmed_available, views_count, status, expires_at, created_at, updated_at, pet_friendly
 `
 
 type UpdatePropertyAvailabilityParams struct {
@@ -1019,6 +1543,7 @@ func (q *Queries) UpdatePropertyAvailability(ctx context.Context, arg UpdateProp
 		&i.ExpiresAt,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.PetFriendly,
 	)
 	return i, err
 }
@@ -1027,7 +1552,7 @@ const updatePropertyStatus = `-- name: UpdatePropertyStatus :one
 UPDATE properties 
 SET status = $2, updated_at = NOW()
 WHERE id = $1 
-RETURNING id, landlord_id, title, description, property_type, address, city, state, country, latitude, longitude, bedrooms, bathrooms, rent_amount, rent_period, security_deposit, agency_fee, legal_fee, amenities, furnishing_status, parking_spaces, total_area, is_verified, verification_badge, verified_at, verified_by, is_available, last_confirmed_available, views_count, status, expires_at, created_at, updated_at
+RETURNING id, landlord_id, title, description, property_type, address, city, state, country, latitude, longitude, bedrooms, bathrooms, rent_amount, rent_period, security_deposit, agency_fee, legal_fee, amenities, furnishing_status, parking_spaces, total_area, is_verified, verification_badge, verified_at, verified_by, is_available, last_confirmed_available, views_count, status, expires_at, created_at, updated_at, pet_friendly
 `
 
 type UpdatePropertyStatusParams struct {
@@ -1073,6 +1598,7 @@ func (q *Queries) UpdatePropertyStatus(ctx context.Context, arg UpdatePropertySt
 		&i.ExpiresAt,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.PetFriendly,
 	)
 	return i, err
 }
@@ -1082,7 +1608,7 @@ UPDATE properties
 SET is_verified = true, verification_badge = $2, verified_at = NOW(), 
     verified_by = $3, updated_at = NOW()
 WHERE id = $1 
-RETURNING id, landlord_id, title, description, property_type, address, city, state, country, latitude, longitude, bedrooms, bathrooms, rent_amount, rent_period, security_deposit, agency_fee, legal_fee, amenities, furnishing_status, parking_spaces, total_area, is_verified, verification_badge, verified_at, verified_by, is_available, last_confirmed_available, views_count, status, expires_at, created_at, updated_at
+RETURNING id, landlord_id, title, description, property_type, address, city, state, country, latitude, longitude, bedrooms, bathrooms, rent_amount, rent_period, security_deposit, agency_fee, legal_fee, amenities, furnishing_status, parking_spaces, total_area, is_verified, verification_badge, verified_at, verified_by, is_available, last_confirmed_available, views_count, status, expires_at, created_at, updated_at, pet_friendly
 `
 
 type VerifyPropertyParams struct {
@@ -1129,6 +1655,7 @@ func (q *Queries) VerifyProperty(ctx context.Context, arg VerifyPropertyParams)
 		&i.ExpiresAt,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.PetFriendly,
 	)
 	return i, err
 }
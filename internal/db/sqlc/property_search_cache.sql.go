@@ -49,6 +49,20 @@ func (q *Queries) CleanupOldCache(ctx context.Context, createdAt pgtype.Timestam
 	return err
 }
 
+const cleanupStaleSearchCache = `-- name: CleanupStaleSearchCache :execrows
+DELETE FROM property_search_cache
+WHERE expires_at <= NOW() AND last_searched_at < $1
+`
+
+// Clean up expired cache entries that haven't been searched since a time; the others are kept for search statistics
+func (q *Queries) CleanupStaleSearchCache(ctx context.Context, lastSearchedAt time.Time) (int64, error) {
+	result, err := q.db.Exec(ctx, cleanupStaleSearchCache, lastSearchedAt)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
 const countActiveCacheEntries = `-- name: CountActiveCacheEntries :one
 SELECT COUNT(*) FROM property_search_cache 
 WHERE expires_at > NOW()
@@ -92,7 +106,7 @@ INSERT INTO property_search_cache (
   search_hash, search_params, property_ids, result_count, expires_at
 ) VALUES (
   $1, $2, $3, $4, $5
-) RETURNING id, search_hash, search_params, property_ids, result_count, expires_at, created_at
+) RETURNING id, search_hash, search_params, property_ids, result_count, expires_at, created_at, search_count, last_searched_at
 `
 
 type CreatePropertySearchCacheParams struct {
@@ -121,6 +135,8 @@ func (q *Queries) CreatePropertySearchCache(ctx context.Context, arg CreatePrope
 		&i.ResultCount,
 		&i.ExpiresAt,
 		&i.CreatedAt,
+		&i.SearchCount,
+		&i.LastSearchedAt,
 	)
 	return i, err
 }
@@ -147,11 +163,55 @@ func (q *Queries) DeletePropertySearchCache(ctx context.Context, id int64) error
 	return err
 }
 
+const expirePropertySearchCaches = `-- name: ExpirePropertySearchCaches :execrows
+UPDATE property_search_cache
+SET expires_at = NOW()
+WHERE id IN (
+  SELECT c.id
+  FROM property_search_cache c
+  JOIN properties p ON p.id = $1
+  CROSS JOIN LATERAL (SELECT COALESCE(c.search_params, '{}')::jsonb AS f) s
+  WHERE c.expires_at > NOW()
+    AND (',' || COALESCE(c.property_ids, '') || ',' LIKE '%,' || p.id || ',%'
+      OR (p.status = 'active' AND p.is_available = true
+        AND (s.f->>'city' IS NULL OR p.city ILIKE '%' || (s.f->>'city') || '%')
+        AND (s.f->>'state' IS NULL OR p.state ILIKE '%' || (s.f->>'state') || '%')
+        AND (s.f->>'property_type' IS NULL OR p.property_type::text = s.f->>'property_type')
+        AND (s.f->>'min_rent' IS NULL OR p.rent_amount >= (s.f->>'min_rent')::decimal)
+        AND (s.f->>'max_rent' IS NULL OR p.rent_amount <= (s.f->>'max_rent')::decimal)
+        AND (s.f->>'min_bedrooms' IS NULL OR p.bedrooms >= (s.f->>'min_bedrooms')::integer)
+        AND (s.f->>'min_bathrooms' IS NULL OR p.bathrooms >= (s.f->>'min_bathrooms')::integer)
+        AND (s.f->>'furnishing_status' IS NULL OR p.furnishing_status::text = s.f->>'furnishing_status')
+        AND (s.f->'amenities' IS NULL
+          OR regexp_split_to_array(lower(COALESCE(p.amenities, '')), '\s*,\s*')
+            @> ARRAY(SELECT jsonb_array_elements_text(s.f->'amenities')))
+        AND (NOT COALESCE((s.f->>'pet_friendly')::boolean, false) OR p.pet_friendly)
+        AND (NOT COALESCE((s.f->>'verified_only')::boolean, false) OR p.is_verified = true)
+        AND (s.f->>'min_parking_spaces' IS NULL OR COALESCE(p.parking_spaces, 0) >= (s.f->>'min_parking_spaces')::integer)
+        AND (s.f->>'max_agency_fee' IS NULL OR COALESCE(p.agency_fee, 0) <= (s.f->>'max_agency_fee')::decimal)
+        AND (s.f->>'query' IS NULL
+          OR property_search_vector(p.title, p.description, p.address, p.city, p.amenities) @@ property_search_query(s.f->>'query')
+          OR EXISTS (SELECT 1 FROM jsonb_array_elements_text(COALESCE(s.f->'terms', '[]')) AS term
+            WHERE term <% (p.city || ' ' || p.state || ' ' || p.address))))
+    )
+)
+`
+
+// Expire the cached searches a property is in, or would now be in with its current values.
+// search_params holds the filters that SearchProperties was called with, see the query of SearchProperties.
+func (q *Queries) ExpirePropertySearchCaches(ctx context.Context, propertyID int64) (int64, error) {
+	result, err := q.db.Exec(ctx, expirePropertySearchCaches, propertyID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
 const extendCacheExpiry = `-- name: ExtendCacheExpiry :one
 UPDATE property_search_cache 
 SET expires_at = $2
 WHERE search_hash = $1 
-RETURNING id, search_hash, search_params, property_ids, result_count, expires_at, created_at
+RETURNING id, search_hash, search_params, property_ids, result_count, expires_at, created_at, search_count, last_searched_at
 `
 
 type ExtendCacheExpiryParams struct {
@@ -171,12 +231,14 @@ func (q *Queries) ExtendCacheExpiry(ctx context.Context, arg ExtendCacheExpiryPa
 		&i.ResultCount,
 		&i.ExpiresAt,
 		&i.CreatedAt,
+		&i.SearchCount,
+		&i.LastSearchedAt,
 	)
 	return i, err
 }
 
 const getActiveCacheEntries = `-- name: GetActiveCacheEntries :many
-SELECT id, search_hash, search_params, property_ids, result_count, expires_at, created_at FROM property_search_cache 
+SELECT id, search_hash, search_params, property_ids, result_count, expires_at, created_at, search_count, last_searched_at FROM property_search_cache 
 WHERE expires_at > NOW()
 ORDER BY created_at DESC
 LIMIT $1 OFFSET $2
@@ -205,6 +267,8 @@ func (q *Queries) GetActiveCacheEntries(ctx context.Context, arg GetActiveCacheE
 			&i.ResultCount,
 			&i.ExpiresAt,
 			&i.CreatedAt,
+			&i.SearchCount,
+			&i.LastSearchedAt,
 		); err != nil {
 			return nil, err
 		}
@@ -217,7 +281,7 @@ func (q *Queries) GetActiveCacheEntries(ctx context.Context, arg GetActiveCacheE
 }
 
 const getCacheBySearchHash = `-- name: GetCacheBySearchHash :one
-SELECT id, search_hash, search_params, property_ids, result_count, expires_at, created_at FROM property_search_cache 
+SELECT id, search_hash, search_params, property_ids, result_count, expires_at, created_at, search_count, last_searched_at FROM property_search_cache 
 WHERE search_hash = $1 AND expires_at > NOW()
 LIMIT 1
 `
@@ -234,12 +298,14 @@ func (q *Queries) GetCacheBySearchHash(ctx context.Context, searchHash string) (
 		&i.ResultCount,
 		&i.ExpiresAt,
 		&i.CreatedAt,
+		&i.SearchCount,
+		&i.LastSearchedAt,
 	)
 	return i, err
 }
 
 const getCacheEntriesByResultCount = `-- name: GetCacheEntriesByResultCount :many
-SELECT id, search_hash, search_params, property_ids, result_count, expires_at, created_at FROM property_search_cache 
+SELECT id, search_hash, search_params, property_ids, result_count, expires_at, created_at, search_count, last_searched_at FROM property_search_cache 
 WHERE result_count >= $1 AND expires_at > NOW()
 ORDER BY result_count DESC, created_at DESC
 LIMIT $2 OFFSET $3
@@ -269,6 +335,8 @@ func (q *Queries) GetCacheEntriesByResultCount(ctx context.Context, arg GetCache
 			&i.ResultCount,
 			&i.ExpiresAt,
 			&i.CreatedAt,
+			&i.SearchCount,
+			&i.LastSearchedAt,
 		); err != nil {
 			return nil, err
 		}
@@ -315,19 +383,21 @@ SELECT
   COUNT(*) as total_entries,
   COUNT(CASE WHEN expires_at > NOW() THEN 1 END) as active_entries,
   COUNT(CASE WHEN expires_at <= NOW() THEN 1 END) as expired_entries,
-  AVG(result_count) as average_result_count,
-  MAX(result_count) as max_result_count,
-  MIN(result_count) as min_result_count
+  COALESCE(AVG(result_count), 0)::float8 as average_result_count,
+  COALESCE(MAX(result_count), 0)::integer as max_result_count,
+  COALESCE(MIN(result_count), 0)::integer as min_result_count,
+  COALESCE(SUM(search_count), 0)::bigint as total_searches
 FROM property_search_cache
 `
 
 type GetCacheStatisticsRow struct {
-	TotalEntries       int64       `json:"total_entries"`
-	ActiveEntries      int64       `json:"active_entries"`
-	ExpiredEntries     int64       `json:"expired_entries"`
-	AverageResultCount float64     `json:"average_result_count"`
-	MaxResultCount     interface{} `json:"max_result_count"`
-	MinResultCount     interface{} `json:"min_result_count"`
+	TotalEntries       int64   `json:"total_entries"`
+	ActiveEntries      int64   `json:"active_entries"`
+	ExpiredEntries     int64   `json:"expired_entries"`
+	AverageResultCount float64 `json:"average_result_count"`
+	MaxResultCount     int32   `json:"max_result_count"`
+	MinResultCount     int32   `json:"min_result_count"`
+	TotalSearches      int64   `json:"total_searches"`
 }
 
 // Get cache statistics
@@ -341,12 +411,13 @@ func (q *Queries) GetCacheStatistics(ctx context.Context) (GetCacheStatisticsRow
 		&i.AverageResultCount,
 		&i.MaxResultCount,
 		&i.MinResultCount,
+		&i.TotalSearches,
 	)
 	return i, err
 }
 
 const getExpiredCacheEntries = `-- name: GetExpiredCacheEntries :many
-SELECT id, search_hash, search_params, property_ids, result_count, expires_at, created_at FROM property_search_cache 
+SELECT id, search_hash, search_params, property_ids, result_count, expires_at, created_at, search_count, last_searched_at FROM property_search_cache 
 WHERE expires_at <= NOW()
 ORDER BY expires_at ASC
 LIMIT $1 OFFSET $2
@@ -375,6 +446,8 @@ func (q *Queries) GetExpiredCacheEntries(ctx context.Context, arg GetExpiredCach
 			&i.ResultCount,
 			&i.ExpiresAt,
 			&i.CreatedAt,
+			&i.SearchCount,
+			&i.LastSearchedAt,
 		); err != nil {
 			return nil, err
 		}
@@ -439,30 +512,33 @@ func (q *Queries) GetMostCachedSearches(ctx context.Context, arg GetMostCachedSe
 }
 
 const getPopularSearchCaches = `-- name: GetPopularSearchCaches :many
-SELECT search_hash, search_params, result_count, COUNT(*) as access_count
-FROM property_search_cache 
-WHERE created_at >= $1
-GROUP BY search_hash, search_params, result_count
-ORDER BY access_count DESC, result_count DESC
+SELECT search_params,
+  SUM(search_count)::bigint AS search_count,
+  COUNT(*) AS cached_pages,
+  MAX(last_searched_at)::timestamptz AS last_searched_at
+FROM property_search_cache
+WHERE last_searched_at >= $1
+GROUP BY search_params
+ORDER BY search_count DESC, last_searched_at DESC
 LIMIT $2 OFFSET $3
 `
 
 type GetPopularSearchCachesParams struct {
-	CreatedAt pgtype.Timestamptz `json:"created_at"`
-	Limit     int32              `json:"limit"`
-	Offset    int32              `json:"offset"`
+	LastSearchedAt time.Time `json:"last_searched_at"`
+	Limit          int32     `json:"limit"`
+	Offset         int32     `json:"offset"`
 }
 
 type GetPopularSearchCachesRow struct {
-	SearchHash   string      `json:"search_hash"`
-	SearchParams pgtype.Text `json:"search_params"`
-	ResultCount  int32       `json:"result_count"`
-	AccessCount  int64       `json:"access_count"`
+	SearchParams   pgtype.Text `json:"search_params"`
+	SearchCount    int64       `json:"search_count"`
+	CachedPages    int64       `json:"cached_pages"`
+	LastSearchedAt time.Time   `json:"last_searched_at"`
 }
 
-// Get popular search caches
+// Get the most run searches since a time, adding up the cached pages of each
 func (q *Queries) GetPopularSearchCaches(ctx context.Context, arg GetPopularSearchCachesParams) ([]GetPopularSearchCachesRow, error) {
-	rows, err := q.db.Query(ctx, getPopularSearchCaches, arg.CreatedAt, arg.Limit, arg.Offset)
+	rows, err := q.db.Query(ctx, getPopularSearchCaches, arg.LastSearchedAt, arg.Limit, arg.Offset)
 	if err != nil {
 		return nil, err
 	}
@@ -471,10 +547,10 @@ func (q *Queries) GetPopularSearchCaches(ctx context.Context, arg GetPopularSear
 	for rows.Next() {
 		var i GetPopularSearchCachesRow
 		if err := rows.Scan(
-			&i.SearchHash,
 			&i.SearchParams,
-			&i.ResultCount,
-			&i.AccessCount,
+			&i.SearchCount,
+			&i.CachedPages,
+			&i.LastSearchedAt,
 		); err != nil {
 			return nil, err
 		}
@@ -487,7 +563,7 @@ func (q *Queries) GetPopularSearchCaches(ctx context.Context, arg GetPopularSear
 }
 
 const getPropertySearchCacheByID = `-- name: GetPropertySearchCacheByID :one
-SELECT id, search_hash, search_params, property_ids, result_count, expires_at, created_at FROM property_search_cache 
+SELECT id, search_hash, search_params, property_ids, result_count, expires_at, created_at, search_count, last_searched_at FROM property_search_cache 
 WHERE id = $1 LIMIT 1
 `
 
@@ -503,12 +579,32 @@ func (q *Queries) GetPropertySearchCacheByID(ctx context.Context, id int64) (Pro
 		&i.ResultCount,
 		&i.ExpiresAt,
 		&i.CreatedAt,
+		&i.SearchCount,
+		&i.LastSearchedAt,
 	)
 	return i, err
 }
 
+const recordSearchCacheHits = `-- name: RecordSearchCacheHits :exec
+UPDATE property_search_cache AS c
+SET search_count = c.search_count + h.hits, last_searched_at = NOW()
+FROM unnest($1::bigint[], $2::int[]) AS h(id, hits)
+WHERE c.id = h.id
+`
+
+type RecordSearchCacheHitsParams struct {
+	Ids  []int64 `json:"ids"`
+	Hits []int32 `json:"hits"`
+}
+
+// Count searches served from cache, batched as the number of hits of each entry
+func (q *Queries) RecordSearchCacheHits(ctx context.Context, arg RecordSearchCacheHitsParams) error {
+	_, err := q.db.Exec(ctx, recordSearchCacheHits, arg.Ids, arg.Hits)
+	return err
+}
+
 const searchCacheEntries = `-- name: SearchCacheEntries :many
-SELECT id, search_hash, search_params, property_ids, result_count, expires_at, created_at FROM property_search_cache 
+SELECT id, search_hash, search_params, property_ids, result_count, expires_at, created_at, search_count, last_searched_at FROM property_search_cache 
 WHERE search_params ILIKE '%' || $1 || '%'
 ORDER BY created_at DESC
 LIMIT $2 OFFSET $3
@@ -538,6 +634,8 @@ func (q *Queries) SearchCacheEntries(ctx context.Context, arg SearchCacheEntries
 			&i.ResultCount,
 			&i.ExpiresAt,
 			&i.CreatedAt,
+			&i.SearchCount,
+			&i.LastSearchedAt,
 		); err != nil {
 			return nil, err
 		}
@@ -553,7 +651,7 @@ const updateSearchCache = `-- name: UpdateSearchCache :one
 UPDATE property_search_cache 
 SET property_ids = $2, result_count = $3, expires_at = $4
 WHERE search_hash = $1 
-RETURNING id, search_hash, search_params, property_ids, result_count, expires_at, created_at
+RETURNING id, search_hash, search_params, property_ids, result_count, expires_at, created_at, search_count, last_searched_at
 `
 
 type UpdateSearchCacheParams struct {
@@ -580,6 +678,56 @@ func (q *Queries) UpdateSearchCache(ctx context.Context, arg UpdateSearchCachePa
 		&i.ResultCount,
 		&i.ExpiresAt,
 		&i.CreatedAt,
+		&i.SearchCount,
+		&i.LastSearchedAt,
+	)
+	return i, err
+}
+
+const upsertPropertySearchCache = `-- name: UpsertPropertySearchCache :one
+INSERT INTO property_search_cache (
+  search_hash, search_params, property_ids, result_count, expires_at
+) VALUES (
+  $1, $2, $3, $4, $5
+)
+ON CONFLICT (search_hash) DO UPDATE
+SET search_params = EXCLUDED.search_params,
+  property_ids = EXCLUDED.property_ids,
+  result_count = EXCLUDED.result_count,
+  expires_at = EXCLUDED.expires_at,
+  search_count = property_search_cache.search_count + 1,
+  last_searched_at = NOW()
+RETURNING id, search_hash, search_params, property_ids, result_count, expires_at, created_at, search_count, last_searched_at
+`
+
+type UpsertPropertySearchCacheParams struct {
+	SearchHash   string      `json:"search_hash"`
+	SearchParams pgtype.Text `json:"search_params"`
+	PropertyIds  pgtype.Text `json:"property_ids"`
+	ResultCount  int32       `json:"result_count"`
+	ExpiresAt    time.Time   `json:"expires_at"`
+}
+
+// Cache one page of a search, or refresh it when the search ran again after the entry expired or was invalidated
+func (q *Queries) UpsertPropertySearchCache(ctx context.Context, arg UpsertPropertySearchCacheParams) (PropertySearchCache, error) {
+	row := q.db.QueryRow(ctx, upsertPropertySearchCache,
+		arg.SearchHash,
+		arg.SearchParams,
+		arg.PropertyIds,
+		arg.ResultCount,
+		arg.ExpiresAt,
+	)
+	var i PropertySearchCache
+	err := row.Scan(
+		&i.ID,
+		&i.SearchHash,
+		&i.SearchParams,
+		&i.PropertyIds,
+		&i.ResultCount,
+		&i.ExpiresAt,
+		&i.CreatedAt,
+		&i.SearchCount,
+		&i.LastSearchedAt,
 	)
 	return i, err
 }
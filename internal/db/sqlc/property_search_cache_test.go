@@ -0,0 +1,97 @@
+package db
+
+import (
+	"context"
+	"math/big"
+	"os"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/r-scheele/sqr/internal/util"
+	"github.com/stretchr/testify/require"
+)
+
+// newTestQueries connects to the migrated database in DB_SOURCE. The test is skipped without one.
+func newTestQueries(t *testing.T) *Queries {
+	dbSource := os.Getenv("DB_SOURCE")
+	if testing.Short() || dbSource == "" {
+		t.Skip("needs a migrated database in DB_SOURCE")
+	}
+
+	connPool, err := pgxpool.New(context.Background(), dbSource)
+	require.NoError(t, err)
+	t.Cleanup(connPool.Close)
+
+	return New(connPool)
+}
+
+func createRandomListedProperty(t *testing.T, q *Queries, city string) Property {
+	landlord, err := q.CreateUser(context.Background(), CreateUserParams{
+		Email:        util.RandomEmail(),
+		Phone:        util.RandomPhone(),
+		PasswordHash: util.RandomString(32),
+		FirstName:    util.RandomString(6),
+		LastName:     util.RandomString(6),
+		UserType:     UserTypeEnumLandlord,
+	})
+	require.NoError(t, err)
+
+	property, err := q.CreateProperty(context.Background(), CreatePropertyParams{
+		LandlordID:   landlord.ID,
+		Title:        "Two bedroom flat",
+		PropertyType: PropertyTypeEnumApartment,
+		Address:      "12 Admiralty Way",
+		City:         city,
+		State:        "Lagos",
+		Bedrooms:     2,
+		Bathrooms:    2,
+		RentAmount:   pgtype.Numeric{Int: big.NewInt(util.RandomInt(1_000_000, 5_000_000)), Valid: true},
+	})
+	require.NoError(t, err)
+
+	property, err = q.UpdatePropertyStatus(context.Background(), UpdatePropertyStatusParams{
+		ID:     property.ID,
+		Status: NullPropertyStatusEnum{PropertyStatusEnum: PropertyStatusEnumActive, Valid: true},
+	})
+	require.NoError(t, err)
+	return property
+}
+
+func createSearchCacheEntry(t *testing.T, q *Queries, params string, propertyIDs string) PropertySearchCache {
+	entry, err := q.UpsertPropertySearchCache(context.Background(), UpsertPropertySearchCacheParams{
+		SearchHash:   util.RandomString(64),
+		SearchParams: pgtype.Text{String: params, Valid: true},
+		PropertyIds:  pgtype.Text{String: propertyIDs, Valid: true},
+		ExpiresAt:    time.Now().Add(time.Hour),
+	})
+	require.NoError(t, err)
+	return entry
+}
+
+func TestExpirePropertySearchCaches(t *testing.T) {
+	q := newTestQueries(t)
+	city := "city" + util.RandomString(8)
+	property := createRandomListedProperty(t, q, city)
+
+	// Cached with the property in its results
+	containing := createSearchCacheEntry(t, q, `{"sort_by":"newest"}`, "1,"+strconv.FormatInt(property.ID, 10))
+	// The property now matches its filters
+	matching := createSearchCacheEntry(t, q, `{"city":"`+city+`","sort_by":"newest"}`, "")
+	// Neither
+	unrelated := createSearchCacheEntry(t, q, `{"city":"other`+city+`","sort_by":"newest"}`, "")
+
+	expired, err := q.ExpirePropertySearchCaches(context.Background(), property.ID)
+	require.NoError(t, err)
+	require.GreaterOrEqual(t, expired, int64(2))
+
+	for _, entry := range []PropertySearchCache{containing, matching} {
+		_, err = q.GetCacheBySearchHash(context.Background(), entry.SearchHash)
+		require.ErrorIs(t, err, ErrRecordNotFound)
+	}
+
+	_, err = q.GetCacheBySearchHash(context.Background(), unrelated.SearchHash)
+	require.NoError(t, err)
+}
@@ -0,0 +1,58 @@
+package db
+
+import (
+	"sync"
+	"time"
+)
+
+const (
+	// searchHitFlushInterval is how often the searches served from property_search_cache are added to
+	// its search_count, so that a cache hit doesn't cost a write
+	searchHitFlushInterval = time.Minute
+	// searchHitFlushTimeout bounds the batched update, which runs outside of any request
+	searchHitFlushTimeout = 10 * time.Second
+)
+
+// searchHitCounter counts cache hits in memory between flushes. Hits not flushed when the
+// process stops are lost, which only makes the popular searches slightly less accurate.
+type searchHitCounter struct {
+	mu        sync.Mutex
+	hits      map[int64]int32
+	flushedAt time.Time
+}
+
+func newSearchHitCounter(now time.Time) *searchHitCounter {
+	return &searchHitCounter{
+		hits:      make(map[int64]int32),
+		flushedAt: now,
+	}
+}
+
+// record counts a hit of the cache entry. Once searchHitFlushInterval has passed since the last flush,
+// it returns the hits counted so far and starts over; the caller must write them.
+func (counter *searchHitCounter) record(id int64, now time.Time) map[int64]int32 {
+	counter.mu.Lock()
+	defer counter.mu.Unlock()
+
+	counter.hits[id]++
+	if now.Sub(counter.flushedAt) < searchHitFlushInterval {
+		return nil
+	}
+
+	hits := counter.hits
+	counter.hits = make(map[int64]int32)
+	counter.flushedAt = now
+	return hits
+}
+
+func newRecordSearchCacheHitsParams(hits map[int64]int32) RecordSearchCacheHitsParams {
+	arg := RecordSearchCacheHitsParams{
+		Ids:  make([]int64, 0, len(hits)),
+		Hits: make([]int32, 0, len(hits)),
+	}
+	for id, count := range hits {
+		arg.Ids = append(arg.Ids, id)
+		arg.Hits = append(arg.Hits, count)
+	}
+	return arg
+}
@@ -0,0 +1,149 @@
+package db
+
+import (
+	"encoding/json"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/r-scheele/sqr/internal/cache"
+)
+
+// propertySearchFilters is what a SearchProperties call searches for, normalized so that searches which can
+// only return the same properties in the same order are stored as the same search_params.
+// ExpirePropertySearchCaches reads the fields by their json names.
+type propertySearchFilters struct {
+	Query            string   `json:"query,omitempty"`
+	Terms            []string `json:"terms,omitempty"`
+	City             string   `json:"city,omitempty"`
+	State            string   `json:"state,omitempty"`
+	PropertyType     string   `json:"property_type,omitempty"`
+	MinRent          *float64 `json:"min_rent,omitempty"`
+	MaxRent          *float64 `json:"max_rent,omitempty"`
+	MinBedrooms      *int32   `json:"min_bedrooms,omitempty"`
+	MinBathrooms     *int32   `json:"min_bathrooms,omitempty"`
+	FurnishingStatus string   `json:"furnishing_status,omitempty"`
+	Amenities        []string `json:"amenities,omitempty"`
+	PetFriendly      bool     `json:"pet_friendly,omitempty"`
+	VerifiedOnly     bool     `json:"verified_only,omitempty"`
+	MinParkingSpaces *int32   `json:"min_parking_spaces,omitempty"`
+	MaxAgencyFee     *float64 `json:"max_agency_fee,omitempty"`
+	SortBy           string   `json:"sort_by"`
+}
+
+// propertySearchPage is which page of a search a SearchProperties call reads. Only first pages are cached,
+// so a page is told apart by its size alone.
+type propertySearchPage struct {
+	Limit int32 `json:"limit"`
+}
+
+// propertySearchKey identifies the first page of one search in property_search_cache. Params is shared by
+// the pages of every size, Hash is the SHA-256 of the params and the page.
+type propertySearchKey struct {
+	Params string
+	Hash   string
+}
+
+// isFirstSearchPage reports whether the call reads the first page of its search. Deeper pages are read by
+// few users, so caching them would cost a write for nearly every call without saving many searches.
+func isFirstSearchPage(arg SearchPropertiesParams) bool {
+	return !arg.CursorID.Valid && !arg.CursorRank.Valid && !arg.CursorRent.Valid &&
+		!arg.CursorCreatedAt.Valid && !arg.CursorViews.Valid
+}
+
+func newPropertySearchKey(arg SearchPropertiesParams) (propertySearchKey, error) {
+	filters := propertySearchFilters{
+		Query:            strings.ToLower(strings.Join(strings.Fields(arg.Query.String), " ")),
+		Terms:            normalizeSearchWords(arg.Terms),
+		City:             strings.ToLower(strings.TrimSpace(arg.City.String)),
+		State:            strings.ToLower(strings.TrimSpace(arg.State.String)),
+		MinRent:          numericPtr(arg.MinRent),
+		MaxRent:          numericPtr(arg.MaxRent),
+		MinBedrooms:      int4Ptr(arg.MinBedrooms),
+		MinBathrooms:     int4Ptr(arg.MinBathrooms),
+		Amenities:        normalizeSearchWords(arg.Amenities),
+		PetFriendly:      arg.PetFriendly,
+		VerifiedOnly:     arg.VerifiedOnly,
+		MinParkingSpaces: int4Ptr(arg.MinParkingSpaces),
+		MaxAgencyFee:     numericPtr(arg.MaxAgencyFee),
+		SortBy:           arg.SortBy,
+	}
+	if arg.PropertyType.Valid {
+		filters.PropertyType = string(arg.PropertyType.PropertyTypeEnum)
+	}
+	if arg.FurnishingStatus.Valid {
+		filters.FurnishingStatus = string(arg.FurnishingStatus.FurnishingStatusEnum)
+	}
+
+	page := propertySearchPage{
+		Limit: arg.Limit,
+	}
+
+	params, err := json.Marshal(filters)
+	if err != nil {
+		return propertySearchKey{}, err
+	}
+	pageParams, err := json.Marshal(page)
+	if err != nil {
+		return propertySearchKey{}, err
+	}
+
+	return propertySearchKey{
+		Params: string(params),
+		Hash:   cache.SearchHash(append(append(params, '\n'), pageParams...)),
+	}, nil
+}
+
+// normalizeSearchWords lowercases, sorts and dedupes words whose order SearchProperties ignores
+func normalizeSearchWords(words []string) []string {
+	normalized := make([]string, 0, len(words))
+	for _, word := range words {
+		normalized = append(normalized, strings.ToLower(strings.TrimSpace(word)))
+	}
+	sort.Strings(normalized)
+
+	unique := normalized[:0]
+	for i, word := range normalized {
+		if i == 0 || word != normalized[i-1] {
+			unique = append(unique, word)
+		}
+	}
+	return unique
+}
+
+func numericPtr(n pgtype.Numeric) *float64 {
+	if !n.Valid {
+		return nil
+	}
+	value, err := n.Float64Value()
+	if err != nil || !value.Valid {
+		return nil
+	}
+	return &value.Float64
+}
+
+func int4Ptr(n pgtype.Int4) *int32 {
+	if !n.Valid {
+		return nil
+	}
+	return &n.Int32
+}
+
+// parsePropertySearchIDs reads the comma separated property_ids of a cache entry
+func parsePropertySearchIDs(propertyIDs string) ([]int64, error) {
+	if propertyIDs == "" {
+		return nil, nil
+	}
+
+	fields := strings.Split(propertyIDs, ",")
+	ids := make([]int64, len(fields))
+	for i, field := range fields {
+		id, err := strconv.ParseInt(field, 10, 64)
+		if err != nil {
+			return nil, err
+		}
+		ids[i] = id
+	}
+	return ids, nil
+}
@@ -0,0 +1,68 @@
+package db
+
+import (
+	"testing"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewPropertySearchKeyNormalizes(t *testing.T) {
+	arg := SearchPropertiesParams{
+		Query:     pgtype.Text{String: "Quiet  Flat", Valid: true},
+		Terms:     []string{"lekki", "Ajah"},
+		City:      pgtype.Text{String: " Lagos ", Valid: true},
+		Amenities: []string{"Pool", "gym", "pool"},
+		SortBy:    "newest",
+		Limit:     10,
+	}
+	same := SearchPropertiesParams{
+		Query:     pgtype.Text{String: "quiet flat", Valid: true},
+		Terms:     []string{"ajah", "LEKKI"},
+		City:      pgtype.Text{String: "lagos", Valid: true},
+		Amenities: []string{"gym", "pool"},
+		SortBy:    "newest",
+		Limit:     10,
+	}
+
+	key, err := newPropertySearchKey(arg)
+	require.NoError(t, err)
+	sameKey, err := newPropertySearchKey(same)
+	require.NoError(t, err)
+	require.Equal(t, key, sameKey)
+	require.JSONEq(t, `{"query":"quiet flat","terms":["ajah","lekki"],"city":"lagos","amenities":["gym","pool"],"sort_by":"newest"}`, key.Params)
+
+	otherSort := same
+	otherSort.SortBy = "price_low"
+	otherSortKey, err := newPropertySearchKey(otherSort)
+	require.NoError(t, err)
+	require.NotEqual(t, key.Params, otherSortKey.Params)
+	require.NotEqual(t, key.Hash, otherSortKey.Hash)
+
+	// Pages of every size share the params of the search, so the stats group them
+	otherLimit := same
+	otherLimit.Limit = 20
+	otherLimitKey, err := newPropertySearchKey(otherLimit)
+	require.NoError(t, err)
+	require.Equal(t, key.Params, otherLimitKey.Params)
+	require.NotEqual(t, key.Hash, otherLimitKey.Hash)
+}
+
+func TestIsFirstSearchPage(t *testing.T) {
+	require.True(t, isFirstSearchPage(SearchPropertiesParams{Limit: 10}))
+	require.False(t, isFirstSearchPage(SearchPropertiesParams{CursorID: pgtype.Int8{Int64: 42, Valid: true}, Limit: 10}))
+	require.False(t, isFirstSearchPage(SearchPropertiesParams{CursorViews: pgtype.Int4{Int32: 3, Valid: true}, Limit: 10}))
+}
+
+func TestParsePropertySearchIDs(t *testing.T) {
+	ids, err := parsePropertySearchIDs("3,1,2")
+	require.NoError(t, err)
+	require.Equal(t, []int64{3, 1, 2}, ids)
+
+	ids, err = parsePropertySearchIDs("")
+	require.NoError(t, err)
+	require.Empty(t, ids)
+
+	_, err = parsePropertySearchIDs("1,x")
+	require.Error(t, err)
+}
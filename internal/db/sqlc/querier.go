@@ -6,6 +6,7 @@ package db
 
 import (
 	"context"
+	"time"
 
 	"github.com/jackc/pgx/v5/pgtype"
 )
@@ -17,6 +18,12 @@ type Querier interface {
 	AddDisputeEvidence(ctx context.Context, arg AddDisputeEvidenceParams) (DisputeCase, error)
 	// Add response to rating
 	AddResponseToRating(ctx context.Context, arg AddResponseToRatingParams) (UserRating, error)
+	// Clear the license, banking and availability details of a deleted agent
+	AnonymizeInspectionAgentProfile(ctx context.Context, userID int64) error
+	// Clear the business, banking and guarantor details of a deleted landlord
+	AnonymizeLandlordProfile(ctx context.Context, userID int64) error
+	// Replace the personal data of a deleted user, keeping the row for the records that reference it
+	AnonymizeUser(ctx context.Context, arg AnonymizeUserParams) error
 	// Approve inspection agent
 	ApproveInspectionAgent(ctx context.Context, arg ApproveInspectionAgentParams) (InspectionAgentProfile, error)
 	// Approve inspection report
@@ -25,8 +32,10 @@ type Querier interface {
 	ApproveRentalApplication(ctx context.Context, arg ApproveRentalApplicationParams) (RentalApplication, error)
 	// Assign admin to dispute
 	AssignAdminToDispute(ctx context.Context, arg AssignAdminToDisputeParams) (DisputeCase, error)
-	// Assign inspection agent
+	// Assign inspection agent, only if the agent has been approved
 	AssignInspectionAgent(ctx context.Context, arg AssignInspectionAgentParams) (InspectionRequest, error)
+	// Cancel a deletion that is still in its grace period
+	CancelAccountDeletion(ctx context.Context, id int64) (AccountDeletion, error)
 	// Cancel inspection
 	CancelInspection(ctx context.Context, arg CancelInspectionParams) (InspectionRequest, error)
 	// Cancel payment
@@ -51,12 +60,22 @@ type Querier interface {
 	CleanupOldConversations(ctx context.Context, createdAt pgtype.Timestamptz) error
 	// Clean up old sessions
 	CleanupOldSessions(ctx context.Context, createdAt pgtype.Timestamptz) error
+	// Clean up expired cache entries that haven't been searched since a time; the others are kept for search statistics
+	CleanupStaleSearchCache(ctx context.Context, lastSearchedAt time.Time) (int64, error)
 	// Close dispute
 	CloseDispute(ctx context.Context, arg CloseDisputeParams) (DisputeCase, error)
+	// Group the available properties inside a viewport into grid cells of cell_degrees, for maps zoomed out too far to show pins
+	ClusterPropertiesInBounds(ctx context.Context, arg ClusterPropertiesInBoundsParams) ([]ClusterPropertiesInBoundsRow, error)
+	// Mark a deletion as carried out
+	CompleteAccountDeletion(ctx context.Context, id int64) (AccountDeletion, error)
+	// Store the assembled archive of a pending export
+	CompleteAccountExport(ctx context.Context, arg CompleteAccountExportParams) (AccountExport, error)
 	// Complete agreement
 	CompleteAgreement(ctx context.Context, id int64) (RentalAgreement, error)
 	// Complete inspection
 	CompleteInspection(ctx context.Context, id int64) (InspectionRequest, error)
+	// Mark a pending verification as verified together with its final data
+	CompleteUserVerification(ctx context.Context, arg CompleteUserVerificationParams) (UserVerification, error)
 	// Confirm inspection
 	ConfirmInspection(ctx context.Context, arg ConfirmInspectionParams) (InspectionRequest, error)
 	// Count active cache entries
@@ -93,6 +112,8 @@ type Querier interface {
 	CountEscalatedConversations(ctx context.Context) (int64, error)
 	// Count expired cache entries
 	CountExpiredCacheEntries(ctx context.Context) (int64, error)
+	// Count users matching FilterUsers
+	CountFilteredUsers(ctx context.Context, arg CountFilteredUsersParams) (int64, error)
 	// Count inspection requests by status
 	CountInspectionRequestsByStatus(ctx context.Context, status NullInspectionStatusEnum) (int64, error)
 	// Count inquiries for landlord
@@ -105,6 +126,8 @@ type Querier interface {
 	CountNotificationsByType(ctx context.Context, arg CountNotificationsByTypeParams) (int64, error)
 	// Count payments by status
 	CountPaymentsByStatus(ctx context.Context, status NullPaymentStatusFullEnum) (int64, error)
+	// Count pending agent applications
+	CountPendingAgentApplications(ctx context.Context) (int64, error)
 	// Count pending approval reports
 	CountPendingApprovalReports(ctx context.Context) (int64, error)
 	// Count pending verifications
@@ -129,6 +152,10 @@ type Querier interface {
 	CountPublicSettings(ctx context.Context) (int64, error)
 	// Count ratings for user
 	CountRatingsForUser(ctx context.Context, ratedUserID int64) (int64, error)
+	// Count the exports a user requested since the given time
+	CountRecentAccountExports(ctx context.Context, arg CountRecentAccountExportsParams) (int64, error)
+	// Count verifications of a type created for a user since a point in time
+	CountRecentUserVerifications(ctx context.Context, arg CountRecentUserVerificationsParams) (int64, error)
 	// Count rental agreements by status
 	CountRentalAgreementsByStatus(ctx context.Context, status NullAgreementStatusEnum) (int64, error)
 	// Count rental applications by status
@@ -139,6 +166,8 @@ type Querier interface {
 	CountSettingsByType(ctx context.Context, settingType NullSettingTypeEnum) (int64, error)
 	// Count system settings
 	CountSystemSettings(ctx context.Context) (int64, error)
+	// Count the documents of a tenant
+	CountTenantDocuments(ctx context.Context, ownerID int64) (int64, error)
 	// Count tenant's inspection requests
 	CountTenantInspectionRequests(ctx context.Context, tenantID int64) (int64, error)
 	// Count tenant's rental agreements
@@ -167,25 +196,33 @@ type Querier interface {
 	CountUserSavedProperties(ctx context.Context, tenantID int64) (int64, error)
 	// Count user total sessions
 	CountUserTotalSessions(ctx context.Context, userID int64) (int64, error)
-	// Count users by type
-	CountUsersByType(ctx context.Context, userType UserTypeEnum) (int64, error)
 	// Count verified reviews for property
 	CountVerifiedPropertyReviews(ctx context.Context, propertyID int64) (int64, error)
 	// Count verified ratings for user
 	CountVerifiedRatingsForUser(ctx context.Context, ratedUserID int64) (int64, error)
+	// Schedule the deletion of an account
+	CreateAccountDeletion(ctx context.Context, arg CreateAccountDeletionParams) (AccountDeletion, error)
+	// Create a pending account export
+	CreateAccountExport(ctx context.Context, userID int64) (AccountExport, error)
+	// Add an availability exception or blackout
+	CreateAgentAvailabilityException(ctx context.Context, arg CreateAgentAvailabilityExceptionParams) (AgentAvailabilityException, error)
+	// Add a weekly availability window
+	CreateAgentAvailabilityWindow(ctx context.Context, arg CreateAgentAvailabilityWindowParams) (AgentAvailabilityWindow, error)
 	// Create audit log
 	CreateAuditLog(ctx context.Context, arg CreateAuditLogParams) (AuditLog, error)
 	// Create chatbot conversation
 	CreateChatbotConversation(ctx context.Context, arg CreateChatbotConversationParams) (ChatbotConversation, error)
 	// Create dispute case
 	CreateDisputeCase(ctx context.Context, arg CreateDisputeCaseParams) (DisputeCase, error)
+	// Share a document with the landlord of a rental application. Sharing a revoked document again restores the access.
+	CreateDocumentAccessGrant(ctx context.Context, arg CreateDocumentAccessGrantParams) (DocumentAccessGrant, error)
 	// Create a new inspection agent profile
 	CreateInspectionAgentProfile(ctx context.Context, arg CreateInspectionAgentProfileParams) (InspectionAgentProfile, error)
 	// Create inspection report
 	CreateInspectionReport(ctx context.Context, arg CreateInspectionReportParams) (InspectionReport, error)
 	// Create inspection request
 	CreateInspectionRequest(ctx context.Context, arg CreateInspectionRequestParams) (InspectionRequest, error)
-	// Create a new landlord profile
+	// Create a landlord profile, or fill in the one created without details at signup
 	CreateLandlordProfile(ctx context.Context, arg CreateLandlordProfileParams) (LandlordProfile, error)
 	// Create message
 	CreateMessage(ctx context.Context, arg CreateMessageParams) (Message, error)
@@ -209,26 +246,38 @@ type Querier interface {
 	CreateRentalApplication(ctx context.Context, arg CreateRentalApplicationParams) (RentalApplication, error)
 	// Create system setting
 	CreateSystemSetting(ctx context.Context, arg CreateSystemSettingParams) (SystemSetting, error)
+	// Record an uploaded document
+	CreateTenantDocument(ctx context.Context, arg CreateTenantDocumentParams) (TenantDocument, error)
 	// Create a new tenant profile
 	CreateTenantProfile(ctx context.Context, arg CreateTenantProfileParams) (TenantProfile, error)
 	// Create a new user
 	CreateUser(ctx context.Context, arg CreateUserParams) (User, error)
+	// Link a provider identity to a user
+	CreateUserIdentity(ctx context.Context, arg CreateUserIdentityParams) (UserIdentity, error)
 	// Create user rating
 	CreateUserRating(ctx context.Context, arg CreateUserRatingParams) (UserRating, error)
 	// Create user session
 	CreateUserSession(ctx context.Context, arg CreateUserSessionParams) (UserSession, error)
 	// Create a new user verification
 	CreateUserVerification(ctx context.Context, arg CreateUserVerificationParams) (UserVerification, error)
+	// Take down every listing of a landlord
+	DeactivateLandlordProperties(ctx context.Context, landlordID int64) error
 	// Deactivate all user sessions except current
 	DeactivateOtherUserSessions(ctx context.Context, arg DeactivateOtherUserSessionsParams) error
 	// Deactivate session
 	DeactivateSession(ctx context.Context, sessionToken string) error
+	// Deactivate user session by session ID
+	DeactivateUserSessionBySessionID(ctx context.Context, arg DeactivateUserSessionBySessionIDParams) ([]UserSession, error)
 	// Deactivate user sessions
 	DeactivateUserSessions(ctx context.Context, userID int64) error
-	// Decrement landlord property count
+	// Decrement landlord property count, creating the profile of a landlord who has none
 	DecrementLandlordPropertyCount(ctx context.Context, userID int64) error
 	// Decrement helpful votes
 	DecrementReviewHelpfulVotes(ctx context.Context, id int64) error
+	// Delete all availability exceptions of an agent
+	DeleteAgentAvailabilityExceptions(ctx context.Context, agentID int64) error
+	// Delete all weekly availability windows of an agent
+	DeleteAgentAvailabilityWindows(ctx context.Context, agentID int64) error
 	// Delete all media for property
 	DeleteAllPropertyMedia(ctx context.Context, propertyID int64) error
 	// Delete all user notifications
@@ -285,22 +334,49 @@ type Querier interface {
 	DeleteSavedProperty(ctx context.Context, id int64) error
 	// Delete system setting
 	DeleteSystemSetting(ctx context.Context, settingKey string) error
+	// Delete a document, returning it so that its content can be removed from the blob store
+	DeleteTenantDocument(ctx context.Context, id int64) (TenantDocument, error)
+	// Delete all documents of a tenant, returning the blob store keys of their content
+	DeleteTenantDocumentsByOwner(ctx context.Context, ownerID int64) ([]string, error)
 	// Delete tenant profile
 	DeleteTenantProfile(ctx context.Context, userID int64) error
 	// Delete user (soft delete by setting inactive)
 	DeleteUser(ctx context.Context, id int64) error
+	// Delete every chatbot conversation of a user
+	DeleteUserChatbotConversations(ctx context.Context, userID int64) error
+	// Unlink every provider identity of a user
+	DeleteUserIdentitiesByUserID(ctx context.Context, userID int64) error
 	// Delete user rating
 	DeleteUserRating(ctx context.Context, id int64) error
 	// Delete user session
 	DeleteUserSession(ctx context.Context, id int64) error
 	// Delete user verification
 	DeleteUserVerification(ctx context.Context, id int64) error
+	// Delete all verifications of a type for a user
+	DeleteUserVerificationsByType(ctx context.Context, arg DeleteUserVerificationsByTypeParams) error
+	// Delete every verification of a user
+	DeleteUserVerificationsByUserID(ctx context.Context, userID int64) error
 	// Update conversation escalation
 	EscalateConversation(ctx context.Context, arg EscalateConversationParams) (ChatbotConversation, error)
+	// Expire the cached searches a property is in, or would now be in with its current values.
+	// search_params holds the filters that SearchProperties was called with, see the query of SearchProperties.
+	ExpirePropertySearchCaches(ctx context.Context, propertyID int64) (int64, error)
 	// Extend cache expiry
 	ExtendCacheExpiry(ctx context.Context, arg ExtendCacheExpiryParams) (PropertySearchCache, error)
+	// Mark a pending export as failed
+	FailAccountExport(ctx context.Context, id int64) error
 	// Fail payment
 	FailPayment(ctx context.Context, arg FailPaymentParams) (Payment, error)
+	// Filter users by an optional search term, type and active status
+	FilterUsers(ctx context.Context, arg FilterUsersParams) ([]User, error)
+	// Get account deletion by ID
+	GetAccountDeletion(ctx context.Context, id int64) (AccountDeletion, error)
+	// Get account export by ID
+	GetAccountExport(ctx context.Context, id int64) (AccountExport, error)
+	// Collect everything we hold about a user as one JSON document
+	GetAccountExportData(ctx context.Context, userID int64) ([]byte, error)
+	// Get account lockout by user ID
+	GetAccountLockout(ctx context.Context, userID int64) (AccountLockout, error)
 	// Get active cache entries
 	GetActiveCacheEntries(ctx context.Context, arg GetActiveCacheEntriesParams) ([]PropertySearchCache, error)
 	// Get active agreements for landlord
@@ -321,6 +397,8 @@ type Querier interface {
 	GetAgreementsPendingSignatures(ctx context.Context, arg GetAgreementsPendingSignaturesParams) ([]GetAgreementsPendingSignaturesRow, error)
 	// Get all system settings
 	GetAllSystemSettings(ctx context.Context, arg GetAllSystemSettingsParams) ([]SystemSetting, error)
+	// Get all user active sessions
+	GetAllUserActiveSessions(ctx context.Context, userID int64) ([]UserSession, error)
 	// Get application conversation
 	GetApplicationConversation(ctx context.Context, arg GetApplicationConversationParams) ([]GetApplicationConversationRow, error)
 	// Get approved reports by date range
@@ -477,6 +555,10 @@ type Querier interface {
 	GetPaymentsByStatus(ctx context.Context, arg GetPaymentsByStatusParams) ([]GetPaymentsByStatusRow, error)
 	// Get payments by type
 	GetPaymentsByType(ctx context.Context, arg GetPaymentsByTypeParams) ([]GetPaymentsByTypeRow, error)
+	// Get the deletion of a user that is still in its grace period
+	GetPendingAccountDeletion(ctx context.Context, userID int64) (AccountDeletion, error)
+	// Get the export of a user that is still being assembled
+	GetPendingAccountExport(ctx context.Context, userID int64) (AccountExport, error)
 	// Get pending applications for landlord
 	GetPendingApplicationsForLandlord(ctx context.Context, arg GetPendingApplicationsForLandlordParams) ([]GetPendingApplicationsForLandlordRow, error)
 	// Get pending approval reports
@@ -491,7 +573,7 @@ type Querier interface {
 	GetPendingPushNotifications(ctx context.Context, arg GetPendingPushNotificationsParams) ([]Notification, error)
 	// Get pending SMS notifications
 	GetPendingSMSNotifications(ctx context.Context, arg GetPendingSMSNotificationsParams) ([]GetPendingSMSNotificationsRow, error)
-	// Get popular search caches
+	// Get the most run searches since a time, adding up the cached pages of each
 	GetPopularSearchCaches(ctx context.Context, arg GetPopularSearchCachesParams) ([]GetPopularSearchCachesRow, error)
 	// Get primary media for property
 	GetPrimaryPropertyMedia(ctx context.Context, propertyID int64) (PropertyMedium, error)
@@ -505,6 +587,8 @@ type Querier interface {
 	GetPropertyCommunityReviews(ctx context.Context, arg GetPropertyCommunityReviewsParams) ([]GetPropertyCommunityReviewsRow, error)
 	// Get property conversation
 	GetPropertyConversation(ctx context.Context, arg GetPropertyConversationParams) ([]GetPropertyConversationRow, error)
+	// Lock a property while its status changes
+	GetPropertyForUpdate(ctx context.Context, id int64) (Property, error)
 	// Get inquiries for property
 	GetPropertyInquiries(ctx context.Context, arg GetPropertyInquiriesParams) ([]GetPropertyInquiriesRow, error)
 	// Get property inquiry by ID
@@ -559,6 +643,8 @@ type Querier interface {
 	GetReportStatistics(ctx context.Context) (GetReportStatisticsRow, error)
 	// Get reports by agent
 	GetReportsByAgent(ctx context.Context, arg GetReportsByAgentParams) ([]GetReportsByAgentRow, error)
+	// Get rotated user session by token
+	GetRotatedUserSessionByToken(ctx context.Context, sessionToken string) (UserSession, error)
 	// Get saved property by ID
 	GetSavedPropertyByID(ctx context.Context, id int64) (SavedProperty, error)
 	// Get session statistics
@@ -577,6 +663,8 @@ type Querier interface {
 	GetSystemSettingByID(ctx context.Context, id int64) (SystemSetting, error)
 	// Get system setting by key
 	GetSystemSettingByKey(ctx context.Context, settingKey string) (SystemSetting, error)
+	// Get a document by ID
+	GetTenantDocument(ctx context.Context, id int64) (TenantDocument, error)
 	// Get inquiries for tenant
 	GetTenantInquiries(ctx context.Context, arg GetTenantInquiriesParams) ([]GetTenantInquiriesRow, error)
 	// Get tenant's inspection requests
@@ -619,6 +707,8 @@ type Querier interface {
 	GetUserEarningSummary(ctx context.Context, payeeID pgtype.Int8) (GetUserEarningSummaryRow, error)
 	// Get user earnings (as payee)
 	GetUserEarnings(ctx context.Context, arg GetUserEarningsParams) ([]GetUserEarningsRow, error)
+	// Get the identity a provider knows by subject
+	GetUserIdentity(ctx context.Context, arg GetUserIdentityParams) (UserIdentity, error)
 	// Get user login logs
 	GetUserLoginLogs(ctx context.Context, arg GetUserLoginLogsParams) ([]GetUserLoginLogsRow, error)
 	// Get notification statistics for user
@@ -661,9 +751,13 @@ type Querier interface {
 	GetVerifiedPropertyCommunityReviews(ctx context.Context, arg GetVerifiedPropertyCommunityReviewsParams) ([]GetVerifiedPropertyCommunityReviewsRow, error)
 	// Get verified ratings for user
 	GetVerifiedRatingsForUser(ctx context.Context, arg GetVerifiedRatingsForUserParams) ([]GetVerifiedRatingsForUserRow, error)
+	// Check whether a document was shared with a user for an application that is still open or approved
+	HasDocumentAccess(ctx context.Context, arg HasDocumentAccessParams) (bool, error)
 	// Increment agent inspection count
 	IncrementAgentInspectionCount(ctx context.Context, userID int64) error
-	// Increment landlord property count
+	// Increment failed login attempts
+	IncrementFailedLoginAttempts(ctx context.Context, userID int64) (AccountLockout, error)
+	// Increment landlord property count, creating the profile of a landlord who has none
 	IncrementLandlordPropertyCount(ctx context.Context, userID int64) error
 	// Increment property views
 	IncrementPropertyViews(ctx context.Context, id int64) error
@@ -673,8 +767,18 @@ type Querier interface {
 	IsPropertySavedByUser(ctx context.Context, arg IsPropertySavedByUserParams) (bool, error)
 	// Landlord sign agreement
 	LandlordSignAgreement(ctx context.Context, id int64) (RentalAgreement, error)
+	// Page through active users by id, for periodic jobs
+	ListActiveUsersAfterID(ctx context.Context, arg ListActiveUsersAfterIDParams) ([]User, error)
+	// List the availability exceptions of an agent that overlap a range of dates
+	ListAgentAvailabilityExceptions(ctx context.Context, arg ListAgentAvailabilityExceptionsParams) ([]AgentAvailabilityException, error)
+	// List the weekly availability windows of an agent
+	ListAgentAvailabilityWindows(ctx context.Context, agentID int64) ([]AgentAvailabilityWindow, error)
+	// List the confirmed inspections of an agent in a range of dates, which take up their time
+	ListAgentBookedInspections(ctx context.Context, arg ListAgentBookedInspectionsParams) ([]ListAgentBookedInspectionsRow, error)
 	// List approved agents by area
 	ListApprovedAgentsByArea(ctx context.Context, arg ListApprovedAgentsByAreaParams) ([]ListApprovedAgentsByAreaRow, error)
+	// List the deletions whose grace period has ended, oldest first
+	ListDueAccountDeletions(ctx context.Context, limit int32) ([]AccountDeletion, error)
 	// List featured properties
 	ListFeaturedProperties(ctx context.Context, arg ListFeaturedPropertiesParams) ([]ListFeaturedPropertiesRow, error)
 	// List landlords by property count
@@ -689,18 +793,29 @@ type Querier interface {
 	ListPropertiesByLandlord(ctx context.Context, arg ListPropertiesByLandlordParams) ([]Property, error)
 	// List properties by location
 	ListPropertiesByLocation(ctx context.Context, arg ListPropertiesByLocationParams) ([]Property, error)
+	// List the map pins of available properties inside a viewport, most viewed first
+	ListPropertyPinsInBounds(ctx context.Context, arg ListPropertyPinsInBoundsParams) ([]ListPropertyPinsInBoundsRow, error)
+	// Get the SearchProperties rows of cached result ids, ranked and highlighted for the same query and terms.
+	// Properties that were unpublished or rented since are left out; the caller puts the rows back in cached order.
+	ListPropertySearchResults(ctx context.Context, arg ListPropertySearchResultsParams) ([]ListPropertySearchResultsRow, error)
 	// List recent properties
 	ListRecentProperties(ctx context.Context, arg ListRecentPropertiesParams) ([]ListRecentPropertiesRow, error)
 	// List recent reviews
 	ListRecentPropertyReviews(ctx context.Context, arg ListRecentPropertyReviewsParams) ([]ListRecentPropertyReviewsRow, error)
+	// List the documents shared for a rental application
+	ListRentalApplicationDocuments(ctx context.Context, rentalApplicationID int64) ([]ListRentalApplicationDocumentsRow, error)
+	// List the documents of a tenant, newest first
+	ListTenantDocuments(ctx context.Context, ownerID int64) ([]TenantDocument, error)
 	// List top agents by rating
 	ListTopAgentsByRating(ctx context.Context, arg ListTopAgentsByRatingParams) ([]ListTopAgentsByRatingRow, error)
 	// List top landlords by rating
 	ListTopLandlordsByRating(ctx context.Context, arg ListTopLandlordsByRatingParams) ([]ListTopLandlordsByRatingRow, error)
-	// List users by type
-	ListUsersByType(ctx context.Context, arg ListUsersByTypeParams) ([]User, error)
+	// List the provider identities linked to a user
+	ListUserIdentitiesByUserID(ctx context.Context, userID int64) ([]UserIdentity, error)
 	// List verifications by type and status
 	ListVerificationsByTypeAndStatus(ctx context.Context, arg ListVerificationsByTypeAndStatusParams) ([]ListVerificationsByTypeAndStatusRow, error)
+	// Lock account until the given time
+	LockAccount(ctx context.Context, arg LockAccountParams) (AccountLockout, error)
 	// Mark all user notifications as read
 	MarkAllUserNotificationsAsRead(ctx context.Context, userID int64) error
 	// Mark inquiry as read
@@ -713,20 +828,34 @@ type Querier interface {
 	MarkNotificationAsRead(ctx context.Context, id int64) error
 	// Mark multiple notifications as read
 	MarkNotificationsAsRead(ctx context.Context, dollar_1 []int64) error
+	// Mark user session as rotated
+	MarkUserSessionRotated(ctx context.Context, arg MarkUserSessionRotatedParams) (int64, error)
 	// Process payment
 	ProcessPayment(ctx context.Context, arg ProcessPaymentParams) (Payment, error)
+	// Drop the archive of an export once its download link has expired
+	PurgeAccountExport(ctx context.Context, id int64) error
+	// Count searches served from cache, batched as the number of hits of each entry
+	RecordSearchCacheHits(ctx context.Context, arg RecordSearchCacheHitsParams) error
+	// Remove the content of every message a user sent
+	RedactMessagesBySender(ctx context.Context, senderID int64) error
+	// Remove the employment details, references and notes a tenant attached to their applications
+	RedactTenantRentalApplications(ctx context.Context, tenantID int64) error
 	// Refund payment
 	RefundPayment(ctx context.Context, arg RefundPaymentParams) (Payment, error)
 	// Reject inspection agent
-	RejectInspectionAgent(ctx context.Context, userID int64) error
+	RejectInspectionAgent(ctx context.Context, arg RejectInspectionAgentParams) (InspectionAgentProfile, error)
 	// Reject inspection report
 	RejectInspectionReport(ctx context.Context, id int64) error
 	// Reject rental application
 	RejectRentalApplication(ctx context.Context, arg RejectRentalApplicationParams) (RentalApplication, error)
+	// Reset account lockout
+	ResetAccountLockout(ctx context.Context, userID int64) error
 	// Resolve dispute
 	ResolveDispute(ctx context.Context, arg ResolveDisputeParams) (DisputeCase, error)
 	// Respond to inquiry
 	RespondToInquiry(ctx context.Context, arg RespondToInquiryParams) (PropertyInquiry, error)
+	// Revoke the access to a document that was shared for a rental application
+	RevokeDocumentAccessGrant(ctx context.Context, arg RevokeDocumentAccessGrantParams) (DocumentAccessGrant, error)
 	// Save a property
 	SaveProperty(ctx context.Context, arg SavePropertyParams) (SavedProperty, error)
 	// Search cache entries
@@ -735,20 +864,31 @@ type Querier interface {
 	SearchChatbotConversations(ctx context.Context, arg SearchChatbotConversationsParams) ([]SearchChatbotConversationsRow, error)
 	// Search messages
 	SearchMessages(ctx context.Context, arg SearchMessagesParams) ([]SearchMessagesRow, error)
-	// Search properties with filters
+	// Search available properties with filters and an optional free-text query, one keyset page at a time.
+	// Amenities and terms are lowercase; amenities must all be listed, while terms are the words of query
+	// that may be misspelled area names. The cursor is the sort key and id of the last row of the previous page,
+	// and only the cursor column of sort_by is read. Snippets highlight the matches of query with <mark> tags.
 	SearchProperties(ctx context.Context, arg SearchPropertiesParams) ([]SearchPropertiesRow, error)
+	// Search available properties within radius_meters of a point, nearest first
+	SearchPropertiesNearby(ctx context.Context, arg SearchPropertiesNearbyParams) ([]SearchPropertiesNearbyRow, error)
+	// Count the properties matching the SearchProperties filters and query by type, bedroom count and city
+	SearchPropertyFacets(ctx context.Context, arg SearchPropertyFacetsParams) ([]SearchPropertyFacetsRow, error)
 	// Search settings
 	SearchSettings(ctx context.Context, arg SearchSettingsParams) ([]SystemSetting, error)
 	// Search tenant profiles by criteria
 	SearchTenantProfiles(ctx context.Context, arg SearchTenantProfilesParams) ([]SearchTenantProfilesRow, error)
-	// Search users
-	SearchUsers(ctx context.Context, arg SearchUsersParams) ([]User, error)
 	// Set primary media
 	SetPrimaryMedia(ctx context.Context, arg SetPrimaryMediaParams) error
+	// Submit or resubmit an agent application for review
+	SubmitInspectionAgentApplication(ctx context.Context, arg SubmitInspectionAgentApplicationParams) (InspectionAgentProfile, error)
+	// Replace verification data only if nobody changed it since it was read
+	SwapVerificationData(ctx context.Context, arg SwapVerificationDataParams) (int64, error)
 	// Tenant sign agreement
 	TenantSignAgreement(ctx context.Context, id int64) (RentalAgreement, error)
 	// Terminate agreement
 	TerminateAgreement(ctx context.Context, id int64) (RentalAgreement, error)
+	// Record a sign-in with an identity and the email the provider reported
+	TouchUserIdentity(ctx context.Context, arg TouchUserIdentityParams) (UserIdentity, error)
 	// Unsave a property
 	UnsaveProperty(ctx context.Context, arg UnsavePropertyParams) error
 	// Update agent banking details
@@ -763,8 +903,6 @@ type Querier interface {
 	UpdateAgreementDocument(ctx context.Context, arg UpdateAgreementDocumentParams) (RentalAgreement, error)
 	// Update agreement status
 	UpdateAgreementStatus(ctx context.Context, arg UpdateAgreementStatusParams) (RentalAgreement, error)
-	// Update application documents
-	UpdateApplicationDocuments(ctx context.Context, arg UpdateApplicationDocumentsParams) (RentalApplication, error)
 	// Update employment details
 	UpdateApplicationEmploymentDetails(ctx context.Context, arg UpdateApplicationEmploymentDetailsParams) (RentalApplication, error)
 	// Update application references
@@ -849,18 +987,26 @@ type Querier interface {
 	UpdateUser(ctx context.Context, arg UpdateUserParams) (User, error)
 	// Update user active status
 	UpdateUserActiveStatus(ctx context.Context, arg UpdateUserActiveStatusParams) error
+	// Update whether the nin of the user was verified by the identity provider
+	UpdateUserIdentityVerified(ctx context.Context, arg UpdateUserIdentityVerifiedParams) error
 	// Update user last login time
 	UpdateUserLastLogin(ctx context.Context, id int64) error
+	// Update user nin, which has to be verified again
+	UpdateUserNIN(ctx context.Context, arg UpdateUserNINParams) error
 	// Update user password
 	UpdateUserPassword(ctx context.Context, arg UpdateUserPasswordParams) error
 	// Update user rating
 	UpdateUserRating(ctx context.Context, arg UpdateUserRatingParams) (UserRating, error)
+	// Update user type
+	UpdateUserType(ctx context.Context, arg UpdateUserTypeParams) error
 	// Update user verification status
 	UpdateUserVerificationStatus(ctx context.Context, arg UpdateUserVerificationStatusParams) error
 	// Update verification data
 	UpdateVerificationData(ctx context.Context, arg UpdateVerificationDataParams) (UserVerification, error)
 	// Update verification status
 	UpdateVerificationStatus(ctx context.Context, arg UpdateVerificationStatusParams) (UserVerification, error)
+	// Cache one page of a search, or refresh it when the search ran again after the entry expired or was invalidated
+	UpsertPropertySearchCache(ctx context.Context, arg UpsertPropertySearchCacheParams) (PropertySearchCache, error)
 	// Verify property
 	VerifyProperty(ctx context.Context, arg VerifyPropertyParams) (Property, error)
 	// Verify property review
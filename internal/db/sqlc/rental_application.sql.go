@@ -15,7 +15,7 @@ const approveRentalApplication = `-- name: ApproveRentalApplication :one
 UPDATE rental_applications 
 SET status = 'approved', decided_at = NOW(), decided_by = $2, updated_at = NOW()
 WHERE id = $1 
-RETURNING id, property_id, tenant_id, landlord_id, application_documents, employment_details, "references", preferred_move_in_date, additional_notes, status, decision_reason, decided_at, decided_by, created_at, updated_at
+RETURNING id, property_id, tenant_id, landlord_id, employment_details, "references", preferred_move_in_date, additional_notes, status, decision_reason, decided_at, decided_by, created_at, updated_at
 `
 
 type ApproveRentalApplicationParams struct {
@@ -32,7 +32,6 @@ func (q *Queries) ApproveRentalApplication(ctx context.Context, arg ApproveRenta
 		&i.PropertyID,
 		&i.TenantID,
 		&i.LandlordID,
-		&i.ApplicationDocuments,
 		&i.EmploymentDetails,
 		&i.References,
 		&i.PreferredMoveInDate,
@@ -121,22 +120,21 @@ func (q *Queries) CountTenantRentalApplications(ctx context.Context, tenantID in
 
 const createRentalApplication = `-- name: CreateRentalApplication :one
 INSERT INTO rental_applications (
-  property_id, tenant_id, landlord_id, application_documents, employment_details,
+  property_id, tenant_id, landlord_id, employment_details,
   "references", preferred_move_in_date, additional_notes
 ) VALUES (
-  $1, $2, $3, $4, $5, $6, $7, $8
-) RETURNING id, property_id, tenant_id, landlord_id, application_documents, employment_details, "references", preferred_move_in_date, additional_notes, status, decision_reason, decided_at, decided_by, created_at, updated_at
+  $1, $2, $3, $4, $5, $6, $7
+) RETURNING id, property_id, tenant_id, landlord_id, employment_details, "references", preferred_move_in_date, additional_notes, status, decision_reason, decided_at, decided_by, created_at, updated_at
 `
 
 type CreateRentalApplicationParams struct {
-	PropertyID           int64       `json:"property_id"`
-	TenantID             int64       `json:"tenant_id"`
-	LandlordID           int64       `json:"landlord_id"`
-	ApplicationDocuments pgtype.Text `json:"application_documents"`
-	EmploymentDetails    pgtype.Text `json:"employment_details"`
-	References           pgtype.Text `json:"references"`
-	PreferredMoveInDate  pgtype.Date `json:"preferred_move_in_date"`
-	AdditionalNotes      pgtype.Text `json:"additional_notes"`
+	PropertyID          int64       `json:"property_id"`
+	TenantID            int64       `json:"tenant_id"`
+	LandlordID          int64       `json:"landlord_id"`
+	EmploymentDetails   pgtype.Text `json:"employment_details"`
+	References          pgtype.Text `json:"references"`
+	PreferredMoveInDate pgtype.Date `json:"preferred_move_in_date"`
+	AdditionalNotes     pgtype.Text `json:"additional_notes"`
 }
 
 // Create rental application
@@ -145,7 +143,6 @@ func (q *Queries) CreateRentalApplication(ctx context.Context, arg CreateRentalA
 		arg.PropertyID,
 		arg.TenantID,
 		arg.LandlordID,
-		arg.ApplicationDocuments,
 		arg.EmploymentDetails,
 		arg.References,
 		arg.PreferredMoveInDate,
@@ -157,7 +154,6 @@ func (q *Queries) CreateRentalApplication(ctx context.Context, arg CreateRentalA
 		&i.PropertyID,
 		&i.TenantID,
 		&i.LandlordID,
-		&i.ApplicationDocuments,
 		&i.EmploymentDetails,
 		&i.References,
 		&i.PreferredMoveInDate,
@@ -214,7 +210,7 @@ func (q *Queries) GetLandlordApplicationStats(ctx context.Context, landlordID in
 }
 
 const getLandlordRentalApplications = `-- name: GetLandlordRentalApplications :many
-SELECT ra.id, ra.property_id, ra.tenant_id, ra.landlord_id, ra.application_documents, ra.employment_details, ra."references", ra.preferred_move_in_date, ra.additional_notes, ra.status, ra.decision_reason, ra.decided_at, ra.decided_by, ra.created_at, ra.updated_at, p.title as property_title,
+SELECT ra.id, ra.property_id, ra.tenant_id, ra.landlord_id, ra.employment_details, ra."references", ra.preferred_move_in_date, ra.additional_notes, ra.status, ra.decision_reason, ra.decided_at, ra.decided_by, ra.created_at, ra.updated_at, p.title as property_title,
        t.first_name as tenant_first_name, t.last_name as tenant_last_name, t.email as tenant_email,
        tp.occupation, tp.monthly_income
 FROM rental_applications ra
@@ -233,27 +229,26 @@ type GetLandlordRentalApplicationsParams struct {
 }
 
 type GetLandlordRentalApplicationsRow struct {
-	ID                   int64                     `json:"id"`
-	PropertyID           int64                     `json:"property_id"`
-	TenantID             int64                     `json:"tenant_id"`
-	LandlordID           int64                     `json:"landlord_id"`
-	ApplicationDocuments pgtype.Text               `json:"application_documents"`
-	EmploymentDetails    pgtype.Text               `json:"employment_details"`
-	References           pgtype.Text               `json:"references"`
-	PreferredMoveInDate  pgtype.Date               `json:"preferred_move_in_date"`
-	AdditionalNotes      pgtype.Text               `json:"additional_notes"`
-	Status               NullApplicationStatusEnum `json:"status"`
-	DecisionReason       pgtype.Text               `json:"decision_reason"`
-	DecidedAt            pgtype.Timestamptz        `json:"decided_at"`
-	DecidedBy            pgtype.Int8               `json:"decided_by"`
-	CreatedAt            pgtype.Timestamptz        `json:"created_at"`
-	UpdatedAt            pgtype.Timestamptz        `json:"updated_at"`
-	PropertyTitle        string                    `json:"property_title"`
-	TenantFirstName      string                    `json:"tenant_first_name"`
-	TenantLastName       string                    `json:"tenant_last_name"`
-	TenantEmail          string                    `json:"tenant_email"`
-	Occupation           pgtype.Text               `json:"occupation"`
-	MonthlyIncome        pgtype.Numeric            `json:"monthly_income"`
+	ID                  int64                     `json:"id"`
+	PropertyID          int64                     `json:"property_id"`
+	TenantID            int64                     `json:"tenant_id"`
+	LandlordID          int64                     `json:"landlord_id"`
+	EmploymentDetails   pgtype.Text               `json:"employment_details"`
+	References          pgtype.Text               `json:"references"`
+	PreferredMoveInDate pgtype.Date               `json:"preferred_move_in_date"`
+	AdditionalNotes     pgtype.Text               `json:"additional_notes"`
+	Status              NullApplicationStatusEnum `json:"status"`
+	DecisionReason      pgtype.Text               `json:"decision_reason"`
+	DecidedAt           pgtype.Timestamptz        `json:"decided_at"`
+	DecidedBy           pgtype.Int8               `json:"decided_by"`
+	CreatedAt           pgtype.Timestamptz        `json:"created_at"`
+	UpdatedAt           pgtype.Timestamptz        `json:"updated_at"`
+	PropertyTitle       string                    `json:"property_title"`
+	TenantFirstName     string                    `json:"tenant_first_name"`
+	TenantLastName      string                    `json:"tenant_last_name"`
+	TenantEmail         string                    `json:"tenant_email"`
+	Occupation          pgtype.Text               `json:"occupation"`
+	MonthlyIncome       pgtype.Numeric            `json:"monthly_income"`
 }
 
 // Get landlord's rental applications
@@ -271,7 +266,6 @@ func (q *Queries) GetLandlordRentalApplications(ctx context.Context, arg GetLand
 			&i.PropertyID,
 			&i.TenantID,
 			&i.LandlordID,
-			&i.ApplicationDocuments,
 			&i.EmploymentDetails,
 			&i.References,
 			&i.PreferredMoveInDate,
@@ -300,7 +294,7 @@ func (q *Queries) GetLandlordRentalApplications(ctx context.Context, arg GetLand
 }
 
 const getPendingApplicationsForLandlord = `-- name: GetPendingApplicationsForLandlord :many
-SELECT ra.id, ra.property_id, ra.tenant_id, ra.landlord_id, ra.application_documents, ra.employment_details, ra."references", ra.preferred_move_in_date, ra.additional_notes, ra.status, ra.decision_reason, ra.decided_at, ra.decided_by, ra.created_at, ra.updated_at, p.title as property_title,
+SELECT ra.id, ra.property_id, ra.tenant_id, ra.landlord_id, ra.employment_details, ra."references", ra.preferred_move_in_date, ra.additional_notes, ra.status, ra.decision_reason, ra.decided_at, ra.decided_by, ra.created_at, ra.updated_at, p.title as property_title,
        t.first_name as tenant_first_name, t.last_name as tenant_last_name, t.email as tenant_email,
        tp.occupation, tp.monthly_income
 FROM rental_applications ra
@@ -319,27 +313,26 @@ type GetPendingApplicationsForLandlordParams struct {
 }
 
 type GetPendingApplicationsForLandlordRow struct {
-	ID                   int64                     `json:"id"`
-	PropertyID           int64                     `json:"property_id"`
-	TenantID             int64                     `json:"tenant_id"`
-	LandlordID           int64                     `json:"landlord_id"`
-	ApplicationDocuments pgtype.Text               `json:"application_documents"`
-	EmploymentDetails    pgtype.Text               `json:"employment_details"`
-	References           pgtype.Text               `json:"references"`
-	PreferredMoveInDate  pgtype.Date               `json:"preferred_move_in_date"`
-	AdditionalNotes      pgtype.Text               `json:"additional_notes"`
-	Status               NullApplicationStatusEnum `json:"status"`
-	DecisionReason       pgtype.Text               `json:"decision_reason"`
-	DecidedAt            pgtype.Timestamptz        `json:"decided_at"`
-	DecidedBy            pgtype.Int8               `json:"decided_by"`
-	CreatedAt            pgtype.Timestamptz        `json:"created_at"`
-	UpdatedAt            pgtype.Timestamptz        `json:"updated_at"`
-	PropertyTitle        string                    `json:"property_title"`
-	TenantFirstName      string                    `json:"tenant_first_name"`
-	TenantLastName       string                    `json:"tenant_last_name"`
-	TenantEmail          string                    `json:"tenant_email"`
-	Occupation           pgtype.Text               `json:"occupation"`
-	MonthlyIncome        pgtype.Numeric            `json:"monthly_income"`
+	ID                  int64                     `json:"id"`
+	PropertyID          int64                     `json:"property_id"`
+	TenantID            int64                     `json:"tenant_id"`
+	LandlordID          int64                     `json:"landlord_id"`
+	EmploymentDetails   pgtype.Text               `json:"employment_details"`
+	References          pgtype.Text               `json:"references"`
+	PreferredMoveInDate pgtype.Date               `json:"preferred_move_in_date"`
+	AdditionalNotes     pgtype.Text               `json:"additional_notes"`
+	Status              NullApplicationStatusEnum `json:"status"`
+	DecisionReason      pgtype.Text               `json:"decision_reason"`
+	DecidedAt           pgtype.Timestamptz        `json:"decided_at"`
+	DecidedBy           pgtype.Int8               `json:"decided_by"`
+	CreatedAt           pgtype.Timestamptz        `json:"created_at"`
+	UpdatedAt           pgtype.Timestamptz        `json:"updated_at"`
+	PropertyTitle       string                    `json:"property_title"`
+	TenantFirstName     string                    `json:"tenant_first_name"`
+	TenantLastName      string                    `json:"tenant_last_name"`
+	TenantEmail         string                    `json:"tenant_email"`
+	Occupation          pgtype.Text               `json:"occupation"`
+	MonthlyIncome       pgtype.Numeric            `json:"monthly_income"`
 }
 
 // Get pending applications for landlord
@@ -357,7 +350,6 @@ func (q *Queries) GetPendingApplicationsForLandlord(ctx context.Context, arg Get
 			&i.PropertyID,
 			&i.TenantID,
 			&i.LandlordID,
-			&i.ApplicationDocuments,
 			&i.EmploymentDetails,
 			&i.References,
 			&i.PreferredMoveInDate,
@@ -386,7 +378,7 @@ func (q *Queries) GetPendingApplicationsForLandlord(ctx context.Context, arg Get
 }
 
 const getPropertyRentalApplications = `-- name: GetPropertyRentalApplications :many
-SELECT ra.id, ra.property_id, ra.tenant_id, ra.landlord_id, ra.application_documents, ra.employment_details, ra."references", ra.preferred_move_in_date, ra.additional_notes, ra.status, ra.decision_reason, ra.decided_at, ra.decided_by, ra.created_at, ra.updated_at, t.first_name as tenant_first_name, t.last_name as tenant_last_name, t.email as tenant_email,
+SELECT ra.id, ra.property_id, ra.tenant_id, ra.landlord_id, ra.employment_details, ra."references", ra.preferred_move_in_date, ra.additional_notes, ra.status, ra.decision_reason, ra.decided_at, ra.decided_by, ra.created_at, ra.updated_at, t.first_name as tenant_first_name, t.last_name as tenant_last_name, t.email as tenant_email,
        tp.occupation, tp.employer, tp.monthly_income
 FROM rental_applications ra
 JOIN users t ON ra.tenant_id = t.id
@@ -403,27 +395,26 @@ type GetPropertyRentalApplicationsParams struct {
 }
 
 type GetPropertyRentalApplicationsRow struct {
-	ID                   int64                     `json:"id"`
-	PropertyID           int64                     `json:"property_id"`
-	TenantID             int64                     `json:"tenant_id"`
-	LandlordID           int64                     `json:"landlord_id"`
-	ApplicationDocuments pgtype.Text               `json:"application_documents"`
-	EmploymentDetails    pgtype.Text               `json:"employment_details"`
-	References           pgtype.Text               `json:"references"`
-	PreferredMoveInDate  pgtype.Date               `json:"preferred_move_in_date"`
-	AdditionalNotes      pgtype.Text               `json:"additional_notes"`
-	Status               NullApplicationStatusEnum `json:"status"`
-	DecisionReason       pgtype.Text               `json:"decision_reason"`
-	DecidedAt            pgtype.Timestamptz        `json:"decided_at"`
-	DecidedBy            pgtype.Int8               `json:"decided_by"`
-	CreatedAt            pgtype.Timestamptz        `json:"created_at"`
-	UpdatedAt            pgtype.Timestamptz        `json:"updated_at"`
-	TenantFirstName      string                    `json:"tenant_first_name"`
-	TenantLastName       string                    `json:"tenant_last_name"`
-	TenantEmail          string                    `json:"tenant_email"`
-	Occupation           pgtype.Text               `json:"occupation"`
-	Employer             pgtype.Text               `json:"employer"`
-	MonthlyIncome        pgtype.Numeric            `json:"monthly_income"`
+	ID                  int64                     `json:"id"`
+	PropertyID          int64                     `json:"property_id"`
+	TenantID            int64                     `json:"tenant_id"`
+	LandlordID          int64                     `json:"landlord_id"`
+	EmploymentDetails   pgtype.Text               `json:"employment_details"`
+	References          pgtype.Text               `json:"references"`
+	PreferredMoveInDate pgtype.Date               `json:"preferred_move_in_date"`
+	AdditionalNotes     pgtype.Text               `json:"additional_notes"`
+	Status              NullApplicationStatusEnum `json:"status"`
+	DecisionReason      pgtype.Text               `json:"decision_reason"`
+	DecidedAt           pgtype.Timestamptz        `json:"decided_at"`
+	DecidedBy           pgtype.Int8               `json:"decided_by"`
+	CreatedAt           pgtype.Timestamptz        `json:"created_at"`
+	UpdatedAt           pgtype.Timestamptz        `json:"updated_at"`
+	TenantFirstName     string                    `json:"tenant_first_name"`
+	TenantLastName      string                    `json:"tenant_last_name"`
+	TenantEmail         string                    `json:"tenant_email"`
+	Occupation          pgtype.Text               `json:"occupation"`
+	Employer            pgtype.Text               `json:"employer"`
+	MonthlyIncome       pgtype.Numeric            `json:"monthly_income"`
 }
 
 // Get applications for property
@@ -441,7 +432,6 @@ func (q *Queries) GetPropertyRentalApplications(ctx context.Context, arg GetProp
 			&i.PropertyID,
 			&i.TenantID,
 			&i.LandlordID,
-			&i.ApplicationDocuments,
 			&i.EmploymentDetails,
 			&i.References,
 			&i.PreferredMoveInDate,
@@ -470,7 +460,7 @@ func (q *Queries) GetPropertyRentalApplications(ctx context.Context, arg GetProp
 }
 
 const getRentalApplicationByID = `-- name: GetRentalApplicationByID :one
-SELECT id, property_id, tenant_id, landlord_id, application_documents, employment_details, "references", preferred_move_in_date, additional_notes, status, decision_reason, decided_at, decided_by, created_at, updated_at FROM rental_applications 
+SELECT id, property_id, tenant_id, landlord_id, employment_details, "references", preferred_move_in_date, additional_notes, status, decision_reason, decided_at, decided_by, created_at, updated_at FROM rental_applications 
 WHERE id = $1 LIMIT 1
 `
 
@@ -483,7 +473,6 @@ func (q *Queries) GetRentalApplicationByID(ctx context.Context, id int64) (Renta
 		&i.PropertyID,
 		&i.TenantID,
 		&i.LandlordID,
-		&i.ApplicationDocuments,
 		&i.EmploymentDetails,
 		&i.References,
 		&i.PreferredMoveInDate,
@@ -499,7 +488,7 @@ func (q *Queries) GetRentalApplicationByID(ctx context.Context, id int64) (Renta
 }
 
 const getRentalApplicationWithDetails = `-- name: GetRentalApplicationWithDetails :one
-SELECT ra.id, ra.property_id, ra.tenant_id, ra.landlord_id, ra.application_documents, ra.employment_details, ra."references", ra.preferred_move_in_date, ra.additional_notes, ra.status, ra.decision_reason, ra.decided_at, ra.decided_by, ra.created_at, ra.updated_at, p.title as property_title, p.rent_amount, p.address as property_address,
+SELECT ra.id, ra.property_id, ra.tenant_id, ra.landlord_id, ra.employment_details, ra."references", ra.preferred_move_in_date, ra.additional_notes, ra.status, ra.decision_reason, ra.decided_at, ra.decided_by, ra.created_at, ra.updated_at, p.title as property_title, p.rent_amount, p.address as property_address,
        t.first_name as tenant_first_name, t.last_name as tenant_last_name, t.email as tenant_email, t.phone as tenant_phone,
        l.first_name as landlord_first_name, l.last_name as landlord_last_name, l.email as landlord_email,
        tp.occupation, tp.employer, tp.monthly_income,
@@ -514,36 +503,35 @@ WHERE ra.id = $1 LIMIT 1
 `
 
 type GetRentalApplicationWithDetailsRow struct {
-	ID                   int64                     `json:"id"`
-	PropertyID           int64                     `json:"property_id"`
-	TenantID             int64                     `json:"tenant_id"`
-	LandlordID           int64                     `json:"landlord_id"`
-	ApplicationDocuments pgtype.Text               `json:"application_documents"`
-	EmploymentDetails    pgtype.Text               `json:"employment_details"`
-	References           pgtype.Text               `json:"references"`
-	PreferredMoveInDate  pgtype.Date               `json:"preferred_move_in_date"`
-	AdditionalNotes      pgtype.Text               `json:"additional_notes"`
-	Status               NullApplicationStatusEnum `json:"status"`
-	DecisionReason       pgtype.Text               `json:"decision_reason"`
-	DecidedAt            pgtype.Timestamptz        `json:"decided_at"`
-	DecidedBy            pgtype.Int8               `json:"decided_by"`
-	CreatedAt            pgtype.Timestamptz        `json:"created_at"`
-	UpdatedAt            pgtype.Timestamptz        `json:"updated_at"`
-	PropertyTitle        string                    `json:"property_title"`
-	RentAmount           pgtype.Numeric            `json:"rent_amount"`
-	PropertyAddress      string                    `json:"property_address"`
-	TenantFirstName      string                    `json:"tenant_first_name"`
-	TenantLastName       string                    `json:"tenant_last_name"`
-	TenantEmail          string                    `json:"tenant_email"`
-	TenantPhone          string                    `json:"tenant_phone"`
-	LandlordFirstName    string                    `json:"landlord_first_name"`
-	LandlordLastName     string                    `json:"landlord_last_name"`
-	LandlordEmail        string                    `json:"landlord_email"`
-	Occupation           pgtype.Text               `json:"occupation"`
-	Employer             pgtype.Text               `json:"employer"`
-	MonthlyIncome        pgtype.Numeric            `json:"monthly_income"`
-	DecidedByFirstName   pgtype.Text               `json:"decided_by_first_name"`
-	DecidedByLastName    pgtype.Text               `json:"decided_by_last_name"`
+	ID                  int64                     `json:"id"`
+	PropertyID          int64                     `json:"property_id"`
+	TenantID            int64                     `json:"tenant_id"`
+	LandlordID          int64                     `json:"landlord_id"`
+	EmploymentDetails   pgtype.Text               `json:"employment_details"`
+	References          pgtype.Text               `json:"references"`
+	PreferredMoveInDate pgtype.Date               `json:"preferred_move_in_date"`
+	AdditionalNotes     pgtype.Text               `json:"additional_notes"`
+	Status              NullApplicationStatusEnum `json:"status"`
+	DecisionReason      pgtype.Text               `json:"decision_reason"`
+	DecidedAt           pgtype.Timestamptz        `json:"decided_at"`
+	DecidedBy           pgtype.Int8               `json:"decided_by"`
+	CreatedAt           pgtype.Timestamptz        `json:"created_at"`
+	UpdatedAt           pgtype.Timestamptz        `json:"updated_at"`
+	PropertyTitle       string                    `json:"property_title"`
+	RentAmount          pgtype.Numeric            `json:"rent_amount"`
+	PropertyAddress     string                    `json:"property_address"`
+	TenantFirstName     string                    `json:"tenant_first_name"`
+	TenantLastName      string                    `json:"tenant_last_name"`
+	TenantEmail         string                    `json:"tenant_email"`
+	TenantPhone         string                    `json:"tenant_phone"`
+	LandlordFirstName   string                    `json:"landlord_first_name"`
+	LandlordLastName    string                    `json:"landlord_last_name"`
+	LandlordEmail       string                    `json:"landlord_email"`
+	Occupation          pgtype.Text               `json:"occupation"`
+	Employer            pgtype.Text               `json:"employer"`
+	MonthlyIncome       pgtype.Numeric            `json:"monthly_income"`
+	DecidedByFirstName  pgtype.Text               `json:"decided_by_first_name"`
+	DecidedByLastName   pgtype.Text               `json:"decided_by_last_name"`
 }
 
 // Get rental application with details
@@ -555,7 +543,6 @@ func (q *Queries) GetRentalApplicationWithDetails(ctx context.Context, id int64)
 		&i.PropertyID,
 		&i.TenantID,
 		&i.LandlordID,
-		&i.ApplicationDocuments,
 		&i.EmploymentDetails,
 		&i.References,
 		&i.PreferredMoveInDate,
@@ -586,7 +573,7 @@ func (q *Queries) GetRentalApplicationWithDetails(ctx context.Context, id int64)
 }
 
 const getTenantRentalApplications = `-- name: GetTenantRentalApplications :many
-SELECT ra.id, ra.property_id, ra.tenant_id, ra.landlord_id, ra.application_documents, ra.employment_details, ra."references", ra.preferred_move_in_date, ra.additional_notes, ra.status, ra.decision_reason, ra.decided_at, ra.decided_by, ra.created_at, ra.updated_at, p.title as property_title, p.rent_amount, p.address as property_address,
+SELECT ra.id, ra.property_id, ra.tenant_id, ra.landlord_id, ra.employment_details, ra."references", ra.preferred_move_in_date, ra.additional_notes, ra.status, ra.decision_reason, ra.decided_at, ra.decided_by, ra.created_at, ra.updated_at, p.title as property_title, p.rent_amount, p.address as property_address,
        l.first_name as landlord_first_name, l.last_name as landlord_last_name
 FROM rental_applications ra
 JOIN properties p ON ra.property_id = p.id
@@ -603,26 +590,25 @@ type GetTenantRentalApplicationsParams struct {
 }
 
 type GetTenantRentalApplicationsRow struct {
-	ID                   int64                     `json:"id"`
-	PropertyID           int64                     `json:"property_id"`
-	TenantID             int64                     `json:"tenant_id"`
-	LandlordID           int64                     `json:"landlord_id"`
-	ApplicationDocuments pgtype.Text               `json:"application_documents"`
-	EmploymentDetails    pgtype.Text               `json:"employment_details"`
-	References           pgtype.Text               `json:"references"`
-	PreferredMoveInDate  pgtype.Date               `json:"preferred_move_in_date"`
-	AdditionalNotes      pgtype.Text               `json:"additional_notes"`
-	Status               NullApplicationStatusEnum `json:"status"`
-	DecisionReason       pgtype.Text               `json:"decision_reason"`
-	DecidedAt            pgtype.Timestamptz        `json:"decided_at"`
-	DecidedBy            pgtype.Int8               `json:"decided_by"`
-	CreatedAt            pgtype.Timestamptz        `json:"created_at"`
-	UpdatedAt            pgtype.Timestamptz        `json:"updated_at"`
-	PropertyTitle        string                    `json:"property_title"`
-	RentAmount           pgtype.Numeric            `json:"rent_amount"`
-	PropertyAddress      string                    `json:"property_address"`
-	LandlordFirstName    string                    `json:"landlord_first_name"`
-	LandlordLastName     string                    `json:"landlord_last_name"`
+	ID                  int64                     `json:"id"`
+	PropertyID          int64                     `json:"property_id"`
+	TenantID            int64                     `json:"tenant_id"`
+	LandlordID          int64                     `json:"landlord_id"`
+	EmploymentDetails   pgtype.Text               `json:"employment_details"`
+	References          pgtype.Text               `json:"references"`
+	PreferredMoveInDate pgtype.Date               `json:"preferred_move_in_date"`
+	AdditionalNotes     pgtype.Text               `json:"additional_notes"`
+	Status              NullApplicationStatusEnum `json:"status"`
+	DecisionReason      pgtype.Text               `json:"decision_reason"`
+	DecidedAt           pgtype.Timestamptz        `json:"decided_at"`
+	DecidedBy           pgtype.Int8               `json:"decided_by"`
+	CreatedAt           pgtype.Timestamptz        `json:"created_at"`
+	UpdatedAt           pgtype.Timestamptz        `json:"updated_at"`
+	PropertyTitle       string                    `json:"property_title"`
+	RentAmount          pgtype.Numeric            `json:"rent_amount"`
+	PropertyAddress     string                    `json:"property_address"`
+	LandlordFirstName   string                    `json:"landlord_first_name"`
+	LandlordLastName    string                    `json:"landlord_last_name"`
 }
 
 // Get tenant's rental applications
@@ -640,7 +626,6 @@ func (q *Queries) GetTenantRentalApplications(ctx context.Context, arg GetTenant
 			&i.PropertyID,
 			&i.TenantID,
 			&i.LandlordID,
-			&i.ApplicationDocuments,
 			&i.EmploymentDetails,
 			&i.References,
 			&i.PreferredMoveInDate,
@@ -667,11 +652,23 @@ func (q *Queries) GetTenantRentalApplications(ctx context.Context, arg GetTenant
 	return items, nil
 }
 
+const redactTenantRentalApplications = `-- name: RedactTenantRentalApplications :exec
+UPDATE rental_applications 
+SET employment_details = NULL, "references" = NULL, additional_notes = NULL, updated_at = NOW()
+WHERE tenant_id = $1
+`
+
+// Remove the employment details, references and notes a tenant attached to their applications
+func (q *Queries) RedactTenantRentalApplications(ctx context.Context, tenantID int64) error {
+	_, err := q.db.Exec(ctx, redactTenantRentalApplications, tenantID)
+	return err
+}
+
 const rejectRentalApplication = `-- name: RejectRentalApplication :one
 UPDATE rental_applications 
 SET status = 'rejected', decision_reason = $2, decided_at = NOW(), decided_by = $3, updated_at = NOW()
 WHERE id = $1 
-RETURNING id, property_id, tenant_id, landlord_id, application_documents, employment_details, "references", preferred_move_in_date, additional_notes, status, decision_reason, decided_at, decided_by, created_at, updated_at
+RETURNING id, property_id, tenant_id, landlord_id, employment_details, "references", preferred_move_in_date, additional_notes, status, decision_reason, decided_at, decided_by, created_at, updated_at
 `
 
 type RejectRentalApplicationParams struct {
@@ -689,43 +686,6 @@ func (q *Queries) RejectRentalApplication(ctx context.Context, arg RejectRentalA
 		&i.PropertyID,
 		&i.TenantID,
 		&i.LandlordID,
-		&i.ApplicationDocuments,
-		&i.EmploymentDetails,
-		&i.References,
-		&i.PreferredMoveInDate,
-		&i.AdditionalNotes,
-		&i.Status,
-		&i.DecisionReason,
-		&i.DecidedAt,
-		&i.DecidedBy,
-		&i.CreatedAt,
-		&i.UpdatedAt,
-	)
-	return i, err
-}
-
-const updateApplicationDocuments = `-- name: UpdateApplicationDocuments :one
-UPDATE rental_applications 
-SET application_documents = $2, updated_at = NOW()
-WHERE id = $1 
-RETURNING id, property_id, tenant_id, landlord_id, application_documents, employment_details, "references", preferred_move_in_date, additional_notes, status, decision_reason, decided_at, decided_by, created_at, updated_at
-`
-
-type UpdateApplicationDocumentsParams struct {
-	ID                   int64       `json:"id"`
-	ApplicationDocuments pgtype.Text `json:"application_documents"`
-}
-
-// Update application documents
-func (q *Queries) UpdateApplicationDocuments(ctx context.Context, arg UpdateApplicationDocumentsParams) (RentalApplication, error) {
-	row := q.db.QueryRow(ctx, updateApplicationDocuments, arg.ID, arg.ApplicationDocuments)
-	var i RentalApplication
-	err := row.Scan(
-		&i.ID,
-		&i.PropertyID,
-		&i.TenantID,
-		&i.LandlordID,
-		&i.ApplicationDocuments,
 		&i.EmploymentDetails,
 		&i.References,
 		&i.PreferredMoveInDate,
@@ -744,7 +704,7 @@ const updateApplicationEmploymentDetails = `-- name: UpdateApplicationEmployment
 UPDATE rental_applications 
 SET employment_details = $2, updated_at = NOW()
 WHERE id = $1 
-RETURNING id, property_id, tenant_id, landlord_id, application_documents, employment_details, "references", preferred_move_in_date, additional_notes, status, decision_reason, decided_at, decided_by, created_at, updated_at
+RETURNING id, property_id, tenant_id, landlord_id, employment_details, "references", preferred_move_in_date, additional_notes, status, decision_reason, decided_at, decided_by, created_at, updated_at
 `
 
 type UpdateApplicationEmploymentDetailsParams struct {
@@ -761,7 +721,6 @@ func (q *Queries) UpdateApplicationEmploymentDetails(ctx context.Context, arg Up
 		&i.PropertyID,
 		&i.TenantID,
 		&i.LandlordID,
-		&i.ApplicationDocuments,
 		&i.EmploymentDetails,
 		&i.References,
 		&i.PreferredMoveInDate,
@@ -780,7 +739,7 @@ const updateApplicationReferences = `-- name: UpdateApplicationReferences :one
 UPDATE rental_applications 
 SET "references" = $2, updated_at = NOW()
 WHERE id = $1 
-RETURNING id, property_id, tenant_id, landlord_id, application_documents, employment_details, "references", preferred_move_in_date, additional_notes, status, decision_reason, decided_at, decided_by, created_at, updated_at
+RETURNING id, property_id, tenant_id, landlord_id, employment_details, "references", preferred_move_in_date, additional_notes, status, decision_reason, decided_at, decided_by, created_at, updated_at
 `
 
 type UpdateApplicationReferencesParams struct {
@@ -797,7 +756,6 @@ func (q *Queries) UpdateApplicationReferences(ctx context.Context, arg UpdateApp
 		&i.PropertyID,
 		&i.TenantID,
 		&i.LandlordID,
-		&i.ApplicationDocuments,
 		&i.EmploymentDetails,
 		&i.References,
 		&i.PreferredMoveInDate,
@@ -816,7 +774,7 @@ const updateRentalApplicationStatus = `-- name: UpdateRentalApplicationStatus :o
 UPDATE rental_applications 
 SET status = $2, decision_reason = $3, decided_at = NOW(), decided_by = $4, updated_at = NOW()
 WHERE id = $1 
-RETURNING id, property_id, tenant_id, landlord_id, application_documents, employment_details, "references", preferred_move_in_date, additional_notes, status, decision_reason, decided_at, decided_by, created_at, updated_at
+RETURNING id, property_id, tenant_id, landlord_id, employment_details, "references", preferred_move_in_date, additional_notes, status, decision_reason, decided_at, decided_by, created_at, updated_at
 `
 
 type UpdateRentalApplicationStatusParams struct {
@@ -840,7 +798,6 @@ func (q *Queries) UpdateRentalApplicationStatus(ctx context.Context, arg UpdateR
 		&i.PropertyID,
 		&i.TenantID,
 		&i.LandlordID,
-		&i.ApplicationDocuments,
 		&i.EmploymentDetails,
 		&i.References,
 		&i.PreferredMoveInDate,
@@ -859,7 +816,7 @@ const withdrawRentalApplication = `-- name: WithdrawRentalApplication :one
 UPDATE rental_applications 
 SET status = 'withdrawn', updated_at = NOW()
 WHERE id = $1 
-RETURNING id, property_id, tenant_id, landlord_id, application_documents, employment_details, "references", preferred_move_in_date, additional_notes, status, decision_reason, decided_at, decided_by, created_at, updated_at
+RETURNING id, property_id, tenant_id, landlord_id, employment_details, "references", preferred_move_in_date, additional_notes, status, decision_reason, decided_at, decided_by, created_at, updated_at
 `
 
 // Withdraw rental application
@@ -871,7 +828,6 @@ func (q *Queries) WithdrawRentalApplication(ctx context.Context, id int64) (Rent
 		&i.PropertyID,
 		&i.TenantID,
 		&i.LandlordID,
-		&i.ApplicationDocuments,
 		&i.EmploymentDetails,
 		&i.References,
 		&i.PreferredMoveInDate,
@@ -11,6 +11,25 @@ type Store interface {
 	Querier
 	CreateUserTx(ctx context.Context, arg CreateUserTxParams) (CreateUserTxResult, error)
 	VerifyEmailTx(ctx context.Context, arg VerifyEmailTxParams) (VerifyEmailTxResult, error)
+	RotateSessionTx(ctx context.Context, arg RotateSessionTxParams) (RotateSessionTxResult, error)
+	RevokeSessionFamilyTx(ctx context.Context, arg RevokeSessionFamilyTxParams) (RevokeSessionFamilyTxResult, error)
+	RecordFailedLoginTx(ctx context.Context, arg RecordFailedLoginTxParams) (RecordFailedLoginTxResult, error)
+	RecordSuccessfulLoginTx(ctx context.Context, arg RecordSuccessfulLoginTxParams) (RecordSuccessfulLoginTxResult, error)
+	UnlockAccountTx(ctx context.Context, arg UnlockAccountTxParams) (UnlockAccountTxResult, error)
+	SubmitNINVerificationTx(ctx context.Context, arg SubmitNINVerificationTxParams) (SubmitNINVerificationTxResult, error)
+	CompleteNINVerificationTx(ctx context.Context, arg CompleteNINVerificationTxParams) (CompleteNINVerificationTxResult, error)
+	SetUserActiveStatusTx(ctx context.Context, arg SetUserActiveStatusTxParams) (SetUserActiveStatusTxResult, error)
+	ChangeUserTypeTx(ctx context.Context, arg ChangeUserTypeTxParams) (ChangeUserTypeTxResult, error)
+	ForcePasswordResetTx(ctx context.Context, arg ForcePasswordResetTxParams) (ForcePasswordResetTxResult, error)
+	FederatedLoginTx(ctx context.Context, arg FederatedLoginTxParams) (FederatedLoginTxResult, error)
+	CreateAccountExportTx(ctx context.Context, arg CreateAccountExportTxParams) (CreateAccountExportTxResult, error)
+	ScheduleAccountDeletionTx(ctx context.Context, arg ScheduleAccountDeletionTxParams) (ScheduleAccountDeletionTxResult, error)
+	AnonymizeAccountTx(ctx context.Context, arg AnonymizeAccountTxParams) (AnonymizeAccountTxResult, error)
+	SubmitAgentApplicationTx(ctx context.Context, arg SubmitAgentApplicationTxParams) (SubmitAgentApplicationTxResult, error)
+	ReviewAgentApplicationTx(ctx context.Context, arg ReviewAgentApplicationTxParams) (ReviewAgentApplicationTxResult, error)
+	SetAgentAvailabilityTx(ctx context.Context, arg SetAgentAvailabilityTxParams) (SetAgentAvailabilityTxResult, error)
+	ShareApplicationDocumentsTx(ctx context.Context, arg ShareApplicationDocumentsTxParams) (ShareApplicationDocumentsTxResult, error)
+	ChangePropertyStatusTx(ctx context.Context, arg ChangePropertyStatusTxParams) (ChangePropertyStatusTxResult, error)
 }
 
 // SQLStore provides all functions to execute SQL queries and transactions
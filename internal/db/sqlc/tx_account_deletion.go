@@ -0,0 +1,161 @@
+package db
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/rs/zerolog/log"
+)
+
+var (
+	// ErrDeletionNotPending is returned for deletions that were already carried out or cancelled
+	ErrDeletionNotPending = errors.New("account deletion is not pending")
+	// ErrDeletionNotDue is returned when the grace period of a deletion has not ended yet
+	ErrDeletionNotDue = errors.New("account deletion is not due yet")
+)
+
+type ScheduleAccountDeletionTxParams struct {
+	UserID       int64
+	Reason       pgtype.Text
+	ScheduledFor time.Time
+	AfterCreate  func(deletion AccountDeletion) error
+}
+
+type ScheduleAccountDeletionTxResult struct {
+	Deletion AccountDeletion
+}
+
+// ScheduleAccountDeletionTx records a deletion that is carried out once its grace period ends
+func (store *SQLStore) ScheduleAccountDeletionTx(ctx context.Context, arg ScheduleAccountDeletionTxParams) (ScheduleAccountDeletionTxResult, error) {
+	var result ScheduleAccountDeletionTxResult
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		var err error
+
+		result.Deletion, err = q.CreateAccountDeletion(ctx, CreateAccountDeletionParams{
+			UserID:       arg.UserID,
+			Reason:       arg.Reason,
+			ScheduledFor: arg.ScheduledFor,
+		})
+		if err != nil {
+			log.Error().Err(err).Int64("user_id", arg.UserID).Msg("failed to create account deletion")
+			return err
+		}
+
+		return arg.AfterCreate(result.Deletion)
+	})
+
+	return result, err
+}
+
+type AnonymizeAccountTxParams struct {
+	DeletionID int64
+	// PasswordHash replaces the password so the account can never be signed in to again
+	PasswordHash string
+}
+
+type AnonymizeAccountTxResult struct {
+	// User is the account as it was before its personal data was replaced
+	User     User
+	Deletion AccountDeletion
+	AuditLog AuditLog
+	// DocumentStorageKeys locate the content of the deleted documents, which is kept outside the database
+	DocumentStorageKeys []string
+}
+
+// AnonymizeAccountTx carries out a deletion whose grace period has ended. Personal data is erased or
+// replaced, while the rows that payments, agreements and the audit log point at are kept for bookkeeping.
+func (store *SQLStore) AnonymizeAccountTx(ctx context.Context, arg AnonymizeAccountTxParams) (AnonymizeAccountTxResult, error) {
+	var result AnonymizeAccountTxResult
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		deletion, err := q.GetAccountDeletion(ctx, arg.DeletionID)
+		if err != nil {
+			return err
+		}
+		if deletion.Status != AccountRequestStatusEnumPending {
+			return ErrDeletionNotPending
+		}
+		if time.Now().Before(deletion.ScheduledFor) {
+			return ErrDeletionNotDue
+		}
+
+		userID := deletion.UserID
+		result.User, err = q.GetUserByID(ctx, userID)
+		if err != nil {
+			return err
+		}
+
+		// Email and phone are unique, so they get placeholders that can't collide or receive anything
+		err = q.AnonymizeUser(ctx, AnonymizeUserParams{
+			ID:           userID,
+			Email:        fmt.Sprintf("deleted-%d@deleted.sqr.invalid", userID),
+			Phone:        fmt.Sprintf("deleted-%d", userID),
+			PasswordHash: arg.PasswordHash,
+		})
+		if err != nil {
+			log.Error().Err(err).Int64("user_id", userID).Msg("failed to anonymize user")
+			return err
+		}
+
+		erasures := []struct {
+			name string
+			run  func(ctx context.Context, userID int64) error
+		}{
+			{"tenant profile", q.DeleteTenantProfile},
+			{"landlord profile", q.AnonymizeLandlordProfile},
+			{"inspection agent profile", q.AnonymizeInspectionAgentProfile},
+			{"verifications", q.DeleteUserVerificationsByUserID},
+			{"identities", q.DeleteUserIdentitiesByUserID},
+			{"sessions", q.DeleteAllUserSessions},
+			{"notifications", q.DeleteAllUserNotifications},
+			{"saved properties", q.DeleteAllUserSavedProperties},
+			{"chatbot conversations", q.DeleteUserChatbotConversations},
+			{"messages", q.RedactMessagesBySender},
+			{"rental applications", q.RedactTenantRentalApplications},
+			{"properties", q.DeactivateLandlordProperties},
+		}
+		for _, erasure := range erasures {
+			err = erasure.run(ctx, userID)
+			if err != nil {
+				log.Error().Err(err).Int64("user_id", userID).Msgf("failed to erase %s", erasure.name)
+				return err
+			}
+		}
+
+		// Their access grants go with them
+		result.DocumentStorageKeys, err = q.DeleteTenantDocumentsByOwner(ctx, userID)
+		if err != nil {
+			log.Error().Err(err).Int64("user_id", userID).Msg("failed to erase documents")
+			return err
+		}
+
+		result.Deletion, err = q.CompleteAccountDeletion(ctx, deletion.ID)
+		if err != nil {
+			return err
+		}
+
+		newValues, err := json.Marshal(map[string]interface{}{"deletion_id": deletion.ID, "anonymized": true})
+		if err != nil {
+			return err
+		}
+
+		result.AuditLog, err = q.CreateAuditLog(ctx, CreateAuditLogParams{
+			UserID:     pgtype.Int8{Int64: userID, Valid: true},
+			Action:     AuditActionEnumDelete,
+			EntityType: "user",
+			EntityID:   pgtype.Int8{Int64: userID, Valid: true},
+			NewValues:  pgtype.Text{String: string(newValues), Valid: true},
+		})
+		if err != nil {
+			log.Error().Err(err).Int64("user_id", userID).Msg("failed to create audit log")
+		}
+		return err
+	})
+
+	return result, err
+}
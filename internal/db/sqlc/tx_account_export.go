@@ -0,0 +1,35 @@
+package db
+
+import (
+	"context"
+
+	"github.com/rs/zerolog/log"
+)
+
+type CreateAccountExportTxParams struct {
+	UserID      int64
+	AfterCreate func(export AccountExport) error
+}
+
+type CreateAccountExportTxResult struct {
+	Export AccountExport
+}
+
+// CreateAccountExportTx records a pending export and hands it to the worker through AfterCreate
+func (store *SQLStore) CreateAccountExportTx(ctx context.Context, arg CreateAccountExportTxParams) (CreateAccountExportTxResult, error) {
+	var result CreateAccountExportTxResult
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		var err error
+
+		result.Export, err = q.CreateAccountExport(ctx, arg.UserID)
+		if err != nil {
+			log.Error().Err(err).Int64("user_id", arg.UserID).Msg("failed to create account export")
+			return err
+		}
+
+		return arg.AfterCreate(result.Export)
+	})
+
+	return result, err
+}
@@ -0,0 +1,203 @@
+package db
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/rs/zerolog/log"
+)
+
+// AdminActor identifies the admin behind a change, for the audit log
+type AdminActor struct {
+	AdminID   int64
+	IpAddress pgtype.Text
+	UserAgent pgtype.Text
+}
+
+type SetUserActiveStatusTxParams struct {
+	AdminActor
+	UserID   int64
+	IsActive bool
+}
+
+type SetUserActiveStatusTxResult struct {
+	User     User
+	AuditLog AuditLog
+}
+
+// SetUserActiveStatusTx activates or deactivates an account on behalf of an admin
+func (store *SQLStore) SetUserActiveStatusTx(ctx context.Context, arg SetUserActiveStatusTxParams) (SetUserActiveStatusTxResult, error) {
+	var result SetUserActiveStatusTxResult
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		oldUser, err := q.GetUserByID(ctx, arg.UserID)
+		if err != nil {
+			return err
+		}
+
+		err = q.UpdateUserActiveStatus(ctx, UpdateUserActiveStatusParams{
+			ID:       arg.UserID,
+			IsActive: pgtype.Bool{Bool: arg.IsActive, Valid: true},
+		})
+		if err != nil {
+			log.Error().Err(err).Int64("user_id", arg.UserID).Msg("failed to update user active status")
+			return err
+		}
+
+		result.User, err = q.GetUserByID(ctx, arg.UserID)
+		if err != nil {
+			return err
+		}
+
+		result.AuditLog, err = createUserAuditLog(ctx, q, arg.AdminActor, arg.UserID,
+			map[string]interface{}{"is_active": oldUser.IsActive.Bool},
+			map[string]interface{}{"is_active": result.User.IsActive.Bool},
+		)
+		return err
+	})
+
+	return result, err
+}
+
+type ChangeUserTypeTxParams struct {
+	AdminActor
+	UserID   int64
+	UserType UserTypeEnum
+}
+
+type ChangeUserTypeTxResult struct {
+	User     User
+	AuditLog AuditLog
+}
+
+// ChangeUserTypeTx changes the role of an account on behalf of an admin, creating the profile of the new role
+func (store *SQLStore) ChangeUserTypeTx(ctx context.Context, arg ChangeUserTypeTxParams) (ChangeUserTypeTxResult, error) {
+	var result ChangeUserTypeTxResult
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		oldUser, err := q.GetUserByID(ctx, arg.UserID)
+		if err != nil {
+			return err
+		}
+
+		err = q.UpdateUserType(ctx, UpdateUserTypeParams{
+			ID:       arg.UserID,
+			UserType: arg.UserType,
+		})
+		if err != nil {
+			log.Error().Err(err).Int64("user_id", arg.UserID).Msg("failed to update user type")
+			return err
+		}
+
+		result.User, err = q.GetUserByID(ctx, arg.UserID)
+		if err != nil {
+			return err
+		}
+
+		err = createRoleProfile(ctx, q, result.User)
+		if err != nil {
+			return err
+		}
+
+		result.AuditLog, err = createUserAuditLog(ctx, q, arg.AdminActor, arg.UserID,
+			map[string]interface{}{"user_type": oldUser.UserType},
+			map[string]interface{}{"user_type": result.User.UserType},
+		)
+		return err
+	})
+
+	return result, err
+}
+
+type ForcePasswordResetTxParams struct {
+	AdminActor
+	UserID int64
+	// PasswordHash replaces the current password so it can no longer be used to sign in
+	PasswordHash     string
+	VerificationData []byte
+	AfterCreate      func(user User) error
+}
+
+type ForcePasswordResetTxResult struct {
+	User         User
+	Verification UserVerification
+	AuditLog     AuditLog
+}
+
+// ForcePasswordResetTx invalidates the password of an account and opens a password reset, on behalf of an admin
+func (store *SQLStore) ForcePasswordResetTx(ctx context.Context, arg ForcePasswordResetTxParams) (ForcePasswordResetTxResult, error) {
+	var result ForcePasswordResetTxResult
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		var err error
+
+		result.User, err = q.GetUserByID(ctx, arg.UserID)
+		if err != nil {
+			return err
+		}
+
+		err = q.UpdateUserPassword(ctx, UpdateUserPasswordParams{
+			ID:           arg.UserID,
+			PasswordHash: arg.PasswordHash,
+		})
+		if err != nil {
+			log.Error().Err(err).Int64("user_id", arg.UserID).Msg("failed to replace user password")
+			return err
+		}
+
+		result.Verification, err = q.CreateUserVerification(ctx, CreateUserVerificationParams{
+			UserID:             arg.UserID,
+			VerificationType:   VerificationTypeEnumPasswordReset,
+			VerificationStatus: NullVerificationStatusEnum{VerificationStatusEnum: VerificationStatusEnumPending, Valid: true},
+			VerificationData:   arg.VerificationData,
+		})
+		if err != nil {
+			log.Error().Err(err).Int64("user_id", arg.UserID).Msg("failed to create password reset verification")
+			return err
+		}
+
+		// The hashes themselves never go into the audit log
+		result.AuditLog, err = createUserAuditLog(ctx, q, arg.AdminActor, arg.UserID,
+			map[string]interface{}{"password_reset_required": false},
+			map[string]interface{}{"password_reset_required": true},
+		)
+		if err != nil {
+			return err
+		}
+
+		return arg.AfterCreate(result.User)
+	})
+
+	return result, err
+}
+
+// createUserAuditLog records an admin change to a user with the values before and after it
+func createUserAuditLog(ctx context.Context, q *Queries, actor AdminActor, userID int64, oldValues, newValues interface{}) (AuditLog, error) {
+	oldJSON, err := json.Marshal(oldValues)
+	if err != nil {
+		return AuditLog{}, err
+	}
+
+	newJSON, err := json.Marshal(newValues)
+	if err != nil {
+		return AuditLog{}, err
+	}
+
+	auditLog, err := q.CreateAuditLog(ctx, CreateAuditLogParams{
+		UserID:     pgtype.Int8{Int64: actor.AdminID, Valid: true},
+		Action:     AuditActionEnumUpdate,
+		EntityType: "user",
+		EntityID:   pgtype.Int8{Int64: userID, Valid: true},
+		OldValues:  pgtype.Text{String: string(oldJSON), Valid: true},
+		NewValues:  pgtype.Text{String: string(newJSON), Valid: true},
+		IpAddress:  actor.IpAddress,
+		UserAgent:  actor.UserAgent,
+	})
+	if err != nil {
+		log.Error().Err(err).Int64("user_id", userID).Msg("failed to create audit log")
+		return AuditLog{}, err
+	}
+
+	return auditLog, nil
+}
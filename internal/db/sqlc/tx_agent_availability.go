@@ -0,0 +1,62 @@
+package db
+
+import (
+	"context"
+
+	"github.com/rs/zerolog/log"
+)
+
+type SetAgentAvailabilityTxParams struct {
+	AgentID int64
+	// Windows and Exceptions replace the whole calendar of the agent; their AgentID is ignored
+	Windows    []CreateAgentAvailabilityWindowParams
+	Exceptions []CreateAgentAvailabilityExceptionParams
+}
+
+type SetAgentAvailabilityTxResult struct {
+	Windows    []AgentAvailabilityWindow
+	Exceptions []AgentAvailabilityException
+}
+
+// SetAgentAvailabilityTx replaces the weekly windows and exceptions of an agent in one go
+func (store *SQLStore) SetAgentAvailabilityTx(ctx context.Context, arg SetAgentAvailabilityTxParams) (SetAgentAvailabilityTxResult, error) {
+	var result SetAgentAvailabilityTxResult
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		err := q.DeleteAgentAvailabilityWindows(ctx, arg.AgentID)
+		if err != nil {
+			return err
+		}
+
+		err = q.DeleteAgentAvailabilityExceptions(ctx, arg.AgentID)
+		if err != nil {
+			return err
+		}
+
+		result.Windows = make([]AgentAvailabilityWindow, 0, len(arg.Windows))
+		for _, windowArg := range arg.Windows {
+			windowArg.AgentID = arg.AgentID
+			window, err := q.CreateAgentAvailabilityWindow(ctx, windowArg)
+			if err != nil {
+				log.Error().Err(err).Int64("agent_id", arg.AgentID).Msg("failed to create availability window")
+				return err
+			}
+			result.Windows = append(result.Windows, window)
+		}
+
+		result.Exceptions = make([]AgentAvailabilityException, 0, len(arg.Exceptions))
+		for _, exceptionArg := range arg.Exceptions {
+			exceptionArg.AgentID = arg.AgentID
+			exception, err := q.CreateAgentAvailabilityException(ctx, exceptionArg)
+			if err != nil {
+				log.Error().Err(err).Int64("agent_id", arg.AgentID).Msg("failed to create availability exception")
+				return err
+			}
+			result.Exceptions = append(result.Exceptions, exception)
+		}
+
+		return nil
+	})
+
+	return result, err
+}
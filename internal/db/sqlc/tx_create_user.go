@@ -2,6 +2,7 @@ package db
 
 import (
 	"context"
+	"errors"
 
 	"github.com/rs/zerolog/log"
 )
@@ -27,8 +28,31 @@ func (store *SQLStore) CreateUserTx(ctx context.Context, arg CreateUserTxParams)
 			return err
 		}
 
+		err = createRoleProfile(ctx, q, result.User)
+		if err != nil {
+			return err
+		}
+
 		return arg.AfterCreate(result.User)
 	})
 
 	return result, err
 }
+
+// createRoleProfile gives an account the profile its user type needs, so the profile
+// RPCs can always update it instead of asking the user to create it first.
+// A profile the account already filled in is kept.
+func createRoleProfile(ctx context.Context, q *Queries, user User) error {
+	if user.UserType != UserTypeEnumLandlord {
+		return nil
+	}
+
+	_, err := q.CreateLandlordProfile(ctx, CreateLandlordProfileParams{UserID: user.ID})
+	if errors.Is(err, ErrRecordNotFound) {
+		return nil
+	}
+	if err != nil {
+		log.Error().Err(err).Int64("user_id", user.ID).Msg("failed to create landlord profile")
+	}
+	return err
+}
@@ -0,0 +1,196 @@
+package db
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/rs/zerolog/log"
+)
+
+// ErrIdentityEmailNotVerified is returned when an unknown identity can't be matched to an account
+// because the provider has not verified its email
+var ErrIdentityEmailNotVerified = errors.New("identity email is not verified by the provider")
+
+type FederatedLoginTxParams struct {
+	Provider      string
+	Subject       string
+	Email         string
+	EmailVerified bool
+	// NewUser creates the account when neither the identity nor its email is known.
+	// When it is nil such a sign-in fails with ErrRecordNotFound.
+	NewUser *CreateUserParams
+	// PasswordHash replaces the password of an existing account whose email was never verified
+	// when the identity gets linked to it, so whoever registered the address first can't sign in any more
+	PasswordHash string
+	AfterCreate  func(user User) error
+}
+
+type FederatedLoginTxResult struct {
+	User     User
+	Identity UserIdentity
+	// Created is set when the sign-in created the account, Linked when it linked the identity to one
+	Created bool
+	Linked  bool
+	// PasswordReplaced is set when the existing password of the linked account was discarded
+	PasswordReplaced bool
+}
+
+// FederatedLoginTx finds the user behind an identity asserted by an OpenID Connect provider.
+// Unknown identities are linked to the account with the same verified email, or to a new account.
+func (store *SQLStore) FederatedLoginTx(ctx context.Context, arg FederatedLoginTxParams) (FederatedLoginTxResult, error) {
+	var result FederatedLoginTxResult
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		identity, err := q.GetUserIdentity(ctx, GetUserIdentityParams{
+			Provider: arg.Provider,
+			Subject:  arg.Subject,
+		})
+		if err == nil {
+			result.Identity, err = q.TouchUserIdentity(ctx, TouchUserIdentityParams{
+				ID:    identity.ID,
+				Email: arg.Email,
+			})
+			if err != nil {
+				return err
+			}
+
+			result.User, err = q.GetUserByID(ctx, identity.UserID)
+			return err
+		}
+		if !errors.Is(err, ErrRecordNotFound) {
+			return err
+		}
+
+		// Matching by email is only safe when the provider vouches for the address
+		if !arg.EmailVerified {
+			return ErrIdentityEmailNotVerified
+		}
+
+		user, err := q.GetUserByEmail(ctx, arg.Email)
+		switch {
+		case err == nil:
+			verified, err := isEmailVerified(ctx, q, user.ID)
+			if err != nil {
+				return err
+			}
+
+			if !verified {
+				err = q.UpdateUserPassword(ctx, UpdateUserPasswordParams{
+					ID:           user.ID,
+					PasswordHash: arg.PasswordHash,
+				})
+				if err != nil {
+					log.Error().Err(err).Int64("user_id", user.ID).Msg("failed to replace user password")
+					return err
+				}
+				result.PasswordReplaced = true
+
+				err = markEmailVerified(ctx, q, user.ID, arg.Provider)
+				if err != nil {
+					return err
+				}
+			}
+		case errors.Is(err, ErrRecordNotFound):
+			if arg.NewUser == nil {
+				return ErrRecordNotFound
+			}
+
+			user, err = q.CreateUser(ctx, *arg.NewUser)
+			if err != nil {
+				log.Error().Err(err).Msg("failed to create user")
+				return err
+			}
+			result.Created = true
+
+			err = createRoleProfile(ctx, q, user)
+			if err != nil {
+				return err
+			}
+
+			err = markEmailVerified(ctx, q, user.ID, arg.Provider)
+			if err != nil {
+				return err
+			}
+		default:
+			return err
+		}
+
+		result.Identity, err = q.CreateUserIdentity(ctx, CreateUserIdentityParams{
+			UserID:   user.ID,
+			Provider: arg.Provider,
+			Subject:  arg.Subject,
+			Email:    arg.Email,
+		})
+		if err != nil {
+			log.Error().Err(err).Int64("user_id", user.ID).Str("provider", arg.Provider).Msg("failed to link identity")
+			return err
+		}
+		result.Linked = true
+
+		result.User, err = q.GetUserByID(ctx, user.ID)
+		if err != nil {
+			return err
+		}
+
+		if result.Created && arg.AfterCreate != nil {
+			return arg.AfterCreate(result.User)
+		}
+		return nil
+	})
+
+	return result, err
+}
+
+func isEmailVerified(ctx context.Context, q *Queries, userID int64) (bool, error) {
+	verification, err := q.GetUserVerificationByType(ctx, GetUserVerificationByTypeParams{
+		UserID:           userID,
+		VerificationType: VerificationTypeEnumEmail,
+	})
+	if err != nil {
+		if errors.Is(err, ErrRecordNotFound) {
+			return false, nil
+		}
+		return false, err
+	}
+
+	return verification.VerificationStatus.Valid &&
+		verification.VerificationStatus.VerificationStatusEnum == VerificationStatusEnumVerified, nil
+}
+
+// markEmailVerified records the email of the user as verified by an identity provider,
+// the same way VerifyEmailTx does for the link we send by email
+func markEmailVerified(ctx context.Context, q *Queries, userID int64, provider string) error {
+	data, err := json.Marshal(map[string]string{"provider": provider})
+	if err != nil {
+		return err
+	}
+
+	verification, err := q.CreateUserVerification(ctx, CreateUserVerificationParams{
+		UserID:           userID,
+		VerificationType: VerificationTypeEnumEmail,
+		VerificationData: data,
+	})
+	if err != nil {
+		log.Error().Err(err).Int64("user_id", userID).Msg("failed to create email verification")
+		return err
+	}
+
+	_, err = q.UpdateVerificationStatus(ctx, UpdateVerificationStatusParams{
+		ID: verification.ID,
+		VerificationStatus: NullVerificationStatusEnum{
+			VerificationStatusEnum: VerificationStatusEnumVerified,
+			Valid:                  true,
+		},
+	})
+	if err != nil {
+		log.Error().Err(err).Int64("verification_id", verification.ID).Msg("failed to update verification status")
+		return err
+	}
+
+	return q.UpdateUserVerificationStatus(ctx, UpdateUserVerificationStatusParams{
+		ID:         userID,
+		IsVerified: pgtype.Bool{Bool: true, Valid: true},
+	})
+}
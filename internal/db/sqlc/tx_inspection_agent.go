@@ -0,0 +1,170 @@
+package db
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/rs/zerolog/log"
+)
+
+var (
+	// ErrAgentAlreadyApproved is returned when an approved agent submits an application again
+	ErrAgentAlreadyApproved = errors.New("inspection agent is already approved")
+	// ErrApplicationNotPending is returned when reviewing an application that was never submitted or already decided
+	ErrApplicationNotPending = errors.New("agent application is not pending review")
+)
+
+type SubmitAgentApplicationTxParams struct {
+	SubmitInspectionAgentApplicationParams
+	// LicenseData is stored on the license verification the admins review, with the license documents
+	LicenseData []byte
+}
+
+type SubmitAgentApplicationTxResult struct {
+	Profile      InspectionAgentProfile
+	Verification UserVerification
+}
+
+// SubmitAgentApplicationTx puts the application of an agent, new or previously rejected, in the review queue
+func (store *SQLStore) SubmitAgentApplicationTx(ctx context.Context, arg SubmitAgentApplicationTxParams) (SubmitAgentApplicationTxResult, error) {
+	var result SubmitAgentApplicationTxResult
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		profile, err := q.GetInspectionAgentProfileByUserID(ctx, arg.UserID)
+		if err != nil && !errors.Is(err, ErrRecordNotFound) {
+			return err
+		}
+		if err == nil && profile.IsApproved.Bool {
+			return ErrAgentAlreadyApproved
+		}
+
+		result.Profile, err = q.SubmitInspectionAgentApplication(ctx, arg.SubmitInspectionAgentApplicationParams)
+		if err != nil {
+			log.Error().Err(err).Int64("user_id", arg.UserID).Msg("failed to submit agent application")
+			return err
+		}
+
+		result.Verification, err = q.CreateUserVerification(ctx, CreateUserVerificationParams{
+			UserID:             arg.UserID,
+			VerificationType:   VerificationTypeEnumLicense,
+			VerificationStatus: NullVerificationStatusEnum{VerificationStatusEnum: VerificationStatusEnumPending, Valid: true},
+			VerificationData:   arg.LicenseData,
+		})
+		if err != nil {
+			log.Error().Err(err).Int64("user_id", arg.UserID).Msg("failed to create license verification")
+		}
+		return err
+	})
+
+	return result, err
+}
+
+type ReviewAgentApplicationTxParams struct {
+	AdminActor
+	AgentID int64
+	Approve bool
+	// RejectionReason is shown to the agent when the application is rejected
+	RejectionReason pgtype.Text
+	AfterReview     func(profile InspectionAgentProfile) error
+}
+
+type ReviewAgentApplicationTxResult struct {
+	Profile  InspectionAgentProfile
+	AuditLog AuditLog
+}
+
+// ReviewAgentApplicationTx approves or rejects a pending agent application on behalf of an admin,
+// together with the license verification that came with it
+func (store *SQLStore) ReviewAgentApplicationTx(ctx context.Context, arg ReviewAgentApplicationTxParams) (ReviewAgentApplicationTxResult, error) {
+	var result ReviewAgentApplicationTxResult
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		profile, err := q.GetInspectionAgentProfileByUserID(ctx, arg.AgentID)
+		if err != nil {
+			return err
+		}
+		if !profile.SubmittedAt.Valid || profile.IsApproved.Bool || profile.RejectedAt.Valid {
+			return ErrApplicationNotPending
+		}
+
+		verificationStatus := VerificationStatusEnumVerified
+		if arg.Approve {
+			result.Profile, err = q.ApproveInspectionAgent(ctx, ApproveInspectionAgentParams{
+				UserID:     arg.AgentID,
+				ApprovedBy: pgtype.Int8{Int64: arg.AdminID, Valid: true},
+			})
+		} else {
+			verificationStatus = VerificationStatusEnumRejected
+			result.Profile, err = q.RejectInspectionAgent(ctx, RejectInspectionAgentParams{
+				UserID:          arg.AgentID,
+				RejectionReason: arg.RejectionReason,
+			})
+		}
+		if err != nil {
+			log.Error().Err(err).Int64("user_id", arg.AgentID).Msg("failed to review agent application")
+			return err
+		}
+
+		verification, err := q.GetUserVerificationByType(ctx, GetUserVerificationByTypeParams{
+			UserID:           arg.AgentID,
+			VerificationType: VerificationTypeEnumLicense,
+		})
+		switch {
+		case err == nil:
+			_, err = q.UpdateVerificationStatus(ctx, UpdateVerificationStatusParams{
+				ID:                 verification.ID,
+				VerificationStatus: NullVerificationStatusEnum{VerificationStatusEnum: verificationStatus, Valid: true},
+				VerifiedBy:         pgtype.Int8{Int64: arg.AdminID, Valid: true},
+			})
+			if err != nil {
+				log.Error().Err(err).Int64("verification_id", verification.ID).Msg("failed to update license verification")
+				return err
+			}
+		case !errors.Is(err, ErrRecordNotFound):
+			return err
+		}
+
+		result.AuditLog, err = createAgentApplicationAuditLog(ctx, q, arg.AdminActor, profile, result.Profile)
+		if err != nil {
+			return err
+		}
+
+		return arg.AfterReview(result.Profile)
+	})
+
+	return result, err
+}
+
+func createAgentApplicationAuditLog(ctx context.Context, q *Queries, actor AdminActor, oldProfile, newProfile InspectionAgentProfile) (AuditLog, error) {
+	oldJSON, err := json.Marshal(map[string]interface{}{"is_approved": oldProfile.IsApproved.Bool})
+	if err != nil {
+		return AuditLog{}, err
+	}
+
+	newJSON, err := json.Marshal(map[string]interface{}{
+		"is_approved":      newProfile.IsApproved.Bool,
+		"rejection_reason": newProfile.RejectionReason.String,
+	})
+	if err != nil {
+		return AuditLog{}, err
+	}
+
+	auditLog, err := q.CreateAuditLog(ctx, CreateAuditLogParams{
+		UserID:     pgtype.Int8{Int64: actor.AdminID, Valid: true},
+		Action:     AuditActionEnumVerification,
+		EntityType: "inspection_agent_profile",
+		EntityID:   pgtype.Int8{Int64: newProfile.ID, Valid: true},
+		OldValues:  pgtype.Text{String: string(oldJSON), Valid: true},
+		NewValues:  pgtype.Text{String: string(newJSON), Valid: true},
+		IpAddress:  actor.IpAddress,
+		UserAgent:  actor.UserAgent,
+	})
+	if err != nil {
+		log.Error().Err(err).Int64("user_id", newProfile.UserID).Msg("failed to create audit log")
+		return AuditLog{}, err
+	}
+
+	return auditLog, nil
+}
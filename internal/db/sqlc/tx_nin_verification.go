@@ -0,0 +1,139 @@
+package db
+
+import (
+	"context"
+	"errors"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/rs/zerolog/log"
+)
+
+var ErrVerificationNotPending = errors.New("verification is not pending")
+
+type SubmitNINVerificationTxParams struct {
+	UserID int64
+	// EncryptedNIN is stored on the user as is, it must never be the plain number
+	EncryptedNIN     string
+	VerificationData []byte
+	AfterCreate      func(verification UserVerification) error
+}
+
+type SubmitNINVerificationTxResult struct {
+	Verification UserVerification
+}
+
+// SubmitNINVerificationTx stores the (encrypted) NIN on the user and opens a pending nin verification
+func (store *SQLStore) SubmitNINVerificationTx(ctx context.Context, arg SubmitNINVerificationTxParams) (SubmitNINVerificationTxResult, error) {
+	var result SubmitNINVerificationTxResult
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		var err error
+
+		err = q.UpdateUserNIN(ctx, UpdateUserNINParams{
+			ID:  arg.UserID,
+			Nin: pgtype.Text{String: arg.EncryptedNIN, Valid: true},
+		})
+		if err != nil {
+			log.Error().Err(err).Int64("user_id", arg.UserID).Msg("failed to update user nin")
+			return err
+		}
+
+		result.Verification, err = q.CreateUserVerification(ctx, CreateUserVerificationParams{
+			UserID:             arg.UserID,
+			VerificationType:   VerificationTypeEnumNin,
+			VerificationStatus: NullVerificationStatusEnum{VerificationStatusEnum: VerificationStatusEnumPending, Valid: true},
+			VerificationData:   arg.VerificationData,
+		})
+		if err != nil {
+			log.Error().Err(err).Int64("user_id", arg.UserID).Msg("failed to create nin verification")
+			return err
+		}
+
+		return arg.AfterCreate(result.Verification)
+	})
+
+	return result, err
+}
+
+type CompleteNINVerificationTxParams struct {
+	VerificationID   int64
+	Verified         bool
+	VerificationData []byte
+}
+
+type CompleteNINVerificationTxResult struct {
+	User         User
+	Verification UserVerification
+}
+
+// CompleteNINVerificationTx records the provider's answer on a pending nin verification.
+// A verified NIN marks the identity of the user as verified; users.is_verified is the email flag and is left alone.
+func (store *SQLStore) CompleteNINVerificationTx(ctx context.Context, arg CompleteNINVerificationTxParams) (CompleteNINVerificationTxResult, error) {
+	var result CompleteNINVerificationTxResult
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		var err error
+
+		verification, err := q.GetUserVerificationByID(ctx, arg.VerificationID)
+		if err != nil {
+			log.Error().Err(err).Int64("verification_id", arg.VerificationID).Msg("failed to get nin verification")
+			return err
+		}
+
+		if verification.VerificationType != VerificationTypeEnumNin {
+			return errors.New("verification record is not for nin")
+		}
+		if verification.VerificationStatus.VerificationStatusEnum != VerificationStatusEnumPending {
+			return ErrVerificationNotPending
+		}
+
+		_, err = q.UpdateVerificationData(ctx, UpdateVerificationDataParams{
+			ID:               verification.ID,
+			VerificationData: arg.VerificationData,
+		})
+		if err != nil {
+			log.Error().Err(err).Int64("verification_id", verification.ID).Msg("failed to update nin verification data")
+			return err
+		}
+
+		verificationStatus := VerificationStatusEnumRejected
+		if arg.Verified {
+			verificationStatus = VerificationStatusEnumVerified
+		}
+
+		result.Verification, err = q.UpdateVerificationStatus(ctx, UpdateVerificationStatusParams{
+			ID: verification.ID,
+			VerificationStatus: NullVerificationStatusEnum{
+				VerificationStatusEnum: verificationStatus,
+				Valid:                  true,
+			},
+			VerifiedBy: pgtype.Int8{Valid: false}, // Verified by the identity provider, not a person
+		})
+		if err != nil {
+			log.Error().Err(err).Int64("verification_id", verification.ID).Msg("failed to update nin verification status")
+			return err
+		}
+
+		// Submitting the NIN cleared the flag, so only a match has to set it
+		if arg.Verified {
+			err = q.UpdateUserIdentityVerified(ctx, UpdateUserIdentityVerifiedParams{
+				ID:                 verification.UserID,
+				IsIdentityVerified: true,
+			})
+			if err != nil {
+				log.Error().Err(err).Int64("user_id", verification.UserID).Msg("failed to update user identity verification")
+				return err
+			}
+		}
+
+		result.User, err = q.GetUserByID(ctx, verification.UserID)
+		if err != nil {
+			log.Error().Err(err).Int64("user_id", verification.UserID).Msg("failed to get user")
+			return err
+		}
+
+		return nil
+	})
+
+	return result, err
+}
@@ -0,0 +1,119 @@
+package db
+
+import (
+	"context"
+	"errors"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/rs/zerolog/log"
+)
+
+// ErrPropertyStatusTransition is returned when a property can't move from its current status to the requested one
+var ErrPropertyStatusTransition = errors.New("property cannot move to that status")
+
+// propertyTransitions lists the statuses each status of a property can move to. Archived (inactive)
+// listings are final, a landlord lists the property again as a new draft.
+var propertyTransitions = map[PropertyStatusEnum][]PropertyStatusEnum{
+	PropertyStatusEnumDraft:    {PropertyStatusEnumActive, PropertyStatusEnumInactive},
+	PropertyStatusEnumActive:   {PropertyStatusEnumDraft, PropertyStatusEnumRented, PropertyStatusEnumInactive},
+	PropertyStatusEnumRented:   {PropertyStatusEnumActive, PropertyStatusEnumInactive},
+	PropertyStatusEnumInactive: {},
+}
+
+// PropertyStatus returns the status of a property, which is a draft until it is first published
+func PropertyStatus(property Property) PropertyStatusEnum {
+	if !property.Status.Valid {
+		return PropertyStatusEnumDraft
+	}
+	return property.Status.PropertyStatusEnum
+}
+
+// CanChangePropertyStatus reports whether a property with status from can move to status to
+func CanChangePropertyStatus(from, to PropertyStatusEnum) bool {
+	for _, status := range propertyTransitions[from] {
+		if status == to {
+			return true
+		}
+	}
+	return false
+}
+
+// propertyIsListed reports whether a property counts towards the total_properties of its landlord,
+// which are the properties that were published and not taken down
+func propertyIsListed(status PropertyStatusEnum) bool {
+	return status == PropertyStatusEnumActive || status == PropertyStatusEnumRented
+}
+
+type ChangePropertyStatusTxParams struct {
+	PropertyID int64
+	Status     PropertyStatusEnum
+	// BeforeChange can refuse the change once the property is locked, such as when it isn't ready to be published
+	BeforeChange func(property Property) error
+}
+
+type ChangePropertyStatusTxResult struct {
+	Property Property
+	// OldStatus is the status the property had before the change
+	OldStatus PropertyStatusEnum
+}
+
+// ChangePropertyStatusTx moves a property through its listing lifecycle, keeping its availability and the
+// property count of its landlord in line with the new status
+func (store *SQLStore) ChangePropertyStatusTx(ctx context.Context, arg ChangePropertyStatusTxParams) (ChangePropertyStatusTxResult, error) {
+	var result ChangePropertyStatusTxResult
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		property, err := q.GetPropertyForUpdate(ctx, arg.PropertyID)
+		if err != nil {
+			return err
+		}
+
+		result.OldStatus = PropertyStatus(property)
+		if !CanChangePropertyStatus(result.OldStatus, arg.Status) {
+			return ErrPropertyStatusTransition
+		}
+
+		if arg.BeforeChange != nil {
+			err = arg.BeforeChange(property)
+			if err != nil {
+				return err
+			}
+		}
+
+		result.Property, err = q.UpdatePropertyStatus(ctx, UpdatePropertyStatusParams{
+			ID:     property.ID,
+			Status: NullPropertyStatusEnum{PropertyStatusEnum: arg.Status, Valid: true},
+		})
+		if err != nil {
+			log.Error().Err(err).Int64("property_id", property.ID).Msg("failed to update property status")
+			return err
+		}
+
+		// Only published properties show up in searches, and rented ones stay off them until relisted
+		switch arg.Status {
+		case PropertyStatusEnumActive, PropertyStatusEnumRented:
+			result.Property, err = q.UpdatePropertyAvailability(ctx, UpdatePropertyAvailabilityParams{
+				ID:          property.ID,
+				IsAvailable: pgtype.Bool{Bool: arg.Status == PropertyStatusEnumActive, Valid: true},
+			})
+			if err != nil {
+				log.Error().Err(err).Int64("property_id", property.ID).Msg("failed to update property availability")
+				return err
+			}
+		}
+
+		wasListed, isListed := propertyIsListed(result.OldStatus), propertyIsListed(arg.Status)
+		switch {
+		case isListed && !wasListed:
+			err = q.IncrementLandlordPropertyCount(ctx, property.LandlordID)
+		case wasListed && !isListed:
+			err = q.DecrementLandlordPropertyCount(ctx, property.LandlordID)
+		}
+		if err != nil {
+			log.Error().Err(err).Int64("landlord_id", property.LandlordID).Msg("failed to update landlord property count")
+		}
+		return err
+	})
+
+	return result, err
+}
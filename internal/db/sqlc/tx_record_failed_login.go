@@ -0,0 +1,104 @@
+package db
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/rs/zerolog/log"
+)
+
+type RecordFailedLoginTxParams struct {
+	// UserID is empty when the login used an unknown email
+	UserID    pgtype.Int8
+	Email     string
+	IpAddress pgtype.Text
+	UserAgent pgtype.Text
+	// MaxAttempts is the number of failures after which the account gets locked
+	MaxAttempts int32
+	// LockoutDuration is doubled for every lockout in a row, up to MaxLockoutDuration
+	LockoutDuration    time.Duration
+	MaxLockoutDuration time.Duration
+}
+
+type RecordFailedLoginTxResult struct {
+	AuditLog AuditLog
+	Lockout  AccountLockout
+	// Locked is set when this failure caused the account to be locked
+	Locked bool
+}
+
+// RecordFailedLoginTx writes a failed login to the audit log and, for known
+// users, counts it towards an account lockout with exponential backoff.
+func (store *SQLStore) RecordFailedLoginTx(ctx context.Context, arg RecordFailedLoginTxParams) (RecordFailedLoginTxResult, error) {
+	var result RecordFailedLoginTxResult
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		var err error
+
+		details, err := json.Marshal(map[string]string{
+			"email": arg.Email,
+		})
+		if err != nil {
+			return err
+		}
+
+		result.AuditLog, err = q.CreateAuditLog(ctx, CreateAuditLogParams{
+			UserID:     arg.UserID,
+			Action:     AuditActionEnumLogin,
+			EntityType: "failed_login",
+			EntityID:   arg.UserID,
+			NewValues:  pgtype.Text{String: string(details), Valid: true},
+			IpAddress:  arg.IpAddress,
+			UserAgent:  arg.UserAgent,
+		})
+		if err != nil {
+			log.Error().Err(err).Msg("failed to record failed login")
+			return err
+		}
+
+		if !arg.UserID.Valid {
+			return nil
+		}
+
+		result.Lockout, err = q.IncrementFailedLoginAttempts(ctx, arg.UserID.Int64)
+		if err != nil {
+			log.Error().Err(err).Int64("user_id", arg.UserID.Int64).Msg("failed to increment failed login attempts")
+			return err
+		}
+
+		if result.Lockout.FailedAttempts < arg.MaxAttempts {
+			return nil
+		}
+
+		result.Lockout, err = q.LockAccount(ctx, LockAccountParams{
+			UserID: arg.UserID.Int64,
+			LockedUntil: pgtype.Timestamptz{
+				Time:  time.Now().Add(lockoutDuration(arg.LockoutDuration, arg.MaxLockoutDuration, result.Lockout.LockoutCount)),
+				Valid: true,
+			},
+		})
+		if err != nil {
+			log.Error().Err(err).Int64("user_id", arg.UserID.Int64).Msg("failed to lock account")
+			return err
+		}
+
+		result.Locked = true
+		return nil
+	})
+
+	return result, err
+}
+
+// lockoutDuration doubles the base duration for every previous lockout
+func lockoutDuration(base time.Duration, max time.Duration, previousLockouts int32) time.Duration {
+	duration := base
+	for i := int32(0); i < previousLockouts; i++ {
+		duration *= 2
+		if duration >= max {
+			return max
+		}
+	}
+	return duration
+}
@@ -0,0 +1,51 @@
+package db
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/rs/zerolog/log"
+)
+
+type RecordSuccessfulLoginTxParams struct {
+	UserID    int64
+	IpAddress pgtype.Text
+	UserAgent pgtype.Text
+}
+
+type RecordSuccessfulLoginTxResult struct {
+	AuditLog AuditLog
+}
+
+// RecordSuccessfulLoginTx writes a successful login to the audit log and
+// clears any failed attempts counted against the account.
+func (store *SQLStore) RecordSuccessfulLoginTx(ctx context.Context, arg RecordSuccessfulLoginTxParams) (RecordSuccessfulLoginTxResult, error) {
+	var result RecordSuccessfulLoginTxResult
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		var err error
+
+		result.AuditLog, err = q.CreateAuditLog(ctx, CreateAuditLogParams{
+			UserID:     pgtype.Int8{Int64: arg.UserID, Valid: true},
+			Action:     AuditActionEnumLogin,
+			EntityType: "successful_login",
+			EntityID:   pgtype.Int8{Int64: arg.UserID, Valid: true},
+			IpAddress:  arg.IpAddress,
+			UserAgent:  arg.UserAgent,
+		})
+		if err != nil {
+			log.Error().Err(err).Int64("user_id", arg.UserID).Msg("failed to record successful login")
+			return err
+		}
+
+		err = q.ResetAccountLockout(ctx, arg.UserID)
+		if err != nil {
+			log.Error().Err(err).Int64("user_id", arg.UserID).Msg("failed to reset account lockout")
+			return err
+		}
+
+		return q.UpdateUserLastLogin(ctx, arg.UserID)
+	})
+
+	return result, err
+}
@@ -0,0 +1,78 @@
+package db
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/rs/zerolog/log"
+)
+
+type RevokeSessionFamilyTxParams struct {
+	// ReusedSession is the rotated session whose refresh token was presented again
+	ReusedSession UserSession
+	IpAddress     pgtype.Text
+	UserAgent     pgtype.Text
+}
+
+type RevokeSessionFamilyTxResult struct {
+	RevokedSessions []UserSession
+	AuditLog        AuditLog
+}
+
+// RevokeSessionFamilyTx handles refresh token reuse. Since a rotated token should
+// never be seen again, the token family is considered compromised and every active
+// session of the user is revoked, leaving an audit trail behind.
+func (store *SQLStore) RevokeSessionFamilyTx(ctx context.Context, arg RevokeSessionFamilyTxParams) (RevokeSessionFamilyTxResult, error) {
+	var result RevokeSessionFamilyTxResult
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		var err error
+
+		userID := arg.ReusedSession.UserID
+
+		result.RevokedSessions, err = q.GetAllUserActiveSessions(ctx, userID)
+		if err != nil {
+			log.Error().Err(err).Int64("user_id", userID).Msg("failed to get active sessions")
+			return err
+		}
+
+		err = q.DeactivateUserSessions(ctx, userID)
+		if err != nil {
+			log.Error().Err(err).Int64("user_id", userID).Msg("failed to deactivate user sessions")
+			return err
+		}
+
+		details, err := json.Marshal(map[string]interface{}{
+			"session_id":       arg.ReusedSession.SessionID,
+			"revoked_sessions": len(result.RevokedSessions),
+		})
+		if err != nil {
+			return err
+		}
+
+		result.AuditLog, err = q.CreateAuditLog(ctx, CreateAuditLogParams{
+			UserID:     pgtype.Int8{Int64: userID, Valid: true},
+			Action:     AuditActionEnumLogout,
+			EntityType: "refresh_token_reuse",
+			EntityID:   pgtype.Int8{Int64: arg.ReusedSession.ID, Valid: true},
+			NewValues:  pgtype.Text{String: string(details), Valid: true},
+			IpAddress:  arg.IpAddress,
+			UserAgent:  arg.UserAgent,
+		})
+		if err != nil {
+			log.Error().Err(err).Int64("user_id", userID).Msg("failed to create audit log")
+			return err
+		}
+
+		log.Warn().
+			Int64("user_id", userID).
+			Str("session_id", arg.ReusedSession.SessionID.String()).
+			Int("revoked_sessions", len(result.RevokedSessions)).
+			Msg("refresh token reuse detected, revoked user sessions")
+
+		return nil
+	})
+
+	return result, err
+}
@@ -0,0 +1,71 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/rs/zerolog/log"
+)
+
+// ErrSessionAlreadyRotated is returned when the session being rotated has
+// already been replaced, e.g. by a concurrent refresh with the same token.
+var ErrSessionAlreadyRotated = errors.New("session has already been rotated")
+
+type RotateSessionTxParams struct {
+	Session         UserSession
+	NewSessionToken string
+	IpAddress       pgtype.Text
+	DeviceInfo      pgtype.Text
+	ExpiresAt       time.Time
+}
+
+type RotateSessionTxResult struct {
+	OldSession UserSession
+	NewSession UserSession
+}
+
+// RotateSessionTx replaces a session with a new one carrying a fresh refresh token.
+// Both rows share the same session_id, which identifies the token family, and the
+// old row is marked as rotated and linked to its replacement.
+func (store *SQLStore) RotateSessionTx(ctx context.Context, arg RotateSessionTxParams) (RotateSessionTxResult, error) {
+	var result RotateSessionTxResult
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		var err error
+
+		result.NewSession, err = q.CreateUserSession(ctx, CreateUserSessionParams{
+			UserID:       arg.Session.UserID,
+			SessionID:    arg.Session.SessionID,
+			SessionToken: arg.NewSessionToken,
+			DeviceInfo:   arg.DeviceInfo,
+			IpAddress:    arg.IpAddress,
+			LocationData: arg.Session.LocationData,
+			ExpiresAt:    arg.ExpiresAt,
+		})
+		if err != nil {
+			log.Error().Err(err).Int64("session_id", arg.Session.ID).Msg("failed to create rotated session")
+			return err
+		}
+
+		rows, err := q.MarkUserSessionRotated(ctx, MarkUserSessionRotatedParams{
+			ID:         arg.Session.ID,
+			ReplacedBy: pgtype.Int8{Int64: result.NewSession.ID, Valid: true},
+		})
+		if err != nil {
+			log.Error().Err(err).Int64("session_id", arg.Session.ID).Msg("failed to mark session as rotated")
+			return err
+		}
+
+		// Someone else rotated this session between our read and this update
+		if rows == 0 {
+			return ErrSessionAlreadyRotated
+		}
+
+		result.OldSession, err = q.GetUserSessionByID(ctx, arg.Session.ID)
+		return err
+	})
+
+	return result, err
+}
@@ -0,0 +1,59 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/rs/zerolog/log"
+)
+
+// ErrDocumentNotOwned is returned when a tenant shares a document that isn't theirs
+var ErrDocumentNotOwned = errors.New("document does not belong to the tenant")
+
+type ShareApplicationDocumentsTxParams struct {
+	// Application the documents are shared for, with its landlord
+	Application RentalApplication
+	DocumentIDs []int64
+}
+
+type ShareApplicationDocumentsTxResult struct {
+	Grants []DocumentAccessGrant
+}
+
+// ShareApplicationDocumentsTx gives the landlord of an application access to documents of its tenant.
+// Either every document is shared or none is.
+func (store *SQLStore) ShareApplicationDocumentsTx(ctx context.Context, arg ShareApplicationDocumentsTxParams) (ShareApplicationDocumentsTxResult, error) {
+	var result ShareApplicationDocumentsTxResult
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		result.Grants = make([]DocumentAccessGrant, 0, len(arg.DocumentIDs))
+		for _, documentID := range arg.DocumentIDs {
+			document, err := q.GetTenantDocument(ctx, documentID)
+			if err != nil {
+				if errors.Is(err, ErrRecordNotFound) {
+					return fmt.Errorf("document %d: %w", documentID, ErrDocumentNotOwned)
+				}
+				return err
+			}
+			if document.OwnerID != arg.Application.TenantID {
+				return fmt.Errorf("document %d: %w", documentID, ErrDocumentNotOwned)
+			}
+
+			grant, err := q.CreateDocumentAccessGrant(ctx, CreateDocumentAccessGrantParams{
+				DocumentID:          documentID,
+				RentalApplicationID: arg.Application.ID,
+				GranteeID:           arg.Application.LandlordID,
+			})
+			if err != nil {
+				log.Error().Err(err).Int64("application_id", arg.Application.ID).Msg("failed to create document access grant")
+				return err
+			}
+			result.Grants = append(result.Grants, grant)
+		}
+
+		return nil
+	})
+
+	return result, err
+}
@@ -0,0 +1,66 @@
+package db
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/rs/zerolog/log"
+)
+
+type UnlockAccountTxParams struct {
+	UserID    int64
+	AdminID   int64
+	IpAddress pgtype.Text
+	UserAgent pgtype.Text
+}
+
+type UnlockAccountTxResult struct {
+	// Lockout is the lockout state before it was cleared
+	Lockout  AccountLockout
+	AuditLog AuditLog
+}
+
+// UnlockAccountTx lets an admin clear the lockout of an account, recording
+// the previous lockout state in the audit log.
+func (store *SQLStore) UnlockAccountTx(ctx context.Context, arg UnlockAccountTxParams) (UnlockAccountTxResult, error) {
+	var result UnlockAccountTxResult
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		var err error
+
+		result.Lockout, err = q.GetAccountLockout(ctx, arg.UserID)
+		if err != nil {
+			return err
+		}
+
+		err = q.ResetAccountLockout(ctx, arg.UserID)
+		if err != nil {
+			log.Error().Err(err).Int64("user_id", arg.UserID).Msg("failed to reset account lockout")
+			return err
+		}
+
+		oldValues, err := json.Marshal(result.Lockout)
+		if err != nil {
+			return err
+		}
+
+		result.AuditLog, err = q.CreateAuditLog(ctx, CreateAuditLogParams{
+			UserID:     pgtype.Int8{Int64: arg.AdminID, Valid: true},
+			Action:     AuditActionEnumUpdate,
+			EntityType: "account_lockout",
+			EntityID:   pgtype.Int8{Int64: arg.UserID, Valid: true},
+			OldValues:  pgtype.Text{String: string(oldValues), Valid: true},
+			IpAddress:  arg.IpAddress,
+			UserAgent:  arg.UserAgent,
+		})
+		if err != nil {
+			log.Error().Err(err).Int64("user_id", arg.UserID).Msg("failed to create audit log")
+			return err
+		}
+
+		return nil
+	})
+
+	return result, err
+}
@@ -11,14 +11,48 @@ import (
 	"github.com/jackc/pgx/v5/pgtype"
 )
 
-const countUsersByType = `-- name: CountUsersByType :one
+const anonymizeUser = `-- name: AnonymizeUser :exec
+UPDATE users 
+SET email = $2, phone = $3, password_hash = $4, first_name = 'Deleted', last_name = 'User',
+    nin = NULL, profile_picture_url = NULL, is_verified = false, is_identity_verified = false,
+    is_active = false, updated_at = NOW()
+WHERE id = $1
+`
+
+type AnonymizeUserParams struct {
+	ID           int64  `json:"id"`
+	Email        string `json:"email"`
+	Phone        string `json:"phone"`
+	PasswordHash string `json:"password_hash"`
+}
+
+// Replace the personal data of a deleted user, keeping the row for the records that reference it
+func (q *Queries) AnonymizeUser(ctx context.Context, arg AnonymizeUserParams) error {
+	_, err := q.db.Exec(ctx, anonymizeUser,
+		arg.ID,
+		arg.Email,
+		arg.Phone,
+		arg.PasswordHash,
+	)
+	return err
+}
+
+const countFilteredUsers = `-- name: CountFilteredUsers :one
 SELECT COUNT(*) FROM users 
-WHERE user_type = $1 AND is_active = true
+WHERE ($1::text IS NULL OR first_name ILIKE $1 OR last_name ILIKE $1 OR email ILIKE $1)
+  AND ($2::user_type_enum IS NULL OR user_type = $2)
+  AND ($3::boolean IS NULL OR is_active = $3)
 `
 
-// Count users by type
-func (q *Queries) CountUsersByType(ctx context.Context, userType UserTypeEnum) (int64, error) {
-	row := q.db.QueryRow(ctx, countUsersByType, userType)
+type CountFilteredUsersParams struct {
+	Search   pgtype.Text      `json:"search"`
+	UserType NullUserTypeEnum `json:"user_type"`
+	IsActive pgtype.Bool      `json:"is_active"`
+}
+
+// Count users matching FilterUsers
+func (q *Queries) CountFilteredUsers(ctx context.Context, arg CountFilteredUsersParams) (int64, error) {
+	row := q.db.QueryRow(ctx, countFilteredUsers, arg.Search, arg.UserType, arg.IsActive)
 	var count int64
 	err := row.Scan(&count)
 	return count, err
@@ -29,7 +63,7 @@ INSERT INTO users (
   email, phone, password_hash, first_name, last_name, user_type, nin, profile_picture_url
 ) VALUES (
   $1, $2, $3, $4, $5, $6, $7, $8
-) RETURNING id, email, phone, password_hash, first_name, last_name, user_type, nin, is_verified, is_active, profile_picture_url, created_at, updated_at, last_login
+) RETURNING id, email, phone, password_hash, first_name, last_name, user_type, nin, is_verified, is_active, profile_picture_url, created_at, updated_at, last_login, is_identity_verified
 `
 
 type CreateUserParams struct {
@@ -71,6 +105,7 @@ func (q *Queries) CreateUser(ctx context.Context, arg CreateUserParams) (User, e
 		&i.CreatedAt,
 		&i.UpdatedAt,
 		&i.LastLogin,
+		&i.IsIdentityVerified,
 	)
 	return i, err
 }
@@ -87,8 +122,68 @@ func (q *Queries) DeleteUser(ctx context.Context, id int64) error {
 	return err
 }
 
+const filterUsers = `-- name: FilterUsers :many
+SELECT id, email, phone, password_hash, first_name, last_name, user_type, nin, is_verified, is_active, profile_picture_url, created_at, updated_at, last_login, is_identity_verified FROM users 
+WHERE ($1::text IS NULL OR first_name ILIKE $1 OR last_name ILIKE $1 OR email ILIKE $1)
+  AND ($2::user_type_enum IS NULL OR user_type = $2)
+  AND ($3::boolean IS NULL OR is_active = $3)
+ORDER BY created_at DESC
+LIMIT $4 OFFSET $5
+`
+
+type FilterUsersParams struct {
+	Search   pgtype.Text      `json:"search"`
+	UserType NullUserTypeEnum `json:"user_type"`
+	IsActive pgtype.Bool      `json:"is_active"`
+	Limit    int32            `json:"limit"`
+	Offset   int32            `json:"offset"`
+}
+
+// Filter users by an optional search term, type and active status
+func (q *Queries) FilterUsers(ctx context.Context, arg FilterUsersParams) ([]User, error) {
+	rows, err := q.db.Query(ctx, filterUsers,
+		arg.Search,
+		arg.UserType,
+		arg.IsActive,
+		arg.Limit,
+		arg.Offset,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []User{}
+	for rows.Next() {
+		var i User
+		if err := rows.Scan(
+			&i.ID,
+			&i.Email,
+			&i.Phone,
+			&i.PasswordHash,
+			&i.FirstName,
+			&i.LastName,
+			&i.UserType,
+			&i.Nin,
+			&i.IsVerified,
+			&i.IsActive,
+			&i.ProfilePictureUrl,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.LastLogin,
+			&i.IsIdentityVerified,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const getUserByEmail = `-- name: GetUserByEmail :one
-SELECT id, email, phone, password_hash, first_name, last_name, user_type, nin, is_verified, is_active, profile_picture_url, created_at, updated_at, last_login FROM users 
+SELECT id, email, phone, password_hash, first_name, last_name, user_type, nin, is_verified, is_active, profile_picture_url, created_at, updated_at, last_login, is_identity_verified FROM users 
 WHERE email = $1 LIMIT 1
 `
 
@@ -111,12 +206,13 @@ func (q *Queries) GetUserByEmail(ctx context.Context, email string) (User, error
 		&i.CreatedAt,
 		&i.UpdatedAt,
 		&i.LastLogin,
+		&i.IsIdentityVerified,
 	)
 	return i, err
 }
 
 const getUserByID = `-- name: GetUserByID :one
-SELECT id, email, phone, password_hash, first_name, last_name, user_type, nin, is_verified, is_active, profile_picture_url, created_at, updated_at, last_login FROM users 
+SELECT id, email, phone, password_hash, first_name, last_name, user_type, nin, is_verified, is_active, profile_picture_url, created_at, updated_at, last_login, is_identity_verified FROM users 
 WHERE id = $1 LIMIT 1
 `
 
@@ -139,12 +235,13 @@ func (q *Queries) GetUserByID(ctx context.Context, id int64) (User, error) {
 		&i.CreatedAt,
 		&i.UpdatedAt,
 		&i.LastLogin,
+		&i.IsIdentityVerified,
 	)
 	return i, err
 }
 
 const getUserByPhone = `-- name: GetUserByPhone :one
-SELECT id, email, phone, password_hash, first_name, last_name, user_type, nin, is_verified, is_active, profile_picture_url, created_at, updated_at, last_login FROM users 
+SELECT id, email, phone, password_hash, first_name, last_name, user_type, nin, is_verified, is_active, profile_picture_url, created_at, updated_at, last_login, is_identity_verified FROM users 
 WHERE phone = $1 LIMIT 1
 `
 
@@ -167,76 +264,26 @@ func (q *Queries) GetUserByPhone(ctx context.Context, phone string) (User, error
 		&i.CreatedAt,
 		&i.UpdatedAt,
 		&i.LastLogin,
+		&i.IsIdentityVerified,
 	)
 	return i, err
 }
 
-const listUsersByType = `-- name: ListUsersByType :many
-SELECT id, email, phone, password_hash, first_name, last_name, user_type, nin, is_verified, is_active, profile_picture_url, created_at, updated_at, last_login FROM users 
-WHERE user_type = $1 AND is_active = true
-ORDER BY created_at DESC
-LIMIT $2 OFFSET $3
-`
-
-type ListUsersByTypeParams struct {
-	UserType UserTypeEnum `json:"user_type"`
-	Limit    int32        `json:"limit"`
-	Offset   int32        `json:"offset"`
-}
-
-// List users by type
-func (q *Queries) ListUsersByType(ctx context.Context, arg ListUsersByTypeParams) ([]User, error) {
-	rows, err := q.db.Query(ctx, listUsersByType, arg.UserType, arg.Limit, arg.Offset)
-	if err != nil {
-		return nil, err
-	}
-	defer rows.Close()
-	items := []User{}
-	for rows.Next() {
-		var i User
-		if err := rows.Scan(
-			&i.ID,
-			&i.Email,
-			&i.Phone,
-			&i.PasswordHash,
-			&i.FirstName,
-			&i.LastName,
-			&i.UserType,
-			&i.Nin,
-			&i.IsVerified,
-			&i.IsActive,
-			&i.ProfilePictureUrl,
-			&i.CreatedAt,
-			&i.UpdatedAt,
-			&i.LastLogin,
-		); err != nil {
-			return nil, err
-		}
-		items = append(items, i)
-	}
-	if err := rows.Err(); err != nil {
-		return nil, err
-	}
-	return items, nil
-}
-
-const searchUsers = `-- name: SearchUsers :many
-SELECT id, email, phone, password_hash, first_name, last_name, user_type, nin, is_verified, is_active, profile_picture_url, created_at, updated_at, last_login FROM users 
-WHERE (first_name ILIKE $1 OR last_name ILIKE $1 OR email ILIKE $1) 
-  AND is_active = true
-ORDER BY created_at DESC
-LIMIT $2 OFFSET $3
+const listActiveUsersAfterID = `-- name: ListActiveUsersAfterID :many
+SELECT id, email, phone, password_hash, first_name, last_name, user_type, nin, is_verified, is_active, profile_picture_url, created_at, updated_at, last_login, is_identity_verified FROM users 
+WHERE is_active = true AND id > $1
+ORDER BY id
+LIMIT $2
 `
 
-type SearchUsersParams struct {
-	FirstName string `json:"first_name"`
-	Limit     int32  `json:"limit"`
-	Offset    int32  `json:"offset"`
+type ListActiveUsersAfterIDParams struct {
+	AfterID int64 `json:"after_id"`
+	Limit   int32 `json:"limit"`
 }
 
-// Search users
-func (q *Queries) SearchUsers(ctx context.Context, arg SearchUsersParams) ([]User, error) {
-	rows, err := q.db.Query(ctx, searchUsers, arg.FirstName, arg.Limit, arg.Offset)
+// Page through active users by id, for periodic jobs
+func (q *Queries) ListActiveUsersAfterID(ctx context.Context, arg ListActiveUsersAfterIDParams) ([]User, error) {
+	rows, err := q.db.Query(ctx, listActiveUsersAfterID, arg.AfterID, arg.Limit)
 	if err != nil {
 		return nil, err
 	}
@@ -259,6 +306,7 @@ func (q *Queries) SearchUsers(ctx context.Context, arg SearchUsersParams) ([]Use
 			&i.CreatedAt,
 			&i.UpdatedAt,
 			&i.LastLogin,
+			&i.IsIdentityVerified,
 		); err != nil {
 			return nil, err
 		}
@@ -275,7 +323,7 @@ UPDATE users
 SET email = $2, phone = $3, first_name = $4, last_name = $5, nin = $6, 
     profile_picture_url = $7, updated_at = NOW()
 WHERE id = $1 
-RETURNING id, email, phone, password_hash, first_name, last_name, user_type, nin, is_verified, is_active, profile_picture_url, created_at, updated_at, last_login
+RETURNING id, email, phone, password_hash, first_name, last_name, user_type, nin, is_verified, is_active, profile_picture_url, created_at, updated_at, last_login, is_identity_verified
 `
 
 type UpdateUserParams struct {
@@ -315,6 +363,7 @@ func (q *Queries) UpdateUser(ctx context.Context, arg UpdateUserParams) (User, e
 		&i.CreatedAt,
 		&i.UpdatedAt,
 		&i.LastLogin,
+		&i.IsIdentityVerified,
 	)
 	return i, err
 }
@@ -336,6 +385,23 @@ func (q *Queries) UpdateUserActiveStatus(ctx context.Context, arg UpdateUserActi
 	return err
 }
 
+const updateUserIdentityVerified = `-- name: UpdateUserIdentityVerified :exec
+UPDATE users 
+SET is_identity_verified = $2, updated_at = NOW()
+WHERE id = $1
+`
+
+type UpdateUserIdentityVerifiedParams struct {
+	ID                 int64 `json:"id"`
+	IsIdentityVerified bool  `json:"is_identity_verified"`
+}
+
+// Update whether the nin of the user was verified by the identity provider
+func (q *Queries) UpdateUserIdentityVerified(ctx context.Context, arg UpdateUserIdentityVerifiedParams) error {
+	_, err := q.db.Exec(ctx, updateUserIdentityVerified, arg.ID, arg.IsIdentityVerified)
+	return err
+}
+
 const updateUserLastLogin = `-- name: UpdateUserLastLogin :exec
 UPDATE users 
 SET last_login = NOW() 
@@ -348,6 +414,23 @@ func (q *Queries) UpdateUserLastLogin(ctx context.Context, id int64) error {
 	return err
 }
 
+const updateUserNIN = `-- name: UpdateUserNIN :exec
+UPDATE users 
+SET nin = $2, is_identity_verified = false, updated_at = NOW()
+WHERE id = $1
+`
+
+type UpdateUserNINParams struct {
+	ID  int64       `json:"id"`
+	Nin pgtype.Text `json:"nin"`
+}
+
+// Update user nin, which has to be verified again
+func (q *Queries) UpdateUserNIN(ctx context.Context, arg UpdateUserNINParams) error {
+	_, err := q.db.Exec(ctx, updateUserNIN, arg.ID, arg.Nin)
+	return err
+}
+
 const updateUserPassword = `-- name: UpdateUserPassword :exec
 UPDATE users 
 SET password_hash = $2, updated_at = NOW()
@@ -365,6 +448,23 @@ func (q *Queries) UpdateUserPassword(ctx context.Context, arg UpdateUserPassword
 	return err
 }
 
+const updateUserType = `-- name: UpdateUserType :exec
+UPDATE users 
+SET user_type = $2, updated_at = NOW()
+WHERE id = $1
+`
+
+type UpdateUserTypeParams struct {
+	ID       int64        `json:"id"`
+	UserType UserTypeEnum `json:"user_type"`
+}
+
+// Update user type
+func (q *Queries) UpdateUserType(ctx context.Context, arg UpdateUserTypeParams) error {
+	_, err := q.db.Exec(ctx, updateUserType, arg.ID, arg.UserType)
+	return err
+}
+
 const updateUserVerificationStatus = `-- name: UpdateUserVerificationStatus :exec
 UPDATE users 
 SET is_verified = $2, updated_at = NOW()
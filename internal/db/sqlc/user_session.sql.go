@@ -9,6 +9,7 @@ import (
 	"context"
 	"time"
 
+	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5/pgtype"
 )
 
@@ -62,14 +63,15 @@ func (q *Queries) CountUserTotalSessions(ctx context.Context, userID int64) (int
 
 const createUserSession = `-- name: CreateUserSession :one
 INSERT INTO user_sessions (
-  user_id, session_token, device_info, ip_address, location_data, expires_at
+  user_id, session_id, session_token, device_info, ip_address, location_data, expires_at
 ) VALUES (
-  $1, $2, $3, $4, $5, $6
-) RETURNING id, user_id, session_token, device_info, ip_address, location_data, expires_at, is_active, created_at
+  $1, $2, $3, $4, $5, $6, $7
+) RETURNING id, user_id, session_token, device_info, ip_address, location_data, expires_at, is_active, created_at, session_id, rotated_at, replaced_by
 `
 
 type CreateUserSessionParams struct {
 	UserID       int64       `json:"user_id"`
+	SessionID    uuid.UUID   `json:"session_id"`
 	SessionToken string      `json:"session_token"`
 	DeviceInfo   pgtype.Text `json:"device_info"`
 	IpAddress    pgtype.Text `json:"ip_address"`
@@ -81,6 +83,7 @@ type CreateUserSessionParams struct {
 func (q *Queries) CreateUserSession(ctx context.Context, arg CreateUserSessionParams) (UserSession, error) {
 	row := q.db.QueryRow(ctx, createUserSession,
 		arg.UserID,
+		arg.SessionID,
 		arg.SessionToken,
 		arg.DeviceInfo,
 		arg.IpAddress,
@@ -98,6 +101,9 @@ func (q *Queries) CreateUserSession(ctx context.Context, arg CreateUserSessionPa
 		&i.ExpiresAt,
 		&i.IsActive,
 		&i.CreatedAt,
+		&i.SessionID,
+		&i.RotatedAt,
+		&i.ReplacedBy,
 	)
 	return i, err
 }
@@ -131,6 +137,52 @@ func (q *Queries) DeactivateSession(ctx context.Context, sessionToken string) er
 	return err
 }
 
+const deactivateUserSessionBySessionID = `-- name: DeactivateUserSessionBySessionID :many
+UPDATE user_sessions 
+SET is_active = false
+WHERE user_id = $1 AND session_id = $2 AND is_active = true
+RETURNING id, user_id, session_token, device_info, ip_address, location_data, expires_at, is_active, created_at, session_id, rotated_at, replaced_by
+`
+
+type DeactivateUserSessionBySessionIDParams struct {
+	UserID    int64     `json:"user_id"`
+	SessionID uuid.UUID `json:"session_id"`
+}
+
+// Deactivate user session by session ID
+func (q *Queries) DeactivateUserSessionBySessionID(ctx context.Context, arg DeactivateUserSessionBySessionIDParams) ([]UserSession, error) {
+	rows, err := q.db.Query(ctx, deactivateUserSessionBySessionID, arg.UserID, arg.SessionID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []UserSession{}
+	for rows.Next() {
+		var i UserSession
+		if err := rows.Scan(
+			&i.ID,
+			&i.UserID,
+			&i.SessionToken,
+			&i.DeviceInfo,
+			&i.IpAddress,
+			&i.LocationData,
+			&i.ExpiresAt,
+			&i.IsActive,
+			&i.CreatedAt,
+			&i.SessionID,
+			&i.RotatedAt,
+			&i.ReplacedBy,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const deactivateUserSessions = `-- name: DeactivateUserSessions :exec
 UPDATE user_sessions 
 SET is_active = false
@@ -165,8 +217,48 @@ func (q *Queries) DeleteUserSession(ctx context.Context, id int64) error {
 	return err
 }
 
+const getAllUserActiveSessions = `-- name: GetAllUserActiveSessions :many
+SELECT id, user_id, session_token, device_info, ip_address, location_data, expires_at, is_active, created_at, session_id, rotated_at, replaced_by FROM user_sessions 
+WHERE user_id = $1 AND is_active = true
+ORDER BY created_at DESC
+`
+
+// Get all user active sessions
+func (q *Queries) GetAllUserActiveSessions(ctx context.Context, userID int64) ([]UserSession, error) {
+	rows, err := q.db.Query(ctx, getAllUserActiveSessions, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []UserSession{}
+	for rows.Next() {
+		var i UserSession
+		if err := rows.Scan(
+			&i.ID,
+			&i.UserID,
+			&i.SessionToken,
+			&i.DeviceInfo,
+			&i.IpAddress,
+			&i.LocationData,
+			&i.ExpiresAt,
+			&i.IsActive,
+			&i.CreatedAt,
+			&i.SessionID,
+			&i.RotatedAt,
+			&i.ReplacedBy,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const getExpiredSessions = `-- name: GetExpiredSessions :many
-SELECT id, user_id, session_token, device_info, ip_address, location_data, expires_at, is_active, created_at FROM user_sessions 
+SELECT id, user_id, session_token, device_info, ip_address, location_data, expires_at, is_active, created_at, session_id, rotated_at, replaced_by FROM user_sessions 
 WHERE expires_at <= NOW() AND is_active = true
 ORDER BY expires_at ASC
 LIMIT $1 OFFSET $2
@@ -197,6 +289,9 @@ func (q *Queries) GetExpiredSessions(ctx context.Context, arg GetExpiredSessions
 			&i.ExpiresAt,
 			&i.IsActive,
 			&i.CreatedAt,
+			&i.SessionID,
+			&i.RotatedAt,
+			&i.ReplacedBy,
 		); err != nil {
 			return nil, err
 		}
@@ -208,6 +303,33 @@ func (q *Queries) GetExpiredSessions(ctx context.Context, arg GetExpiredSessions
 	return items, nil
 }
 
+const getRotatedUserSessionByToken = `-- name: GetRotatedUserSessionByToken :one
+SELECT id, user_id, session_token, device_info, ip_address, location_data, expires_at, is_active, created_at, session_id, rotated_at, replaced_by FROM user_sessions 
+WHERE session_token = $1 AND rotated_at IS NOT NULL
+LIMIT 1
+`
+
+// Get rotated user session by token
+func (q *Queries) GetRotatedUserSessionByToken(ctx context.Context, sessionToken string) (UserSession, error) {
+	row := q.db.QueryRow(ctx, getRotatedUserSessionByToken, sessionToken)
+	var i UserSession
+	err := row.Scan(
+		&i.ID,
+		&i.UserID,
+		&i.SessionToken,
+		&i.DeviceInfo,
+		&i.IpAddress,
+		&i.LocationData,
+		&i.ExpiresAt,
+		&i.IsActive,
+		&i.CreatedAt,
+		&i.SessionID,
+		&i.RotatedAt,
+		&i.ReplacedBy,
+	)
+	return i, err
+}
+
 const getSessionStatistics = `-- name: GetSessionStatistics :one
 SELECT 
   COUNT(*) as total_sessions,
@@ -241,7 +363,7 @@ func (q *Queries) GetSessionStatistics(ctx context.Context) (GetSessionStatistic
 }
 
 const getSessionWithUserDetails = `-- name: GetSessionWithUserDetails :one
-SELECT us.id, us.user_id, us.session_token, us.device_info, us.ip_address, us.location_data, us.expires_at, us.is_active, us.created_at, u.first_name, u.last_name, u.email, u.user_type
+SELECT us.id, us.user_id, us.session_token, us.device_info, us.ip_address, us.location_data, us.expires_at, us.is_active, us.created_at, us.session_id, us.rotated_at, us.replaced_by, u.first_name, u.last_name, u.email, u.user_type
 FROM user_sessions us
 JOIN users u ON us.user_id = u.id
 WHERE us.session_token = $1 AND us.is_active = true AND us.expires_at > NOW()
@@ -258,6 +380,9 @@ type GetSessionWithUserDetailsRow struct {
 	ExpiresAt    time.Time          `json:"expires_at"`
 	IsActive     pgtype.Bool        `json:"is_active"`
 	CreatedAt    pgtype.Timestamptz `json:"created_at"`
+	SessionID    uuid.UUID          `json:"session_id"`
+	RotatedAt    pgtype.Timestamptz `json:"rotated_at"`
+	ReplacedBy   pgtype.Int8        `json:"replaced_by"`
 	FirstName    string             `json:"first_name"`
 	LastName     string             `json:"last_name"`
 	Email        string             `json:"email"`
@@ -278,6 +403,9 @@ func (q *Queries) GetSessionWithUserDetails(ctx context.Context, sessionToken st
 		&i.ExpiresAt,
 		&i.IsActive,
 		&i.CreatedAt,
+		&i.SessionID,
+		&i.RotatedAt,
+		&i.ReplacedBy,
 		&i.FirstName,
 		&i.LastName,
 		&i.Email,
@@ -287,7 +415,7 @@ func (q *Queries) GetSessionWithUserDetails(ctx context.Context, sessionToken st
 }
 
 const getSessionsByIPAddress = `-- name: GetSessionsByIPAddress :many
-SELECT us.id, us.user_id, us.session_token, us.device_info, us.ip_address, us.location_data, us.expires_at, us.is_active, us.created_at, u.first_name, u.last_name, u.email
+SELECT us.id, us.user_id, us.session_token, us.device_info, us.ip_address, us.location_data, us.expires_at, us.is_active, us.created_at, us.session_id, us.rotated_at, us.replaced_by, u.first_name, u.last_name, u.email
 FROM user_sessions us
 JOIN users u ON us.user_id = u.id
 WHERE us.ip_address = $1
@@ -311,6 +439,9 @@ type GetSessionsByIPAddressRow struct {
 	ExpiresAt    time.Time          `json:"expires_at"`
 	IsActive     pgtype.Bool        `json:"is_active"`
 	CreatedAt    pgtype.Timestamptz `json:"created_at"`
+	SessionID    uuid.UUID          `json:"session_id"`
+	RotatedAt    pgtype.Timestamptz `json:"rotated_at"`
+	ReplacedBy   pgtype.Int8        `json:"replaced_by"`
 	FirstName    string             `json:"first_name"`
 	LastName     string             `json:"last_name"`
 	Email        string             `json:"email"`
@@ -336,6 +467,9 @@ func (q *Queries) GetSessionsByIPAddress(ctx context.Context, arg GetSessionsByI
 			&i.ExpiresAt,
 			&i.IsActive,
 			&i.CreatedAt,
+			&i.SessionID,
+			&i.RotatedAt,
+			&i.ReplacedBy,
 			&i.FirstName,
 			&i.LastName,
 			&i.Email,
@@ -351,7 +485,7 @@ func (q *Queries) GetSessionsByIPAddress(ctx context.Context, arg GetSessionsByI
 }
 
 const getUserActiveSessions = `-- name: GetUserActiveSessions :many
-SELECT id, user_id, session_token, device_info, ip_address, location_data, expires_at, is_active, created_at FROM user_sessions 
+SELECT id, user_id, session_token, device_info, ip_address, location_data, expires_at, is_active, created_at, session_id, rotated_at, replaced_by FROM user_sessions 
 WHERE user_id = $1 AND is_active = true AND expires_at > NOW()
 ORDER BY created_at DESC
 LIMIT $2 OFFSET $3
@@ -383,6 +517,9 @@ func (q *Queries) GetUserActiveSessions(ctx context.Context, arg GetUserActiveSe
 			&i.ExpiresAt,
 			&i.IsActive,
 			&i.CreatedAt,
+			&i.SessionID,
+			&i.RotatedAt,
+			&i.ReplacedBy,
 		); err != nil {
 			return nil, err
 		}
@@ -395,7 +532,7 @@ func (q *Queries) GetUserActiveSessions(ctx context.Context, arg GetUserActiveSe
 }
 
 const getUserSessionByID = `-- name: GetUserSessionByID :one
-SELECT id, user_id, session_token, device_info, ip_address, location_data, expires_at, is_active, created_at FROM user_sessions 
+SELECT id, user_id, session_token, device_info, ip_address, location_data, expires_at, is_active, created_at, session_id, rotated_at, replaced_by FROM user_sessions 
 WHERE id = $1 LIMIT 1
 `
 
@@ -413,12 +550,15 @@ func (q *Queries) GetUserSessionByID(ctx context.Context, id int64) (UserSession
 		&i.ExpiresAt,
 		&i.IsActive,
 		&i.CreatedAt,
+		&i.SessionID,
+		&i.RotatedAt,
+		&i.ReplacedBy,
 	)
 	return i, err
 }
 
 const getUserSessionByToken = `-- name: GetUserSessionByToken :one
-SELECT id, user_id, session_token, device_info, ip_address, location_data, expires_at, is_active, created_at FROM user_sessions 
+SELECT id, user_id, session_token, device_info, ip_address, location_data, expires_at, is_active, created_at, session_id, rotated_at, replaced_by FROM user_sessions 
 WHERE session_token = $1 AND is_active = true AND expires_at > NOW()
 LIMIT 1
 `
@@ -437,6 +577,9 @@ func (q *Queries) GetUserSessionByToken(ctx context.Context, sessionToken string
 		&i.ExpiresAt,
 		&i.IsActive,
 		&i.CreatedAt,
+		&i.SessionID,
+		&i.RotatedAt,
+		&i.ReplacedBy,
 	)
 	return i, err
 }
@@ -472,7 +615,7 @@ func (q *Queries) GetUserSessionSummary(ctx context.Context, userID int64) (GetU
 }
 
 const getUserSessions = `-- name: GetUserSessions :many
-SELECT id, user_id, session_token, device_info, ip_address, location_data, expires_at, is_active, created_at FROM user_sessions 
+SELECT id, user_id, session_token, device_info, ip_address, location_data, expires_at, is_active, created_at, session_id, rotated_at, replaced_by FROM user_sessions 
 WHERE user_id = $1
 ORDER BY created_at DESC
 LIMIT $2 OFFSET $3
@@ -504,6 +647,9 @@ func (q *Queries) GetUserSessions(ctx context.Context, arg GetUserSessionsParams
 			&i.ExpiresAt,
 			&i.IsActive,
 			&i.CreatedAt,
+			&i.SessionID,
+			&i.RotatedAt,
+			&i.ReplacedBy,
 		); err != nil {
 			return nil, err
 		}
@@ -515,6 +661,26 @@ func (q *Queries) GetUserSessions(ctx context.Context, arg GetUserSessionsParams
 	return items, nil
 }
 
+const markUserSessionRotated = `-- name: MarkUserSessionRotated :execrows
+UPDATE user_sessions 
+SET is_active = false, rotated_at = NOW(), replaced_by = $2
+WHERE id = $1 AND is_active = true AND rotated_at IS NULL
+`
+
+type MarkUserSessionRotatedParams struct {
+	ID         int64       `json:"id"`
+	ReplacedBy pgtype.Int8 `json:"replaced_by"`
+}
+
+// Mark user session as rotated
+func (q *Queries) MarkUserSessionRotated(ctx context.Context, arg MarkUserSessionRotatedParams) (int64, error) {
+	result, err := q.db.Exec(ctx, markUserSessionRotated, arg.ID, arg.ReplacedBy)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
 const updateSessionActivity = `-- name: UpdateSessionActivity :exec
 UPDATE user_sessions 
 SET expires_at = $2
@@ -11,6 +11,35 @@ import (
 	"github.com/jackc/pgx/v5/pgtype"
 )
 
+const completeUserVerification = `-- name: CompleteUserVerification :one
+UPDATE user_verifications 
+SET verification_status = 'verified', verified_at = NOW(), verification_data = $2
+WHERE id = $1 AND verification_status = 'pending'
+RETURNING id, user_id, verification_type, verification_status, verification_data, verified_at, verified_by, created_at
+`
+
+type CompleteUserVerificationParams struct {
+	ID               int64  `json:"id"`
+	VerificationData []byte `json:"verification_data"`
+}
+
+// Mark a pending verification as verified together with its final data
+func (q *Queries) CompleteUserVerification(ctx context.Context, arg CompleteUserVerificationParams) (UserVerification, error) {
+	row := q.db.QueryRow(ctx, completeUserVerification, arg.ID, arg.VerificationData)
+	var i UserVerification
+	err := row.Scan(
+		&i.ID,
+		&i.UserID,
+		&i.VerificationType,
+		&i.VerificationStatus,
+		&i.VerificationData,
+		&i.VerifiedAt,
+		&i.VerifiedBy,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
 const countPendingVerifications = `-- name: CountPendingVerifications :one
 SELECT COUNT(*) FROM user_verifications 
 WHERE verification_status = 'pending'
@@ -24,6 +53,25 @@ func (q *Queries) CountPendingVerifications(ctx context.Context) (int64, error)
 	return count, err
 }
 
+const countRecentUserVerifications = `-- name: CountRecentUserVerifications :one
+SELECT COUNT(*) FROM user_verifications 
+WHERE user_id = $1 AND verification_type = $2 AND created_at >= $3
+`
+
+type CountRecentUserVerificationsParams struct {
+	UserID           int64                `json:"user_id"`
+	VerificationType VerificationTypeEnum `json:"verification_type"`
+	CreatedAt        pgtype.Timestamptz   `json:"created_at"`
+}
+
+// Count verifications of a type created for a user since a point in time
+func (q *Queries) CountRecentUserVerifications(ctx context.Context, arg CountRecentUserVerificationsParams) (int64, error) {
+	row := q.db.QueryRow(ctx, countRecentUserVerifications, arg.UserID, arg.VerificationType, arg.CreatedAt)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
 const createUserVerification = `-- name: CreateUserVerification :one
 INSERT INTO user_verifications (
   user_id, verification_type, verification_status, verification_data
@@ -72,6 +120,33 @@ func (q *Queries) DeleteUserVerification(ctx context.Context, id int64) error {
 	return err
 }
 
+const deleteUserVerificationsByType = `-- name: DeleteUserVerificationsByType :exec
+DELETE FROM user_verifications 
+WHERE user_id = $1 AND verification_type = $2
+`
+
+type DeleteUserVerificationsByTypeParams struct {
+	UserID           int64                `json:"user_id"`
+	VerificationType VerificationTypeEnum `json:"verification_type"`
+}
+
+// Delete all verifications of a type for a user
+func (q *Queries) DeleteUserVerificationsByType(ctx context.Context, arg DeleteUserVerificationsByTypeParams) error {
+	_, err := q.db.Exec(ctx, deleteUserVerificationsByType, arg.UserID, arg.VerificationType)
+	return err
+}
+
+const deleteUserVerificationsByUserID = `-- name: DeleteUserVerificationsByUserID :exec
+DELETE FROM user_verifications 
+WHERE user_id = $1
+`
+
+// Delete every verification of a user
+func (q *Queries) DeleteUserVerificationsByUserID(ctx context.Context, userID int64) error {
+	_, err := q.db.Exec(ctx, deleteUserVerificationsByUserID, userID)
+	return err
+}
+
 const getPasswordResetVerification = `-- name: GetPasswordResetVerification :one
 SELECT id, user_id, verification_type, verification_status, verification_data, verified_at, verified_by, created_at FROM user_verifications 
 WHERE verification_type = 'password_reset'
@@ -315,6 +390,27 @@ func (q *Queries) ListVerificationsByTypeAndStatus(ctx context.Context, arg List
 	return items, nil
 }
 
+const swapVerificationData = `-- name: SwapVerificationData :execrows
+UPDATE user_verifications
+SET verification_data = $1
+WHERE id = $2 AND verification_data::text = $3::text
+`
+
+type SwapVerificationDataParams struct {
+	VerificationData []byte `json:"verification_data"`
+	ID               int64  `json:"id"`
+	PreviousData     string `json:"previous_data"`
+}
+
+// Replace verification data only if nobody changed it since it was read
+func (q *Queries) SwapVerificationData(ctx context.Context, arg SwapVerificationDataParams) (int64, error) {
+	result, err := q.db.Exec(ctx, swapVerificationData, arg.VerificationData, arg.ID, arg.PreviousData)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
 const updateVerificationData = `-- name: UpdateVerificationData :one
 UPDATE user_verifications 
 SET verification_data = $2
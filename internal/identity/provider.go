@@ -0,0 +1,82 @@
+package identity
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ErrProviderUnavailable is returned when the provider could not be reached; the lookup can be retried
+var ErrProviderUnavailable = errors.New("identity provider is unavailable")
+
+// NINRequest holds the details a National Identification Number is checked against
+type NINRequest struct {
+	NIN       string
+	FirstName string
+	LastName  string
+	Phone     string
+}
+
+// NINResult is the outcome of a NIN lookup
+type NINResult struct {
+	Match     bool
+	Reference string
+	Reason    string
+}
+
+// IdentityProvider verifies identity documents with an external registry
+type IdentityProvider interface {
+	Name() string
+	VerifyNIN(ctx context.Context, req NINRequest) (*NINResult, error)
+}
+
+// FakeProvider returns deterministic results without calling out to a registry.
+// It is meant for local development and tests:
+//   - a NIN starting with "000" behaves as if the registry is down
+//   - a NIN ending with an odd digit does not match
+//   - any other NIN matches
+type FakeProvider struct{}
+
+func NewFakeProvider() IdentityProvider {
+	return &FakeProvider{}
+}
+
+func (provider *FakeProvider) Name() string {
+	return "fake"
+}
+
+func (provider *FakeProvider) VerifyNIN(ctx context.Context, req NINRequest) (*NINResult, error) {
+	if req.NIN == "" {
+		return nil, fmt.Errorf("nin is required")
+	}
+	if strings.HasPrefix(req.NIN, "000") {
+		return nil, ErrProviderUnavailable
+	}
+
+	sum := sha256.Sum256([]byte(req.NIN))
+	result := &NINResult{
+		Reference: "FAKE-" + strings.ToUpper(hex.EncodeToString(sum[:6])),
+	}
+
+	last := req.NIN[len(req.NIN)-1]
+	if (last-'0')%2 == 1 {
+		result.Reason = "details do not match the NIN record"
+		return result, nil
+	}
+
+	result.Match = true
+	return result, nil
+}
+
+// NewProvider returns the provider configured by name
+func NewProvider(name string) (IdentityProvider, error) {
+	switch name {
+	case "", "fake":
+		return NewFakeProvider(), nil
+	default:
+		return nil, fmt.Errorf("unknown identity provider: %s", name)
+	}
+}
@@ -0,0 +1,39 @@
+package identity
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestFakeProviderVerifyNIN(t *testing.T) {
+	provider := NewFakeProvider()
+
+	result, err := provider.VerifyNIN(context.Background(), NINRequest{NIN: "12345678902"})
+	require.NoError(t, err)
+	require.True(t, result.Match)
+	require.NotEmpty(t, result.Reference)
+	require.Empty(t, result.Reason)
+
+	again, err := provider.VerifyNIN(context.Background(), NINRequest{NIN: "12345678902"})
+	require.NoError(t, err)
+	require.Equal(t, result.Reference, again.Reference)
+
+	result, err = provider.VerifyNIN(context.Background(), NINRequest{NIN: "12345678901"})
+	require.NoError(t, err)
+	require.False(t, result.Match)
+	require.NotEmpty(t, result.Reason)
+
+	_, err = provider.VerifyNIN(context.Background(), NINRequest{NIN: "00045678902"})
+	require.ErrorIs(t, err, ErrProviderUnavailable)
+}
+
+func TestNewProvider(t *testing.T) {
+	provider, err := NewProvider("fake")
+	require.NoError(t, err)
+	require.Equal(t, "fake", provider.Name())
+
+	_, err = NewProvider("unknown")
+	require.Error(t, err)
+}
@@ -0,0 +1,163 @@
+package oidc
+
+import (
+	"context"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math/big"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// keySetRefreshInterval is how long fetched keys are trusted before the set is downloaded again
+	keySetRefreshInterval = time.Hour
+	// keySetMinRefreshInterval stops tokens with unknown key ids from hammering the provider
+	keySetMinRefreshInterval = time.Minute
+)
+
+// KeySet is a JSON Web Key Set of RSA signing keys.
+// The source is either an http(s) URL, such as a provider's jwks_uri, or a path to a local
+// JWKS file (optionally prefixed with file://), which is handy for development and tests.
+type KeySet struct {
+	source string
+	client *http.Client
+
+	mu        sync.Mutex
+	keys      map[string]*rsa.PublicKey
+	fetchedAt time.Time
+}
+
+func NewKeySet(source string, client *http.Client) *KeySet {
+	return &KeySet{
+		source: source,
+		client: client,
+	}
+}
+
+type jsonWebKey struct {
+	Kid string `json:"kid"`
+	Kty string `json:"kty"`
+	Use string `json:"use"`
+	N   string `json:"n"`
+	E   string `json:"e"`
+}
+
+type jsonWebKeySet struct {
+	Keys []jsonWebKey `json:"keys"`
+}
+
+// Key returns the public key with the given key id, refreshing the set when the key is unknown
+func (set *KeySet) Key(ctx context.Context, kid string) (*rsa.PublicKey, error) {
+	set.mu.Lock()
+	defer set.mu.Unlock()
+
+	key, ok := set.keys[kid]
+	if ok && time.Since(set.fetchedAt) < keySetRefreshInterval {
+		return key, nil
+	}
+
+	// Providers rotate keys, so an unknown kid is worth one refetch, but not one per request
+	if set.keys == nil || time.Since(set.fetchedAt) >= keySetMinRefreshInterval {
+		keys, err := set.fetch(ctx)
+		if err != nil {
+			if ok {
+				return key, nil
+			}
+			return nil, err
+		}
+
+		set.keys = keys
+		set.fetchedAt = time.Now()
+		key, ok = keys[kid]
+	}
+
+	if !ok {
+		return nil, fmt.Errorf("%w: unknown signing key %q", ErrInvalidToken, kid)
+	}
+	return key, nil
+}
+
+func (set *KeySet) fetch(ctx context.Context) (map[string]*rsa.PublicKey, error) {
+	data, err := set.read(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var jwks jsonWebKeySet
+	err = json.Unmarshal(data, &jwks)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse key set: %w", err)
+	}
+
+	keys := make(map[string]*rsa.PublicKey, len(jwks.Keys))
+	for _, jwk := range jwks.Keys {
+		if jwk.Kty != "RSA" || (jwk.Use != "" && jwk.Use != "sig") {
+			continue
+		}
+
+		key, err := parseRSAKey(jwk)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse key %q: %w", jwk.Kid, err)
+		}
+		keys[jwk.Kid] = key
+	}
+
+	return keys, nil
+}
+
+func (set *KeySet) read(ctx context.Context) ([]byte, error) {
+	if !strings.HasPrefix(set.source, "http://") && !strings.HasPrefix(set.source, "https://") {
+		data, err := os.ReadFile(strings.TrimPrefix(set.source, "file://"))
+		if err != nil {
+			return nil, fmt.Errorf("failed to read key set: %w", err)
+		}
+		return data, nil
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, set.source, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	rsp, err := set.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrProviderUnavailable, err)
+	}
+	defer rsp.Body.Close()
+
+	if rsp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("%w: key set returned status %d", ErrProviderUnavailable, rsp.StatusCode)
+	}
+
+	return io.ReadAll(rsp.Body)
+}
+
+func parseRSAKey(jwk jsonWebKey) (*rsa.PublicKey, error) {
+	n, err := base64.RawURLEncoding.DecodeString(jwk.N)
+	if err != nil {
+		return nil, err
+	}
+
+	e, err := base64.RawURLEncoding.DecodeString(jwk.E)
+	if err != nil {
+		return nil, err
+	}
+
+	exponent := new(big.Int).SetBytes(e)
+	if !exponent.IsInt64() || exponent.Int64() > 1<<31-1 {
+		return nil, fmt.Errorf("invalid exponent")
+	}
+
+	key := &rsa.PublicKey{
+		N: new(big.Int).SetBytes(n),
+		E: int(exponent.Int64()),
+	}
+	return key, nil
+}
@@ -0,0 +1,218 @@
+package oidc
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+const (
+	GoogleProviderName = "google"
+	GoogleJWKSURL      = "https://www.googleapis.com/oauth2/v3/certs"
+	GoogleTokenURL     = "https://oauth2.googleapis.com/token"
+)
+
+// googleIssuers are the values Google puts in the iss claim of its ID tokens
+var googleIssuers = []string{"https://accounts.google.com", "accounts.google.com"}
+
+var (
+	// ErrInvalidToken is returned for ID tokens or authorization codes that cannot be trusted
+	ErrInvalidToken = errors.New("invalid identity token")
+	// ErrProviderUnavailable is returned when the provider could not be reached; the call can be retried
+	ErrProviderUnavailable = errors.New("identity provider is unavailable")
+)
+
+// Claims is the identity asserted by a verified ID token
+type Claims struct {
+	Subject       string
+	Email         string
+	EmailVerified bool
+	GivenName     string
+	FamilyName    string
+	Name          string
+	Picture       string
+}
+
+// OIDCProvider verifies identities asserted by an OpenID Connect provider
+type OIDCProvider interface {
+	Name() string
+	// VerifyIDToken checks the signature, issuer, audience and lifetime of an ID token.
+	// nonce is compared to the nonce claim when it is not empty.
+	VerifyIDToken(ctx context.Context, rawIDToken string, nonce string) (*Claims, error)
+	// ExchangeCode redeems an authorization code for the ID token it was issued with
+	ExchangeCode(ctx context.Context, code string, redirectURI string) (string, error)
+}
+
+// ProviderConfig describes an OpenID Connect provider and our client registration with it
+type ProviderConfig struct {
+	Name         string
+	ClientID     string
+	ClientSecret string
+	Issuers      []string
+	JWKSURL      string
+	TokenURL     string
+}
+
+// Provider is an OIDCProvider for providers that sign ID tokens with RS256
+type Provider struct {
+	config ProviderConfig
+	keys   *KeySet
+	client *http.Client
+}
+
+func NewProvider(config ProviderConfig) (OIDCProvider, error) {
+	if config.ClientID == "" {
+		return nil, fmt.Errorf("client id is required")
+	}
+	if config.JWKSURL == "" {
+		return nil, fmt.Errorf("jwks url is required")
+	}
+
+	client := &http.Client{Timeout: 10 * time.Second}
+	provider := &Provider{
+		config: config,
+		keys:   NewKeySet(config.JWKSURL, client),
+		client: client,
+	}
+	return provider, nil
+}
+
+// NewGoogleProvider returns the provider for "Sign in with Google".
+// jwksURL and tokenURL default to Google's endpoints when empty.
+func NewGoogleProvider(clientID, clientSecret, jwksURL, tokenURL string) (OIDCProvider, error) {
+	if jwksURL == "" {
+		jwksURL = GoogleJWKSURL
+	}
+	if tokenURL == "" {
+		tokenURL = GoogleTokenURL
+	}
+
+	return NewProvider(ProviderConfig{
+		Name:         GoogleProviderName,
+		ClientID:     clientID,
+		ClientSecret: clientSecret,
+		Issuers:      googleIssuers,
+		JWKSURL:      jwksURL,
+		TokenURL:     tokenURL,
+	})
+}
+
+func (provider *Provider) Name() string {
+	return provider.config.Name
+}
+
+type idTokenClaims struct {
+	jwt.RegisteredClaims
+	Nonce         string `json:"nonce,omitempty"`
+	Email         string `json:"email,omitempty"`
+	EmailVerified bool   `json:"email_verified,omitempty"`
+	GivenName     string `json:"given_name,omitempty"`
+	FamilyName    string `json:"family_name,omitempty"`
+	Name          string `json:"name,omitempty"`
+	Picture       string `json:"picture,omitempty"`
+}
+
+func (provider *Provider) VerifyIDToken(ctx context.Context, rawIDToken string, nonce string) (*Claims, error) {
+	keyFunc := func(token *jwt.Token) (interface{}, error) {
+		kid, _ := token.Header["kid"].(string)
+		return provider.keys.Key(ctx, kid)
+	}
+
+	claims := &idTokenClaims{}
+	_, err := jwt.ParseWithClaims(rawIDToken, claims, keyFunc,
+		jwt.WithValidMethods([]string{jwt.SigningMethodRS256.Alg()}),
+		jwt.WithAudience(provider.config.ClientID),
+		jwt.WithExpirationRequired(),
+		jwt.WithIssuedAt(),
+		jwt.WithLeeway(time.Minute),
+	)
+	if err != nil {
+		if errors.Is(err, ErrProviderUnavailable) {
+			return nil, ErrProviderUnavailable
+		}
+		return nil, fmt.Errorf("%w: %s", ErrInvalidToken, err)
+	}
+
+	if !provider.trustsIssuer(claims.Issuer) {
+		return nil, fmt.Errorf("%w: untrusted issuer %q", ErrInvalidToken, claims.Issuer)
+	}
+	if claims.Subject == "" {
+		return nil, fmt.Errorf("%w: missing subject", ErrInvalidToken)
+	}
+	if nonce != "" && claims.Nonce != nonce {
+		return nil, fmt.Errorf("%w: nonce does not match", ErrInvalidToken)
+	}
+
+	result := &Claims{
+		Subject:       claims.Subject,
+		Email:         strings.ToLower(claims.Email),
+		EmailVerified: claims.EmailVerified,
+		GivenName:     claims.GivenName,
+		FamilyName:    claims.FamilyName,
+		Name:          claims.Name,
+		Picture:       claims.Picture,
+	}
+	return result, nil
+}
+
+func (provider *Provider) trustsIssuer(issuer string) bool {
+	for _, trusted := range provider.config.Issuers {
+		if issuer == trusted {
+			return true
+		}
+	}
+	return false
+}
+
+type tokenResponse struct {
+	IDToken          string `json:"id_token"`
+	Error            string `json:"error"`
+	ErrorDescription string `json:"error_description"`
+}
+
+func (provider *Provider) ExchangeCode(ctx context.Context, code string, redirectURI string) (string, error) {
+	form := url.Values{
+		"grant_type":    {"authorization_code"},
+		"code":          {code},
+		"client_id":     {provider.config.ClientID},
+		"client_secret": {provider.config.ClientSecret},
+		"redirect_uri":  {redirectURI},
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, provider.config.TokenURL, strings.NewReader(form.Encode()))
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+
+	rsp, err := provider.client.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("%w: %s", ErrProviderUnavailable, err)
+	}
+	defer rsp.Body.Close()
+
+	if rsp.StatusCode >= http.StatusInternalServerError {
+		return "", fmt.Errorf("%w: token endpoint returned status %d", ErrProviderUnavailable, rsp.StatusCode)
+	}
+
+	var body tokenResponse
+	err = json.NewDecoder(rsp.Body).Decode(&body)
+	if err != nil {
+		return "", fmt.Errorf("failed to parse token response: %w", err)
+	}
+
+	if rsp.StatusCode != http.StatusOK || body.IDToken == "" {
+		// invalid_grant and friends: the code is expired, reused or was issued to another client
+		return "", fmt.Errorf("%w: code exchange failed: %s %s", ErrInvalidToken, body.Error, body.ErrorDescription)
+	}
+
+	return body.IDToken, nil
+}
@@ -0,0 +1,205 @@
+package oidc
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/require"
+)
+
+const testClientID = "test-client.apps.googleusercontent.com"
+
+// writeTestKeySet saves the public half of key as a local JWKS file
+func writeTestKeySet(t *testing.T, kid string, key *rsa.PrivateKey) string {
+	jwks := jsonWebKeySet{
+		Keys: []jsonWebKey{{
+			Kid: kid,
+			Kty: "RSA",
+			Use: "sig",
+			N:   base64.RawURLEncoding.EncodeToString(key.N.Bytes()),
+			E:   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes()),
+		}},
+	}
+	data, err := json.Marshal(jwks)
+	require.NoError(t, err)
+
+	path := filepath.Join(t.TempDir(), "jwks.json")
+	require.NoError(t, os.WriteFile(path, data, 0o600))
+	return path
+}
+
+func signTestIDToken(t *testing.T, kid string, key *rsa.PrivateKey, claims jwt.MapClaims) string {
+	token := jwt.NewWithClaims(jwt.SigningMethodRS256, claims)
+	token.Header["kid"] = kid
+
+	raw, err := token.SignedString(key)
+	require.NoError(t, err)
+	return raw
+}
+
+func validTestClaims() jwt.MapClaims {
+	now := time.Now()
+	return jwt.MapClaims{
+		"iss":            "https://accounts.google.com",
+		"aud":            testClientID,
+		"sub":            "1234567890",
+		"email":          "Ada@Example.com",
+		"email_verified": true,
+		"given_name":     "Ada",
+		"family_name":    "Lovelace",
+		"iat":            now.Unix(),
+		"exp":            now.Add(time.Hour).Unix(),
+	}
+}
+
+func TestVerifyIDToken(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	otherKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	provider, err := NewGoogleProvider(testClientID, "secret", "file://"+writeTestKeySet(t, "key-1", key), "")
+	require.NoError(t, err)
+	require.Equal(t, GoogleProviderName, provider.Name())
+
+	ctx := context.Background()
+
+	claims, err := provider.VerifyIDToken(ctx, signTestIDToken(t, "key-1", key, validTestClaims()), "")
+	require.NoError(t, err)
+	require.Equal(t, "1234567890", claims.Subject)
+	require.Equal(t, "ada@example.com", claims.Email)
+	require.True(t, claims.EmailVerified)
+	require.Equal(t, "Ada", claims.GivenName)
+	require.Equal(t, "Lovelace", claims.FamilyName)
+
+	withNonce := validTestClaims()
+	withNonce["nonce"] = "n-0S6_WzA2Mj"
+	_, err = provider.VerifyIDToken(ctx, signTestIDToken(t, "key-1", key, withNonce), "n-0S6_WzA2Mj")
+	require.NoError(t, err)
+
+	testCases := []struct {
+		name  string
+		kid   string
+		key   *rsa.PrivateKey
+		edit  func(claims jwt.MapClaims)
+		nonce string
+	}{
+		{name: "WrongSignature", kid: "key-1", key: otherKey, edit: func(claims jwt.MapClaims) {}},
+		{name: "UnknownKey", kid: "key-2", key: key, edit: func(claims jwt.MapClaims) {}},
+		{name: "WrongAudience", kid: "key-1", key: key, edit: func(claims jwt.MapClaims) { claims["aud"] = "another-client" }},
+		{name: "WrongIssuer", kid: "key-1", key: key, edit: func(claims jwt.MapClaims) { claims["iss"] = "https://evil.example.com" }},
+		{name: "Expired", kid: "key-1", key: key, edit: func(claims jwt.MapClaims) {
+			claims["exp"] = time.Now().Add(-time.Hour).Unix()
+		}},
+		{name: "NoExpiry", kid: "key-1", key: key, edit: func(claims jwt.MapClaims) { delete(claims, "exp") }},
+		{name: "NoSubject", kid: "key-1", key: key, edit: func(claims jwt.MapClaims) { delete(claims, "sub") }},
+		{name: "WrongNonce", kid: "key-1", key: key, edit: func(claims jwt.MapClaims) { claims["nonce"] = "other" }, nonce: "expected"},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			claims := validTestClaims()
+			tc.edit(claims)
+
+			_, err := provider.VerifyIDToken(ctx, signTestIDToken(t, tc.kid, tc.key, claims), tc.nonce)
+			require.ErrorIs(t, err, ErrInvalidToken)
+		})
+	}
+}
+
+func TestVerifyIDTokenRejectsHS256(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	provider, err := NewGoogleProvider(testClientID, "secret", writeTestKeySet(t, "key-1", key), "")
+	require.NoError(t, err)
+
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, validTestClaims())
+	token.Header["kid"] = "key-1"
+	raw, err := token.SignedString([]byte("secret"))
+	require.NoError(t, err)
+
+	_, err = provider.VerifyIDToken(context.Background(), raw, "")
+	require.ErrorIs(t, err, ErrInvalidToken)
+}
+
+func TestExchangeCode(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	idToken := signTestIDToken(t, "key-1", key, validTestClaims())
+
+	tokenServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.NoError(t, r.ParseForm())
+		require.Equal(t, "authorization_code", r.PostForm.Get("grant_type"))
+		require.Equal(t, testClientID, r.PostForm.Get("client_id"))
+		require.Equal(t, "https://app.example.com/callback", r.PostForm.Get("redirect_uri"))
+
+		w.Header().Set("Content-Type", "application/json")
+		if r.PostForm.Get("code") != "good-code" {
+			w.WriteHeader(http.StatusBadRequest)
+			json.NewEncoder(w).Encode(map[string]string{"error": "invalid_grant"})
+			return
+		}
+		json.NewEncoder(w).Encode(map[string]string{"id_token": idToken})
+	}))
+	defer tokenServer.Close()
+
+	provider, err := NewGoogleProvider(testClientID, "secret", writeTestKeySet(t, "key-1", key), tokenServer.URL)
+	require.NoError(t, err)
+
+	ctx := context.Background()
+
+	raw, err := provider.ExchangeCode(ctx, "good-code", "https://app.example.com/callback")
+	require.NoError(t, err)
+	require.Equal(t, idToken, raw)
+
+	_, err = provider.ExchangeCode(ctx, "bad-code", "https://app.example.com/callback")
+	require.ErrorIs(t, err, ErrInvalidToken)
+}
+
+func TestKeySetFromURL(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	data, err := os.ReadFile(writeTestKeySet(t, "key-1", key))
+	require.NoError(t, err)
+
+	requests := 0
+	jwksServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.Write(data)
+	}))
+	defer jwksServer.Close()
+
+	set := NewKeySet(jwksServer.URL, jwksServer.Client())
+	ctx := context.Background()
+
+	got, err := set.Key(ctx, "key-1")
+	require.NoError(t, err)
+	require.Equal(t, key.PublicKey.N, got.N)
+	require.Equal(t, key.PublicKey.E, got.E)
+
+	// Cached keys and unknown key ids within the refresh interval don't hit the provider again
+	_, err = set.Key(ctx, "key-1")
+	require.NoError(t, err)
+	_, err = set.Key(ctx, "key-2")
+	require.ErrorIs(t, err, ErrInvalidToken)
+	require.Equal(t, 1, requests)
+
+	jwksServer.Close()
+	unavailable := NewKeySet(jwksServer.URL, jwksServer.Client())
+	_, err = unavailable.Key(ctx, "key-1")
+	require.ErrorIs(t, err, ErrProviderUnavailable)
+}
@@ -3,6 +3,7 @@ package ratelimit
 import (
 	"context"
 	"fmt"
+	"net"
 	"strings"
 	"time"
 
@@ -37,10 +38,10 @@ func (g *GRPCRateLimiter) UnaryInterceptor() grpc.UnaryServerInterceptor {
 		}
 
 		// Get rate limit key
-		key, _ := g.getRateLimitKey(ctx, info.FullMethod)
+		key, rule := g.getRateLimitKey(ctx, info.FullMethod)
 
 		// Check rate limit
-		result, err := g.limiter.Allow(ctx, key)
+		result, err := g.limiter.AllowWithLimits(ctx, key, int64(rule.RPS), rule.Window)
 		if err != nil {
 			return nil, status.Errorf(codes.Internal, "rate limiter error: %v", err)
 		}
@@ -64,8 +65,9 @@ func (g *GRPCRateLimiter) getRateLimitKey(ctx context.Context, method string) (s
 	if rule, exists := g.rules[method]; exists {
 		switch rule.Scope {
 		case "user":
-			if userID := getUserIDFromContext(ctx); userID != "" {
-				return fmt.Sprintf("user:%s:%s", userID, method), rule
+			// Public methods have no authenticated caller and fall back to the default rule
+			if userID, ok := userIDFromContext(ctx); ok {
+				return fmt.Sprintf("user:%d:%s", userID, method), rule
 			}
 		case "ip":
 			if ip := getClientIPFromContext(ctx); ip != "" {
@@ -76,7 +78,12 @@ func (g *GRPCRateLimiter) getRateLimitKey(ctx context.Context, method string) (s
 		}
 	}
 
-	// Default rule by IP
+	// Default rule by user, so callers sharing an address behind a carrier NAT don't share a limit
+	if userID, ok := userIDFromContext(ctx); ok {
+		return fmt.Sprintf("user:%d", userID), g.getDefaultSingleRule()
+	}
+
+	// Default rule by IP for anonymous callers
 	if ip := getClientIPFromContext(ctx); ip != "" {
 		return fmt.Sprintf("ip:%s", ip), g.getDefaultSingleRule()
 	}
@@ -136,6 +143,90 @@ func (g *GRPCRateLimiter) getDefaultRules() map[string]RateLimitRule {
 			Window:  time.Minute,
 			Scope:   "user",
 		},
+		"/pb.Sqr/ListMySessions": {
+			Pattern: "/pb.Sqr/ListMySessions",
+			RPS:     30, // 30 reads per minute per user
+			Window:  time.Minute,
+			Scope:   "user",
+		},
+		"/pb.Sqr/RevokeSession": {
+			Pattern: "/pb.Sqr/RevokeSession",
+			RPS:     10, // 10 revocations per minute per user
+			Window:  time.Minute,
+			Scope:   "user",
+		},
+		"/pb.Sqr/RevokeOtherSessions": {
+			Pattern: "/pb.Sqr/RevokeOtherSessions",
+			RPS:     5, // 5 bulk revocations per minute per user
+			Window:  time.Minute,
+			Scope:   "user",
+		},
+		"/pb.Sqr/EnrollMFA": {
+			Pattern: "/pb.Sqr/EnrollMFA",
+			RPS:     5, // 5 enrolments per minute per user
+			Window:  time.Minute,
+			Scope:   "user",
+		},
+		"/pb.Sqr/ConfirmMFA": {
+			Pattern: "/pb.Sqr/ConfirmMFA",
+			RPS:     5, // 5 confirmation attempts per minute per user
+			Window:  time.Minute,
+			Scope:   "user",
+		},
+		"/pb.Sqr/DisableMFA": {
+			Pattern: "/pb.Sqr/DisableMFA",
+			RPS:     5, // 5 attempts per minute per user
+			Window:  time.Minute,
+			Scope:   "user",
+		},
+		"/pb.Sqr/VerifyMFA": {
+			Pattern: "/pb.Sqr/VerifyMFA",
+			RPS:     10, // 10 code attempts per minute per ip, same as login
+			Window:  time.Minute,
+			Scope:   "ip",
+		},
+		"/pb.Sqr/RequestPhoneVerification": {
+			Pattern: "/pb.Sqr/RequestPhoneVerification",
+			RPS:     3, // 3 sms per minute per user
+			Window:  time.Minute,
+			Scope:   "user",
+		},
+		"/pb.Sqr/ConfirmPhoneVerification": {
+			Pattern: "/pb.Sqr/ConfirmPhoneVerification",
+			RPS:     10, // 10 code attempts per minute per user
+			Window:  time.Minute,
+			Scope:   "user",
+		},
+		"/pb.Sqr/RequestLoginCode": {
+			Pattern: "/pb.Sqr/RequestLoginCode",
+			RPS:     5, // 5 code requests per minute per ip
+			Window:  time.Minute,
+			Scope:   "ip",
+		},
+		"/pb.Sqr/ExchangeLoginCode": {
+			Pattern: "/pb.Sqr/ExchangeLoginCode",
+			RPS:     10, // 10 code attempts per minute per ip, same as login
+			Window:  time.Minute,
+			Scope:   "ip",
+		},
+		"/pb.Sqr/SubmitNINVerification": {
+			Pattern: "/pb.Sqr/SubmitNINVerification",
+			RPS:     3, // 3 submissions per hour per user, each one is a paid lookup
+			Window:  time.Hour,
+			Scope:   "user",
+		},
+		"/pb.Sqr/LoginWithOIDC": {
+			Pattern: "/pb.Sqr/LoginWithOIDC",
+			RPS:     10, // 10 sign-ins per minute per ip, same as login
+			Window:  time.Minute,
+			Scope:   "ip",
+		},
+		"/pb.Sqr/UnlockUserAccount": {
+			Pattern: "/pb.Sqr/UnlockUserAccount",
+			RPS:     30, // 30 unlocks per minute per admin
+			Window:  time.Minute,
+			Scope:   "user",
+		},
 	}
 }
 
@@ -148,18 +239,38 @@ func (g *GRPCRateLimiter) getDefaultSingleRule() RateLimitRule {
 	}
 }
 
-func getUserIDFromContext(ctx context.Context) string {
-	if md, ok := metadata.FromIncomingContext(ctx); ok {
-		if userIDs := md.Get("user-id"); len(userIDs) > 0 {
-			return userIDs[0]
-		}
-	}
-	return ""
+type userIDContextKey struct{}
+
+// ContextWithUserID records the authenticated caller, whom the rules scoped to a user count requests of.
+// The interceptor must run after the caller is authenticated for those rules to apply per user.
+func ContextWithUserID(ctx context.Context, userID int64) context.Context {
+	return context.WithValue(ctx, userIDContextKey{}, userID)
+}
+
+func userIDFromContext(ctx context.Context) (int64, bool) {
+	userID, ok := ctx.Value(userIDContextKey{}).(int64)
+	return userID, ok
 }
 
 func getClientIPFromContext(ctx context.Context) string {
-	if p, ok := peer.FromContext(ctx); ok {
-		return strings.Split(p.Addr.String(), ":")[0]
+	p, ok := peer.FromContext(ctx)
+	if !ok {
+		return ""
 	}
-	return ""
+
+	ip := p.Addr.String()
+	if host, _, err := net.SplitHostPort(ip); err == nil {
+		ip = host
+	}
+
+	// The HTTP gateway connects over loopback and forwards the address of its client
+	if parsed := net.ParseIP(ip); parsed != nil && parsed.IsLoopback() {
+		if md, ok := metadata.FromIncomingContext(ctx); ok {
+			if forwardedFor := md.Get("x-forwarded-for"); len(forwardedFor) > 0 {
+				return strings.TrimSpace(strings.Split(forwardedFor[0], ",")[0])
+			}
+		}
+	}
+
+	return ip
 }
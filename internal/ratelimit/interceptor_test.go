@@ -0,0 +1,111 @@
+package ratelimit
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	"github.com/r-scheele/sqr/internal/util"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+)
+
+func TestGetRateLimitKey(t *testing.T) {
+	g := NewGRPCRateLimiter(nil, util.Config{})
+	ctx := peer.NewContext(context.Background(), &peer.Peer{Addr: &net.TCPAddr{IP: net.ParseIP("10.0.0.1"), Port: 5000}})
+
+	testCases := []struct {
+		name   string
+		ctx    context.Context
+		method string
+		key    string
+		rule   RateLimitRule
+	}{
+		{
+			name:   "UserRule",
+			ctx:    ContextWithUserID(ctx, 7),
+			method: "/pb.Sqr/SubmitNINVerification",
+			key:    "user:7:/pb.Sqr/SubmitNINVerification",
+			rule:   g.rules["/pb.Sqr/SubmitNINVerification"],
+		},
+		{
+			name:   "UserRuleWithoutCaller",
+			ctx:    ctx,
+			method: "/pb.Sqr/RefreshToken",
+			key:    "ip:10.0.0.1",
+			rule:   g.getDefaultSingleRule(),
+		},
+		{
+			name:   "IPRule",
+			ctx:    ContextWithUserID(ctx, 7),
+			method: "/pb.Sqr/LoginUser",
+			key:    "ip:10.0.0.1:/pb.Sqr/LoginUser",
+			rule:   g.rules["/pb.Sqr/LoginUser"],
+		},
+		{
+			name:   "DefaultRuleWithCaller",
+			ctx:    ContextWithUserID(ctx, 7),
+			method: "/pb.Sqr/ListProperties",
+			key:    "user:7",
+			rule:   g.getDefaultSingleRule(),
+		},
+		{
+			name:   "DefaultRuleWithoutCaller",
+			ctx:    ctx,
+			method: "/pb.Sqr/ListProperties",
+			key:    "ip:10.0.0.1",
+			rule:   g.getDefaultSingleRule(),
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			key, rule := g.getRateLimitKey(tc.ctx, tc.method)
+			require.Equal(t, tc.key, key)
+			require.Equal(t, tc.rule, rule)
+		})
+	}
+}
+
+func TestGetClientIPFromContext(t *testing.T) {
+	forwarded := metadata.Pairs("x-forwarded-for", "203.0.113.7, 10.0.0.2")
+
+	testCases := []struct {
+		name string
+		addr net.Addr
+		md   metadata.MD
+		ip   string
+	}{
+		{
+			name: "Gateway",
+			addr: &net.TCPAddr{IP: net.ParseIP("127.0.0.1"), Port: 51000},
+			md:   forwarded,
+			ip:   "203.0.113.7",
+		},
+		{
+			name: "ForwardedByRemotePeer",
+			addr: &net.TCPAddr{IP: net.ParseIP("198.51.100.2"), Port: 51000},
+			md:   forwarded,
+			ip:   "198.51.100.2",
+		},
+		{
+			name: "IPv6Peer",
+			addr: &net.TCPAddr{IP: net.ParseIP("2001:db8::1"), Port: 51000},
+			md:   metadata.MD{},
+			ip:   "2001:db8::1",
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := metadata.NewIncomingContext(context.Background(), tc.md)
+			ctx = peer.NewContext(ctx, &peer.Peer{Addr: tc.addr})
+			require.Equal(t, tc.ip, getClientIPFromContext(ctx))
+		})
+	}
+}
@@ -7,6 +7,7 @@ import (
 
 type Limiter interface {
 	Allow(ctx context.Context, key string) (*Result, error)
+	AllowWithLimits(ctx context.Context, key string, limit int64, window time.Duration) (*Result, error)
 	Reset(ctx context.Context, key string) error
 }
 
@@ -19,7 +20,7 @@ type Result struct {
 
 type RateLimitRule struct {
 	Pattern string        // URL pattern or user type
-	RPS     int           // Requests per second
+	RPS     int           // Requests per window
 	Burst   int           // Burst capacity
 	Window  time.Duration // Time window
 	Scope   string        // "ip", "user", "endpoint"
@@ -0,0 +1,71 @@
+package sms
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog/log"
+)
+
+type SMSSender interface {
+	SendSMS(to string, message string) error
+}
+
+// LogSender writes messages to the application log instead of delivering them.
+// It is meant for local development.
+type LogSender struct{}
+
+func NewLogSender() SMSSender {
+	return &LogSender{}
+}
+
+func (sender *LogSender) SendSMS(to string, message string) error {
+	log.Info().Str("to", to).Str("message", message).Msg("sms sent")
+	return nil
+}
+
+// FileSender appends messages to an outbox file so they can be read back in development and tests
+type FileSender struct {
+	path string
+	mu   sync.Mutex
+}
+
+func NewFileSender(path string) SMSSender {
+	return &FileSender{
+		path: path,
+	}
+}
+
+func (sender *FileSender) SendSMS(to string, message string) error {
+	sender.mu.Lock()
+	defer sender.mu.Unlock()
+
+	err := os.MkdirAll(filepath.Dir(sender.path), 0o755)
+	if err != nil {
+		return fmt.Errorf("failed to create sms outbox directory: %w", err)
+	}
+
+	file, err := os.OpenFile(sender.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o600)
+	if err != nil {
+		return fmt.Errorf("failed to open sms outbox %s: %w", sender.path, err)
+	}
+	defer file.Close()
+
+	_, err = fmt.Fprintf(file, "%s\t%s\t%s\n", time.Now().UTC().Format(time.RFC3339), to, message)
+	if err != nil {
+		return fmt.Errorf("failed to write sms to outbox: %w", err)
+	}
+
+	return nil
+}
+
+// NewSender returns the sender configured by senderType: "file" writes to outboxPath, anything else logs
+func NewSender(senderType string, outboxPath string) SMSSender {
+	if senderType == "file" && outboxPath != "" {
+		return NewFileSender(outboxPath)
+	}
+	return NewLogSender()
+}
@@ -0,0 +1,35 @@
+package sms
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSendSMSWithFileSender(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "sms", "outbox.log")
+	sender := NewFileSender(path)
+
+	err := sender.SendSMS("+2348012345678", "Your SQR verification code is 123456")
+	require.NoError(t, err)
+
+	err = sender.SendSMS("+2348087654321", "Your SQR verification code is 654321")
+	require.NoError(t, err)
+
+	content, err := os.ReadFile(path)
+	require.NoError(t, err)
+
+	lines := strings.Split(strings.TrimSpace(string(content)), "\n")
+	require.Len(t, lines, 2)
+	require.Contains(t, lines[0], "+2348012345678\tYour SQR verification code is 123456")
+	require.Contains(t, lines[1], "+2348087654321\tYour SQR verification code is 654321")
+}
+
+func TestNewSender(t *testing.T) {
+	require.IsType(t, &FileSender{}, NewSender("file", filepath.Join(t.TempDir(), "outbox.log")))
+	require.IsType(t, &LogSender{}, NewSender("file", ""))
+	require.IsType(t, &LogSender{}, NewSender("log", ""))
+}
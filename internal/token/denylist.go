@@ -0,0 +1,89 @@
+package token
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/r-scheele/sqr/internal/cache"
+)
+
+// Denylist keeps track of tokens that were revoked before they expired
+type Denylist interface {
+	// RevokeToken revokes a single token until it expires
+	RevokeToken(ctx context.Context, payload *Payload) error
+
+	// RevokeSession revokes every token issued for a session
+	RevokeSession(ctx context.Context, sessionID uuid.UUID, ttl time.Duration) error
+
+	// RevokeUser revokes every token issued to a user up to now
+	RevokeUser(ctx context.Context, userID int64, ttl time.Duration) error
+
+	// IsRevoked checks if the token has been revoked
+	IsRevoked(ctx context.Context, payload *Payload) (bool, error)
+}
+
+// CacheDenylist is a Denylist stored in the cache. Entries only need to live
+// as long as the tokens they revoke, so they expire on their own.
+type CacheDenylist struct {
+	cache cache.CacheManager
+}
+
+// NewCacheDenylist creates a new CacheDenylist
+func NewCacheDenylist(cache cache.CacheManager) Denylist {
+	return &CacheDenylist{
+		cache: cache,
+	}
+}
+
+func (denylist *CacheDenylist) RevokeToken(ctx context.Context, payload *Payload) error {
+	ttl := time.Until(payload.ExpiredAt)
+	if ttl <= 0 {
+		// Already expired, nothing to revoke
+		return nil
+	}
+
+	return denylist.cache.Set(ctx, cache.RevokedTokenKey(payload.ID.String()), []byte("1"), ttl)
+}
+
+func (denylist *CacheDenylist) RevokeSession(ctx context.Context, sessionID uuid.UUID, ttl time.Duration) error {
+	if ttl <= 0 {
+		return nil
+	}
+
+	return denylist.cache.Set(ctx, cache.RevokedSessionKey(sessionID.String()), []byte("1"), ttl)
+}
+
+func (denylist *CacheDenylist) RevokeUser(ctx context.Context, userID int64, ttl time.Duration) error {
+	if ttl <= 0 {
+		return nil
+	}
+
+	// The user keeps using the service afterwards, so only tokens
+	// issued before this moment are revoked
+	return denylist.cache.SetJSON(ctx, cache.RevokedUserKey(userID), time.Now(), ttl)
+}
+
+func (denylist *CacheDenylist) IsRevoked(ctx context.Context, payload *Payload) (bool, error) {
+	revoked, err := denylist.cache.Exists(ctx, cache.RevokedTokenKey(payload.ID.String()))
+	if err != nil || revoked {
+		return revoked, err
+	}
+
+	revoked, err = denylist.cache.Exists(ctx, cache.RevokedSessionKey(payload.SessionID.String()))
+	if err != nil || revoked {
+		return revoked, err
+	}
+
+	var revokedAt time.Time
+	err = denylist.cache.GetJSON(ctx, cache.RevokedUserKey(payload.UserID), &revokedAt)
+	if err != nil {
+		if errors.Is(err, cache.ErrCacheNotFound) || errors.Is(err, cache.ErrCacheMiss) {
+			return false, nil
+		}
+		return false, err
+	}
+
+	return payload.IssuedAt.Before(revokedAt), nil
+}
@@ -0,0 +1,95 @@
+package token
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/r-scheele/sqr/internal/cache"
+	"github.com/r-scheele/sqr/internal/util"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestDenylist(t *testing.T) Denylist {
+	memoryCache := cache.NewMemoryCache(util.Config{
+		MemoryCacheSize:            100,
+		MemoryCacheTTL:             time.Minute,
+		MemoryCacheCleanUpInterval: time.Minute,
+	})
+	t.Cleanup(func() { memoryCache.Close() })
+
+	return NewCacheDenylist(cache.NewManager(memoryCache))
+}
+
+func TestDenylistRevokeToken(t *testing.T) {
+	denylist := newTestDenylist(t)
+	ctx := context.Background()
+
+	payload, err := NewPayload(util.RandomInt(1, 1000), util.RandomEmail(), util.TenantRole, uuid.New(), time.Minute, TokenTypeAccessToken)
+	require.NoError(t, err)
+
+	other, err := NewPayload(payload.UserID, payload.Email, payload.Role, payload.SessionID, time.Minute, TokenTypeAccessToken)
+	require.NoError(t, err)
+
+	revoked, err := denylist.IsRevoked(ctx, payload)
+	require.NoError(t, err)
+	require.False(t, revoked)
+
+	err = denylist.RevokeToken(ctx, payload)
+	require.NoError(t, err)
+
+	revoked, err = denylist.IsRevoked(ctx, payload)
+	require.NoError(t, err)
+	require.True(t, revoked)
+
+	revoked, err = denylist.IsRevoked(ctx, other)
+	require.NoError(t, err)
+	require.False(t, revoked)
+}
+
+func TestDenylistRevokeSession(t *testing.T) {
+	denylist := newTestDenylist(t)
+	ctx := context.Background()
+
+	sessionID := uuid.New()
+	payload, err := NewPayload(util.RandomInt(1, 1000), util.RandomEmail(), util.TenantRole, sessionID, time.Minute, TokenTypeAccessToken)
+	require.NoError(t, err)
+
+	other, err := NewPayload(payload.UserID, payload.Email, payload.Role, uuid.New(), time.Minute, TokenTypeAccessToken)
+	require.NoError(t, err)
+
+	err = denylist.RevokeSession(ctx, sessionID, time.Minute)
+	require.NoError(t, err)
+
+	revoked, err := denylist.IsRevoked(ctx, payload)
+	require.NoError(t, err)
+	require.True(t, revoked)
+
+	revoked, err = denylist.IsRevoked(ctx, other)
+	require.NoError(t, err)
+	require.False(t, revoked)
+}
+
+func TestDenylistRevokeUser(t *testing.T) {
+	denylist := newTestDenylist(t)
+	ctx := context.Background()
+
+	userID := util.RandomInt(1, 1000)
+	before, err := NewPayload(userID, util.RandomEmail(), util.TenantRole, uuid.New(), time.Minute, TokenTypeAccessToken)
+	require.NoError(t, err)
+
+	err = denylist.RevokeUser(ctx, userID, time.Minute)
+	require.NoError(t, err)
+
+	after, err := NewPayload(userID, before.Email, before.Role, uuid.New(), time.Minute, TokenTypeAccessToken)
+	require.NoError(t, err)
+
+	revoked, err := denylist.IsRevoked(ctx, before)
+	require.NoError(t, err)
+	require.True(t, revoked)
+
+	revoked, err = denylist.IsRevoked(ctx, after)
+	require.NoError(t, err)
+	require.False(t, revoked)
+}
@@ -0,0 +1,79 @@
+package token
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"fmt"
+	"time"
+
+	"github.com/aead/chacha20poly1305"
+	"github.com/google/uuid"
+	"github.com/o1egl/paseto"
+)
+
+// DownloadPayload is carried by the token of an expiring download link
+type DownloadPayload struct {
+	ID         uuid.UUID `json:"id"`
+	DocumentID int64     `json:"document_id"`
+	UserID     int64     `json:"user_id"` // who the link was issued to
+	IssuedAt   time.Time `json:"issued_at"`
+	ExpiredAt  time.Time `json:"expired_at"`
+}
+
+// DownloadSigner issues the tokens of download links. The links are handed out without a session, so their
+// tokens are encrypted with a key derived from the symmetric key and can't be mistaken for access tokens.
+type DownloadSigner struct {
+	paseto *paseto.V2
+	key    []byte
+}
+
+// NewDownloadSigner creates a new DownloadSigner from the symmetric key of the token maker
+func NewDownloadSigner(symmetricKey string) (*DownloadSigner, error) {
+	if len(symmetricKey) != chacha20poly1305.KeySize {
+		return nil, fmt.Errorf("invalid key size: must be exactly %d characters", chacha20poly1305.KeySize)
+	}
+
+	mac := hmac.New(sha256.New, []byte(symmetricKey))
+	mac.Write([]byte("sqr document download"))
+
+	signer := &DownloadSigner{
+		paseto: paseto.NewV2(),
+		key:    mac.Sum(nil),
+	}
+	return signer, nil
+}
+
+// CreateDownloadToken creates a token that lets anyone holding it download a document until it expires
+func (signer *DownloadSigner) CreateDownloadToken(documentID int64, userID int64, duration time.Duration) (string, *DownloadPayload, error) {
+	tokenID, err := uuid.NewRandom()
+	if err != nil {
+		return "", nil, err
+	}
+
+	payload := &DownloadPayload{
+		ID:         tokenID,
+		DocumentID: documentID,
+		UserID:     userID,
+		IssuedAt:   time.Now(),
+		ExpiredAt:  time.Now().Add(duration),
+	}
+
+	token, err := signer.paseto.Encrypt(signer.key, payload, nil)
+	return token, payload, err
+}
+
+// VerifyDownloadToken checks that a download token was issued by this signer and has not expired
+func (signer *DownloadSigner) VerifyDownloadToken(token string) (*DownloadPayload, error) {
+	payload := &DownloadPayload{}
+
+	err := signer.paseto.Decrypt(token, signer.key, payload, nil)
+	if err != nil || payload.DocumentID == 0 {
+		return nil, ErrInvalidToken
+	}
+
+	if time.Now().After(payload.ExpiredAt) {
+		return nil, ErrExpiredToken
+	}
+
+	return payload, nil
+}
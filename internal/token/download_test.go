@@ -0,0 +1,60 @@
+package token
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/r-scheele/sqr/internal/util"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDownloadSigner(t *testing.T) {
+	signer, err := NewDownloadSigner(util.RandomString(32))
+	require.NoError(t, err)
+
+	documentID := util.RandomInt(1, 1000)
+	userID := util.RandomInt(1, 1000)
+
+	token, payload, err := signer.CreateDownloadToken(documentID, userID, time.Minute)
+	require.NoError(t, err)
+	require.NotEmpty(t, token)
+
+	verified, err := signer.VerifyDownloadToken(token)
+	require.NoError(t, err)
+	require.Equal(t, payload.ID, verified.ID)
+	require.Equal(t, documentID, verified.DocumentID)
+	require.Equal(t, userID, verified.UserID)
+	require.WithinDuration(t, time.Now().Add(time.Minute), verified.ExpiredAt, time.Second)
+}
+
+func TestExpiredDownloadToken(t *testing.T) {
+	signer, err := NewDownloadSigner(util.RandomString(32))
+	require.NoError(t, err)
+
+	token, _, err := signer.CreateDownloadToken(util.RandomInt(1, 1000), util.RandomInt(1, 1000), -time.Minute)
+	require.NoError(t, err)
+
+	payload, err := signer.VerifyDownloadToken(token)
+	require.ErrorIs(t, err, ErrExpiredToken)
+	require.Nil(t, payload)
+}
+
+func TestDownloadTokenIsNotAnAccessToken(t *testing.T) {
+	key := util.RandomString(32)
+
+	signer, err := NewDownloadSigner(key)
+	require.NoError(t, err)
+	maker, err := NewPasetoMaker(key)
+	require.NoError(t, err)
+
+	downloadToken, _, err := signer.CreateDownloadToken(util.RandomInt(1, 1000), util.RandomInt(1, 1000), time.Minute)
+	require.NoError(t, err)
+	_, err = maker.VerifyToken(downloadToken, TokenTypeAccessToken)
+	require.ErrorIs(t, err, ErrInvalidToken)
+
+	accessToken, _, err := maker.CreateToken(util.RandomInt(1, 1000), util.RandomEmail(), util.TenantRole, uuid.New(), time.Minute, TokenTypeAccessToken)
+	require.NoError(t, err)
+	_, err = signer.VerifyDownloadToken(accessToken)
+	require.ErrorIs(t, err, ErrInvalidToken)
+}
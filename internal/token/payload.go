@@ -19,20 +19,25 @@ type TokenType byte
 const (
 	TokenTypeAccessToken  = 1
 	TokenTypeRefreshToken = 2
+	// TokenTypeMFAPending is issued after a correct password when the account has MFA enabled.
+	// It can only be exchanged for an access/refresh token pair through VerifyMFA.
+	TokenTypeMFAPending = 3
 )
 
 // Payload contains the payload data of the token
 type Payload struct {
 	ID        uuid.UUID `json:"id"`
 	Type      TokenType `json:"token_type"`
-	Username  string    `json:"username"`
+	UserID    int64     `json:"user_id"`
+	SessionID uuid.UUID `json:"session_id"`
+	Email     string    `json:"email"`
 	Role      string    `json:"role"`
 	IssuedAt  time.Time `json:"issued_at"`
 	ExpiredAt time.Time `json:"expired_at"`
 }
 
-// NewPayload creates a new token payload with a specific username and duration
-func NewPayload(username string, role string, duration time.Duration, tokenType TokenType) (*Payload, error) {
+// NewPayload creates a new token payload for a specific user, session and duration
+func NewPayload(userID int64, email string, role string, sessionID uuid.UUID, duration time.Duration, tokenType TokenType) (*Payload, error) {
 	tokenID, err := uuid.NewRandom()
 	if err != nil {
 		return nil, err
@@ -41,7 +46,9 @@ func NewPayload(username string, role string, duration time.Duration, tokenType
 	payload := &Payload{
 		ID:        tokenID,
 		Type:      tokenType,
-		Username:  username,
+		UserID:    userID,
+		SessionID: sessionID,
+		Email:     email,
 		Role:      role,
 		IssuedAt:  time.Now(),
 		ExpiredAt: time.Now().Add(duration),
@@ -0,0 +1,196 @@
+package util
+
+import (
+	"fmt"
+	"sort"
+	"time"
+)
+
+const (
+	// AvailabilityTimezone is the timezone agent calendars and inspection times are kept in
+	AvailabilityTimezone = "Africa/Lagos"
+	// InspectionDuration is how long a confirmed inspection keeps an agent busy
+	InspectionDuration = time.Hour
+
+	clockLayout = "15:04"
+	dateLayout  = "2006-01-02"
+)
+
+// availabilityLocation falls back to West Africa Time, which Lagos keeps all year,
+// when the zone database isn't installed
+var availabilityLocation = func() *time.Location {
+	loc, err := time.LoadLocation(AvailabilityTimezone)
+	if err != nil {
+		return time.FixedZone("WAT", 60*60)
+	}
+	return loc
+}()
+
+// AvailabilityLocation returns the location of AvailabilityTimezone
+func AvailabilityLocation() *time.Location {
+	return availabilityLocation
+}
+
+// ParseClockTime parses a "15:04" time of day into its offset from midnight
+func ParseClockTime(value string) (time.Duration, error) {
+	t, err := time.Parse(clockLayout, value)
+	if err != nil {
+		return 0, fmt.Errorf("invalid time of day %q: %w", value, err)
+	}
+	return time.Duration(t.Hour())*time.Hour + time.Duration(t.Minute())*time.Minute, nil
+}
+
+// FormatClockTime formats an offset from midnight as "15:04"
+func FormatClockTime(offset time.Duration) string {
+	return time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC).Add(offset).Format(clockLayout)
+}
+
+// ParseDate parses a "2006-01-02" date into its midnight in AvailabilityTimezone
+func ParseDate(value string) (time.Time, error) {
+	date, err := time.ParseInLocation(dateLayout, value, availabilityLocation)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid date %q: %w", value, err)
+	}
+	return date, nil
+}
+
+// FormatDate formats a date as "2006-01-02"
+func FormatDate(date time.Time) string {
+	return date.Format(dateLayout)
+}
+
+// ClockRange is a range of times of day, as offsets from midnight
+type ClockRange struct {
+	Start time.Duration
+	End   time.Duration
+}
+
+// WeeklyWindow is a range of time an agent is available every week on Weekday
+type WeeklyWindow struct {
+	Weekday time.Weekday
+	ClockRange
+}
+
+// AvailabilityException changes the weekly windows from StartDate to EndDate, both included.
+// An exception without Times covers whole days; unavailable ones are blackout dates.
+type AvailabilityException struct {
+	StartDate time.Time
+	EndDate   time.Time
+	Times     *ClockRange
+	Available bool
+}
+
+// TimeSlot is a range of time in AvailabilityTimezone
+type TimeSlot struct {
+	Start time.Time
+	End   time.Time
+}
+
+// AvailabilityCalendar is the weekly availability of an agent along with its exceptions
+type AvailabilityCalendar struct {
+	Windows    []WeeklyWindow
+	Exceptions []AvailabilityException
+}
+
+// FreeSlots cuts the time the agent is available on the days from from to to into slots of slotLength.
+// Slots that overlap booked or start before now are left out.
+func (calendar AvailabilityCalendar) FreeSlots(from, to time.Time, slotLength time.Duration, booked []TimeSlot, now time.Time) []TimeSlot {
+	slots := []TimeSlot{}
+	if slotLength <= 0 {
+		return slots
+	}
+
+	from = startOfDay(from)
+	to = startOfDay(to)
+	for day := from; !day.After(to); day = day.AddDate(0, 0, 1) {
+		for _, free := range subtractSlots(calendar.openSlots(day), booked) {
+			for start := free.Start; !start.Add(slotLength).After(free.End); start = start.Add(slotLength) {
+				if start.Before(now) {
+					continue
+				}
+				slots = append(slots, TimeSlot{Start: start, End: start.Add(slotLength)})
+			}
+		}
+	}
+
+	return slots
+}
+
+// openSlots returns the merged ranges of time the agent is available on day
+func (calendar AvailabilityCalendar) openSlots(day time.Time) []TimeSlot {
+	var open, closed []TimeSlot
+	for _, window := range calendar.Windows {
+		if window.Weekday == day.Weekday() {
+			open = append(open, clockSlot(day, window.ClockRange))
+		}
+	}
+
+	for _, exception := range calendar.Exceptions {
+		if day.Before(startOfDay(exception.StartDate)) || day.After(startOfDay(exception.EndDate)) {
+			continue
+		}
+
+		times := ClockRange{Start: 0, End: 24 * time.Hour}
+		if exception.Times != nil {
+			times = *exception.Times
+		}
+
+		if exception.Available {
+			open = append(open, clockSlot(day, times))
+		} else {
+			closed = append(closed, clockSlot(day, times))
+		}
+	}
+
+	return subtractSlots(mergeSlots(open), closed)
+}
+
+func startOfDay(t time.Time) time.Time {
+	t = t.In(availabilityLocation)
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, availabilityLocation)
+}
+
+func clockSlot(day time.Time, times ClockRange) TimeSlot {
+	return TimeSlot{Start: day.Add(times.Start), End: day.Add(times.End)}
+}
+
+// mergeSlots sorts slots and joins the ones that overlap or touch
+func mergeSlots(slots []TimeSlot) []TimeSlot {
+	sort.Slice(slots, func(i, j int) bool { return slots[i].Start.Before(slots[j].Start) })
+
+	var merged []TimeSlot
+	for _, slot := range slots {
+		last := len(merged) - 1
+		if last >= 0 && !slot.Start.After(merged[last].End) {
+			if slot.End.After(merged[last].End) {
+				merged[last].End = slot.End
+			}
+			continue
+		}
+		merged = append(merged, slot)
+	}
+
+	return merged
+}
+
+// subtractSlots removes the time covered by taken from slots
+func subtractSlots(slots []TimeSlot, taken []TimeSlot) []TimeSlot {
+	for _, t := range taken {
+		var remaining []TimeSlot
+		for _, slot := range slots {
+			if !t.Start.Before(slot.End) || !t.End.After(slot.Start) {
+				remaining = append(remaining, slot)
+				continue
+			}
+			if slot.Start.Before(t.Start) {
+				remaining = append(remaining, TimeSlot{Start: slot.Start, End: t.Start})
+			}
+			if t.End.Before(slot.End) {
+				remaining = append(remaining, TimeSlot{Start: t.End, End: slot.End})
+			}
+		}
+		slots = remaining
+	}
+
+	return slots
+}
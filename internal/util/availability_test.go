@@ -0,0 +1,81 @@
+package util
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func mustParseDate(t *testing.T, value string) time.Time {
+	date, err := ParseDate(value)
+	require.NoError(t, err)
+	return date
+}
+
+func TestClockTime(t *testing.T) {
+	offset, err := ParseClockTime("09:30")
+	require.NoError(t, err)
+	require.Equal(t, 9*time.Hour+30*time.Minute, offset)
+	require.Equal(t, "09:30", FormatClockTime(offset))
+
+	_, err = ParseClockTime("25:00")
+	require.Error(t, err)
+}
+
+func TestFreeSlots(t *testing.T) {
+	// 2026-06-01 is a Monday
+	monday := mustParseDate(t, "2026-06-01")
+	tuesday := mustParseDate(t, "2026-06-02")
+	wednesday := mustParseDate(t, "2026-06-03")
+	longAgo := monday.AddDate(-1, 0, 0)
+
+	calendar := AvailabilityCalendar{
+		Windows: []WeeklyWindow{
+			{Weekday: time.Monday, ClockRange: ClockRange{Start: 9 * time.Hour, End: 12 * time.Hour}},
+			{Weekday: time.Tuesday, ClockRange: ClockRange{Start: 9 * time.Hour, End: 11 * time.Hour}},
+		},
+	}
+
+	t.Run("WeeklyWindows", func(t *testing.T) {
+		slots := calendar.FreeSlots(monday, wednesday, time.Hour, nil, longAgo)
+		require.Len(t, slots, 5)
+		require.Equal(t, monday.Add(9*time.Hour), slots[0].Start)
+		require.Equal(t, tuesday.Add(10*time.Hour), slots[4].Start)
+		require.Equal(t, AvailabilityLocation(), slots[0].Start.Location())
+	})
+
+	t.Run("BookedInspection", func(t *testing.T) {
+		booked := []TimeSlot{{Start: monday.Add(10 * time.Hour), End: monday.Add(11 * time.Hour)}}
+		slots := calendar.FreeSlots(monday, monday, time.Hour, booked, longAgo)
+		require.Equal(t, []TimeSlot{
+			{Start: monday.Add(9 * time.Hour), End: monday.Add(10 * time.Hour)},
+			{Start: monday.Add(11 * time.Hour), End: monday.Add(12 * time.Hour)},
+		}, slots)
+	})
+
+	t.Run("Blackout", func(t *testing.T) {
+		blackout := calendar
+		blackout.Exceptions = []AvailabilityException{{StartDate: monday, EndDate: monday}}
+		slots := blackout.FreeSlots(monday, tuesday, time.Hour, nil, longAgo)
+		require.Len(t, slots, 2)
+		require.Equal(t, tuesday.Add(9*time.Hour), slots[0].Start)
+	})
+
+	t.Run("Exceptions", func(t *testing.T) {
+		changed := calendar
+		changed.Exceptions = []AvailabilityException{
+			{StartDate: monday, EndDate: monday, Times: &ClockRange{Start: 9 * time.Hour, End: 10 * time.Hour}},
+			{StartDate: wednesday, EndDate: wednesday, Times: &ClockRange{Start: 14 * time.Hour, End: 15 * time.Hour}, Available: true},
+		}
+		slots := changed.FreeSlots(monday, wednesday, time.Hour, nil, longAgo)
+		require.Len(t, slots, 5)
+		require.Equal(t, monday.Add(10*time.Hour), slots[0].Start)
+		require.Equal(t, wednesday.Add(14*time.Hour), slots[4].Start)
+	})
+
+	t.Run("PastSlots", func(t *testing.T) {
+		slots := calendar.FreeSlots(monday, monday, 30*time.Minute, nil, monday.Add(11*time.Hour+time.Minute))
+		require.Equal(t, []TimeSlot{{Start: monday.Add(11*time.Hour + 30*time.Minute), End: monday.Add(12 * time.Hour)}}, slots)
+	})
+}
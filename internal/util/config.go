@@ -19,6 +19,8 @@ type Config struct {
 	TokenSymmetricKey    string        `mapstructure:"TOKEN_SYMMETRIC_KEY"`
 	AccessTokenDuration  time.Duration `mapstructure:"ACCESS_TOKEN_DURATION"`
 	RefreshTokenDuration time.Duration `mapstructure:"REFRESH_TOKEN_DURATION"`
+	MFATokenDuration     time.Duration `mapstructure:"MFA_TOKEN_DURATION"` // life of the mfa_pending token issued by LoginUser
+	MFAIssuer            string        `mapstructure:"MFA_ISSUER"`         // issuer shown in authenticator apps
 
 	RedisAddress      string        `mapstructure:"REDIS_ADDRESS"`
 	RedisPassword     string        `mapstructure:"REDIS_PASSWORD"`
@@ -42,6 +44,49 @@ type Config struct {
 	EmailSenderAddress  string `mapstructure:"EMAIL_SENDER_ADDRESS"`
 	EmailSenderPassword string `mapstructure:"EMAIL_SENDER_PASSWORD"`
 
+	SMSSenderType string `mapstructure:"SMS_SENDER_TYPE"` // log, file
+	SMSOutboxPath string `mapstructure:"SMS_OUTBOX_PATH"` // used by the file sender
+
+	IdentityProvider      string `mapstructure:"IDENTITY_PROVIDER"`       // fake
+	IdentityEncryptionKey string `mapstructure:"IDENTITY_ENCRYPTION_KEY"` // 32 characters, encrypts NINs and TOTP secrets at rest
+
+	GoogleClientID     string `mapstructure:"GOOGLE_CLIENT_ID"` // sign in with google is off when empty
+	GoogleClientSecret string `mapstructure:"GOOGLE_CLIENT_SECRET"`
+	GoogleJWKSURL      string `mapstructure:"GOOGLE_JWKS_URL"`  // https url or local jwks file, defaults to google's keys
+	GoogleTokenURL     string `mapstructure:"GOOGLE_TOKEN_URL"` // defaults to google's token endpoint
+
+	AccountExportTTL           time.Duration `mapstructure:"ACCOUNT_EXPORT_TTL"`            // how long an export can be downloaded
+	AccountDeletionGracePeriod time.Duration `mapstructure:"ACCOUNT_DELETION_GRACE_PERIOD"` // time to cancel a deletion before data is erased
+
+	BlobStoreType   string        `mapstructure:"BLOB_STORE_TYPE"`   // local (default) or memory
+	BlobStorePath   string        `mapstructure:"BLOB_STORE_PATH"`   // root directory of the local store, defaults to data/blobs
+	DocumentMaxSize int64         `mapstructure:"DOCUMENT_MAX_SIZE"` // in bytes, defaults to 10 MiB
+	DocumentURLTTL  time.Duration `mapstructure:"DOCUMENT_URL_TTL"`  // how long a document download link works, defaults to 15 minutes
+
+	LandlordPublishMinCompletion int32  `mapstructure:"LANDLORD_PUBLISH_MIN_COMPLETION"` // profile completion score needed to publish properties, defaults to 60
+	ProfileNudgeSchedule         string `mapstructure:"PROFILE_NUDGE_SCHEDULE"`          // cron spec in Africa/Lagos for emailing incomplete profiles, defaults to mondays at 9am
+
+	SearchCacheCleanupSchedule   string `mapstructure:"SEARCH_CACHE_CLEANUP_SCHEDULE"`   // cron spec in Africa/Lagos for deleting stale cached searches, defaults to hourly
+	AccountDeletionSweepSchedule string `mapstructure:"ACCOUNT_DELETION_SWEEP_SCHEDULE"` // cron spec in Africa/Lagos for deleting accounts past their grace period, defaults to hourly
+
+	PasswordMinLength           int    `mapstructure:"PASSWORD_MIN_LENGTH"`            // defaults to 8
+	PasswordMinCharacterClasses int    `mapstructure:"PASSWORD_MIN_CHARACTER_CLASSES"` // of lower, upper, digits and symbols, defaults to 3
+	BreachedPasswordsFile       string `mapstructure:"BREACHED_PASSWORDS_FILE"`        // passwords or sha-1 hashes, one per line; off when empty
+
+	PasswordHashAlgorithm string `mapstructure:"PASSWORD_HASH_ALGORITHM"` // bcrypt (default) or argon2id; older hashes are upgraded at login
+	PasswordBcryptCost    int    `mapstructure:"PASSWORD_BCRYPT_COST"`    // defaults to bcrypt.DefaultCost
+
+	OTPDuration       time.Duration `mapstructure:"OTP_DURATION"`        // how long a one-time code stays valid
+	OTPMaxAttempts    int           `mapstructure:"OTP_MAX_ATTEMPTS"`    // wrong guesses before a code is burned
+	OTPResendInterval time.Duration `mapstructure:"OTP_RESEND_INTERVAL"` // minimum time between two codes
+	OTPHashKey        string        `mapstructure:"OTP_HASH_KEY"`        // keys the hashes codes are stored under, TOKEN_SYMMETRIC_KEY when empty
+
+	LoginMaxFailedAttempts      int           `mapstructure:"LOGIN_MAX_FAILED_ATTEMPTS"`        // per account, before it gets locked
+	LoginMaxFailedAttemptsPerIP int           `mapstructure:"LOGIN_MAX_FAILED_ATTEMPTS_PER_IP"` // per ip, within LoginFailureWindow
+	LoginFailureWindow          time.Duration `mapstructure:"LOGIN_FAILURE_WINDOW"`
+	LoginLockoutDuration        time.Duration `mapstructure:"LOGIN_LOCKOUT_DURATION"`     // first lockout, doubled for each one after
+	LoginMaxLockoutDuration     time.Duration `mapstructure:"LOGIN_MAX_LOCKOUT_DURATION"` // upper bound for the backoff
+
 	RateLimitEnabled      bool          `mapstructure:"RATE_LIMIT_ENABLED"`
 	RateLimitDefaultRPS   int           `mapstructure:"RATE_LIMIT_DEFAULT_RPS"`
 	RateLimitDefaultBurst int           `mapstructure:"RATE_LIMIT_DEFAULT_BURST"`
@@ -0,0 +1,40 @@
+package util
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestEncryptField(t *testing.T) {
+	key := RandomString(EncryptionKeySize)
+	plaintext := "12345678901"
+
+	ciphertext1, err := EncryptField(key, plaintext)
+	require.NoError(t, err)
+	require.NotContains(t, ciphertext1, plaintext)
+
+	ciphertext2, err := EncryptField(key, plaintext)
+	require.NoError(t, err)
+	require.NotEqual(t, ciphertext1, ciphertext2)
+
+	decrypted, err := DecryptField(key, ciphertext1)
+	require.NoError(t, err)
+	require.Equal(t, plaintext, decrypted)
+}
+
+func TestDecryptFieldWrongKey(t *testing.T) {
+	ciphertext, err := EncryptField(RandomString(EncryptionKeySize), "12345678901")
+	require.NoError(t, err)
+
+	_, err = DecryptField(RandomString(EncryptionKeySize), ciphertext)
+	require.ErrorIs(t, err, ErrInvalidCiphertext)
+
+	_, err = DecryptField(RandomString(EncryptionKeySize), "not base64!")
+	require.ErrorIs(t, err, ErrInvalidCiphertext)
+}
+
+func TestEncryptFieldInvalidKey(t *testing.T) {
+	_, err := EncryptField("short", "12345678901")
+	require.Error(t, err)
+}
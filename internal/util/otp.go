@@ -0,0 +1,35 @@
+package util
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"math/big"
+	"strconv"
+)
+
+// GenerateNumericCode returns a random numeric one-time code of n digits
+func GenerateNumericCode(n int) (string, error) {
+	max := big.NewInt(1)
+	for i := 0; i < n; i++ {
+		max.Mul(max, big.NewInt(10))
+	}
+
+	value, err := rand.Int(rand.Reader, max)
+	if err != nil {
+		return "", fmt.Errorf("failed to generate code: %w", err)
+	}
+
+	return fmt.Sprintf("%0*d", n, value.Int64()), nil
+}
+
+// HashOTPCode returns the hash under which a one-time code of the user is stored. A code has only a million
+// possible values, so it is an HMAC keyed by a server secret and bound to the user, which makes a leaked
+// hash useless without the key and for any other user.
+func HashOTPCode(key string, userID int64, code string) string {
+	mac := hmac.New(sha256.New, []byte(key))
+	mac.Write([]byte(strconv.FormatInt(userID, 10) + ":" + code))
+	return hex.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,25 @@
+package util
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestGenerateNumericCode(t *testing.T) {
+	for i := 0; i < 100; i++ {
+		code, err := GenerateNumericCode(6)
+		require.NoError(t, err)
+		require.Len(t, code, 6)
+		require.Regexp(t, `^\d{6}$`, code)
+	}
+}
+
+func TestHashOTPCode(t *testing.T) {
+	key := RandomString(32)
+
+	require.Equal(t, HashOTPCode(key, 1, "123456"), HashOTPCode(key, 1, "123456"))
+	require.NotEqual(t, HashOTPCode(key, 1, "123456"), HashOTPCode(key, 1, "123457"))
+	require.NotEqual(t, HashOTPCode(key, 1, "123456"), HashOTPCode(key, 2, "123456"))
+	require.NotEqual(t, HashOTPCode(key, 1, "123456"), HashOTPCode(RandomString(32), 1, "123456"))
+}
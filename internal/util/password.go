@@ -1,21 +1,181 @@
 package util
 
 import (
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base64"
+	"errors"
 	"fmt"
+	"strings"
 
+	"golang.org/x/crypto/argon2"
 	"golang.org/x/crypto/bcrypt"
 )
 
-// HashPassword returns the bcrypt hash of the password
-func HashPassword(password string) (string, error) {
-	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+const (
+	PasswordHashBcrypt   = "bcrypt"
+	PasswordHashArgon2id = "argon2id"
+)
+
+// ErrPasswordMismatch is returned when a password doesn't match its hash, whatever the algorithm
+var ErrPasswordMismatch = bcrypt.ErrMismatchedHashAndPassword
+
+// Argon2Params are the cost parameters of an argon2id hash. They are stored in every hash,
+// so changing them only affects new hashes and flags the old ones for rehashing.
+type Argon2Params struct {
+	Memory      uint32 // in KiB
+	Iterations  uint32
+	Parallelism uint8
+	SaltLength  uint32
+	KeyLength   uint32
+}
+
+// DefaultArgon2Params follow the second recommendation of RFC 9106 for memory-constrained servers
+var DefaultArgon2Params = Argon2Params{
+	Memory:      64 * 1024,
+	Iterations:  3,
+	Parallelism: 4,
+	SaltLength:  16,
+	KeyLength:   32,
+}
+
+// PasswordHasher hashes new passwords with one algorithm but checks hashes of any supported one.
+// bcrypt hashes carry their cost and argon2id hashes use the PHC string format
+// ($argon2id$v=19$m=...,t=...,p=...$salt$hash), so every stored hash says how it was made.
+type PasswordHasher struct {
+	Algorithm  string
+	BcryptCost int
+	Argon2     Argon2Params
+}
+
+var defaultPasswordHasher = PasswordHasher{
+	Algorithm:  PasswordHashBcrypt,
+	BcryptCost: bcrypt.DefaultCost,
+	Argon2:     DefaultArgon2Params,
+}
+
+// NewPasswordHasher returns a hasher for algorithm, bcrypt when it is empty. A zero bcryptCost means the default cost.
+func NewPasswordHasher(algorithm string, bcryptCost int) (PasswordHasher, error) {
+	hasher := defaultPasswordHasher
+	if algorithm != "" {
+		hasher.Algorithm = algorithm
+	}
+	if bcryptCost != 0 {
+		hasher.BcryptCost = bcryptCost
+	}
+
+	switch hasher.Algorithm {
+	case PasswordHashBcrypt, PasswordHashArgon2id:
+	default:
+		return hasher, fmt.Errorf("unsupported password hash algorithm %q", hasher.Algorithm)
+	}
+	if hasher.BcryptCost < bcrypt.MinCost || hasher.BcryptCost > bcrypt.MaxCost {
+		return hasher, fmt.Errorf("bcrypt cost must be between %d and %d", bcrypt.MinCost, bcrypt.MaxCost)
+	}
+
+	return hasher, nil
+}
+
+// Hash returns the hash of the password made with the configured algorithm
+func (hasher PasswordHasher) Hash(password string) (string, error) {
+	if hasher.Algorithm == PasswordHashArgon2id {
+		return hashArgon2id(password, hasher.Argon2)
+	}
+
+	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(password), hasher.BcryptCost)
 	if err != nil {
 		return "", fmt.Errorf("failed to hash password: %w", err)
 	}
 	return string(hashedPassword), nil
 }
 
+// Verify checks the password against a hash of any supported algorithm. needsRehash is set when the
+// password is correct but the hash wasn't made with the configured algorithm and parameters.
+func (hasher PasswordHasher) Verify(password string, hashedPassword string) (needsRehash bool, err error) {
+	if strings.HasPrefix(hashedPassword, "$"+PasswordHashArgon2id+"$") {
+		params, salt, key, err := decodeArgon2id(hashedPassword)
+		if err != nil {
+			return false, err
+		}
+
+		actual := argon2.IDKey([]byte(password), salt, params.Iterations, params.Memory, params.Parallelism, uint32(len(key)))
+		if subtle.ConstantTimeCompare(actual, key) != 1 {
+			return false, ErrPasswordMismatch
+		}
+
+		return hasher.Algorithm != PasswordHashArgon2id || params != hasher.Argon2, nil
+	}
+
+	err = bcrypt.CompareHashAndPassword([]byte(hashedPassword), []byte(password))
+	if err != nil {
+		return false, err
+	}
+
+	if hasher.Algorithm != PasswordHashBcrypt {
+		return true, nil
+	}
+	cost, err := bcrypt.Cost([]byte(hashedPassword))
+	if err != nil {
+		return false, err
+	}
+	return cost != hasher.BcryptCost, nil
+}
+
+// HashPassword returns the hash of the password made with the default hasher
+func HashPassword(password string) (string, error) {
+	return defaultPasswordHasher.Hash(password)
+}
+
 // CheckPassword checks if the provided password is correct or not
 func CheckPassword(password string, hashedPassword string) error {
-	return bcrypt.CompareHashAndPassword([]byte(hashedPassword), []byte(password))
+	_, err := defaultPasswordHasher.Verify(password, hashedPassword)
+	return err
+}
+
+func hashArgon2id(password string, params Argon2Params) (string, error) {
+	salt := make([]byte, params.SaltLength)
+	if _, err := rand.Read(salt); err != nil {
+		return "", fmt.Errorf("failed to generate salt: %w", err)
+	}
+
+	key := argon2.IDKey([]byte(password), salt, params.Iterations, params.Memory, params.Parallelism, params.KeyLength)
+
+	return fmt.Sprintf("$%s$v=%d$m=%d,t=%d,p=%d$%s$%s",
+		PasswordHashArgon2id, argon2.Version, params.Memory, params.Iterations, params.Parallelism,
+		base64.RawStdEncoding.EncodeToString(salt), base64.RawStdEncoding.EncodeToString(key)), nil
+}
+
+var errMalformedArgon2Hash = errors.New("malformed argon2id hash")
+
+func decodeArgon2id(hashedPassword string) (params Argon2Params, salt []byte, key []byte, err error) {
+	parts := strings.Split(hashedPassword, "$")
+	if len(parts) != 6 {
+		return params, nil, nil, errMalformedArgon2Hash
+	}
+
+	var version int
+	if _, err := fmt.Sscanf(parts[2], "v=%d", &version); err != nil {
+		return params, nil, nil, errMalformedArgon2Hash
+	}
+	if version != argon2.Version {
+		return params, nil, nil, fmt.Errorf("unsupported argon2 version %d", version)
+	}
+
+	_, err = fmt.Sscanf(parts[3], "m=%d,t=%d,p=%d", &params.Memory, &params.Iterations, &params.Parallelism)
+	if err != nil {
+		return params, nil, nil, errMalformedArgon2Hash
+	}
+
+	salt, err = base64.RawStdEncoding.DecodeString(parts[4])
+	if err != nil {
+		return params, nil, nil, errMalformedArgon2Hash
+	}
+	key, err = base64.RawStdEncoding.DecodeString(parts[5])
+	if err != nil || len(key) == 0 {
+		return params, nil, nil, errMalformedArgon2Hash
+	}
+
+	params.SaltLength = uint32(len(salt))
+	params.KeyLength = uint32(len(key))
+	return params, salt, key, nil
 }
@@ -1,6 +1,7 @@
 package util
 
 import (
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/require"
@@ -26,3 +27,54 @@ func TestPassword(t *testing.T) {
 	require.NotEmpty(t, hashedPassword2)
 	require.NotEqual(t, hashedPassword1, hashedPassword2)
 }
+
+func TestPasswordHasher(t *testing.T) {
+	password := RandomString(6)
+
+	argon2Hasher, err := NewPasswordHasher(PasswordHashArgon2id, 0)
+	require.NoError(t, err)
+
+	hashedPassword, err := argon2Hasher.Hash(password)
+	require.NoError(t, err)
+	require.True(t, strings.HasPrefix(hashedPassword, "$argon2id$v=19$m=65536,t=3,p=4$"))
+
+	needsRehash, err := argon2Hasher.Verify(password, hashedPassword)
+	require.NoError(t, err)
+	require.False(t, needsRehash)
+
+	_, err = argon2Hasher.Verify(RandomString(6), hashedPassword)
+	require.ErrorIs(t, err, ErrPasswordMismatch)
+
+	// The default hasher still checks argon2id hashes, but wants them back as bcrypt
+	require.NoError(t, CheckPassword(password, hashedPassword))
+	needsRehash, err = defaultPasswordHasher.Verify(password, hashedPassword)
+	require.NoError(t, err)
+	require.True(t, needsRehash)
+
+	// Old bcrypt hashes keep working and get upgraded
+	bcryptHash, err := HashPassword(password)
+	require.NoError(t, err)
+	needsRehash, err = argon2Hasher.Verify(password, bcryptHash)
+	require.NoError(t, err)
+	require.True(t, needsRehash)
+
+	strongerBcrypt, err := NewPasswordHasher(PasswordHashBcrypt, bcrypt.DefaultCost+1)
+	require.NoError(t, err)
+	needsRehash, err = strongerBcrypt.Verify(password, bcryptHash)
+	require.NoError(t, err)
+	require.True(t, needsRehash)
+
+	strongerArgon2 := argon2Hasher
+	strongerArgon2.Argon2.Iterations++
+	needsRehash, err = strongerArgon2.Verify(password, hashedPassword)
+	require.NoError(t, err)
+	require.True(t, needsRehash)
+
+	_, err = argon2Hasher.Verify(password, "$argon2id$v=19$m=65536$salt$hash")
+	require.Error(t, err)
+
+	_, err = NewPasswordHasher("md5", 0)
+	require.Error(t, err)
+	_, err = NewPasswordHasher(PasswordHashBcrypt, 64)
+	require.Error(t, err)
+}
@@ -36,6 +36,11 @@ func RandomOwner() string {
 	return RandomString(6)
 }
 
+// RandomPassword generates a random password that satisfies the default password policy
+func RandomPassword() string {
+	return strings.ToUpper(RandomString(1)) + RandomString(7) + fmt.Sprint(RandomInt(10, 99)) + "!"
+}
+
 // RandomMoney generates a random amount of money
 func RandomMoney() int64 {
 	return RandomInt(0, 1000)
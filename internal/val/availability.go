@@ -0,0 +1,64 @@
+package val
+
+import (
+	"fmt"
+	"time"
+)
+
+const (
+	// MaxAvailabilityWindows caps the weekly windows of an agent
+	MaxAvailabilityWindows = 50
+	// MaxAvailabilityExceptions caps the exceptions of an agent
+	MaxAvailabilityExceptions = 100
+	// MaxSlotSearchDays caps the range of dates free slots are searched in
+	MaxSlotSearchDays = 31
+)
+
+func ValidateDayOfWeek(day int32) error {
+	if day < 0 || day > 6 {
+		return fmt.Errorf("must be from 0 (Sunday) to 6 (Saturday)")
+	}
+	return nil
+}
+
+// ValidateClockRange checks a "15:04" start and end time where start comes first
+func ValidateClockRange(start, end string) error {
+	startTime, err := time.Parse("15:04", start)
+	if err != nil {
+		return fmt.Errorf("start time must be formatted as HH:MM")
+	}
+	endTime, err := time.Parse("15:04", end)
+	if err != nil {
+		return fmt.Errorf("end time must be formatted as HH:MM")
+	}
+	if !startTime.Before(endTime) {
+		return fmt.Errorf("start time must be before end time")
+	}
+	return nil
+}
+
+// ValidateDateRange checks a "2006-01-02" start and end date where start doesn't come after end
+func ValidateDateRange(start, end string, maxDays int) error {
+	startDate, err := time.Parse("2006-01-02", start)
+	if err != nil {
+		return fmt.Errorf("start date must be formatted as YYYY-MM-DD")
+	}
+	endDate, err := time.Parse("2006-01-02", end)
+	if err != nil {
+		return fmt.Errorf("end date must be formatted as YYYY-MM-DD")
+	}
+	if endDate.Before(startDate) {
+		return fmt.Errorf("end date must not be before start date")
+	}
+	if maxDays > 0 && endDate.Sub(startDate) >= time.Duration(maxDays)*24*time.Hour {
+		return fmt.Errorf("must not span more than %d days", maxDays)
+	}
+	return nil
+}
+
+func ValidateSlotMinutes(minutes int32) error {
+	if minutes < 15 || minutes > 240 || minutes%15 != 0 {
+		return fmt.Errorf("must be a multiple of 15 from 15-240")
+	}
+	return nil
+}
@@ -0,0 +1,69 @@
+package val
+
+import (
+	"fmt"
+	"mime"
+	"net/http"
+	"strings"
+)
+
+const (
+	// MaxTenantDocuments caps the documents a tenant can keep in their vault
+	MaxTenantDocuments = 50
+	// MaxSharedDocuments caps the documents shared with one request
+	MaxSharedDocuments = 20
+)
+
+// DocumentContentTypes are the formats documents can be uploaded in
+var DocumentContentTypes = []string{"application/pdf", "image/jpeg", "image/png"}
+
+func ValidateDocumentType(value string) error {
+	validTypes := []string{"government_id", "payslip", "bank_statement", "employment_letter", "reference_letter", "other"}
+	for _, validType := range validTypes {
+		if value == validType {
+			return nil
+		}
+	}
+	return fmt.Errorf("must be one of: %v", validTypes)
+}
+
+func ValidateDocumentFileName(name string) error {
+	if err := ValidateString(name, 1, 255); err != nil {
+		return err
+	}
+	if strings.ContainsAny(name, `/\`) || strings.ContainsFunc(name, func(r rune) bool { return r < ' ' }) {
+		return fmt.Errorf("must not contain slashes or control characters")
+	}
+	return nil
+}
+
+// ValidateDocumentContent checks the size of content and that its bytes are of the declared content type,
+// so that a file can't be passed off as another format
+func ValidateDocumentContent(contentType string, content []byte, maxSize int64) error {
+	if len(content) == 0 {
+		return fmt.Errorf("must not be empty")
+	}
+	if int64(len(content)) > maxSize {
+		return fmt.Errorf("must be at most %d bytes", maxSize)
+	}
+
+	declared, _, err := mime.ParseMediaType(contentType)
+	if err != nil || !isDocumentContentType(declared) {
+		return fmt.Errorf("content type must be one of: %v", DocumentContentTypes)
+	}
+
+	detected, _, _ := mime.ParseMediaType(http.DetectContentType(content))
+	if detected != declared {
+		return fmt.Errorf("is not a valid %s file", declared)
+	}
+	return nil
+}
+
+func isDocumentContentType(contentType string) bool {
+	for _, allowed := range DocumentContentTypes {
+		if contentType == allowed {
+			return true
+		}
+	}
+	return false
+}
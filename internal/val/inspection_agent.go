@@ -0,0 +1,70 @@
+package val
+
+import (
+	"fmt"
+	"net/url"
+	"regexp"
+)
+
+const (
+	// MaxHourlyRate is in naira
+	MaxHourlyRate = 1_000_000
+	// MaxLicenseDocuments caps the files attached to an agent application
+	MaxLicenseDocuments = 5
+)
+
+var isValidLicenseNumber = regexp.MustCompile(`^[a-zA-Z0-9][a-zA-Z0-9/-]{3,49}$`).MatchString
+
+func ValidateLicenseNumber(license string) error {
+	if !isValidLicenseNumber(license) {
+		return fmt.Errorf("must be 4-50 letters, digits, dashes or slashes")
+	}
+	return nil
+}
+
+func ValidateSpecializations(specializations []string) error {
+	if len(specializations) == 0 || len(specializations) > 10 {
+		return fmt.Errorf("must list between 1-10 specializations")
+	}
+	for _, specialization := range specializations {
+		if err := ValidateString(specialization, 2, 50); err != nil {
+			return fmt.Errorf("specialization %q %w", specialization, err)
+		}
+	}
+	return nil
+}
+
+func ValidateServiceAreas(areas []string) error {
+	if len(areas) == 0 || len(areas) > 20 {
+		return fmt.Errorf("must list between 1-20 service areas")
+	}
+	for _, area := range areas {
+		if err := ValidateString(area, 2, 100); err != nil {
+			return fmt.Errorf("service area %q %w", area, err)
+		}
+	}
+	return nil
+}
+
+func ValidateHourlyRate(rate float64) error {
+	if rate <= 0 || rate > MaxHourlyRate {
+		return fmt.Errorf("must be more than 0 and at most %d", MaxHourlyRate)
+	}
+	return nil
+}
+
+func ValidateLicenseDocuments(urls []string) error {
+	if len(urls) == 0 || len(urls) > MaxLicenseDocuments {
+		return fmt.Errorf("must attach between 1-%d license documents", MaxLicenseDocuments)
+	}
+	for _, rawURL := range urls {
+		if err := ValidateString(rawURL, 1, 500); err != nil {
+			return fmt.Errorf("document url %w", err)
+		}
+		u, err := url.Parse(rawURL)
+		if err != nil || u.Scheme != "https" || u.Host == "" {
+			return fmt.Errorf("document %q must be an https url", rawURL)
+		}
+	}
+	return nil
+}
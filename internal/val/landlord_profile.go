@@ -0,0 +1,69 @@
+package val
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+var (
+	// CAC numbers: RC for companies, BN for business names, IT for incorporated trustees
+	isValidBusinessRegistration = regexp.MustCompile(`^(RC|BN|IT)?\d{4,8}$`).MatchString
+	// FIRS TINs are 8 digits and a 4 digit suffix, JTB TINs are 10 digits
+	isValidTaxID = regexp.MustCompile(`^(\d{8}-\d{4}|\d{10})$`).MatchString
+	// NUBAN account numbers are always 10 digits
+	isValidBankAccount     = regexp.MustCompile(`^\d{10}$`).MatchString
+	isValidBankAccountName = regexp.MustCompile(`^[a-zA-Z][a-zA-Z\s.,&'-]*$`).MatchString
+)
+
+func ValidateBusinessName(name string) error {
+	return ValidateString(strings.TrimSpace(name), 2, 200)
+}
+
+func ValidateBusinessRegistration(registration string) error {
+	registration = strings.ToUpper(strings.ReplaceAll(registration, " ", ""))
+	if !isValidBusinessRegistration(registration) {
+		return fmt.Errorf("must be a CAC registration number such as RC123456 or BN1234567")
+	}
+	return nil
+}
+
+func ValidateTaxID(taxID string) error {
+	if !isValidTaxID(taxID) {
+		return fmt.Errorf("must be a TIN of the form 12345678-0001 or 10 digits")
+	}
+	return nil
+}
+
+func ValidateBankName(name string) error {
+	return ValidateString(strings.TrimSpace(name), 2, 100)
+}
+
+func ValidateBankAccount(account string) error {
+	if !isValidBankAccount(account) {
+		return fmt.Errorf("must be a 10 digit NUBAN account number")
+	}
+	return nil
+}
+
+func ValidateBankAccountName(name string) error {
+	if err := ValidateString(name, 2, 200); err != nil {
+		return err
+	}
+	if !isValidBankAccountName(name) {
+		return fmt.Errorf("must contain only letters, spaces and punctuation")
+	}
+	return nil
+}
+
+func ValidateGuarantorName(name string) error {
+	return ValidateFullName(name)
+}
+
+func ValidateGuarantorPhone(phone string) error {
+	return ValidatePhoneNumber(phone)
+}
+
+func ValidateGuarantorAddress(address string) error {
+	return ValidateString(address, 10, 300)
+}
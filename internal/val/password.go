@@ -0,0 +1,200 @@
+package val
+
+import (
+	"bufio"
+	"context"
+	"crypto/sha1"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+const (
+	DefaultPasswordMinLength           = 8
+	DefaultPasswordMaxLength           = 100
+	DefaultPasswordMinCharacterClasses = 3
+
+	// breachedHashPrefixLength is how much of a SHA-1 hash is revealed to a breached password lookup
+	breachedHashPrefixLength = 5
+)
+
+var isSHA1Hash = regexp.MustCompile(`^[0-9a-fA-F]{40}$`).MatchString
+
+// PasswordPolicy describes what a new password must look like. Passwords that are only compared
+// with a stored hash, such as at login, are still checked with ValidatePassword.
+type PasswordPolicy struct {
+	MinLength int
+	MaxLength int
+	// MinCharacterClasses is how many of lowercase letters, uppercase letters, digits and symbols must appear
+	MinCharacterClasses int
+	// Breached rejects passwords known from breaches or common-password lists when it is set
+	Breached BreachedPasswordChecker
+}
+
+// PasswordOwner is the personal information a password must not contain
+type PasswordOwner struct {
+	Email     string
+	FirstName string
+	LastName  string
+}
+
+func DefaultPasswordPolicy() PasswordPolicy {
+	return PasswordPolicy{
+		MinLength:           DefaultPasswordMinLength,
+		MaxLength:           DefaultPasswordMaxLength,
+		MinCharacterClasses: DefaultPasswordMinCharacterClasses,
+	}
+}
+
+// Check returns every rule the password breaks, so each can be reported as its own field violation.
+// The error is only set when the breached password lookup failed; the other rules are checked regardless.
+func (policy PasswordPolicy) Check(ctx context.Context, password string, owner PasswordOwner) (violations []error, err error) {
+	n := utf8.RuneCountInString(password)
+	if n < policy.MinLength || n > policy.MaxLength {
+		violations = append(violations, fmt.Errorf("must contain from %d-%d characters", policy.MinLength, policy.MaxLength))
+	}
+
+	if classes := countCharacterClasses(password); classes < policy.MinCharacterClasses {
+		violations = append(violations, fmt.Errorf(
+			"must contain at least %d of lowercase letters, uppercase letters, digits and symbols", policy.MinCharacterClasses))
+	}
+
+	if containsPersonalInfo(password, owner) {
+		violations = append(violations, fmt.Errorf("must not contain your name or email"))
+	}
+
+	if policy.Breached != nil && password != "" {
+		breached, lookupErr := IsBreachedPassword(ctx, policy.Breached, password)
+		if lookupErr != nil {
+			err = fmt.Errorf("failed to look up breached passwords: %w", lookupErr)
+		} else if breached {
+			violations = append(violations, fmt.Errorf("is too common or has appeared in a data breach, choose another"))
+		}
+	}
+
+	return violations, err
+}
+
+func countCharacterClasses(password string) int {
+	var lower, upper, digit, symbol bool
+	for _, r := range password {
+		switch {
+		case unicode.IsLower(r):
+			lower = true
+		case unicode.IsUpper(r):
+			upper = true
+		case unicode.IsDigit(r):
+			digit = true
+		default:
+			symbol = true
+		}
+	}
+
+	classes := 0
+	for _, present := range []bool{lower, upper, digit, symbol} {
+		if present {
+			classes++
+		}
+	}
+	return classes
+}
+
+// containsPersonalInfo ignores parts shorter than three characters, which would match too much
+func containsPersonalInfo(password string, owner PasswordOwner) bool {
+	password = strings.ToLower(password)
+
+	parts := []string{owner.FirstName, owner.LastName}
+	if local, _, found := strings.Cut(owner.Email, "@"); found {
+		parts = append(parts, local)
+	}
+
+	for _, part := range parts {
+		part = strings.ToLower(strings.TrimSpace(part))
+		if len(part) >= 3 && strings.Contains(password, part) {
+			return true
+		}
+	}
+	return false
+}
+
+// BreachedPasswordChecker looks up breached passwords by the first five hex characters of their SHA-1
+// hash, the k-anonymity range scheme of Have I Been Pwned. Only the prefix is ever handed over, so an
+// implementation can be a remote service without learning the password.
+type BreachedPasswordChecker interface {
+	// HashSuffixes returns the remaining 35 uppercase hex characters of every breached hash starting with prefix
+	HashSuffixes(ctx context.Context, prefix string) ([]string, error)
+}
+
+// IsBreachedPassword reports whether checker knows the password
+func IsBreachedPassword(ctx context.Context, checker BreachedPasswordChecker, password string) (bool, error) {
+	sum := sha1.Sum([]byte(password))
+	hash := strings.ToUpper(hex.EncodeToString(sum[:]))
+	prefix, suffix := hash[:breachedHashPrefixLength], hash[breachedHashPrefixLength:]
+
+	suffixes, err := checker.HashSuffixes(ctx, prefix)
+	if err != nil {
+		return false, err
+	}
+
+	for _, candidate := range suffixes {
+		if candidate == suffix {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// BreachedPasswordList is a BreachedPasswordChecker over a list kept in memory
+type BreachedPasswordList struct {
+	ranges map[string][]string
+}
+
+// LoadBreachedPasswordList reads a breached or common-password file, see ParseBreachedPasswordList
+func LoadBreachedPasswordList(path string) (*BreachedPasswordList, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	return ParseBreachedPasswordList(file)
+}
+
+// ParseBreachedPasswordList reads one entry per line. An entry of 40 hex characters, optionally followed
+// by ":count" as in the Have I Been Pwned downloads, is taken as a SHA-1 hash and anything else as a
+// plain password. Empty lines and lines starting with # are skipped.
+func ParseBreachedPasswordList(r io.Reader) (*BreachedPasswordList, error) {
+	list := &BreachedPasswordList{ranges: make(map[string][]string)}
+
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := strings.TrimRight(scanner.Text(), "\r")
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		hash, _, _ := strings.Cut(line, ":")
+		if !isSHA1Hash(hash) {
+			sum := sha1.Sum([]byte(line))
+			hash = hex.EncodeToString(sum[:])
+		}
+		hash = strings.ToUpper(hash)
+
+		prefix := hash[:breachedHashPrefixLength]
+		list.ranges[prefix] = append(list.ranges[prefix], hash[breachedHashPrefixLength:])
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	return list, nil
+}
+
+func (list *BreachedPasswordList) HashSuffixes(ctx context.Context, prefix string) ([]string, error) {
+	return list.ranges[strings.ToUpper(prefix)], nil
+}
@@ -0,0 +1,177 @@
+package val
+
+import (
+	"fmt"
+	"math"
+	"strings"
+)
+
+const (
+	// MaxPropertyAmount fits the decimal(12,2) columns rent and fees are stored in
+	MaxPropertyAmount = 9999999999.99
+	// MaxPropertyArea fits the decimal(8,2) total_area column, in square metres
+	MaxPropertyArea = 999999.99
+	// MaxPropertyAmenities caps the amenities a listing can show
+	MaxPropertyAmenities = 30
+	// MaxSearchRadiusKm caps radius searches to a metropolitan area
+	MaxSearchRadiusKm = 50
+	// MaxMapZoom is the closest zoom level of web map tiles
+	MaxMapZoom = 22
+	// MaxSearchStatsDays is the longest window of the search cache stats, searches are kept that long
+	MaxSearchStatsDays = 30
+)
+
+// The values of the property enums in the database
+var (
+	PropertyTypes      = []string{"apartment", "house", "studio", "duplex", "commercial"}
+	RentPeriods        = []string{"monthly", "annually"}
+	FurnishingStatuses = []string{"furnished", "semi_furnished", "unfurnished"}
+)
+
+// PropertySearchSorts are the orders SearchProperties can return results in; relevance needs a free-text query
+var PropertySearchSorts = []string{"relevance", "newest", "price_asc", "price_desc", "popular"}
+
+func ValidatePropertyTitle(title string) error {
+	return ValidateString(strings.TrimSpace(title), 5, 255)
+}
+
+func ValidatePropertyDescription(description string) error {
+	return ValidateString(strings.TrimSpace(description), 20, 5000)
+}
+
+func ValidatePropertyType(propertyType string) error {
+	return validateOneOf(propertyType, PropertyTypes)
+}
+
+func ValidatePropertyAddress(address string) error {
+	return ValidateString(strings.TrimSpace(address), 5, 300)
+}
+
+// ValidatePropertyLocality checks a city, state or country name
+func ValidatePropertyLocality(name string) error {
+	return ValidateString(strings.TrimSpace(name), 2, 100)
+}
+
+func ValidateLatitude(latitude float64) error {
+	if math.IsNaN(latitude) || latitude < -90 || latitude > 90 {
+		return fmt.Errorf("must be between -90 and 90")
+	}
+	return nil
+}
+
+func ValidateLongitude(longitude float64) error {
+	if math.IsNaN(longitude) || longitude < -180 || longitude > 180 {
+		return fmt.Errorf("must be between -180 and 180")
+	}
+	return nil
+}
+
+// ValidateRoomCount checks bedrooms and bathrooms; a studio has no separate bedroom
+func ValidateRoomCount(count int32) error {
+	if count < 0 || count > 50 {
+		return fmt.Errorf("must be between 0 and 50")
+	}
+	return nil
+}
+
+func ValidateRentAmount(amount float64) error {
+	if math.IsNaN(amount) || amount <= 0 {
+		return fmt.Errorf("must be greater than 0")
+	}
+	if amount > MaxPropertyAmount {
+		return fmt.Errorf("must not exceed %.2f", MaxPropertyAmount)
+	}
+	return nil
+}
+
+// ValidatePropertyFee checks the security deposit, agency and legal fees, where 0 means there is none
+func ValidatePropertyFee(fee float64) error {
+	if math.IsNaN(fee) || fee < 0 {
+		return fmt.Errorf("cannot be negative")
+	}
+	if fee > MaxPropertyAmount {
+		return fmt.Errorf("must not exceed %.2f", MaxPropertyAmount)
+	}
+	return nil
+}
+
+func ValidateRentPeriod(period string) error {
+	return validateOneOf(period, RentPeriods)
+}
+
+func ValidateFurnishingStatus(status string) error {
+	return validateOneOf(status, FurnishingStatuses)
+}
+
+func ValidateParkingSpaces(spaces int32) error {
+	if spaces < 0 || spaces > 100 {
+		return fmt.Errorf("must be between 0 and 100")
+	}
+	return nil
+}
+
+// ValidateTotalArea checks the floor area in square metres, where 0 means it is unknown
+func ValidateTotalArea(area float64) error {
+	if math.IsNaN(area) || area < 0 {
+		return fmt.Errorf("cannot be negative")
+	}
+	if area > MaxPropertyArea {
+		return fmt.Errorf("must not exceed %.2f", MaxPropertyArea)
+	}
+	return nil
+}
+
+func ValidateAmenities(amenities []string) error {
+	if len(amenities) > MaxPropertyAmenities {
+		return fmt.Errorf("cannot list more than %d amenities", MaxPropertyAmenities)
+	}
+
+	for _, amenity := range amenities {
+		if err := ValidateString(strings.TrimSpace(amenity), 2, 50); err != nil {
+			return fmt.Errorf("amenity %q: %v", amenity, err)
+		}
+		// Amenities are stored as a comma separated list
+		if strings.Contains(amenity, ",") {
+			return fmt.Errorf("amenity %q must not contain commas", amenity)
+		}
+	}
+	return nil
+}
+
+func ValidateSearchQuery(query string) error {
+	return ValidateString(strings.TrimSpace(query), 2, 200)
+}
+
+func ValidatePropertySearchSort(sortBy string) error {
+	return validateOneOf(sortBy, PropertySearchSorts)
+}
+
+func ValidateSearchRadius(radiusKm float64) error {
+	if math.IsNaN(radiusKm) || radiusKm <= 0 || radiusKm > MaxSearchRadiusKm {
+		return fmt.Errorf("must be greater than 0 and at most %d km", MaxSearchRadiusKm)
+	}
+	return nil
+}
+
+func ValidateMapZoom(zoom int32) error {
+	if zoom < 0 || zoom > MaxMapZoom {
+		return fmt.Errorf("must be between 0 and %d", MaxMapZoom)
+	}
+	return nil
+}
+
+func ValidateSearchStatsDays(days int32) error {
+	if days < 1 || days > MaxSearchStatsDays {
+		return fmt.Errorf("must be between 1 and %d", MaxSearchStatsDays)
+	}
+	return nil
+}
+
+func validateOneOf(value string, allowed []string) error {
+	for _, candidate := range allowed {
+		if value == candidate {
+			return nil
+		}
+	}
+	return fmt.Errorf("must be one of: %v", allowed)
+}
@@ -11,6 +11,9 @@ var (
 	isValidUsername = regexp.MustCompile(`^[a-z0-9_]+$`).MatchString
 	isValidFullName = regexp.MustCompile(`^[a-zA-Z\s]+$`).MatchString
 	isValidPhone    = regexp.MustCompile(`^\+[1-9]\d{1,14}$`).MatchString
+	isValidOTPCode  = regexp.MustCompile(`^\d{6}$`).MatchString
+	isValidRecovery = regexp.MustCompile(`^[a-zA-Z0-9]{5}-[a-zA-Z0-9]{5}$`).MatchString
+	isValidNIN      = regexp.MustCompile(`^\d{11}$`).MatchString
 )
 
 func ValidateString(value string, minLength int, maxLength int) error {
@@ -62,10 +65,55 @@ func ValidateEmailId(value int64) error {
 	return nil
 }
 
+// ValidateID checks the id of any record referenced by a request
+func ValidateID(value int64) error {
+	if value <= 0 {
+		return fmt.Errorf("must be a positive integer")
+	}
+	return nil
+}
+
+func ValidatePageID(value int32) error {
+	if value < 1 {
+		return fmt.Errorf("must be a positive integer")
+	}
+	return nil
+}
+
+func ValidatePageSize(value int32) error {
+	if value < 1 || value > 50 {
+		return fmt.Errorf("must be between 1 and 50")
+	}
+	return nil
+}
+
 func ValidateSecretCode(value string) error {
 	return ValidateString(value, 32, 128)
 }
 
+func ValidateOTPCode(value string) error {
+	if !isValidOTPCode(value) {
+		return fmt.Errorf("must be a 6 digit code")
+	}
+	return nil
+}
+
+// ValidateMFACode accepts an authenticator code or a recovery code
+func ValidateMFACode(value string) error {
+	if !isValidOTPCode(value) && !isValidRecovery(value) {
+		return fmt.Errorf("must be a 6 digit code or a recovery code")
+	}
+	return nil
+}
+
+// ValidateNIN checks the format of a Nigerian National Identification Number
+func ValidateNIN(value string) error {
+	if !isValidNIN(value) {
+		return fmt.Errorf("must be an 11 digit NIN")
+	}
+	return nil
+}
+
 func ValidatePhoneNumber(value string) error {
 	if err := ValidateString(value, 7, 20); err != nil {
 		return err
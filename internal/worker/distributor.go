@@ -22,6 +22,51 @@ type TaskDistributor interface {
 		payload *PayloadSendPasswordResetEmail,
 		opts ...asynq.Option,
 	) error
+	DistributeTaskSendAccountLockedEmail(
+		ctx context.Context,
+		payload *PayloadSendAccountLockedEmail,
+		opts ...asynq.Option,
+	) error
+	DistributeTaskSendPhoneOTP(
+		ctx context.Context,
+		payload *PayloadSendPhoneOTP,
+		opts ...asynq.Option,
+	) error
+	DistributeTaskSendLoginCodeEmail(
+		ctx context.Context,
+		payload *PayloadSendLoginCodeEmail,
+		opts ...asynq.Option,
+	) error
+	DistributeTaskVerifyNIN(
+		ctx context.Context,
+		payload *PayloadVerifyNIN,
+		opts ...asynq.Option,
+	) error
+	DistributeTaskGenerateAccountExport(
+		ctx context.Context,
+		payload *PayloadGenerateAccountExport,
+		opts ...asynq.Option,
+	) error
+	DistributeTaskPurgeAccountExport(
+		ctx context.Context,
+		payload *PayloadPurgeAccountExport,
+		opts ...asynq.Option,
+	) error
+	DistributeTaskDeleteAccount(
+		ctx context.Context,
+		payload *PayloadDeleteAccount,
+		opts ...asynq.Option,
+	) error
+	DistributeTaskSendAccountDeletionEmail(
+		ctx context.Context,
+		payload *PayloadSendAccountDeletionEmail,
+		opts ...asynq.Option,
+	) error
+	DistributeTaskNotifyAgentApplicationReviewed(
+		ctx context.Context,
+		payload *PayloadNotifyAgentApplicationReviewed,
+		opts ...asynq.Option,
+	) error
 }
 
 type RedisTaskDistributor struct {
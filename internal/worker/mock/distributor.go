@@ -36,6 +36,139 @@ func (m *MockTaskDistributor) EXPECT() *MockTaskDistributorMockRecorder {
 	return m.recorder
 }
 
+// DistributeTaskDeleteAccount mocks base method.
+func (m *MockTaskDistributor) DistributeTaskDeleteAccount(arg0 context.Context, arg1 *worker.PayloadDeleteAccount, arg2 ...asynq.Option) error {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "DistributeTaskDeleteAccount", varargs...)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DistributeTaskDeleteAccount indicates an expected call of DistributeTaskDeleteAccount.
+func (mr *MockTaskDistributorMockRecorder) DistributeTaskDeleteAccount(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DistributeTaskDeleteAccount", reflect.TypeOf((*MockTaskDistributor)(nil).DistributeTaskDeleteAccount), varargs...)
+}
+
+// DistributeTaskGenerateAccountExport mocks base method.
+func (m *MockTaskDistributor) DistributeTaskGenerateAccountExport(arg0 context.Context, arg1 *worker.PayloadGenerateAccountExport, arg2 ...asynq.Option) error {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "DistributeTaskGenerateAccountExport", varargs...)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DistributeTaskGenerateAccountExport indicates an expected call of DistributeTaskGenerateAccountExport.
+func (mr *MockTaskDistributorMockRecorder) DistributeTaskGenerateAccountExport(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DistributeTaskGenerateAccountExport", reflect.TypeOf((*MockTaskDistributor)(nil).DistributeTaskGenerateAccountExport), varargs...)
+}
+
+// DistributeTaskNotifyAgentApplicationReviewed mocks base method.
+func (m *MockTaskDistributor) DistributeTaskNotifyAgentApplicationReviewed(arg0 context.Context, arg1 *worker.PayloadNotifyAgentApplicationReviewed, arg2 ...asynq.Option) error {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "DistributeTaskNotifyAgentApplicationReviewed", varargs...)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DistributeTaskNotifyAgentApplicationReviewed indicates an expected call of DistributeTaskNotifyAgentApplicationReviewed.
+func (mr *MockTaskDistributorMockRecorder) DistributeTaskNotifyAgentApplicationReviewed(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DistributeTaskNotifyAgentApplicationReviewed", reflect.TypeOf((*MockTaskDistributor)(nil).DistributeTaskNotifyAgentApplicationReviewed), varargs...)
+}
+
+// DistributeTaskPurgeAccountExport mocks base method.
+func (m *MockTaskDistributor) DistributeTaskPurgeAccountExport(arg0 context.Context, arg1 *worker.PayloadPurgeAccountExport, arg2 ...asynq.Option) error {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "DistributeTaskPurgeAccountExport", varargs...)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DistributeTaskPurgeAccountExport indicates an expected call of DistributeTaskPurgeAccountExport.
+func (mr *MockTaskDistributorMockRecorder) DistributeTaskPurgeAccountExport(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DistributeTaskPurgeAccountExport", reflect.TypeOf((*MockTaskDistributor)(nil).DistributeTaskPurgeAccountExport), varargs...)
+}
+
+// DistributeTaskSendAccountDeletionEmail mocks base method.
+func (m *MockTaskDistributor) DistributeTaskSendAccountDeletionEmail(arg0 context.Context, arg1 *worker.PayloadSendAccountDeletionEmail, arg2 ...asynq.Option) error {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "DistributeTaskSendAccountDeletionEmail", varargs...)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DistributeTaskSendAccountDeletionEmail indicates an expected call of DistributeTaskSendAccountDeletionEmail.
+func (mr *MockTaskDistributorMockRecorder) DistributeTaskSendAccountDeletionEmail(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DistributeTaskSendAccountDeletionEmail", reflect.TypeOf((*MockTaskDistributor)(nil).DistributeTaskSendAccountDeletionEmail), varargs...)
+}
+
+// DistributeTaskSendAccountLockedEmail mocks base method.
+func (m *MockTaskDistributor) DistributeTaskSendAccountLockedEmail(arg0 context.Context, arg1 *worker.PayloadSendAccountLockedEmail, arg2 ...asynq.Option) error {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "DistributeTaskSendAccountLockedEmail", varargs...)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DistributeTaskSendAccountLockedEmail indicates an expected call of DistributeTaskSendAccountLockedEmail.
+func (mr *MockTaskDistributorMockRecorder) DistributeTaskSendAccountLockedEmail(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DistributeTaskSendAccountLockedEmail", reflect.TypeOf((*MockTaskDistributor)(nil).DistributeTaskSendAccountLockedEmail), varargs...)
+}
+
+// DistributeTaskSendLoginCodeEmail mocks base method.
+func (m *MockTaskDistributor) DistributeTaskSendLoginCodeEmail(arg0 context.Context, arg1 *worker.PayloadSendLoginCodeEmail, arg2 ...asynq.Option) error {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "DistributeTaskSendLoginCodeEmail", varargs...)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DistributeTaskSendLoginCodeEmail indicates an expected call of DistributeTaskSendLoginCodeEmail.
+func (mr *MockTaskDistributorMockRecorder) DistributeTaskSendLoginCodeEmail(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DistributeTaskSendLoginCodeEmail", reflect.TypeOf((*MockTaskDistributor)(nil).DistributeTaskSendLoginCodeEmail), varargs...)
+}
+
 // DistributeTaskSendPasswordResetEmail mocks base method.
 func (m *MockTaskDistributor) DistributeTaskSendPasswordResetEmail(arg0 context.Context, arg1 *worker.PayloadSendPasswordResetEmail, arg2 ...asynq.Option) error {
 	m.ctrl.T.Helper()
@@ -55,6 +188,25 @@ func (mr *MockTaskDistributorMockRecorder) DistributeTaskSendPasswordResetEmail(
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DistributeTaskSendPasswordResetEmail", reflect.TypeOf((*MockTaskDistributor)(nil).DistributeTaskSendPasswordResetEmail), varargs...)
 }
 
+// DistributeTaskSendPhoneOTP mocks base method.
+func (m *MockTaskDistributor) DistributeTaskSendPhoneOTP(arg0 context.Context, arg1 *worker.PayloadSendPhoneOTP, arg2 ...asynq.Option) error {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "DistributeTaskSendPhoneOTP", varargs...)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DistributeTaskSendPhoneOTP indicates an expected call of DistributeTaskSendPhoneOTP.
+func (mr *MockTaskDistributorMockRecorder) DistributeTaskSendPhoneOTP(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DistributeTaskSendPhoneOTP", reflect.TypeOf((*MockTaskDistributor)(nil).DistributeTaskSendPhoneOTP), varargs...)
+}
+
 // DistributeTaskSendVerifyEmail mocks base method.
 func (m *MockTaskDistributor) DistributeTaskSendVerifyEmail(arg0 context.Context, arg1 *worker.PayloadSendVerifyEmail, arg2 ...asynq.Option) error {
 	m.ctrl.T.Helper()
@@ -92,3 +244,22 @@ func (mr *MockTaskDistributorMockRecorder) DistributeTaskSendWelcomeEmail(arg0,
 	varargs := append([]interface{}{arg0, arg1}, arg2...)
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DistributeTaskSendWelcomeEmail", reflect.TypeOf((*MockTaskDistributor)(nil).DistributeTaskSendWelcomeEmail), varargs...)
 }
+
+// DistributeTaskVerifyNIN mocks base method.
+func (m *MockTaskDistributor) DistributeTaskVerifyNIN(arg0 context.Context, arg1 *worker.PayloadVerifyNIN, arg2 ...asynq.Option) error {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "DistributeTaskVerifyNIN", varargs...)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DistributeTaskVerifyNIN indicates an expected call of DistributeTaskVerifyNIN.
+func (mr *MockTaskDistributorMockRecorder) DistributeTaskVerifyNIN(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DistributeTaskVerifyNIN", reflect.TypeOf((*MockTaskDistributor)(nil).DistributeTaskVerifyNIN), varargs...)
+}
@@ -5,8 +5,12 @@ import (
 
 	"github.com/go-redis/redis/v8"
 	"github.com/hibiken/asynq"
+	"github.com/r-scheele/sqr/internal/blob"
 	db "github.com/r-scheele/sqr/internal/db/sqlc"
+	"github.com/r-scheele/sqr/internal/identity"
 	"github.com/r-scheele/sqr/internal/mail"
+	"github.com/r-scheele/sqr/internal/sms"
+	"github.com/r-scheele/sqr/internal/util"
 	"github.com/rs/zerolog/log"
 )
 
@@ -21,15 +25,39 @@ type TaskProcessor interface {
 	ProcessTaskSendVerifyEmail(ctx context.Context, task *asynq.Task) error
 	ProcessTaskSendWelcomeEmail(ctx context.Context, task *asynq.Task) error
 	ProcessTaskSendPasswordResetEmail(ctx context.Context, task *asynq.Task) error
+	ProcessTaskSendAccountLockedEmail(ctx context.Context, task *asynq.Task) error
+	ProcessTaskSendPhoneOTP(ctx context.Context, task *asynq.Task) error
+	ProcessTaskSendLoginCodeEmail(ctx context.Context, task *asynq.Task) error
+	ProcessTaskVerifyNIN(ctx context.Context, task *asynq.Task) error
+	ProcessTaskGenerateAccountExport(ctx context.Context, task *asynq.Task) error
+	ProcessTaskPurgeAccountExport(ctx context.Context, task *asynq.Task) error
+	ProcessTaskDeleteAccount(ctx context.Context, task *asynq.Task) error
+	ProcessTaskSendAccountDeletionEmail(ctx context.Context, task *asynq.Task) error
+	ProcessTaskNotifyAgentApplicationReviewed(ctx context.Context, task *asynq.Task) error
+	ProcessTaskNudgeIncompleteProfiles(ctx context.Context, task *asynq.Task) error
+	ProcessTaskCleanupPropertySearchCache(ctx context.Context, task *asynq.Task) error
+	ProcessTaskSweepAccountDeletions(ctx context.Context, task *asynq.Task) error
 }
 
 type RedisTaskProcessor struct {
-	server *asynq.Server
-	store  db.Store
-	mailer mail.EmailSender
+	server   *asynq.Server
+	store    db.Store
+	mailer   mail.EmailSender
+	sms      sms.SMSSender
+	identity identity.IdentityProvider
+	blob     blob.BlobStore
+	config   util.Config
 }
 
-func NewRedisTaskProcessor(redisOpt asynq.RedisClientOpt, store db.Store, mailer mail.EmailSender) TaskProcessor {
+func NewRedisTaskProcessor(
+	redisOpt asynq.RedisClientOpt,
+	store db.Store,
+	mailer mail.EmailSender,
+	smsSender sms.SMSSender,
+	identityProvider identity.IdentityProvider,
+	blobStore blob.BlobStore,
+	config util.Config,
+) TaskProcessor {
 	logger := NewLogger()
 	redis.SetLogger(logger)
 
@@ -49,9 +77,13 @@ func NewRedisTaskProcessor(redisOpt asynq.RedisClientOpt, store db.Store, mailer
 	)
 
 	return &RedisTaskProcessor{
-		server: server,
-		store:  store,
-		mailer: mailer,
+		server:   server,
+		store:    store,
+		mailer:   mailer,
+		sms:      smsSender,
+		identity: identityProvider,
+		blob:     blobStore,
+		config:   config,
 	}
 }
 
@@ -61,6 +93,18 @@ func (processor *RedisTaskProcessor) Start() error {
 	mux.HandleFunc(TaskSendVerifyEmail, processor.ProcessTaskSendVerifyEmail)
 	mux.HandleFunc(TaskSendWelcomeEmail, processor.ProcessTaskSendWelcomeEmail)
 	mux.HandleFunc(TaskSendPasswordResetEmail, processor.ProcessTaskSendPasswordResetEmail)
+	mux.HandleFunc(TaskSendAccountLockedEmail, processor.ProcessTaskSendAccountLockedEmail)
+	mux.HandleFunc(TaskSendPhoneOTP, processor.ProcessTaskSendPhoneOTP)
+	mux.HandleFunc(TaskSendLoginCodeEmail, processor.ProcessTaskSendLoginCodeEmail)
+	mux.HandleFunc(TaskVerifyNIN, processor.ProcessTaskVerifyNIN)
+	mux.HandleFunc(TaskGenerateAccountExport, processor.ProcessTaskGenerateAccountExport)
+	mux.HandleFunc(TaskPurgeAccountExport, processor.ProcessTaskPurgeAccountExport)
+	mux.HandleFunc(TaskDeleteAccount, processor.ProcessTaskDeleteAccount)
+	mux.HandleFunc(TaskSendAccountDeletionEmail, processor.ProcessTaskSendAccountDeletionEmail)
+	mux.HandleFunc(TaskNotifyAgentApplicationReviewed, processor.ProcessTaskNotifyAgentApplicationReviewed)
+	mux.HandleFunc(TaskNudgeIncompleteProfiles, processor.ProcessTaskNudgeIncompleteProfiles)
+	mux.HandleFunc(TaskCleanupPropertySearchCache, processor.ProcessTaskCleanupPropertySearchCache)
+	mux.HandleFunc(TaskSweepAccountDeletions, processor.ProcessTaskSweepAccountDeletions)
 
 	return processor.server.Start(mux)
 }
@@ -0,0 +1,40 @@
+package worker
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hibiken/asynq"
+	"github.com/r-scheele/sqr/internal/util"
+	"github.com/r-scheele/sqr/internal/val"
+	"github.com/rs/zerolog/log"
+)
+
+const TaskCleanupPropertySearchCache = "task:cleanup_property_search_cache"
+
+// DefaultSearchCacheCleanupSchedule is used when SEARCH_CACHE_CLEANUP_SCHEDULE is not set: every hour
+const DefaultSearchCacheCleanupSchedule = "15 * * * *"
+
+// SearchCacheRetention is how long an expired search is kept for the popular searches of the search cache stats
+const SearchCacheRetention = val.MaxSearchStatsDays * 24 * time.Hour
+
+// SearchCacheCleanupSchedule is the cron spec, in util.AvailabilityTimezone, of TaskCleanupPropertySearchCache
+func SearchCacheCleanupSchedule(config util.Config) string {
+	if config.SearchCacheCleanupSchedule != "" {
+		return config.SearchCacheCleanupSchedule
+	}
+	return DefaultSearchCacheCleanupSchedule
+}
+
+// ProcessTaskCleanupPropertySearchCache deletes the expired property searches that nobody ran for SearchCacheRetention.
+// It runs periodically with an empty payload.
+func (processor *RedisTaskProcessor) ProcessTaskCleanupPropertySearchCache(ctx context.Context, task *asynq.Task) error {
+	deleted, err := processor.store.CleanupStaleSearchCache(ctx, time.Now().Add(-SearchCacheRetention))
+	if err != nil {
+		return fmt.Errorf("failed to clean up search cache: %w", err)
+	}
+
+	log.Info().Str("type", task.Type()).Int64("deleted", deleted).Msg("cleaned up property search cache")
+	return nil
+}
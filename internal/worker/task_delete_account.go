@@ -0,0 +1,107 @@
+package worker
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/hibiken/asynq"
+	db "github.com/r-scheele/sqr/internal/db/sqlc"
+	"github.com/r-scheele/sqr/internal/util"
+	"github.com/rs/zerolog/log"
+)
+
+const TaskDeleteAccount = "task:delete_account"
+
+type PayloadDeleteAccount struct {
+	UserID     int64 `json:"user_id"`
+	DeletionID int64 `json:"deletion_id"`
+}
+
+func (distributor *RedisTaskDistributor) DistributeTaskDeleteAccount(
+	ctx context.Context,
+	payload *PayloadDeleteAccount,
+	opts ...asynq.Option,
+) error {
+	jsonPayload, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("failed to marshal task payload: %w", err)
+	}
+
+	task := asynq.NewTask(TaskDeleteAccount, jsonPayload, opts...)
+	info, err := distributor.client.EnqueueContext(ctx, task)
+	if err != nil {
+		return fmt.Errorf("failed to enqueue task: %w", err)
+	}
+
+	log.Info().Str("type", task.Type()).Bytes("payload", task.Payload()).
+		Str("queue", info.Queue).Int("max_retry", info.MaxRetry).Msg("enqueued task")
+	return nil
+}
+
+func (processor *RedisTaskProcessor) ProcessTaskDeleteAccount(ctx context.Context, task *asynq.Task) error {
+	var payload PayloadDeleteAccount
+	if err := json.Unmarshal(task.Payload(), &payload); err != nil {
+		return fmt.Errorf("failed to unmarshal payload: %w", asynq.SkipRetry)
+	}
+
+	return processor.deleteAccount(ctx, task.Type(), payload.DeletionID)
+}
+
+// deleteAccount carries out a deletion whose grace period has ended. It is called by the task enqueued for the
+// end of the grace period and by the periodic sweep, so a deletion that was already carried out is not an error.
+func (processor *RedisTaskProcessor) deleteAccount(ctx context.Context, taskType string, deletionID int64) error {
+	// Nobody knows this password, so the account can't be signed in to after it is anonymized
+	hashedPassword, err := util.HashPassword(util.RandomString(32))
+	if err != nil {
+		return fmt.Errorf("failed to hash password: %w", err)
+	}
+
+	result, err := processor.store.AnonymizeAccountTx(ctx, db.AnonymizeAccountTxParams{
+		DeletionID:   deletionID,
+		PasswordHash: hashedPassword,
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, db.ErrRecordNotFound):
+			return fmt.Errorf("account deletion doesn't exist: %w", asynq.SkipRetry)
+		case errors.Is(err, db.ErrDeletionNotPending):
+			log.Info().Str("type", taskType).Int64("deletion_id", deletionID).
+				Msg("account deletion was cancelled or already carried out")
+			return nil
+		}
+		// ErrDeletionNotDue included: the retry runs after the grace period has ended
+		return fmt.Errorf("failed to delete account: %w", err)
+	}
+
+	// Only the rows were removed in the transaction, the content of the documents is kept outside the database
+	for _, storageKey := range result.DocumentStorageKeys {
+		err = processor.blob.Delete(ctx, storageKey)
+		if err != nil {
+			log.Error().Err(err).Str("storage_key", storageKey).Msg("failed to delete document content")
+		}
+	}
+
+	user := result.User
+	subject := "Your SQR account has been deleted"
+	content := fmt.Sprintf(`
+		<h1>Account Deleted</h1>
+		<p>Hello %s,</p>
+		<p>As you requested, your SQR account has been deleted and your personal data erased.</p>
+		<p>Records we are required to keep, such as payments and signed agreements, no longer identify you.</p>
+		<p>Thank you for using SQR.</p>
+		<br>
+		<p>Best regards,<br>The SQR Team</p>
+	`, user.FirstName)
+
+	// The address is no longer stored anywhere, this is the last message sent to it
+	err = processor.mailer.SendEmail(subject, content, []string{user.Email}, nil, nil, nil)
+	if err != nil {
+		log.Error().Err(err).Int64("user_id", user.ID).Msg("failed to send account deleted email")
+	}
+
+	log.Info().Str("type", taskType).Int64("user_id", user.ID).
+		Int64("deletion_id", result.Deletion.ID).Msg("deleted account")
+	return nil
+}
@@ -0,0 +1,164 @@
+package worker
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/hibiken/asynq"
+	"github.com/jackc/pgx/v5/pgtype"
+	db "github.com/r-scheele/sqr/internal/db/sqlc"
+	"github.com/r-scheele/sqr/internal/util"
+	"github.com/rs/zerolog/log"
+)
+
+const TaskGenerateAccountExport = "task:generate_account_export"
+
+// DefaultAccountExportTTL is used when ACCOUNT_EXPORT_TTL is not set
+const DefaultAccountExportTTL = 7 * 24 * time.Hour
+
+// accountExportDocument is the name of the JSON document inside an export archive
+const accountExportDocument = "account.json"
+
+type PayloadGenerateAccountExport struct {
+	UserID   int64 `json:"user_id"`
+	ExportID int64 `json:"export_id"`
+}
+
+// AccountExportTTL is how long a finished export can be downloaded before its content is purged
+func AccountExportTTL(config util.Config) time.Duration {
+	if config.AccountExportTTL > 0 {
+		return config.AccountExportTTL
+	}
+	return DefaultAccountExportTTL
+}
+
+func (distributor *RedisTaskDistributor) DistributeTaskGenerateAccountExport(
+	ctx context.Context,
+	payload *PayloadGenerateAccountExport,
+	opts ...asynq.Option,
+) error {
+	jsonPayload, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("failed to marshal task payload: %w", err)
+	}
+
+	task := asynq.NewTask(TaskGenerateAccountExport, jsonPayload, opts...)
+	info, err := distributor.client.EnqueueContext(ctx, task)
+	if err != nil {
+		return fmt.Errorf("failed to enqueue task: %w", err)
+	}
+
+	log.Info().Str("type", task.Type()).Bytes("payload", task.Payload()).
+		Str("queue", info.Queue).Int("max_retry", info.MaxRetry).Msg("enqueued task")
+	return nil
+}
+
+func (processor *RedisTaskProcessor) ProcessTaskGenerateAccountExport(ctx context.Context, task *asynq.Task) error {
+	var payload PayloadGenerateAccountExport
+	if err := json.Unmarshal(task.Payload(), &payload); err != nil {
+		return fmt.Errorf("failed to unmarshal payload: %w", asynq.SkipRetry)
+	}
+
+	export, err := processor.store.GetAccountExport(ctx, payload.ExportID)
+	if err != nil {
+		if errors.Is(err, db.ErrRecordNotFound) {
+			return fmt.Errorf("account export doesn't exist: %w", asynq.SkipRetry)
+		}
+		return fmt.Errorf("failed to get account export: %w", err)
+	}
+	if export.UserID != payload.UserID {
+		return fmt.Errorf("account export %d doesn't belong to user %d: %w", export.ID, payload.UserID, asynq.SkipRetry)
+	}
+	if export.Status != db.AccountRequestStatusEnumPending {
+		// Already handled by an earlier run of this task
+		log.Info().Str("type", task.Type()).Int64("export_id", export.ID).
+			Msg("account export is no longer pending")
+		return nil
+	}
+
+	user, err := processor.store.GetUserByID(ctx, payload.UserID)
+	if err != nil {
+		return fmt.Errorf("failed to get user: %w", err)
+	}
+
+	data, err := processor.store.GetAccountExportData(ctx, user.ID)
+	if err != nil {
+		return fmt.Errorf("failed to collect account data: %w", err)
+	}
+
+	archive, err := buildAccountExportArchive(data)
+	if err != nil {
+		processor.failAccountExport(ctx, export.ID)
+		return fmt.Errorf("failed to build export archive for user %d: %s: %w", user.ID, err, asynq.SkipRetry)
+	}
+
+	expiresAt := time.Now().Add(AccountExportTTL(processor.config))
+	export, err = processor.store.CompleteAccountExport(ctx, db.CompleteAccountExportParams{
+		ID:        export.ID,
+		FileName:  pgtype.Text{String: fmt.Sprintf("sqr-export-%d.zip", export.ID), Valid: true},
+		Content:   archive,
+		ExpiresAt: pgtype.Timestamptz{Time: expiresAt, Valid: true},
+	})
+	if err != nil {
+		if errors.Is(err, db.ErrRecordNotFound) {
+			return nil
+		}
+		return fmt.Errorf("failed to complete account export: %w", err)
+	}
+
+	subject := "Your SQR data export is ready"
+	content := fmt.Sprintf(`
+		<h1>Your Data Export Is Ready</h1>
+		<p>Hello %s,</p>
+		<p>The copy of your personal data you requested is ready.</p>
+		<p>Sign in and <a href="https://sqr.com/account/exports/%d">download it here</a>. The link works until %s.</p>
+		<p>If you didn't request this export, please reset your password.</p>
+		<br>
+		<p>Best regards,<br>The SQR Team</p>
+	`, user.FirstName, export.ID, expiresAt.UTC().Format(time.RFC1123))
+
+	err = processor.mailer.SendEmail(subject, content, []string{user.Email}, nil, nil, nil)
+	if err != nil {
+		// The export can be downloaded anyway, so it is not generated again
+		log.Error().Err(err).Int64("user_id", user.ID).Msg("failed to send account export email")
+	}
+
+	log.Info().Str("type", task.Type()).Int64("user_id", user.ID).
+		Int64("export_id", export.ID).Int("size", len(archive)).Msg("generated account export")
+	return nil
+}
+
+func (processor *RedisTaskProcessor) failAccountExport(ctx context.Context, exportID int64) {
+	err := processor.store.FailAccountExport(ctx, exportID)
+	if err != nil {
+		log.Error().Err(err).Int64("export_id", exportID).Msg("failed to mark account export as failed")
+	}
+}
+
+// buildAccountExportArchive zips the account data as an indented JSON document
+func buildAccountExportArchive(data []byte) ([]byte, error) {
+	var document bytes.Buffer
+	if err := json.Indent(&document, data, "", "  "); err != nil {
+		return nil, err
+	}
+
+	var archive bytes.Buffer
+	writer := zip.NewWriter(&archive)
+	file, err := writer.Create(accountExportDocument)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := file.Write(document.Bytes()); err != nil {
+		return nil, err
+	}
+	if err := writer.Close(); err != nil {
+		return nil, err
+	}
+
+	return archive.Bytes(), nil
+}
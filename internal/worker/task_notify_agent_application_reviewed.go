@@ -0,0 +1,96 @@
+package worker
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/hibiken/asynq"
+	db "github.com/r-scheele/sqr/internal/db/sqlc"
+	"github.com/rs/zerolog/log"
+)
+
+const TaskNotifyAgentApplicationReviewed = "task:notify_agent_application_reviewed"
+
+type PayloadNotifyAgentApplicationReviewed struct {
+	UserID   int64  `json:"user_id"`
+	Approved bool   `json:"approved"`
+	Reason   string `json:"reason,omitempty"`
+}
+
+func (distributor *RedisTaskDistributor) DistributeTaskNotifyAgentApplicationReviewed(
+	ctx context.Context,
+	payload *PayloadNotifyAgentApplicationReviewed,
+	opts ...asynq.Option,
+) error {
+	jsonPayload, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("failed to marshal task payload: %w", err)
+	}
+
+	task := asynq.NewTask(TaskNotifyAgentApplicationReviewed, jsonPayload, opts...)
+	info, err := distributor.client.EnqueueContext(ctx, task)
+	if err != nil {
+		return fmt.Errorf("failed to enqueue task: %w", err)
+	}
+
+	log.Info().Str("type", task.Type()).Bytes("payload", task.Payload()).
+		Str("queue", info.Queue).Int("max_retry", info.MaxRetry).Msg("enqueued task")
+	return nil
+}
+
+// ProcessTaskNotifyAgentApplicationReviewed tells an agent the outcome of their application by email and in-app.
+// The email goes first since only it is retried; a retry must not leave duplicate in-app notifications.
+func (processor *RedisTaskProcessor) ProcessTaskNotifyAgentApplicationReviewed(ctx context.Context, task *asynq.Task) error {
+	var payload PayloadNotifyAgentApplicationReviewed
+	if err := json.Unmarshal(task.Payload(), &payload); err != nil {
+		return fmt.Errorf("failed to unmarshal payload: %w", asynq.SkipRetry)
+	}
+
+	user, err := processor.store.GetUserByID(ctx, payload.UserID)
+	if err != nil {
+		if errors.Is(err, db.ErrRecordNotFound) {
+			return fmt.Errorf("user doesn't exist: %w", asynq.SkipRetry)
+		}
+		return fmt.Errorf("failed to get user: %w", err)
+	}
+
+	title := "Your inspection agent application has been approved"
+	message := "Your license has been checked and your application approved. You can now be assigned inspections."
+	if !payload.Approved {
+		title = "Your inspection agent application was not approved"
+		message = "We could not approve your inspection agent application."
+		if payload.Reason != "" {
+			message += " Reason: " + payload.Reason + "."
+		}
+		message += " You can correct your details and submit the application again."
+	}
+
+	content := fmt.Sprintf(`
+		<h1>%s</h1>
+		<p>Hello %s,</p>
+		<p>%s</p>
+		<br>
+		<p>Best regards,<br>The SQR Team</p>
+	`, title, user.FirstName, message)
+
+	err = processor.mailer.SendEmail(title, content, []string{user.Email}, nil, nil, nil)
+	if err != nil {
+		return fmt.Errorf("failed to send agent application email to [%s]: %w", user.Email, err)
+	}
+
+	_, err = processor.store.CreateNotification(ctx, db.CreateNotificationParams{
+		UserID:           user.ID,
+		NotificationType: db.NotificationTypeEnumApplicationStatus,
+		Title:            title,
+		Content:          message,
+	})
+	if err != nil {
+		log.Error().Err(err).Int64("user_id", user.ID).Msg("failed to create agent application notification")
+	}
+
+	log.Info().Str("type", task.Type()).Int64("user_id", user.ID).
+		Bool("approved", payload.Approved).Msg("notified agent of application review")
+	return nil
+}
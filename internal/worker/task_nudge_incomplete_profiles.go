@@ -0,0 +1,95 @@
+package worker
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hibiken/asynq"
+	db "github.com/r-scheele/sqr/internal/db/sqlc"
+	"github.com/r-scheele/sqr/internal/util"
+	"github.com/rs/zerolog/log"
+)
+
+const TaskNudgeIncompleteProfiles = "task:nudge_incomplete_profiles"
+
+// DefaultProfileNudgeSchedule is used when PROFILE_NUDGE_SCHEDULE is not set: mondays at 9am
+const DefaultProfileNudgeSchedule = "0 9 * * 1"
+
+// profileNudgePageSize is how many users are checked per query
+const profileNudgePageSize = 100
+
+// ProfileNudgeSchedule is the cron spec, in util.AvailabilityTimezone, of TaskNudgeIncompleteProfiles
+func ProfileNudgeSchedule(config util.Config) string {
+	if config.ProfileNudgeSchedule != "" {
+		return config.ProfileNudgeSchedule
+	}
+	return DefaultProfileNudgeSchedule
+}
+
+// ProcessTaskNudgeIncompleteProfiles emails every active user whose profile isn't complete the steps they have left.
+// It runs periodically with an empty payload. A failed email is logged rather than retried, which would email
+// everyone before it again; the next run picks the user up.
+func (processor *RedisTaskProcessor) ProcessTaskNudgeIncompleteProfiles(ctx context.Context, task *asynq.Task) error {
+	var afterID int64
+	var nudged int
+
+	for {
+		users, err := processor.store.ListActiveUsersAfterID(ctx, db.ListActiveUsersAfterIDParams{
+			AfterID: afterID,
+			Limit:   profileNudgePageSize,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to list users: %w", err)
+		}
+
+		for _, user := range users {
+			afterID = user.ID
+			if user.UserType == db.UserTypeEnumAdmin {
+				continue
+			}
+
+			completion, err := db.GetProfileCompletion(ctx, processor.store, user)
+			if err != nil {
+				log.Error().Err(err).Int64("user_id", user.ID).Msg("failed to get profile completion")
+				continue
+			}
+			if completion.Score >= 100 {
+				continue
+			}
+
+			err = processor.sendProfileNudgeEmail(user, completion)
+			if err != nil {
+				log.Error().Err(err).Int64("user_id", user.ID).Msg("failed to send profile nudge email")
+				continue
+			}
+			nudged++
+		}
+
+		if len(users) < profileNudgePageSize {
+			break
+		}
+	}
+
+	log.Info().Str("type", task.Type()).Int("nudged", nudged).Msg("nudged incomplete profiles")
+	return nil
+}
+
+func (processor *RedisTaskProcessor) sendProfileNudgeEmail(user db.User, completion db.ProfileCompletion) error {
+	var steps strings.Builder
+	for _, item := range completion.Missing() {
+		fmt.Fprintf(&steps, "<li>%s</li>", item.Label)
+	}
+
+	subject := "Complete your SQR profile"
+	content := fmt.Sprintf(`
+		<h1>Your profile is %d%% complete</h1>
+		<p>Hello %s,</p>
+		<p>A complete profile builds trust with the people you rent with. Here is what is left to do:</p>
+		<ul>%s</ul>
+		<br>
+		<p>Best regards,<br>The SQR Team</p>
+	`, completion.Score, user.FirstName, steps.String())
+
+	return processor.mailer.SendEmail(subject, content, []string{user.Email}, nil, nil, nil)
+}
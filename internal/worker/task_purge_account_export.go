@@ -0,0 +1,83 @@
+package worker
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/hibiken/asynq"
+	db "github.com/r-scheele/sqr/internal/db/sqlc"
+	"github.com/rs/zerolog/log"
+)
+
+const TaskPurgeAccountExport = "task:purge_account_export"
+
+type PayloadPurgeAccountExport struct {
+	ExportID int64 `json:"export_id"`
+}
+
+func (distributor *RedisTaskDistributor) DistributeTaskPurgeAccountExport(
+	ctx context.Context,
+	payload *PayloadPurgeAccountExport,
+	opts ...asynq.Option,
+) error {
+	jsonPayload, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("failed to marshal task payload: %w", err)
+	}
+
+	task := asynq.NewTask(TaskPurgeAccountExport, jsonPayload, opts...)
+	info, err := distributor.client.EnqueueContext(ctx, task)
+	if err != nil {
+		return fmt.Errorf("failed to enqueue task: %w", err)
+	}
+
+	log.Info().Str("type", task.Type()).Bytes("payload", task.Payload()).
+		Str("queue", info.Queue).Int("max_retry", info.MaxRetry).Msg("enqueued task")
+	return nil
+}
+
+func (processor *RedisTaskProcessor) ProcessTaskPurgeAccountExport(ctx context.Context, task *asynq.Task) error {
+	var payload PayloadPurgeAccountExport
+	if err := json.Unmarshal(task.Payload(), &payload); err != nil {
+		return fmt.Errorf("failed to unmarshal payload: %w", asynq.SkipRetry)
+	}
+
+	export, err := processor.store.GetAccountExport(ctx, payload.ExportID)
+	if err != nil {
+		if errors.Is(err, db.ErrRecordNotFound) {
+			// Gone together with its user
+			return nil
+		}
+		return fmt.Errorf("failed to get account export: %w", err)
+	}
+
+	switch export.Status {
+	case db.AccountRequestStatusEnumPending:
+		// The export never finished within its lifetime, so nobody is waiting for it any more
+		processor.failAccountExport(ctx, export.ID)
+		return nil
+	case db.AccountRequestStatusEnumCompleted:
+		if export.Content == nil {
+			return nil
+		}
+	default:
+		return nil
+	}
+
+	if export.ExpiresAt.Valid && time.Now().Before(export.ExpiresAt.Time) {
+		// Finished later than expected; retrying with backoff purges it once it expires
+		return fmt.Errorf("account export %d doesn't expire until %s", export.ID, export.ExpiresAt.Time.UTC().Format(time.RFC3339))
+	}
+
+	err = processor.store.PurgeAccountExport(ctx, export.ID)
+	if err != nil {
+		return fmt.Errorf("failed to purge account export: %w", err)
+	}
+
+	log.Info().Str("type", task.Type()).Int64("export_id", export.ID).
+		Int64("user_id", export.UserID).Msg("purged account export")
+	return nil
+}
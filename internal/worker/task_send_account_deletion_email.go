@@ -0,0 +1,68 @@
+package worker
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/hibiken/asynq"
+	"github.com/rs/zerolog/log"
+)
+
+const TaskSendAccountDeletionEmail = "task:send_account_deletion_email"
+
+type PayloadSendAccountDeletionEmail struct {
+	Username     string    `json:"username"`
+	Email        string    `json:"email"`
+	ScheduledFor time.Time `json:"scheduled_for"`
+}
+
+func (distributor *RedisTaskDistributor) DistributeTaskSendAccountDeletionEmail(
+	ctx context.Context,
+	payload *PayloadSendAccountDeletionEmail,
+	opts ...asynq.Option,
+) error {
+	jsonPayload, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("failed to marshal task payload: %w", err)
+	}
+
+	task := asynq.NewTask(TaskSendAccountDeletionEmail, jsonPayload, opts...)
+	info, err := distributor.client.EnqueueContext(ctx, task)
+	if err != nil {
+		return fmt.Errorf("failed to enqueue task: %w", err)
+	}
+
+	log.Info().Str("type", task.Type()).Bytes("payload", task.Payload()).
+		Str("queue", info.Queue).Int("max_retry", info.MaxRetry).Msg("enqueued task")
+	return nil
+}
+
+func (processor *RedisTaskProcessor) ProcessTaskSendAccountDeletionEmail(ctx context.Context, task *asynq.Task) error {
+	var payload PayloadSendAccountDeletionEmail
+	if err := json.Unmarshal(task.Payload(), &payload); err != nil {
+		return fmt.Errorf("failed to unmarshal payload: %w", asynq.SkipRetry)
+	}
+
+	subject := "Your SQR account is scheduled for deletion"
+	content := fmt.Sprintf(`
+		<h1>Account Deletion Scheduled</h1>
+		<p>Hello %s,</p>
+		<p>We received a request to delete your SQR account. Your account and personal data will be erased on %s.</p>
+		<p>Changed your mind? Sign in before then and cancel the deletion from your account settings.</p>
+		<p>If you didn't request this, sign in and cancel it, then reset your password.</p>
+		<br>
+		<p>Best regards,<br>The SQR Team</p>
+	`, payload.Username, payload.ScheduledFor.UTC().Format(time.RFC1123))
+
+	to := []string{payload.Email}
+	err := processor.mailer.SendEmail(subject, content, to, nil, nil, nil)
+	if err != nil {
+		return fmt.Errorf("failed to send account deletion email to [%s]: %w", payload.Email, err)
+	}
+
+	log.Info().Str("type", task.Type()).Str("email", payload.Email).
+		Msg("sent account deletion email")
+	return nil
+}
@@ -0,0 +1,75 @@
+package worker
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/hibiken/asynq"
+	"github.com/rs/zerolog/log"
+)
+
+const TaskSendAccountLockedEmail = "task:send_account_locked_email"
+
+type PayloadSendAccountLockedEmail struct {
+	Username    string    `json:"username"`
+	Email       string    `json:"email"`
+	IpAddress   string    `json:"ip_address"`
+	LockedUntil time.Time `json:"locked_until"`
+}
+
+func (distributor *RedisTaskDistributor) DistributeTaskSendAccountLockedEmail(
+	ctx context.Context,
+	payload *PayloadSendAccountLockedEmail,
+	opts ...asynq.Option,
+) error {
+	jsonPayload, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("failed to marshal task payload: %w", err)
+	}
+
+	task := asynq.NewTask(TaskSendAccountLockedEmail, jsonPayload, opts...)
+	info, err := distributor.client.EnqueueContext(ctx, task)
+	if err != nil {
+		return fmt.Errorf("failed to enqueue task: %w", err)
+	}
+
+	log.Info().Str("type", task.Type()).Bytes("payload", task.Payload()).
+		Str("queue", info.Queue).Int("max_retry", info.MaxRetry).Msg("enqueued task")
+	return nil
+}
+
+func (processor *RedisTaskProcessor) ProcessTaskSendAccountLockedEmail(ctx context.Context, task *asynq.Task) error {
+	var payload PayloadSendAccountLockedEmail
+	if err := json.Unmarshal(task.Payload(), &payload); err != nil {
+		return fmt.Errorf("failed to unmarshal payload: %w", asynq.SkipRetry)
+	}
+
+	ipAddress := payload.IpAddress
+	if ipAddress == "" {
+		ipAddress = "an unknown address"
+	}
+
+	subject := "Your SQR account has been temporarily locked"
+	content := fmt.Sprintf(`
+		<h1>Account Temporarily Locked</h1>
+		<p>Hello %s,</p>
+		<p>We noticed several failed attempts to sign in to your SQR account from %s.</p>
+		<p>To keep your account safe, we have locked it until %s.</p>
+		<p>If this was you, you can sign in again after that time or reset your password.</p>
+		<p>If this wasn't you, we recommend resetting your password as soon as the lock expires.</p>
+		<br>
+		<p>Best regards,<br>The SQR Team</p>
+	`, payload.Username, ipAddress, payload.LockedUntil.UTC().Format(time.RFC1123))
+
+	to := []string{payload.Email}
+	err := processor.mailer.SendEmail(subject, content, to, nil, nil, nil)
+	if err != nil {
+		return fmt.Errorf("failed to send account locked email to [%s]: %w", payload.Email, err)
+	}
+
+	log.Info().Str("type", task.Type()).Str("email", payload.Email).
+		Msg("sent account locked email")
+	return nil
+}
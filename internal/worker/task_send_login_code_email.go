@@ -0,0 +1,78 @@
+package worker
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+
+	"github.com/hibiken/asynq"
+	"github.com/rs/zerolog/log"
+)
+
+const TaskSendLoginCodeEmail = "task:send_login_code_email"
+
+type PayloadSendLoginCodeEmail struct {
+	Username         string `json:"username"`
+	Email            string `json:"email"`
+	Code             string `json:"code"`
+	ExpiresInMinutes int    `json:"expires_in_minutes"`
+}
+
+func (distributor *RedisTaskDistributor) DistributeTaskSendLoginCodeEmail(
+	ctx context.Context,
+	payload *PayloadSendLoginCodeEmail,
+	opts ...asynq.Option,
+) error {
+	jsonPayload, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("failed to marshal task payload: %w", err)
+	}
+
+	task := asynq.NewTask(TaskSendLoginCodeEmail, jsonPayload, opts...)
+	info, err := distributor.client.EnqueueContext(ctx, task)
+	if err != nil {
+		return fmt.Errorf("failed to enqueue task: %w", err)
+	}
+
+	// The payload carries the code, so only the type and queue are logged
+	log.Info().Str("type", task.Type()).
+		Str("queue", info.Queue).Int("max_retry", info.MaxRetry).Msg("enqueued task")
+	return nil
+}
+
+func (processor *RedisTaskProcessor) ProcessTaskSendLoginCodeEmail(ctx context.Context, task *asynq.Task) error {
+	var payload PayloadSendLoginCodeEmail
+	if err := json.Unmarshal(task.Payload(), &payload); err != nil {
+		return fmt.Errorf("failed to unmarshal payload: %w", asynq.SkipRetry)
+	}
+
+	// The magic link carries the same code, so clicking it or typing the code both work
+	params := url.Values{}
+	params.Set("email", payload.Email)
+	params.Set("code", payload.Code)
+	loginURL := "https://sqr.com/login/code?" + params.Encode()
+
+	subject := "Your SQR sign-in code"
+	content := fmt.Sprintf(`
+		<h1>Sign in to SQR</h1>
+		<p>Hello %s,</p>
+		<p>Use the code below to sign in to your SQR account:</p>
+		<h2>%s</h2>
+		<p>Or <a href="%s">click here</a> to sign in on this device.</p>
+		<p>This code will expire in %d minutes and can only be used once.</p>
+		<p>If you did not try to sign in, you can safely ignore this email.</p>
+		<br>
+		<p>Best regards,<br>The SQR Team</p>
+	`, payload.Username, payload.Code, loginURL, payload.ExpiresInMinutes)
+
+	to := []string{payload.Email}
+	err := processor.mailer.SendEmail(subject, content, to, nil, nil, nil)
+	if err != nil {
+		return fmt.Errorf("failed to send login code email to [%s]: %w", payload.Email, err)
+	}
+
+	log.Info().Str("type", task.Type()).Str("email", payload.Email).
+		Msg("sent login code email")
+	return nil
+}
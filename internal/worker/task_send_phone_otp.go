@@ -0,0 +1,60 @@
+package worker
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/hibiken/asynq"
+	"github.com/rs/zerolog/log"
+)
+
+const TaskSendPhoneOTP = "task:send_phone_otp"
+
+type PayloadSendPhoneOTP struct {
+	Phone string `json:"phone"`
+	Code  string `json:"code"`
+	// ExpiresInMinutes is shown to the user in the message
+	ExpiresInMinutes int `json:"expires_in_minutes"`
+}
+
+func (distributor *RedisTaskDistributor) DistributeTaskSendPhoneOTP(
+	ctx context.Context,
+	payload *PayloadSendPhoneOTP,
+	opts ...asynq.Option,
+) error {
+	jsonPayload, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("failed to marshal task payload: %w", err)
+	}
+
+	task := asynq.NewTask(TaskSendPhoneOTP, jsonPayload, opts...)
+	info, err := distributor.client.EnqueueContext(ctx, task)
+	if err != nil {
+		return fmt.Errorf("failed to enqueue task: %w", err)
+	}
+
+	// The payload carries the code, so only the type and queue are logged
+	log.Info().Str("type", task.Type()).
+		Str("queue", info.Queue).Int("max_retry", info.MaxRetry).Msg("enqueued task")
+	return nil
+}
+
+func (processor *RedisTaskProcessor) ProcessTaskSendPhoneOTP(ctx context.Context, task *asynq.Task) error {
+	var payload PayloadSendPhoneOTP
+	if err := json.Unmarshal(task.Payload(), &payload); err != nil {
+		return fmt.Errorf("failed to unmarshal payload: %w", asynq.SkipRetry)
+	}
+
+	message := fmt.Sprintf("Your SQR verification code is %s. It expires in %d minutes. Do not share it with anyone.",
+		payload.Code, payload.ExpiresInMinutes)
+
+	err := processor.sms.SendSMS(payload.Phone, message)
+	if err != nil {
+		return fmt.Errorf("failed to send otp sms to [%s]: %w", payload.Phone, err)
+	}
+
+	log.Info().Str("type", task.Type()).Str("phone", payload.Phone).
+		Msg("sent phone otp")
+	return nil
+}
@@ -0,0 +1,51 @@
+package worker
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hibiken/asynq"
+	"github.com/r-scheele/sqr/internal/util"
+	"github.com/rs/zerolog/log"
+)
+
+const TaskSweepAccountDeletions = "task:sweep_account_deletions"
+
+// DefaultAccountDeletionSweepSchedule is used when ACCOUNT_DELETION_SWEEP_SCHEDULE is not set: every hour
+const DefaultAccountDeletionSweepSchedule = "45 * * * *"
+
+// AccountDeletionSweepBatchSize is how many due deletions one run of the sweep carries out
+const AccountDeletionSweepBatchSize = 100
+
+// AccountDeletionSweepSchedule is the cron spec, in util.AvailabilityTimezone, of TaskSweepAccountDeletions
+func AccountDeletionSweepSchedule(config util.Config) string {
+	if config.AccountDeletionSweepSchedule != "" {
+		return config.AccountDeletionSweepSchedule
+	}
+	return DefaultAccountDeletionSweepSchedule
+}
+
+// ProcessTaskSweepAccountDeletions carries out the pending deletions whose grace period has ended.
+// TaskDeleteAccount normally deletes the account on time, the sweep catches the ones whose task was lost
+// or ran out of retries. It runs periodically with an empty payload.
+func (processor *RedisTaskProcessor) ProcessTaskSweepAccountDeletions(ctx context.Context, task *asynq.Task) error {
+	deletions, err := processor.store.ListDueAccountDeletions(ctx, AccountDeletionSweepBatchSize)
+	if err != nil {
+		return fmt.Errorf("failed to list due account deletions: %w", err)
+	}
+
+	failed := 0
+	for _, deletion := range deletions {
+		err = processor.deleteAccount(ctx, task.Type(), deletion.ID)
+		if err != nil {
+			log.Error().Err(err).Int64("deletion_id", deletion.ID).Msg("failed to delete account")
+			failed++
+		}
+	}
+
+	log.Info().Str("type", task.Type()).Int("due", len(deletions)).Int("failed", failed).Msg("swept account deletions")
+	if failed > 0 {
+		return fmt.Errorf("failed to delete %d of %d due accounts", failed, len(deletions))
+	}
+	return nil
+}
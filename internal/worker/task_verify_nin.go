@@ -0,0 +1,171 @@
+package worker
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/hibiken/asynq"
+	"github.com/jackc/pgx/v5/pgtype"
+	db "github.com/r-scheele/sqr/internal/db/sqlc"
+	"github.com/r-scheele/sqr/internal/identity"
+	"github.com/r-scheele/sqr/internal/util"
+	"github.com/rs/zerolog/log"
+)
+
+const TaskVerifyNIN = "task:verify_nin"
+
+type PayloadVerifyNIN struct {
+	UserID         int64 `json:"user_id"`
+	VerificationID int64 `json:"verification_id"`
+}
+
+// NINVerificationData is stored in verification_data of a nin verification. The NIN itself is kept
+// encrypted on the user and never copied here.
+type NINVerificationData struct {
+	Provider    string     `json:"provider"`
+	Reference   string     `json:"reference,omitempty"`
+	Reason      string     `json:"reason,omitempty"`
+	SubmittedAt time.Time  `json:"submitted_at"`
+	CheckedAt   *time.Time `json:"checked_at,omitempty"`
+}
+
+func (distributor *RedisTaskDistributor) DistributeTaskVerifyNIN(
+	ctx context.Context,
+	payload *PayloadVerifyNIN,
+	opts ...asynq.Option,
+) error {
+	jsonPayload, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("failed to marshal task payload: %w", err)
+	}
+
+	task := asynq.NewTask(TaskVerifyNIN, jsonPayload, opts...)
+	info, err := distributor.client.EnqueueContext(ctx, task)
+	if err != nil {
+		return fmt.Errorf("failed to enqueue task: %w", err)
+	}
+
+	log.Info().Str("type", task.Type()).Bytes("payload", task.Payload()).
+		Str("queue", info.Queue).Int("max_retry", info.MaxRetry).Msg("enqueued task")
+	return nil
+}
+
+func (processor *RedisTaskProcessor) ProcessTaskVerifyNIN(ctx context.Context, task *asynq.Task) error {
+	var payload PayloadVerifyNIN
+	if err := json.Unmarshal(task.Payload(), &payload); err != nil {
+		return fmt.Errorf("failed to unmarshal payload: %w", asynq.SkipRetry)
+	}
+
+	verification, err := processor.store.GetUserVerificationByID(ctx, payload.VerificationID)
+	if err != nil {
+		if errors.Is(err, db.ErrRecordNotFound) {
+			return fmt.Errorf("nin verification doesn't exist: %w", asynq.SkipRetry)
+		}
+		return fmt.Errorf("failed to get nin verification: %w", err)
+	}
+	if verification.UserID != payload.UserID || verification.VerificationType != db.VerificationTypeEnumNin {
+		return fmt.Errorf("verification %d is not a nin verification of user %d: %w", payload.VerificationID, payload.UserID, asynq.SkipRetry)
+	}
+	if verification.VerificationStatus.VerificationStatusEnum != db.VerificationStatusEnumPending {
+		// Already handled by an earlier run of this task
+		log.Info().Str("type", task.Type()).Int64("verification_id", verification.ID).
+			Msg("nin verification is no longer pending")
+		return nil
+	}
+
+	var data NINVerificationData
+	if len(verification.VerificationData) > 0 {
+		if err := json.Unmarshal(verification.VerificationData, &data); err != nil {
+			return fmt.Errorf("failed to read nin verification data: %w", asynq.SkipRetry)
+		}
+	}
+
+	user, err := processor.store.GetUserByID(ctx, payload.UserID)
+	if err != nil {
+		return fmt.Errorf("failed to get user: %w", err)
+	}
+
+	nin, err := util.DecryptField(processor.config.IdentityEncryptionKey, user.Nin.String)
+	if err != nil {
+		return fmt.Errorf("failed to decrypt nin of user %d: %w", user.ID, asynq.SkipRetry)
+	}
+
+	result, err := processor.identity.VerifyNIN(ctx, identity.NINRequest{
+		NIN:       nin,
+		FirstName: user.FirstName,
+		LastName:  user.LastName,
+		Phone:     user.Phone,
+	})
+	if err != nil {
+		// Returning the error lets asynq retry with backoff, which covers a provider outage
+		return fmt.Errorf("failed to verify nin with %s: %w", processor.identity.Name(), err)
+	}
+
+	checkedAt := time.Now()
+	data.Provider = processor.identity.Name()
+	data.Reference = result.Reference
+	data.Reason = result.Reason
+	data.CheckedAt = &checkedAt
+
+	dataJSON, err := json.Marshal(data)
+	if err != nil {
+		return fmt.Errorf("failed to marshal nin verification data: %w", err)
+	}
+
+	txResult, err := processor.store.CompleteNINVerificationTx(ctx, db.CompleteNINVerificationTxParams{
+		VerificationID:   verification.ID,
+		Verified:         result.Match,
+		VerificationData: dataJSON,
+	})
+	if err != nil {
+		if errors.Is(err, db.ErrVerificationNotPending) {
+			return nil
+		}
+		return fmt.Errorf("failed to complete nin verification: %w", err)
+	}
+
+	processor.notifyNINVerificationResult(ctx, txResult.User, result)
+
+	log.Info().Str("type", task.Type()).Int64("user_id", user.ID).
+		Bool("verified", result.Match).Msg("processed nin verification")
+	return nil
+}
+
+// notifyNINVerificationResult tells the user the outcome in-app and by email. The verification is already
+// stored at this point, so failures are only logged and the task is not retried.
+func (processor *RedisTaskProcessor) notifyNINVerificationResult(ctx context.Context, user db.User, result *identity.NINResult) {
+	title := "Your NIN has been verified"
+	message := "Your National Identification Number was verified successfully. Your identity is now verified."
+	if !result.Match {
+		title = "We could not verify your NIN"
+		message = "We could not verify your National Identification Number against your account details. " +
+			"Please check the number and your name, then submit it again."
+	}
+
+	_, err := processor.store.CreateNotification(ctx, db.CreateNotificationParams{
+		UserID:           user.ID,
+		NotificationType: db.NotificationTypeEnumSystemAlert,
+		Title:            title,
+		Content:          message,
+		RelatedEntityID:  pgtype.Int8{Valid: false},
+	})
+	if err != nil {
+		log.Error().Err(err).Int64("user_id", user.ID).Msg("failed to create nin verification notification")
+	}
+
+	content := fmt.Sprintf(`
+		<h1>%s</h1>
+		<p>Hello %s,</p>
+		<p>%s</p>
+		<br>
+		<p>Best regards,<br>The SQR Team</p>
+	`, title, user.FirstName, message)
+
+	err = processor.mailer.SendEmail(title, content, []string{user.Email}, nil, nil, nil)
+	if err != nil {
+		log.Error().Err(err).Int64("user_id", user.ID).Msg("failed to send nin verification email")
+	}
+}
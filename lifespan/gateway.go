@@ -7,6 +7,7 @@ import (
 
 	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
 	"github.com/r-scheele/sqr/gapi"
+	"github.com/r-scheele/sqr/internal/cache"
 	db "github.com/r-scheele/sqr/internal/db/sqlc"
 	"github.com/r-scheele/sqr/internal/pb"
 	"github.com/r-scheele/sqr/internal/ratelimit"
@@ -16,6 +17,8 @@ import (
 	"github.com/rs/cors"
 	"github.com/rs/zerolog/log"
 	"golang.org/x/sync/errgroup"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
 	"google.golang.org/protobuf/encoding/protojson"
 )
 
@@ -24,10 +27,11 @@ func RunGatewayServer(
 	waitGroup *errgroup.Group,
 	config util.Config,
 	store db.Store,
+	cacheManager cache.CacheManager,
 	taskDistributor worker.TaskDistributor,
 	rateLimiter *ratelimit.GRPCRateLimiter,
 ) {
-	server, err := gapi.NewServer(config, store, taskDistributor, rateLimiter)
+	server, err := gapi.NewServer(config, store, cacheManager, taskDistributor, rateLimiter)
 	if err != nil {
 		log.Fatal().Err(err).Msg("cannot create server")
 	}
@@ -43,13 +47,17 @@ func RunGatewayServer(
 
 	grpcMux := runtime.NewServeMux(jsonOption)
 
-	err = pb.RegisterSqrHandlerServer(ctx, grpcMux, server)
+	// The gateway calls the gRPC server over a loopback connection instead of in-process,
+	// so every HTTP call goes through the same interceptors as a gRPC call
+	dialOptions := []grpc.DialOption{grpc.WithTransportCredentials(insecure.NewCredentials())}
+	err = pb.RegisterSqrHandlerFromEndpoint(ctx, grpcMux, config.GRPCServerAddress, dialOptions)
 	if err != nil {
-		log.Fatal().Err(err).Msg("cannot register handler server")
+		log.Fatal().Err(err).Msg("cannot register handler endpoint")
 	}
 
 	mux := http.NewServeMux()
 	mux.Handle("/", grpcMux)
+	mux.HandleFunc(gapi.DocumentDownloadPath, server.ServeDocumentDownload)
 
 	statikFS, err := fs.New()
 	if err != nil {
@@ -4,8 +4,11 @@ import (
 	"context"
 
 	"github.com/hibiken/asynq"
+	"github.com/r-scheele/sqr/internal/blob"
 	db "github.com/r-scheele/sqr/internal/db/sqlc"
+	"github.com/r-scheele/sqr/internal/identity"
 	"github.com/r-scheele/sqr/internal/mail"
+	"github.com/r-scheele/sqr/internal/sms"
 	"github.com/r-scheele/sqr/internal/util"
 	"github.com/r-scheele/sqr/internal/worker"
 	"github.com/rs/zerolog/log"
@@ -20,10 +23,19 @@ func RunTaskProcessor(
 	store db.Store,
 ) {
 	mailer := mail.NewGmailSender(config.EmailSenderName, config.EmailSenderAddress, config.EmailSenderPassword)
-	taskProcessor := worker.NewRedisTaskProcessor(redisOpt, store, mailer)
+	smsSender := sms.NewSender(config.SMSSenderType, config.SMSOutboxPath)
+	identityProvider, err := identity.NewProvider(config.IdentityProvider)
+	if err != nil {
+		log.Fatal().Err(err).Msg("cannot create identity provider")
+	}
+	blobStore, err := blob.NewBlobStore(config.BlobStoreType, config.BlobStorePath)
+	if err != nil {
+		log.Fatal().Err(err).Msg("cannot create blob store")
+	}
+	taskProcessor := worker.NewRedisTaskProcessor(redisOpt, store, mailer, smsSender, identityProvider, blobStore, config)
 
 	log.Info().Msg("start task processor")
-	err := taskProcessor.Start()
+	err = taskProcessor.Start()
 	if err != nil {
 		log.Fatal().Err(err).Msg("failed to start task processor")
 	}
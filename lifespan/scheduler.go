@@ -0,0 +1,78 @@
+package lifespan
+
+import (
+	"context"
+	"time"
+
+	"github.com/hibiken/asynq"
+	"github.com/r-scheele/sqr/internal/util"
+	"github.com/r-scheele/sqr/internal/worker"
+	"github.com/rs/zerolog/log"
+	"golang.org/x/sync/errgroup"
+)
+
+// RunTaskScheduler enqueues the periodic tasks. Every replica runs one, so the tasks are unique
+// for a while to keep them from being enqueued once per replica.
+func RunTaskScheduler(
+	ctx context.Context,
+	waitGroup *errgroup.Group,
+	config util.Config,
+	redisOpt asynq.RedisClientOpt,
+) {
+	scheduler := asynq.NewScheduler(redisOpt, &asynq.SchedulerOpts{
+		Logger:   worker.NewLogger(),
+		Location: util.AvailabilityLocation(),
+		EnqueueErrorHandler: func(task *asynq.Task, opts []asynq.Option, err error) {
+			log.Error().Err(err).Str("type", task.Type()).Msg("failed to enqueue periodic task")
+		},
+	})
+
+	_, err := scheduler.Register(
+		worker.ProfileNudgeSchedule(config),
+		asynq.NewTask(worker.TaskNudgeIncompleteProfiles, nil),
+		asynq.Queue(worker.QueueDefault),
+		asynq.MaxRetry(3),
+		asynq.Unique(time.Hour),
+	)
+	if err != nil {
+		log.Fatal().Err(err).Msg("cannot schedule profile nudges")
+	}
+
+	_, err = scheduler.Register(
+		worker.SearchCacheCleanupSchedule(config),
+		asynq.NewTask(worker.TaskCleanupPropertySearchCache, nil),
+		asynq.Queue(worker.QueueDefault),
+		asynq.MaxRetry(3),
+		asynq.Unique(time.Hour),
+	)
+	if err != nil {
+		log.Fatal().Err(err).Msg("cannot schedule search cache cleanup")
+	}
+
+	_, err = scheduler.Register(
+		worker.AccountDeletionSweepSchedule(config),
+		asynq.NewTask(worker.TaskSweepAccountDeletions, nil),
+		asynq.Queue(worker.QueueDefault),
+		asynq.MaxRetry(3),
+		asynq.Unique(time.Hour),
+	)
+	if err != nil {
+		log.Fatal().Err(err).Msg("cannot schedule account deletion sweep")
+	}
+
+	log.Info().Msg("start task scheduler")
+	err = scheduler.Start()
+	if err != nil {
+		log.Fatal().Err(err).Msg("failed to start task scheduler")
+	}
+
+	waitGroup.Go(func() error {
+		<-ctx.Done()
+		log.Info().Msg("graceful shutdown task scheduler")
+
+		scheduler.Shutdown()
+		log.Info().Msg("task scheduler is stopped")
+
+		return nil
+	})
+}
@@ -6,6 +6,7 @@ import (
 	"net"
 
 	"github.com/r-scheele/sqr/gapi"
+	"github.com/r-scheele/sqr/internal/cache"
 	db "github.com/r-scheele/sqr/internal/db/sqlc"
 	"github.com/r-scheele/sqr/internal/pb"
 	"github.com/r-scheele/sqr/internal/ratelimit"
@@ -22,16 +23,19 @@ func RunGrpcServer(
 	waitGroup *errgroup.Group,
 	config util.Config,
 	store db.Store,
+	cacheManager cache.CacheManager,
 	taskDistributor worker.TaskDistributor,
 	rateLimiter *ratelimit.GRPCRateLimiter,
 ) {
-	server, err := gapi.NewServer(config, store, taskDistributor, rateLimiter)
+	server, err := gapi.NewServer(config, store, cacheManager, taskDistributor, rateLimiter)
 	if err != nil {
 		log.Fatal().Err(err).Msg("cannot create server")
 	}
 
-	gprcLogger := grpc.UnaryInterceptor(gapi.GrpcLogger)
-	grpcServer := grpc.NewServer(gprcLogger)
+	// The rate limiter runs after authorization so the rules scoped to a user count the authenticated caller.
+	// The gateway calls this server too, so its requests are limited by the same rules.
+	interceptors := grpc.ChainUnaryInterceptor(gapi.GrpcLogger, server.AuthorizationInterceptor, rateLimiter.UnaryInterceptor())
+	grpcServer := grpc.NewServer(interceptors, grpc.MaxRecvMsgSize(gapi.MaxRequestSize(config)))
 	pb.RegisterSqrServer(grpcServer, server)
 	reflection.Register(grpcServer)
 
@@ -73,9 +73,10 @@ func main() {
 	rateLimiter := ratelimit.NewGRPCRateLimiter(limiter, config)
 
 	waitGroup, ctx := errgroup.WithContext(ctx)
-	lifespan.RunTaskProcessor(ctx, waitGroup, config, redisOpt, store)
-	lifespan.RunGatewayServer(ctx, waitGroup, config, cachedStore, taskDistributor, rateLimiter)
-	lifespan.RunGrpcServer(ctx, waitGroup, config, cachedStore, taskDistributor, rateLimiter)
+	lifespan.RunTaskProcessor(ctx, waitGroup, config, redisOpt, cachedStore)
+	lifespan.RunTaskScheduler(ctx, waitGroup, config, redisOpt)
+	lifespan.RunGatewayServer(ctx, waitGroup, config, cachedStore, cacheManager, taskDistributor, rateLimiter)
+	lifespan.RunGrpcServer(ctx, waitGroup, config, cachedStore, cacheManager, taskDistributor, rateLimiter)
 
 	err = waitGroup.Wait()
 	if err != nil {